@@ -29,13 +29,25 @@ func GetSignersFromContext(ctx *cli.Context, offset int) ([]transaction.Signer,
 	args := ctx.Args()
 	var signers []transaction.Signer
 	if args.Present() && len(args) > offset {
-		for i, c := range args[offset:] {
-			cosigner, err := parseCosigner(c)
-			if err != nil {
-				return nil, cli.NewExitError(fmt.Errorf("failed to parse signer #%d: %w", i, err), 1)
-			}
-			signers = append(signers, cosigner)
+		var err error
+		signers, err = ParseSigners(args[offset:])
+		if err != nil {
+			return nil, cli.NewExitError(err, 1)
+		}
+	}
+	return signers, nil
+}
+
+// ParseSigners parses signers specified as `address[:scope]` strings,
+// CalledByEntry scope is used by default.
+func ParseSigners(args []string) ([]transaction.Signer, error) {
+	var signers []transaction.Signer
+	for i, c := range args {
+		cosigner, err := parseCosigner(c)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse signer #%d: %w", i, err)
 		}
+		signers = append(signers, cosigner)
 	}
 	return signers, nil
 }
@@ -226,6 +226,122 @@ func TestContractDeployWithData(t *testing.T) {
 	require.Equal(t, []byte("take_me_to_church"), res.Stack[0].Value())
 }
 
+func TestContractDeployWithProfile(t *testing.T) {
+	e := newExecutor(t, true)
+
+	// For proper nef generation.
+	config.Version = "0.90.0-test"
+
+	tmpDir := path.Join(os.TempDir(), "neogo.test.deployprofile")
+	require.NoError(t, os.Mkdir(tmpDir, os.ModePerm))
+	t.Cleanup(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	nefName := path.Join(tmpDir, "deploy.nef")
+	manifestName := path.Join(tmpDir, "deploy.manifest.json")
+	e.Run(t, "neo-go", "contract", "compile",
+		"--in", "testdata/deploy/main.go", // compile single file
+		"--config", "testdata/deploy/neo-go.yml",
+		"--out", nefName, "--manifest", manifestName)
+
+	// Wallet path is relative to the deployment configuration.
+	rawWallet, err := ioutil.ReadFile(validatorWallet)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(path.Join(tmpDir, "wallet.json"), rawWallet, 0644))
+	confName := path.Join(tmpDir, "neo-go.deploy.yml")
+	require.NoError(t, ioutil.WriteFile(confName, []byte(`networks:
+  unittest:
+    rpc: http://`+e.RPC.Addr+`
+    wallet: wallet.json
+    address: `+validatorAddr+`
+    data: ["[", "key1", "12", "]"]
+    invoke:
+      - method: testFind
+        args: ["int:0"]
+`), 0644))
+
+	t.Run("unknown network", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "contract", "deploy",
+			"--network", "mainnet", "--deploy-config", confName,
+			"--in", nefName, "--manifest", manifestName)
+	})
+
+	e.In.WriteString("one\r")
+	e.Run(t, "neo-go", "contract", "deploy",
+		"--network", "unittest", "--deploy-config", confName,
+		"--in", nefName, "--manifest", manifestName)
+
+	e.checkTxPersisted(t, "Sent invocation transaction ")
+	line, err := e.Out.ReadString('\n')
+	require.NoError(t, err)
+	line = strings.TrimSpace(strings.TrimPrefix(line, "Contract: "))
+	h, err := util.Uint160DecodeStringLE(line)
+	require.NoError(t, err)
+	e.checkTxPersisted(t, "Sent invocation transaction ")
+	e.checkEOF(t)
+
+	rawRecord, err := ioutil.ReadFile(path.Join(tmpDir, "deployed.json"))
+	require.NoError(t, err)
+	var records map[string]struct {
+		Hash   util.Uint160 `json:"hash"`
+		Sender util.Uint160 `json:"sender"`
+	}
+	require.NoError(t, json.Unmarshal(rawRecord, &records))
+	require.Equal(t, h, records["unittest"].Hash)
+	require.Equal(t, validatorAddr, address.Uint160ToString(records["unittest"].Sender))
+
+	checkValue := func(t *testing.T, key string, expected []byte) {
+		e.Run(t, "neo-go", "contract", "testinvokefunction",
+			"--rpc-endpoint", "http://"+e.RPC.Addr,
+			h.StringLE(), "getValueWithKey", key)
+
+		res := new(result.Invoke)
+		require.NoError(t, json.Unmarshal(e.Out.Bytes(), res))
+		require.Equal(t, vm.HaltState.String(), res.State, res.FaultException)
+		require.Len(t, res.Stack, 1)
+		require.Equal(t, expected, res.Stack[0].Value())
+	}
+	// Data parameter is taken from the profile.
+	checkValue(t, "key1", []byte{12})
+	// Post-deploy invocation is performed.
+	checkValue(t, "findkey1", []byte("value1"))
+
+	t.Run("Update", func(t *testing.T) {
+		nefName := path.Join(tmpDir, "updated.nef")
+		manifestName := path.Join(tmpDir, "updated.manifest.json")
+		e.Run(t, "neo-go", "contract", "compile",
+			"--config", "testdata/deploy/neo-go.yml",
+			"--in", "testdata/deploy/", // compile all files in dir
+			"--out", nefName, "--manifest", manifestName)
+
+		t.Run("no contract", func(t *testing.T) {
+			e.RunWithError(t, "neo-go", "contract", "update",
+				"--rpc-endpoint", "http://"+e.RPC.Addr,
+				"--wallet", validatorWallet, "--address", validatorAddr,
+				"--in", nefName, "--manifest", manifestName)
+		})
+
+		e.In.WriteString("one\r")
+		e.Run(t, "neo-go", "contract", "update",
+			"--network", "unittest", "--deploy-config", confName,
+			"--in", nefName, "--manifest", manifestName)
+		e.checkTxPersisted(t, "Sent invocation transaction ")
+		e.checkNextLine(t, "^Contract: "+h.StringLE()+"$")
+		e.checkEOF(t)
+
+		e.Run(t, "neo-go", "contract", "testinvokefunction",
+			"--rpc-endpoint", "http://"+e.RPC.Addr,
+			h.StringLE(), "getValue")
+
+		res := new(result.Invoke)
+		require.NoError(t, json.Unmarshal(e.Out.Bytes(), res))
+		require.Equal(t, vm.HaltState.String(), res.State)
+		require.Len(t, res.Stack, 1)
+		require.Equal(t, []byte("on update|sub update"), res.Stack[0].Value())
+	})
+}
+
 func deployVerifyContract(t *testing.T, e *executor) util.Uint160 {
 	tmpDir := path.Join(os.TempDir(), "neogo.test.deployverifycontract")
 	require.NoError(t, os.Mkdir(tmpDir, os.ModePerm))
@@ -253,6 +369,115 @@ func deployVerifyContract(t *testing.T, e *executor) util.Uint160 {
 	return hVerify
 }
 
+func TestContractVerifySource(t *testing.T) {
+	e := newExecutor(t, true)
+
+	// For proper nef generation.
+	config.Version = "0.90.0-test"
+
+	h := deployVerifyContract(t, e)
+
+	t.Run("missing hash", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "contract", "verify-source",
+			"--rpc-endpoint", "http://"+e.RPC.Addr,
+			"--in", "testdata/verify.go")
+	})
+
+	e.Run(t, "neo-go", "contract", "verify-source",
+		"--rpc-endpoint", "http://"+e.RPC.Addr,
+		"--hash", h.StringLE(), "--in", "testdata/verify.go")
+	res := new(result.SourceAttestation)
+	require.NoError(t, json.Unmarshal(e.Out.Bytes(), res))
+	require.True(t, res.Verified, res.Reason)
+	require.Equal(t, h, res.Contract)
+	require.Equal(t, "neo-go-0.90.0-test", res.Compiler)
+	require.Equal(t, res.Compiler, res.ContractCompiler)
+	require.Equal(t, res.ContractChecksum, res.Checksum)
+	require.Equal(t, 1, len(res.Sources))
+	require.Equal(t, "verify.go", res.Sources[0].Name)
+
+	t.Run("mismatch", func(t *testing.T) {
+		tmpDir := path.Join(os.TempDir(), "neogo.test.verifysource")
+		require.NoError(t, os.Mkdir(tmpDir, os.ModePerm))
+		t.Cleanup(func() {
+			os.RemoveAll(tmpDir)
+		})
+		out := path.Join(tmpDir, "attestation.json")
+		e.RunWithError(t, "neo-go", "contract", "verify-source",
+			"--rpc-endpoint", "http://"+e.RPC.Addr,
+			"--hash", h.StringLE(), "--in", "testdata/deploy/main.go",
+			"--out", out)
+		data, err := ioutil.ReadFile(out)
+		require.NoError(t, err)
+		res := new(result.SourceAttestation)
+		require.NoError(t, json.Unmarshal(data, res))
+		require.False(t, res.Verified)
+		require.Equal(t, "script mismatch", res.Reason)
+	})
+}
+
+func TestContractAnalyze(t *testing.T) {
+	e := newExecutor(t, false)
+
+	cmd := []string{"neo-go", "contract", "analyze"}
+	t.Run("missing source", func(t *testing.T) {
+		e.RunWithError(t, cmd...)
+	})
+	t.Run("invalid severity", func(t *testing.T) {
+		e.RunWithError(t, append(cmd, "--in", "testdata/verify.go", "--severity", "critical")...)
+	})
+
+	e.Run(t, append(cmd, "--in", "testdata/verify.go")...)
+	e.checkNextLine(t, "^OK$")
+	e.checkEOF(t)
+
+	e.RunWithError(t, append(cmd, "--in", "testdata/deploy/main.go")...)
+	e.checkNextLine(t, `^MEDIUM .*main\.go:\d+:\d+: TestFind: unbounded storage iteration.* \[unbounded-iteration\]$`)
+	e.checkEOF(t)
+
+	e.Run(t, append(cmd, "--in", "testdata/deploy/main.go", "--severity", "high")...)
+	e.checkNextLine(t, "^OK$")
+	e.checkEOF(t)
+}
+
+func TestContractVerifyBuild(t *testing.T) {
+	e := newExecutor(t, false)
+
+	// For proper nef generation.
+	config.Version = "0.90.0-test"
+
+	tmpDir := path.Join(os.TempDir(), "neogo.test.verifybuild")
+	require.NoError(t, os.Mkdir(tmpDir, os.ModePerm))
+	t.Cleanup(func() {
+		os.RemoveAll(tmpDir)
+	})
+	nefName := path.Join(tmpDir, "deploy.nef")
+	debugName := path.Join(tmpDir, "deploy.debug.json")
+	e.Run(t, "neo-go", "contract", "compile",
+		"--in", "testdata/deploy/main.go",
+		"--out", nefName, "--debug", debugName)
+
+	cmd := []string{"neo-go", "contract", "verify-build"}
+	t.Run("missing source", func(t *testing.T) {
+		e.RunWithError(t, append(cmd, "--debug", debugName)...)
+	})
+	t.Run("missing debug info", func(t *testing.T) {
+		e.RunWithError(t, append(cmd, "--in", "testdata/deploy/main.go")...)
+	})
+
+	cmd = append(cmd, "--debug", debugName)
+	e.Run(t, append(cmd, "--in", "testdata/deploy/main.go", "--nef", nefName)...)
+	e.checkNextLine(t, "^OK$")
+	e.checkEOF(t)
+
+	t.Run("mismatch", func(t *testing.T) {
+		e.RunWithError(t, append(cmd, "--in", "testdata/deploy/updated.go")...)
+		e.checkNextLine(t, "^source file github.com/nspcc-dev/neo-go/cli/testdata/deploy/sub/put.go is missing$")
+		e.checkNextLine(t, "^source file main.go is missing$")
+		e.checkNextLine(t, "^unexpected source file updated.go$")
+	})
+}
+
 func TestComlileAndInvokeFunction(t *testing.T) {
 	e := newExecutor(t, true)
 
@@ -541,6 +766,230 @@ func TestContractInspect(t *testing.T) {
 	})
 }
 
+func TestContractDecompile(t *testing.T) {
+	e := newExecutor(t, false)
+
+	// For proper nef generation.
+	config.Version = "0.90.0-test"
+	const srcPath = "testdata/deploy/main.go"
+
+	tmpDir := path.Join(os.TempDir(), "neogo.test.contract.decompile")
+	require.NoError(t, os.Mkdir(tmpDir, os.ModePerm))
+	t.Cleanup(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	nefName := path.Join(tmpDir, "deploy.nef")
+	manifestName := path.Join(tmpDir, "deploy.manifest.json")
+	debugName := path.Join(tmpDir, "deploy.debug.json")
+	e.Run(t, "neo-go", "contract", "compile",
+		"--in", srcPath,
+		"--config", "testdata/deploy/neo-go.yml",
+		"--out", nefName, "--manifest", manifestName, "--debug", debugName)
+
+	cmd := []string{"neo-go", "contract", "decompile"}
+	t.Run("missing input", func(t *testing.T) {
+		e.RunWithError(t, cmd...)
+		e.RunWithError(t, append(cmd, "--in", path.Join(tmpDir, "not.exists"))...)
+		e.RunWithError(t, append(cmd, "--in", srcPath)...)
+		e.RunWithError(t, append(cmd, "--in", nefName, "--debug", path.Join(tmpDir, "not.exists"))...)
+		e.RunWithError(t, append(cmd, "--in", nefName, "--manifest", srcPath)...)
+	})
+	t.Run("script only", func(t *testing.T) {
+		e.Run(t, append(cmd, "--in", nefName)...)
+		out := e.Out.String()
+		require.Contains(t, out, "SYSCALL")
+		require.Contains(t, out, "L1:")
+		require.NotContains(t, out, "; method")
+	})
+	t.Run("with manifest", func(t *testing.T) {
+		e.Run(t, append(cmd, "--in", nefName, "--manifest", manifestName)...)
+		out := e.Out.String()
+		require.Contains(t, out, "; method getValue\n")
+		require.Contains(t, out, "; method _deploy\n")
+	})
+	t.Run("with debug info", func(t *testing.T) {
+		e.Run(t, append(cmd, "--in", nefName, "--debug", debugName)...)
+		out := e.Out.String()
+		require.Contains(t, out, "; method main.GetValue [")
+		require.Contains(t, out, srcPath+":")
+		require.Contains(t, out, "storage.GetContext()")
+	})
+}
+
+func TestContractDiff(t *testing.T) {
+	e := newExecutor(t, false)
+
+	// For proper nef generation.
+	config.Version = "0.90.0-test"
+
+	tmpDir := path.Join(os.TempDir(), "neogo.test.contract.diff")
+	require.NoError(t, os.Mkdir(tmpDir, os.ModePerm))
+	t.Cleanup(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	nefName := path.Join(tmpDir, "deploy.nef")
+	manifestName := path.Join(tmpDir, "deploy.manifest.json")
+	e.Run(t, "neo-go", "contract", "compile",
+		"--in", "testdata/deploy/main.go",
+		"--config", "testdata/deploy/neo-go.yml",
+		"--out", nefName, "--manifest", manifestName)
+	const verifyNef = "testdata/verify.nef"
+
+	cmd := []string{"neo-go", "contract", "diff"}
+	t.Run("invalid arguments", func(t *testing.T) {
+		e.RunWithError(t, cmd...)
+		e.RunWithError(t, append(cmd, verifyNef)...)
+		e.RunWithError(t, append(cmd, verifyNef, path.Join(tmpDir, "not.exists"))...)
+		e.RunWithError(t, append(cmd, "--new-manifest", path.Join(tmpDir, "not.exists"), verifyNef, nefName)...)
+		e.RunWithError(t, append(cmd, "--old-manifest", nefName, verifyNef, nefName)...)
+	})
+	t.Run("no changes", func(t *testing.T) {
+		e.Run(t, append(cmd, verifyNef, verifyNef)...)
+		e.checkNextLine(t, "No changes")
+		e.checkEOF(t)
+	})
+	t.Run("changes", func(t *testing.T) {
+		e.Run(t, append(cmd, "--new-manifest", manifestName, verifyNef, nefName)...)
+		out := e.Out.String()
+		require.Contains(t, out, "* name: verify -> Test deploy\n")
+		require.Contains(t, out, "- verify() Boolean\n")
+		require.Contains(t, out, "+ getValue() String\n")
+		require.Contains(t, out, "Events:\n  - Hello world!(args Array)\n")
+		require.Contains(t, out, "+ \"findkey1\"\n")
+		require.NotContains(t, out, "Permissions:")
+	})
+}
+
+func TestContractExportABI(t *testing.T) {
+	e := newExecutor(t, false)
+
+	tmpDir := path.Join(os.TempDir(), "neogo.test.contract.exportabi")
+	require.NoError(t, os.Mkdir(tmpDir, os.ModePerm))
+	t.Cleanup(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	const manifestName = "testdata/verify.manifest.json"
+	abiName := path.Join(tmpDir, "verify.abi.json")
+	tsName := path.Join(tmpDir, "verify.d.ts")
+	cmd := []string{"neo-go", "contract", "export-abi"}
+	t.Run("invalid arguments", func(t *testing.T) {
+		e.RunWithError(t, append(cmd, "--out", abiName)...)
+		e.RunWithError(t, append(cmd, "--manifest", manifestName)...)
+		e.RunWithError(t, append(cmd, "--manifest", path.Join(tmpDir, "not.exists"), "--out", abiName)...)
+		e.RunWithError(t, append(cmd, "--manifest", "testdata/verify.go", "--out", abiName)...)
+		e.RunWithError(t, append(cmd, "--manifest", manifestName, "--debug", "testdata/verify.go", "--out", abiName)...)
+	})
+	t.Run("valid", func(t *testing.T) {
+		e.Run(t, append(cmd, "--manifest", manifestName, "--out", abiName, "--ts", tsName)...)
+
+		data, err := ioutil.ReadFile(abiName)
+		require.NoError(t, err)
+		m := new(manifest.Manifest)
+		require.NoError(t, json.Unmarshal(data, m))
+		require.Equal(t, "verify", m.Name)
+		require.Equal(t, 2, len(m.ABI.Methods))
+
+		data, err = ioutil.ReadFile(tsName)
+		require.NoError(t, err)
+		ts := string(data)
+		require.Contains(t, ts, "export interface VerifyHelloWorldEvent {\n  args: any[];\n}")
+		require.Contains(t, ts, "export interface VerifyContract {")
+		require.Contains(t, ts, "  verify(): Promise<boolean>;")
+		require.Contains(t, ts, "  onNEP17Payment(from: ByteArray, amount: bigint, data: any): Promise<void>;")
+	})
+}
+
+func TestContractManifestCheck(t *testing.T) {
+	e := newExecutor(t, false)
+
+	tmpDir := path.Join(os.TempDir(), "neogo.test.contract.manifestcheck")
+	require.NoError(t, os.Mkdir(tmpDir, os.ModePerm))
+	t.Cleanup(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	const configName = "testdata/deploy/neo-go.yml"
+	manifestName := path.Join(tmpDir, "deploy.manifest.json")
+	e.Run(t, "neo-go", "contract", "compile",
+		"--in", "testdata/deploy/main.go",
+		"--config", configName,
+		"--out", path.Join(tmpDir, "deploy.nef"), "--manifest", manifestName)
+
+	writeFile := func(t *testing.T, name string, data string) string {
+		p := path.Join(tmpDir, name)
+		require.NoError(t, ioutil.WriteFile(p, []byte(data), os.ModePerm))
+		return p
+	}
+	cmd := []string{"neo-go", "contract", "manifest", "check"}
+	t.Run("invalid arguments", func(t *testing.T) {
+		e.RunWithError(t, cmd...)
+		e.RunWithError(t, append(cmd, "--config", path.Join(tmpDir, "not.exists"))...)
+		e.RunWithError(t, append(cmd, "--manifest", path.Join(tmpDir, "not.exists"))...)
+	})
+	t.Run("valid", func(t *testing.T) {
+		e.Run(t, append(cmd, "--config", configName, "--manifest", manifestName)...)
+		e.checkNextLine(t, "OK")
+		e.checkEOF(t)
+	})
+	t.Run("bad config", func(t *testing.T) {
+		conf := writeFile(t, "unknown.yml", "name: Test deploy\nsafemethod: [getValue]\n")
+		e.RunWithError(t, append(cmd, "--config", conf)...)
+		require.Contains(t, e.Out.String(), "field safemethod not found")
+
+		conf = writeFile(t, "events.yml", `name: Test deploy
+events:
+  - name: Transfer
+    parameters:
+      - name: from
+        type: Hash160
+      - name: from
+        type: Integer
+  - name: Transfer
+permissions:
+  - contract: bad
+    methods: "*"
+`)
+		e.RunWithError(t, append(cmd, "--config", conf)...)
+		out := e.Out.String()
+		require.Contains(t, out, `event "Transfer" has duplicate parameter "from"`)
+		require.Contains(t, out, `duplicate event "Transfer"`)
+		require.Contains(t, out, "invalid permission #0")
+
+		conf = writeFile(t, "type.yml", "name: Test deploy\nevents:\n  - name: Ev\n    parameters:\n      - name: a\n        type: NoSuchType\n")
+		e.RunWithError(t, append(cmd, "--config", conf)...)
+	})
+	t.Run("bad manifest", func(t *testing.T) {
+		data, err := ioutil.ReadFile(manifestName)
+		require.NoError(t, err)
+		var m map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &m))
+		m["permisions"] = []interface{}{}
+		m["abi"].(map[string]interface{})["methods"].([]interface{})[0].(map[string]interface{})["returns"] = "Void"
+		data, err = json.Marshal(m)
+		require.NoError(t, err)
+		bad := writeFile(t, "unknown.manifest.json", string(data))
+		e.RunWithError(t, append(cmd, "--manifest", bad)...)
+		out := e.Out.String()
+		require.Contains(t, out, `unknown field "permisions"`)
+		require.Contains(t, out, `unknown field "abi.methods.0.returns"`)
+	})
+	t.Run("mismatch", func(t *testing.T) {
+		conf := writeFile(t, "other.yml", `name: Other
+safemethods: [noSuchMethod]
+events:
+  - name: Ev
+`)
+		e.RunWithError(t, append(cmd, "--config", conf, "--manifest", manifestName)...)
+		out := e.Out.String()
+		require.Contains(t, out, "contract name mismatch")
+		require.Contains(t, out, `safe method "noSuchMethod" is missing from manifest`)
+		require.Contains(t, out, `event "Ev" is missing from manifest`)
+	})
+}
+
 func TestCompileExamples(t *testing.T) {
 	const examplePath = "../examples"
 	infos, err := ioutil.ReadDir(examplePath)
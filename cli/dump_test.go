@@ -1,9 +1,13 @@
 package main
 
 import (
+	"archive/zip"
+	"encoding/binary"
+	"encoding/json"
 	"io/ioutil"
 	"os"
 	"path"
+	"strconv"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/pkg/config"
@@ -62,4 +66,144 @@ func TestDBRestore(t *testing.T) {
 	d2, err := ioutil.ReadFile(dumpPath)
 	require.NoError(t, err)
 	require.Equal(t, d1, d2, "dumps differ")
+
+	// Compact and check that nothing has changed.
+	e.Run(t, "neo-go", "db", "compact", "--unittest", "--config-path", tmpDir)
+	e.checkNextLine(t, "^Reclaimed -?[0-9]+ bytes$")
+	e.Run(t, "neo-go", "db", "dump", "--unittest",
+		"--config-path", tmpDir, "--out", dumpPath)
+	d2, err = ioutil.ReadFile(dumpPath)
+	require.NoError(t, err)
+	require.Equal(t, d1, d2, "dumps differ after compaction")
+
+	// Chunked dump.
+	chunksDir := path.Join(tmpDir, "chunks")
+	chunkArgs := []string{"neo-go", "db", "dump", "--unittest",
+		"--config-path", tmpDir, "--out", chunksDir, "--chunk", "20"}
+	e.RunWithError(t, append(chunkArgs, "--start", "5")...)
+	e.Run(t, append(chunkArgs, "--count", "30")...)
+
+	type manifest struct {
+		ChunkSize uint32 `json:"chunksize"`
+		Chunks    []struct {
+			Start uint32 `json:"start"`
+			Count uint32 `json:"count"`
+			File  string `json:"file"`
+		} `json:"chunks"`
+	}
+	readManifest := func() *manifest {
+		data, err := ioutil.ReadFile(path.Join(chunksDir, "manifest.json"))
+		require.NoError(t, err)
+		m := new(manifest)
+		require.NoError(t, json.Unmarshal(data, m))
+		require.Equal(t, uint32(20), m.ChunkSize)
+		return m
+	}
+	m := readManifest()
+	require.Equal(t, 2, len(m.Chunks))
+	require.Equal(t, uint32(20), m.Chunks[1].Start)
+	require.Equal(t, uint32(10), m.Chunks[1].Count)
+
+	// Incomplete chunk is dumped again.
+	e.Run(t, append(chunkArgs, "--resume")...)
+	m = readManifest()
+	var (
+		body  []byte
+		total uint32
+	)
+	for i, c := range m.Chunks {
+		require.Equal(t, uint32(i*20), c.Start)
+		data, err := ioutil.ReadFile(path.Join(chunksDir, c.File))
+		require.NoError(t, err)
+		require.Equal(t, c.Start, binary.LittleEndian.Uint32(data))
+		require.Equal(t, c.Count, binary.LittleEndian.Uint32(data[4:]))
+		body = append(body, data[8:]...)
+		total += c.Count
+	}
+	require.Equal(t, binary.LittleEndian.Uint32(d1), total)
+	require.Equal(t, d1[4:], body)
+	require.Equal(t, "BlockStorage_100000", path.Dir(m.Chunks[0].File))
+
+	// Nothing to do.
+	e.Run(t, append(chunkArgs, "--resume")...)
+	require.Equal(t, m, readManifest())
+
+	// Start is not allowed with resume.
+	e.RunWithError(t, append(chunkArgs, "--resume", "--start", "0")...)
+	// Count is relative to the resumed position.
+	e.RunWithError(t, append(chunkArgs, "--resume", "--count", strconv.Itoa(int(total)))...)
+	require.Equal(t, m, readManifest())
+}
+
+func TestDBRestoreCSharpPackage(t *testing.T) {
+	tmpDir := path.Join(os.TempDir(), "neogo.restorecstest")
+	require.NoError(t, os.Mkdir(tmpDir, os.ModePerm))
+	t.Cleanup(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	chainPath := path.Join(tmpDir, "neogotestchain")
+	cfg, err := config.LoadFile("../config/protocol.unit_testnet.yml")
+	require.NoError(t, err, "could not load config")
+	cfg.ApplicationConfiguration.DBConfiguration.Type = "leveldb"
+	cfg.ApplicationConfiguration.DBConfiguration.LevelDBOptions.DataDirectoryPath = chainPath
+
+	out, err := yaml.Marshal(cfg)
+	require.NoError(t, err)
+
+	cfgPath := path.Join(tmpDir, "protocol.unit_testnet.yml")
+	require.NoError(t, ioutil.WriteFile(cfgPath, out, os.ModePerm))
+
+	const inDump = "./testdata/chain50x2.acc"
+	d, err := ioutil.ReadFile(inDump)
+	require.NoError(t, err)
+
+	// Make chain.10.acc out of inDump starting from block 10.
+	const start = 10
+	blocks := d[4:]
+	for i := 0; i < start; i++ {
+		blocks = blocks[4+binary.LittleEndian.Uint32(blocks):]
+	}
+	csDump := make([]byte, 8, 8+len(blocks))
+	binary.LittleEndian.PutUint32(csDump, start)
+	binary.LittleEndian.PutUint32(csDump[4:], binary.LittleEndian.Uint32(d)-start)
+	csDump = append(csDump, blocks...)
+
+	accPath := path.Join(tmpDir, "chain.10.acc")
+	require.NoError(t, ioutil.WriteFile(accPath, csDump, os.ModePerm))
+
+	zipPath := path.Join(tmpDir, "chain.10.acc.zip")
+	zf, err := os.Create(zipPath)
+	require.NoError(t, err)
+	zw := zip.NewWriter(zf)
+	w, err := zw.Create("chain.10.acc")
+	require.NoError(t, err)
+	_, err = w.Write(csDump)
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	require.NoError(t, zf.Close())
+
+	e := newExecutor(t, false)
+	baseArgs := []string{"neo-go", "db", "restore", "--unittest", "--config-path", tmpDir}
+
+	// Gap between the chain and the first block in the file.
+	e.RunWithError(t, append(baseArgs, "--in", accPath)...)
+
+	// First 15 blocks from the regular dump.
+	e.Run(t, append(baseArgs, "--in", inDump, "--count", "15")...)
+
+	// Blocks 10..14 are already in the chain and skipped.
+	e.Run(t, append(baseArgs, "--in", zipPath, "--count", "10")...)
+
+	// The rest of it.
+	e.Run(t, append(baseArgs, "--in", accPath)...)
+
+	// Dump and compare.
+	dumpPath := path.Join(tmpDir, "testdump.acc")
+	e.Run(t, "neo-go", "db", "dump", "--unittest",
+		"--config-path", tmpDir, "--out", dumpPath)
+
+	d2, err := ioutil.ReadFile(dumpPath)
+	require.NoError(t, err)
+	require.Equal(t, d, d2, "dumps differ")
 }
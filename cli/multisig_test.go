@@ -2,15 +2,19 @@ package main
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"math/big"
 	"os"
 	"path"
+	"sort"
+	"strings"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"github.com/stretchr/testify/require"
 )
 
@@ -156,3 +160,72 @@ func TestSignMultisigTx(t *testing.T) {
 		require.Equal(t, big.NewInt(2), b)
 	})
 }
+
+func TestMultisigCreateShow(t *testing.T) {
+	e := newExecutor(t, false)
+
+	_, pubs := generateKeys(t, 3)
+	script, err := smartcontract.CreateMultiSigRedeemScript(2, pubs.Copy())
+	require.NoError(t, err)
+	multisigAddr := address.Uint160ToString(hash.Hash160(script))
+
+	cmd := []string{"neo-go", "wallet", "multisig", "create", "--min", "2"}
+	for i := range pubs {
+		cmd = append(cmd, hex.EncodeToString(pubs[i].Bytes()))
+	}
+	t.Run("insufficient keys", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "wallet", "multisig", "create", "--min", "2",
+			hex.EncodeToString(pubs[0].Bytes()))
+	})
+	t.Run("invalid key", func(t *testing.T) {
+		e.RunWithError(t, append(cmd, "0102")...)
+	})
+
+	e.Run(t, cmd...)
+	e.checkNextLine(t, "Script: "+hex.EncodeToString(script))
+	e.checkNextLine(t, "Address: "+multisigAddr)
+	line := strings.TrimPrefix(e.getNextLine(t), "Account: ")
+	acc := new(wallet.Account)
+	require.NoError(t, json.Unmarshal([]byte(line), acc))
+	require.Equal(t, multisigAddr, acc.Address)
+	require.Equal(t, script, acc.Contract.Script)
+	require.Equal(t, 2, len(acc.Contract.Parameters))
+	e.checkEOF(t)
+
+	t.Run("add to wallet", func(t *testing.T) {
+		walletPath := path.Join(os.TempDir(), "multisigCreate.json")
+		t.Cleanup(func() {
+			os.Remove(walletPath)
+		})
+		e.Run(t, "neo-go", "wallet", "init", "--wallet", walletPath)
+		e.Run(t, append(cmd, "--wallet", walletPath, "--name", "multi")...)
+
+		w, err := wallet.NewWalletFromFile(walletPath)
+		require.NoError(t, err)
+		t.Cleanup(w.Close)
+		actual := w.GetAccount(hash.Hash160(script))
+		require.NotNil(t, actual)
+		require.Equal(t, "multi", actual.Label)
+		require.True(t, actual.IsWatchOnly())
+
+		e.RunWithError(t, append(cmd, "--wallet", walletPath)...)
+	})
+
+	t.Run("show", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "wallet", "multisig", "show")
+		e.RunWithError(t, "neo-go", "wallet", "multisig", "show", "zz")
+		e.RunWithError(t, "neo-go", "wallet", "multisig", "show",
+			hex.EncodeToString(pubs[0].GetVerificationScript()))
+
+		e.Run(t, "neo-go", "wallet", "multisig", "show", hex.EncodeToString(script))
+		e.checkNextLine(t, "Address: "+multisigAddr)
+		e.checkNextLine(t, "Signatures: 2 out of 3")
+		e.checkNextLine(t, "Keys:")
+		sorted := pubs.Copy()
+		sort.Sort(sorted)
+		for i := range sorted {
+			e.checkNextLine(t, hex.EncodeToString(sorted[i].Bytes()))
+		}
+		e.checkEOF(t)
+	})
+}
@@ -16,10 +16,14 @@ import (
 const validUntilBlockIncrement = 50
 
 // InitAndSave creates incompletely signed transaction which can used
-// as input to `multisig sign`.
+// as input to `multisig sign`. Transaction is not signed at all for
+// watch-only accounts.
 func InitAndSave(net netmode.Magic, tx *transaction.Transaction, acc *wallet.Account, filename string) error {
 	// avoid fast transaction expiration
 	tx.ValidUntilBlock += validUntilBlockIncrement
+	if acc.IsWatchOnly() {
+		return Save(context.NewParameterContext("Neo.Core.ContractTransaction", net, tx), filename)
+	}
 	priv := acc.PrivateKey()
 	pub := priv.PublicKey()
 	sign := priv.SignHashable(uint32(net), tx)
@@ -0,0 +1,82 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/blockchainer"
+	"github.com/nspcc-dev/neo-go/pkg/core/chaindump"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+)
+
+// readManifest reads chunked dump manifest from the given directory.
+func readManifest(dir string) (*chaindump.Manifest, error) {
+	data, err := ioutil.ReadFile(filepath.Join(dir, chaindump.ManifestFile))
+	if err != nil {
+		return nil, err
+	}
+	m := new(chaindump.Manifest)
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("bad manifest: %w", err)
+	}
+	return m, nil
+}
+
+// nextBlock returns the index of the first block that is not yet dumped
+// (or the start of the last incomplete chunk that is to be dumped again).
+// Incomplete chunk is removed from the manifest.
+func nextBlock(m *chaindump.Manifest) uint32 {
+	n := len(m.Chunks)
+	if n == 0 {
+		return 0
+	}
+	last := m.Chunks[n-1]
+	if last.Count < m.ChunkSize {
+		m.Chunks = m.Chunks[:n-1]
+		return last.Start
+	}
+	return last.Start + last.Count
+}
+
+// writeManifest atomically replaces manifest in the given directory.
+func writeManifest(m *chaindump.Manifest, dir string) error {
+	data, err := json.MarshalIndent(m, "", " ")
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(dir, chaindump.ManifestFile)
+	if err := ioutil.WriteFile(path+".tmp", data, os.ModePerm); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+// writeChunk dumps count blocks starting from start into C#-compatible
+// `chain.$START.acc` file placed into `BlockStorage_$DIRNO` directory (based
+// on the last block of the chunk with the given size) and returns its path
+// relative to dir. The file only appears after all blocks are written.
+func writeChunk(bc blockchainer.Blockchainer, dir string, size, start, count uint32) (string, error) {
+	name := filepath.Join(blockStorageDir(start+size-1), fmt.Sprintf("chain.%d.acc", start))
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return "", err
+	}
+	f, err := os.Create(path + ".tmp")
+	if err != nil {
+		return "", err
+	}
+	w := io.NewBinWriterFromIO(f)
+	w.WriteU32LE(start)
+	w.WriteU32LE(count)
+	err = chaindump.Dump(bc, w, start, count)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return "", err
+	}
+	return name, os.Rename(path+".tmp", path)
+}
@@ -0,0 +1,355 @@
+package server
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core"
+	"github.com/nspcc-dev/neo-go/pkg/core/native"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
+	"github.com/nspcc-dev/neo-go/pkg/network"
+	"github.com/nspcc-dev/neo-go/pkg/rpc"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/server"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/urfave/cli"
+	"go.uber.org/zap"
+)
+
+// DevnetPassword is the password of all wallets created for local
+// development network.
+const DevnetPassword = "devnet"
+
+// devnetOptions contains local development network parameters.
+type devnetOptions struct {
+	Nodes     int
+	BlockTime time.Duration
+	Faucets   int
+	FaucetGAS int64
+	FaucetNEO int64
+	Dir       string
+	P2PPort   uint16
+	RPCPort   uint16
+}
+
+// devnet is a set of node configurations and faucet accounts comprising
+// local development network.
+type devnet struct {
+	configs []config.Config
+	faucets []*wallet.Account
+}
+
+// devnetNode is a single running devnet node.
+type devnetNode struct {
+	chain *core.Blockchain
+	serv  *network.Server
+	rpc   *server.Server
+}
+
+func newDevnetCommand() cli.Command {
+	return cli.Command{
+		Name:  "devnet",
+		Usage: "local development network",
+		Subcommands: []cli.Command{
+			{
+				Name:      "up",
+				Usage:     "start local consensus network in a single process",
+				UsageText: "neo-go devnet up [--nodes 4] [--block-time 1000] [--faucets 1] [--dir ./devnet]",
+				Action:    devnetUp,
+				Flags: []cli.Flag{
+					cli.IntFlag{
+						Name:  "nodes, n",
+						Value: 4,
+						Usage: "number of consensus nodes",
+					},
+					cli.UintFlag{
+						Name:  "block-time",
+						Value: 1000,
+						Usage: "block time in milliseconds",
+					},
+					cli.IntFlag{
+						Name:  "faucets",
+						Value: 1,
+						Usage: "number of faucet accounts",
+					},
+					cli.Int64Flag{
+						Name:  "faucet-gas",
+						Value: 1000000,
+						Usage: "amount of GAS transferred to every faucet account in genesis block",
+					},
+					cli.Int64Flag{
+						Name:  "faucet-neo",
+						Value: 1000000,
+						Usage: "amount of NEO transferred to every faucet account in genesis block",
+					},
+					cli.StringFlag{
+						Name:  "dir",
+						Value: "./devnet",
+						Usage: "directory for node and faucet wallets",
+					},
+					cli.UintFlag{
+						Name:  "p2p-port",
+						Value: 20333,
+						Usage: "P2P port of the first node, other nodes use subsequent ports",
+					},
+					cli.UintFlag{
+						Name:  "rpc-port",
+						Value: 30333,
+						Usage: "RPC port of the first node, other nodes use subsequent ports",
+					},
+					cli.BoolFlag{Name: "debug, d"},
+				},
+			},
+		},
+	}
+}
+
+func devnetUp(ctx *cli.Context) error {
+	opts := devnetOptions{
+		Nodes:     ctx.Int("nodes"),
+		BlockTime: time.Duration(ctx.Uint("block-time")) * time.Millisecond,
+		Faucets:   ctx.Int("faucets"),
+		FaucetGAS: ctx.Int64("faucet-gas"),
+		FaucetNEO: ctx.Int64("faucet-neo"),
+		Dir:       ctx.String("dir"),
+		P2PPort:   uint16(ctx.Uint("p2p-port")),
+		RPCPort:   uint16(ctx.Uint("rpc-port")),
+	}
+	net, err := newDevnet(opts)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	log, err := handleLoggingParams(ctx, config.ApplicationConfiguration{})
+	if err != nil {
+		return err
+	}
+
+	grace, cancel := context.WithCancel(newGraceContext())
+	defer cancel()
+
+	errChan := make(chan error)
+	nodes := make([]devnetNode, 0, len(net.configs))
+	defer func() {
+		for _, n := range nodes {
+			n.serv.Shutdown()
+			if err := n.rpc.Shutdown(); err != nil {
+				log.Warn("error on RPC server shutdown", zap.Error(err))
+			}
+			n.chain.Close()
+		}
+	}()
+	for i, cfg := range net.configs {
+		nodeLog := log.With(zap.Int("node", i))
+		chain, err := core.NewBlockchain(storage.NewMemoryStore(), cfg.ProtocolConfiguration, nodeLog)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("could not initialize blockchain: %w", err), 1)
+		}
+		go chain.Run()
+		serv, err := network.NewServer(network.NewServerConfig(cfg), chain, nodeLog)
+		if err != nil {
+			chain.Close()
+			return cli.NewExitError(fmt.Errorf("failed to create network server: %w", err), 1)
+		}
+		rpcServer := server.New(chain, cfg.ApplicationConfiguration.RPC, serv, serv.GetOracle(), nodeLog)
+		nodes = append(nodes, devnetNode{chain: chain, serv: serv, rpc: &rpcServer})
+
+		go serv.Start(errChan)
+		rpcServer.Start(errChan)
+	}
+
+	w := ctx.App.Writer
+	fmt.Fprintf(w, "Development network with %d consensus node(s) is up, block time is %s\n", len(nodes), opts.BlockTime)
+	for i, cfg := range net.configs {
+		fmt.Fprintf(w, "  node #%d: P2P %s:%d, RPC http://%s:%d, wallet %s\n", i,
+			cfg.ApplicationConfiguration.Address, cfg.ApplicationConfiguration.NodePort,
+			cfg.ApplicationConfiguration.RPC.Address, cfg.ApplicationConfiguration.RPC.Port,
+			cfg.ApplicationConfiguration.UnlockWallet.Path)
+	}
+	fmt.Fprintf(w, "Faucet wallet %s (password %q):\n", devnetFaucetWallet(opts.Dir), DevnetPassword)
+	for _, acc := range net.faucets {
+		fmt.Fprintf(w, "  %s: %d GAS, %d NEO\n", acc.Address, opts.FaucetGAS, opts.FaucetNEO)
+	}
+
+	select {
+	case err := <-errChan:
+		cancel()
+		return cli.NewExitError(fmt.Errorf("server error: %w", err), 1)
+	case <-grace.Done():
+	}
+	return nil
+}
+
+// newDevnet creates (or reuses) node and faucet wallets in the specified
+// directory and generates configurations for all devnet nodes.
+func newDevnet(opts devnetOptions) (*devnet, error) {
+	if opts.Nodes <= 0 {
+		return nil, errors.New("number of nodes must be positive")
+	}
+	if opts.Faucets < 0 || opts.FaucetGAS < 0 || opts.FaucetNEO < 0 {
+		return nil, errors.New("faucet parameters can't be negative")
+	}
+	// Genesis transfers are made from the initial supply, checking amounts
+	// against it also prevents overflows.
+	if opts.Faucets > 0 && (opts.FaucetGAS > native.InitialGAS/int64(opts.Faucets) ||
+		opts.FaucetNEO > native.NEOTotalSupply/int64(opts.Faucets)) {
+		return nil, fmt.Errorf("faucet amounts exceed initial supply (%d GAS, %d NEO)", native.InitialGAS, native.NEOTotalSupply)
+	}
+	if opts.BlockTime < time.Millisecond {
+		return nil, errors.New("invalid block time")
+	}
+	if int(opts.P2PPort)+opts.Nodes > 65536 || int(opts.RPCPort)+opts.Nodes > 65536 {
+		return nil, errors.New("port range is out of bounds")
+	}
+	if err := os.MkdirAll(opts.Dir, os.ModePerm); err != nil {
+		return nil, fmt.Errorf("can't create devnet directory: %w", err)
+	}
+
+	var (
+		committee = make([]string, opts.Nodes)
+		seeds     = make([]string, opts.Nodes)
+		wallets   = make([]string, opts.Nodes)
+	)
+	for i := 0; i < opts.Nodes; i++ {
+		wallets[i] = filepath.Join(opts.Dir, fmt.Sprintf("node%d.json", i))
+		accs, err := devnetAccounts(wallets[i], 1)
+		if err != nil {
+			return nil, err
+		}
+		committee[i] = hex.EncodeToString(accs[0].PrivateKey().PublicKey().Bytes())
+		seeds[i] = fmt.Sprintf("127.0.0.1:%d", int(opts.P2PPort)+i)
+	}
+
+	faucets, err := devnetAccounts(devnetFaucetWallet(opts.Dir), opts.Faucets)
+	if err != nil {
+		return nil, err
+	}
+	var genesis config.Genesis
+	for _, acc := range faucets {
+		if opts.FaucetGAS != 0 {
+			genesis.Transfers = append(genesis.Transfers, config.GenesisTransfer{
+				Asset:  nativenames.Gas,
+				To:     acc.Address,
+				Amount: opts.FaucetGAS * native.GASFactor,
+			})
+		}
+		if opts.FaucetNEO != 0 {
+			genesis.Transfers = append(genesis.Transfers, config.GenesisTransfer{
+				Asset:  nativenames.Neo,
+				To:     acc.Address,
+				Amount: opts.FaucetNEO,
+			})
+		}
+	}
+
+	secondsPerBlock := int(opts.BlockTime / time.Second)
+	if secondsPerBlock == 0 {
+		secondsPerBlock = 1
+	}
+	proto := config.ProtocolConfiguration{
+		Magic:                       netmode.PrivNet,
+		MemPoolSize:                 50000,
+		MaxTraceableBlocks:          200000,
+		SecondsPerBlock:             secondsPerBlock,
+		MillisecondsPerBlockHistory: map[uint32]int{0: int(opts.BlockTime / time.Millisecond)},
+		StandbyCommittee:            committee,
+		ValidatorsCount:             opts.Nodes,
+		SeedList:                    seeds,
+		VerifyBlocks:                true,
+		VerifyTransactions:          true,
+		Genesis:                     genesis,
+	}
+	net := &devnet{faucets: faucets}
+	for i := 0; i < opts.Nodes; i++ {
+		net.configs = append(net.configs, config.Config{
+			ProtocolConfiguration: proto,
+			ApplicationConfiguration: config.ApplicationConfiguration{
+				Address:           "127.0.0.1",
+				NodePort:          opts.P2PPort + uint16(i),
+				DBConfiguration:   storage.DBConfiguration{Type: "inmemory"},
+				Relay:             true,
+				DialTimeout:       3,
+				ProtoTickInterval: 2,
+				PingInterval:      30,
+				PingTimeout:       90,
+				MaxPeers:          opts.Nodes + 10,
+				AttemptConnPeers:  opts.Nodes,
+				MinPeers:          opts.Nodes - 1,
+				RPC: rpc.Config{
+					Address:      "127.0.0.1",
+					Enabled:      true,
+					MaxGasInvoke: fixedn.Fixed8FromInt64(100),
+					Port:         opts.RPCPort + uint16(i),
+				},
+				UnlockWallet: config.Wallet{
+					Path:     wallets[i],
+					Password: DevnetPassword,
+				},
+			},
+		})
+	}
+	return net, nil
+}
+
+// devnetFaucetWallet returns path to the faucet wallet.
+func devnetFaucetWallet(dir string) string {
+	return filepath.Join(dir, "faucet.json")
+}
+
+// devnetAccounts returns n accounts from the specified wallet creating it
+// and adding new accounts to it if needed, so that the same keys are used
+// on devnet restarts. All accounts are decrypted.
+func devnetAccounts(path string, n int) ([]*wallet.Account, error) {
+	var (
+		w   *wallet.Wallet
+		err error
+	)
+	if _, err = os.Stat(path); err == nil {
+		w, err = wallet.NewWalletFromFile(path)
+	} else {
+		w, err = wallet.NewWallet(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can't open devnet wallet: %w", err)
+	}
+	defer w.Close()
+
+	var (
+		accs  = make([]*wallet.Account, 0, n)
+		dirty = len(w.Accounts) == 0
+	)
+	for _, acc := range w.Accounts {
+		if len(accs) == n {
+			break
+		}
+		if err := acc.Decrypt(DevnetPassword); err != nil {
+			return nil, fmt.Errorf("can't decrypt account %s from %s: %w", acc.Address, path, err)
+		}
+		accs = append(accs, acc)
+	}
+	for len(accs) < n {
+		acc, err := wallet.NewAccount()
+		if err != nil {
+			return nil, err
+		}
+		if err := acc.Encrypt(DevnetPassword); err != nil {
+			return nil, err
+		}
+		w.AddAccount(acc)
+		accs = append(accs, acc)
+		dirty = true
+	}
+	if dirty {
+		if err := w.Save(); err != nil {
+			return nil, fmt.Errorf("can't save devnet wallet: %w", err)
+		}
+	}
+	return accs, nil
+}
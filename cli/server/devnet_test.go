@@ -0,0 +1,99 @@
+package server
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/native"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDevnet(t *testing.T) {
+	dir, err := ioutil.TempDir("", "neogo.devnet")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	opts := devnetOptions{
+		Nodes:     4,
+		BlockTime: 500 * time.Millisecond,
+		Faucets:   2,
+		FaucetGAS: 100,
+		FaucetNEO: 10,
+		Dir:       dir,
+		P2PPort:   40333,
+		RPCPort:   50333,
+	}
+	net, err := newDevnet(opts)
+	require.NoError(t, err)
+	require.Equal(t, 4, len(net.configs))
+	require.Equal(t, 2, len(net.faucets))
+
+	for i, cfg := range net.configs {
+		proto := cfg.ProtocolConfiguration
+		require.Equal(t, 4, proto.ValidatorsCount)
+		require.Equal(t, 4, len(proto.StandbyCommittee))
+		require.Equal(t, 4, len(proto.SeedList))
+		require.Equal(t, 500*time.Millisecond, proto.TimePerBlock(1))
+		require.Equal(t, 4, len(proto.Genesis.Transfers))
+		require.Equal(t, nativenames.Gas, proto.Genesis.Transfers[0].Asset)
+		require.Equal(t, 100*native.GASFactor, proto.Genesis.Transfers[0].Amount)
+		require.Equal(t, net.faucets[0].Address, proto.Genesis.Transfers[0].To)
+		require.Equal(t, nativenames.Neo, proto.Genesis.Transfers[1].Asset)
+		require.Equal(t, int64(10), proto.Genesis.Transfers[1].Amount)
+
+		app := cfg.ApplicationConfiguration
+		require.Equal(t, uint16(40333+i), app.NodePort)
+		require.Equal(t, uint16(50333+i), app.RPC.Port)
+		require.True(t, app.RPC.Enabled)
+		require.Equal(t, 3, app.MinPeers)
+		require.Equal(t, filepath.Join(dir, fmt.Sprintf("node%d.json", i)), app.UnlockWallet.Path)
+		require.Equal(t, DevnetPassword, app.UnlockWallet.Password)
+	}
+
+	t.Run("reuse wallets", func(t *testing.T) {
+		opts := opts
+		opts.Nodes = 1
+		opts.Faucets = 3
+		net2, err := newDevnet(opts)
+		require.NoError(t, err)
+		require.Equal(t, net.configs[0].ProtocolConfiguration.StandbyCommittee[:1],
+			net2.configs[0].ProtocolConfiguration.StandbyCommittee)
+		require.Equal(t, 3, len(net2.faucets))
+		require.Equal(t, net.faucets[0].Address, net2.faucets[0].Address)
+		require.Equal(t, net.faucets[1].Address, net2.faucets[1].Address)
+		require.Equal(t, 0, net2.configs[0].ApplicationConfiguration.MinPeers)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		check := func(t *testing.T, f func(o *devnetOptions)) {
+			o := opts
+			f(&o)
+			_, err := newDevnet(o)
+			require.Error(t, err)
+		}
+		t.Run("no nodes", func(t *testing.T) {
+			check(t, func(o *devnetOptions) { o.Nodes = 0 })
+		})
+		t.Run("negative faucets", func(t *testing.T) {
+			check(t, func(o *devnetOptions) { o.Faucets = -1 })
+		})
+		t.Run("too much GAS", func(t *testing.T) {
+			check(t, func(o *devnetOptions) { o.FaucetGAS = math.MaxInt64 / 2 })
+		})
+		t.Run("too much NEO", func(t *testing.T) {
+			check(t, func(o *devnetOptions) { o.FaucetNEO = native.NEOTotalSupply })
+		})
+		t.Run("block time", func(t *testing.T) {
+			check(t, func(o *devnetOptions) { o.BlockTime = 0 })
+		})
+		t.Run("ports", func(t *testing.T) {
+			check(t, func(o *devnetOptions) { o.P2PPort = 65535 })
+		})
+	})
+}
@@ -1,79 +1,27 @@
 package server
 
 import (
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 
+	"github.com/nspcc-dev/neo-go/pkg/core"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/storage"
 )
 
-type dump []blockDump
-
-type blockDump struct {
-	Block   uint32      `json:"block"`
-	Size    int         `json:"size"`
-	Storage []storageOp `json:"storage"`
-}
-
-type storageOp struct {
-	State string `json:"state"`
-	Key   string `json:"key"`
-	Value string `json:"value,omitempty"`
-}
-
-// batchToMap converts batch to a map so that JSON is compatible
+// dump is a set of storage changes of several blocks, its JSON is compatible
 // with https://github.com/NeoResearch/neo-storage-audit/
-func batchToMap(index uint32, batch *storage.MemBatch) blockDump {
-	size := len(batch.Put) + len(batch.Deleted)
-	ops := make([]storageOp, 0, size)
-	for i := range batch.Put {
-		key := batch.Put[i].Key
-		if len(key) == 0 || key[0] != byte(storage.STStorage) {
-			continue
-		}
-
-		op := "Added"
-		if batch.Put[i].Exists {
-			op = "Changed"
-		}
-
-		ops = append(ops, storageOp{
-			State: op,
-			Key:   base64.StdEncoding.EncodeToString(key[1:]),
-			Value: base64.StdEncoding.EncodeToString(batch.Put[i].Value),
-		})
-	}
-
-	for i := range batch.Deleted {
-		key := batch.Deleted[i].Key
-		if len(key) == 0 || key[0] != byte(storage.STStorage) || !batch.Deleted[i].Exists {
-			continue
-		}
-
-		ops = append(ops, storageOp{
-			State: "Deleted",
-			Key:   base64.StdEncoding.EncodeToString(key[1:]),
-		})
-	}
-
-	return blockDump{
-		Block:   index,
-		Size:    len(ops),
-		Storage: ops,
-	}
-}
+type dump []state.StorageChanges
 
 func newDump() *dump {
 	return new(dump)
 }
 
 func (d *dump) add(index uint32, batch *storage.MemBatch) {
-	m := batchToMap(index, batch)
-	*d = append(*d, m)
+	*d = append(*d, *core.NewStorageChanges(index, batch))
 }
 
 func (d *dump) tryPersist(prefix string, index uint32) error {
@@ -127,10 +75,7 @@ func readFile(path string) (*dump, error) {
 // File dump-block-$FILENO.json contains blocks from $FILENO-999, $FILENO
 // Example: file `BlockStorage_100000/dump-block-6000.json` contains blocks from 5001 to 6000.
 func getPath(prefix string, index uint32) (string, error) {
-	dirN := ((index + 99999) / 100000) * 100000
-	dir := fmt.Sprintf("BlockStorage_%d", dirN)
-
-	path := filepath.Join(prefix, dir)
+	path := filepath.Join(prefix, blockStorageDir(index))
 	info, err := os.Stat(path)
 	if os.IsNotExist(err) {
 		err := os.MkdirAll(path, os.ModePerm)
@@ -145,3 +90,10 @@ func getPath(prefix string, index uint32) (string, error) {
 	file := fmt.Sprintf("dump-block-%d.json", fileN)
 	return filepath.Join(path, file), nil
 }
+
+// blockStorageDir returns the name of `BlockStorage_$DIRNO` directory for
+// the given block index.
+func blockStorageDir(index uint32) string {
+	dirN := ((index + 99999) / 100000) * 100000
+	return fmt.Sprintf("BlockStorage_%d", dirN)
+}
@@ -1,10 +1,16 @@
 package server
 
 import (
+	"archive/zip"
 	"context"
 	"fmt"
+	gio "io"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
 
 	"github.com/nspcc-dev/neo-go/cli/options"
 	"github.com/nspcc-dev/neo-go/pkg/config"
@@ -16,11 +22,15 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/network"
 	"github.com/nspcc-dev/neo-go/pkg/network/metrics"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/server"
+	"github.com/nspcc-dev/neo-go/pkg/services/faucet"
 	"github.com/urfave/cli"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
+// accStartRe matches C# node dump file names containing starting block index.
+var accStartRe = regexp.MustCompile(`^chain\.[0-9]+\.acc$`)
+
 // NewCommands returns 'node' command.
 func NewCommands() []cli.Command {
 	var cfgFlags = []cli.Flag{
@@ -45,7 +55,15 @@ func NewCommands() []cli.Command {
 		},
 		cli.StringFlag{
 			Name:  "out, o",
-			Usage: "Output file (stdout if not given)",
+			Usage: "Output file (stdout if not given), output directory for chunked dumps",
+		},
+		cli.UintFlag{
+			Name:  "chunk",
+			Usage: "number of blocks per file for chunked dump (default or 0: single file)",
+		},
+		cli.BoolFlag{
+			Name:  "resume",
+			Usage: "continue chunked dump using its manifest",
 		},
 	)
 	var cfgCountInFlags = make([]cli.Flag, len(cfgWithCountFlags))
@@ -57,12 +75,24 @@ func NewCommands() []cli.Command {
 		},
 		cli.StringFlag{
 			Name:  "in, i",
-			Usage: "Input file (stdin if not given)",
+			Usage: "Input file (stdin if not given), C# node chain.N.acc and .zip packages are supported",
 		},
 		cli.StringFlag{
 			Name:  "dump",
 			Usage: "directory for storing JSON dumps",
 		},
+		cli.UintFlag{
+			Name:  "workers",
+			Usage: "number of block decoding workers (default or 0: number of CPUs)",
+		},
+	)
+	var cfgForkFlags = make([]cli.Flag, len(cfgFlags))
+	copy(cfgForkFlags, cfgFlags)
+	cfgForkFlags = append(cfgForkFlags,
+		cli.UintFlag{
+			Name:  "height",
+			Usage: "expected chain height to fork at (default: current height)",
+		},
 	)
 	return []cli.Command{
 		{
@@ -87,8 +117,21 @@ func NewCommands() []cli.Command {
 					Action: restoreDB,
 					Flags:  cfgCountInFlags,
 				},
+				{
+					Name:   "compact",
+					Usage:  "compact the database reclaiming space taken by deleted data",
+					Action: compactDB,
+					Flags:  cfgFlags,
+				},
+				{
+					Name:   "fork",
+					Usage:  "turn the chain into a private one with standby committee from the configuration",
+					Action: forkDB,
+					Flags:  cfgForkFlags,
+				},
 			},
 		},
+		newDevnetCommand(),
 	}
 }
 
@@ -170,6 +213,9 @@ func dumpDB(ctx *cli.Context) error {
 	}
 	count := uint32(ctx.Uint("count"))
 	start := uint32(ctx.Uint("start"))
+	if chunk := uint32(ctx.Uint("chunk")); chunk != 0 {
+		return dumpDBChunks(ctx, cfg, log, chunk, start, count)
+	}
 
 	var outStream = os.Stdout
 	if out := ctx.String("out"); out != "" {
@@ -181,6 +227,10 @@ func dumpDB(ctx *cli.Context) error {
 	defer outStream.Close()
 	writer := io.NewBinWriterFromIO(outStream)
 
+	// Dumping doesn't change anything, so the DB can be shared with other
+	// readers (if it's supported by the backend).
+	cfg.ApplicationConfiguration.DBConfiguration.LevelDBOptions.ReadOnly = true
+	cfg.ApplicationConfiguration.DBConfiguration.BoltDBOptions.ReadOnly = true
 	chain, prometheus, pprof, err := initBCWithMetrics(cfg, log)
 	if err != nil {
 		return err
@@ -204,6 +254,133 @@ func dumpDB(ctx *cli.Context) error {
 	return nil
 }
 
+// dumpDBChunks dumps blocks into a set of files with chunk blocks each.
+func dumpDBChunks(ctx *cli.Context, cfg config.Config, log *zap.Logger, chunk, start, count uint32) error {
+	dir := ctx.String("out")
+	if dir == "" {
+		return cli.NewExitError("output directory is required for chunked dump", 1)
+	}
+	if start%chunk != 0 {
+		return cli.NewExitError(fmt.Errorf("start block %d is not a multiple of chunk size %d", start, chunk), 1)
+	}
+	if ctx.Bool("resume") && ctx.IsSet("start") {
+		return cli.NewExitError("--start can't be used with --resume, dump continues from the last chunk", 1)
+	}
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	m := &chaindump.Manifest{ChunkSize: chunk}
+	if ctx.Bool("resume") {
+		old, err := readManifest(dir)
+		if err == nil {
+			if old.ChunkSize != chunk {
+				return cli.NewExitError(fmt.Errorf("chunk size mismatch: dump has %d, %d requested", old.ChunkSize, chunk), 1)
+			}
+			m = old
+			if len(m.Chunks) != 0 {
+				start = nextBlock(m)
+			}
+		} else if !os.IsNotExist(err) {
+			return cli.NewExitError(err, 1)
+		}
+	}
+
+	cfg.ApplicationConfiguration.DBConfiguration.LevelDBOptions.ReadOnly = true
+	cfg.ApplicationConfiguration.DBConfiguration.BoltDBOptions.ReadOnly = true
+	chain, prometheus, pprof, err := initBCWithMetrics(cfg, log)
+	if err != nil {
+		return err
+	}
+	defer chain.Close()
+	defer prometheus.ShutDown()
+	defer pprof.ShutDown()
+
+	// Start can be changed by resume, so the end is computed after that.
+	var (
+		chainCount = chain.BlockHeight() + 1
+		end        = start + count
+	)
+	if count == 0 {
+		end = chainCount
+	} else if end > chainCount {
+		return cli.NewExitError(fmt.Errorf("chain is not that high (%d) to dump %d blocks starting from %d", chainCount-1, count, start), 1)
+	}
+	gctx := newGraceContext()
+	for s := start; s < end; s += chunk {
+		select {
+		case <-gctx.Done():
+			return cli.NewExitError(gctx.Err(), 1)
+		default:
+		}
+		n := chunk
+		if end-s < n {
+			n = end - s
+		}
+		file, err := writeChunk(chain, dir, chunk, s, n)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("failed to dump blocks %d-%d: %w", s, s+n-1, err), 1)
+		}
+		m.Chunks = append(m.Chunks, chaindump.Chunk{Start: s, Count: n, File: file})
+		if err := writeManifest(m, dir); err != nil {
+			return cli.NewExitError(fmt.Errorf("failed to write manifest: %w", err), 1)
+		}
+	}
+	return nil
+}
+
+func compactDB(ctx *cli.Context) error {
+	cfg, err := getConfigFromContext(ctx)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	store, err := storage.NewStore(cfg.ApplicationConfiguration.DBConfiguration)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("could not initialize storage: %w", err), 1)
+	}
+	defer store.Close()
+	c, ok := store.(storage.Compactor)
+	if !ok {
+		return cli.NewExitError(fmt.Errorf("%s DB doesn't support compaction", cfg.ApplicationConfiguration.DBConfiguration.Type), 1)
+	}
+	reclaimed, err := c.Compact()
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to compact DB: %w", err), 1)
+	}
+	fmt.Fprintf(ctx.App.Writer, "Reclaimed %d bytes\n", reclaimed)
+	return nil
+}
+
+func forkDB(ctx *cli.Context) error {
+	cfg, err := getConfigFromContext(ctx)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	log, err := handleLoggingParams(ctx, cfg.ApplicationConfiguration)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	chain, prometheus, pprof, err := initBCWithMetrics(cfg, log)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		pprof.ShutDown()
+		prometheus.ShutDown()
+		chain.Close()
+	}()
+
+	height := chain.BlockHeight()
+	if ctx.IsSet("height") && uint32(ctx.Uint("height")) != height {
+		return cli.NewExitError(fmt.Errorf("chain is at %d, restore it up to %d using the original network configuration first", height, ctx.Uint("height")), 1)
+	}
+	if err := chain.Fork(); err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to fork the chain: %w", err), 1)
+	}
+	fmt.Fprintf(ctx.App.Writer, "Chain forked at %d, the next block is to be signed by %d standby validators\n",
+		height, cfg.ProtocolConfiguration.ValidatorsCount)
+	return nil
+}
+
 func restoreDB(ctx *cli.Context) error {
 	cfg, err := getConfigFromContext(ctx)
 	if err != nil {
@@ -216,9 +393,12 @@ func restoreDB(ctx *cli.Context) error {
 	count := uint32(ctx.Uint("count"))
 	skip := uint32(ctx.Uint("skip"))
 
-	var inStream = os.Stdin
+	var (
+		inStream  gio.ReadCloser = os.Stdin
+		withStart bool
+	)
 	if in := ctx.String("in"); in != "" {
-		inStream, err = os.Open(in)
+		inStream, withStart, err = openDump(in)
 		if err != nil {
 			return cli.NewExitError(err, 1)
 		}
@@ -239,9 +419,26 @@ func restoreDB(ctx *cli.Context) error {
 	defer prometheus.ShutDown()
 	defer pprof.ShutDown()
 
+	var start uint32
+	if withStart {
+		start = reader.ReadU32LE()
+	}
 	var allBlocks = reader.ReadU32LE()
 	if reader.Err != nil {
-		return cli.NewExitError(err, 1)
+		return cli.NewExitError(reader.Err, 1)
+	}
+	if withStart {
+		height := chain.BlockHeight()
+		if start > height+1 {
+			return cli.NewExitError(fmt.Errorf("input file starts with block %d, but chain height is %d", start, height), 1)
+		}
+		// Blocks already present in the chain are skipped the same way C# node does it.
+		if skip == 0 && height+1 > start {
+			skip = height + 1 - start
+			if skip > allBlocks {
+				skip = allBlocks
+			}
+		}
 	}
 	if skip+count > allBlocks {
 		return cli.NewExitError(fmt.Errorf("input file has only %d blocks, can't read %d starting from %d", allBlocks, count, skip), 1)
@@ -288,13 +485,56 @@ func restoreDB(ctx *cli.Context) error {
 		}
 	}
 
-	err = chaindump.Restore(chain, reader, skip, count, f)
+	workers := int(ctx.Uint("workers"))
+	if workers == 0 {
+		workers = runtime.NumCPU()
+	}
+	err = chaindump.RestoreWithDecoders(chain, reader, skip, count, workers, f)
 	if err != nil {
 		return cli.NewExitError(err, 1)
 	}
 	return nil
 }
 
+// zipDump is a block dump packed into zip archive.
+type zipDump struct {
+	gio.ReadCloser
+	archive *zip.ReadCloser
+}
+
+// Close implements io.Closer interface.
+func (d *zipDump) Close() error {
+	_ = d.ReadCloser.Close()
+	return d.archive.Close()
+}
+
+// openDump opens block dump file for reading. It supports C# node formats
+// in addition to the one used by `db dump`: `chain.N.acc` files starting
+// with block N contain starting block index before the number of blocks
+// (which is reported via the second result) and either file can be packed
+// into zip archive (offline package).
+func openDump(name string) (gio.ReadCloser, bool, error) {
+	withStart := accStartRe.MatchString(filepath.Base(strings.TrimSuffix(name, ".zip")))
+	if !strings.HasSuffix(name, ".zip") {
+		f, err := os.Open(name)
+		return f, withStart, err
+	}
+	archive, err := zip.OpenReader(name)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(archive.File) != 1 {
+		_ = archive.Close()
+		return nil, false, fmt.Errorf("archive should contain exactly one file, got %d", len(archive.File))
+	}
+	r, err := archive.File[0].Open()
+	if err != nil {
+		_ = archive.Close()
+		return nil, false, err
+	}
+	return &zipDump{ReadCloser: r, archive: archive}, withStart, nil
+}
+
 func startServer(ctx *cli.Context) error {
 	cfg, err := getConfigFromContext(ctx)
 	if err != nil {
@@ -315,15 +555,32 @@ func startServer(ctx *cli.Context) error {
 		return err
 	}
 
+	restoreMemPool(chain, cfg.ApplicationConfiguration.MemPoolDumpFile, log)
+
 	serv, err := network.NewServer(serverConfig, chain, log)
 	if err != nil {
 		return cli.NewExitError(fmt.Errorf("failed to create network server: %w", err), 1)
 	}
 	rpcServer := server.New(chain, cfg.ApplicationConfiguration.RPC, serv, serv.GetOracle(), log)
+	var fct *faucet.Faucet
+	if cfg.ApplicationConfiguration.Faucet.Enabled {
+		fct, err = faucet.New(faucet.Config{
+			Log:     log,
+			MainCfg: cfg.ApplicationConfiguration.Faucet,
+			Chain:   chain,
+			RelayTx: serv.RelayTxn,
+		})
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("failed to create faucet: %w", err), 1)
+		}
+	}
 	errChan := make(chan error)
 
 	go serv.Start(errChan)
 	rpcServer.Start(errChan)
+	if fct != nil {
+		fct.Start(errChan)
+	}
 
 	fmt.Fprintln(ctx.App.Writer, logo())
 	fmt.Fprintln(ctx.App.Writer, serv.UserAgent)
@@ -342,8 +599,14 @@ Main:
 			if serverErr := rpcServer.Shutdown(); serverErr != nil {
 				shutdownErr = fmt.Errorf("error on shutdown: %w", serverErr)
 			}
+			if fct != nil {
+				if serverErr := fct.Shutdown(); serverErr != nil {
+					shutdownErr = fmt.Errorf("error on faucet shutdown: %w", serverErr)
+				}
+			}
 			prometheus.ShutDown()
 			pprof.ShutDown()
+			saveMemPool(chain, cfg.ApplicationConfiguration.MemPoolDumpFile, log)
 			chain.Close()
 			break Main
 		}
@@ -356,6 +619,32 @@ Main:
 	return nil
 }
 
+// restoreMemPool loads transactions saved on previous node shutdown into the
+// memory pool if it's enabled in the configuration.
+func restoreMemPool(chain *core.Blockchain, path string, log *zap.Logger) {
+	if path == "" {
+		return
+	}
+	n, err := chain.LoadMemPool(path)
+	if err != nil {
+		log.Warn("failed to restore mempool", zap.String("file", path), zap.Error(err))
+		return
+	}
+	log.Info("mempool restored", zap.String("file", path), zap.Int("transactions", n))
+}
+
+// saveMemPool saves memory pool contents if it's enabled in the configuration.
+func saveMemPool(chain *core.Blockchain, path string, log *zap.Logger) {
+	if path == "" {
+		return
+	}
+	if err := chain.SaveMemPool(path); err != nil {
+		log.Warn("failed to save mempool", zap.String("file", path), zap.Error(err))
+		return
+	}
+	log.Info("mempool saved", zap.String("file", path), zap.Int("transactions", chain.GetMemPool().Count()))
+}
+
 // configureAddresses sets up addresses for RPC, Prometheus and Pprof depending from the provided config.
 // In case RPC or Prometheus or Pprof Address provided each of them will use it.
 // In case global Address (of the node) provided and RPC/Prometheus/Pprof don't have configured addresses they will
@@ -0,0 +1,42 @@
+package smartcontract
+
+import (
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/urfave/cli"
+)
+
+// contractAnalyze checks contract source for common vulnerabilities printing
+// all findings of the specified severity or higher.
+func contractAnalyze(ctx *cli.Context) error {
+	src := ctx.String("in")
+	if len(src) == 0 {
+		return cli.NewExitError(errNoInput, 1)
+	}
+	minSeverity := compiler.SeverityLow
+	if s := ctx.String("severity"); s != "" {
+		var err error
+		minSeverity, err = compiler.ParseSeverity(s)
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+	}
+	findings, err := compiler.Analyze(src, nil)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to analyze contract: %w", err), 1)
+	}
+	var n int
+	for _, f := range findings {
+		if f.Severity < minSeverity {
+			continue
+		}
+		fmt.Fprintln(ctx.App.Writer, f)
+		n++
+	}
+	if n != 0 {
+		return cli.NewExitError(fmt.Errorf("%d issue(s) found", n), 1)
+	}
+	fmt.Fprintln(ctx.App.Writer, "OK")
+	return nil
+}
@@ -0,0 +1,278 @@
+package smartcontract
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sort"
+
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/urfave/cli"
+	"gopkg.in/yaml.v2"
+)
+
+// manifestCheck validates contract configuration and/or manifest files
+// reporting all problems found.
+func manifestCheck(ctx *cli.Context) error {
+	confFile := ctx.String("config")
+	manifestFile := ctx.String("manifest")
+	if confFile == "" && manifestFile == "" {
+		return cli.NewExitError(errors.New("no configuration or manifest file specified"), 1)
+	}
+	var (
+		problems []string
+		conf     *ProjectConfig
+		m        *manifest.Manifest
+	)
+	if confFile != "" {
+		data, err := ioutil.ReadFile(confFile)
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		var ps []string
+		conf, ps = checkContractConfig(data)
+		problems = append(problems, prefixProblems(confFile, ps)...)
+	}
+	if manifestFile != "" {
+		data, err := ioutil.ReadFile(manifestFile)
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		var ps []string
+		m, ps = checkManifest(data)
+		problems = append(problems, prefixProblems(manifestFile, ps)...)
+	}
+	if conf != nil && m != nil {
+		problems = append(problems, compareConfigWithManifest(conf, m)...)
+	}
+	for _, p := range problems {
+		fmt.Fprintln(ctx.App.Writer, p)
+	}
+	if len(problems) != 0 {
+		return cli.NewExitError(fmt.Errorf("%d problem(s) found", len(problems)), 1)
+	}
+	fmt.Fprintln(ctx.App.Writer, "OK")
+	return nil
+}
+
+func prefixProblems(file string, ps []string) []string {
+	for i := range ps {
+		ps[i] = file + ": " + ps[i]
+	}
+	return ps
+}
+
+// checkContractConfig strictly parses contract configuration file returning
+// the configuration (nil if it can't be parsed) and the list of problems
+// found.
+func checkContractConfig(data []byte) (*ProjectConfig, []string) {
+	conf := new(ProjectConfig)
+	if err := yaml.UnmarshalStrict(data, conf); err != nil {
+		return nil, []string{err.Error()}
+	}
+	var problems []string
+	if conf.Name == "" {
+		problems = append(problems, "contract name is not specified")
+	}
+	problems = append(problems, checkDuplicates("safe method", conf.SafeMethods)...)
+	problems = append(problems, checkDuplicates("supported standard", conf.SupportedStandards)...)
+	problems = append(problems, checkEvents(conf.Events)...)
+	if _, err := toManifestPermissions(conf.Permissions); err != nil {
+		problems = append(problems, err.Error())
+	}
+	return conf, problems
+}
+
+// checkManifest parses manifest rejecting unknown fields and returns it (nil
+// if it can't be parsed) with the list of problems found. Group signatures
+// are not checked, because contract hash is not known.
+func checkManifest(data []byte) (*manifest.Manifest, []string) {
+	m := new(manifest.Manifest)
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, []string{err.Error()}
+	}
+	var problems []string
+	// Unknown fields are detected by comparing the original data with the
+	// parsed manifest, nested objects use custom unmarshalers so
+	// json.Decoder.DisallowUnknownFields can't be used.
+	var orig, parsed interface{}
+	if err := json.Unmarshal(data, &orig); err != nil {
+		return nil, []string{err.Error()}
+	}
+	if err := roundTripJSON(m, &parsed); err != nil {
+		return nil, []string{err.Error()}
+	}
+	problems = append(problems, unknownJSONKeys("", orig, parsed)...)
+	if m.Name == "" {
+		problems = append(problems, "contract name is not specified")
+	}
+	problems = append(problems, checkDuplicates("supported standard", m.SupportedStandards)...)
+	if err := m.ABI.IsValid(); err != nil {
+		problems = append(problems, fmt.Sprintf("invalid ABI: %v", err))
+	}
+	if err := manifest.Permissions(m.Permissions).AreValid(); err != nil {
+		problems = append(problems, fmt.Sprintf("invalid permissions: %v", err))
+	}
+	return m, problems
+}
+
+func roundTripJSON(v interface{}, res interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	return d.Decode(res)
+}
+
+// unknownJSONKeys returns paths of object keys present in orig, but missing
+// in parsed.
+func unknownJSONKeys(path string, orig, parsed interface{}) []string {
+	var problems []string
+	switch o := orig.(type) {
+	case map[string]interface{}:
+		p, ok := parsed.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		keys := make([]string, 0, len(o))
+		for k := range o {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			pv, ok := p[k]
+			if !ok {
+				problems = append(problems, fmt.Sprintf("unknown field %q", path+k))
+				continue
+			}
+			problems = append(problems, unknownJSONKeys(path+k+".", o[k], pv)...)
+		}
+	case []interface{}:
+		p, ok := parsed.([]interface{})
+		if !ok || len(p) != len(o) {
+			return nil
+		}
+		for i := range o {
+			problems = append(problems, unknownJSONKeys(fmt.Sprintf("%s%d.", path, i), o[i], p[i])...)
+		}
+	}
+	return problems
+}
+
+func checkDuplicates(what string, ss []string) []string {
+	var (
+		problems []string
+		seen     = make(map[string]bool, len(ss))
+	)
+	for _, s := range ss {
+		if s == "" {
+			problems = append(problems, fmt.Sprintf("empty %s", what))
+			continue
+		}
+		if seen[s] {
+			problems = append(problems, fmt.Sprintf("duplicate %s %q", what, s))
+		}
+		seen[s] = true
+	}
+	return problems
+}
+
+func checkEvents(events []manifest.Event) []string {
+	var (
+		problems []string
+		seen     = make(map[string]bool, len(events))
+	)
+	for _, e := range events {
+		if e.Name == "" {
+			problems = append(problems, "event without name")
+			continue
+		}
+		if seen[e.Name] {
+			problems = append(problems, fmt.Sprintf("duplicate event %q", e.Name))
+		}
+		seen[e.Name] = true
+		params := make(map[string]bool, len(e.Parameters))
+		for _, p := range e.Parameters {
+			if p.Name == "" {
+				problems = append(problems, fmt.Sprintf("event %q has parameter without name", e.Name))
+				continue
+			}
+			if params[p.Name] {
+				problems = append(problems, fmt.Sprintf("event %q has duplicate parameter %q", e.Name, p.Name))
+			}
+			params[p.Name] = true
+		}
+	}
+	return problems
+}
+
+// compareConfigWithManifest reports mismatches between configuration and
+// manifest generated from it.
+func compareConfigWithManifest(conf *ProjectConfig, m *manifest.Manifest) []string {
+	var problems []string
+	if conf.Name != m.Name {
+		problems = append(problems, fmt.Sprintf("contract name mismatch: %q in config, %q in manifest", conf.Name, m.Name))
+	}
+	for _, e := range conf.Events {
+		me := m.ABI.GetEvent(e.Name)
+		if me == nil {
+			problems = append(problems, fmt.Sprintf("event %q is missing from manifest", e.Name))
+			continue
+		}
+		if len(me.Parameters) != len(e.Parameters) {
+			problems = append(problems, fmt.Sprintf("event %q has %d parameter(s) in config and %d in manifest",
+				e.Name, len(e.Parameters), len(me.Parameters)))
+			continue
+		}
+		for i := range e.Parameters {
+			if e.Parameters[i] != me.Parameters[i] {
+				problems = append(problems, fmt.Sprintf("event %q parameter #%d mismatch: %s %s in config, %s %s in manifest",
+					e.Name, i, e.Parameters[i].Name, e.Parameters[i].Type, me.Parameters[i].Name, me.Parameters[i].Type))
+			}
+		}
+	}
+	for _, me := range m.ABI.Events {
+		var found bool
+		for _, e := range conf.Events {
+			if e.Name == me.Name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			problems = append(problems, fmt.Sprintf("event %q is missing from config", me.Name))
+		}
+	}
+	for _, name := range conf.SafeMethods {
+		if md := m.ABI.GetMethod(name, -1); md == nil {
+			problems = append(problems, fmt.Sprintf("safe method %q is missing from manifest", name))
+		} else if !md.Safe {
+			problems = append(problems, fmt.Sprintf("method %q is not marked as safe in manifest", name))
+		}
+	}
+	ps, err := toManifestPermissions(conf.Permissions)
+	if err == nil && conf.Permissions != nil {
+		if !permissionsEqual(ps, m.Permissions) {
+			problems = append(problems, "permissions in config differ from manifest ones")
+		}
+	}
+	return problems
+}
+
+func permissionsEqual(a, b []manifest.Permission) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		da, errA := json.Marshal(a[i])
+		db, errB := json.Marshal(b[i])
+		if errA != nil || errB != nil || !bytes.Equal(da, db) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,309 @@
+package smartcontract
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/bigint"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/urfave/cli"
+)
+
+// decompiledInstruction is a single instruction of the decompiled script.
+type decompiledInstruction struct {
+	offset int
+	op     opcode.Opcode
+	param  []byte
+	// targets contains offsets this instruction can jump to.
+	targets []int
+}
+
+// decompiledMethod is a method boundary in the decompiled script.
+type decompiledMethod struct {
+	name  string
+	start int
+	end   int
+}
+
+func decompile(ctx *cli.Context) error {
+	in := ctx.String("in")
+	if len(in) == 0 {
+		return cli.NewExitError(errNoInput, 1)
+	}
+	f, err := ioutil.ReadFile(in)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to read .nef file: %w", err), 1)
+	}
+	nefFile, err := nef.FileFromBytes(f)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to restore .nef file: %w", err), 1)
+	}
+
+	var di *compiler.DebugInfo
+	if debugFile := ctx.String("debug"); debugFile != "" {
+		data, err := ioutil.ReadFile(debugFile)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("failed to read debug info: %w", err), 1)
+		}
+		di = new(compiler.DebugInfo)
+		if err := json.Unmarshal(data, di); err != nil {
+			return cli.NewExitError(fmt.Errorf("failed to restore debug info: %w", err), 1)
+		}
+	}
+	var m *manifest.Manifest
+	if manifestFile := ctx.String("manifest"); manifestFile != "" {
+		data, err := ioutil.ReadFile(manifestFile)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("failed to read manifest file: %w", err), 1)
+		}
+		m = new(manifest.Manifest)
+		if err := json.Unmarshal(data, m); err != nil {
+			return cli.NewExitError(fmt.Errorf("failed to restore manifest file: %w", err), 1)
+		}
+	}
+
+	if err := writeListing(ctx.App.Writer, nefFile.Script, di, m); err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	return nil
+}
+
+// writeListing writes annotated listing of the script to w. Method
+// boundaries are taken from debug info or manifest (if any), jump targets
+// are replaced with labels and source lines are printed before the code
+// they correspond to if debug info is provided.
+func writeListing(w io.Writer, script []byte, di *compiler.DebugInfo, m *manifest.Manifest) error {
+	instrs, err := decodeScript(script)
+	if err != nil {
+		return err
+	}
+	methods := listingMethods(di, m)
+	methodNames := make(map[int]string, len(methods))
+	for i := range methods {
+		methodNames[methods[i].start] = methods[i].name
+	}
+
+	var offsets []int
+	for i := range instrs {
+		for _, off := range instrs[i].targets {
+			// Zero TRY offset means there is no catch (or finally) block.
+			if off != instrs[i].offset || isJumpOpcode(instrs[i].op) {
+				offsets = append(offsets, off)
+			}
+		}
+	}
+	sort.Ints(offsets)
+	labels := make(map[int]string)
+	var labelCount int
+	for _, off := range offsets {
+		if _, ok := labels[off]; ok {
+			continue
+		}
+		if name, ok := methodNames[off]; ok {
+			labels[off] = name
+		} else {
+			labelCount++
+			labels[off] = fmt.Sprintf("L%d", labelCount)
+		}
+	}
+
+	var src *sourceFiles
+	seqPoints := make(map[int][]compiler.DebugSeqPoint)
+	if di != nil {
+		src = &sourceFiles{documents: di.Documents}
+		for i := range di.Methods {
+			for _, sp := range di.Methods[i].SeqPoints {
+				seqPoints[sp.Opcode] = append(seqPoints[sp.Opcode], sp)
+			}
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	for i := range instrs {
+		off := instrs[i].offset
+		for j := range methods {
+			if methods[j].start == off {
+				if methods[j].end != 0 {
+					fmt.Fprintf(bw, "\n; method %s [%d-%d]\n", methods[j].name, methods[j].start, methods[j].end)
+				} else {
+					fmt.Fprintf(bw, "\n; method %s\n", methods[j].name)
+				}
+			}
+		}
+		for _, sp := range seqPoints[off] {
+			fmt.Fprintf(bw, ";   %s\n", src.describe(sp))
+		}
+		if l, ok := labels[off]; ok && methodNames[off] != l {
+			fmt.Fprintf(bw, "%s:\n", l)
+		}
+		fmt.Fprintf(bw, "%-8d%-12s%s\n", off, instrs[i].op, describeParam(&instrs[i], labels))
+	}
+	return bw.Flush()
+}
+
+// decodeScript splits script into instructions and calculates jump targets.
+func decodeScript(script []byte) ([]decompiledInstruction, error) {
+	var res []decompiledInstruction
+	ctx := vm.NewContext(script)
+	for ctx.NextIP() < len(script) {
+		op, param, err := ctx.Next()
+		if err != nil {
+			return nil, fmt.Errorf("invalid instruction at offset %d: %w", ctx.IP(), err)
+		}
+		instr := decompiledInstruction{offset: ctx.IP(), op: op, param: param}
+		switch {
+		case isJumpOpcode(op):
+			instr.targets = []int{jumpTarget(instr.offset, param)}
+		case op == opcode.TRY:
+			instr.targets = []int{jumpTarget(instr.offset, param[:1]), jumpTarget(instr.offset, param[1:])}
+		case op == opcode.TRYL:
+			instr.targets = []int{jumpTarget(instr.offset, param[:4]), jumpTarget(instr.offset, param[4:])}
+		}
+		res = append(res, instr)
+	}
+	return res, nil
+}
+
+// isJumpOpcode returns true for instructions with a single offset parameter.
+func isJumpOpcode(op opcode.Opcode) bool {
+	return opcode.JMP <= op && op <= opcode.CALLL || op == opcode.PUSHA ||
+		op == opcode.ENDTRY || op == opcode.ENDTRYL
+}
+
+// jumpTarget returns absolute offset for relative offset parameter of
+// instruction at ip.
+func jumpTarget(ip int, param []byte) int {
+	var rOffset int32
+	if len(param) == 1 {
+		rOffset = int32(int8(param[0]))
+	} else {
+		rOffset = int32(binary.LittleEndian.Uint32(param))
+	}
+	return ip + int(rOffset)
+}
+
+// listingMethods returns methods in the order of their start offsets. Debug
+// info is preferred as it contains private methods as well.
+func listingMethods(di *compiler.DebugInfo, m *manifest.Manifest) []decompiledMethod {
+	var res []decompiledMethod
+	switch {
+	case di != nil:
+		for i := range di.Methods {
+			res = append(res, decompiledMethod{
+				name:  di.Methods[i].Name.Namespace + "." + di.Methods[i].ID,
+				start: int(di.Methods[i].Range.Start),
+				end:   int(di.Methods[i].Range.End),
+			})
+		}
+	case m != nil:
+		for i := range m.ABI.Methods {
+			res = append(res, decompiledMethod{
+				name:  m.ABI.Methods[i].Name,
+				start: m.ABI.Methods[i].Offset,
+			})
+		}
+	}
+	sort.SliceStable(res, func(i, j int) bool { return res[i].start < res[j].start })
+	return res
+}
+
+// describeParam returns human-readable instruction parameter using labels
+// for jump targets.
+func describeParam(instr *decompiledInstruction, labels map[int]string) string {
+	p := instr.param
+	if p == nil {
+		return ""
+	}
+	op := instr.op
+	switch {
+	case isJumpOpcode(op):
+		return labels[instr.targets[0]]
+	case op == opcode.TRY || op == opcode.TRYL:
+		desc := make([]string, 2)
+		for i, kind := range []string{"catch", "finally"} {
+			if instr.targets[i] == instr.offset {
+				desc[i] = "no " + kind
+			} else {
+				desc[i] = kind + " " + labels[instr.targets[i]]
+			}
+		}
+		return strings.Join(desc, ", ")
+	case op == opcode.SYSCALL:
+		name, err := interopnames.FromID(vm.GetInteropID(p))
+		if err != nil {
+			name = "unknown"
+		}
+		return fmt.Sprintf("%s (%x)", name, p)
+	case op <= opcode.PUSHINT256:
+		return bigint.FromBytes(p).String()
+	case op == opcode.CONVERT || op == opcode.ISTYPE || op == opcode.NEWARRAYT:
+		return stackitem.Type(p[0]).String()
+	case op == opcode.INITSLOT:
+		return fmt.Sprintf("%d local, %d arg", p[0], p[1])
+	case op == opcode.INITSSLOT, op == opcode.LDLOC, op == opcode.STLOC, op == opcode.LDARG,
+		op == opcode.STARG, op == opcode.LDSFLD, op == opcode.STSFLD:
+		return fmt.Sprint(p[0])
+	case op == opcode.CALLT:
+		return fmt.Sprint(binary.LittleEndian.Uint16(p))
+	case opcode.PUSHDATA1 <= op && op <= opcode.PUSHDATA4 && isPrintable(p):
+		return fmt.Sprintf("%x (%q)", p, p)
+	default:
+		return fmt.Sprintf("%x", p)
+	}
+}
+
+// isPrintable returns true if data is a non-empty valid UTF-8 string without
+// control characters.
+func isPrintable(data []byte) bool {
+	if len(data) == 0 || !utf8.Valid(data) {
+		return false
+	}
+	for _, r := range string(data) {
+		if r < ' ' {
+			return false
+		}
+	}
+	return true
+}
+
+// sourceFiles lazily reads source files referenced by debug info.
+type sourceFiles struct {
+	documents []string
+	lines     map[int][]string
+}
+
+// describe returns sequence point location along with the source line if
+// the file is available.
+func (s *sourceFiles) describe(sp compiler.DebugSeqPoint) string {
+	if sp.Document < 0 || sp.Document >= len(s.documents) {
+		return fmt.Sprintf("<unknown document %d>:%d", sp.Document, sp.StartLine)
+	}
+	loc := fmt.Sprintf("%s:%d", s.documents[sp.Document], sp.StartLine)
+	if s.lines == nil {
+		s.lines = make(map[int][]string)
+	}
+	lines, ok := s.lines[sp.Document]
+	if !ok {
+		if data, err := ioutil.ReadFile(s.documents[sp.Document]); err == nil {
+			lines = strings.Split(string(data), "\n")
+		}
+		s.lines[sp.Document] = lines
+	}
+	if sp.StartLine < 1 || sp.StartLine > len(lines) {
+		return loc
+	}
+	return loc + "  " + strings.TrimSpace(lines[sp.StartLine-1])
+}
@@ -0,0 +1,337 @@
+package smartcontract
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/cli/cmdargs"
+	"github.com/nspcc-dev/neo-go/cli/flags"
+	"github.com/nspcc-dev/neo-go/cli/options"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/client"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/urfave/cli"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// defaultDeployConfig is the deployment configuration file used when
+	// network is specified without '--deploy-config' flag.
+	defaultDeployConfig = "neo-go.deploy.yml"
+	// defaultDeployRecord is the file deployed contract hashes are saved to
+	// if deployment configuration doesn't specify any, it's relative to the
+	// configuration file.
+	defaultDeployRecord = "deployed.json"
+	// deployWaitTimeout is the maximum time to wait for the deployed contract
+	// to appear in the chain before saving it to the deployment record.
+	deployWaitTimeout = 2 * time.Minute
+	// deployPollInterval is the interval between deployed contract checks.
+	deployPollInterval = time.Second
+)
+
+var (
+	networkFlag = cli.StringFlag{
+		Name:  "network, n",
+		Usage: "network profile from deployment configuration to use (mainnet, testnet or custom one)",
+	}
+	deployConfigFlag = cli.StringFlag{
+		Name:  "deploy-config",
+		Usage: "deployment configuration file (" + defaultDeployConfig + " by default)",
+	}
+)
+
+// deployConfig is project-level deployment configuration.
+type deployConfig struct {
+	// Record is the file to save deployed contract hashes to.
+	Record   string                   `yaml:"record"`
+	Networks map[string]deployNetwork `yaml:"networks"`
+}
+
+// deployNetwork is a network profile used for deployment.
+type deployNetwork struct {
+	RPC     string `yaml:"rpc"`
+	Wallet  string `yaml:"wallet"`
+	Address string `yaml:"address"`
+	// Data is the 'data' parameter for '_deploy' method in CLI syntax.
+	Data []string `yaml:"data"`
+	// Invoke is the list of methods invoked after successful deployment.
+	Invoke []deployInvocation `yaml:"invoke"`
+}
+
+// deployInvocation is a post-deploy method invocation of the deployed contract.
+type deployInvocation struct {
+	Method string `yaml:"method"`
+	// Args are method parameters in CLI syntax.
+	Args []string `yaml:"args"`
+	// Signers are additional transaction signers in CLI syntax.
+	Signers []string `yaml:"signers"`
+}
+
+// deployRecord is the deployed contract information saved for the network.
+type deployRecord struct {
+	Hash   util.Uint160 `json:"hash"`
+	Sender util.Uint160 `json:"sender"`
+}
+
+// deployProfile is the network profile selected via '--network' flag.
+type deployProfile struct {
+	name   string
+	record string
+	deployNetwork
+}
+
+// getDeployProfile reads deployment configuration and returns the profile of
+// the network specified via '--network' flag (nil if it's not set). Profile
+// RPC endpoint, wallet and address are used for flags that are not set
+// explicitly.
+func getDeployProfile(ctx *cli.Context) (*deployProfile, error) {
+	name := ctx.String("network")
+	if name == "" {
+		return nil, nil
+	}
+	confFile := ctx.String("deploy-config")
+	if confFile == "" {
+		confFile = defaultDeployConfig
+	}
+	data, err := ioutil.ReadFile(confFile)
+	if err != nil {
+		return nil, cli.NewExitError(fmt.Errorf("can't read deployment configuration: %w", err), 1)
+	}
+	conf := new(deployConfig)
+	if err := yaml.UnmarshalStrict(data, conf); err != nil {
+		return nil, cli.NewExitError(fmt.Errorf("can't parse deployment configuration: %w", err), 1)
+	}
+	net, ok := conf.Networks[name]
+	if !ok {
+		return nil, cli.NewExitError(fmt.Errorf("network %q is not defined in %s", name, confFile), 1)
+	}
+	// Paths are relative to the configuration file, so that it can be used
+	// from any directory.
+	dir := filepath.Dir(confFile)
+	relPath := func(p string) string {
+		if p == "" || filepath.IsAbs(p) {
+			return p
+		}
+		return filepath.Join(dir, p)
+	}
+	if conf.Record == "" {
+		conf.Record = defaultDeployRecord
+	}
+	for flag, value := range map[string]string{
+		options.RPCEndpointFlag: net.RPC,
+		"wallet":                relPath(net.Wallet),
+		"address":               net.Address,
+	} {
+		if value == "" || ctx.IsSet(flag) {
+			continue
+		}
+		if err := ctx.Set(flag, value); err != nil {
+			return nil, cli.NewExitError(fmt.Errorf("invalid %s in %q network profile: %w", flag, name, err), 1)
+		}
+	}
+	return &deployProfile{
+		name:          name,
+		record:        relPath(conf.Record),
+		deployNetwork: net,
+	}, nil
+}
+
+// readDeployRecords reads deployment record file, non-existent file is
+// treated as an empty one.
+func readDeployRecords(file string) (map[string]deployRecord, error) {
+	records := make(map[string]deployRecord)
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return records, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("can't parse deployment record: %w", err)
+	}
+	return records, nil
+}
+
+// saveDeployRecord updates deployment record file with the contract
+// deployed to the profile network.
+func (p *deployProfile) saveDeployRecord(rec deployRecord) error {
+	records, err := readDeployRecords(p.record)
+	if err != nil {
+		return err
+	}
+	records[p.name] = rec
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(p.record, data, 0644)
+}
+
+// getDeployData parses 'data' parameter from the given arguments falling back
+// to the profile one if there are none. It returns the number of arguments
+// handled along with the parameter (nil if there is no data).
+func getDeployData(args []string, prof *deployProfile) (int, *smartcontract.Parameter, error) {
+	offset, data, err := cmdargs.ParseParams(args, true)
+	if err != nil {
+		return 0, nil, cli.NewExitError(fmt.Errorf("unable to parse 'data' parameter: %w", err), 1)
+	}
+	if len(data) == 0 && prof != nil && len(prof.Data) != 0 {
+		_, data, err = cmdargs.ParseParams(prof.Data, true)
+		if err != nil {
+			return 0, nil, cli.NewExitError(fmt.Errorf("unable to parse 'data' parameter of %q network profile: %w", prof.name, err), 1)
+		}
+	}
+	if len(data) > 1 {
+		return 0, nil, cli.NewExitError("'data' should be represented as a single parameter", 1)
+	}
+	if len(data) == 0 {
+		return offset, nil, nil
+	}
+	return offset, &data[0], nil
+}
+
+// readContractFiles reads and checks .nef and manifest files specified via
+// '--in' and '--manifest' flags.
+func readContractFiles(ctx *cli.Context) ([]byte, *nef.File, []byte, *manifest.Manifest, error) {
+	in := ctx.String("in")
+	if len(in) == 0 {
+		return nil, nil, nil, nil, cli.NewExitError(errNoInput, 1)
+	}
+	manifestFile := ctx.String("manifest")
+	if len(manifestFile) == 0 {
+		return nil, nil, nil, nil, cli.NewExitError(errNoManifestFile, 1)
+	}
+
+	f, err := ioutil.ReadFile(in)
+	if err != nil {
+		return nil, nil, nil, nil, cli.NewExitError(err, 1)
+	}
+	// Check the file.
+	nefFile, err := nef.FileFromBytes(f)
+	if err != nil {
+		return nil, nil, nil, nil, cli.NewExitError(fmt.Errorf("failed to read .nef file: %w", err), 1)
+	}
+
+	manifestBytes, err := ioutil.ReadFile(manifestFile)
+	if err != nil {
+		return nil, nil, nil, nil, cli.NewExitError(fmt.Errorf("failed to read manifest file: %w", err), 1)
+	}
+	m := &manifest.Manifest{}
+	err = json.Unmarshal(manifestBytes, m)
+	if err != nil {
+		return nil, nil, nil, nil, cli.NewExitError(fmt.Errorf("failed to restore manifest file: %w", err), 1)
+	}
+	return f, &nefFile, manifestBytes, m, nil
+}
+
+// postDeploy waits for the deployed contract to be accepted by the chain,
+// saves it to the profile record and performs profile post-deploy
+// invocations. Nothing is saved if the contract doesn't appear in the chain,
+// so failed deployments don't override the previous record.
+func (p *deployProfile) postDeploy(ctx *cli.Context, c *client.Client, acc *wallet.Account, wall *wallet.Wallet, rec deployRecord) error {
+	if err := waitContract(c, rec.Hash); err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	if err := p.saveDeployRecord(rec); err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to save deployment record: %w", err), 1)
+	}
+	for i, inv := range p.Invoke {
+		if inv.Method == "" {
+			return cli.NewExitError(fmt.Errorf("post-deploy invocation #%d: no method specified", i), 1)
+		}
+		_, params, err := cmdargs.ParseParams(inv.Args, true)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("post-deploy invocation #%d: %w", i, err), 1)
+		}
+		signers, err := cmdargs.ParseSigners(inv.Signers)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("post-deploy invocation #%d: %w", i, err), 1)
+		}
+		if _, err := invokeWithAccount(ctx, acc, wall, rec.Hash, inv.Method, params, signers); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// waitContract waits for the contract with the specified hash to be deployed.
+func waitContract(c *client.Client, h util.Uint160) error {
+	deadline := time.Now().Add(deployWaitTimeout)
+	for {
+		_, err := c.GetContractStateByHash(h)
+		if err == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("contract %s is not deployed after %s: %w", h.StringLE(), deployWaitTimeout, err)
+		}
+		time.Sleep(deployPollInterval)
+	}
+}
+
+// contractUpdate updates deployed contract via its 'update' method.
+func contractUpdate(ctx *cli.Context) error {
+	prof, err := getDeployProfile(ctx)
+	if err != nil {
+		return err
+	}
+	f, _, manifestBytes, _, err := readContractFiles(ctx)
+	if err != nil {
+		return err
+	}
+
+	var hash util.Uint160
+	hashFlag := ctx.Generic("hash").(*flags.Address)
+	switch {
+	case hashFlag.IsSet:
+		hash = hashFlag.Uint160()
+	case prof != nil:
+		records, err := readDeployRecords(prof.record)
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		rec, ok := records[prof.name]
+		if !ok {
+			return cli.NewExitError(fmt.Errorf("no contract deployed to %q network in %s", prof.name, prof.record), 1)
+		}
+		hash = rec.Hash
+	default:
+		return cli.NewExitError(errors.New("no contract specified, use '--hash' or '--network' flag"), 1)
+	}
+
+	appCallParams := []smartcontract.Parameter{
+		{
+			Type:  smartcontract.ByteArrayType,
+			Value: f,
+		},
+		{
+			Type:  smartcontract.ByteArrayType,
+			Value: manifestBytes,
+		},
+	}
+	offset, data, err := getDeployData(ctx.Args(), prof)
+	if err != nil {
+		return err
+	}
+	if data != nil {
+		appCallParams = append(appCallParams, *data)
+	}
+	cosigners, exitErr := cmdargs.GetSignersFromContext(ctx, offset)
+	if exitErr != nil {
+		return exitErr
+	}
+	if _, err := invokeWithArgs(ctx, true, hash, "update", appCallParams, cosigners); err != nil {
+		return err
+	}
+	fmt.Fprintf(ctx.App.Writer, "Contract: %s\n", hash.StringLE())
+	return nil
+}
@@ -0,0 +1,295 @@
+package smartcontract
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/urfave/cli"
+)
+
+// storageKeyWindow is the number of instructions before storage syscall
+// which are checked for constant keys.
+const storageKeyWindow = 4
+
+// maxStorageKeyLen is the maximum length of constant considered to be a
+// storage key (or prefix).
+const maxStorageKeyLen = 64
+
+// contractVersion is a contract state to be compared.
+type contractVersion struct {
+	nef      *nef.File
+	manifest *manifest.Manifest
+}
+
+// contractChanges is a set of changes in a single section of the report.
+type contractChanges struct {
+	title   string
+	changes []string
+}
+
+func contractDiff(ctx *cli.Context) error {
+	args := ctx.Args()
+	if len(args) != 2 {
+		return cli.NewExitError(errors.New("old and new .nef files should be provided"), 1)
+	}
+	oldV, err := readContractVersion(args[0], ctx.String("old-manifest"))
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	newV, err := readContractVersion(args[1], ctx.String("new-manifest"))
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	report, err := diffContracts(oldV, newV)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	writeDiffReport(ctx.App.Writer, report)
+	return nil
+}
+
+// readContractVersion reads NEF file and manifest. If manifest path is not
+// specified, a file with the same name as NEF and '.manifest.json' extension
+// is used.
+func readContractVersion(nefPath, manifestPath string) (*contractVersion, error) {
+	data, err := ioutil.ReadFile(nefPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read .nef file: %w", err)
+	}
+	nefFile, err := nef.FileFromBytes(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore .nef file %s: %w", nefPath, err)
+	}
+	if manifestPath == "" {
+		manifestPath = strings.TrimSuffix(nefPath, ".nef") + ".manifest.json"
+	}
+	data, err = ioutil.ReadFile(manifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest file: %w", err)
+	}
+	m := new(manifest.Manifest)
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("failed to restore manifest file %s: %w", manifestPath, err)
+	}
+	return &contractVersion{nef: &nefFile, manifest: m}, nil
+}
+
+// diffContracts returns all changes between old and new contract versions,
+// sections without changes are omitted.
+func diffContracts(oldV, newV *contractVersion) ([]contractChanges, error) {
+	var report []contractChanges
+	add := func(title string, changes []string) {
+		if len(changes) != 0 {
+			report = append(report, contractChanges{title: title, changes: changes})
+		}
+	}
+	om, nm := oldV.manifest, newV.manifest
+
+	var general []string
+	if om.Name != nm.Name {
+		general = append(general, fmt.Sprintf("* name: %s -> %s", om.Name, nm.Name))
+	}
+	if !bytes.Equal(oldV.nef.Script, newV.nef.Script) {
+		general = append(general, fmt.Sprintf("* script: %d bytes -> %d bytes", len(oldV.nef.Script), len(newV.nef.Script)))
+	}
+	if oldV.nef.Compiler != newV.nef.Compiler {
+		general = append(general, fmt.Sprintf("* compiler: %s -> %s", oldV.nef.Compiler, newV.nef.Compiler))
+	}
+	add("General", general)
+
+	add("Methods", diffMethods(om.ABI.Methods, nm.ABI.Methods))
+	add("Events", diffEvents(om.ABI.Events, nm.ABI.Events))
+	add("Permissions", diffPermissions(om.Permissions, nm.Permissions))
+	add("Supported standards", diffStrings(om.SupportedStandards, nm.SupportedStandards))
+	add("Trusts", diffStrings(trustsStrings(om.Trusts), trustsStrings(nm.Trusts)))
+
+	oldKeys, err := storageKeys(oldV.nef.Script)
+	if err != nil {
+		return nil, fmt.Errorf("old contract: %w", err)
+	}
+	newKeys, err := storageKeys(newV.nef.Script)
+	if err != nil {
+		return nil, fmt.Errorf("new contract: %w", err)
+	}
+	add("Storage keys (hints)", diffStrings(oldKeys, newKeys))
+	return report, nil
+}
+
+// writeDiffReport writes human-readable report to w.
+func writeDiffReport(w io.Writer, report []contractChanges) {
+	if len(report) == 0 {
+		fmt.Fprintln(w, "No changes")
+		return
+	}
+	for i := range report {
+		fmt.Fprintf(w, "%s:\n", report[i].title)
+		for _, c := range report[i].changes {
+			fmt.Fprintf(w, "  %s\n", c)
+		}
+	}
+}
+
+// methodSignature returns method signature in `name(p Type) Type` format.
+func methodSignature(m *manifest.Method) string {
+	s := m.Name + "(" + parametersString(m.Parameters) + ") " + m.ReturnType.String()
+	if m.Safe {
+		s += " (safe)"
+	}
+	return s
+}
+
+func parametersString(ps []manifest.Parameter) string {
+	params := make([]string, len(ps))
+	for i := range ps {
+		params[i] = ps[i].Name + " " + ps[i].Type.String()
+	}
+	return strings.Join(params, ", ")
+}
+
+// diffMethods compares methods with the same name and number of parameters,
+// changed offsets are not reported as they're expected to change with code.
+func diffMethods(om, nm []manifest.Method) []string {
+	key := func(m *manifest.Method) string { return fmt.Sprintf("%s/%d", m.Name, len(m.Parameters)) }
+	oldS := make(map[string]string, len(om))
+	newS := make(map[string]string, len(nm))
+	for i := range om {
+		oldS[key(&om[i])] = methodSignature(&om[i])
+	}
+	for i := range nm {
+		newS[key(&nm[i])] = methodSignature(&nm[i])
+	}
+	return diffSignatures(oldS, newS)
+}
+
+func diffEvents(oe, ne []manifest.Event) []string {
+	oldS := make(map[string]string, len(oe))
+	newS := make(map[string]string, len(ne))
+	for i := range oe {
+		oldS[oe[i].Name] = oe[i].Name + "(" + parametersString(oe[i].Parameters) + ")"
+	}
+	for i := range ne {
+		newS[ne[i].Name] = ne[i].Name + "(" + parametersString(ne[i].Parameters) + ")"
+	}
+	return diffSignatures(oldS, newS)
+}
+
+func diffPermissions(op, np []manifest.Permission) []string {
+	toMap := func(ps []manifest.Permission) map[string]string {
+		res := make(map[string]string, len(ps))
+		for i := range ps {
+			c, _ := ps[i].Contract.MarshalJSON()
+			ms, _ := ps[i].Methods.MarshalJSON()
+			res[string(c)] = string(c) + ": " + string(ms)
+		}
+		return res
+	}
+	return diffSignatures(toMap(op), toMap(np))
+}
+
+// diffSignatures compares two sets of items identified by keys, items present
+// only in one of them are reported as added or removed and items with the
+// same key, but different description as changed.
+func diffSignatures(oldS, newS map[string]string) []string {
+	var res []string
+	for _, k := range sortedKeys(oldS) {
+		n, ok := newS[k]
+		switch {
+		case !ok:
+			res = append(res, "- "+oldS[k])
+		case n != oldS[k]:
+			res = append(res, fmt.Sprintf("* %s -> %s", oldS[k], n))
+		}
+	}
+	for _, k := range sortedKeys(newS) {
+		if _, ok := oldS[k]; !ok {
+			res = append(res, "+ "+newS[k])
+		}
+	}
+	return res
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func diffStrings(oldS, newS []string) []string {
+	toMap := func(ss []string) map[string]string {
+		res := make(map[string]string, len(ss))
+		for _, s := range ss {
+			res[s] = s
+		}
+		return res
+	}
+	return diffSignatures(toMap(oldS), toMap(newS))
+}
+
+func trustsStrings(ts manifest.WildUint160s) []string {
+	if ts.IsWildcard() {
+		return []string{"*"}
+	}
+	res := make([]string, len(ts.Value))
+	for i := range ts.Value {
+		res[i] = "0x" + ts.Value[i].StringLE()
+	}
+	return res
+}
+
+// storageKeys returns constants pushed right before storage syscalls (storage
+// context is usually pushed after the key), they are likely to be storage
+// keys or key prefixes. This is a heuristic, keys
+// calculated at runtime can't be found this way.
+func storageKeys(script []byte) ([]string, error) {
+	instrs, err := decodeScript(script)
+	if err != nil {
+		return nil, err
+	}
+	storageIDs := make(map[uint32]bool)
+	for _, name := range []string{interopnames.SystemStorageGet, interopnames.SystemStoragePut,
+		interopnames.SystemStorageDelete, interopnames.SystemStorageFind} {
+		storageIDs[interopnames.ToID([]byte(name))] = true
+	}
+	seen := make(map[string]bool)
+	var res []string
+	for i := range instrs {
+		if instrs[i].op != opcode.SYSCALL || !storageIDs[vm.GetInteropID(instrs[i].param)] {
+			continue
+		}
+		for j := i - 1; j >= 0 && j >= i-storageKeyWindow; j-- {
+			op, data := instrs[j].op, instrs[j].param
+			if op < opcode.PUSHDATA1 || op > opcode.PUSHDATA4 {
+				continue
+			}
+			if len(data) == 0 || len(data) > maxStorageKeyLen {
+				break
+			}
+			var s string
+			if isPrintable(data) {
+				s = fmt.Sprintf("%q", data)
+			} else {
+				s = fmt.Sprintf("0x%x", data)
+			}
+			if !seen[s] {
+				seen[s] = true
+				res = append(res, s)
+			}
+			break
+		}
+	}
+	return res, nil
+}
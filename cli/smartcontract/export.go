@@ -0,0 +1,220 @@
+package smartcontract
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/urfave/cli"
+)
+
+// exportedABI is contract ABI description in the format used by neon-js and
+// neow3j (a subset of manifest).
+type exportedABI struct {
+	Name               string       `json:"name"`
+	SupportedStandards []string     `json:"supportedstandards"`
+	ABI                manifest.ABI `json:"abi"`
+}
+
+// tsTypes maps contract parameter types to TypeScript types declared in
+// tsHeader.
+var tsTypes = map[smartcontract.ParamType]string{
+	smartcontract.AnyType:              "any",
+	smartcontract.BoolType:             "boolean",
+	smartcontract.IntegerType:          "bigint",
+	smartcontract.ByteArrayType:        "ByteArray",
+	smartcontract.StringType:           "string",
+	smartcontract.Hash160Type:          "Hash160",
+	smartcontract.Hash256Type:          "Hash256",
+	smartcontract.PublicKeyType:        "PublicKey",
+	smartcontract.SignatureType:        "Signature",
+	smartcontract.ArrayType:            "any[]",
+	smartcontract.MapType:              "Map<any, any>",
+	smartcontract.InteropInterfaceType: "InteropInterface",
+	smartcontract.VoidType:             "void",
+}
+
+// tsReserved contains TypeScript reserved words which can't be used as
+// parameter names.
+var tsReserved = map[string]bool{
+	"break": true, "case": true, "catch": true, "class": true, "const": true,
+	"continue": true, "debugger": true, "default": true, "delete": true,
+	"do": true, "else": true, "enum": true, "export": true, "extends": true,
+	"false": true, "finally": true, "for": true, "function": true, "if": true,
+	"import": true, "in": true, "instanceof": true, "new": true, "null": true,
+	"return": true, "super": true, "switch": true, "this": true, "throw": true,
+	"true": true, "try": true, "typeof": true, "var": true, "void": true,
+	"while": true, "with": true,
+}
+
+const tsHeader = `// Code generated by neo-go contract export-abi. DO NOT EDIT.
+
+/** Hex-encoded byte string. */
+export type ByteArray = string;
+/** Script hash in 0x-prefixed little-endian hex form. */
+export type Hash160 = string;
+/** Hash in 0x-prefixed little-endian hex form. */
+export type Hash256 = string;
+/** Hex-encoded compressed public key. */
+export type PublicKey = string;
+/** Hex-encoded signature. */
+export type Signature = string;
+/** Opaque interop item (like iterator) returned by the contract. */
+export type InteropInterface = unknown;
+`
+
+func exportABI(ctx *cli.Context) error {
+	manifestFile := ctx.String("manifest")
+	if manifestFile == "" {
+		return cli.NewExitError(errNoManifestFile, 1)
+	}
+	out, tsOut := ctx.String("out"), ctx.String("ts")
+	if out == "" && tsOut == "" {
+		return cli.NewExitError(errors.New("no output file was specified, use '--out' and/or '--ts' flags"), 1)
+	}
+	data, err := ioutil.ReadFile(manifestFile)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to read manifest file: %w", err), 1)
+	}
+	m := new(manifest.Manifest)
+	if err := json.Unmarshal(data, m); err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to restore manifest file: %w", err), 1)
+	}
+	var di *compiler.DebugInfo
+	if debugFile := ctx.String("debug"); debugFile != "" {
+		data, err := ioutil.ReadFile(debugFile)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("failed to read debug info: %w", err), 1)
+		}
+		di = new(compiler.DebugInfo)
+		if err := json.Unmarshal(data, di); err != nil {
+			return cli.NewExitError(fmt.Errorf("failed to restore debug info: %w", err), 1)
+		}
+	}
+
+	if out != "" {
+		data, err := json.MarshalIndent(exportedABI{
+			Name:               m.Name,
+			SupportedStandards: m.SupportedStandards,
+			ABI:                m.ABI,
+		}, "", "  ")
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		if err := ioutil.WriteFile(out, data, os.ModePerm); err != nil {
+			return cli.NewExitError(err, 1)
+		}
+	}
+	if tsOut != "" {
+		if err := ioutil.WriteFile(tsOut, []byte(typeScriptTypings(m, di)), os.ModePerm); err != nil {
+			return cli.NewExitError(err, 1)
+		}
+	}
+	return nil
+}
+
+// typeScriptTypings returns TypeScript declarations for the contract methods
+// and events. Debug info (if any) is used to reference Go functions in
+// comments.
+func typeScriptTypings(m *manifest.Manifest, di *compiler.DebugInfo) string {
+	goNames := make(map[string]string)
+	if di != nil {
+		for i := range di.Methods {
+			mi := &di.Methods[i]
+			goNames[fmt.Sprintf("%s/%d", mi.Name.Name, len(mi.Parameters))] = mi.Name.Namespace + "." + mi.ID
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(tsHeader)
+	name := tsIdentifier(m.Name, true)
+	for i := range m.ABI.Events {
+		e := &m.ABI.Events[i]
+		fmt.Fprintf(&b, "\n/** Parameters of '%s' event. */\n", e.Name)
+		fmt.Fprintf(&b, "export interface %s%sEvent {\n", name, tsIdentifier(e.Name, true))
+		for j, p := range e.Parameters {
+			fmt.Fprintf(&b, "  %s: %s;\n", tsParamName(p.Name, j), tsType(p.Type))
+		}
+		b.WriteString("}\n")
+	}
+
+	fmt.Fprintf(&b, "\n/** Methods of '%s' contract. */\n", m.Name)
+	fmt.Fprintf(&b, "export interface %sContract {\n", name)
+	for i := range m.ABI.Methods {
+		md := &m.ABI.Methods[i]
+		var notes []string
+		if g, ok := goNames[fmt.Sprintf("%s/%d", md.Name, len(md.Parameters))]; ok {
+			notes = append(notes, "Go: "+g)
+		}
+		if md.Safe {
+			notes = append(notes, "safe")
+		}
+		if len(notes) != 0 {
+			fmt.Fprintf(&b, "  /** %s */\n", strings.Join(notes, ", "))
+		}
+		params := make([]string, len(md.Parameters))
+		for j, p := range md.Parameters {
+			params[j] = tsParamName(p.Name, j) + ": " + tsType(p.Type)
+		}
+		fmt.Fprintf(&b, "  %s(%s): Promise<%s>;\n", tsMethodName(md.Name), strings.Join(params, ", "), tsType(md.ReturnType))
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func tsType(t smartcontract.ParamType) string {
+	if s, ok := tsTypes[t]; ok {
+		return s
+	}
+	return "any"
+}
+
+// tsIdentifier converts s to a valid TypeScript identifier dropping
+// non-alphanumeric characters, words are capitalized if exported is true.
+func tsIdentifier(s string, exported bool) string {
+	var b strings.Builder
+	upper := exported
+	for _, r := range s {
+		if r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_') {
+			upper = exported
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	res := b.String()
+	if res == "" || unicode.IsDigit(rune(res[0])) {
+		res = "_" + res
+	}
+	return res
+}
+
+func tsParamName(s string, i int) string {
+	if s == "" {
+		return fmt.Sprintf("arg%d", i)
+	}
+	s = tsIdentifier(s, false)
+	if tsReserved[s] {
+		s += "_"
+	}
+	return s
+}
+
+// tsMethodName returns method name usable in interface declaration, names
+// which are not valid identifiers are quoted.
+func tsMethodName(s string) string {
+	if tsIdentifier(s, false) == s {
+		return s
+	}
+	return fmt.Sprintf("%q", s)
+}
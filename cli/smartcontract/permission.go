@@ -0,0 +1,57 @@
+package smartcontract
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+)
+
+// permission is a contract permission as specified in the configuration file.
+// Contract is either "*", contract hash or group public key, Methods is
+// either "*" or a list of method names.
+type permission struct {
+	Contract string      `yaml:"contract"`
+	Methods  interface{} `yaml:"methods"`
+}
+
+// toManifestPermissions converts permissions from the configuration file to
+// manifest permissions.
+func toManifestPermissions(ps []permission) ([]manifest.Permission, error) {
+	if ps == nil {
+		return nil, nil
+	}
+	res := make([]manifest.Permission, len(ps))
+	for i := range ps {
+		data, err := json.Marshal(map[string]interface{}{
+			"contract": ps[i].Contract,
+			"methods":  ps[i].Methods,
+		})
+		if err == nil {
+			err = json.Unmarshal(data, &res[i])
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid permission #%d: %w", i, err)
+		}
+	}
+	if err := manifest.Permissions(res).AreValid(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// fromManifestPermissions converts manifest permissions to the form used in
+// the configuration file.
+func fromManifestPermissions(ps []manifest.Permission) []permission {
+	res := make([]permission, len(ps))
+	for i := range ps {
+		data, _ := ps[i].Contract.MarshalJSON()
+		_ = json.Unmarshal(data, &res[i].Contract)
+		if ps[i].Methods.IsWildcard() {
+			res[i].Methods = "*"
+		} else {
+			res[i].Methods = ps[i].Methods.Value
+		}
+	}
+	return res
+}
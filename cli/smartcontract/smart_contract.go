@@ -112,6 +112,8 @@ func NewCommands() []cli.Command {
 			Name:  "manifest, m",
 			Usage: "Manifest input file (*.manifest.json)",
 		},
+		networkFlag,
+		deployConfigFlag,
 	}...)
 	return []cli.Command{{
 		Name:  "contract",
@@ -150,23 +152,61 @@ func NewCommands() []cli.Command {
 						Name:  "no-standards",
 						Usage: "do not check compliance with supported standards",
 					},
+					cli.BoolFlag{
+						Name:  "check-standards",
+						Usage: "report all problems of compliance with supported standards (even if manifest is not emitted)",
+					},
 					cli.BoolFlag{
 						Name:  "no-events",
 						Usage: "do not check emitted events with the manifest",
 					},
+					cli.BoolFlag{
+						Name:  "no-optimize",
+						Usage: "do not optimize generated bytecode",
+					},
+					cli.BoolFlag{
+						Name:  "no-permissions",
+						Usage: "do not check that permissions allow all contract calls",
+					},
+					cli.BoolFlag{
+						Name:  "suggest-permissions",
+						Usage: "print permissions needed for contract calls and warn about unnecessary ones",
+					},
 				},
 			},
 			{
 				Name:      "deploy",
 				Usage:     "deploy a smart contract (.nef with description)",
-				UsageText: "neo-go contract deploy -r endpoint -w wallet [-a address] [-g gas] --in contract.nef --manifest contract.manifest.json [--out file] [--force] [data]",
+				UsageText: "neo-go contract deploy -r endpoint -w wallet [-a address] [-g gas] [--network name [--deploy-config file]] --in contract.nef --manifest contract.manifest.json [--out file] [--force] [data]",
 				Description: `Deploys given contract into the chain. The gas parameter is for additional
    gas to be added as a network fee to prioritize the transaction. The data 
    parameter is an optional parameter to be passed to '_deploy' method.
+
+   If network is specified, its profile from the deployment configuration
+   (neo-go.deploy.yml by default) provides RPC endpoint, wallet, address and
+   data parameter unless they're given explicitly. Deployed contract hash is
+   then saved to the deployment record (for later 'update' command use) and
+   post-deploy invocations from the profile are performed.
 `,
 				Action: contractDeploy,
 				Flags:  deployFlags,
 			},
+			{
+				Name:      "update",
+				Usage:     "update deployed smart contract (.nef with description)",
+				UsageText: "neo-go contract update -r endpoint -w wallet [-a address] [-g gas] {--hash hash | --network name [--deploy-config file]} --in contract.nef --manifest contract.manifest.json [--out file] [--force] [data] [--] [signers...]",
+				Description: `Updates deployed contract by invoking its 'update' method with the given
+   .nef file, manifest and optional data parameter. Contract is specified either
+   by its hash or by the network profile, in which case the hash is taken from
+   the deployment record saved by 'deploy' command. Signers are specified the
+   same way as for invokefunction command.
+`,
+				Action: contractUpdate,
+				Flags: append(deployFlags, flags.AddressFlag{
+					Name:  "hash",
+					Usage: "hash of the contract to update",
+				}),
+			},
 			{
 				Name:      "invokefunction",
 				Usage:     "invoke deployed contract on the blockchain",
@@ -333,6 +373,112 @@ func NewCommands() []cli.Command {
 					},
 				},
 			},
+			{
+				Name:      "decompile",
+				Usage:     "creates an annotated listing of the contract code",
+				UsageText: "neo-go contract decompile -i contract.nef [-m contract.manifest.json] [-d contract.debug.json]",
+				Description: `Prints contract instructions split by methods with jump targets
+   replaced by labels. Method boundaries are taken from the debug info file
+   (if provided) or from the manifest. If debug info is provided, source
+   lines are printed before the code generated for them (source files are
+   looked up using paths from the debug info).
+`,
+				Action: decompile,
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "in, i",
+						Usage: "input .nef file",
+					},
+					cli.StringFlag{
+						Name:  "manifest, m",
+						Usage: "contract manifest (*.manifest.json) file",
+					},
+					cli.StringFlag{
+						Name:  "debug, d",
+						Usage: "contract debug info file",
+					},
+				},
+			},
+			{
+				Name:      "diff",
+				Usage:     "compares two versions of a contract",
+				UsageText: "neo-go contract diff [--old-manifest file] [--new-manifest file] old.nef new.nef",
+				Description: `Reports changes between old and new contract versions: method
+   signatures, events, permissions, supported standards and trusts. Storage
+   keys are hinted using constants pushed before storage syscalls, keys
+   calculated at runtime can't be detected. If manifest is not specified,
+   it's looked for next to the corresponding .nef file with the
+   '.manifest.json' extension.
+`,
+				Action: contractDiff,
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "old-manifest",
+						Usage: "manifest of the old contract version",
+					},
+					cli.StringFlag{
+						Name:  "new-manifest",
+						Usage: "manifest of the new contract version",
+					},
+				},
+			},
+			{
+				Name:      "export-abi",
+				Usage:     "exports contract ABI for use with JavaScript/TypeScript and Java SDKs",
+				UsageText: "neo-go contract export-abi -m contract.manifest.json [-d contract.debug.json] [-o contract.abi.json] [--ts contract.d.ts]",
+				Description: `Converts contract manifest into ABI description compatible with neon-js
+   and neow3j (--out) and TypeScript declarations of contract methods and
+   events (--ts). Debug info is optional and is used to reference Go
+   functions implementing contract methods in generated comments.
+`,
+				Action: exportABI,
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "manifest, m",
+						Usage: "contract manifest (*.manifest.json) file",
+					},
+					cli.StringFlag{
+						Name:  "debug, d",
+						Usage: "contract debug info file",
+					},
+					cli.StringFlag{
+						Name:  "out, o",
+						Usage: "output ABI (JSON) file",
+					},
+					cli.StringFlag{
+						Name:  "ts",
+						Usage: "output TypeScript declarations file",
+					},
+				},
+			},
+			{
+				Name:  "manifest",
+				Usage: "manifest-related commands",
+				Subcommands: []cli.Command{
+					{
+						Name:      "check",
+						Usage:     "strictly validate contract configuration and manifest files",
+						UsageText: "neo-go contract manifest check [-c contract.yml] [-m contract.manifest.json]",
+						Description: `Checks contract configuration and/or manifest files reporting unknown
+   fields, invalid values, duplicate or malformed events and permissions. If
+   both files are given, the manifest is also checked to match the
+   configuration (name, events, safe methods and permissions). Group
+   signatures are not checked.
+`,
+						Action: manifestCheck,
+						Flags: []cli.Flag{
+							cli.StringFlag{
+								Name:  "config, c",
+								Usage: "configuration input file (*.yml)",
+							},
+							cli.StringFlag{
+								Name:  "manifest, m",
+								Usage: "contract manifest (*.manifest.json) file",
+							},
+						},
+					},
+				},
+			},
 			{
 				Name:   "calc-hash",
 				Usage:  "calculates hash of a contract after deployment",
@@ -352,6 +498,94 @@ func NewCommands() []cli.Command {
 					},
 				},
 			},
+			{
+				Name:      "verify-source",
+				Usage:     "verify that contract source matches the deployed contract",
+				UsageText: "neo-go contract verify-source -r endpoint --hash hash -i path [--out file]",
+				Description: `Compiles given contract source (Go file or directory) with the current
+   compiler version and compares the resulting NEF with the one of the deployed
+   contract. Verification attestation (JSON with source file hashes, compiler
+   versions and NEF checksums) is printed or saved to the file specified with
+   '--out'. The command fails if the source doesn't match the contract, note
+   that the contract must be compiled by the same compiler version to be
+   verified.
+`,
+				Action: verifySource,
+				Flags: append([]cli.Flag{
+					flags.AddressFlag{
+						Name:  "hash",
+						Usage: "hash of the deployed contract",
+					},
+					cli.StringFlag{
+						Name:  "in, i",
+						Usage: "contract source file or directory",
+					},
+					cli.StringFlag{
+						Name:  "out",
+						Usage: "file to save attestation to",
+					},
+				}, options.RPC...),
+			},
+			{
+				Name:      "analyze",
+				Usage:     "check contract source for common vulnerabilities",
+				UsageText: "neo-go contract analyze -i path [--severity low|medium|high]",
+				Description: `Analyzes given contract source (Go file or directory) and reports potential
+   vulnerabilities found in exported methods (and functions they call):
+     * unchecked-witness: token transfer without preceding witness check (high)
+     * unprotected-update: contract update or destruction without preceding
+       witness check (high)
+     * reentrancy: storage modified after external call or transfer (which
+       invokes recipient's onNEP17Payment), external call from payment
+       callback (medium)
+     * unbounded-iteration: storage iteration without any bound (medium)
+     * ignored-witness: witness check with result not used in any condition,
+       return or panic (low)
+   Witness checks are only counted for unchecked-witness and
+   unprotected-update if their result is used in a condition, returned or
+   passed to panic.
+   Findings are printed sorted by severity, '--severity' flag makes the command
+   ignore ones below the level specified. Analysis is heuristic, so findings
+   are to be reviewed manually. The command fails if there are any findings.
+`,
+				Action: contractAnalyze,
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "in, i",
+						Usage: "contract source file or directory",
+					},
+					cli.StringFlag{
+						Name:  "severity",
+						Usage: "minimum severity of findings to report (low, medium or high)",
+					},
+				},
+			},
+			{
+				Name:      "verify-build",
+				Usage:     "reproduce contract build using debug info metadata",
+				UsageText: "neo-go contract verify-build -i path --debug file [--nef file]",
+				Description: `Compiles given contract source (Go file or directory) with the build flags
+   from debug info build metadata and checks that the same compiler version
+   and source files are used and the NEF with the same checksum is produced.
+   If '--nef' is given, the NEF file is also compared with the compiled one.
+   All mismatches found are printed, the command fails if there are any.
+`,
+				Action: verifyBuild,
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "in, i",
+						Usage: "contract source file or directory",
+					},
+					cli.StringFlag{
+						Name:  "debug, d",
+						Usage: "debug info file with build metadata",
+					},
+					cli.StringFlag{
+						Name:  "nef",
+						Usage: "NEF file to compare with",
+					},
+				},
+			},
 		},
 	}}
 }
@@ -419,7 +653,7 @@ func contractCompile(ctx *cli.Context) error {
 	manifestFile := ctx.String("manifest")
 	confFile := ctx.String("config")
 	debugFile := ctx.String("debug")
-	if len(confFile) == 0 && (len(manifestFile) != 0 || len(debugFile) != 0) {
+	if len(confFile) == 0 && (len(manifestFile) != 0 || len(debugFile) != 0 || ctx.Bool("check-standards")) {
 		return cli.NewExitError(errNoConfFile, 1)
 	}
 
@@ -430,7 +664,11 @@ func contractCompile(ctx *cli.Context) error {
 		ManifestFile: manifestFile,
 
 		NoStandardCheck: ctx.Bool("no-standards"),
+		CheckStandards:  ctx.Bool("check-standards"),
 		NoEventsCheck:   ctx.Bool("no-events"),
+		NoOptimize:      ctx.Bool("no-optimize"),
+
+		NoPermissionsCheck: ctx.Bool("no-permissions"),
 	}
 
 	if len(confFile) != 0 {
@@ -442,6 +680,16 @@ func contractCompile(ctx *cli.Context) error {
 		o.ContractEvents = conf.Events
 		o.ContractSupportedStandards = conf.SupportedStandards
 		o.SafeMethods = conf.SafeMethods
+		o.Permissions, err = toManifestPermissions(conf.Permissions)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("bad config: %w", err), 1)
+		}
+	}
+
+	if ctx.Bool("suggest-permissions") {
+		if err := suggestPermissions(ctx, src, o); err != nil {
+			return cli.NewExitError(err, 1)
+		}
 	}
 
 	result, err := compiler.CompileAndSave(src, o)
@@ -455,6 +703,28 @@ func contractCompile(ctx *cli.Context) error {
 	return nil
 }
 
+// suggestPermissions prints permissions section of the configuration file
+// allowing exactly the contract calls made by the contract along with
+// warnings about unnecessary configured permissions.
+func suggestPermissions(ctx *cli.Context, src string, o *compiler.Options) error {
+	_, di, err := compiler.CompileWithOptions(src, nil, o)
+	if err != nil {
+		return fmt.Errorf("error while trying to compile smart contract file: %w", err)
+	}
+	b, err := yaml.Marshal(ProjectConfig{Permissions: fromManifestPermissions(compiler.SuggestPermissions(di))})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(ctx.App.Writer, "Suggested permissions:")
+	fmt.Fprint(ctx.App.Writer, string(b))
+	if o.Permissions != nil {
+		for _, w := range compiler.LintPermissions(di, o.Permissions) {
+			fmt.Fprintln(ctx.App.Writer, "Warning:", w)
+		}
+	}
+	return nil
+}
+
 func calcHash(ctx *cli.Context) error {
 	sender := ctx.Generic("sender").(*flags.Address)
 	if !sender.IsSet {
@@ -541,21 +811,33 @@ func invokeInternal(ctx *cli.Context, signAndPush bool) error {
 }
 
 func invokeWithArgs(ctx *cli.Context, signAndPush bool, script util.Uint160, operation string, params []smartcontract.Parameter, cosigners []transaction.Signer) (util.Uint160, error) {
+	var (
+		acc  *wallet.Account
+		wall *wallet.Wallet
+		err  error
+	)
+	if signAndPush {
+		acc, wall, err = getAccFromContext(ctx)
+		if err != nil {
+			return util.Uint160{}, err
+		}
+	}
+	return invokeWithAccount(ctx, acc, wall, script, operation, params, cosigners)
+}
+
+// invokeWithAccount is the same as invokeWithArgs, but uses already decrypted
+// account, transaction is signed and sent only if acc is not nil.
+func invokeWithAccount(ctx *cli.Context, acc *wallet.Account, wall *wallet.Wallet, script util.Uint160, operation string, params []smartcontract.Parameter, cosigners []transaction.Signer) (util.Uint160, error) {
 	var (
 		err               error
 		gas               fixedn.Fixed8
 		cosignersAccounts []client.SignerAccount
 		resp              *result.Invoke
-		acc               *wallet.Account
-		wall              *wallet.Wallet
 		sender            util.Uint160
+		signAndPush       = acc != nil
 	)
 	if signAndPush {
 		gas = flags.Fixed8FromContext(ctx, "gas")
-		acc, wall, err = getAccFromContext(ctx)
-		if err != nil {
-			return sender, err
-		}
 		sender, err = address.StringToUint160(acc.Address)
 		if err != nil {
 			return sender, err
@@ -665,6 +947,7 @@ type ProjectConfig struct {
 	SafeMethods        []string
 	SupportedStandards []string
 	Events             []manifest.Event
+	Permissions        []permission `yaml:",omitempty"`
 }
 
 func inspect(ctx *cli.Context) error {
@@ -738,33 +1021,13 @@ func getAccFromContext(ctx *cli.Context) (*wallet.Account, *wallet.Wallet, error
 
 // contractDeploy deploys contract.
 func contractDeploy(ctx *cli.Context) error {
-	in := ctx.String("in")
-	if len(in) == 0 {
-		return cli.NewExitError(errNoInput, 1)
-	}
-	manifestFile := ctx.String("manifest")
-	if len(manifestFile) == 0 {
-		return cli.NewExitError(errNoManifestFile, 1)
-	}
-
-	f, err := ioutil.ReadFile(in)
-	if err != nil {
-		return cli.NewExitError(err, 1)
-	}
-	// Check the file.
-	nefFile, err := nef.FileFromBytes(f)
+	prof, err := getDeployProfile(ctx)
 	if err != nil {
-		return cli.NewExitError(fmt.Errorf("failed to read .nef file: %w", err), 1)
-	}
-
-	manifestBytes, err := ioutil.ReadFile(manifestFile)
-	if err != nil {
-		return cli.NewExitError(fmt.Errorf("failed to read manifest file: %w", err), 1)
+		return err
 	}
-	m := &manifest.Manifest{}
-	err = json.Unmarshal(manifestBytes, m)
+	f, nefFile, manifestBytes, m, err := readContractFiles(ctx)
 	if err != nil {
-		return cli.NewExitError(fmt.Errorf("failed to restore manifest file: %w", err), 1)
+		return err
 	}
 
 	appCallParams := []smartcontract.Parameter{
@@ -777,15 +1040,12 @@ func contractDeploy(ctx *cli.Context) error {
 			Value: manifestBytes,
 		},
 	}
-	_, data, err := cmdargs.ParseParams(ctx.Args(), true)
+	_, data, err := getDeployData(ctx.Args(), prof)
 	if err != nil {
-		return cli.NewExitError(fmt.Errorf("unable to parse 'data' parameter: %w", err), 1)
-	}
-	if len(data) > 1 {
-		return cli.NewExitError("'data' should be represented as a single parameter", 1)
+		return err
 	}
-	if len(data) != 0 {
-		appCallParams = append(appCallParams, data[0])
+	if data != nil {
+		appCallParams = append(appCallParams, *data)
 	}
 
 	gctx, cancel := options.GetTimeoutContext(ctx)
@@ -800,13 +1060,22 @@ func contractDeploy(ctx *cli.Context) error {
 	if err != nil {
 		return cli.NewExitError(fmt.Errorf("failed to get management contract's hash: %w", err), 1)
 	}
-	sender, extErr := invokeWithArgs(ctx, true, mgmtHash, "deploy", appCallParams, nil)
+	acc, wall, err := getAccFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	sender, extErr := invokeWithAccount(ctx, acc, wall, mgmtHash, "deploy", appCallParams, nil)
 	if extErr != nil {
 		return extErr
 	}
 
 	hash := state.CreateContractHash(sender, nefFile.Checksum, m.Name)
 	fmt.Fprintf(ctx.App.Writer, "Contract: %s\n", hash.StringLE())
+	// Transaction is not sent if it's saved to file, so there is nothing
+	// to record and invoke yet.
+	if prof != nil && ctx.String("out") == "" {
+		return prof.postDeploy(ctx, c, acc, wall, deployRecord{Hash: hash, Sender: sender})
+	}
 	return nil
 }
 
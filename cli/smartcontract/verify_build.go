@@ -0,0 +1,69 @@
+package smartcontract
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
+	"github.com/urfave/cli"
+)
+
+// verifyBuild reproduces contract build using metadata from debug info and
+// reports all mismatches found.
+func verifyBuild(ctx *cli.Context) error {
+	src := ctx.String("in")
+	if len(src) == 0 {
+		return cli.NewExitError(errNoInput, 1)
+	}
+	debugFile := ctx.String("debug")
+	if len(debugFile) == 0 {
+		return cli.NewExitError(errors.New("no debug info file specified, use '--debug' flag"), 1)
+	}
+	data, err := ioutil.ReadFile(debugFile)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	di := new(compiler.DebugInfo)
+	if err := json.Unmarshal(data, di); err != nil {
+		return cli.NewExitError(fmt.Errorf("can't parse debug info: %w", err), 1)
+	}
+	if di.Build == nil {
+		return cli.NewExitError(errors.New("debug info doesn't contain build metadata"), 1)
+	}
+
+	f, problems, err := compiler.ReproduceBuild(src, nil, di.Build)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	if nefFile := ctx.String("nef"); nefFile != "" {
+		data, err := ioutil.ReadFile(nefFile)
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		expected, err := nef.FileFromBytes(data)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("failed to read .nef file: %w", err), 1)
+		}
+		if expected.Compiler != f.Compiler {
+			problems = append(problems, fmt.Sprintf("%s: compiled with %s", nefFile, expected.Compiler))
+		}
+		if !bytes.Equal(expected.Script, f.Script) {
+			problems = append(problems, fmt.Sprintf("%s: script mismatch", nefFile))
+		}
+		if expected.Checksum != di.Build.Checksum {
+			problems = append(problems, fmt.Sprintf("%s: checksum %d doesn't match metadata", nefFile, expected.Checksum))
+		}
+	}
+	for _, p := range problems {
+		fmt.Fprintln(ctx.App.Writer, p)
+	}
+	if len(problems) != 0 {
+		return cli.NewExitError(fmt.Errorf("build is not reproduced, %d problem(s) found", len(problems)), 1)
+	}
+	fmt.Fprintln(ctx.App.Writer, "OK")
+	return nil
+}
@@ -0,0 +1,65 @@
+package smartcontract
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/nspcc-dev/neo-go/cli/flags"
+	"github.com/nspcc-dev/neo-go/cli/options"
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/urfave/cli"
+)
+
+// verifySource recompiles contract source and compares it with the deployed
+// contract printing source verification attestation.
+func verifySource(ctx *cli.Context) error {
+	src := ctx.String("in")
+	if len(src) == 0 {
+		return cli.NewExitError(errNoInput, 1)
+	}
+	hashFlag := ctx.Generic("hash").(*flags.Address)
+	if !hashFlag.IsSet {
+		return cli.NewExitError(errors.New("no contract hash specified, use '--hash' flag"), 1)
+	}
+
+	gctx, cancel := options.GetTimeoutContext(ctx)
+	defer cancel()
+
+	c, err := options.GetRPCClient(gctx, ctx)
+	if err != nil {
+		return err
+	}
+	// Height is requested first, so the contract state is not older.
+	count, err := c.GetBlockCount()
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to get block count: %w", err), 1)
+	}
+	cs, err := c.GetContractStateByHash(hashFlag.Uint160())
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to get contract state: %w", err), 1)
+	}
+	res, err := compiler.VerifySource(src, nil, nil, &cs.NEF)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	res.Contract = cs.Hash
+	res.Height = count - 1
+
+	data, err := json.MarshalIndent(res, "", "  ")
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	if out := ctx.String("out"); out != "" {
+		if err := ioutil.WriteFile(out, data, 0644); err != nil {
+			return cli.NewExitError(fmt.Errorf("failed to write attestation: %w", err), 1)
+		}
+	} else {
+		fmt.Fprintln(ctx.App.Writer, string(data))
+	}
+	if !res.Verified {
+		return cli.NewExitError(fmt.Errorf("source doesn't match the contract: %s", res.Reason), 1)
+	}
+	return nil
+}
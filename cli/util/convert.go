@@ -3,6 +3,7 @@ package util
 import (
 	"fmt"
 
+	"github.com/nspcc-dev/neo-go/cli/options"
 	vmcli "github.com/nspcc-dev/neo-go/pkg/vm/cli"
 	"github.com/urfave/cli"
 )
@@ -23,6 +24,17 @@ func NewCommands() []cli.Command {
         and converted to other formats. Strings are escaped and output in quotes.`,
 					Action: handleParse,
 				},
+				{
+					Name:  "txsend",
+					Usage: "Send complete transaction stored in a context file",
+					UsageText: `txsend [-r <endpoint>] <file.in>
+
+<file.in> is a parameter context file (created with --out option of commands
+        creating transactions and filled with 'wallet sign') which contains
+        all signatures required for the transaction.`,
+					Action: sendTx,
+					Flags:  options.RPC,
+				},
 			},
 		},
 	}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/cli/paramcontext"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/context"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUtilTxSend(t *testing.T) {
+	e := newExecutor(t, true)
+
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+
+	tmpDir := os.TempDir()
+	txPath := path.Join(tmpDir, "txsend.json")
+	incompletePath := path.Join(tmpDir, "txsend_incomplete.json")
+	t.Cleanup(func() {
+		os.Remove(txPath)
+		os.Remove(incompletePath)
+	})
+	e.In.WriteString("one\r")
+	e.Run(t, "neo-go", "wallet", "nep17", "transfer",
+		"--rpc-endpoint", "http://"+e.RPC.Addr,
+		"--wallet", validatorWallet, "--from", validatorAddr,
+		"--to", priv.Address(), "--token", "NEO", "--amount", "1",
+		"--out", txPath)
+	txHash := e.getNextLine(t)
+
+	t.Run("missing file", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "util", "txsend",
+			"--rpc-endpoint", "http://"+e.RPC.Addr)
+	})
+	t.Run("incomplete", func(t *testing.T) {
+		pc, err := paramcontext.Read(txPath)
+		require.NoError(t, err)
+		pc.Items = make(map[util.Uint160]*context.Item)
+		require.NoError(t, paramcontext.Save(pc, incompletePath))
+
+		e.RunWithError(t, "neo-go", "util", "txsend",
+			"--rpc-endpoint", "http://"+e.RPC.Addr, incompletePath)
+	})
+
+	e.Run(t, "neo-go", "util", "txsend",
+		"--rpc-endpoint", "http://"+e.RPC.Addr, txPath)
+	tx, _ := e.checkTxPersisted(t)
+	require.Equal(t, txHash, tx.Hash().StringLE())
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/abiosoft/readline"
 	vmcli "github.com/nspcc-dev/neo-go/pkg/vm/cli"
+	"github.com/nspcc-dev/neo-go/pkg/vm/dap"
 	"github.com/urfave/cli"
 )
 
@@ -16,11 +17,25 @@ func NewCommands() []cli.Command {
 		Action: startVMPrompt,
 		Flags: []cli.Flag{
 			cli.BoolFlag{Name: "debug, d"},
+			cli.BoolFlag{
+				Name:  "dap",
+				Usage: "serve Debug Adapter Protocol on stdin/stdout instead of starting the prompt",
+			},
+			cli.StringFlag{
+				Name:  "dap-listen",
+				Usage: "serve Debug Adapter Protocol on the given loopback TCP address instead of starting the prompt",
+			},
 		},
 	}}
 }
 
 func startVMPrompt(ctx *cli.Context) error {
+	if addr := ctx.String("dap-listen"); addr != "" {
+		return dap.ListenAndServe(addr)
+	}
+	if ctx.Bool("dap") {
+		return dap.NewSession(os.Stdin, ctx.App.Writer).Run()
+	}
 	p := vmcli.NewWithConfig(true, os.Exit, &readline.Config{
 		Stdout: ctx.App.Writer,
 		Stderr: ctx.App.ErrWriter,
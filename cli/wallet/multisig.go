@@ -1,16 +1,129 @@
 package wallet
 
 import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/nspcc-dev/neo-go/cli/flags"
 	"github.com/nspcc-dev/neo-go/cli/options"
 	"github.com/nspcc-dev/neo-go/cli/paramcontext"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"github.com/urfave/cli"
 )
 
+func newMultisigCommands() []cli.Command {
+	return []cli.Command{
+		{
+			Name:      "create",
+			Usage:     "create multisignature account from public keys",
+			UsageText: "create --min <n> [--wallet <path>] [--name <account_name>] <pubkey1> [<pubkey2> [...]]",
+			Description: `Creates m out of n multisignature verification script from the given
+   public keys and prints it along with the address and NEP-6 account entry.
+   If --wallet is given, the account is also added to the wallet (without
+   any private key, use 'wallet import-multisig' to add one of the keys).
+`,
+			Action: createMultisig,
+			Flags: []cli.Flag{
+				walletPathFlag,
+				cli.StringFlag{
+					Name:  "name, n",
+					Usage: "Optional account name",
+				},
+				cli.IntFlag{
+					Name:  "min, m",
+					Usage: "Minimal number of signatures",
+				},
+			},
+		},
+		{
+			Name:      "show",
+			Usage:     "decode multisignature verification script",
+			UsageText: "show <script>",
+			Description: `Prints the address, the number of signatures required and public
+   keys of the given hex-encoded multisignature verification script.
+`,
+			Action: showMultisig,
+		},
+	}
+}
+
+func createMultisig(ctx *cli.Context) error {
+	m := ctx.Int("min")
+	pubs, err := parseMultisigKeys(ctx.Args(), m)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	acc, err := wallet.NewWatchOnlyMultisigAccount(m, pubs)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	acc.Label = ctx.String("name")
+
+	if path := ctx.String("wallet"); path != "" {
+		wall, err := openWallet(path)
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		defer wall.Close()
+		if err := addAccountAndSave(wall, acc); err != nil {
+			return cli.NewExitError(err, 1)
+		}
+	}
+
+	b, err := json.Marshal(acc)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	fmt.Fprintf(ctx.App.Writer, "Script: %s\n", hex.EncodeToString(acc.Contract.Script))
+	fmt.Fprintf(ctx.App.Writer, "Address: %s\n", acc.Address)
+	fmt.Fprintf(ctx.App.Writer, "Account: %s\n", string(b))
+	return nil
+}
+
+func showMultisig(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return cli.NewExitError(errors.New("exactly one script should be provided"), 1)
+	}
+	script, err := hex.DecodeString(ctx.Args().First())
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("invalid script: %w", err), 1)
+	}
+	m, pubs, ok := vm.ParseMultiSigContract(script)
+	if !ok {
+		return cli.NewExitError(errors.New("not a multisignature verification script"), 1)
+	}
+	fmt.Fprintf(ctx.App.Writer, "Address: %s\n", address.Uint160ToString(hash.Hash160(script)))
+	fmt.Fprintf(ctx.App.Writer, "Signatures: %d out of %d\n", m, len(pubs))
+	fmt.Fprintln(ctx.App.Writer, "Keys:")
+	for i := range pubs {
+		fmt.Fprintln(ctx.App.Writer, hex.EncodeToString(pubs[i]))
+	}
+	return nil
+}
+
+// parseMultisigKeys decodes public keys for m out of n multisignature account.
+func parseMultisigKeys(args []string, m int) ([]*keys.PublicKey, error) {
+	if len(args) < m {
+		return nil, errors.New("insufficient number of public keys")
+	}
+	pubs := make([]*keys.PublicKey, len(args))
+	for i := range args {
+		var err error
+		pubs[i], err = keys.NewPublicKeyFromString(args[i])
+		if err != nil {
+			return nil, fmt.Errorf("can't decode public key %d: %w", i, err)
+		}
+	}
+	return pubs, nil
+}
+
 func signStoredTransaction(ctx *cli.Context) error {
 	wall, err := openWallet(ctx.String("wallet"))
 	if err != nil {
@@ -62,12 +175,8 @@ func signStoredTransaction(ctx *cli.Context) error {
 		}
 	}
 	if len(ctx.String(options.RPCEndpointFlag)) != 0 {
-		for i := range tx.Signers {
-			w, err := c.GetWitness(tx.Signers[i].Account)
-			if err != nil {
-				return cli.NewExitError(err, 1)
-			}
-			tx.Scripts = append(tx.Scripts, *w)
+		if _, err := c.GetCompleteTransaction(); err != nil {
+			return cli.NewExitError(err, 1)
 		}
 
 		gctx, cancel := options.GetTimeoutContext(ctx)
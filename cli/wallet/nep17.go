@@ -368,7 +368,7 @@ func multiTransferNEP17(ctx *cli.Context) error {
 	if err != nil {
 		return cli.NewExitError(err, 1)
 	}
-	acc, err := getDecryptedAccount(ctx, wall, from)
+	acc, err := getTransferAccount(ctx, wall, from)
 	if err != nil {
 		return cli.NewExitError(err, 1)
 	}
@@ -451,7 +451,7 @@ func transferNEP17(ctx *cli.Context) error {
 	if err != nil {
 		return cli.NewExitError(err, 1)
 	}
-	acc, err := getDecryptedAccount(ctx, wall, from)
+	acc, err := getTransferAccount(ctx, wall, from)
 	if err != nil {
 		return cli.NewExitError(err, 1)
 	}
@@ -525,6 +525,16 @@ func signAndSendTransfer(ctx *cli.Context, c *client.Client, acc *wallet.Account
 	return nil
 }
 
+// getTransferAccount returns watch-only account for the given address as is
+// if transaction is to be saved into file (for signing elsewhere) and the
+// decrypted account otherwise.
+func getTransferAccount(ctx *cli.Context, wall *wallet.Wallet, addr util.Uint160) (*wallet.Account, error) {
+	if acc := wall.GetAccount(addr); acc != nil && acc.IsWatchOnly() && ctx.String("out") != "" {
+		return acc, nil
+	}
+	return getDecryptedAccount(ctx, wall, addr)
+}
+
 func getDefaultAddress(fromFlag *flags.Address, w *wallet.Wallet) (util.Uint160, error) {
 	if fromFlag.IsSet {
 		return fromFlag.Uint160(), nil
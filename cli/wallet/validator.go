@@ -192,6 +192,9 @@ func getDecryptedAccount(ctx *cli.Context, wall *wallet.Wallet, addr util.Uint16
 	if acc == nil {
 		return nil, fmt.Errorf("can't find account for the address: %s", address.Uint160ToString(addr))
 	}
+	if acc.IsWatchOnly() {
+		return nil, fmt.Errorf("account %s is watch-only and can't sign", acc.Address)
+	}
 
 	if pass, err := input.ReadPassword("Password > "); err != nil {
 		fmt.Println("ERROR", pass, err)
@@ -13,8 +13,10 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"github.com/urfave/cli"
@@ -66,10 +68,27 @@ func NewCommands() []cli.Command {
 		walletPathFlag,
 		flags.AddressFlag{
 			Name:  "address, a",
-			Usage: "Address to claim GAS for",
+			Usage: "Address to claim GAS for (the one paying fees with --all)",
 		},
+		cli.BoolFlag{
+			Name:  "all",
+			Usage: "Claim GAS for all wallet accounts that have some to claim",
+		},
+		gasFlag,
 	}
 	claimFlags = append(claimFlags, options.RPC...)
+	unclaimedFlags := []cli.Flag{
+		walletPathFlag,
+		flags.AddressFlag{
+			Name:  "address, a",
+			Usage: "Address to calculate unclaimed GAS for (all wallet accounts by default)",
+		},
+		cli.UintFlag{
+			Name:  "height",
+			Usage: "Height to project unclaimed GAS for current NEO balances at (the next block by default)",
+		},
+	}
+	unclaimedFlags = append(unclaimedFlags, options.RPC...)
 	signFlags := []cli.Flag{
 		walletPathFlag,
 		outFlag,
@@ -85,11 +104,30 @@ func NewCommands() []cli.Command {
 		Usage: "create, open and manage a NEO wallet",
 		Subcommands: []cli.Command{
 			{
-				Name:   "claim",
-				Usage:  "claim GAS",
+				Name:      "claim",
+				Usage:     "claim GAS",
+				UsageText: "claim --wallet <path> (--address <addr> | --all [--address <payer>]) [--gas <gas>] [-r <endpoint>]",
+				Description: `Claims GAS generated by NEO of the given account or (with --all) all
+   standard signature accounts of the wallet that have some GAS to claim.
+   Accounts are claimed for in batches of up to 16 accounts per transaction,
+   all transactions are paid for by the --address account (or the first
+   account claimed for if it's not specified). Hashes of transactions sent
+   are printed.
+`,
 				Action: claimGas,
 				Flags:  claimFlags,
 			},
+			{
+				Name:      "unclaimed",
+				Usage:     "calculate unclaimed GAS",
+				UsageText: "unclaimed --wallet <path> [--address <addr>] [--height <height>] [-r <endpoint>]",
+				Description: `Prints the amount of GAS the given account or all wallet accounts can
+   claim at the given height along with their total. It's calculated for
+   the current NEO balances with a single invocation.
+`,
+				Action: calculateUnclaimed,
+				Flags:  unclaimedFlags,
+			},
 			{
 				Name:   "init",
 				Usage:  "create a new wallet",
@@ -100,6 +138,14 @@ func NewCommands() []cli.Command {
 						Name:  "account, a",
 						Usage: "Create a new account",
 					},
+					cli.BoolFlag{
+						Name:  "mnemonic, m",
+						Usage: "Create a new account derived from the new BIP-39 mnemonic",
+					},
+					cli.BoolFlag{
+						Name:  "restore, r",
+						Usage: "Create an account derived from the existing BIP-39 mnemonic",
+					},
 				},
 			},
 			{
@@ -208,6 +254,32 @@ func NewCommands() []cli.Command {
 					},
 				}, options.RPC...),
 			},
+			{
+				Name:      "import-watch",
+				Usage:     "import watch-only account (without private key)",
+				UsageText: "import-watch --wallet <path> (--address <addr> | --key <pubkey>) [--name <account_name>]",
+				Description: `Adds an account that has no private key to the wallet, it can't
+   sign anything, but allows to track the account state. Accounts imported
+   with public key can be used to create transactions (like NEP17
+   transfers with --out option) that are to be signed elsewhere.
+`,
+				Action: importWatchOnly,
+				Flags: []cli.Flag{
+					walletPathFlag,
+					flags.AddressFlag{
+						Name:  "address, a",
+						Usage: "Account address or hash in LE form",
+					},
+					cli.StringFlag{
+						Name:  "key, k",
+						Usage: "Hex-encoded public key of the standard signature account",
+					},
+					cli.StringFlag{
+						Name:  "name, n",
+						Usage: "Optional account name",
+					},
+				},
+			},
 			{
 				Name:      "remove",
 				Usage:     "remove an account from the wallet",
@@ -229,6 +301,11 @@ func NewCommands() []cli.Command {
 				Action:    signStoredTransaction,
 				Flags:     signFlags,
 			},
+			{
+				Name:        "multisig",
+				Usage:       "work with multisignature accounts",
+				Subcommands: newMultisigCommands(),
+			},
 			{
 				Name:        "nep17",
 				Usage:       "work with NEP17 contracts",
@@ -251,14 +328,9 @@ func claimGas(ctx *cli.Context) error {
 	defer wall.Close()
 
 	addrFlag := ctx.Generic("address").(*flags.Address)
-	if !addrFlag.IsSet {
+	if !addrFlag.IsSet && !ctx.Bool("all") {
 		return cli.NewExitError("address was not provided", 1)
 	}
-	scriptHash := addrFlag.Uint160()
-	acc, err := getDecryptedAccount(ctx, wall, scriptHash)
-	if err != nil {
-		return cli.NewExitError(err, 1)
-	}
 
 	gctx, cancel := options.GetTimeoutContext(ctx)
 	defer cancel()
@@ -268,16 +340,129 @@ func claimGas(ctx *cli.Context) error {
 		return cli.NewExitError(err, 1)
 	}
 
-	neoContractHash, err := c.GetNativeContractHash(nativenames.Neo)
+	if !ctx.Bool("all") {
+		scriptHash := addrFlag.Uint160()
+		acc, err := getDecryptedAccount(ctx, wall, scriptHash)
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		neoContractHash, err := c.GetNativeContractHash(nativenames.Neo)
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		hash, err := c.TransferNEP17(acc, scriptHash, neoContractHash, 0, int64(ctx.Generic("gas").(*flags.Fixed8).Value), nil, nil)
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		fmt.Fprintln(ctx.App.Writer, hash.StringLE())
+		return nil
+	}
+
+	var (
+		hashes []util.Uint160
+		accs   []*wallet.Account
+	)
+	for _, acc := range wall.Accounts {
+		if acc.IsWatchOnly() || acc.Contract == nil || !vm.IsSignatureContract(acc.Contract.Script) {
+			continue
+		}
+		hashes = append(hashes, acc.Contract.ScriptHash())
+	}
+	count, err := c.GetBlockCount()
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	gas, err := c.ProjectUnclaimedGas(hashes, count)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("can't calculate unclaimed GAS: %w", err), 1)
+	}
+	for i, h := range hashes {
+		if gas[i] <= 0 {
+			continue
+		}
+		acc, err := getDecryptedAccount(ctx, wall, h)
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		accs = append(accs, acc)
+	}
+	if len(accs) == 0 {
+		return cli.NewExitError("no GAS to claim", 1)
+	}
+	payer := accs[0]
+	if addrFlag.IsSet {
+		payer = nil
+		for _, acc := range accs {
+			if acc.Contract.ScriptHash().Equals(addrFlag.Uint160()) {
+				payer = acc
+				break
+			}
+		}
+		if payer == nil {
+			payer, err = getDecryptedAccount(ctx, wall, addrFlag.Uint160())
+			if err != nil {
+				return cli.NewExitError(err, 1)
+			}
+		}
+	}
+	txHashes, err := c.ClaimGas(payer, accs, int64(ctx.Generic("gas").(*flags.Fixed8).Value))
+	for _, h := range txHashes {
+		fmt.Fprintln(ctx.App.Writer, h.StringLE())
+	}
 	if err != nil {
 		return cli.NewExitError(err, 1)
 	}
-	hash, err := c.TransferNEP17(acc, scriptHash, neoContractHash, 0, 0, nil, nil)
+	return nil
+}
+
+func calculateUnclaimed(ctx *cli.Context) error {
+	wall, err := openWallet(ctx.String("wallet"))
 	if err != nil {
 		return cli.NewExitError(err, 1)
 	}
+	defer wall.Close()
 
-	fmt.Fprintln(ctx.App.Writer, hash.StringLE())
+	var hashes []util.Uint160
+	addrFlag := ctx.Generic("address").(*flags.Address)
+	if addrFlag.IsSet {
+		if wall.GetAccount(addrFlag.Uint160()) == nil {
+			return cli.NewExitError(fmt.Errorf("can't find account for the address: %s", address.Uint160ToString(addrFlag.Uint160())), 1)
+		}
+		hashes = append(hashes, addrFlag.Uint160())
+	} else {
+		for _, acc := range wall.Accounts {
+			h, err := address.StringToUint160(acc.Address)
+			if err != nil {
+				return cli.NewExitError(fmt.Errorf("invalid account address %s: %w", acc.Address, err), 1)
+			}
+			hashes = append(hashes, h)
+		}
+	}
+
+	gctx, cancel := options.GetTimeoutContext(ctx)
+	defer cancel()
+
+	c, err := options.GetRPCClient(gctx, ctx)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	height := uint32(ctx.Uint("height"))
+	if !ctx.IsSet("height") {
+		height, err = c.GetBlockCount()
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+	}
+	gas, err := c.ProjectUnclaimedGas(hashes, height)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("can't calculate unclaimed GAS: %w", err), 1)
+	}
+	var total int64
+	for i, h := range hashes {
+		fmt.Fprintf(ctx.App.Writer, "%s: %s\n", address.Uint160ToString(h), fixedn.Fixed8(gas[i]))
+		total += gas[i]
+	}
+	fmt.Fprintf(ctx.App.Writer, "Total: %s\n", fixedn.Fixed8(total))
 	return nil
 }
 
@@ -392,18 +577,9 @@ func importMultisig(ctx *cli.Context) error {
 	defer wall.Close()
 
 	m := ctx.Int("min")
-	if ctx.NArg() < m {
-		return cli.NewExitError(errors.New("insufficient number of public keys"), 1)
-	}
-
-	args := []string(ctx.Args())
-	pubs := make([]*keys.PublicKey, len(args))
-
-	for i := range args {
-		pubs[i], err = keys.NewPublicKeyFromString(args[i])
-		if err != nil {
-			return cli.NewExitError(fmt.Errorf("can't decode public key %d: %w", i, err), 1)
-		}
+	pubs, err := parseMultisigKeys(ctx.Args(), m)
+	if err != nil {
+		return cli.NewExitError(err, 1)
 	}
 
 	acc, err := newAccountFromWIF(ctx.App.Writer, ctx.String("wif"))
@@ -480,6 +656,39 @@ func importDeployed(ctx *cli.Context) error {
 	return nil
 }
 
+func importWatchOnly(ctx *cli.Context) error {
+	wall, err := openWallet(ctx.String("wallet"))
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	defer wall.Close()
+
+	var (
+		acc      *wallet.Account
+		addrFlag = ctx.Generic("address").(*flags.Address)
+		key      = ctx.String("key")
+	)
+	switch {
+	case addrFlag.IsSet && key != "":
+		return cli.NewExitError("either address or public key should be provided", 1)
+	case addrFlag.IsSet:
+		acc = wallet.NewWatchOnlyAccount(addrFlag.Uint160())
+	case key != "":
+		pub, err := keys.NewPublicKeyFromString(key)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("invalid public key: %w", err), 1)
+		}
+		acc = wallet.NewWatchOnlyAccountFromPublicKey(pub)
+	default:
+		return cli.NewExitError("address or public key was not provided", 1)
+	}
+	acc.Label = ctx.String("name")
+	if err := addAccountAndSave(wall, acc); err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	return nil
+}
+
 func importWallet(ctx *cli.Context) error {
 	wall, err := openWallet(ctx.String("wallet"))
 	if err != nil {
@@ -564,6 +773,9 @@ func dumpWallet(ctx *cli.Context) error {
 			return cli.NewExitError(err, 1)
 		}
 		for i := range wall.Accounts {
+			if wall.Accounts[i].IsWatchOnly() {
+				continue
+			}
 			// Just testing the decryption here.
 			err := wall.Accounts[i].Decrypt(pass)
 			if err != nil {
@@ -593,6 +805,9 @@ func dumpKeys(ctx *cli.Context) error {
 
 	hasPrinted := false
 	for _, acc := range accounts {
+		if acc.Contract == nil {
+			continue
+		}
 		pub, ok := vm.ParseSignatureContract(acc.Contract.Script)
 		if ok {
 			if hasPrinted {
@@ -635,7 +850,11 @@ func createWallet(ctx *cli.Context) error {
 		return cli.NewExitError(err, 1)
 	}
 
-	if ctx.Bool("account") {
+	if ctx.Bool("mnemonic") || ctx.Bool("restore") {
+		if err := createAccountFromMnemonic(ctx.App.Writer, wall, ctx.Bool("restore")); err != nil {
+			return cli.NewExitError(err, 1)
+		}
+	} else if ctx.Bool("account") {
 		if err := createAccount(wall); err != nil {
 			return cli.NewExitError(err, 1)
 		}
@@ -673,6 +892,50 @@ func createAccount(wall *wallet.Wallet) error {
 	return wall.CreateAccount(name, phrase)
 }
 
+// createAccountFromMnemonic creates an account with the key derived from the
+// new (or existing one if restore is true) BIP-39 mnemonic using the first
+// Neo BIP-44 derivation path.
+func createAccountFromMnemonic(w io.Writer, wall *wallet.Wallet, restore bool) error {
+	var (
+		mnemonic string
+		err      error
+	)
+	if restore {
+		mnemonic, err = input.ReadPassword("Enter mnemonic > ")
+	} else {
+		mnemonic, err = keys.NewMnemonic(keys.MaxMnemonicEntropy)
+		if err == nil {
+			fmt.Fprintf(w, "Mnemonic (write it down, it can be used to restore your key):\n%s\n", mnemonic)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	seed, err := keys.MnemonicToSeed(strings.TrimSpace(mnemonic), "")
+	if err != nil {
+		return err
+	}
+	master, err := keys.NewMasterHDKey(seed)
+	if err != nil {
+		return err
+	}
+	hk, err := master.Derive(keys.NeoDerivationPath(0, 0))
+	if err != nil {
+		return err
+	}
+	name, phrase, err := readAccountInfo()
+	if err != nil {
+		return err
+	}
+	acc := wallet.NewAccountFromPrivateKey(hk.PrivateKey)
+	acc.Label = name
+	if err := acc.Encrypt(phrase); err != nil {
+		return err
+	}
+	wall.AddAccount(acc)
+	return wall.Save()
+}
+
 func openWallet(path string) (*wallet.Wallet, error) {
 	if len(path) == 0 {
 		return nil, errNoPath
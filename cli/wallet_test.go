@@ -6,14 +6,20 @@ import (
 	"math/big"
 	"os"
 	"path"
+	"regexp"
+	"strconv"
 	"strings"
 	"testing"
 
 	"github.com/abiosoft/readline"
+	"github.com/nspcc-dev/neo-go/cli/paramcontext"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"github.com/stretchr/testify/require"
 )
@@ -52,6 +58,40 @@ func TestWalletInit(t *testing.T) {
 		w.Close()
 	})
 
+	t.Run("mnemonic", func(t *testing.T) {
+		walletPath := path.Join(tmpDir, "walletmnemonic.json")
+		e.In.WriteString("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about\r")
+		e.In.WriteString("hd\rpass\rpass\r")
+		e.Run(t, "neo-go", "wallet", "init", "--restore",
+			"--wallet", walletPath)
+
+		w, err := wallet.NewWalletFromFile(walletPath)
+		require.NoError(t, err)
+		require.Len(t, w.Accounts, 1)
+		require.Equal(t, "hd", w.Accounts[0].Label)
+		require.NoError(t, w.Accounts[0].Decrypt("pass"))
+		require.Equal(t, "38bcb1943801333aecdb9099b368ca8ea5b13a2c22862f8e1be77a46ed88738b",
+			hex.EncodeToString(w.Accounts[0].PrivateKey().Bytes()))
+		w.Close()
+
+		t.Run("bad mnemonic", func(t *testing.T) {
+			e.In.WriteString("abandon abandon abandon\r")
+			e.RunWithError(t, "neo-go", "wallet", "init", "--restore",
+				"--wallet", path.Join(tmpDir, "walletbadmnemonic.json"))
+		})
+		t.Run("new", func(t *testing.T) {
+			walletPath := path.Join(tmpDir, "walletnewmnemonic.json")
+			e.In.WriteString("hd\rpass\rpass\r")
+			e.Run(t, "neo-go", "wallet", "init", "--mnemonic",
+				"--wallet", walletPath)
+
+			w, err := wallet.NewWalletFromFile(walletPath)
+			require.NoError(t, err)
+			require.Len(t, w.Accounts, 1)
+			w.Close()
+		})
+	})
+
 	t.Run("CreateAccount", func(t *testing.T) {
 		e.In.WriteString("testname\r")
 		e.In.WriteString("testpass\r")
@@ -166,6 +206,89 @@ func TestWalletInit(t *testing.T) {
 	})
 }
 
+func TestWalletImportWatchOnly(t *testing.T) {
+	tmpDir := path.Join(os.TempDir(), "neogo.test.walletwatch")
+	require.NoError(t, os.Mkdir(tmpDir, os.ModePerm))
+	t.Cleanup(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	e := newExecutor(t, true)
+
+	walletPath := path.Join(tmpDir, "wallet.json")
+	e.Run(t, "neo-go", "wallet", "init", "--wallet", walletPath)
+
+	pub := hex.EncodeToString(validatorPriv.PublicKey().Bytes())
+	t.Run("missing address and key", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "wallet", "import-watch", "--wallet", walletPath)
+	})
+	t.Run("both address and key", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "wallet", "import-watch", "--wallet", walletPath,
+			"--address", validatorAddr, "--key", pub)
+	})
+	t.Run("invalid key", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "wallet", "import-watch", "--wallet", walletPath,
+			"--key", "0102")
+	})
+
+	t.Run("address", func(t *testing.T) {
+		priv, err := keys.NewPrivateKey()
+		require.NoError(t, err)
+		e.Run(t, "neo-go", "wallet", "import-watch", "--wallet", walletPath,
+			"--address", priv.Address(), "--name", "watcher")
+
+		w, err := wallet.NewWalletFromFile(walletPath)
+		require.NoError(t, err)
+		t.Cleanup(w.Close)
+		acc := w.GetAccount(priv.GetScriptHash())
+		require.NotNil(t, acc)
+		require.True(t, acc.IsWatchOnly())
+		require.Equal(t, "watcher", acc.Label)
+		require.Nil(t, acc.Contract)
+	})
+
+	e.Run(t, "neo-go", "wallet", "import-watch", "--wallet", walletPath, "--key", pub)
+	w, err := wallet.NewWalletFromFile(walletPath)
+	require.NoError(t, err)
+	t.Cleanup(w.Close)
+	acc := w.GetAccount(validatorHash)
+	require.NotNil(t, acc)
+	require.True(t, acc.IsWatchOnly())
+	require.Equal(t, validatorPriv.PublicKey().GetVerificationScript(), acc.Contract.Script)
+
+	t.Run("AlreadyExists", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "wallet", "import-watch", "--wallet", walletPath, "--key", pub)
+	})
+
+	privTo, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	args := []string{"neo-go", "wallet", "nep17", "transfer",
+		"--rpc-endpoint", "http://" + e.RPC.Addr,
+		"--wallet", walletPath, "--from", validatorAddr,
+		"--to", privTo.Address(), "--token", "NEO", "--amount", "1"}
+	t.Run("can't sign", func(t *testing.T) {
+		e.RunWithError(t, args...)
+	})
+
+	outPath := path.Join(tmpDir, "tx.json")
+	e.Run(t, append(args, "--out", outPath)...)
+	e.getNextLine(t)
+
+	pc, err := paramcontext.Read(outPath)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(pc.Items))
+
+	e.In.WriteString("one\r")
+	e.Run(t, "neo-go", "wallet", "sign",
+		"--rpc-endpoint", "http://"+e.RPC.Addr,
+		"--wallet", validatorWallet, "--address", validatorAddr,
+		"--in", outPath, "--out", outPath)
+	e.checkTxPersisted(t)
+
+	b, _ := e.Chain.GetGoverningTokenBalance(privTo.GetScriptHash())
+	require.Equal(t, big.NewInt(1), b)
+}
+
 func TestWalletExport(t *testing.T) {
 	e := newExecutor(t, false)
 
@@ -239,6 +362,89 @@ func TestClaimGas(t *testing.T) {
 	}
 }
 
+func TestClaimGasAll(t *testing.T) {
+	e := newExecutor(t, true)
+
+	w, err := wallet.NewWalletFromFile(validatorWallet)
+	require.NoError(t, err)
+	t.Cleanup(w.Close)
+
+	var claimers []util.Uint160
+	for _, acc := range w.Accounts {
+		if !vm.IsSignatureContract(acc.Contract.Script) {
+			continue
+		}
+		h := acc.Contract.ScriptHash()
+		if b, _ := e.Chain.GetGoverningTokenBalance(h); b.Sign() == 0 {
+			continue
+		}
+		cl, err := e.Chain.CalculateClaimable(h, e.Chain.BlockHeight()+1)
+		require.NoError(t, err)
+		if cl.Sign() > 0 {
+			claimers = append(claimers, h)
+		}
+	}
+	require.NotEqual(t, 0, len(claimers))
+
+	t.Run("no address or --all", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "wallet", "claim",
+			"--rpc-endpoint", "http://"+e.RPC.Addr,
+			"--wallet", validatorWallet)
+	})
+	e.In.WriteString(strings.Repeat("one\r", len(claimers)))
+	e.Run(t, "neo-go", "wallet", "claim",
+		"--rpc-endpoint", "http://"+e.RPC.Addr,
+		"--wallet", validatorWallet,
+		"--all")
+	tx, _ := e.checkTxPersisted(t)
+	e.checkEOF(t)
+	require.Equal(t, len(claimers), len(tx.Signers))
+	for i := range claimers {
+		require.Equal(t, claimers[i], tx.Signers[i].Account)
+	}
+}
+
+func TestUnclaimedGas(t *testing.T) {
+	e := newExecutor(t, true)
+
+	height := e.Chain.BlockHeight() + 10
+	cl, err := e.Chain.CalculateClaimable(validatorHash, height)
+	require.NoError(t, err)
+	require.True(t, cl.Sign() > 0)
+	expected := fixedn.Fixed8(cl.Int64()).String()
+
+	e.Run(t, "neo-go", "wallet", "unclaimed",
+		"--rpc-endpoint", "http://"+e.RPC.Addr,
+		"--wallet", validatorWallet,
+		"--address", validatorAddr,
+		"--height", strconv.Itoa(int(height)))
+	e.checkNextLine(t, "^"+validatorAddr+": "+regexp.QuoteMeta(expected)+"$")
+	e.checkNextLine(t, "^Total: "+regexp.QuoteMeta(expected)+"$")
+	e.checkEOF(t)
+
+	t.Run("all accounts", func(t *testing.T) {
+		w, err := wallet.NewWalletFromFile(validatorWallet)
+		require.NoError(t, err)
+		t.Cleanup(w.Close)
+
+		e.Run(t, "neo-go", "wallet", "unclaimed",
+			"--rpc-endpoint", "http://"+e.RPC.Addr,
+			"--wallet", validatorWallet,
+			"--height", strconv.Itoa(int(height)))
+		for _, acc := range w.Accounts {
+			e.checkNextLine(t, "^"+acc.Address+": ")
+		}
+		e.checkNextLine(t, "^Total: ")
+		e.checkEOF(t)
+	})
+	t.Run("unknown address", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "wallet", "unclaimed",
+			"--rpc-endpoint", "http://"+e.RPC.Addr,
+			"--wallet", validatorWallet,
+			"--address", address.Uint160ToString(util.Uint160{1, 2, 3}))
+	})
+}
+
 func TestImportDeployed(t *testing.T) {
 	e := newExecutor(t, true)
 
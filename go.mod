@@ -6,6 +6,7 @@ require (
 	github.com/abiosoft/readline v0.0.0-20180607040430-155bce2042db
 	github.com/alicebob/miniredis v2.5.0+incompatible
 	github.com/btcsuite/btcd v0.20.1-beta
+	github.com/cockroachdb/pebble v0.0.0-20210120202502-6110b03a8a85
 	github.com/dgraph-io/badger/v2 v2.0.3
 	github.com/go-redis/redis v6.10.2+incompatible
 	github.com/gogo/protobuf v1.1.1
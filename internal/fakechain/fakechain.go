@@ -40,6 +40,8 @@ type FakeChain struct {
 	NotaryDepositExpiration  uint32
 	PostBlock                []func(blockchainer.Blockchainer, *mempool.Pool, *block.Block)
 	UtilityTokenBalance      *big.Int
+	KnownPeers               []byte
+	StorageChangesF          func(uint32) (*state.StorageChanges, error)
 }
 
 // NewFakeChain returns new FakeChain structure.
@@ -51,7 +53,7 @@ func NewFakeChain() *FakeChain {
 		blocks:                make(map[util.Uint256]*block.Block),
 		hdrHashes:             make(map[uint32]util.Uint256),
 		txs:                   make(map[util.Uint256]*transaction.Transaction),
-		ProtocolConfiguration: config.ProtocolConfiguration{Magic: netmode.UnitTestNet, P2PNotaryRequestPayloadPoolSize: 10},
+		ProtocolConfiguration: config.ProtocolConfiguration{Magic: netmode.UnitTestNet, MaxBlockSize: 262144, P2PNotaryRequestPayloadPoolSize: 10},
 	}
 }
 
@@ -194,6 +196,11 @@ func (chain *FakeChain) Close() {
 	panic("TODO")
 }
 
+// CompactStorage implements Blockchainer interface.
+func (chain *FakeChain) CompactStorage() (int64, error) {
+	panic("TODO")
+}
+
 // HeaderHeight implements Blockchainer interface.
 func (chain *FakeChain) HeaderHeight() uint32 {
 	return atomic.LoadUint32(&chain.Blockheight)
@@ -212,6 +219,11 @@ func (chain *FakeChain) GetBlock(hash util.Uint256) (*block.Block, error) {
 	return nil, errors.New("not found")
 }
 
+// FetchBlock implements Blockchainer interface.
+func (chain *FakeChain) FetchBlock(hash util.Uint256) (*block.Block, error) {
+	return chain.GetBlock(hash)
+}
+
 // GetCommittee implements Blockchainer interface.
 func (chain *FakeChain) GetCommittee() (keys.PublicKeys, error) {
 	panic("TODO")
@@ -254,11 +266,41 @@ func (chain *FakeChain) GetNextBlockValidators() ([]*keys.PublicKey, error) {
 	panic("TODO")
 }
 
+// GetNextCommittee implements Blockchainer interface.
+func (chain *FakeChain) GetNextCommittee() (keys.PublicKeys, []*big.Int, error) {
+	panic("TODO")
+}
+
+// GetNEOBalanceState implements Blockchainer interface.
+func (chain *FakeChain) GetNEOBalanceState(acc util.Uint160) (*state.NEOBalanceState, error) {
+	panic("TODO")
+}
+
+// ForEachNEP11Transfer implements Blockchainer interface.
+func (chain *FakeChain) ForEachNEP11Transfer(util.Uint160, func(*state.NEP11Transfer) (bool, error)) error {
+	panic("TODO")
+}
+
+// ForEachAddressTransaction implements Blockchainer interface.
+func (chain *FakeChain) ForEachAddressTransaction(util.Uint160, func(*state.AddressTransaction) (bool, error)) error {
+	panic("TODO")
+}
+
 // ForEachNEP17Transfer implements Blockchainer interface.
 func (chain *FakeChain) ForEachNEP17Transfer(util.Uint160, func(*state.NEP17Transfer) (bool, error)) error {
 	panic("TODO")
 }
 
+// ForEachNotification implements Blockchainer interface.
+func (chain *FakeChain) ForEachNotification(util.Uint160, string, uint32, func(*state.IndexedNotification) (bool, error)) error {
+	panic("TODO")
+}
+
+// GetNEP11Balances implements Blockchainer interface.
+func (chain *FakeChain) GetNEP11Balances(util.Uint160) *state.NEP11Balances {
+	panic("TODO")
+}
+
 // GetNEP17Balances implements Blockchainer interface.
 func (chain *FakeChain) GetNEP17Balances(util.Uint160) *state.NEP17Balances {
 	panic("TODO")
@@ -289,6 +331,14 @@ func (chain *FakeChain) GetStateModule() blockchainer.StateRoot {
 	return nil
 }
 
+// GetStorageChanges implements Blockchainer interface.
+func (chain *FakeChain) GetStorageChanges(index uint32) (*state.StorageChanges, error) {
+	if chain.StorageChangesF != nil {
+		return chain.StorageChangesF(index)
+	}
+	panic("TODO")
+}
+
 // GetStorageItem implements Blockchainer interface.
 func (chain *FakeChain) GetStorageItem(id int32, key []byte) state.StorageItem {
 	panic("TODO")
@@ -299,6 +349,21 @@ func (chain *FakeChain) GetTestVM(t trigger.Type, tx *transaction.Transaction, b
 	panic("TODO")
 }
 
+// GetTestVMWithStorageDiff implements Blockchainer interface.
+func (chain *FakeChain) GetTestVMWithStorageDiff(t trigger.Type, tx *transaction.Transaction, b *block.Block, o *state.Overrides) (*vm.VM, func() ([]state.StorageDiff, error), error) {
+	panic("TODO")
+}
+
+// SimulateTransactions implements Blockchainer interface.
+func (chain *FakeChain) SimulateTransactions(txs []*transaction.Transaction, b *block.Block, o *state.Overrides, gasLimit int64) ([]state.AppExecResult, *state.StorageChanges, error) {
+	panic("TODO")
+}
+
+// GetTestVMWithOverrides implements Blockchainer interface.
+func (chain *FakeChain) GetTestVMWithOverrides(t trigger.Type, tx *transaction.Transaction, b *block.Block, o *state.Overrides) (*vm.VM, error) {
+	panic("TODO")
+}
+
 // GetStorageItems implements Blockchainer interface.
 func (chain *FakeChain) GetStorageItems(id int32) (map[string]state.StorageItem, error) {
 	panic("TODO")
@@ -334,11 +399,27 @@ func (chain *FakeChain) GetTransaction(h util.Uint256) (*transaction.Transaction
 	return nil, 0, errors.New("not found")
 }
 
+// GetTransactionTrace implements Blockchainer interface.
+func (chain *FakeChain) GetTransactionTrace(hash util.Uint256) (*state.TransactionTrace, error) {
+	panic("TODO")
+}
+
 // GetMemPool implements Blockchainer interface.
 func (chain *FakeChain) GetMemPool() *mempool.Pool {
 	return chain.Pool
 }
 
+// GetKnownPeers implements Blockchainer interface.
+func (chain *FakeChain) GetKnownPeers() ([]byte, error) {
+	return chain.KnownPeers, nil
+}
+
+// PutKnownPeers implements Blockchainer interface.
+func (chain *FakeChain) PutKnownPeers(data []byte) error {
+	chain.KnownPeers = data
+	return nil
+}
+
 // GetGoverningTokenBalance implements Blockchainer interface.
 func (chain *FakeChain) GetGoverningTokenBalance(acc util.Uint160) (*big.Int, uint32) {
 	panic("TODO")
@@ -367,6 +448,11 @@ func (chain FakeChain) SetOracle(services.Oracle) {
 	panic("TODO")
 }
 
+// SetBlockFetcher implements Blockchainer interface.
+func (chain *FakeChain) SetBlockFetcher(services.BlockFetcher) {
+	panic("TODO")
+}
+
 // SetNotary implements Blockchainer interface.
 func (chain *FakeChain) SetNotary(notary services.Notary) {
 	panic("TODO")
@@ -387,6 +473,11 @@ func (chain *FakeChain) SubscribeForNotifications(ch chan<- *state.NotificationE
 	panic("TODO")
 }
 
+// SubscribeForStorageChanges implements Blockchainer interface.
+func (chain *FakeChain) SubscribeForStorageChanges(ch chan<- *state.StorageChanges) {
+	panic("TODO")
+}
+
 // SubscribeForTransactions implements Blockchainer interface.
 func (chain *FakeChain) SubscribeForTransactions(ch chan<- *transaction.Transaction) {
 	panic("TODO")
@@ -427,6 +518,11 @@ func (chain *FakeChain) UnsubscribeFromNotifications(ch chan<- *state.Notificati
 	panic("TODO")
 }
 
+// UnsubscribeFromStorageChanges implements Blockchainer interface.
+func (chain *FakeChain) UnsubscribeFromStorageChanges(ch chan<- *state.StorageChanges) {
+	panic("TODO")
+}
+
 // UnsubscribeFromTransactions implements Blockchainer interface.
 func (chain *FakeChain) UnsubscribeFromTransactions(ch chan<- *transaction.Transaction) {
 	panic("TODO")
@@ -241,8 +241,22 @@ func (c *codegen) analyzeFuncUsage() funcUsage {
 
 	c.ForEachFile(func(f *ast.File, pkg *types.Package) {
 		isMain := pkg == c.mainPkg.Pkg
-		ast.Inspect(f, func(node ast.Node) bool {
+		var inspect func(node ast.Node) bool
+		inspect = func(node ast.Node) bool {
 			switch n := node.(type) {
+			case *ast.IfStmt:
+				// Functions called only from the branch not taken are not used.
+				if cond, ok := c.constantCondition(n.Cond); ok {
+					if n.Init != nil {
+						ast.Inspect(n.Init, inspect)
+					}
+					if cond {
+						ast.Inspect(n.Body, inspect)
+					} else if n.Else != nil {
+						ast.Inspect(n.Else, inspect)
+					}
+					return false
+				}
 			case *ast.CallExpr:
 				switch t := n.Fun.(type) {
 				case *ast.Ident:
@@ -262,7 +276,8 @@ func (c *codegen) analyzeFuncUsage() funcUsage {
 				}
 			}
 			return true
-		})
+		}
+		ast.Inspect(f, inspect)
 	})
 	return usage
 }
@@ -293,7 +308,7 @@ func isSyscall(fun *funcScope) bool {
 		return false
 	}
 	return fun.pkg.Name() == "neogointernal" && (strings.HasPrefix(fun.name, "Syscall") ||
-		strings.HasPrefix(fun.name, "Opcode"))
+		strings.HasPrefix(fun.name, "Opcode") || strings.HasPrefix(fun.name, "Asm"))
 }
 
 const interopPrefix = "github.com/nspcc-dev/neo-go/pkg/interop"
@@ -0,0 +1,442 @@
+package compiler
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+)
+
+// Severity is the severity of contract analysis finding.
+type Severity byte
+
+// Severity levels of findings.
+const (
+	SeverityLow Severity = iota
+	SeverityMedium
+	SeverityHigh
+)
+
+// Names of checks performed by Analyze.
+const (
+	// CheckUncheckedWitness reports token transfers not preceded by witness
+	// check.
+	CheckUncheckedWitness = "unchecked-witness"
+	// CheckReentrancy reports storage changes made after external calls
+	// (including transfers invoking recipient's onNEP17Payment) and external
+	// calls made from payment callbacks.
+	CheckReentrancy = "reentrancy"
+	// CheckUnboundedIteration reports storage iterations without any bound
+	// in public methods.
+	CheckUnboundedIteration = "unbounded-iteration"
+	// CheckUnprotectedUpdate reports contract update or destruction not
+	// preceded by witness check.
+	CheckUnprotectedUpdate = "unprotected-update"
+	// CheckIgnoredWitness reports witness checks with result not affecting
+	// the execution.
+	CheckIgnoredWitness = "ignored-witness"
+)
+
+// String implements fmt.Stringer interface.
+func (s Severity) String() string {
+	switch s {
+	case SeverityLow:
+		return "low"
+	case SeverityMedium:
+		return "medium"
+	case SeverityHigh:
+		return "high"
+	default:
+		return fmt.Sprintf("Severity(%d)", byte(s))
+	}
+}
+
+// ParseSeverity parses severity from its string representation.
+func ParseSeverity(s string) (Severity, error) {
+	switch strings.ToLower(s) {
+	case "low":
+		return SeverityLow, nil
+	case "medium":
+		return SeverityMedium, nil
+	case "high":
+		return SeverityHigh, nil
+	default:
+		return 0, fmt.Errorf("unknown severity %q", s)
+	}
+}
+
+// Finding is a potential vulnerability found by Analyze.
+type Finding struct {
+	Severity Severity
+	// Check is the name of the check reporting the finding.
+	Check string
+	// Method is the name of the contract method (Go function) affected.
+	Method string
+	// Position is the source position of the code the finding refers to,
+	// it can be located in a function called by Method.
+	Position token.Position
+	Message  string
+}
+
+// String implements fmt.Stringer interface.
+func (f Finding) String() string {
+	return fmt.Sprintf("%s %s: %s: %s [%s]", strings.ToUpper(f.Severity.String()),
+		f.Position, f.Method, f.Message, f.Check)
+}
+
+// eventKind is the kind of security-relevant action performed by the code.
+type eventKind byte
+
+const (
+	evWitness eventKind = iota
+	evIgnoredWitness
+	evTransfer
+	evExternalCall
+	evStorageWrite
+	evUpdate
+	evUnboundedLoop
+)
+
+// event is a security-relevant action found in the code, events are recorded
+// in the order of execution (with calls to contract functions flattened).
+type event struct {
+	kind eventKind
+	pos  token.Pos
+}
+
+// funcBody is a function declaration along with type information for it.
+type funcBody struct {
+	decl *ast.FuncDecl
+	info *types.Info
+}
+
+type analyzer struct {
+	funcs   map[*types.Func]funcBody
+	visited map[*ast.FuncDecl]bool
+	// witnessVars are variables storing witness check results.
+	witnessVars map[types.Object]bool
+}
+
+// Analyze checks contract source (name and r have the same meaning as for
+// Compile) for common vulnerabilities. Analysis is heuristic, every exported
+// function of the main package is checked along with all contract functions
+// it calls. Findings are returned sorted by severity (most severe first) and
+// then by their order in the code.
+func Analyze(name string, r io.Reader) ([]Finding, error) {
+	info, err := getBuildInfo(name, r)
+	if err != nil {
+		return nil, err
+	}
+	a := &analyzer{
+		funcs:       make(map[*types.Func]funcBody),
+		visited:     make(map[*ast.FuncDecl]bool),
+		witnessVars: make(map[types.Object]bool),
+	}
+	for _, pkg := range info.program.AllPackages {
+		if isInteropPath(pkg.Pkg.Path()) {
+			continue
+		}
+		for _, f := range pkg.Files {
+			for _, d := range f.Decls {
+				fd, ok := d.(*ast.FuncDecl)
+				if !ok || fd.Body == nil {
+					continue
+				}
+				if fn, ok := pkg.Defs[fd.Name].(*types.Func); ok {
+					a.funcs[fn] = funcBody{decl: fd, info: &pkg.Info}
+				}
+			}
+		}
+	}
+
+	var (
+		findings []Finding
+		fset     = info.program.Fset
+		mainPkg  = info.program.Package(info.initialPackage)
+	)
+	for _, f := range mainPkg.Files {
+		for _, d := range f.Decls {
+			fd, ok := d.(*ast.FuncDecl)
+			if !ok || fd.Recv != nil || fd.Body == nil || !fd.Name.IsExported() {
+				continue
+			}
+			a.visited[fd] = true
+			evs := a.events(fd.Body, &mainPkg.Info)
+			delete(a.visited, fd)
+			for _, fnd := range checkEvents(fd.Name.Name, evs) {
+				fnd.Position = fset.Position(fnd.pos)
+				findings = append(findings, fnd.Finding)
+			}
+		}
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].Severity > findings[j].Severity
+	})
+	return findings, nil
+}
+
+// events returns security-relevant events of the node.
+func (a *analyzer) events(n ast.Node, info *types.Info) []event {
+	return a.guardEvents(n, info, false)
+}
+
+// guardEvents returns security-relevant events of the node, guard is true
+// for nodes controlling the execution (if conditions, returned values and
+// panic arguments), only witness checks used there are counted.
+func (a *analyzer) guardEvents(n ast.Node, info *types.Info, guard bool) []event {
+	if n == nil {
+		return nil
+	}
+	var evs []event
+	ast.Inspect(n, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.IfStmt:
+			evs = append(evs, a.events(n.Init, info)...)
+			evs = append(evs, a.guardEvents(n.Cond, info, true)...)
+			evs = append(evs, a.events(n.Body, info)...)
+			evs = append(evs, a.events(n.Else, info)...)
+			return false
+		case *ast.ReturnStmt:
+			for _, r := range n.Results {
+				evs = append(evs, a.guardEvents(r, info, true)...)
+			}
+			return false
+		case *ast.AssignStmt:
+			evs = append(evs, a.assignEvents(n.Lhs, n.Rhs, info)...)
+			for _, l := range n.Lhs {
+				evs = append(evs, a.events(l, info)...)
+			}
+			return false
+		case *ast.ValueSpec:
+			lhs := make([]ast.Expr, len(n.Names))
+			for i := range n.Names {
+				lhs[i] = n.Names[i]
+			}
+			evs = append(evs, a.assignEvents(lhs, n.Values, info)...)
+			return false
+		case *ast.Ident:
+			if guard && a.witnessVars[info.ObjectOf(n)] {
+				evs = append(evs, event{kind: evWitness, pos: n.Pos()})
+			}
+		case *ast.ForStmt:
+			if isUnboundedIteration(n, info) {
+				evs = append(evs, event{kind: evUnboundedLoop, pos: n.Pos()})
+			}
+		case *ast.CallExpr:
+			argGuard := guard
+			if id, ok := n.Fun.(*ast.Ident); ok && id.Name == "panic" {
+				if _, ok := info.Uses[id].(*types.Builtin); ok {
+					argGuard = true
+				}
+			}
+			// Function expression and arguments are evaluated before
+			// the call itself.
+			evs = append(evs, a.guardEvents(n.Fun, info, guard)...)
+			for _, arg := range n.Args {
+				evs = append(evs, a.guardEvents(arg, info, argGuard)...)
+			}
+			evs = append(evs, a.callEvents(n, info, guard)...)
+			return false
+		}
+		return true
+	})
+	return evs
+}
+
+// assignEvents returns events of the assigned values. Variables witness check
+// results are assigned to are remembered, so that checks are counted once
+// these variables are used in guards.
+func (a *analyzer) assignEvents(lhs []ast.Expr, rhs []ast.Expr, info *types.Info) []event {
+	var evs []event
+	for i, r := range rhs {
+		call, ok := unparen(r).(*ast.CallExpr)
+		if ok && len(lhs) == len(rhs) && isWitnessCall(call, info) {
+			if id, ok := lhs[i].(*ast.Ident); ok && id.Name != "_" {
+				if obj := info.ObjectOf(id); obj != nil {
+					a.witnessVars[obj] = true
+					for _, arg := range call.Args {
+						evs = append(evs, a.events(arg, info)...)
+					}
+					continue
+				}
+			}
+		}
+		evs = append(evs, a.events(r, info)...)
+	}
+	return evs
+}
+
+// unparen returns the expression with enclosing parentheses removed.
+func unparen(e ast.Expr) ast.Expr {
+	for {
+		p, ok := e.(*ast.ParenExpr)
+		if !ok {
+			return e
+		}
+		e = p.X
+	}
+}
+
+// isWitnessCall returns true if the call is runtime.CheckWitness one.
+func isWitnessCall(call *ast.CallExpr, info *types.Info) bool {
+	fn := calledFunc(call, info)
+	return fn != nil && fn.Pkg() != nil && fn.Pkg().Path() == interopPrefix+"/runtime" && fn.Name() == "CheckWitness"
+}
+
+// callEvents returns events of the function call, calls to contract
+// functions are followed. guard is true if the call result controls the
+// execution.
+func (a *analyzer) callEvents(call *ast.CallExpr, info *types.Info, guard bool) []event {
+	fn := calledFunc(call, info)
+	if fn == nil || fn.Pkg() == nil {
+		return nil
+	}
+	ev := event{pos: call.Pos()}
+	switch fn.Pkg().Path() + "." + fn.Name() {
+	case interopPrefix + "/runtime.CheckWitness":
+		ev.kind = evWitness
+		if !guard {
+			ev.kind = evIgnoredWitness
+		}
+	case interopPrefix + "/contract.Call":
+		ev.kind = evExternalCall
+		if len(call.Args) > 1 {
+			tv := info.Types[call.Args[1]]
+			if tv.Value != nil && tv.Value.Kind() == constant.String &&
+				constant.StringVal(tv.Value) == "transfer" {
+				ev.kind = evTransfer
+			}
+		}
+	case interopPrefix + "/native/neo.Transfer", interopPrefix + "/native/gas.Transfer",
+		interopPrefix + "/native/nameservice.Transfer":
+		ev.kind = evTransfer
+	case interopPrefix + "/native/management.Update", interopPrefix + "/native/management.UpdateWithData",
+		interopPrefix + "/native/management.Destroy":
+		ev.kind = evUpdate
+	case interopPrefix + "/storage.Put", interopPrefix + "/storage.Delete":
+		ev.kind = evStorageWrite
+	default:
+		body, ok := a.funcs[fn]
+		if !ok || a.visited[body.decl] {
+			return nil
+		}
+		a.visited[body.decl] = true
+		evs := a.events(body.decl.Body, body.info)
+		delete(a.visited, body.decl)
+		return evs
+	}
+	return []event{ev}
+}
+
+// calledFunc returns the function called (nil for calls of function values,
+// builtins and conversions).
+func calledFunc(call *ast.CallExpr, info *types.Info) *types.Func {
+	var id *ast.Ident
+	switch f := call.Fun.(type) {
+	case *ast.Ident:
+		id = f
+	case *ast.SelectorExpr:
+		id = f.Sel
+	default:
+		return nil
+	}
+	fn, _ := info.Uses[id].(*types.Func)
+	return fn
+}
+
+// isUnboundedIteration returns true if the loop iterates over all iterator
+// elements without any bound, i.e. its condition is iterator.Next call only
+// and its body doesn't contain break or return statements.
+func isUnboundedIteration(loop *ast.ForStmt, info *types.Info) bool {
+	call, ok := loop.Cond.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	fn := calledFunc(call, info)
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != interopPrefix+"/iterator" || fn.Name() != "Next" {
+		return false
+	}
+	bounded := false
+	ast.Inspect(loop.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			bounded = true
+		case *ast.BranchStmt:
+			if n.Tok == token.BREAK {
+				bounded = true
+			}
+		}
+		return !bounded
+	})
+	return !bounded
+}
+
+// pendingFinding is a finding with position not yet resolved.
+type pendingFinding struct {
+	Finding
+	pos token.Pos
+}
+
+// checkEvents performs all checks on the events of the method.
+func checkEvents(method string, evs []event) []pendingFinding {
+	var (
+		findings []pendingFinding
+		seen     = make(map[string]bool)
+		witness  bool
+		external bool
+	)
+	report := func(sev Severity, check string, pos token.Pos, msg string) {
+		key := fmt.Sprintf("%s:%d", check, pos)
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		findings = append(findings, pendingFinding{
+			Finding: Finding{
+				Severity: sev,
+				Check:    check,
+				Method:   method,
+				Message:  msg,
+			},
+			pos: pos,
+		})
+	}
+	isCallback := strings.EqualFold(method, manifest.MethodOnNEP17Payment) ||
+		strings.EqualFold(method, manifest.MethodOnNEP11Payment)
+	for _, ev := range evs {
+		switch ev.kind {
+		case evWitness:
+			witness = true
+		case evIgnoredWitness:
+			report(SeverityLow, CheckIgnoredWitness, ev.pos, "witness check result is not used")
+		case evTransfer, evExternalCall:
+			if ev.kind == evTransfer && !witness {
+				report(SeverityHigh, CheckUncheckedWitness, ev.pos, "token transfer without witness check")
+			}
+			if isCallback {
+				report(SeverityMedium, CheckReentrancy, ev.pos, "external call from payment callback, called contract can re-enter")
+			}
+			external = true
+		case evStorageWrite:
+			if external {
+				report(SeverityMedium, CheckReentrancy, ev.pos,
+					"storage is modified after external call, called contract (or transfer recipient via onNEP17Payment) can re-enter before the state is updated")
+			}
+		case evUpdate:
+			if !witness {
+				report(SeverityHigh, CheckUnprotectedUpdate, ev.pos, "contract update or destruction without witness check")
+			}
+		case evUnboundedLoop:
+			report(SeverityMedium, CheckUnboundedIteration, ev.pos, "unbounded storage iteration, GAS usage grows with the storage size")
+		}
+	}
+	return findings
+}
@@ -0,0 +1,113 @@
+package compiler_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyze(t *testing.T) {
+	src := `package foo
+	import (
+		"github.com/nspcc-dev/neo-go/pkg/interop"
+		"github.com/nspcc-dev/neo-go/pkg/interop/contract"
+		"github.com/nspcc-dev/neo-go/pkg/interop/iterator"
+		"github.com/nspcc-dev/neo-go/pkg/interop/native/gas"
+		"github.com/nspcc-dev/neo-go/pkg/interop/native/management"
+		"github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+		"github.com/nspcc-dev/neo-go/pkg/interop/storage"
+	)
+	var owner = interop.Hash160("aaaaaaaaaaaaaaaaaaaa")
+	func checkOwner() {
+		if !runtime.CheckWitness(owner) {
+			panic("not an owner")
+		}
+	}
+	func Withdraw(to interop.Hash160, amount int) {
+		gas.Transfer(runtime.GetExecutingScriptHash(), to, amount, nil)
+	}
+	func SafeWithdraw(to interop.Hash160, amount int) {
+		checkOwner()
+		ctx := storage.GetContext()
+		storage.Put(ctx, to, 0)
+		contract.Call(to, "transfer", contract.All, runtime.GetExecutingScriptHash(), to, amount, nil)
+	}
+	func Claim(to interop.Hash160) {
+		checkOwner()
+		ctx := storage.GetContext()
+		amount := storage.Get(ctx, to).(int)
+		gas.Transfer(runtime.GetExecutingScriptHash(), to, amount, nil)
+		storage.Delete(ctx, to)
+	}
+	func OnNEP17Payment(from interop.Hash160, amount int, data interface{}) {
+		contract.Call(runtime.GetCallingScriptHash(), "balanceOf", contract.ReadOnly, from)
+	}
+	func Sum() int {
+		var sum int
+		it := storage.Find(storage.GetReadOnlyContext(), "b", storage.ValuesOnly)
+		for iterator.Next(it) {
+			sum += iterator.Value(it).(int)
+		}
+		return sum
+	}
+	func First() interface{} {
+		it := storage.Find(storage.GetReadOnlyContext(), "b", storage.ValuesOnly)
+		for iterator.Next(it) {
+			return iterator.Value(it)
+		}
+		return nil
+	}
+	func Update(script, manifest []byte) {
+		management.Update(script, manifest)
+	}
+	func Destroy() {
+		checkOwner()
+		management.Destroy()
+	}
+	func IgnoredWithdraw(to interop.Hash160, amount int) {
+		runtime.CheckWitness(owner)
+		gas.Transfer(runtime.GetExecutingScriptHash(), to, amount, nil)
+	}
+	func VarWithdraw(to interop.Hash160, amount int) {
+		ok := runtime.CheckWitness(owner)
+		if !ok {
+			panic("not an owner")
+		}
+		gas.Transfer(runtime.GetExecutingScriptHash(), to, amount, nil)
+	}`
+	findings, err := compiler.Analyze("foo.go", strings.NewReader(src))
+	require.NoError(t, err)
+
+	type result struct {
+		sev    compiler.Severity
+		check  string
+		method string
+	}
+	actual := make([]result, len(findings))
+	for i, f := range findings {
+		actual[i] = result{f.Severity, f.Check, f.Method}
+		require.Equal(t, "foo.go", f.Position.Filename)
+	}
+	require.Equal(t, []result{
+		{compiler.SeverityHigh, compiler.CheckUncheckedWitness, "Withdraw"},
+		{compiler.SeverityHigh, compiler.CheckUnprotectedUpdate, "Update"},
+		{compiler.SeverityHigh, compiler.CheckUncheckedWitness, "IgnoredWithdraw"},
+		{compiler.SeverityMedium, compiler.CheckReentrancy, "Claim"},
+		{compiler.SeverityMedium, compiler.CheckReentrancy, "OnNEP17Payment"},
+		{compiler.SeverityMedium, compiler.CheckUnboundedIteration, "Sum"},
+		{compiler.SeverityLow, compiler.CheckIgnoredWitness, "IgnoredWithdraw"},
+	}, actual)
+	require.Equal(t, 18, findings[0].Position.Line)
+}
+
+func TestParseSeverity(t *testing.T) {
+	for _, s := range []compiler.Severity{compiler.SeverityLow, compiler.SeverityMedium, compiler.SeverityHigh} {
+		actual, err := compiler.ParseSeverity(strings.ToUpper(s.String()))
+		require.NoError(t, err)
+		require.Equal(t, s, actual)
+	}
+	_, err := compiler.ParseSeverity("critical")
+	require.Error(t, err)
+}
@@ -0,0 +1,182 @@
+package compiler
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/bigint"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+// asmCommentPrefix starts a comment in the assembly code.
+const asmCommentPrefix = "//"
+
+// asmLabel is a label defined or referenced in the assembly code.
+type asmLabel struct {
+	id      uint16
+	defined bool
+}
+
+// emitAsm emits hand-written assembly code. Every instruction is an opcode
+// name followed by its operands separated by spaces, labels are defined as
+// `name:` and can be used as operands of jump and call instructions.
+func (c *codegen) emitAsm(code string) error {
+	labels := make(map[string]*asmLabel)
+	getLabel := func(name string) *asmLabel {
+		l, ok := labels[name]
+		if !ok {
+			l = &asmLabel{id: c.newLabel()}
+			labels[name] = l
+		}
+		return l
+	}
+
+	var tokens []string
+	for _, line := range strings.Split(code, "\n") {
+		if i := strings.Index(line, asmCommentPrefix); i >= 0 {
+			line = line[:i]
+		}
+		tokens = append(tokens, strings.Fields(line)...)
+	}
+	for len(tokens) != 0 {
+		tok := tokens[0]
+		tokens = tokens[1:]
+		if strings.HasSuffix(tok, ":") {
+			name := strings.TrimSuffix(tok, ":")
+			l := getLabel(name)
+			if l.defined {
+				return fmt.Errorf("label %s is already defined", name)
+			}
+			l.defined = true
+			c.setLabel(l.id)
+			continue
+		}
+		op, err := opcode.FromString(tok)
+		if err != nil {
+			return fmt.Errorf("invalid opcode: %s", tok)
+		}
+		n := asmOperandCount(op)
+		if n < 0 {
+			return fmt.Errorf("%s is not supported in assembly", op)
+		}
+		if len(tokens) < n {
+			return fmt.Errorf("%s: missing operand", op)
+		}
+		args := tokens[:n]
+		tokens = tokens[n:]
+		if err := c.emitAsmInstruction(op, args, getLabel); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	for name, l := range labels {
+		if !l.defined {
+			return fmt.Errorf("label %s is not defined", name)
+		}
+	}
+	return nil
+}
+
+// asmOperandCount returns the number of operands of the instruction or -1 if
+// the instruction can't be used in assembly.
+func asmOperandCount(op opcode.Opcode) int {
+	switch {
+	case op == opcode.TRY, op == opcode.TRYL, op == opcode.ENDTRY, op == opcode.ENDTRYL:
+		return -1
+	case op <= opcode.PUSHINT256, op == opcode.PUSHA,
+		opcode.PUSHDATA1 <= op && op <= opcode.PUSHDATA4,
+		opcode.JMP <= op && op <= opcode.CALLL,
+		op == opcode.CALLT, op == opcode.SYSCALL,
+		op == opcode.ISTYPE, op == opcode.CONVERT, op == opcode.NEWARRAYT,
+		op == opcode.INITSSLOT, op == opcode.LDSFLD, op == opcode.STSFLD,
+		op == opcode.LDLOC, op == opcode.STLOC, op == opcode.LDARG, op == opcode.STARG:
+		return 1
+	case op == opcode.INITSLOT:
+		return 2
+	default:
+		return 0
+	}
+}
+
+func (c *codegen) emitAsmInstruction(op opcode.Opcode, args []string, getLabel func(string) *asmLabel) error {
+	w := c.prog.BinWriter
+	switch {
+	case op <= opcode.PUSHINT256:
+		n, ok := new(big.Int).SetString(args[0], 0)
+		if !ok {
+			return fmt.Errorf("invalid integer: %s", args[0])
+		}
+		size := 1 << op
+		buf := bigint.ToBytes(n)
+		if len(buf) > size {
+			return fmt.Errorf("%s doesn't fit into %d bytes", args[0], size)
+		}
+		param := make([]byte, size)
+		copy(param, buf)
+		if n.Sign() < 0 {
+			for i := len(buf); i < size; i++ {
+				param[i] = 0xFF
+			}
+		}
+		emit.Instruction(w, op, param)
+	case opcode.PUSHDATA1 <= op && op <= opcode.PUSHDATA4:
+		data, err := hex.DecodeString(strings.TrimPrefix(args[0], "0x"))
+		if err != nil {
+			return err
+		}
+		size := 1 << (op - opcode.PUSHDATA1)
+		if size < 4 && len(data) >= 1<<(8*size) {
+			return errors.New("data is too big")
+		}
+		param := make([]byte, 4)
+		binary.LittleEndian.PutUint32(param, uint32(len(data)))
+		emit.Instruction(w, op, append(param[:size], data...))
+	case opcode.JMP <= op && op <= opcode.CALLL:
+		// Label offsets are written along with the program jumps, short
+		// forms are restored there when possible.
+		emit.Jmp(w, toLongForm(op), getLabel(args[0]).id)
+	case op == opcode.PUSHA:
+		buf := make([]byte, 4)
+		binary.LittleEndian.PutUint16(buf, getLabel(args[0]).id)
+		emit.Instruction(w, op, buf)
+	case op == opcode.SYSCALL:
+		if _, err := interopnames.FromID(interopnames.ToID([]byte(args[0]))); err != nil {
+			return fmt.Errorf("unknown syscall: %s", args[0])
+		}
+		emit.Syscall(w, args[0])
+	case op == opcode.ISTYPE, op == opcode.CONVERT, op == opcode.NEWARRAYT:
+		typ, err := stackitem.FromString(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid type: %s", args[0])
+		}
+		emit.Instruction(w, op, []byte{byte(typ)})
+	case op == opcode.CALLT:
+		id, err := strconv.ParseUint(args[0], 0, 16)
+		if err != nil {
+			return err
+		}
+		buf := make([]byte, 2)
+		binary.LittleEndian.PutUint16(buf, uint16(id))
+		emit.Instruction(w, op, buf)
+	case len(args) != 0:
+		param := make([]byte, len(args))
+		for i := range args {
+			b, err := strconv.ParseUint(args[i], 0, 8)
+			if err != nil {
+				return err
+			}
+			param[i] = byte(b)
+		}
+		emit.Instruction(w, op, param)
+	default:
+		emit.Opcodes(w, op)
+	}
+	return w.Err
+}
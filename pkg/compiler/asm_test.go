@@ -0,0 +1,85 @@
+package compiler_test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAsm(t *testing.T) {
+	t.Run("arguments", func(t *testing.T) {
+		src := `package foo
+		import "github.com/nspcc-dev/neo-go/pkg/interop/neogointernal"
+		func Main() int {
+			a, b := 2, 5
+			return neogointernal.Asm("ADD PUSHINT8 -3 MUL", a, b).(int)
+		}`
+		eval(t, src, big.NewInt(-21))
+	})
+	t.Run("labels", func(t *testing.T) {
+		src := "package foo\n" +
+			"import \"github.com/nspcc-dev/neo-go/pkg/interop/neogointernal\"\n" +
+			"func sum(n int) int {\n" +
+			"	return neogointernal.Asm(`\n" +
+			"		PUSH0 SWAP       // acc n\n" +
+			"	loop:\n" +
+			"		DUP JMPIFNOT end\n" +
+			"		DUP ROT ADD SWAP // acc+n n\n" +
+			"		DEC JMP loop\n" +
+			"	end:\n" +
+			"		DROP`, n).(int)\n" +
+			"}\n" +
+			"func Main() int {\n" +
+			"	return sum(4)\n" +
+			"}\n"
+		eval(t, src, big.NewInt(10))
+	})
+	t.Run("operands", func(t *testing.T) {
+		src := `package foo
+		import "github.com/nspcc-dev/neo-go/pkg/interop/neogointernal"
+		func Main() int {
+			return neogointernal.Asm("PUSHDATA1 0x05 CONVERT Integer").(int)
+		}`
+		eval(t, src, big.NewInt(5))
+	})
+	t.Run("no return", func(t *testing.T) {
+		src := `package foo
+		import "github.com/nspcc-dev/neo-go/pkg/interop/neogointernal"
+		func Main() int {
+			a := 3
+			neogointernal.AsmNoReturn("PUSH1 ADD DROP", a)
+			neogointernal.Asm("PUSH7")
+			return a
+		}`
+		eval(t, src, big.NewInt(3))
+	})
+	t.Run("errors", func(t *testing.T) {
+		codes := map[string]string{
+			"invalid opcode":    `"PUSH42"`,
+			"missing operand":   `"PUSHINT8"`,
+			"big integer":       `"PUSHINT8 1000"`,
+			"invalid data":      `"PUSHDATA1 xyz"`,
+			"undefined label":   `"JMP end"`,
+			"duplicate label":   `"a: a: NOP"`,
+			"unknown syscall":   `"SYSCALL System.Unknown"`,
+			"invalid type":      `"ISTYPE Kek"`,
+			"unsupported":       `"TRY 1 2"`,
+			"not a constant":    `code`,
+			"variadic argument": `"NOP", args...`,
+		}
+		for name, code := range codes {
+			t.Run(name, func(t *testing.T) {
+				src := `package foo
+				import "github.com/nspcc-dev/neo-go/pkg/interop/neogointernal"
+				func Main(code string, args []interface{}) {
+					neogointernal.AsmNoReturn(` + code + `)
+				}`
+				_, err := compiler.Compile("foo.go", strings.NewReader(src))
+				require.Error(t, err)
+			})
+		}
+	})
+}
@@ -0,0 +1,161 @@
+package compiler
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"sort"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// FlagNoOptimize is the build flag used when optimizations are disabled.
+const FlagNoOptimize = "no-optimize"
+
+// BuildMetadata is the information required to reproduce contract build, it's
+// a part of debug info.
+type BuildMetadata struct {
+	// Compiler is the compiler name and version, it's the same as the one
+	// in NEF.
+	Compiler string `json:"compiler"`
+	// Flags are the compiler options affecting generated bytecode.
+	Flags []string `json:"flags,omitempty"`
+	// Sources are all contract source files except interop packages which
+	// are defined by the compiler version. Main package files are named by
+	// their base name, others are prefixed with package path.
+	Sources []result.SourceFile `json:"sources"`
+	// SourceHash is SHA256 of all source names and hashes.
+	SourceHash util.Uint256 `json:"sourcehash"`
+	// Checksum is the checksum of NEF file produced. It's only known when NEF
+	// is created, so it's zero for debug info returned by CodeGen.
+	Checksum uint32 `json:"checksum,omitempty"`
+}
+
+// buildMetadata returns build metadata for the program compiled.
+func (c *codegen) buildMetadata() (*BuildMetadata, error) {
+	m := &BuildMetadata{
+		Compiler: "neo-go-" + config.Version,
+		Sources:  []result.SourceFile{},
+	}
+	if !c.optimizationsEnabled() {
+		m.Flags = append(m.Flags, FlagNoOptimize)
+	}
+	fset := c.buildInfo.program.Fset
+	for _, pkgPath := range c.packages {
+		if isInteropPath(pkgPath) {
+			continue
+		}
+		pkg := c.buildInfo.program.Package(pkgPath)
+		for _, f := range pkg.Files {
+			filePath := fset.Position(f.Pos()).Filename
+			data, ok := c.buildInfo.sources[filePath]
+			if !ok {
+				var err error
+				data, err = ioutil.ReadFile(filePath)
+				if err != nil {
+					return nil, fmt.Errorf("can't read source file: %w", err)
+				}
+			}
+			name := path.Base(filePath)
+			if pkg != c.mainPkg {
+				name = pkgPath + "/" + name
+			}
+			m.Sources = append(m.Sources, result.SourceFile{Name: name, Hash: hash.Sha256(data)})
+		}
+	}
+	sort.Slice(m.Sources, func(i, j int) bool { return m.Sources[i].Name < m.Sources[j].Name })
+	m.SourceHash = sourceHash(m.Sources)
+	return m, nil
+}
+
+// sourceHash returns the hash of the source file list.
+func sourceHash(sources []result.SourceFile) util.Uint256 {
+	var b []byte
+	for _, s := range sources {
+		b = append(b, s.Name...)
+		b = append(b, s.Hash.BytesBE()...)
+	}
+	return hash.Sha256(b)
+}
+
+// options returns compiler options corresponding to metadata flags.
+func (m *BuildMetadata) options() (*Options, error) {
+	o := new(Options)
+	for _, f := range m.Flags {
+		switch f {
+		case FlagNoOptimize:
+			o.NoOptimize = true
+		default:
+			return nil, fmt.Errorf("unknown build flag %q", f)
+		}
+	}
+	return o, nil
+}
+
+// ReproduceBuild compiles contract source (name and r have the same meaning as
+// for Compile) with the flags from the given build metadata and checks that
+// the same sources and compiler version are used and the same NEF is
+// produced. It returns the NEF compiled along with the list of mismatches
+// found (empty if the build is reproduced). An error is only returned if the
+// source can't be compiled.
+func ReproduceBuild(name string, r io.Reader, meta *BuildMetadata) (*nef.File, []string, error) {
+	o, err := meta.options()
+	if err != nil {
+		return nil, nil, err
+	}
+	b, di, err := CompileWithOptions(name, r, o)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to compile: %w", err)
+	}
+	f, err := nef.NewFile(b)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create .nef file: %w", err)
+	}
+	var problems []string
+	if meta.Compiler != f.Compiler {
+		problems = append(problems, fmt.Sprintf("compiler mismatch: %s in metadata, %s used", meta.Compiler, f.Compiler))
+	}
+	srcProblems := compareSources(meta.Sources, di.Build.Sources)
+	problems = append(problems, srcProblems...)
+	if len(srcProblems) == 0 && meta.SourceHash != di.Build.SourceHash {
+		problems = append(problems, "source hash mismatch")
+	}
+	if meta.Checksum != f.Checksum {
+		problems = append(problems, fmt.Sprintf("NEF checksum mismatch: %d in metadata, %d compiled", meta.Checksum, f.Checksum))
+	}
+	return f, problems, nil
+}
+
+// compareSources reports differences between expected and actual source file
+// lists.
+func compareSources(expected, actual []result.SourceFile) []string {
+	var (
+		problems []string
+		hashes   = make(map[string]util.Uint256, len(actual))
+		seen     = make(map[string]bool, len(expected))
+	)
+	for _, s := range actual {
+		hashes[s.Name] = s.Hash
+	}
+	for _, s := range expected {
+		seen[s.Name] = true
+		h, ok := hashes[s.Name]
+		switch {
+		case !ok:
+			problems = append(problems, fmt.Sprintf("source file %s is missing", s.Name))
+		case h != s.Hash:
+			problems = append(problems, fmt.Sprintf("source file %s differs", s.Name))
+		}
+	}
+	for _, s := range actual {
+		if !seen[s.Name] {
+			problems = append(problems, fmt.Sprintf("unexpected source file %s", s.Name))
+		}
+	}
+	return problems
+}
@@ -0,0 +1,78 @@
+package compiler_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReproduceBuild(t *testing.T) {
+	config.Version = "0.90.0-test"
+
+	src := `package foo
+	import "github.com/nspcc-dev/neo-go/pkg/compiler/testdata/foo"
+	import "github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+	func Main() int {
+		runtime.Log("main")
+		if false {
+			runtime.Log("never")
+		}
+		return foo.NewBar()
+	}`
+	b, di, err := compiler.CompileWithOptions("foo.go", strings.NewReader(src), &compiler.Options{NoOptimize: true})
+	require.NoError(t, err)
+	f, err := nef.NewFile(b)
+	require.NoError(t, err)
+
+	meta := di.Build
+	require.NotNil(t, meta)
+	require.Equal(t, f.Compiler, meta.Compiler)
+	require.Equal(t, []string{compiler.FlagNoOptimize}, meta.Flags)
+	require.Equal(t, 2, len(meta.Sources))
+	require.Equal(t, "foo.go", meta.Sources[0].Name)
+	require.Equal(t, hash.Sha256([]byte(src)), meta.Sources[0].Hash)
+	require.Equal(t, "github.com/nspcc-dev/neo-go/pkg/compiler/testdata/foo/foo.go", meta.Sources[1].Name)
+	meta.Checksum = f.Checksum
+
+	t.Run("good", func(t *testing.T) {
+		actual, problems, err := compiler.ReproduceBuild("foo.go", strings.NewReader(src), meta)
+		require.NoError(t, err)
+		require.Empty(t, problems)
+		require.Equal(t, f, actual)
+	})
+	t.Run("flags", func(t *testing.T) {
+		m := *meta
+		m.Flags = nil
+		_, problems, err := compiler.ReproduceBuild("foo.go", strings.NewReader(src), &m)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(problems)) // Unreachable code is removed.
+
+		m.Flags = []string{"unknown"}
+		_, _, err = compiler.ReproduceBuild("foo.go", strings.NewReader(src), &m)
+		require.Error(t, err)
+	})
+	t.Run("compiler", func(t *testing.T) {
+		m := *meta
+		m.Compiler = "neo-go-0.1.0"
+		_, problems, err := compiler.ReproduceBuild("foo.go", strings.NewReader(src), &m)
+		require.NoError(t, err)
+		require.Equal(t, []string{"compiler mismatch: neo-go-0.1.0 in metadata, neo-go-0.90.0-test used"}, problems)
+	})
+	t.Run("sources", func(t *testing.T) {
+		changed := strings.Replace(src, `"main"`, `"changed"`, 1)
+		_, problems, err := compiler.ReproduceBuild("bar.go", strings.NewReader(changed), meta)
+		require.NoError(t, err)
+		require.Equal(t, "source file foo.go is missing", problems[0])
+		require.Equal(t, "unexpected source file bar.go", problems[1])
+		require.Equal(t, 3, len(problems)) // And checksum.
+
+		_, problems, err = compiler.ReproduceBuild("foo.go", strings.NewReader(changed), meta)
+		require.NoError(t, err)
+		require.Equal(t, "source file foo.go differs", problems[0])
+	})
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
@@ -95,6 +96,9 @@ type codegen struct {
 
 	// emittedEvents contains all events emitted by contract.
 	emittedEvents map[string][][]string
+	// invokedContracts contains methods of other contracts called by
+	// contract, zero hash is used for contracts not known at compile-time.
+	invokedContracts map[util.Uint160][]string
 
 	// Label table for recording jump destinations.
 	l []int
@@ -472,6 +476,10 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 	if c.prog.Err != nil {
 		return nil
 	}
+	if o := c.buildInfo.options; o != nil && o.Context != nil && o.Context.Err() != nil {
+		c.prog.Err = o.Context.Err()
+		return nil
+	}
 	switch n := node.(type) {
 
 	// General declarations.
@@ -656,6 +664,15 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 		if n.Init != nil {
 			ast.Walk(c, n.Init)
 		}
+		if cond, ok := c.constantCondition(n.Cond); ok {
+			// Only the branch being taken is emitted.
+			if cond {
+				ast.Walk(c, n.Body)
+			} else if n.Else != nil {
+				ast.Walk(c, n.Else)
+			}
+			return nil
+		}
 		if n.Cond != nil {
 			c.emitBoolExpr(n.Cond, true, false, lElse)
 		}
@@ -853,6 +870,7 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 				f.selector = fun.X.(*ast.Ident)
 				isBuiltin = isCustomBuiltin(f)
 				if canInline(f.pkg.Path()) {
+					c.processContractCall(f, n.Args)
 					c.inlineCall(f, n)
 					return nil
 				}
@@ -1176,6 +1194,19 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 		emit.Opcodes(c.prog.BinWriter, opcode.OVER, opcode.OVER)
 		emit.Jmp(c.prog.BinWriter, opcode.JMPLEL, end)
 
+		if isMap {
+			// Keys are iterated over in insertion order, but the ones removed
+			// from the map during iteration should be skipped the same way
+			// Go does it.
+			c.rangeLoadKey()
+			emit.Int(c.prog.BinWriter, 4)
+			emit.Opcodes(c.prog.BinWriter,
+				opcode.PICK, // load map itself (+1 because key was pushed)
+				opcode.SWAP, // key should be on top
+				opcode.HASKEY)
+			emit.Jmp(c.prog.BinWriter, opcode.JMPIFNOTL, post)
+		}
+
 		var keyLoaded bool
 		needValue := n.Value != nil && n.Value.(*ast.Ident).Name != "_"
 		if n.Key != nil && n.Key.(*ast.Ident).Name != "_" {
@@ -1553,8 +1584,20 @@ func (c *codegen) convertSyscall(f *funcScope, expr *ast.CallExpr) {
 		ast.Walk(c, arg)
 	}
 	tv := c.typeAndValueOf(expr.Args[0])
+	if tv.Value == nil || tv.Value.Kind() != constant.String {
+		c.prog.Err = fmt.Errorf("%s: first argument must be a constant string", f.name)
+		return
+	}
 	name := constant.StringVal(tv.Value)
-	if strings.HasPrefix(f.name, "Syscall") {
+	if strings.HasPrefix(f.name, "Asm") {
+		if expr.Ellipsis.IsValid() {
+			c.prog.Err = errors.New("assembly arguments can't be passed as a slice")
+			return
+		}
+		if err := c.emitAsm(name); err != nil {
+			c.prog.Err = fmt.Errorf("invalid assembly: %w", err)
+		}
+	} else if strings.HasPrefix(f.name, "Syscall") {
 		c.emitReverse(len(expr.Args) - 1)
 		emit.Syscall(c.prog.BinWriter, name)
 
@@ -2050,11 +2093,20 @@ func CodeGen(info *buildInfo) ([]byte, *DebugInfo, error) {
 		return nil, nil, err
 	}
 
-	buf, err := c.writeJumps(c.prog.Bytes())
+	buf := c.prog.Bytes()
+	if c.optimizationsEnabled() {
+		buf = c.removeRedundantCode(buf)
+	}
+	buf, err := c.writeJumps(buf)
+	if err != nil {
+		return nil, nil, err
+	}
+	di := c.emitDebugInfo(buf)
+	di.Build, err = c.buildMetadata()
 	if err != nil {
 		return nil, nil, err
 	}
-	return buf, c.emitDebugInfo(buf), nil
+	return buf, di, nil
 }
 
 func (c *codegen) resolveFuncDecls(f *ast.File, pkg *types.Package) {
@@ -2118,6 +2170,13 @@ func (c *codegen) writeJumps(b []byte) ([]byte, error) {
 	for _, f := range c.funcs {
 		f.rng.Start, f.rng.End = correctRange(f.rng.Start, f.rng.End, offsets)
 	}
+	// Correct sequence points, every instruction shortened before the
+	// sequence point moves it.
+	for _, sps := range c.sequencePoints {
+		for i := range sps {
+			sps[i].Opcode -= sort.SearchInts(offsets, sps[i].Opcode) * longToShortRemoveCount
+		}
+	}
 	return shortenJumps(b, offsets), nil
 }
 
@@ -2265,6 +2324,35 @@ func negateJmp(op opcode.Opcode) opcode.Opcode {
 	}
 }
 
+func toLongForm(op opcode.Opcode) opcode.Opcode {
+	switch op {
+	case opcode.JMP:
+		return opcode.JMPL
+	case opcode.JMPIF:
+		return opcode.JMPIFL
+	case opcode.JMPIFNOT:
+		return opcode.JMPIFNOTL
+	case opcode.JMPEQ:
+		return opcode.JMPEQL
+	case opcode.JMPNE:
+		return opcode.JMPNEL
+	case opcode.JMPGT:
+		return opcode.JMPGTL
+	case opcode.JMPGE:
+		return opcode.JMPGEL
+	case opcode.JMPLE:
+		return opcode.JMPLEL
+	case opcode.JMPLT:
+		return opcode.JMPLTL
+	case opcode.CALL:
+		return opcode.CALLL
+	case opcode.ENDTRY:
+		return opcode.ENDTRYL
+	default:
+		return op
+	}
+}
+
 func toShortForm(op opcode.Opcode) opcode.Opcode {
 	switch op {
 	case opcode.JMPL:
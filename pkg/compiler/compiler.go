@@ -1,16 +1,19 @@
 package compiler
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"go/ast"
+	"go/build"
 	"go/parser"
 	"go/types"
 	"io"
 	"io/ioutil"
 	"os"
 	"path"
+	"strconv"
 	"strings"
 
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
@@ -43,6 +46,20 @@ type Options struct {
 	// This setting has effect only if manifest is emitted.
 	NoStandardCheck bool
 
+	// CheckStandards makes the compiler report all supported standards
+	// compliance problems (instead of the first one) even if manifest
+	// file is not emitted. It takes precedence over NoStandardCheck.
+	CheckStandards bool
+
+	// NoPermissionsCheck specifies if permissions need to allow all contract
+	// calls known at compile-time. This setting has effect only if manifest
+	// is emitted.
+	NoPermissionsCheck bool
+
+	// NoOptimize disables optimizations, so that bytecode is generated
+	// for every statement of the program as is.
+	NoOptimize bool
+
 	// Name is contract's name to be written to manifest.
 	Name string
 
@@ -54,11 +71,27 @@ type Options struct {
 
 	// SafeMethods contains list of methods which will be marked as safe in manifest.
 	SafeMethods []string
+
+	// Permissions is a list of permissions for every contract method. If nil,
+	// calls to any method of any contract are allowed.
+	Permissions []manifest.Permission
+
+	// Untrusted restricts compilation of sources received from third
+	// parties: source is to be given as a single file that can only import
+	// interop packages and cgo is disabled.
+	Untrusted bool
+
+	// Context, if set, allows to abort compilation, it's checked between
+	// code generation steps and the context error is returned then.
+	Context context.Context
 }
 
 type buildInfo struct {
 	initialPackage string
 	program        *loader.Program
+	options        *Options
+	// sources contains the contents of files not read from disk.
+	sources map[string][]byte
 }
 
 // ForEachPackage executes fn on each package used in the current program
@@ -101,33 +134,62 @@ func (c *codegen) fillImportMap(f *ast.File, pkg *types.Package) {
 
 func getBuildInfo(name string, src interface{}) (*buildInfo, error) {
 	conf := loader.Config{ParserMode: parser.ParseComments}
+	var sources map[string][]byte
 	if src != nil {
-		f, err := conf.ParseFile(name, src)
+		data, err := readSource(src)
+		if err != nil {
+			return nil, err
+		}
+		f, err := conf.ParseFile(name, data)
 		if err != nil {
 			return nil, err
 		}
 		conf.CreateFromFiles("", f)
+		sources = map[string][]byte{name: data}
 	} else {
-		var names []string
-		if strings.HasSuffix(name, ".go") {
-			names = append(names, name)
-		} else {
-			ds, err := ioutil.ReadDir(name)
-			if err != nil {
-				return nil, fmt.Errorf("'%s' is neither Go source nor a directory", name)
-			}
-			for i := range ds {
-				if !ds[i].IsDir() && strings.HasSuffix(ds[i].Name(), ".go") {
-					names = append(names, path.Join(name, ds[i].Name()))
-				}
-			}
-		}
-		if len(names) == 0 {
-			return nil, errors.New("no files provided")
+		names, err := sourceFileNames(name)
+		if err != nil {
+			return nil, err
 		}
 		conf.CreateFromFilenames("", names...)
 	}
 
+	return loadBuildInfo(&conf, sources)
+}
+
+// getUntrustedBuildInfo is the same as getBuildInfo for a single source file
+// given by a third party. Imports are checked before loading anything, so
+// only interop packages are loaded and no cgo is processed.
+func getUntrustedBuildInfo(name string, src interface{}) (*buildInfo, error) {
+	if src == nil {
+		return nil, errors.New("untrusted source must be given as a single file")
+	}
+	bctx := build.Default
+	bctx.CgoEnabled = false
+	conf := loader.Config{ParserMode: parser.ParseComments, Build: &bctx}
+	data, err := readSource(src)
+	if err != nil {
+		return nil, err
+	}
+	f, err := conf.ParseFile(name, data)
+	if err != nil {
+		return nil, err
+	}
+	for _, imp := range f.Imports {
+		pkgPath, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			return nil, err
+		}
+		if pkgPath != interopPrefix && !strings.HasPrefix(pkgPath, interopPrefix+"/") {
+			return nil, fmt.Errorf("%s: only interop packages can be imported, got %s", name, pkgPath)
+		}
+	}
+	conf.CreateFromFiles("", f)
+	return loadBuildInfo(&conf, map[string][]byte{name: data})
+}
+
+// loadBuildInfo loads the program configured by conf.
+func loadBuildInfo(conf *loader.Config, sources map[string][]byte) (*buildInfo, error) {
 	prog, err := conf.Load()
 	if err != nil {
 		return nil, err
@@ -136,9 +198,48 @@ func getBuildInfo(name string, src interface{}) (*buildInfo, error) {
 	return &buildInfo{
 		initialPackage: prog.InitialPackages()[0].Pkg.Name(),
 		program:        prog,
+		sources:        sources,
 	}, nil
 }
 
+// readSource returns the contents of source given as a string, byte slice or
+// io.Reader.
+func readSource(src interface{}) ([]byte, error) {
+	switch s := src.(type) {
+	case string:
+		return []byte(s), nil
+	case []byte:
+		return s, nil
+	case io.Reader:
+		return ioutil.ReadAll(s)
+	default:
+		return nil, errors.New("invalid source")
+	}
+}
+
+// sourceFileNames returns the list of source files to compile, name is either
+// Go source file or a directory with them.
+func sourceFileNames(name string) ([]string, error) {
+	var names []string
+	if strings.HasSuffix(name, ".go") {
+		names = append(names, name)
+	} else {
+		ds, err := ioutil.ReadDir(name)
+		if err != nil {
+			return nil, fmt.Errorf("'%s' is neither Go source nor a directory", name)
+		}
+		for i := range ds {
+			if !ds[i].IsDir() && strings.HasSuffix(ds[i].Name(), ".go") {
+				names = append(names, path.Join(name, ds[i].Name()))
+			}
+		}
+	}
+	if len(names) == 0 {
+		return nil, errors.New("no files provided")
+	}
+	return names, nil
+}
+
 // Compile compiles a Go program into bytecode that can run on the NEO virtual machine.
 // If `r != nil`, `name` is interpreted as a filename, and `r` as file contents.
 // Otherwise `name` is either file name or name of the directory containing source files.
@@ -153,10 +254,28 @@ func Compile(name string, r io.Reader) ([]byte, error) {
 
 // CompileWithDebugInfo compiles a Go program into bytecode and emits debug info.
 func CompileWithDebugInfo(name string, r io.Reader) ([]byte, *DebugInfo, error) {
-	ctx, err := getBuildInfo(name, r)
+	return CompileWithOptions(name, r, nil)
+}
+
+// CompileWithOptions compiles a Go program into bytecode and emits debug info
+// using the specified compiler options.
+func CompileWithOptions(name string, r io.Reader, o *Options) ([]byte, *DebugInfo, error) {
+	var (
+		ctx *buildInfo
+		err error
+	)
+	if o != nil && o.Untrusted {
+		ctx, err = getUntrustedBuildInfo(name, r)
+	} else {
+		ctx, err = getBuildInfo(name, r)
+	}
 	if err != nil {
 		return nil, nil, err
 	}
+	if o != nil && o.Context != nil && o.Context.Err() != nil {
+		return nil, nil, o.Context.Err()
+	}
+	ctx.options = o
 	return CodeGen(ctx)
 }
 
@@ -173,7 +292,7 @@ func CompileAndSave(src string, o *Options) ([]byte, error) {
 	if len(o.Ext) == 0 {
 		o.Ext = fileExt
 	}
-	b, di, err := CompileWithDebugInfo(src, nil)
+	b, di, err := CompileWithOptions(src, nil, o)
 	if err != nil {
 		return nil, fmt.Errorf("error while trying to compile smart contract file: %w", err)
 	}
@@ -181,6 +300,7 @@ func CompileAndSave(src string, o *Options) ([]byte, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error while trying to create .nef file: %w", err)
 	}
+	di.Build.Checksum = f.Checksum
 	bytes, err := f.Bytes()
 	if err != nil {
 		return nil, fmt.Errorf("error while serializing .nef file: %w", err)
@@ -190,7 +310,7 @@ func CompileAndSave(src string, o *Options) ([]byte, error) {
 	if err != nil {
 		return b, err
 	}
-	if o.DebugInfo == "" && o.ManifestFile == "" {
+	if o.DebugInfo == "" && o.ManifestFile == "" && !o.CheckStandards {
 		return b, nil
 	}
 
@@ -221,11 +341,14 @@ func CompileAndSave(src string, o *Options) ([]byte, error) {
 		}
 	}
 
-	if o.ManifestFile != "" {
+	if o.ManifestFile != "" || o.CheckStandards {
 		m, err := CreateManifest(di, o)
 		if err != nil {
 			return b, err
 		}
+		if o.ManifestFile == "" {
+			return b, nil
+		}
 		mData, err := json.Marshal(m)
 		if err != nil {
 			return b, fmt.Errorf("failed to marshal manifest to JSON: %w", err)
@@ -242,7 +365,11 @@ func CreateManifest(di *DebugInfo, o *Options) (*manifest.Manifest, error) {
 	if err != nil {
 		return m, fmt.Errorf("failed to convert debug info to manifest: %w", err)
 	}
-	if !o.NoStandardCheck {
+	if o.CheckStandards {
+		if err := checkStandards(m, o.ContractSupportedStandards); err != nil {
+			return m, err
+		}
+	} else if !o.NoStandardCheck {
 		if err := standard.CheckABI(m, o.ContractSupportedStandards...); err != nil {
 			return m, err
 		}
@@ -257,6 +384,11 @@ func CreateManifest(di *DebugInfo, o *Options) (*manifest.Manifest, error) {
 			}
 		}
 	}
+	if o.Permissions != nil && !o.NoPermissionsCheck {
+		if err := checkPermissions(di, o.Permissions); err != nil {
+			return m, err
+		}
+	}
 	if !o.NoEventsCheck {
 		for name := range di.EmittedEvents {
 			ev := m.ABI.GetEvent(name)
@@ -281,3 +413,35 @@ func CreateManifest(di *DebugInfo, o *Options) (*manifest.Manifest, error) {
 	}
 	return m, nil
 }
+
+// checkStandards checks m for compliance with all supported standards and
+// payment hooks defined by the contract, all problems found are returned
+// in a single error.
+func checkStandards(m *manifest.Manifest, standards []string) error {
+	standards = append([]string{}, standards...)
+	hooks := [][2]string{
+		{manifest.MethodOnNEP11Payment, manifest.NEP11Payable},
+		{manifest.MethodOnNEP17Payment, manifest.NEP17Payable},
+	}
+	for _, h := range hooks {
+		if m.ABI.GetMethod(h[0], -1) == nil {
+			continue
+		}
+		var declared bool
+		for i := range standards {
+			declared = declared || standards[i] == h[1]
+		}
+		if !declared {
+			standards = append(standards, h[1])
+		}
+	}
+	errs := standard.Report(m, standards...)
+	if len(errs) == 0 {
+		return nil
+	}
+	msgs := make([]string, len(errs))
+	for i := range errs {
+		msgs[i] = "\n\t" + errs[i].Error()
+	}
+	return fmt.Errorf("manifest is not compliant with supported standards:%s", strings.Join(msgs, ""))
+}
@@ -1,6 +1,8 @@
 package compiler_test
 
 import (
+	"context"
+	"errors"
 	"io/ioutil"
 	"os"
 	"path"
@@ -9,6 +11,7 @@ import (
 
 	"github.com/nspcc-dev/neo-go/pkg/compiler"
 	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 	"github.com/stretchr/testify/require"
 )
 
@@ -130,3 +133,64 @@ func TestOnPayableChecks(t *testing.T) {
 		require.Error(t, compileAndCheck(t, src))
 	})
 }
+
+func TestCheckStandards(t *testing.T) {
+	src := `package token
+	import "github.com/nspcc-dev/neo-go/pkg/interop"
+	func Symbol() string { return "TOK" }
+	func TotalSupply() int { return 1 }
+	func BalanceOf(account interop.Hash160) int { return 0 }
+	func Transfer(from, to interop.Hash160, amount string, data interface{}) bool { return false }
+	func OnNEP17Payment(from interop.Hash160, amount int) {}`
+	_, di, err := compiler.CompileWithDebugInfo("token", strings.NewReader(src))
+	require.NoError(t, err)
+
+	o := &compiler.Options{
+		ContractSupportedStandards: []string{manifest.NEP17StandardName},
+		SafeMethods:                []string{"symbol", "totalSupply", "balanceOf"},
+	}
+	_, err = compiler.CreateManifest(di, o)
+	require.Error(t, err)
+	require.NotContains(t, err.Error(), "\n")
+
+	o.CheckStandards = true
+	_, err = compiler.CreateManifest(di, o)
+	require.Error(t, err)
+	lines := strings.Split(err.Error(), "\n")
+	require.Equal(t, 5, len(lines))
+	require.Contains(t, lines[1], "'decimals'")
+	require.Contains(t, lines[2], "'transfer'")
+	require.Contains(t, lines[3], "event 'Transfer'")
+	require.Contains(t, lines[4], manifest.NEP17Payable)
+	require.Equal(t, []string{manifest.NEP17StandardName}, o.ContractSupportedStandards)
+}
+
+func TestUntrustedSource(t *testing.T) {
+	o := &compiler.Options{Untrusted: true}
+	compile := func(src string) error {
+		_, _, err := compiler.CompileWithOptions("untrusted.go", strings.NewReader(src), o)
+		return err
+	}
+
+	require.NoError(t, compile(`package foo
+	import "github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+	func Main() { runtime.Log("untrusted") }`))
+	for _, imp := range []string{"C", "os", "github.com/nspcc-dev/neo-go/pkg/interopx", "github.com/nspcc-dev/neo-go/pkg/vm"} {
+		err := compile(`package foo
+		import _ "` + imp + `"
+		func Main() int { return 1 }`)
+		require.Error(t, err, imp)
+		require.Contains(t, err.Error(), "only interop packages can be imported")
+	}
+
+	_, _, err := compiler.CompileWithOptions(path.Join(examplePath, "runtime"), nil, o)
+	require.Error(t, err)
+
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, _, err := compiler.CompileWithOptions("untrusted.go", strings.NewReader(`package foo
+		func Main() int { return 1 }`), &compiler.Options{Untrusted: true, Context: ctx})
+		require.True(t, errors.Is(err, context.Canceled))
+	})
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 )
 
@@ -24,6 +25,11 @@ type DebugInfo struct {
 	Events    []EventDebugInfo  `json:"events"`
 	// EmittedEvents contains events occurring in code.
 	EmittedEvents map[string][][]string `json:"-"`
+	// InvokedContracts contains methods of other contracts called in code,
+	// zero hash is used for contracts not known at compile-time.
+	InvokedContracts map[util.Uint160][]string `json:"-"`
+	// Build contains the information required to reproduce the build.
+	Build *BuildMetadata `json:"build,omitempty"`
 }
 
 // MethodDebugInfo represents smart-contract's method debug information.
@@ -175,6 +181,7 @@ func (c *codegen) emitDebugInfo(contract []byte) *DebugInfo {
 		d.Methods = append(d.Methods, *m)
 	}
 	d.EmittedEvents = c.emittedEvents
+	d.InvokedContracts = c.invokedContracts
 	return d
 }
 
@@ -436,13 +443,17 @@ func (di *DebugInfo) ConvertToManifest(o *Options) (*manifest.Manifest, error) {
 	if result.ABI.Events == nil {
 		result.ABI.Events = make([]manifest.Event, 0)
 	}
-	result.Permissions = []manifest.Permission{
-		{
-			Contract: manifest.PermissionDesc{
-				Type: manifest.PermissionWildcard,
+	if o.Permissions != nil {
+		result.Permissions = o.Permissions
+	} else {
+		result.Permissions = []manifest.Permission{
+			{
+				Contract: manifest.PermissionDesc{
+					Type: manifest.PermissionWildcard,
+				},
+				Methods: manifest.WildStrings{},
 			},
-			Methods: manifest.WildStrings{},
-		},
+		}
 	}
 	return result, nil
 }
@@ -306,6 +306,37 @@ func TestSequencePoints(t *testing.T) {
 	require.Equal(t, 6, ps[1].StartLine)
 }
 
+func TestSequencePointsAfterJumpsShortening(t *testing.T) {
+	src := `package foo
+	func Main(a int) int {
+		b := f(a)
+		if b > 1 {
+			return b
+		}
+		return 0
+	}
+	func f(a int) int {
+		return a + 1
+	}`
+
+	info, err := getBuildInfo("foo.go", src)
+	require.NoError(t, err)
+	buf, d, err := CodeGen(info)
+	require.NoError(t, err)
+
+	// Sequence points of return statements point to RET instructions.
+	var cnt int
+	for _, m := range d.Methods {
+		for _, sp := range m.SeqPoints {
+			if sp.StartLine == 5 || sp.StartLine == 7 || sp.StartLine == 10 {
+				require.Equal(t, byte(opcode.RET), buf[sp.Opcode], "line %d", sp.StartLine)
+				cnt++
+			}
+		}
+	}
+	require.Equal(t, 3, cnt)
+}
+
 func TestDebugInfo_MarshalJSON(t *testing.T) {
 	d := &DebugInfo{
 		Documents: []string{"/path/to/file"},
@@ -737,6 +737,57 @@ func TestForLoopRangeMap(t *testing.T) {
 	eval(t, src, big.NewInt(42))
 }
 
+func TestForLoopRangeMapOrder(t *testing.T) {
+	src := `package foo
+	func Main() []int {
+		m := map[int]int{
+			3: 1,
+			1: 2,
+			2: 3,
+		}
+		m[0] = 4
+		m[1] = 5
+		var res []int
+		for k, v := range m {
+			res = append(res, k, v)
+		}
+		return res
+	}`
+
+	eval(t, src, []stackitem.Item{
+		stackitem.NewBigInteger(big.NewInt(3)),
+		stackitem.NewBigInteger(big.NewInt(1)),
+		stackitem.NewBigInteger(big.NewInt(1)),
+		stackitem.NewBigInteger(big.NewInt(5)),
+		stackitem.NewBigInteger(big.NewInt(2)),
+		stackitem.NewBigInteger(big.NewInt(3)),
+		stackitem.NewBigInteger(big.NewInt(0)),
+		stackitem.NewBigInteger(big.NewInt(4)),
+	})
+}
+
+func TestForLoopRangeMapDelete(t *testing.T) {
+	src := `package foo
+	func Main() int {
+		m := map[int]int{
+			1: 10,
+			2: 20,
+			3: 30,
+		}
+		var sum int
+		for k, v := range m {
+			delete(m, 2)
+			sum += k + v
+		}
+		for k := range m {
+			sum += k * 100
+		}
+		return sum
+	}`
+
+	eval(t, src, big.NewInt(44+400))
+}
+
 func TestForLoopRangeTypeConversion(t *testing.T) {
 	src := `package foo
 	type intArr []int
@@ -115,6 +115,34 @@ func TestFromAddress(t *testing.T) {
 	})
 }
 
+func TestNeoFSURLs(t *testing.T) {
+	url := "neofs:C3swfg8MiMJ9bXbeFG6dWJTCoHp9hAEZkHezvbSwK1Cc/3nQH1L8u3eM9jt2mZCs6MyjzdjerdSzBkXCYYj4M4Znk"
+	for name, tc := range map[string]string{
+		"ObjectURL": url,
+		"HeaderURL": url + "/header",
+		"HashURL":   url + "/hash",
+	} {
+		t.Run(name, func(t *testing.T) {
+			src := `package foo
+			import "github.com/nspcc-dev/neo-go/pkg/interop/neofs"
+			func Main() string {
+				return neofs.` + name + `("C3swfg8MiMJ9bXbeFG6dWJTCoHp9hAEZkHezvbSwK1Cc",
+					"3nQH1L8u3eM9jt2mZCs6MyjzdjerdSzBkXCYYj4M4Znk")
+			}`
+			eval(t, src, []byte(tc))
+		})
+	}
+	t.Run("RequestRange", func(t *testing.T) {
+		src := `package foo
+		import "github.com/nspcc-dev/neo-go/pkg/interop/neofs"
+		func Main(cid, oid string) {
+			neofs.RequestRange(cid, oid, 10, 20, nil, "callback", nil, 1_0000000)
+		}`
+		_, err := compiler.Compile("foo.go", strings.NewReader(src))
+		require.NoError(t, err)
+	})
+}
+
 func spawnVM(t *testing.T, ic *interop.Context, src string) *vm.VM {
 	b, di, err := compiler.CompileWithDebugInfo("foo.go", strings.NewReader(src))
 	require.NoError(t, err)
@@ -342,6 +370,22 @@ func TestInteropPackage(t *testing.T) {
 	eval(t, src, big.NewInt(42))
 }
 
+func TestNEP24Helpers(t *testing.T) {
+	src := `package foo
+	import (
+		"github.com/nspcc-dev/neo-go/pkg/interop"
+		"github.com/nspcc-dev/neo-go/pkg/interop/nep24"
+	)
+	func Main() int {
+		rs := []nep24.RoyaltyRecipient{{
+			Address: interop.Hash160("aaaaaaaaaaaaaaaaaaaa"),
+			Amount:  nep24.RoyaltyAmount(1000, 250),
+		}}
+		return rs[0].Amount
+	}`
+	eval(t, src, big.NewInt(25))
+}
+
 func TestBuiltinPackage(t *testing.T) {
 	src := `package foo
 	import "github.com/nspcc-dev/neo-go/pkg/compiler/testdata/util"
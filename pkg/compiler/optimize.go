@@ -0,0 +1,227 @@
+package compiler
+
+import (
+	"go/ast"
+	"go/constant"
+	"sort"
+
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+)
+
+// instruction is a single instruction of the program being optimized.
+type instruction struct {
+	ip   int
+	size int
+	op   opcode.Opcode
+}
+
+// optimizationsEnabled returns true if the program should be optimized.
+func (c *codegen) optimizationsEnabled() bool {
+	o := c.buildInfo.options
+	return o == nil || !o.NoOptimize
+}
+
+// constantCondition returns the value of the condition if it is known at
+// compile-time and optimizations are enabled.
+func (c *codegen) constantCondition(cond ast.Expr) (bool, bool) {
+	if cond == nil || !c.optimizationsEnabled() {
+		return false, false
+	}
+	tv := c.typeAndValueOf(cond)
+	if tv.Value == nil || tv.Value.Kind() != constant.Bool {
+		return false, false
+	}
+	return constant.BoolVal(tv.Value), true
+}
+
+// isPureLoad returns true if op only pushes an item on the stack without any
+// other side-effects.
+func isPureLoad(op opcode.Opcode) bool {
+	return op <= opcode.PUSHINT256 ||
+		opcode.PUSHNULL <= op && op <= opcode.PUSH16 ||
+		opcode.LDSFLD0 <= op && op <= opcode.LDSFLD ||
+		opcode.LDLOC0 <= op && op <= opcode.LDLOC ||
+		opcode.LDARG0 <= op && op <= opcode.LDARG ||
+		op == opcode.DUP
+}
+
+// isTerminator returns true if code following op is not reachable from it.
+func isTerminator(op opcode.Opcode) bool {
+	switch op {
+	case opcode.RET, opcode.JMP, opcode.JMPL, opcode.THROW, opcode.ABORT:
+		return true
+	}
+	return false
+}
+
+// removeRedundantCode removes instructions having no effect on the program
+// execution: NOPs, unreachable code, items pushed and dropped right away and
+// repeated swaps. It must be called before jumps are written, so that labels
+// are not yet resolved. All offsets known to codegen are corrected.
+func (c *codegen) removeRedundantCode(b []byte) []byte {
+	var (
+		instrs []instruction
+		spans  [][2]int
+	)
+	ctx := vm.NewContext(b)
+	op, param, err := ctx.Next()
+	for ; err == nil && ctx.IP() < len(b); op, param, err = ctx.Next() {
+		ip := ctx.IP()
+		instrs = append(instrs, instruction{ip: ip, size: ctx.NextIP() - ip, op: op})
+		// Short jumps are emitted with precalculated offsets by some builtins,
+		// code between them and their targets is left intact.
+		switch op {
+		case opcode.JMP, opcode.JMPIF, opcode.JMPIFNOT, opcode.JMPEQ, opcode.JMPNE,
+			opcode.JMPGT, opcode.JMPGE, opcode.JMPLT, opcode.JMPLE,
+			opcode.CALL, opcode.ENDTRY, opcode.TRY:
+			for i := range param {
+				spans = append(spans, [2]int{ip, ip + int(int8(param[i]))})
+			}
+		}
+	}
+	if err != nil {
+		return b
+	}
+
+	// Every label and function start is a target of some jump or call.
+	targets := make(map[int]bool, len(c.l))
+	for _, off := range c.l {
+		targets[off] = true
+	}
+	targets[0] = true
+	if c.initEndOffset >= 0 {
+		targets[c.initEndOffset+1] = true
+	}
+	for _, f := range c.funcs {
+		targets[int(f.rng.Start)] = true
+	}
+	fixed := make([]bool, len(instrs))
+	for _, sp := range spans {
+		lo, hi := sp[0], sp[1]
+		if lo > hi {
+			lo, hi = hi, lo
+		}
+		targets[sp[1]] = true
+		for i := range instrs {
+			if lo <= instrs[i].ip && instrs[i].ip <= hi {
+				fixed[i] = true
+			}
+		}
+	}
+
+	var (
+		removed     = make([]bool, len(instrs))
+		unreachable = make([]bool, len(instrs))
+		kept        []int
+		term        bool
+	)
+	for i, in := range instrs {
+		if fixed[i] {
+			kept = append(kept, i)
+			term = isTerminator(in.op)
+			continue
+		}
+		if term && !targets[in.ip] {
+			removed[i], unreachable[i] = true, true
+			continue
+		}
+		if in.op == opcode.NOP {
+			removed[i] = true
+			continue
+		}
+		if n := len(kept); n > 0 {
+			j := kept[n-1]
+			prev := instrs[j].op
+			if !fixed[j] && (in.op == opcode.DROP && isPureLoad(prev) || in.op == opcode.SWAP && prev == opcode.SWAP) &&
+				!hasTargets(instrs[j+1:i+1], targets) {
+				removed[i], removed[j] = true, true
+				kept = kept[:n-1]
+				term = false
+				continue
+			}
+		}
+		kept = append(kept, i)
+		term = isTerminator(in.op)
+	}
+
+	// starts contains offsets of removed instructions and sizes contain
+	// the total number of bytes removed up to the according instruction.
+	var (
+		starts []int
+		sizes  []int
+		total  int
+		res    = make([]byte, 0, len(b))
+	)
+	for i, in := range instrs {
+		if removed[i] {
+			starts = append(starts, in.ip)
+			total += in.size
+			sizes = append(sizes, total)
+			continue
+		}
+		res = append(res, b[in.ip:in.ip+in.size]...)
+	}
+	if total == 0 {
+		return b
+	}
+	// relocate returns new offset of the instruction at the old offset off.
+	// Offsets of removed instructions become the offsets of the next
+	// instruction left in the program.
+	relocate := func(off int) int {
+		n := sort.SearchInts(starts, off)
+		if n == 0 {
+			return off
+		}
+		return off - sizes[n-1]
+	}
+
+	for i := range c.l {
+		if c.l[i] >= 0 {
+			c.l[i] = relocate(c.l[i])
+		}
+	}
+	// End offsets point to the last byte of the method.
+	if c.deployEndOffset >= 0 {
+		c.deployEndOffset = relocate(c.deployEndOffset+1) - 1
+	}
+	if c.initEndOffset >= 0 {
+		c.initEndOffset = relocate(c.initEndOffset+1) - 1
+	}
+	for _, f := range c.funcs {
+		start := relocate(int(f.rng.Start))
+		if f.rng.End > f.rng.Start {
+			f.rng.End = uint16(relocate(int(f.rng.End)+1) - 1)
+		}
+		f.rng.Start = uint16(start)
+	}
+	for name, sps := range c.sequencePoints {
+		points := sps[:0]
+		for i := range sps {
+			n := sort.Search(len(instrs), func(k int) bool { return instrs[k].ip >= sps[i].Opcode })
+			if n < len(instrs) && instrs[n].ip == sps[i].Opcode && removed[n] {
+				// Code of the dead branch can't be stepped on. As for other
+				// removed code, the next sequence point is more precise
+				// if it points to the same instruction.
+				if unreachable[n] || i+1 < len(sps) && relocate(sps[i+1].Opcode) == relocate(sps[i].Opcode) {
+					continue
+				}
+			}
+			sp := sps[i]
+			sp.Opcode = relocate(sp.Opcode)
+			points = append(points, sp)
+		}
+		c.sequencePoints[name] = points
+	}
+	return res
+}
+
+// hasTargets returns true if any of the instructions is a jump target.
+func hasTargets(instrs []instruction, targets map[int]bool) bool {
+	for i := range instrs {
+		if targets[instrs[i].ip] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+package compiler_test
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/stretchr/testify/require"
+)
+
+func compileOptions(t *testing.T, src string, o *compiler.Options) ([]byte, *compiler.DebugInfo) {
+	b, di, err := compiler.CompileWithOptions("foo.go", strings.NewReader(src), o)
+	require.NoError(t, err)
+	return b, di
+}
+
+func hasMethod(di *compiler.DebugInfo, name string) bool {
+	for i := range di.Methods {
+		if di.Methods[i].Name.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func TestOptimizeConstantCondition(t *testing.T) {
+	src := `package foo
+	const debug = false
+	func Main() int {
+		if debug {
+			return helper()
+		} else if !debug {
+			return 7
+		}
+		return 1
+	}
+	func helper() int {
+		return 2
+	}`
+	eval(t, src, big.NewInt(7))
+
+	b, di := compileOptions(t, src, nil)
+	require.False(t, hasMethod(di, "helper"))
+
+	nb, ndi := compileOptions(t, src, &compiler.Options{NoOptimize: true})
+	require.True(t, hasMethod(ndi, "helper"))
+	require.True(t, len(b) < len(nb))
+}
+
+func TestOptimizeRedundantCode(t *testing.T) {
+	src := `package foo
+	type pair struct {
+		a, b int
+	}
+	func Main() int {
+		p := pair{a: 1, b: 2}
+		x := p.a + p.b
+		_ = x
+		if x > 2 {
+			return x
+		} else {
+			return 0
+		}
+	}`
+	eval(t, src, big.NewInt(3))
+
+	b, di := compileOptions(t, src, nil)
+	nb, _ := compileOptions(t, src, &compiler.Options{NoOptimize: true})
+	require.True(t, bytes.Contains(nb, []byte{byte(opcode.NOP)}))
+	require.True(t, len(b) < len(nb))
+
+	// Sequence points are corrected for the removed code.
+	for _, m := range di.Methods {
+		for _, sp := range m.SeqPoints {
+			require.True(t, int(m.Range.Start) <= sp.Opcode && sp.Opcode <= int(m.Range.End))
+		}
+		require.Equal(t, byte(opcode.RET), b[m.Range.End])
+	}
+}
+
+func TestOptimizeInlineAndBuiltins(t *testing.T) {
+	src := `package foo
+	import "github.com/nspcc-dev/neo-go/pkg/compiler/testdata/inline"
+	func Main() int {
+		var s []int
+		s = append(s, 1, 2)
+		inline.NoArgsReturn1()
+		return len(s) + inline.Sum(1, 2)
+	}`
+	eval(t, src, big.NewInt(5))
+}
@@ -0,0 +1,214 @@
+package compiler
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"sort"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// dynamicMethod is used in the list of invoked methods for methods whose
+// names can't be determined at compile-time.
+const dynamicMethod = "*"
+
+// processContractCall remembers the contract and method invoked by
+// contract.Call (including calls made by native contract wrappers).
+func (c *codegen) processContractCall(f *funcScope, args []ast.Expr) {
+	if f.pkg.Path() != interopPrefix+"/contract" || f.name != "Call" || len(args) < 2 {
+		return
+	}
+	h, _ := c.constantHash(args[0])
+	method := dynamicMethod
+	if tv := c.typeAndValueOf(args[1]); tv.Value != nil && tv.Value.Kind() == constant.String {
+		method = constant.StringVal(tv.Value)
+	}
+	if c.invokedContracts == nil {
+		c.invokedContracts = make(map[util.Uint160][]string)
+	}
+	for _, m := range c.invokedContracts[h] {
+		if m == method {
+			return
+		}
+	}
+	c.invokedContracts[h] = append(c.invokedContracts[h], method)
+}
+
+// constantHash returns the contract hash if it is known at compile-time.
+func (c *codegen) constantHash(e ast.Expr) (util.Uint160, bool) {
+	if tv := c.typeAndValueOf(e); tv.Value != nil && tv.Value.Kind() == constant.String {
+		u, err := util.Uint160DecodeBytesBE([]byte(constant.StringVal(tv.Value)))
+		return u, err == nil
+	}
+	ce, ok := e.(*ast.CallExpr)
+	if !ok || len(ce.Args) != 1 {
+		return util.Uint160{}, false
+	}
+	if c.typeAndValueOf(ce.Fun).IsType() {
+		// Conversion like `interop.Hash160(Hash)`.
+		return c.constantHash(ce.Args[0])
+	}
+	if sel, ok := ce.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "FromAddress" {
+		tv := c.typeAndValueOf(ce.Args[0])
+		if tv.Value == nil || tv.Value.Kind() != constant.String {
+			return util.Uint160{}, false
+		}
+		u, err := address.StringToUint160(constant.StringVal(tv.Value))
+		return u, err == nil
+	}
+	return util.Uint160{}, false
+}
+
+// describeCall returns human-readable call description.
+func describeCall(h util.Uint160, method string) string {
+	var m, ct string
+	if method == dynamicMethod {
+		m = "dynamic method"
+	} else {
+		m = "method '" + method + "'"
+	}
+	if h.Equals(util.Uint160{}) {
+		ct = "dynamic contract"
+	} else {
+		ct = "contract 0x" + h.StringLE()
+	}
+	return m + " of " + ct
+}
+
+// isPermitted checks whether method call is allowed by permissions. Group
+// permissions can't be checked at compile-time, so they're assumed to allow
+// calls to any contract.
+func isPermitted(ps []manifest.Permission, h util.Uint160, method string) bool {
+	for i := range ps {
+		switch ps[i].Contract.Type {
+		case manifest.PermissionHash:
+			if h.Equals(util.Uint160{}) || !ps[i].Contract.Hash().Equals(h) {
+				continue
+			}
+		}
+		if ps[i].Methods.IsWildcard() || method != dynamicMethod && ps[i].Methods.Contains(method) {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedHashes returns hashes of invoked contracts in a sorted order.
+func sortedHashes(invoked map[util.Uint160][]string) []util.Uint160 {
+	hashes := make([]util.Uint160, 0, len(invoked))
+	for h := range invoked {
+		hashes = append(hashes, h)
+	}
+	sort.Slice(hashes, func(i, j int) bool { return hashes[i].Less(hashes[j]) })
+	return hashes
+}
+
+// checkPermissions checks that every contract call known at compile-time is
+// allowed by permissions.
+func checkPermissions(di *DebugInfo, ps []manifest.Permission) error {
+	for _, h := range sortedHashes(di.InvokedContracts) {
+		for _, method := range di.InvokedContracts[h] {
+			if !isPermitted(ps, h, method) {
+				return fmt.Errorf("%s is called but not allowed by permissions", describeCall(h, method))
+			}
+		}
+	}
+	return nil
+}
+
+// SuggestPermissions returns the narrowest permissions allowing all contract
+// calls performed by the program. Wildcard contract permission is used for
+// calls to contracts not known at compile-time, wildcard methods are used
+// for methods not known at compile-time.
+func SuggestPermissions(di *DebugInfo) []manifest.Permission {
+	ps := make([]manifest.Permission, 0, len(di.InvokedContracts))
+	for _, h := range sortedHashes(di.InvokedContracts) {
+		var p manifest.Permission
+		if h.Equals(util.Uint160{}) {
+			p.Contract.Type = manifest.PermissionWildcard
+		} else {
+			p.Contract = manifest.PermissionDesc{Type: manifest.PermissionHash, Value: h}
+		}
+		for _, method := range di.InvokedContracts[h] {
+			if method == dynamicMethod {
+				p.Methods.Value = nil
+				break
+			}
+			p.Methods.Add(method)
+		}
+		sort.Strings(p.Methods.Value)
+		ps = append(ps, p)
+	}
+	return ps
+}
+
+// LintPermissions returns warnings about permissions allowing more than
+// contract calls performed by the program need.
+func LintPermissions(di *DebugInfo, ps []manifest.Permission) []string {
+	var (
+		res          []string
+		dynamic      = di.InvokedContracts[util.Uint160{}]
+		hasDynamic   = len(dynamic) != 0
+		dynamicNames = make(map[string]bool)
+	)
+	for _, m := range dynamic {
+		dynamicNames[m] = true
+	}
+	for i := range ps {
+		var (
+			name    string
+			methods []string
+		)
+		switch ps[i].Contract.Type {
+		case manifest.PermissionWildcard:
+			name = "any contract"
+			if !hasDynamic {
+				var hashes []string
+				for _, h := range sortedHashes(di.InvokedContracts) {
+					hashes = append(hashes, "0x"+h.StringLE())
+				}
+				if len(hashes) == 0 {
+					res = append(res, "permission for any contract is not needed, no contracts are called")
+				} else {
+					res = append(res, "permission for any contract can be restricted to contracts "+strings.Join(hashes, ", "))
+				}
+				continue
+			}
+			methods = dynamic
+		case manifest.PermissionHash:
+			h := ps[i].Contract.Hash()
+			name = "contract 0x" + h.StringLE()
+			methods = append(methods, di.InvokedContracts[h]...)
+			if len(methods) == 0 && !hasDynamic {
+				res = append(res, "permission for "+name+" is not needed, it's not called")
+				continue
+			}
+			methods = append(methods, dynamic...)
+		default:
+			// Group members are not known at compile-time.
+			continue
+		}
+		called := make(map[string]bool)
+		for _, m := range methods {
+			called[m] = true
+		}
+		if called[dynamicMethod] {
+			continue
+		}
+		if ps[i].Methods.IsWildcard() {
+			sort.Strings(methods)
+			res = append(res, fmt.Sprintf("methods of %s can be restricted to %s", name, strings.Join(methods, ", ")))
+			continue
+		}
+		for _, m := range ps[i].Methods.Value {
+			if !called[m] && !dynamicNames[m] {
+				res = append(res, fmt.Sprintf("method '%s' of %s is allowed, but not called", m, name))
+			}
+		}
+	}
+	return res
+}
@@ -0,0 +1,104 @@
+package compiler_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/interop/native/gas"
+	"github.com/nspcc-dev/neo-go/pkg/interop/native/neo"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func hashPermission(t *testing.T, h string, methods ...string) manifest.Permission {
+	u, err := util.Uint160DecodeBytesBE([]byte(h))
+	require.NoError(t, err)
+	p := manifest.Permission{Contract: manifest.PermissionDesc{Type: manifest.PermissionHash, Value: u}}
+	if methods != nil {
+		p.Methods.Value = methods
+	}
+	return p
+}
+
+func TestPermissions(t *testing.T) {
+	src := `package foo
+	import (
+		"github.com/nspcc-dev/neo-go/pkg/interop"
+		"github.com/nspcc-dev/neo-go/pkg/interop/contract"
+		"github.com/nspcc-dev/neo-go/pkg/interop/native/gas"
+		"github.com/nspcc-dev/neo-go/pkg/interop/native/neo"
+	)
+	func Main(h interop.Hash160) int {
+		if neo.Symbol() == gas.Symbol() {
+			return 0
+		}
+		return neo.BalanceOf(h) + neo.BalanceOf(h)
+	}
+	func Dynamic(h interop.Hash160) {
+		contract.Call(h, "transfer", contract.All)
+	}`
+	_, di, err := compiler.CompileWithDebugInfo("foo.go", strings.NewReader(src))
+	require.NoError(t, err)
+
+	wildcard := manifest.Permission{Contract: manifest.PermissionDesc{Type: manifest.PermissionWildcard}}
+	neoPerm := hashPermission(t, neo.Hash, "balanceOf", "symbol")
+	gasPerm := hashPermission(t, gas.Hash, "symbol")
+
+	t.Run("suggest", func(t *testing.T) {
+		suggested := compiler.SuggestPermissions(di)
+		require.Equal(t, 3, len(suggested))
+		expected := []manifest.Permission{wildcard, gasPerm, neoPerm}
+		expected[0].Methods.Value = []string{"transfer"}
+		require.ElementsMatch(t, expected, suggested)
+		require.NoError(t, manifest.Permissions(suggested).AreValid())
+	})
+	t.Run("check", func(t *testing.T) {
+		o := &compiler.Options{Name: "Foo", NoEventsCheck: true}
+		m, err := compiler.CreateManifest(di, o)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(m.Permissions))
+
+		o.Permissions = []manifest.Permission{neoPerm, gasPerm}
+		_, err = compiler.CreateManifest(di, o)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "'transfer'")
+
+		o.NoPermissionsCheck = true
+		m, err = compiler.CreateManifest(di, o)
+		require.NoError(t, err)
+		require.Equal(t, o.Permissions, m.Permissions)
+
+		o.NoPermissionsCheck = false
+		o.Permissions = []manifest.Permission{hashPermission(t, neo.Hash, "symbol"), wildcard}
+		_, err = compiler.CreateManifest(di, o)
+		require.NoError(t, err)
+	})
+	t.Run("lint", func(t *testing.T) {
+		ws := compiler.LintPermissions(di, compiler.SuggestPermissions(di))
+		require.Equal(t, 0, len(ws))
+
+		ws = compiler.LintPermissions(di, []manifest.Permission{
+			hashPermission(t, neo.Hash),
+			hashPermission(t, gas.Hash, "symbol", "decimals"),
+		})
+		require.Equal(t, 2, len(ws))
+		require.Contains(t, ws[0], "balanceOf, symbol")
+		require.Contains(t, ws[1], "'decimals'")
+	})
+	t.Run("lint known contracts", func(t *testing.T) {
+		src := `package foo
+		import "github.com/nspcc-dev/neo-go/pkg/interop/native/neo"
+		func Main() string {
+			return neo.Symbol()
+		}`
+		_, di, err := compiler.CompileWithDebugInfo("foo.go", strings.NewReader(src))
+		require.NoError(t, err)
+
+		ws := compiler.LintPermissions(di, []manifest.Permission{wildcard, hashPermission(t, gas.Hash)})
+		require.Equal(t, 2, len(ws))
+		require.Contains(t, ws[0], "restricted")
+		require.Contains(t, ws[1], "not called")
+	})
+}
@@ -0,0 +1,50 @@
+package compiler
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
+)
+
+// VerifySource compiles contract source (name and r have the same meaning as
+// for Compile) and compares the resulting NEF with the deployed one. The
+// current compiler version is used, so the contract must have been compiled
+// by the same version to be verified. Contract and Height fields of the
+// attestation are to be filled by the caller. An error is only returned if
+// the source can't be compiled.
+func VerifySource(name string, r io.Reader, o *Options, deployed *nef.File) (*result.SourceAttestation, error) {
+	b, di, err := CompileWithOptions(name, r, o)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile: %w", err)
+	}
+	f, err := nef.NewFile(b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create .nef file: %w", err)
+	}
+	res := &result.SourceAttestation{
+		Compiler:         f.Compiler,
+		Sources:          di.Build.Sources,
+		Checksum:         f.Checksum,
+		ContractCompiler: deployed.Compiler,
+		ContractChecksum: deployed.Checksum,
+	}
+	scriptMatches := bytes.Equal(f.Script, deployed.Script)
+	switch {
+	case f.Compiler != deployed.Compiler:
+		res.Reason = fmt.Sprintf("contract is compiled with %s", deployed.Compiler)
+		if scriptMatches {
+			res.Reason += ", script matches"
+		}
+	case !scriptMatches:
+		res.Reason = "script mismatch"
+	case f.Checksum != deployed.Checksum:
+		// Method tokens differ.
+		res.Reason = "checksum mismatch"
+	default:
+		res.Verified = true
+	}
+	return res, nil
+}
@@ -29,4 +29,36 @@ type ApplicationConfiguration struct {
 	Oracle            OracleConfiguration     `yaml:"Oracle"`
 	P2PNotary         P2PNotary               `yaml:"P2PNotary"`
 	StateRoot         StateRoot               `yaml:"StateRoot"`
+	// NodeProfile is a set of data retention settings (NodeProfileArchive,
+	// NodeProfileFull or NodeProfileLight), protocol settings are used as
+	// is if it's not set.
+	NodeProfile string `yaml:"NodeProfile"`
+	// MemPoolDumpFile is the file memory pool transactions are saved to on
+	// node shutdown and restored from on startup, empty value disables it.
+	MemPoolDumpFile string `yaml:"MemPoolDumpFile"`
+	// TxRelay is transaction relay configuration.
+	TxRelay TxRelay `yaml:"TxRelay"`
+	// NAT is NAT traversal configuration.
+	NAT NAT `yaml:"NAT"`
+	// ExtendedCompression enables compression of headers for peers
+	// supporting it, the node then advertises this capability which is
+	// not understood by C# nodes and older NeoGo versions, so it's only
+	// useful for networks where all nodes enable it.
+	ExtendedCompression bool `yaml:"ExtendedCompression"`
+	// HeadersFirstSync makes node fetch and verify headers from peers
+	// ahead of blocks.
+	HeadersFirstSync bool `yaml:"HeadersFirstSync"`
+	// NeoFSBlockFetcher is the configuration of NeoFS service used to
+	// retrieve blocks removed from the local DB.
+	NeoFSBlockFetcher NeoFSBlockFetcher `yaml:"NeoFSBlockFetcher"`
+	// ArchiveSync is the configuration of initial synchronization from
+	// chain archives.
+	ArchiveSync ArchiveSync `yaml:"ArchiveSync"`
+	// ConsensusStateFile is the file consensus messages of the current
+	// round are saved to, so that consensus node can rejoin the same view
+	// after restart. Empty value disables it.
+	ConsensusStateFile string `yaml:"ConsensusStateFile"`
+	// Faucet is the configuration of faucet service transferring funds
+	// to accounts requested via HTTP.
+	Faucet Faucet `yaml:"Faucet"`
 }
@@ -0,0 +1,20 @@
+package config
+
+import "time"
+
+// ArchiveSync is a configuration of initial chain synchronization from
+// chunked chain dump published via HTTP(S) or NeoFS.
+type ArchiveSync struct {
+	Enabled bool `yaml:"Enabled"`
+	// ManifestURL is HTTP(S) URL of the dump manifest, chunk files are
+	// resolved relative to it unless they're absolute URIs (like
+	// "neofs:<Container-ID>/<Object-ID>").
+	ManifestURL string `yaml:"ManifestURL"`
+	// NeoFSNodes are NeoFS nodes used to fetch chunks stored in NeoFS.
+	NeoFSNodes []string `yaml:"NeoFSNodes"`
+	// Timeout is the timeout of a single manifest or chunk request.
+	Timeout time.Duration `yaml:"Timeout"`
+	// TipDistance is the distance to the highest peer block that makes
+	// node stop using archives and switch to P2P synchronization.
+	TipDistance uint32 `yaml:"TipDistance"`
+}
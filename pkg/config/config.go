@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
@@ -63,5 +64,19 @@ func LoadFile(configPath string) (Config, error) {
 		}
 	}
 
+	for h, ms := range config.ProtocolConfiguration.MillisecondsPerBlockHistory {
+		if ms <= 0 {
+			return Config{}, fmt.Errorf("invalid MillisecondsPerBlockHistory value for height %d: %d", h, ms)
+		}
+	}
+
+	if err := config.ProtocolConfiguration.Genesis.resolvePaths(filepath.Dir(configPath)); err != nil {
+		return Config{}, err
+	}
+
+	if err := config.applyNodeProfile(); err != nil {
+		return Config{}, err
+	}
+
 	return config, nil
 }
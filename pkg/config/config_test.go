@@ -1,6 +1,9 @@
 package config
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -12,3 +15,84 @@ func TestUnexpectedNativeUpdateHistoryContract(t *testing.T) {
 	_, err := LoadFile(testConfigPath)
 	require.Error(t, err)
 }
+
+func TestNodeProfile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "neogo.profiletest")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		os.RemoveAll(dir)
+	})
+	cfgPath := filepath.Join(dir, "protocol.yml")
+	load := func(t *testing.T, profile string, protocol string) (Config, error) {
+		data := []byte("ProtocolConfiguration:\n" + protocol + "ApplicationConfiguration:\n  NodeProfile: \"" + profile + "\"\n")
+		require.NoError(t, ioutil.WriteFile(cfgPath, data, os.ModePerm))
+		return LoadFile(cfgPath)
+	}
+	t.Run("default", func(t *testing.T) {
+		cfg, err := load(t, "", "  RemoveUntraceableBlocks: true\n")
+		require.NoError(t, err)
+		require.True(t, cfg.ProtocolConfiguration.RemoveUntraceableBlocks)
+		require.False(t, cfg.ProtocolConfiguration.KeepOnlyLatestState)
+	})
+	t.Run("archive", func(t *testing.T) {
+		cfg, err := load(t, NodeProfileArchive, "  SaveStorageChanges: true\n")
+		require.NoError(t, err)
+		require.False(t, cfg.ProtocolConfiguration.RemoveUntraceableBlocks)
+		require.False(t, cfg.ProtocolConfiguration.KeepOnlyLatestState)
+
+		_, err = load(t, NodeProfileArchive, "  KeepOnlyLatestState: true\n")
+		require.Error(t, err)
+		_, err = load(t, NodeProfileArchive, "  RemoveUntraceableBlocks: true\n")
+		require.Error(t, err)
+	})
+	t.Run("full", func(t *testing.T) {
+		cfg, err := load(t, NodeProfileFull, "  StateRetentionBlocks: 100\n")
+		require.NoError(t, err)
+		require.False(t, cfg.ProtocolConfiguration.RemoveUntraceableBlocks)
+		require.True(t, cfg.ProtocolConfiguration.KeepOnlyLatestState)
+		require.Equal(t, uint32(100), cfg.ProtocolConfiguration.StateRetentionBlocks)
+
+		_, err = load(t, NodeProfileFull, "  RemoveUntraceableBlocks: true\n")
+		require.Error(t, err)
+	})
+	t.Run("light", func(t *testing.T) {
+		cfg, err := load(t, NodeProfileLight, "  MaxTraceableBlocks: 1000\n")
+		require.NoError(t, err)
+		require.True(t, cfg.ProtocolConfiguration.RemoveUntraceableBlocks)
+		require.True(t, cfg.ProtocolConfiguration.KeepOnlyLatestState)
+		require.Equal(t, uint32(1000), cfg.ProtocolConfiguration.MaxTraceableBlocks)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := load(t, "pruned", "  Magic: 42\n")
+		require.Error(t, err)
+	})
+}
+
+func TestGenesisContractPaths(t *testing.T) {
+	dir, err := ioutil.TempDir("", "neogo.genesistest")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		os.RemoveAll(dir)
+	})
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "contracts"), os.ModePerm))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "contracts", "c.nef"), []byte{1}, os.ModePerm))
+	absManifest := filepath.Join(dir, "c.manifest.json")
+	require.NoError(t, ioutil.WriteFile(absManifest, []byte{1}, os.ModePerm))
+
+	cfgPath := filepath.Join(dir, "protocol.yml")
+	load := func(t *testing.T, nef, manif string) (Config, error) {
+		data := []byte("ProtocolConfiguration:\n  Genesis:\n    Contracts:\n      - NEF: " + nef + "\n        Manifest: " + manif + "\n")
+		require.NoError(t, ioutil.WriteFile(cfgPath, data, os.ModePerm))
+		return LoadFile(cfgPath)
+	}
+	cfg, err := load(t, "./contracts/c.nef", absManifest)
+	require.NoError(t, err)
+	c := cfg.ProtocolConfiguration.Genesis.Contracts[0]
+	require.Equal(t, filepath.Join(dir, "contracts", "c.nef"), c.NEF)
+	require.Equal(t, absManifest, c.Manifest)
+
+	_, err = load(t, "./c.nef", absManifest)
+	require.Error(t, err)
+	_, err = load(t, "./contracts/c.nef", "\"\"")
+	require.Error(t, err)
+}
@@ -0,0 +1,35 @@
+package config
+
+import (
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
+)
+
+// Faucet contains faucet service configuration.
+type Faucet struct {
+	Enabled bool   `yaml:"Enabled"`
+	Address string `yaml:"Address"`
+	Port    uint16 `yaml:"Port"`
+	// UnlockWallet is the wallet funds are transferred from, its first
+	// account that can be unlocked is used.
+	UnlockWallet Wallet `yaml:"UnlockWallet"`
+	// GASAmount and NEOAmount are the amounts transferred per request.
+	GASAmount fixedn.Fixed8 `yaml:"GASAmount"`
+	NEOAmount int64         `yaml:"NEOAmount"`
+	// Cooldown is the minimum interval between requests from the same IP
+	// address or to the same account, 1h is used if it's not set.
+	Cooldown time.Duration `yaml:"Cooldown"`
+	// Captcha is CAPTCHA verification configuration.
+	Captcha FaucetCaptcha `yaml:"Captcha"`
+}
+
+// FaucetCaptcha contains CAPTCHA verification settings. Verification is
+// done via siteverify endpoint compatible with reCAPTCHA and hCaptcha.
+type FaucetCaptcha struct {
+	Enabled bool `yaml:"Enabled"`
+	// URL is the verification endpoint address.
+	URL string `yaml:"URL"`
+	// Secret is the secret key shared with the CAPTCHA provider.
+	Secret string `yaml:"Secret"`
+}
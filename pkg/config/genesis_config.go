@@ -0,0 +1,76 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Genesis contains additional genesis block contents for private networks.
+// Any of these settings change genesis block hash, so they must be the same
+// for all nodes of the network.
+type Genesis struct {
+	// Contracts are deployed in the genesis block by the standby validators
+	// multisignature account.
+	Contracts []GenesisContract `yaml:"Contracts"`
+	// Transfers are NEP-17 transfers made from the standby validators
+	// multisignature account (holding all NEO and GAS initially) after
+	// contracts deployment.
+	Transfers []GenesisTransfer `yaml:"Transfers"`
+	// Transactions are arbitrary scripts executed after transfers with the
+	// standby validators multisignature account as a signer.
+	Transactions []GenesisTransaction `yaml:"Transactions"`
+}
+
+// GenesisContract is a contract deployed in the genesis block.
+type GenesisContract struct {
+	// NEF is a path to the contract NEF file, relative paths are resolved
+	// against the directory of the configuration file.
+	NEF string `yaml:"NEF"`
+	// Manifest is a path to the contract manifest file, relative paths are
+	// resolved against the directory of the configuration file.
+	Manifest string `yaml:"Manifest"`
+	// SystemFee is the GAS amount (in fractions) allowed to be spent on the
+	// deployment, default value is used if it's zero.
+	SystemFee int64 `yaml:"SystemFee"`
+}
+
+// GenesisTransfer is a NEP-17 transfer made in the genesis block.
+type GenesisTransfer struct {
+	// Asset is either a native contract name (like "GAS") or a contract
+	// hash in LE form.
+	Asset string `yaml:"Asset"`
+	// To is the recipient address.
+	To string `yaml:"To"`
+	// Amount is the amount of token fractions to transfer.
+	Amount int64 `yaml:"Amount"`
+}
+
+// GenesisTransaction is a transaction executed in the genesis block.
+type GenesisTransaction struct {
+	// Script is a base64-encoded transaction script.
+	Script string `yaml:"Script"`
+	// SystemFee is the GAS amount (in fractions) allowed to be spent by the
+	// script, default value is used if it's zero.
+	SystemFee int64 `yaml:"SystemFee"`
+}
+
+// resolvePaths makes relative contract file paths relative to the given
+// directory and checks that these files exist.
+func (g *Genesis) resolvePaths(dir string) error {
+	for i := range g.Contracts {
+		c := &g.Contracts[i]
+		for _, p := range []*string{&c.NEF, &c.Manifest} {
+			if *p == "" {
+				return fmt.Errorf("genesis contract #%d: missing file path", i)
+			}
+			if !filepath.IsAbs(*p) {
+				*p = filepath.Join(dir, *p)
+			}
+			if _, err := os.Stat(*p); err != nil {
+				return fmt.Errorf("genesis contract #%d: %w", i, err)
+			}
+		}
+	}
+	return nil
+}
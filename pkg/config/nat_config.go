@@ -0,0 +1,14 @@
+package config
+
+import "time"
+
+// NAT contains NAT traversal (port mapping) configuration.
+type NAT struct {
+	Enabled bool `yaml:"Enabled"`
+	// Gateway is NAT-PMP gateway address, when it's empty default gateway is
+	// used (only supported on Linux).
+	Gateway string `yaml:"Gateway"`
+	// Lifetime is the port mapping lifetime, mapping is renewed after half
+	// of it passes.
+	Lifetime time.Duration `yaml:"Lifetime"`
+}
@@ -0,0 +1,13 @@
+package config
+
+// NeoFSBlockFetcher is a configuration of the service fetching old blocks
+// removed from the local DB from NeoFS.
+type NeoFSBlockFetcher struct {
+	Enabled bool               `yaml:"Enabled"`
+	NeoFS   NeoFSConfiguration `yaml:"NeoFS"`
+	// ContainerID is the NeoFS container blocks are stored in.
+	ContainerID string `yaml:"ContainerID"`
+	// IndexAttribute is the name of object attribute containing block
+	// index, "Index" is used if not specified.
+	IndexAttribute string `yaml:"IndexAttribute"`
+}
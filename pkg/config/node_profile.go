@@ -0,0 +1,42 @@
+package config
+
+import (
+	"fmt"
+)
+
+// Node profiles bundling data retention settings.
+const (
+	// NodeProfileArchive keeps all blocks and all historic states, it can't
+	// be combined with any pruning settings.
+	NodeProfileArchive = "archive"
+	// NodeProfileFull keeps all blocks, but only the latest state (plus
+	// StateRetentionBlocks recent ones if set).
+	NodeProfileFull = "full"
+	// NodeProfileLight keeps the latest state, all headers and only
+	// MaxTraceableBlocks recent blocks.
+	NodeProfileLight = "light"
+)
+
+// applyNodeProfile enables protocol settings implied by the node profile and
+// checks that other settings don't contradict it.
+func (c *Config) applyNodeProfile() error {
+	p := &c.ProtocolConfiguration
+	switch profile := c.ApplicationConfiguration.NodeProfile; profile {
+	case "":
+	case NodeProfileArchive:
+		if p.KeepOnlyLatestState || p.RemoveUntraceableBlocks {
+			return fmt.Errorf("%s node profile can't be used with KeepOnlyLatestState or RemoveUntraceableBlocks", profile)
+		}
+	case NodeProfileFull:
+		if p.RemoveUntraceableBlocks {
+			return fmt.Errorf("%s node profile can't be used with RemoveUntraceableBlocks", profile)
+		}
+		p.KeepOnlyLatestState = true
+	case NodeProfileLight:
+		p.KeepOnlyLatestState = true
+		p.RemoveUntraceableBlocks = true
+	default:
+		return fmt.Errorf("invalid NodeProfile: %s", profile)
+	}
+	return nil
+}
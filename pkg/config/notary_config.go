@@ -1,7 +1,30 @@
 package config
 
+import "github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
+
 // P2PNotary stores configuration for Notary node service.
 type P2PNotary struct {
 	Enabled      bool   `yaml:"Enabled"`
 	UnlockWallet Wallet `yaml:"UnlockWallet"`
+	// Sponsorship restricts the set of requests notary node co-signs.
+	Sponsorship NotarySponsorship `yaml:"Sponsorship"`
+}
+
+// NotarySponsorship is a policy for notary requests, requests not satisfying
+// it are ignored by the node. Empty policy allows any request.
+type NotarySponsorship struct {
+	// MaxFee is the maximum sum of system and network fees for each of
+	// main and fallback transactions (checked separately), it's not limited
+	// if 0.
+	MaxFee fixedn.Fixed8 `yaml:"MaxFee"`
+	// AllowedSigners is a list of addresses at least one of which should
+	// be in the main transaction signers list, any signer is allowed if
+	// empty. Only the declared Signers list is checked (witnesses are
+	// checked by the usual transaction verification).
+	AllowedSigners []string `yaml:"AllowedSigners"`
+	// AllowedContracts is a list of contract hashes (LE) main transaction
+	// script can call, any contract is allowed if empty. Scripts with
+	// control flow instructions (jumps, calls, try blocks) can't be checked
+	// and are rejected if it's set.
+	AllowedContracts []string `yaml:"AllowedContracts"`
 }
@@ -8,12 +8,25 @@ type OracleConfiguration struct {
 	AllowPrivateHost      bool               `yaml:"AllowPrivateHost"`
 	Nodes                 []string           `yaml:"Nodes"`
 	NeoFS                 NeoFSConfiguration `yaml:"NeoFS"`
+	IPFS                  IPFSConfiguration  `yaml:"IPFS"`
 	MaxTaskTimeout        time.Duration      `yaml:"MaxTaskTimeout"`
 	RefreshInterval       time.Duration      `yaml:"RefreshInterval"`
 	MaxConcurrentRequests int                `yaml:"MaxConcurrentRequests"`
 	RequestTimeout        time.Duration      `yaml:"RequestTimeout"`
 	ResponseTimeout       time.Duration      `yaml:"ResponseTimeout"`
-	UnlockWallet          Wallet             `yaml:"UnlockWallet"`
+	// MaxResponseSize is the maximum size of data fetched via https or ipfs
+	// before applying request filter, filtered result still can't exceed
+	// transaction.MaxOracleResultSize. It must be the same on all oracle
+	// nodes.
+	MaxResponseSize int `yaml:"MaxResponseSize"`
+	// HTTPHeaders are additional headers sent with https requests matching
+	// specified URL prefixes. They must be the same on all oracle nodes
+	// (as well as IPFS settings), otherwise nodes can get different results.
+	HTTPHeaders []OracleHeaders `yaml:"HTTPHeaders"`
+	// ResponseCacheTTL is the time successful responses are reused for
+	// requests with the same URL and filter, caching is disabled if it's 0.
+	ResponseCacheTTL time.Duration `yaml:"ResponseCacheTTL"`
+	UnlockWallet     Wallet        `yaml:"UnlockWallet"`
 }
 
 // NeoFSConfiguration is a config for the NeoFS service.
@@ -21,3 +34,18 @@ type NeoFSConfiguration struct {
 	Nodes   []string      `yaml:"Nodes"`
 	Timeout time.Duration `yaml:"Timeout"`
 }
+
+// IPFSConfiguration is a config for ipfs requests served via HTTP gateway.
+type IPFSConfiguration struct {
+	// Gateway is the HTTP gateway URL like "http://127.0.0.1:8080", ipfs
+	// requests are not supported if it's empty.
+	Gateway string        `yaml:"Gateway"`
+	Timeout time.Duration `yaml:"Timeout"`
+}
+
+// OracleHeaders is a set of headers added to https requests whose URL has the
+// same scheme and host as URLPrefix and the path starting with its path.
+type OracleHeaders struct {
+	URLPrefix string            `yaml:"URLPrefix"`
+	Headers   map[string]string `yaml:"Headers"`
+}
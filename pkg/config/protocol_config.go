@@ -1,21 +1,53 @@
 package config
 
 import (
+	"time"
+
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
 )
 
+// DefaultTimePerBlock is the time per block used if it's not configured.
+const DefaultTimePerBlock = 15 * time.Second
+
+// maxValidUntilBlockPeriod is the time transactions can be valid for, it
+// determines the maximum ValidUntilBlock increment if block time schedule is
+// configured.
+const maxValidUntilBlockPeriod = 24 * time.Hour
+
+// defaultMaxValidUntilBlockIncrement is the maximum ValidUntilBlock increment
+// used without block time schedule (the same as
+// transaction.MaxValidUntilBlockIncrement and the one used by C# node).
+const defaultMaxValidUntilBlockIncrement = 5760
+
 // ProtocolConfiguration represents the protocol config.
 type (
 	ProtocolConfiguration struct {
 		Magic       netmode.Magic `yaml:"Magic"`
 		MemPoolSize int           `yaml:"MemPoolSize"`
+		// MillisecondsPerBlockHistory changes time per block starting
+		// from the specified heights, SecondsPerBlock is used before the
+		// first of them.
+		MillisecondsPerBlockHistory map[uint32]int `yaml:"MillisecondsPerBlockHistory"`
+		// MemPoolReplaceFeeIncrease is the minimum network fee increase (in
+		// percents) required for transaction to replace conflicting one (see
+		// Conflicts attribute of P2PSigExtensions) in the memory pool.
+		MemPoolReplaceFeeIncrease int `yaml:"MemPoolReplaceFeeIncrease"`
+		// MemPoolReplaceMinFee is the minimum absolute network fee increase
+		// (in GAS fractions) required for such replacement.
+		MemPoolReplaceMinFee int64 `yaml:"MemPoolReplaceMinFee"`
 		// P2PNotaryRequestPayloadPoolSize specifies the memory pool size for P2PNotaryRequestPayloads.
 		// It is valid only if P2PSigExtensions are enabled.
 		P2PNotaryRequestPayloadPoolSize int `yaml:"P2PNotaryRequestPayloadPoolSize"`
+		// Genesis contains additional genesis block contents.
+		Genesis Genesis `yaml:"Genesis"`
 		// KeepOnlyLatestState specifies if MPT should only store latest state.
 		// If true, DB size will be smaller, but older roots won't be accessible.
 		// This value should remain the same for the same database.
 		KeepOnlyLatestState bool `yaml:"KeepOnlyLatestState"`
+		// StateRetentionBlocks is the number of recent blocks to keep MPT
+		// state for when KeepOnlyLatestState is enabled, older state is
+		// removed. Zero value means only the latest state is kept.
+		StateRetentionBlocks uint32 `yaml:"StateRetentionBlocks"`
 		// RemoveUntraceableBlocks specifies if old blocks should be removed.
 		RemoveUntraceableBlocks bool `yaml:"RemoveUntraceableBlocks"`
 		// MaxBlockSize is the maximum block size in bytes.
@@ -28,15 +60,30 @@ type (
 		MaxTransactionsPerBlock uint16 `yaml:"MaxTransactionsPerBlock"`
 		// NativeUpdateHistories is the list of histories of native contracts updates.
 		NativeUpdateHistories map[string][]uint32 `yaml:"NativeActivations"`
+		// NotificationsIndex enables saving notifications of every contract
+		// into a separate index, so that they can be queried later.
+		NotificationsIndex bool `yaml:"NotificationsIndex"`
+		// AddressHistoryIndex enables indexing transactions by their signers,
+		// so that account transaction history can be queried later.
+		AddressHistoryIndex bool `yaml:"AddressHistoryIndex"`
 		// P2PSigExtensions enables additional signature-related logic.
 		P2PSigExtensions bool `yaml:"P2PSigExtensions"`
 		// ReservedAttributes allows to have reserved attributes range for experimental or private purposes.
 		ReservedAttributes bool `yaml:"ReservedAttributes"`
 		// SaveStorageBatch enables storage batch saving before every persist.
-		SaveStorageBatch bool     `yaml:"SaveStorageBatch"`
-		SecondsPerBlock  int      `yaml:"SecondsPerBlock"`
-		SeedList         []string `yaml:"SeedList"`
-		StandbyCommittee []string `yaml:"StandbyCommittee"`
+		SaveStorageBatch bool `yaml:"SaveStorageBatch"`
+		// SaveStorageChanges enables saving contract storage changes made by
+		// every block, so that they can be retrieved later.
+		SaveStorageChanges bool `yaml:"SaveStorageChanges"`
+		// SaveTransactionTraces enables saving execution traces (contract
+		// calls, notifications and storage changes) of every transaction.
+		SaveTransactionTraces bool     `yaml:"SaveTransactionTraces"`
+		SecondsPerBlock       int      `yaml:"SecondsPerBlock"`
+		SeedList              []string `yaml:"SeedList"`
+		StandbyCommittee      []string `yaml:"StandbyCommittee"`
+		// StorageMetrics enables per-contract storage usage metrics. It requires
+		// the whole contract storage to be scanned on node startup.
+		StorageMetrics bool `yaml:"StorageMetrics"`
 		// StateRooInHeader enables storing state root in block header.
 		StateRootInHeader bool `yaml:"StateRootInHeader"`
 		ValidatorsCount   int  `yaml:"ValidatorsCount"`
@@ -46,3 +93,36 @@ type (
 		VerifyTransactions bool `yaml:"VerifyTransactions"`
 	}
 )
+
+// TimePerBlock returns time per block used at the specified height.
+func (p ProtocolConfiguration) TimePerBlock(height uint32) time.Duration {
+	var (
+		res   = time.Duration(p.SecondsPerBlock) * time.Second
+		start uint32
+		found bool
+	)
+	for h, ms := range p.MillisecondsPerBlockHistory {
+		if h <= height && (!found || h > start) {
+			start, found = h, true
+			res = time.Duration(ms) * time.Millisecond
+		}
+	}
+	if res <= 0 {
+		res = DefaultTimePerBlock
+	}
+	return res
+}
+
+// MaxValidUntilBlockIncrement returns the maximum ValidUntilBlock increment
+// for transactions at the specified height. If MillisecondsPerBlockHistory
+// schedule is in effect at this height, it's the number of blocks produced in
+// a day with the block time used at this height, otherwise it's the standard
+// 5760 blocks irrespective of SecondsPerBlock.
+func (p ProtocolConfiguration) MaxValidUntilBlockIncrement(height uint32) uint32 {
+	for h := range p.MillisecondsPerBlockHistory {
+		if h <= height {
+			return uint32(maxValidUntilBlockPeriod / p.TimePerBlock(height))
+		}
+	}
+	return defaultMaxValidUntilBlockIncrement
+}
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProtocolConfigurationTimePerBlock(t *testing.T) {
+	var p ProtocolConfiguration
+	require.Equal(t, DefaultTimePerBlock, p.TimePerBlock(0))
+	require.Equal(t, uint32(transaction.MaxValidUntilBlockIncrement), p.MaxValidUntilBlockIncrement(0))
+
+	p.SecondsPerBlock = 1
+	require.Equal(t, time.Second, p.TimePerBlock(100))
+	// No schedule, the standard value is used.
+	require.Equal(t, uint32(transaction.MaxValidUntilBlockIncrement), p.MaxValidUntilBlockIncrement(100))
+
+	p.SecondsPerBlock = 15
+	p.MillisecondsPerBlockHistory = map[uint32]int{
+		10: 5000,
+		20: 1000,
+	}
+	for h, expected := range map[uint32]time.Duration{
+		0:   15 * time.Second,
+		9:   15 * time.Second,
+		10:  5 * time.Second,
+		19:  5 * time.Second,
+		20:  time.Second,
+		100: time.Second,
+	} {
+		require.Equal(t, expected, p.TimePerBlock(h), h)
+	}
+	require.Equal(t, uint32(transaction.MaxValidUntilBlockIncrement), p.MaxValidUntilBlockIncrement(9))
+	require.Equal(t, uint32(17280), p.MaxValidUntilBlockIncrement(10))
+	require.Equal(t, uint32(86400), p.MaxValidUntilBlockIncrement(20))
+}
+
+func TestMillisecondsPerBlockHistory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "neogo.blocktimetest")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		os.RemoveAll(dir)
+	})
+	cfgPath := filepath.Join(dir, "protocol.yml")
+	load := func(t *testing.T, history string) (Config, error) {
+		data := []byte("ProtocolConfiguration:\n  SecondsPerBlock: 15\n  MillisecondsPerBlockHistory:\n" + history)
+		require.NoError(t, ioutil.WriteFile(cfgPath, data, os.ModePerm))
+		return LoadFile(cfgPath)
+	}
+
+	cfg, err := load(t, "    100: 1000\n")
+	require.NoError(t, err)
+	require.Equal(t, time.Second, cfg.ProtocolConfiguration.TimePerBlock(100))
+
+	_, err = load(t, "    100: 0\n")
+	require.Error(t, err)
+}
@@ -4,4 +4,8 @@ package config
 type StateRoot struct {
 	Enabled      bool   `yaml:"Enabled"`
 	UnlockWallet Wallet `yaml:"UnlockWallet"`
+	// HaltOnMismatch makes node stop if local state root doesn't match
+	// the one signed by state validators, it can be used irrespective of
+	// Enabled setting.
+	HaltOnMismatch bool `yaml:"HaltOnMismatch"`
 }
@@ -0,0 +1,19 @@
+package config
+
+import "time"
+
+// TxRelay contains transaction relay configuration.
+type TxRelay struct {
+	// MaxBandwidth is the maximum outbound traffic (in bytes per second)
+	// transaction relaying can use, 0 means no limit.
+	MaxBandwidth int `yaml:"MaxBandwidth"`
+	// BatchSize is the maximum number of transaction hashes announced to
+	// peers in a single inventory message.
+	BatchSize int `yaml:"BatchSize"`
+	// BatchInterval is the time transactions are accumulated for before
+	// being announced.
+	BatchInterval time.Duration `yaml:"BatchInterval"`
+	// BehindThreshold is the number of blocks node can lag behind its
+	// peers before transaction relaying is throttled down.
+	BehindThreshold uint32 `yaml:"BehindThreshold"`
+}
@@ -55,6 +55,8 @@ type Service interface {
 	OnPayload(p *npayload.Extensible)
 	// OnTransaction is a callback to notify Service about new received transaction.
 	OnTransaction(tx *transaction.Transaction)
+	// GetState returns a snapshot of the consensus process state.
+	GetState() State
 }
 
 type service struct {
@@ -83,6 +85,14 @@ type service struct {
 	// before block is accepted, so in case of change view it will contain
 	// updated value.
 	lastTimestamp uint64
+	// round collects consensus statistics.
+	round *roundTracker
+	// saved contains payloads of the current round to be stored in
+	// the recovery file, savedIndex is the height they belong to and
+	// savedDirty is set when the file needs to be updated.
+	saved      []*Payload
+	savedIndex uint32
+	savedDirty bool
 }
 
 // Config is a configuration for consensus services.
@@ -103,6 +113,10 @@ type Config struct {
 	TimePerBlock time.Duration
 	// Wallet is a local-node wallet configuration.
 	Wallet *config.Wallet
+	// RecoveryFile is the file consensus messages of the current round are
+	// saved to, so that they can be restored after node restart. Empty
+	// value disables it.
+	RecoveryFile string
 }
 
 // NewService returns new consensus.Service instance.
@@ -127,6 +141,7 @@ func NewService(cfg Config) (Service, error) {
 		started:      atomic.NewBool(false),
 		quit:         make(chan struct{}),
 		finished:     make(chan struct{}),
+		round:        newRoundTracker(),
 	}
 
 	if cfg.Wallet == nil {
@@ -245,7 +260,13 @@ func (s *service) newPrepareRequest() payload.PrepareRequest {
 func (s *service) Start() {
 	if s.started.CAS(false, true) {
 		s.log.Info("starting consensus service")
+		s.updateTimePerBlock()
 		s.dbft.Start()
+		if err := s.loadRecovery(); err != nil {
+			s.log.Warn("can't restore consensus state", zap.Error(err))
+		}
+		s.saveRecovery()
+		s.updateRound()
 		s.Chain.SubscribeForBlocks(s.blockEvents)
 		go s.eventLoop()
 	}
@@ -297,7 +318,9 @@ events:
 			}
 
 			s.log.Debug("received message", fields...)
+			s.onMessage(&msg)
 			s.dbft.OnReceive(&msg)
+			s.recordReceived(&msg)
 		case tx := <-s.transactions:
 			s.dbft.OnTransaction(tx)
 		case b := <-s.blockEvents:
@@ -309,7 +332,8 @@ events:
 			s.handleChainBlock(b)
 		default:
 		}
-
+		s.saveRecovery()
+		s.updateRound()
 	}
 	close(s.finished)
 }
@@ -321,10 +345,19 @@ func (s *service) handleChainBlock(b *coreb.Block) {
 			zap.Uint32("dbft index", s.dbft.BlockIndex),
 			zap.Uint32("chain index", s.Chain.BlockHeight()))
 		s.postBlock(b)
+		s.updateTimePerBlock()
 		s.dbft.InitializeConsensus(0)
 	}
 }
 
+// updateTimePerBlock sets dBFT block time for the next block if there is
+// a block time schedule in the protocol configuration.
+func (s *service) updateTimePerBlock() {
+	if len(s.ProtocolConfiguration.MillisecondsPerBlockHistory) != 0 {
+		s.dbft.SecondsPerBlock = s.ProtocolConfiguration.TimePerBlock(s.Chain.BlockHeight() + 1)
+	}
+}
+
 func (s *service) validatePayload(p *Payload) bool {
 	validators := s.getValidators()
 	if int(p.message.ValidatorIndex) >= len(validators) {
@@ -395,6 +428,11 @@ func (s *service) OnPayload(cp *npayload.Extensible) {
 	s.messages <- *p
 }
 
+// GetState implements Service interface.
+func (s *service) GetState() State {
+	return s.round.snapshot()
+}
+
 func (s *service) OnTransaction(tx *transaction.Transaction) {
 	if s.dbft != nil {
 		s.transactions <- tx
@@ -406,6 +444,13 @@ func (s *service) broadcast(p payload.ConsensusPayload) {
 		s.log.Warn("can't sign consensus payload", zap.Error(err))
 	}
 
+	if p.Type() == payload.PrepareRequestType {
+		s.onProposal(p.(*Payload))
+	}
+	// Own payload must be saved before anyone sees it, so that this node
+	// doesn't send a different one for the same view after restart.
+	s.recordPayload(p.(*Payload))
+	s.saveRecovery()
 	ep := &p.(*Payload).Extensible
 	s.Config.Broadcast(ep)
 }
@@ -533,6 +578,7 @@ func (s *service) processBlock(b block.Block) {
 }
 
 func (s *service) postBlock(b *coreb.Block) {
+	s.onBlock(b.Index)
 	if s.lastTimestamp < b.Timestamp {
 		s.lastTimestamp = b.Timestamp
 	}
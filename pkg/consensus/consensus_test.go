@@ -219,6 +219,61 @@ func TestService_GetVerified(t *testing.T) {
 	})
 }
 
+func TestService_GetState(t *testing.T) {
+	srv := newTestService(t)
+	srv.dbft.Start()
+	t.Cleanup(srv.dbft.Timer.Stop)
+	srv.updateRound()
+
+	st := srv.GetState()
+	require.Equal(t, uint32(1), st.BlockIndex)
+	require.Equal(t, uint8(0), st.ViewNumber)
+	require.Equal(t, getPrimaryIndex(1, 0, 4), st.PrimaryIndex)
+	require.Equal(t, srv.dbft.MyIndex, st.MyIndex)
+	require.False(t, st.RoundStart.IsZero())
+	require.Equal(t, 4, len(st.Validators))
+	for i := range st.Validators {
+		require.Equal(t, uint64(0), st.Validators[i].LostRounds)
+	}
+
+	for i := 0; i < 4; i++ {
+		p := new(Payload)
+		// One PrepareRequest and three ChangeViews.
+		if i == 1 {
+			p.SetType(payload.PrepareRequestType)
+			p.SetPayload(&prepareRequest{prevHash: srv.Chain.CurrentBlockHash()})
+		} else {
+			p.SetType(payload.ChangeViewType)
+			p.SetPayload(&changeView{newViewNumber: 1, timestamp: uint64(time.Now().UnixNano() / nsInMs)})
+		}
+		p.SetHeight(1)
+		p.SetValidatorIndex(uint16(i))
+
+		priv, _ := getTestValidator(i)
+		require.NoError(t, p.Sign(priv))
+
+		srv.onMessage(p)
+		srv.dbft.OnReceive(p)
+		srv.updateRound()
+	}
+	require.Equal(t, uint8(1), srv.dbft.ViewNumber)
+
+	st = srv.GetState()
+	require.Equal(t, uint8(1), st.ViewNumber)
+	require.Equal(t, uint64(1), st.ViewChanges)
+	require.Equal(t, getPrimaryIndex(1, 1, 4), st.PrimaryIndex)
+	lost := getPrimaryIndex(1, 0, 4)
+	for i := range st.Validators {
+		require.Equal(t, uint32(1), st.Validators[i].LastSeenHeight)
+		require.Equal(t, uint8(0), st.Validators[i].LastSeenView)
+		if uint(i) == lost {
+			require.Equal(t, uint64(1), st.Validators[i].LostRounds)
+		} else {
+			require.Equal(t, uint64(0), st.Validators[i].LostRounds)
+		}
+	}
+}
+
 func TestService_ValidatePayload(t *testing.T) {
 	srv := newTestService(t)
 	priv, _ := getTestValidator(1)
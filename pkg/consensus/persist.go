@@ -0,0 +1,178 @@
+package consensus
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/nspcc-dev/dbft/payload"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	npayload "github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"go.uber.org/zap"
+)
+
+// maxSavedPayloads is the maximum number of payloads stored in the recovery
+// file, it's enough for a number of views even with big committee.
+const maxSavedPayloads = 1024
+
+// recordPayload remembers payload of the current round to be saved into the
+// recovery file. Recovery requests are not saved as they don't affect dBFT
+// state. Only the latest payload of every type is kept for each validator,
+// so the number of saved payloads is limited by the number of validators.
+func (s *service) recordPayload(p *Payload) {
+	if s.RecoveryFile == "" || p.Type() == payload.RecoveryRequestType ||
+		p.Height() != s.dbft.BlockIndex {
+		return
+	}
+	for i, sp := range s.saved {
+		if sp.ValidatorIndex() == p.ValidatorIndex() && sp.Type() == p.Type() {
+			copy(s.saved[i:], s.saved[i+1:])
+			s.saved[len(s.saved)-1] = nil
+			s.saved = s.saved[:len(s.saved)-1]
+			break
+		}
+	}
+	if len(s.saved) >= maxSavedPayloads {
+		return
+	}
+	s.saved = append(s.saved, p)
+	s.savedDirty = true
+}
+
+// recordReceived remembers payload received from the network after it's
+// processed by dBFT. Payloads from other nodes are only saved if dBFT has
+// accepted them.
+func (s *service) recordReceived(p *Payload) {
+	if int(p.ValidatorIndex()) == s.dbft.MyIndex || s.isAccepted(p) {
+		s.recordPayload(p)
+	}
+}
+
+// isAccepted returns true if p is stored in dBFT context. Recovery messages
+// are not stored there, but they only differ in the set of payloads included
+// by the sender, so they're always accepted (one per validator is saved).
+func (s *service) isAccepted(p *Payload) bool {
+	var ps []payload.ConsensusPayload
+	switch p.Type() {
+	case payload.PrepareRequestType, payload.PrepareResponseType:
+		ps = s.dbft.PreparationPayloads
+	case payload.CommitType:
+		ps = s.dbft.CommitPayloads
+	case payload.ChangeViewType:
+		ps = s.dbft.ChangeViewPayloads
+	case payload.RecoveryMessageType:
+		return true
+	default:
+		return false
+	}
+	i := int(p.ValidatorIndex())
+	return i < len(ps) && ps[i] == payload.ConsensusPayload(p)
+}
+
+// saveRecovery writes payloads of the current round into the recovery file
+// if they've changed. The file is replaced atomically, so that it's always
+// possible to restore from it even if the node crashes while saving it.
+func (s *service) saveRecovery() {
+	if s.RecoveryFile == "" {
+		return
+	}
+	if s.savedIndex != s.dbft.BlockIndex {
+		var fresh []*Payload
+		for _, p := range s.saved {
+			if p.Height() == s.dbft.BlockIndex {
+				fresh = append(fresh, p)
+			}
+		}
+		s.saved = fresh
+		s.savedIndex = s.dbft.BlockIndex
+		s.savedDirty = true
+	}
+	if !s.savedDirty {
+		return
+	}
+	ps := make([]*npayload.Extensible, len(s.saved))
+	for i := range s.saved {
+		ps[i] = &s.saved[i].Extensible
+	}
+	w := io.NewBufBinWriter()
+	w.WriteU32LE(s.savedIndex)
+	w.WriteArray(ps)
+	if w.Err != nil {
+		s.log.Warn("can't encode consensus recovery data", zap.Error(w.Err))
+		return
+	}
+	tmp := s.RecoveryFile + ".tmp"
+	if err := writeFileSync(tmp, w.Bytes()); err != nil {
+		s.log.Warn("can't save consensus recovery data", zap.Error(err))
+		return
+	}
+	if err := os.Rename(tmp, s.RecoveryFile); err != nil {
+		s.log.Warn("can't save consensus recovery data", zap.Error(err))
+		return
+	}
+	s.savedDirty = false
+}
+
+// writeFileSync writes data to the file and flushes it to the disk, so that
+// it can be safely renamed.
+func writeFileSync(name string, data []byte) error {
+	f, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// loadRecovery reads payloads saved into the recovery file for the current
+// block and replays them, so that the node returns to the same view with the
+// same messages it had before restart.
+func (s *service) loadRecovery() error {
+	if s.RecoveryFile == "" {
+		return nil
+	}
+	data, err := ioutil.ReadFile(s.RecoveryFile)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	r := io.NewBinReaderFromIO(bytes.NewReader(data))
+	index := r.ReadU32LE()
+	var ps []*npayload.Extensible
+	r.ReadArray(&ps, maxSavedPayloads)
+	if r.Err != nil {
+		return fmt.Errorf("invalid recovery file: %w", r.Err)
+	}
+	if index != s.dbft.BlockIndex {
+		s.log.Debug("consensus recovery data is outdated",
+			zap.Uint32("saved", index),
+			zap.Uint32("current", s.dbft.BlockIndex))
+		return nil
+	}
+	var restored int
+	for _, ep := range ps {
+		p := s.payloadFromExtensible(ep)
+		if err := p.decodeData(); err != nil || !s.validatePayload(p) {
+			continue
+		}
+		s.dbft.OnReceive(p)
+		s.recordReceived(p)
+		restored++
+	}
+	s.savedIndex = index
+	s.log.Info("consensus state restored",
+		zap.Uint32("height", index),
+		zap.Uint("view", uint(s.dbft.ViewNumber)),
+		zap.Int("payloads", restored))
+	return nil
+}
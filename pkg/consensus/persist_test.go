@@ -0,0 +1,138 @@
+package consensus
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/dbft/payload"
+	"github.com/nspcc-dev/neo-go/pkg/core"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_Recovery(t *testing.T) {
+	dir, err := ioutil.TempDir("", "neogo.consensus")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	file := path.Join(dir, "dbft.state")
+
+	srv := newTestService(t)
+	srv.RecoveryFile = file
+	srv.dbft.Start()
+	t.Cleanup(srv.dbft.Timer.Stop)
+
+	for i := 0; i < 4; i++ {
+		p := NewPayload(srv.ProtocolConfiguration.Magic, false)
+		// One PrepareRequest and three ChangeViews.
+		if i == 1 {
+			p.SetType(payload.PrepareRequestType)
+			p.SetPayload(&prepareRequest{prevHash: srv.Chain.CurrentBlockHash()})
+		} else {
+			p.SetType(payload.ChangeViewType)
+			p.SetPayload(&changeView{newViewNumber: 1, timestamp: uint64(time.Now().UnixNano() / nsInMs)})
+		}
+		p.SetHeight(1)
+		p.SetValidatorIndex(uint16(i))
+
+		priv, pub := getTestValidator(i)
+		p.Sender = pub.GetScriptHash()
+		require.NoError(t, p.Sign(priv))
+
+		srv.dbft.OnReceive(p)
+		srv.recordReceived(p)
+		srv.saveRecovery()
+	}
+	require.Equal(t, uint8(1), srv.dbft.ViewNumber)
+
+	t.Run("restore", func(t *testing.T) {
+		srv2 := newTestServiceWithChain(t, srv.Chain.(*core.Blockchain))
+		srv2.RecoveryFile = file
+		srv2.dbft.Start()
+		t.Cleanup(srv2.dbft.Timer.Stop)
+		require.Equal(t, uint8(0), srv2.dbft.ViewNumber)
+
+		require.NoError(t, srv2.loadRecovery())
+		require.Equal(t, uint8(1), srv2.dbft.ViewNumber)
+		require.Equal(t, len(srv.saved), len(srv2.saved))
+	})
+
+	t.Run("outdated", func(t *testing.T) {
+		srv2 := newTestServiceWithChain(t, srv.Chain.(*core.Blockchain))
+		srv2.RecoveryFile = file
+		srv2.dbft.Start()
+		t.Cleanup(srv2.dbft.Timer.Stop)
+		srv2.dbft.BlockIndex = 2
+
+		require.NoError(t, srv2.loadRecovery())
+		require.Equal(t, uint8(0), srv2.dbft.ViewNumber)
+		require.Equal(t, 0, len(srv2.saved))
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		srv2 := newTestServiceWithChain(t, srv.Chain.(*core.Blockchain))
+		srv2.RecoveryFile = path.Join(dir, "missing")
+		srv2.dbft.Start()
+		t.Cleanup(srv2.dbft.Timer.Stop)
+		require.NoError(t, srv2.loadRecovery())
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		bad := path.Join(dir, "bad")
+		require.NoError(t, ioutil.WriteFile(bad, []byte{1, 2}, 0644))
+		srv2 := newTestServiceWithChain(t, srv.Chain.(*core.Blockchain))
+		srv2.RecoveryFile = bad
+		srv2.dbft.Start()
+		t.Cleanup(srv2.dbft.Timer.Stop)
+		require.Error(t, srv2.loadRecovery())
+	})
+}
+
+func TestService_RecordPayload(t *testing.T) {
+	srv := newTestService(t)
+	srv.RecoveryFile = "unused"
+	srv.dbft.Start()
+	t.Cleanup(srv.dbft.Timer.Stop)
+
+	newChangeView := func(index int) *Payload {
+		p := NewPayload(srv.ProtocolConfiguration.Magic, false)
+		p.SetType(payload.ChangeViewType)
+		p.SetPayload(&changeView{newViewNumber: 1})
+		p.SetHeight(srv.dbft.BlockIndex)
+		p.SetValidatorIndex(uint16(index))
+		return p
+	}
+	other := (srv.dbft.MyIndex + 1) % len(srv.dbft.Validators)
+
+	own := newChangeView(srv.dbft.MyIndex)
+	srv.recordPayload(own)
+	for i := 0; i < maxSavedPayloads; i++ {
+		srv.recordPayload(newChangeView(other))
+	}
+	last := newChangeView(other)
+	srv.recordPayload(last)
+	cm := newChangeView(other)
+	cm.SetType(payload.CommitType)
+	cm.SetPayload(&commit{})
+	srv.recordPayload(cm)
+
+	require.Equal(t, 3, len(srv.saved))
+	require.True(t, own == srv.saved[0])
+	require.True(t, last == srv.saved[1])
+	require.True(t, cm == srv.saved[2])
+
+	t.Run("not accepted", func(t *testing.T) {
+		// Not processed by dBFT.
+		p := newChangeView(other)
+		srv.recordReceived(p)
+		require.Equal(t, 3, len(srv.saved))
+		for _, sp := range srv.saved {
+			require.False(t, p == sp)
+		}
+
+		p = newChangeView(srv.dbft.MyIndex)
+		srv.recordReceived(p)
+		require.True(t, p == srv.saved[2])
+	})
+}
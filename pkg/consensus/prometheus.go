@@ -0,0 +1,59 @@
+package consensus
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Metrics used in monitoring service.
+var (
+	currentView = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Help:      "Current dBFT view number",
+			Name:      "consensus_view",
+			Namespace: "neogo",
+		},
+	)
+
+	viewChanges = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Help:      "Number of dBFT view changes",
+			Name:      "consensus_view_changes",
+			Namespace: "neogo",
+		},
+	)
+
+	proposalLatency = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Help:      "Time from the start of dBFT round to the PrepareRequest",
+			Name:      "consensus_proposal_latency_seconds",
+			Namespace: "neogo",
+			Buckets:   prometheus.ExponentialBuckets(0.05, 2, 10),
+		},
+	)
+
+	commitLatency = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Help:      "Time from the PrepareRequest to the block acceptance",
+			Name:      "consensus_commit_latency_seconds",
+			Namespace: "neogo",
+			Buckets:   prometheus.ExponentialBuckets(0.05, 2, 10),
+		},
+	)
+
+	lostRounds = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Help:      "Number of dBFT rounds lost with the validator being primary",
+			Name:      "consensus_lost_rounds",
+			Namespace: "neogo",
+		},
+		[]string{"validator"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		currentView,
+		viewChanges,
+		proposalLatency,
+		commitLatency,
+		lostRounds,
+	)
+}
@@ -0,0 +1,216 @@
+package consensus
+
+import (
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/dbft/payload"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+)
+
+// State is a snapshot of the consensus process state.
+type State struct {
+	// BlockIndex is an index of the block being accepted.
+	BlockIndex uint32
+	// ViewNumber is the current view number.
+	ViewNumber byte
+	// PrimaryIndex is an index of the current primary validator.
+	PrimaryIndex uint
+	// MyIndex is an index of the node in validators list, -1 if it's not
+	// a validator.
+	MyIndex int
+	// RoundStart is the time current view was started at.
+	RoundStart time.Time
+	// ViewChanges is the number of view changes since the service start.
+	ViewChanges uint64
+	// Preparations, Commits and ChangeViews are the numbers of respective
+	// messages received for the current block.
+	Preparations int
+	Commits      int
+	ChangeViews  int
+	// Validators contains per-validator statistics.
+	Validators []ValidatorState
+}
+
+// ValidatorState contains statistics for a single validator.
+type ValidatorState struct {
+	PublicKey *keys.PublicKey
+	// LastSeenHeight and LastSeenView are the height and the view of the
+	// last message received from the validator.
+	LastSeenHeight uint32
+	LastSeenView   byte
+	// LostRounds is the number of rounds that ended with view change while
+	// this validator was primary.
+	LostRounds uint64
+}
+
+// validatorStats contains validator statistics collected by the service.
+type validatorStats struct {
+	lastSeenHeight uint32
+	lastSeenView   byte
+	seen           bool
+	lostRounds     uint64
+}
+
+// roundTracker collects consensus statistics. It's updated from the service
+// event loop only, but can be read concurrently via snapshot.
+type roundTracker struct {
+	lock        sync.RWMutex
+	state       State
+	proposed    time.Time
+	viewChanges uint64
+	stats       map[string]*validatorStats
+}
+
+func newRoundTracker() *roundTracker {
+	return &roundTracker{
+		state: State{MyIndex: -1},
+		stats: make(map[string]*validatorStats),
+	}
+}
+
+// getPrimaryIndex returns primary index for the specified height and view
+// the same way dBFT does.
+func getPrimaryIndex(height uint32, view byte, n int) uint {
+	p := (int(height) - int(view)) % n
+	if p < 0 {
+		p += n
+	}
+	return uint(p)
+}
+
+// updateRound synchronizes tracker with dBFT context, it should be called
+// after each event processed by dBFT.
+func (s *service) updateRound() {
+	if s.dbft == nil {
+		return
+	}
+	r := s.round
+	height, view := s.dbft.BlockIndex, s.dbft.ViewNumber
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	st := &r.state
+	st.Validators = st.Validators[:0]
+	for _, pub := range s.dbft.Validators {
+		st.Validators = append(st.Validators, ValidatorState{PublicKey: pub.(*publicKey).PublicKey})
+	}
+	switch {
+	case height != st.BlockIndex || st.RoundStart.IsZero():
+		st.RoundStart = time.Now()
+		r.proposed = time.Time{}
+	case view > st.ViewNumber:
+		n := len(st.Validators)
+		for v := st.ViewNumber; v < view && n != 0; v++ {
+			key := keyString(st.Validators[getPrimaryIndex(height, v, n)].PublicKey)
+			r.getStats(key).lostRounds++
+			lostRounds.WithLabelValues(key).Inc()
+		}
+		r.viewChanges += uint64(view - st.ViewNumber)
+		viewChanges.Add(float64(view - st.ViewNumber))
+		st.RoundStart = time.Now()
+		r.proposed = time.Time{}
+	}
+	currentView.Set(float64(view))
+
+	st.BlockIndex = height
+	st.ViewNumber = view
+	st.PrimaryIndex = s.dbft.PrimaryIndex
+	st.MyIndex = s.dbft.MyIndex
+	st.ViewChanges = r.viewChanges
+	st.Preparations = countPayloads(s.dbft.PreparationPayloads)
+	st.Commits = countPayloads(s.dbft.CommitPayloads)
+	st.ChangeViews = countPayloads(s.dbft.ChangeViewPayloads)
+	for i := range st.Validators {
+		if vs, ok := r.stats[keyString(st.Validators[i].PublicKey)]; ok {
+			if vs.seen {
+				st.Validators[i].LastSeenHeight = vs.lastSeenHeight
+				st.Validators[i].LastSeenView = vs.lastSeenView
+			}
+			st.Validators[i].LostRounds = vs.lostRounds
+		}
+	}
+}
+
+// onMessage records message sender and proposal time.
+func (s *service) onMessage(p *Payload) {
+	r := s.round
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if i := int(p.ValidatorIndex()); i < len(r.state.Validators) {
+		vs := r.getStats(keyString(r.state.Validators[i].PublicKey))
+		vs.seen = true
+		vs.lastSeenHeight = p.Height()
+		vs.lastSeenView = p.ViewNumber()
+	}
+	if p.Type() == payload.PrepareRequestType {
+		r.markProposed(p.Height(), p.ViewNumber())
+	}
+}
+
+// onProposal records own proposal time.
+func (s *service) onProposal(p *Payload) {
+	r := s.round
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.markProposed(p.Height(), p.ViewNumber())
+}
+
+// onBlock records commit latency of the block being accepted.
+func (s *service) onBlock(index uint32) {
+	r := s.round
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if index == r.state.BlockIndex && !r.proposed.IsZero() {
+		commitLatency.Observe(time.Since(r.proposed).Seconds())
+		r.proposed = time.Time{}
+	}
+}
+
+// markProposed records proposal for the current round, it must be called
+// with the lock held.
+func (r *roundTracker) markProposed(height uint32, view byte) {
+	if r.proposed.IsZero() && height == r.state.BlockIndex && view == r.state.ViewNumber {
+		r.proposed = time.Now()
+		proposalLatency.Observe(r.proposed.Sub(r.state.RoundStart).Seconds())
+	}
+}
+
+func (r *roundTracker) getStats(key string) *validatorStats {
+	vs, ok := r.stats[key]
+	if !ok {
+		vs = new(validatorStats)
+		r.stats[key] = vs
+	}
+	return vs
+}
+
+func (r *roundTracker) snapshot() State {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	st := r.state
+	st.Validators = make([]ValidatorState, len(r.state.Validators))
+	copy(st.Validators, r.state.Validators)
+	return st
+}
+
+// keyString returns hex-encoded compressed public key.
+func keyString(pub *keys.PublicKey) string {
+	return hex.EncodeToString(pub.Bytes())
+}
+
+func countPayloads(ps []payload.ConsensusPayload) int {
+	var n int
+	for i := range ps {
+		if ps[i] != nil {
+			n++
+		}
+	}
+	return n
+}
@@ -2,10 +2,12 @@ package core
 
 import (
 	"bytes"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"math"
 	"math/big"
+	"runtime"
 	"sort"
 	"sync"
 	"sync/atomic"
@@ -71,6 +73,9 @@ var (
 	// conflicts with other transaction in the chain or pool according to
 	// Conflicts attribute.
 	ErrHasConflicts = errors.New("has conflicts")
+	// ErrBlockHeaderMismatch is returned when trying to add block that
+	// doesn't match already stored header with the same index.
+	ErrBlockHeaderMismatch = errors.New("block doesn't match stored header")
 )
 var (
 	persistInterval = 1 * time.Second
@@ -98,6 +103,9 @@ type Blockchain struct {
 	// Data access object for CRUD operations around storage.
 	dao *dao.Simple
 
+	// Persistent storage backend used by dao.
+	store storage.Store
+
 	// Current index/height of the highest block.
 	// Read access should always be called by BlockHeight().
 	// Write access should only happen in storeBlock().
@@ -122,6 +130,9 @@ type Blockchain struct {
 
 	memPool *mempool.Pool
 
+	// Transactions with already verified witnesses.
+	witnesses *witnessCache
+
 	// postBlock is a set of callback methods which should be run under the Blockchain lock after new block is persisted.
 	// Block's transactions are passed via mempool.
 	postBlock []func(blockchainer.Blockchainer, *mempool.Pool, *block.Block)
@@ -146,6 +157,21 @@ type Blockchain struct {
 	events  chan bcEvent
 	subCh   chan interface{}
 	unsubCh chan interface{}
+
+	// Number of storage changes subscribers, changes are only collected
+	// when there is someone to receive them.
+	storageChangesSubs uint32
+
+	// Per-contract storage usage statistics, nil if disabled.
+	storageStats *storageStats
+
+	// blockFetcher provides blocks removed from the DB, nil if not set.
+	blockFetcher services.BlockFetcher
+
+	// Private chain fork point (see Fork), the block following it is
+	// signed by standby validators.
+	forked     bool
+	forkHeight uint32
 }
 
 // bcEvent is an internal event generated by the Blockchain and then
@@ -155,6 +181,7 @@ type Blockchain struct {
 type bcEvent struct {
 	block          *block.Block
 	appExecResults []*state.AppExecResult
+	storageChanges *state.StorageChanges
 }
 
 // NewBlockchain returns a new blockchain object the will use the
@@ -190,6 +217,12 @@ func NewBlockchain(s storage.Store, cfg config.ProtocolConfiguration, log *zap.L
 		log.Info("MaxTransactionsPerBlock is not set or wrong, using default value",
 			zap.Uint16("MaxTransactionsPerBlock", cfg.MaxTransactionsPerBlock))
 	}
+	if cfg.MemPoolReplaceFeeIncrease < 0 || cfg.MemPoolReplaceMinFee < 0 {
+		return nil, errors.New("MemPoolReplaceFeeIncrease and MemPoolReplaceMinFee can't be negative")
+	}
+	if cfg.StateRetentionBlocks != 0 && !cfg.KeepOnlyLatestState {
+		return nil, errors.New("StateRetentionBlocks can only be used with KeepOnlyLatestState enabled")
+	}
 	committee, err := committeeFromConfig(cfg)
 	if err != nil {
 		return nil, err
@@ -201,9 +234,11 @@ func NewBlockchain(s storage.Store, cfg config.ProtocolConfiguration, log *zap.L
 	bc := &Blockchain{
 		config:      cfg,
 		dao:         dao.NewSimple(s, cfg.StateRootInHeader),
+		store:       s,
 		stopCh:      make(chan struct{}),
 		runToExitCh: make(chan struct{}),
 		memPool:     mempool.New(cfg.MemPoolSize, 0, false),
+		witnesses:   newWitnessCache(cfg.MemPoolSize),
 		sbCommittee: committee,
 		log:         log,
 		events:      make(chan bcEvent),
@@ -213,12 +248,17 @@ func NewBlockchain(s storage.Store, cfg config.ProtocolConfiguration, log *zap.L
 		contracts: *native.NewContracts(cfg.P2PSigExtensions, cfg.NativeUpdateHistories),
 	}
 
+	bc.memPool.SetReplacementPolicy(cfg.MemPoolReplaceFeeIncrease, cfg.MemPoolReplaceMinFee)
 	bc.stateRoot = stateroot.NewModule(bc, bc.log, bc.dao.Store)
 	bc.contracts.Designate.StateRootService = bc.stateRoot
 
 	if err := bc.init(); err != nil {
 		return nil, err
 	}
+	if cfg.StorageMetrics {
+		bc.storageStats = newStorageStats(bc.dao.Store)
+		bc.storageStats.publish(bc.GetContractScriptHash)
+	}
 
 	return bc, nil
 }
@@ -243,6 +283,13 @@ func (bc *Blockchain) SetNotary(mod services.Notary) {
 	bc.contracts.Designate.NotaryService.Store(mod)
 }
 
+// SetBlockFetcher sets the service used to retrieve blocks removed from the
+// DB. It doesn't protected by mutex and must be called before `bc.Run()` to
+// avoid data race.
+func (bc *Blockchain) SetBlockFetcher(mod services.BlockFetcher) {
+	bc.blockFetcher = mod
+}
+
 func (bc *Blockchain) init() error {
 	// If we could not find the version in the Store, we know that there is nothing stored.
 	ver, err := bc.dao.GetVersion()
@@ -263,7 +310,20 @@ func (bc *Blockchain) init() error {
 		if err := bc.stateRoot.Init(0, bc.config.KeepOnlyLatestState); err != nil {
 			return fmt.Errorf("can't init MPT: %w", err)
 		}
-		return bc.storeBlock(genesisBlock, nil)
+		if err := bc.storeBlock(genesisBlock, nil); err != nil {
+			return err
+		}
+		// Genesis transactions come from the configuration, so failing
+		// ones are most likely a configuration mistake.
+		for _, tx := range genesisBlock.Transactions {
+			aers, err := bc.GetAppExecResults(tx.Hash(), trigger.Application)
+			if err == nil && len(aers) != 0 && aers[0].VMState != vm.HaltState {
+				bc.log.Error("genesis transaction failed",
+					zap.Stringer("hash", tx.Hash()),
+					zap.String("exception", aers[0].FaultException))
+			}
+		}
+		return nil
 	}
 	if ver != version {
 		return fmt.Errorf("storage version mismatch betweeen %s and %s", version, ver)
@@ -331,6 +391,15 @@ func (bc *Blockchain) init() error {
 		}
 	}
 
+	forkHeight, err := bc.dao.GetForkHeight()
+	switch {
+	case err == nil:
+		bc.forked = true
+		bc.forkHeight = forkHeight
+	case !errors.Is(err, storage.ErrKeyNotFound):
+		return fmt.Errorf("can't read fork height: %w", err)
+	}
+
 	err = bc.contracts.NEO.InitializeCache(bc, bc.dao)
 	if err != nil {
 		return fmt.Errorf("can't init cache for NEO native contract: %w", err)
@@ -385,6 +454,7 @@ func (bc *Blockchain) notificationDispatcher() {
 		txFeed           = make(map[chan<- *transaction.Transaction]bool)
 		notificationFeed = make(map[chan<- *state.NotificationEvent]bool)
 		executionFeed    = make(map[chan<- *state.AppExecResult]bool)
+		storageFeed      = make(map[chan<- *state.StorageChanges]bool)
 	)
 	for {
 		select {
@@ -400,6 +470,9 @@ func (bc *Blockchain) notificationDispatcher() {
 				notificationFeed[ch] = true
 			case chan<- *state.AppExecResult:
 				executionFeed[ch] = true
+			case chan<- *state.StorageChanges:
+				storageFeed[ch] = true
+				atomic.StoreUint32(&bc.storageChangesSubs, uint32(len(storageFeed)))
 			default:
 				panic(fmt.Sprintf("bad subscription: %T", sub))
 			}
@@ -413,6 +486,9 @@ func (bc *Blockchain) notificationDispatcher() {
 				delete(notificationFeed, ch)
 			case chan<- *state.AppExecResult:
 				delete(executionFeed, ch)
+			case chan<- *state.StorageChanges:
+				delete(storageFeed, ch)
+				atomic.StoreUint32(&bc.storageChangesSubs, uint32(len(storageFeed)))
 			default:
 				panic(fmt.Sprintf("bad unsubscription: %T", unsub))
 			}
@@ -468,6 +544,11 @@ func (bc *Blockchain) notificationDispatcher() {
 					}
 				}
 			}
+			if event.storageChanges != nil {
+				for ch := range storageFeed {
+					ch <- event.storageChanges
+				}
+			}
 			for ch := range blockFeed {
 				ch <- event.block
 			}
@@ -486,6 +567,53 @@ func (bc *Blockchain) Close() {
 	bc.addLock.Unlock()
 }
 
+// Fork turns the chain into a private one continuing from the current
+// height. NEO committee is replaced with the standby committee from the
+// configuration (see NEO.ForkCommittee) and the next block is verified
+// against standby validators instead of the NextConsensus of the current
+// one. State root of the current block is updated to include these
+// changes. It's not supposed to be used with the chain being synchronized.
+func (bc *Blockchain) Fork() error {
+	bc.addLock.Lock()
+	defer bc.addLock.Unlock()
+
+	height := bc.BlockHeight()
+	cache := dao.NewSimple(bc.dao.Store, bc.config.StateRootInHeader)
+	if err := bc.contracts.NEO.ForkCommittee(bc, cache); err != nil {
+		return fmt.Errorf("can't replace committee: %w", err)
+	}
+	mpt, sr, err := bc.stateRoot.AddMPTBatch(height, cache.GetMPTBatch(), cache.Store)
+	if err != nil {
+		return fmt.Errorf("can't update state root: %w", err)
+	}
+	if err := cache.PutForkHeight(height); err != nil {
+		return err
+	}
+
+	bc.lock.Lock()
+	defer bc.lock.Unlock()
+	if _, err := cache.Persist(); err != nil {
+		return err
+	}
+	mpt.Store = bc.dao.Store
+	bc.stateRoot.UpdateCurrentLocal(mpt, sr)
+	bc.forked = true
+	bc.forkHeight = height
+	bc.log.Info("chain forked", zap.Uint32("height", height),
+		zap.Stringer("stateroot", sr.Root))
+	return nil
+}
+
+// CompactStorage compacts persistent storage if it's supported by the
+// backend and returns the number of bytes reclaimed.
+func (bc *Blockchain) CompactStorage() (int64, error) {
+	c, ok := bc.store.(storage.Compactor)
+	if !ok {
+		return 0, errors.New("storage compaction is not supported by the DB backend")
+	}
+	return c.Compact()
+}
+
 // AddBlock accepts successive block for the Blockchain, verifies it and
 // stores internally. Eventually it will be persisted to the backing storage.
 func (bc *Blockchain) AddBlock(block *block.Block) error {
@@ -507,6 +635,9 @@ func (bc *Blockchain) AddBlock(block *block.Block) error {
 		if err != nil {
 			return err
 		}
+	} else if h := bc.GetHeaderHash(int(block.Index)); !h.Equals(block.Hash()) {
+		// Header was added (and verified) before the block.
+		return fmt.Errorf("%w: %s != %s", ErrBlockHeaderMismatch, block.Hash().StringLE(), h.StringLE())
 	}
 	if bc.config.VerifyBlocks {
 		merkle := block.ComputeMerkleRoot()
@@ -514,7 +645,8 @@ func (bc *Blockchain) AddBlock(block *block.Block) error {
 			return errors.New("invalid block: MerkleRoot mismatch")
 		}
 		mp = mempool.New(len(block.Transactions), 0, false)
-		for _, tx := range block.Transactions {
+		witnessErrs := bc.verifyBlockTxWitnesses(block.Transactions)
+		for i, tx := range block.Transactions {
 			var err error
 			// Transactions are verified before adding them
 			// into the pool, so there is no point in doing
@@ -525,7 +657,8 @@ func (bc *Blockchain) AddBlock(block *block.Block) error {
 					continue
 				}
 			} else {
-				err = bc.verifyAndPoolTx(tx, mp, bc)
+				witnessErr := witnessErrs[i]
+				err = bc.verifyAndPoolTxWithWitnesses(tx, mp, bc, func() error { return witnessErr })
 			}
 			if err != nil && bc.config.VerifyTransactions {
 				return fmt.Errorf("transaction %s failed to verify: %w", tx.Hash().StringLE(), err)
@@ -669,10 +802,17 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 		v.SetPriceGetter(systemInterop.GetPrice)
 		v.LoadToken = contract.LoadToken(systemInterop)
 		v.GasLimit = tx.SystemFee
+		if bc.config.SaveTransactionTraces {
+			systemInterop.EnableCallTracing()
+		}
 
 		err := v.Run()
 		var faultException string
+		var txStorage []state.StorageChange
 		if !v.HasFailed() {
+			if bc.config.SaveTransactionTraces {
+				txStorage = newStorageChanges(block.Index, systemInterop.DAO.GetBatch()).Storage
+			}
 			_, err := systemInterop.DAO.Persist()
 			if err != nil {
 				return fmt.Errorf("failed to persist invocation results: %w", err)
@@ -705,6 +845,21 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 		}
 		writeBuf.Reset()
 
+		if bc.config.SaveTransactionTraces {
+			err = cache.PutTransactionTrace(&state.TransactionTrace{
+				Container:      tx.Hash(),
+				VMState:        v.State(),
+				GasConsumed:    v.GasConsumed(),
+				FaultException: faultException,
+				Call:           *systemInterop.CallTrace(),
+				Storage:        txStorage,
+			}, writeBuf)
+			if err != nil {
+				return fmt.Errorf("failed to store tx execution trace: %w", err)
+			}
+			writeBuf.Reset()
+		}
+
 		if bc.config.P2PSigExtensions {
 			for _, attr := range tx.GetAttributes(transaction.ConflictsT) {
 				hash := attr.Value.(*transaction.Conflicts).Hash
@@ -728,6 +883,18 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 		return fmt.Errorf("failed to store postPersist exec result: %w", err)
 	}
 	writeBuf.Reset()
+	if bc.config.AddressHistoryIndex {
+		err = bc.indexAddressTransactions(cache, block)
+		if err != nil {
+			return fmt.Errorf("failed to index transactions: %w", err)
+		}
+	}
+	if bc.config.NotificationsIndex {
+		err = bc.indexNotifications(cache, block, appExecResults)
+		if err != nil {
+			return fmt.Errorf("failed to index notifications: %w", err)
+		}
+	}
 
 	d := cache.DAO.(*dao.Simple)
 	b := d.GetMPTBatch()
@@ -739,8 +906,24 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 		return fmt.Errorf("error while trying to apply MPT changes: %w", err)
 	}
 
-	if bc.config.SaveStorageBatch {
-		bc.lastBatch = cache.DAO.GetBatch()
+	var storageChanges *state.StorageChanges
+	if bc.config.SaveStorageBatch || bc.config.SaveStorageChanges ||
+		atomic.LoadUint32(&bc.storageChangesSubs) != 0 || bc.storageStats != nil {
+		batch := cache.DAO.GetBatch()
+		if bc.config.SaveStorageBatch {
+			bc.lastBatch = batch
+		}
+		if bc.storageStats != nil {
+			bc.storageStats.apply(batch, bc.dao.Store.Get)
+		}
+		storageChanges = newStorageChanges(block.Index, batch)
+	}
+	if bc.config.SaveStorageChanges {
+		err = cache.PutStorageChanges(storageChanges, writeBuf)
+		if err != nil {
+			return fmt.Errorf("failed to store storage changes: %w", err)
+		}
+		writeBuf.Reset()
 	}
 	if bc.config.RemoveUntraceableBlocks {
 		if block.Index > bc.config.MaxTraceableBlocks {
@@ -752,6 +935,14 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 					zap.Error(err))
 			}
 			writeBuf.Reset()
+			if bc.config.SaveStorageChanges {
+				err = cache.DeleteStorageChanges(index)
+				if err != nil {
+					bc.log.Warn("error while removing old storage changes",
+						zap.Uint32("index", index),
+						zap.Error(err))
+				}
+			}
 		}
 	}
 	// Every persist cycle we also compact our in-memory MPT. It's flushed
@@ -788,7 +979,7 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 	// is no one to read this event. And it doesn't make much sense as event
 	// anyway.
 	if block.Index != 0 {
-		bc.events <- bcEvent{block, appExecResults}
+		bc.events <- bcEvent{block, appExecResults, storageChanges}
 	}
 	return nil
 }
@@ -885,7 +1076,7 @@ func (bc *Blockchain) handleNotification(note *state.NotificationEvent, d *dao.C
 		return
 	}
 	arr, ok := note.Item.Value().([]stackitem.Item)
-	if !ok || len(arr) != 3 {
+	if !ok || !(len(arr) == 3 || len(arr) == 4) {
 		return
 	}
 	var from []byte
@@ -917,7 +1108,16 @@ func (bc *Blockchain) handleNotification(note *state.NotificationEvent, d *dao.C
 		}
 		amount = bigint.FromBytes(bs)
 	}
-	bc.processNEP17Transfer(d, h, b, note.ScriptHash, from, to, amount)
+	if len(arr) == 3 {
+		bc.processNEP17Transfer(d, h, b, note.ScriptHash, from, to, amount)
+		return
+	}
+	// NEP11 transfer has an additional token ID parameter.
+	id, err := arr[3].TryBytes()
+	if err != nil || len(id) > state.MaxNEP11TokenIDLen {
+		return
+	}
+	bc.processNEP11Transfer(d, h, b, note.ScriptHash, from, to, amount, id)
 }
 
 func parseUint160(addr []byte) util.Uint160 {
@@ -927,19 +1127,25 @@ func parseUint160(addr []byte) util.Uint160 {
 	return util.Uint160{}
 }
 
+// getAssetID returns the ID of the token contract with the given hash.
+func (bc *Blockchain) getAssetID(cache *dao.Cached, sc util.Uint160) (int32, bool) {
+	nativeContract := bc.contracts.ByHash(sc)
+	if nativeContract != nil {
+		return nativeContract.Metadata().ID, true
+	}
+	assetContract, err := bc.contracts.Management.GetContract(cache, sc)
+	if err != nil {
+		return 0, false
+	}
+	return assetContract.ID, true
+}
+
 func (bc *Blockchain) processNEP17Transfer(cache *dao.Cached, h util.Uint256, b *block.Block, sc util.Uint160, from, to []byte, amount *big.Int) {
 	toAddr := parseUint160(to)
 	fromAddr := parseUint160(from)
-	var id int32
-	nativeContract := bc.contracts.ByHash(sc)
-	if nativeContract != nil {
-		id = nativeContract.Metadata().ID
-	} else {
-		assetContract, err := bc.contracts.Management.GetContract(cache, sc)
-		if err != nil {
-			return
-		}
-		id = assetContract.ID
+	id, ok := bc.getAssetID(cache, sc)
+	if !ok {
+		return
 	}
 	transfer := &state.NEP17Transfer{
 		Asset:     id,
@@ -996,6 +1202,64 @@ func (bc *Blockchain) processNEP17Transfer(cache *dao.Cached, h util.Uint256, b
 	}
 }
 
+func (bc *Blockchain) processNEP11Transfer(cache *dao.Cached, h util.Uint256, b *block.Block, sc util.Uint160, from, to []byte, amount *big.Int, tokenID []byte) {
+	toAddr := parseUint160(to)
+	fromAddr := parseUint160(from)
+	id, ok := bc.getAssetID(cache, sc)
+	if !ok {
+		return
+	}
+	transfer := &state.NEP11Transfer{
+		NEP17Transfer: state.NEP17Transfer{
+			Asset:     id,
+			From:      fromAddr,
+			To:        toAddr,
+			Block:     b.Index,
+			Timestamp: b.Timestamp,
+			Tx:        h,
+		},
+		ID: tokenID,
+	}
+	if !fromAddr.Equals(util.Uint160{}) {
+		balances, err := cache.GetNEP11Balances(fromAddr)
+		if err != nil {
+			return
+		}
+		balances.Add(id, tokenID, new(big.Int).Neg(amount), b.Index)
+		transfer.Amount = *new(big.Int).Neg(amount)
+		balances.NewBatch, err = cache.AppendNEP11Transfer(fromAddr,
+			balances.NextTransferBatch, balances.NewBatch, transfer)
+		if err != nil {
+			return
+		}
+		if balances.NewBatch {
+			balances.NextTransferBatch++
+		}
+		if err := cache.PutNEP11Balances(fromAddr, balances); err != nil {
+			return
+		}
+	}
+	if !toAddr.Equals(util.Uint160{}) {
+		balances, err := cache.GetNEP11Balances(toAddr)
+		if err != nil {
+			return
+		}
+		balances.Add(id, tokenID, amount, b.Index)
+		transfer.Amount = *amount
+		balances.NewBatch, err = cache.AppendNEP11Transfer(toAddr,
+			balances.NextTransferBatch, balances.NewBatch, transfer)
+		if err != nil {
+			return
+		}
+		if balances.NewBatch {
+			balances.NextTransferBatch++
+		}
+		if err := cache.PutNEP11Balances(toAddr, balances); err != nil {
+			return
+		}
+	}
+}
+
 // ForEachNEP17Transfer executes f for each nep17 transfer in log.
 func (bc *Blockchain) ForEachNEP17Transfer(acc util.Uint160, f func(*state.NEP17Transfer) (bool, error)) error {
 	balances, err := bc.dao.GetNEP17Balances(acc)
@@ -1018,6 +1282,235 @@ func (bc *Blockchain) ForEachNEP17Transfer(acc util.Uint160, f func(*state.NEP17
 	return nil
 }
 
+// batchedLogs appends entries to batched logs of a number of contracts or
+// accounts, infos of these logs are retrieved once and kept until flush.
+type batchedLogs struct {
+	infos   map[util.Uint160]*state.BatchedLogInfo
+	getInfo func(util.Uint160) (*state.BatchedLogInfo, error)
+	putInfo func(util.Uint160, *state.BatchedLogInfo) error
+}
+
+// newBatchedLogs creates batchedLogs using the given log info accessors.
+func newBatchedLogs(getInfo func(util.Uint160) (*state.BatchedLogInfo, error), putInfo func(util.Uint160, *state.BatchedLogInfo) error) *batchedLogs {
+	return &batchedLogs{
+		infos:   make(map[util.Uint160]*state.BatchedLogInfo),
+		getInfo: getInfo,
+		putInfo: putInfo,
+	}
+}
+
+// add appends an entry to the log of h using appendF that gets the batch
+// index and the new batch flag and returns true if the batch is full.
+func (l *batchedLogs) add(h util.Uint160, appendF func(index uint32, isNew bool) (bool, error)) error {
+	info, ok := l.infos[h]
+	if !ok {
+		var err error
+		info, err = l.getInfo(h)
+		if err != nil {
+			return err
+		}
+		l.infos[h] = info
+	}
+	var err error
+	info.NewBatch, err = appendF(info.NextBatch, info.NewBatch)
+	if err != nil {
+		return err
+	}
+	if info.NewBatch {
+		info.NextBatch++
+	}
+	return nil
+}
+
+// flush saves infos of all logs changed.
+func (l *batchedLogs) flush() error {
+	for h, info := range l.infos {
+		if err := l.putInfo(h, info); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// forEachLogBatch calls f for every batch index of the log with the given
+// info from the newest batch to the oldest one until f returns false or error.
+func forEachLogBatch(info *state.BatchedLogInfo, f func(index uint32) (bool, error)) error {
+	for i := int(info.NextBatch); i >= 0; i-- {
+		cont, err := f(uint32(i))
+		if err != nil {
+			return err
+		}
+		if !cont {
+			break
+		}
+	}
+	return nil
+}
+
+// notificationLogID returns the ID of notification log of the contract.
+// Notifications with a particular name are additionally stored in a separate
+// log (identified by the hash of the contract and the name) so that they can
+// be retrieved without iterating over other notifications.
+func notificationLogID(sc util.Uint160, name string) util.Uint160 {
+	if name == "" {
+		return sc
+	}
+	return hash.Hash160(append(sc.BytesBE(), name...))
+}
+
+// indexNotifications appends notifications emitted by successful executions
+// to the logs of contracts that emitted them (both general and per-name).
+func (bc *Blockchain) indexNotifications(cache *dao.Cached, b *block.Block, aers []*state.AppExecResult) error {
+	logs := newBatchedLogs(cache.GetNotificationLogInfo, cache.PutNotificationLogInfo)
+	for _, aer := range aers {
+		if aer.VMState != vm.HaltState {
+			continue
+		}
+		for i := range aer.Events {
+			ev := &aer.Events[i]
+			n := &state.IndexedNotification{
+				Block:     b.Index,
+				Container: aer.Container,
+				Name:      ev.Name,
+				Item:      ev.Item,
+			}
+			for _, id := range []util.Uint160{ev.ScriptHash, notificationLogID(ev.ScriptHash, ev.Name)} {
+				id := id
+				err := logs.add(id, func(index uint32, isNew bool) (bool, error) {
+					return cache.AppendNotification(id, index, isNew, n)
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return logs.flush()
+}
+
+// indexAddressTransactions appends block transactions to the logs of their
+// signers.
+func (bc *Blockchain) indexAddressTransactions(cache *dao.Cached, b *block.Block) error {
+	logs := newBatchedLogs(cache.GetAddressTxLogInfo, cache.PutAddressTxLogInfo)
+	for _, tx := range b.Transactions {
+		for i := range tx.Signers {
+			acc := tx.Signers[i].Account
+			t := &state.AddressTransaction{
+				Tx:        tx.Hash(),
+				Block:     b.Index,
+				Timestamp: b.Timestamp,
+				Sender:    i == 0,
+			}
+			err := logs.add(acc, func(index uint32, isNew bool) (bool, error) {
+				return cache.AppendAddressTransaction(acc, index, isNew, t)
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return logs.flush()
+}
+
+// ForEachAddressTransaction executes f for each transaction signed by the
+// account from the newest to the oldest. Transactions are only indexed if
+// AddressHistoryIndex setting is enabled.
+func (bc *Blockchain) ForEachAddressTransaction(acc util.Uint160, f func(*state.AddressTransaction) (bool, error)) error {
+	info, err := bc.dao.GetAddressTxLogInfo(acc)
+	if err != nil {
+		return err
+	}
+	return forEachLogBatch(info, func(index uint32) (bool, error) {
+		lg, err := bc.dao.GetAddressTxLog(acc, index)
+		if err != nil {
+			return false, err
+		}
+		return lg.ForEach(f)
+	})
+}
+
+// ForEachNotification executes f for each notification of the contract (with
+// the given name if it's not empty) emitted in blocks up to the end one from
+// the newest to the oldest. The batch containing notifications of the end
+// block is located with binary search, so newer notifications are mostly
+// not iterated over. Notifications are only indexed if NotificationsIndex
+// setting is enabled.
+func (bc *Blockchain) ForEachNotification(sc util.Uint160, name string, end uint32, f func(*state.IndexedNotification) (bool, error)) error {
+	id := notificationLogID(sc, name)
+	info, err := bc.dao.GetNotificationLogInfo(id)
+	if err != nil {
+		return err
+	}
+	// Batches are ordered by block index, find the first one starting
+	// after the end block, the previous one is where iteration starts.
+	var searchErr error
+	next := sort.Search(int(info.NextBatch)+1, func(i int) bool {
+		if searchErr != nil {
+			return true
+		}
+		lg, err := bc.dao.GetNotificationLog(id, uint32(i))
+		if err != nil {
+			searchErr = err
+			return true
+		}
+		first, err := lg.First()
+		if err != nil {
+			searchErr = err
+			return true
+		}
+		return first == nil || first.Block > end
+	})
+	if searchErr != nil {
+		return searchErr
+	}
+	if next == 0 {
+		return nil
+	}
+	return forEachLogBatch(&state.BatchedLogInfo{NextBatch: uint32(next - 1)}, func(index uint32) (bool, error) {
+		lg, err := bc.dao.GetNotificationLog(id, index)
+		if err != nil {
+			return false, err
+		}
+		return lg.ForEach(func(n *state.IndexedNotification) (bool, error) {
+			if n.Block > end {
+				return true, nil
+			}
+			return f(n)
+		})
+	})
+}
+
+// ForEachNEP11Transfer executes f for each nep11 transfer in log.
+func (bc *Blockchain) ForEachNEP11Transfer(acc util.Uint160, f func(*state.NEP11Transfer) (bool, error)) error {
+	balances, err := bc.dao.GetNEP11Balances(acc)
+	if err != nil {
+		return nil
+	}
+	for i := int(balances.NextTransferBatch); i >= 0; i-- {
+		lg, err := bc.dao.GetNEP11TransferLog(acc, uint32(i))
+		if err != nil {
+			return nil
+		}
+		cont, err := lg.ForEach(f)
+		if err != nil {
+			return err
+		}
+		if !cont {
+			break
+		}
+	}
+	return nil
+}
+
+// GetNEP11Balances returns NEP11 balances for the acc.
+func (bc *Blockchain) GetNEP11Balances(acc util.Uint160) *state.NEP11Balances {
+	bs, err := bc.dao.GetNEP11Balances(acc)
+	if err != nil {
+		return nil
+	}
+	return bs
+}
+
 // GetNEP17Balances returns NEP17 balances for the acc.
 func (bc *Blockchain) GetNEP17Balances(acc util.Uint160) *state.NEP17Balances {
 	bs, err := bc.dao.GetNEP17Balances(acc)
@@ -1104,6 +1597,9 @@ func (bc *Blockchain) persist() error {
 
 		// update monitoring metrics.
 		updatePersistedHeightMetric(bHeight)
+		if bc.storageStats != nil {
+			bc.storageStats.publish(bc.GetContractScriptHash)
+		}
 	}
 
 	return nil
@@ -1123,6 +1619,19 @@ func (bc *Blockchain) GetAppExecResults(hash util.Uint256, trig trigger.Type) ([
 	return bc.dao.GetAppExecResults(hash, trig)
 }
 
+// GetStorageChanges returns contract storage changes made by the block with
+// the given index. They're only available if SaveStorageChanges setting is
+// enabled.
+func (bc *Blockchain) GetStorageChanges(index uint32) (*state.StorageChanges, error) {
+	return bc.dao.GetStorageChanges(index)
+}
+
+// GetTransactionTrace returns execution trace of the transaction with the given
+// hash. Traces are only available if SaveTransactionTraces setting is enabled.
+func (bc *Blockchain) GetTransactionTrace(hash util.Uint256) (*state.TransactionTrace, error) {
+	return bc.dao.GetTransactionTrace(hash)
+}
+
 // GetStorageItem returns an item from storage.
 func (bc *Blockchain) GetStorageItem(id int32, key []byte) state.StorageItem {
 	return bc.dao.GetStorageItem(id, key)
@@ -1160,6 +1669,39 @@ func (bc *Blockchain) GetBlock(hash util.Uint256) (*block.Block, error) {
 	return block, nil
 }
 
+// FetchBlock returns a Block by the given hash like GetBlock does, but blocks
+// removed from the DB are retrieved via the block fetcher (if it's set). It
+// can involve network I/O, so it's only to be used for serving blocks to RPC
+// and P2P clients, never for block processing.
+func (bc *Blockchain) FetchBlock(hash util.Uint256) (*block.Block, error) {
+	b, err := bc.GetBlock(hash)
+	if err == nil || bc.blockFetcher == nil {
+		return b, err
+	}
+	h, herr := bc.GetHeader(hash)
+	if herr != nil || h.Index > bc.BlockHeight() {
+		return nil, err
+	}
+	return bc.fetchBlock(hash, h.Index)
+}
+
+// fetchBlock retrieves removed block from the block fetcher and checks that
+// it's the one we have a header for.
+func (bc *Blockchain) fetchBlock(hash util.Uint256, index uint32) (*block.Block, error) {
+	b, err := bc.blockFetcher.GetBlock(index)
+	if err != nil {
+		return nil, fmt.Errorf("only header is found, failed to fetch block: %w", err)
+	}
+	if !b.Hash().Equals(hash) {
+		return nil, fmt.Errorf("only header is found, fetched block %d has wrong hash %s",
+			index, b.Hash().StringLE())
+	}
+	if !b.ComputeMerkleRoot().Equals(b.MerkleRoot) {
+		return nil, fmt.Errorf("only header is found, fetched block %d has wrong merkle root", index)
+	}
+	return b, nil
+}
+
 // GetHeader returns data block header identified with the given hash value.
 func (bc *Blockchain) GetHeader(hash util.Uint256) (*block.Header, error) {
 	topBlock := bc.topBlock.Load()
@@ -1310,6 +1852,15 @@ func (bc *Blockchain) SubscribeForExecutions(ch chan<- *state.AppExecResult) {
 	bc.subCh <- ch
 }
 
+// SubscribeForStorageChanges adds given channel to new storage changes event
+// broadcasting, so when new block is persisted the set of contract storage
+// changes made by it is sent to the channel. Storage changes are sent before
+// the block itself. Make sure it's read from regularly as not reading these
+// events might affect other Blockchain functions.
+func (bc *Blockchain) SubscribeForStorageChanges(ch chan<- *state.StorageChanges) {
+	bc.subCh <- ch
+}
+
 // UnsubscribeFromBlocks unsubscribes given channel from new block notifications,
 // you can close it afterwards. Passing non-subscribed channel is a no-op.
 func (bc *Blockchain) UnsubscribeFromBlocks(ch chan<- *block.Block) {
@@ -1337,6 +1888,13 @@ func (bc *Blockchain) UnsubscribeFromExecutions(ch chan<- *state.AppExecResult)
 	bc.unsubCh <- ch
 }
 
+// UnsubscribeFromStorageChanges unsubscribes given channel from new storage
+// changes notifications, you can close it afterwards. Passing non-subscribed
+// channel is a no-op.
+func (bc *Blockchain) UnsubscribeFromStorageChanges(ch chan<- *state.StorageChanges) {
+	bc.unsubCh <- ch
+}
+
 // CalculateClaimable calculates the amount of GAS generated by owning specified
 // amount of NEO between specified blocks.
 func (bc *Blockchain) CalculateClaimable(acc util.Uint160, endHeight uint32) (*big.Int, error) {
@@ -1353,6 +1911,22 @@ func (bc *Blockchain) GetMemPool() *mempool.Pool {
 	return bc.memPool
 }
 
+// GetKnownPeers returns serialized known peers data saved with PutKnownPeers,
+// it's nil if there is none.
+func (bc *Blockchain) GetKnownPeers() ([]byte, error) {
+	data, err := bc.dao.Store.Get(storage.SYSKnownPeers.Bytes())
+	if err == storage.ErrKeyNotFound {
+		return nil, nil
+	}
+	return data, err
+}
+
+// PutKnownPeers saves serialized known peers data (which is opaque for the
+// Blockchain) to the DB.
+func (bc *Blockchain) PutKnownPeers(data []byte) error {
+	return bc.dao.Store.Put(storage.SYSKnownPeers.Bytes(), data)
+}
+
 // ApplyPolicyToTxSet applies configured policies to given transaction set. It
 // expects slice to be ordered by fee and returns a subslice of it.
 func (bc *Blockchain) ApplyPolicyToTxSet(txes []*transaction.Transaction) []*transaction.Transaction {
@@ -1430,6 +2004,21 @@ var (
 // verifyAndPoolTx verifies whether a transaction is bonafide or not and tries
 // to add it to the mempool given.
 func (bc *Blockchain) verifyAndPoolTx(t *transaction.Transaction, pool *mempool.Pool, feer mempool.Feer, data ...interface{}) error {
+	return bc.verifyAndPoolTxWithWitnesses(t, pool, feer, func() error {
+		isPartialTx := data != nil
+		err := bc.verifyTxWitnesses(t, nil, isPartialTx)
+		if err == nil && !isPartialTx {
+			bc.witnesses.add(t, bc.witnessPolicy())
+		}
+		return err
+	}, data...)
+}
+
+// verifyAndPoolTxWithWitnesses is the same as verifyAndPoolTx, but it uses the
+// given function for witness checks, which allows to verify witnesses in
+// advance (concurrently for the whole block) and only use the result here.
+func (bc *Blockchain) verifyAndPoolTxWithWitnesses(t *transaction.Transaction, pool *mempool.Pool, feer mempool.Feer,
+	verifyWitnesses func() error, data ...interface{}) error {
 	// This code can technically be moved out of here, because it doesn't
 	// really require a chain lock.
 	err := vm.IsScriptCorrect(t.Script, nil)
@@ -1439,7 +2028,7 @@ func (bc *Blockchain) verifyAndPoolTx(t *transaction.Transaction, pool *mempool.
 
 	height := bc.BlockHeight()
 	isPartialTx := data != nil
-	if t.ValidUntilBlock <= height || !isPartialTx && t.ValidUntilBlock > height+transaction.MaxValidUntilBlockIncrement {
+	if t.ValidUntilBlock <= height || !isPartialTx && t.ValidUntilBlock > height+bc.config.MaxValidUntilBlockIncrement(height) {
 		return fmt.Errorf("%w: ValidUntilBlock = %d, current height = %d", ErrTxExpired, t.ValidUntilBlock, height)
 	}
 	// Policying.
@@ -1474,7 +2063,7 @@ func (bc *Blockchain) verifyAndPoolTx(t *transaction.Transaction, pool *mempool.
 			return err
 		}
 	}
-	err = bc.verifyTxWitnesses(t, nil, isPartialTx)
+	err = verifyWitnesses()
 	if err != nil {
 		return err
 	}
@@ -1684,6 +2273,19 @@ func (bc *Blockchain) GetNextBlockValidators() ([]*keys.PublicKey, error) {
 	return bc.contracts.NEO.GetNextBlockValidatorsInternal(), nil
 }
 
+// GetNextCommittee returns committee expected to be elected at the next
+// committee update (ordered by votes) along with the estimated amount of GAS
+// per block distributed among voters of every member.
+func (bc *Blockchain) GetNextCommittee() (keys.PublicKeys, []*big.Int, error) {
+	return bc.contracts.NEO.ComputeNextCommittee(bc, bc.dao)
+}
+
+// GetNEOBalanceState returns NEO balance state (including the vote) of the
+// specified account.
+func (bc *Blockchain) GetNEOBalanceState(acc util.Uint160) (*state.NEOBalanceState, error) {
+	return bc.contracts.NEO.GetAccountState(bc.dao, acc)
+}
+
 // GetEnrollments returns all registered validators.
 func (bc *Blockchain) GetEnrollments() ([]state.Validator, error) {
 	return bc.contracts.NEO.GetCandidates(bc.dao)
@@ -1693,9 +2295,235 @@ func (bc *Blockchain) GetEnrollments() ([]state.Validator, error) {
 func (bc *Blockchain) GetTestVM(t trigger.Type, tx *transaction.Transaction, b *block.Block) *vm.VM {
 	d := bc.dao.GetWrapped().(*dao.Simple)
 	systemInterop := bc.newInteropContext(t, d, b, tx)
-	vm := systemInterop.SpawnVM()
-	vm.SetPriceGetter(systemInterop.GetPrice)
-	vm.LoadToken = contract.LoadToken(systemInterop)
+	return spawnTestVM(systemInterop)
+}
+
+// GetTestVMWithOverrides is the same as GetTestVM, but it also applies given
+// state overrides, witness overrides modify transaction signers.
+func (bc *Blockchain) GetTestVMWithOverrides(t trigger.Type, tx *transaction.Transaction, b *block.Block, o *state.Overrides) (*vm.VM, error) {
+	if tx != nil {
+		applyWitnessOverrides(tx, o.Witnesses)
+	}
+	d, err := bc.getOverriddenDAO(b, o)
+	if err != nil {
+		return nil, err
+	}
+	systemInterop := bc.newInteropContext(t, d, b, tx)
+	return spawnTestVM(systemInterop), nil
+}
+
+// GetTestVMWithStorageDiff is the same as GetTestVMWithOverrides (overrides
+// can be nil), but it also returns a function that returns contract storage
+// changes made by the VM (relative to the overridden state), it's to be called
+// after the VM is run.
+func (bc *Blockchain) GetTestVMWithStorageDiff(t trigger.Type, tx *transaction.Transaction, b *block.Block,
+	o *state.Overrides) (*vm.VM, func() ([]state.StorageDiff, error), error) {
+	if tx != nil && o != nil {
+		applyWitnessOverrides(tx, o.Witnesses)
+	}
+	d, err := bc.getOverriddenDAO(b, o)
+	if err != nil {
+		return nil, nil, err
+	}
+	systemInterop := bc.newInteropContext(t, d, b, tx)
+	return spawnTestVM(systemInterop), func() ([]state.StorageDiff, error) {
+		return bc.storageDiff(d, systemInterop.DAO)
+	}, nil
+}
+
+// storageDiff converts storage changes made by the VM in the changed DAO on
+// top of the given one into a list of contract storage changes sorted by
+// contract and key. Contract hashes are resolved via the changed DAO first, so
+// that contracts deployed by the VM are known, and via the original one
+// after that, so that contracts destroyed by the VM are known too.
+func (bc *Blockchain) storageDiff(d dao.DAO, changed dao.DAO) ([]state.StorageDiff, error) {
+	var (
+		diff   []state.StorageDiff
+		hashes = make(map[int32]util.Uint160)
+		batch  = changed.GetBatch()
+	)
+	add := func(key []byte, value []byte) error {
+		if len(key) < 5 || key[0] != byte(storage.STStorage) {
+			return nil
+		}
+		id := int32(binary.LittleEndian.Uint32(key[1:]))
+		h, ok := hashes[id]
+		if !ok {
+			var err error
+			h, err = changed.GetContractScriptHash(id)
+			if err != nil {
+				h, err = d.GetContractScriptHash(id)
+			}
+			if err != nil {
+				return fmt.Errorf("unknown contract with ID %d: %w", id, err)
+			}
+			hashes[id] = h
+		}
+		sd := state.StorageDiff{Contract: h, Key: key[5:], New: value}
+		if old := d.GetStorageItem(id, sd.Key); old != nil {
+			sd.Old = old
+		}
+		// Skip deletions of missing items and puts not changing anything.
+		if (sd.Old == nil) == (sd.New == nil) && bytes.Equal(sd.Old, sd.New) {
+			return nil
+		}
+		diff = append(diff, sd)
+		return nil
+	}
+	for i := range batch.Put {
+		if err := add(batch.Put[i].Key, batch.Put[i].Value); err != nil {
+			return nil, err
+		}
+	}
+	for i := range batch.Deleted {
+		if err := add(batch.Deleted[i].Key, nil); err != nil {
+			return nil, err
+		}
+	}
+	sort.Slice(diff, func(i, j int) bool {
+		if c := bytes.Compare(diff[i].Contract.BytesBE(), diff[j].Contract.BytesBE()); c != 0 {
+			return c < 0
+		}
+		return bytes.Compare(diff[i].Key, diff[j].Key) < 0
+	})
+	return diff, nil
+}
+
+// getOverriddenDAO returns wrapped chain DAO with given state overrides (can
+// be nil) applied.
+func (bc *Blockchain) getOverriddenDAO(b *block.Block, o *state.Overrides) (*dao.Simple, error) {
+	d := bc.dao.GetWrapped().(*dao.Simple)
+	if o == nil || len(o.Storage)+len(o.Balances) == 0 {
+		return d, nil
+	}
+	ic := bc.newInteropContext(trigger.Application, d, b, nil)
+	if err := bc.applyStateOverrides(ic, o); err != nil {
+		return nil, err
+	}
+	if _, err := ic.DAO.Persist(); err != nil {
+		return nil, fmt.Errorf("can't apply overrides: %w", err)
+	}
+	return d, nil
+}
+
+// SimulateTransactions runs the given transactions one by one against the
+// current chain state with state overrides applied (witness overrides are
+// applied to every transaction) as if they were included into the given block.
+// Each transaction sees the changes made by the previous ones, changes made by
+// failed transactions are discarded the same way it's done for blocks.
+// Witnesses are not checked and fees are not charged, each transaction can use
+// up to its system fee, but no more than gasLimit GAS. Nothing is persisted,
+// execution results are returned along with storage changes made by the whole
+// set.
+func (bc *Blockchain) SimulateTransactions(txs []*transaction.Transaction, b *block.Block, o *state.Overrides,
+	gasLimit int64) ([]state.AppExecResult, *state.StorageChanges, error) {
+	// Overrides are applied to a separate layer, so that they're not
+	// included into the resulting storage changes.
+	base, err := bc.getOverriddenDAO(b, o)
+	if err != nil {
+		return nil, nil, err
+	}
+	d := base.GetWrapped().(*dao.Simple)
+	aers := make([]state.AppExecResult, 0, len(txs))
+	for i, tx := range txs {
+		if tx.SystemFee < 0 {
+			return nil, nil, fmt.Errorf("transaction #%d: %w", i, transaction.ErrNegativeSystemFee)
+		}
+		if o != nil {
+			applyWitnessOverrides(tx, o.Witnesses)
+		}
+		ic := bc.newInteropContext(trigger.Application, d, b, tx)
+		v := spawnTestVM(ic)
+		v.LoadScriptWithFlags(tx.Script, callflag.All)
+		v.GasLimit = tx.SystemFee
+		if v.GasLimit > gasLimit {
+			v.GasLimit = gasLimit
+		}
+		err := v.Run()
+		var faultException string
+		if err == nil {
+			if _, err := ic.DAO.Persist(); err != nil {
+				return nil, nil, fmt.Errorf("failed to persist invocation results: %w", err)
+			}
+		} else {
+			faultException = err.Error()
+		}
+		aers = append(aers, state.AppExecResult{
+			Container: tx.Hash(),
+			Execution: state.Execution{
+				Trigger:        trigger.Application,
+				VMState:        v.State(),
+				GasConsumed:    v.GasConsumed(),
+				Stack:          v.Estack().ToArray(),
+				Events:         ic.Notifications,
+				FaultException: faultException,
+			},
+		})
+	}
+	return aers, newStorageChanges(b.Index, d.GetBatch()), nil
+}
+
+// applyWitnessOverrides makes the transaction signed by the given accounts
+// with Global scope.
+func applyWitnessOverrides(tx *transaction.Transaction, accs []util.Uint160) {
+	for _, acc := range accs {
+		var found bool
+		for i := range tx.Signers {
+			if tx.Signers[i].Account == acc {
+				tx.Signers[i].Scopes = transaction.Global
+				tx.Signers[i].AllowedContracts = nil
+				tx.Signers[i].AllowedGroups = nil
+				found = true
+				break
+			}
+		}
+		if !found {
+			tx.Signers = append(tx.Signers, transaction.Signer{Account: acc, Scopes: transaction.Global})
+		}
+	}
+}
+
+// applyStateOverrides applies storage and balance overrides to the interop
+// context DAO.
+func (bc *Blockchain) applyStateOverrides(ic *interop.Context, o *state.Overrides) error {
+	for i, so := range o.Storage {
+		cs, err := ic.GetContract(so.Contract)
+		if err != nil {
+			return fmt.Errorf("storage override #%d: unknown contract %s", i, so.Contract.StringLE())
+		}
+		if so.Value == nil {
+			err = ic.DAO.DeleteStorageItem(cs.ID, so.Key)
+		} else {
+			err = ic.DAO.PutStorageItem(cs.ID, so.Key, so.Value)
+		}
+		if err != nil {
+			return fmt.Errorf("storage override #%d: %w", i, err)
+		}
+	}
+	for i, bo := range o.Balances {
+		if bo.Amount == nil || bo.Amount.Sign() < 0 {
+			return fmt.Errorf("balance override #%d: invalid amount", i)
+		}
+		var err error
+		switch bo.Token {
+		case bc.contracts.NEO.Hash:
+			err = bc.contracts.NEO.SetBalance(ic, bo.Account, bo.Amount)
+		case bc.contracts.GAS.Hash:
+			err = bc.contracts.GAS.SetBalance(ic, bo.Account, bo.Amount)
+		default:
+			err = errors.New("only native tokens are supported, use storage overrides for others")
+		}
+		if err != nil {
+			return fmt.Errorf("balance override #%d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func spawnTestVM(ic *interop.Context) *vm.VM {
+	vm := ic.SpawnVM()
+	vm.SetPriceGetter(ic.GetPrice)
+	vm.LoadToken = contract.LoadToken(ic)
 	return vm
 }
 
@@ -1825,11 +2653,63 @@ func (bc *Blockchain) verifyTxWitnesses(t *transaction.Transaction, block *block
 	return nil
 }
 
+// witnessPolicy returns current policy values witness verification depends on.
+func (bc *Blockchain) witnessPolicy() witnessPolicy {
+	return witnessPolicy{
+		execFeeFactor:      bc.GetBaseExecFee(),
+		feePerByte:         bc.FeePerByte(),
+		maxVerificationGas: bc.contracts.Policy.GetMaxVerificationGas(bc.dao),
+	}
+}
+
+// verifyBlockTxWitnesses verifies witnesses of the given block transactions
+// that are not present in the mempool (and were not verified before)
+// concurrently using all available CPUs.
+// Verification doesn't change the state, so it's done against the current
+// one for all transactions (just like sequential verification does). Resulting
+// errors are returned in the same order as transactions, nil for those that
+// weren't verified or are valid.
+func (bc *Blockchain) verifyBlockTxWitnesses(txes []*transaction.Transaction) []error {
+	var (
+		errs    = make([]error, len(txes))
+		toCheck = make(chan int, len(txes))
+		wg      sync.WaitGroup
+	)
+	policy := bc.witnessPolicy()
+	for i := range txes {
+		if !bc.memPool.ContainsKey(txes[i].Hash()) && !bc.witnesses.verified(txes[i], policy) {
+			toCheck <- i
+		}
+	}
+	close(toCheck)
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(toCheck) {
+		workers = len(toCheck)
+	}
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range toCheck {
+				errs[i] = bc.verifyTxWitnesses(txes[i], nil, false)
+			}
+		}()
+	}
+	wg.Wait()
+	return errs
+}
+
 // verifyHeaderWitnesses is a block-specific implementation of VerifyWitnesses logic.
 func (bc *Blockchain) verifyHeaderWitnesses(currHeader, prevHeader *block.Header) error {
 	var hash util.Uint160
 	if prevHeader == nil && currHeader.PrevHash.Equals(util.Uint256{}) {
 		hash = currHeader.Script.ScriptHash()
+	} else if bc.forked && prevHeader.Index == bc.forkHeight {
+		var err error
+		hash, err = getNextConsensusAddress(bc.GetStandByValidators())
+		if err != nil {
+			return err
+		}
 	} else {
 		hash = prevHeader.NextConsensus
 	}
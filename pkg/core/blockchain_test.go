@@ -1,12 +1,15 @@
 package core
 
 import (
+	"bytes"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"math/big"
 	"math/rand"
 	"path"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -17,6 +20,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/blockchainer"
 	"github.com/nspcc-dev/neo-go/pkg/core/chaindump"
+	"github.com/nspcc-dev/neo-go/pkg/core/dao"
 	"github.com/nspcc-dev/neo-go/pkg/core/fee"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
 	"github.com/nspcc-dev/neo-go/pkg/core/mempool"
@@ -107,6 +111,25 @@ func TestAddHeaders(t *testing.T) {
 	assert.Equal(t, h3.Hash(), bc.CurrentHeaderHash())
 }
 
+func TestAddBlockAfterHeader(t *testing.T) {
+	bc := newTestChain(t)
+	lastBlock := bc.topBlock.Load().(*block.Block)
+	b1 := newBlock(bc.config, 1, lastBlock.Hash())
+	require.NoError(t, bc.AddHeaders(&b1.Header))
+
+	other := newBlockCustom(bc.config, func(b *block.Block) {
+		b.PrevHash = lastBlock.Hash()
+		b.Timestamp = b1.Timestamp + 1
+		b.Index = 1
+	})
+	require.NotEqual(t, b1.Hash(), other.Hash())
+	require.True(t, errors.Is(bc.AddBlock(other), ErrBlockHeaderMismatch))
+	require.Equal(t, uint32(0), bc.BlockHeight())
+
+	require.NoError(t, bc.AddBlock(b1))
+	require.Equal(t, uint32(1), bc.BlockHeight())
+}
+
 func TestAddBlock(t *testing.T) {
 	const size = 3
 	bc := newTestChain(t)
@@ -194,6 +217,31 @@ func TestAddBadBlock(t *testing.T) {
 	require.NoError(t, bc.AddBlock(b3))
 }
 
+func TestAddBlockParallelWitnessCheck(t *testing.T) {
+	bc := newTestChain(t)
+	txes := make([]*transaction.Transaction, 8)
+	for i := range txes {
+		txes[i] = bc.newTestTx(testchain.MultisigScriptHash(), []byte{byte(opcode.PUSH1)})
+		require.NoError(t, testchain.SignTx(bc, txes[i]))
+	}
+	bad := bc.newTestTx(testchain.MultisigScriptHash(), []byte{byte(opcode.PUSH1)})
+	require.NoError(t, testchain.SignTx(bc, bad))
+	bad.Scripts[0].InvocationScript[10] ^= 0xff
+
+	withBad := append([]*transaction.Transaction{}, txes[:4]...)
+	withBad = append(withBad, bad)
+	withBad = append(withBad, txes[4:]...)
+	b1 := bc.newBlock(withBad...)
+	err := bc.AddBlock(b1)
+	require.True(t, errors.Is(err, ErrVerificationFailed), "got: %v", err)
+	require.Contains(t, err.Error(), bad.Hash().StringLE())
+	require.Equal(t, uint32(0), bc.BlockHeight())
+
+	b1 = bc.newBlock(txes...)
+	require.NoError(t, bc.AddBlock(b1))
+	require.Equal(t, uint32(1), bc.BlockHeight())
+}
+
 func TestGetHeader(t *testing.T) {
 	bc := newTestChain(t)
 	tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
@@ -1479,6 +1527,37 @@ func TestSubscriptions(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestSubscribeForStorageChanges(t *testing.T) {
+	ch := make(chan *state.StorageChanges, 1)
+
+	bc := newTestChain(t)
+	bc.SubscribeForStorageChanges(ch)
+	require.Eventually(t, func() bool { return atomic.LoadUint32(&bc.storageChangesSubs) == 1 },
+		time.Second, 10*time.Millisecond)
+
+	_, err := bc.genBlocks(1)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return len(ch) != 0 }, time.Second, 10*time.Millisecond)
+
+	changes := <-ch
+	require.Equal(t, uint32(1), changes.Block)
+	require.Equal(t, len(changes.Storage), changes.Size)
+	require.NotEmpty(t, changes.Storage) // GAS is minted for primary node.
+	for i := range changes.Storage {
+		require.Contains(t, []string{state.StorageAdded, state.StorageChanged, state.StorageDeleted}, changes.Storage[i].State)
+		if i > 0 {
+			require.True(t, bytes.Compare(changes.Storage[i-1].Key, changes.Storage[i].Key) < 0)
+		}
+	}
+
+	bc.UnsubscribeFromStorageChanges(ch)
+	require.Eventually(t, func() bool { return atomic.LoadUint32(&bc.storageChangesSubs) == 0 },
+		time.Second, 10*time.Millisecond)
+	_, err = bc.genBlocks(1)
+	require.NoError(t, err)
+	require.Empty(t, ch)
+}
+
 func testDumpAndRestore(t *testing.T, dumpF, restoreF func(c *config.Config)) {
 	if restoreF == nil {
 		restoreF = dumpF
@@ -1528,6 +1607,28 @@ func testDumpAndRestore(t *testing.T, dumpF, restoreF func(c *config.Config)) {
 			require.Equal(t, bc.BlockHeight()-1, lastIndex)
 		})
 	})
+	t.Run("parallel", func(t *testing.T) {
+		bc2 := newTestChainWithCustomCfg(t, restoreF)
+
+		r := io.NewBinReaderFromBuf(buf)
+		require.NoError(t, chaindump.RestoreWithDecoders(bc2, r, 0, bc.BlockHeight()+1, 4, nil))
+		require.Equal(t, bc.BlockHeight(), bc2.BlockHeight())
+		require.Equal(t, bc.CurrentBlockHash(), bc2.CurrentBlockHash())
+
+		t.Run("bad count", func(t *testing.T) {
+			bc3 := newTestChainWithCustomCfg(t, restoreF)
+			r := io.NewBinReaderFromBuf(buf)
+			require.Error(t, chaindump.RestoreWithDecoders(bc3, r, 0, bc.BlockHeight()+2, 4, nil))
+			require.Equal(t, bc.BlockHeight(), bc3.BlockHeight())
+		})
+	})
+	t.Run("too big block", func(t *testing.T) {
+		bc2 := newTestChainWithCustomCfg(t, restoreF)
+		w := io.NewBufBinWriter()
+		w.WriteU32LE(bc2.GetConfig().MaxBlockSize + 1)
+		r := io.NewBinReaderFromBuf(w.Bytes())
+		require.Error(t, chaindump.Restore(bc2, r, 0, 1, nil))
+	})
 
 }
 
@@ -1581,6 +1682,151 @@ func TestRemoveUntraceable(t *testing.T) {
 	require.Error(t, err)
 	_, err = bc.GetHeader(b1.Hash())
 	require.NoError(t, err)
+
+	t.Run("block fetcher", func(t *testing.T) {
+		f := &testBlockFetcher{blocks: map[uint32]*block.Block{b1.Index: b1}}
+		bc.SetBlockFetcher(f)
+		defer bc.SetBlockFetcher(nil)
+
+		_, err := bc.GetBlock(b1.Hash())
+		require.Error(t, err) // Never fetched by core.
+
+		b, err := bc.FetchBlock(b1.Hash())
+		require.NoError(t, err)
+		require.Equal(t, b1.Hash(), b.Hash())
+		require.Equal(t, 1, len(b.Transactions))
+		require.Equal(t, tx1.Hash(), b.Transactions[0].Hash())
+
+		f.blocks[b1.Index] = bc.newBlock()
+		_, err = bc.FetchBlock(b1.Hash())
+		require.Error(t, err)
+
+		f.blocks[b1.Index] = &block.Block{Header: b1.Header, Transactions: []*transaction.Transaction{tx2}}
+		_, err = bc.FetchBlock(b1.Hash())
+		require.Error(t, err)
+
+		delete(f.blocks, b1.Index)
+		_, err = bc.FetchBlock(b1.Hash())
+		require.Error(t, err)
+	})
+}
+
+func TestStateOverrides_Balances(t *testing.T) {
+	bc := newTestChain(t)
+	b := bc.newBlock()
+	newOverrides := func(token util.Uint160, amount *big.Int) *state.Overrides {
+		return &state.Overrides{Balances: []state.BalanceOverride{{
+			Token:   token,
+			Account: util.Uint160{1, 2, 3},
+			Amount:  amount,
+		}}}
+	}
+
+	for _, amount := range []*big.Int{nil, big.NewInt(-1)} {
+		_, err := bc.getOverriddenDAO(b, newOverrides(bc.contracts.GAS.Hash, amount))
+		require.Error(t, err)
+		_, err = bc.getOverriddenDAO(b, newOverrides(bc.contracts.NEO.Hash, amount))
+		require.Error(t, err)
+	}
+	_, err := bc.getOverriddenDAO(b, newOverrides(util.Uint160{4, 5, 6}, big.NewInt(1)))
+	require.Error(t, err)
+
+	d, err := bc.getOverriddenDAO(b, newOverrides(bc.contracts.GAS.Hash, big.NewInt(42)))
+	require.NoError(t, err)
+	si := d.GetStorageItem(bc.contracts.GAS.ID, append([]byte{20}, util.Uint160{1, 2, 3}.BytesBE()...))
+	acc, err := state.NEP17BalanceStateFromBytes(si)
+	require.NoError(t, err)
+	require.Equal(t, int64(42), acc.Balance.Int64())
+}
+
+func TestGetTestVMWithStorageDiff_Deploy(t *testing.T) {
+	bc := newTestChain(t)
+	src := []byte(`package foo
+	import "github.com/nspcc-dev/neo-go/pkg/interop/storage"
+	func _deploy(_ interface{}, isUpdate bool) {
+		storage.Put(storage.GetContext(), "key", "value")
+	}
+	func Main() int { return 1 }`)
+	tx, h, _, err := testchain.NewDeployTx(bc, "foo", testchain.MultisigScriptHash(), bytes.NewReader(src), nil)
+	require.NoError(t, err)
+
+	v, getDiff, err := bc.GetTestVMWithStorageDiff(trigger.Application, tx, bc.newBlock(), nil)
+	require.NoError(t, err)
+	v.GasLimit = -1
+	v.LoadScriptWithFlags(tx.Script, callflag.All)
+	require.NoError(t, v.Run())
+
+	diff, err := getDiff()
+	require.NoError(t, err)
+	var found bool
+	for _, sd := range diff {
+		if sd.Contract.Equals(h) {
+			require.Equal(t, []byte("key"), sd.Key)
+			require.Nil(t, sd.Old)
+			require.Equal(t, []byte("value"), sd.New)
+			found = true
+		}
+	}
+	require.True(t, found)
+}
+
+func TestGetTestVMWithStorageDiff_Destroy(t *testing.T) {
+	bc := newTestChain(t)
+	src := `package foo
+	import (
+		"github.com/nspcc-dev/neo-go/pkg/interop/native/management"
+		"github.com/nspcc-dev/neo-go/pkg/interop/storage"
+	)
+	func _deploy(_ interface{}, isUpdate bool) {
+		storage.Put(storage.GetContext(), "key", "value")
+	}
+	func Destroy() {
+		management.Destroy()
+	}`
+	txDeploy, h, _, err := testchain.NewDeployTx(bc, "foo", neoOwner, strings.NewReader(src), nil)
+	require.NoError(t, err)
+	txDeploy.ValidUntilBlock = bc.BlockHeight() + 1
+	addSigners(neoOwner, txDeploy)
+	require.NoError(t, testchain.SignTx(bc, txDeploy))
+	require.NoError(t, bc.AddBlock(bc.newBlock(txDeploy)))
+	checkTxHalt(t, bc, txDeploy.Hash())
+
+	w := io.NewBufBinWriter()
+	emit.AppCall(w.BinWriter, h, "destroy", callflag.All)
+	require.NoError(t, w.Err)
+	tx := transaction.New(w.Bytes(), 0)
+	tx.Signers = []transaction.Signer{{Account: neoOwner, Scopes: transaction.CalledByEntry}}
+
+	v, getDiff, err := bc.GetTestVMWithStorageDiff(trigger.Application, tx, bc.newBlock(), nil)
+	require.NoError(t, err)
+	v.GasLimit = -1
+	v.LoadScriptWithFlags(tx.Script, callflag.All)
+	require.NoError(t, v.Run())
+
+	diff, err := getDiff()
+	require.NoError(t, err)
+	var found bool
+	for _, sd := range diff {
+		if sd.Contract.Equals(h) {
+			require.Equal(t, []byte("key"), sd.Key)
+			require.Equal(t, []byte("value"), sd.Old)
+			require.Nil(t, sd.New)
+			found = true
+		}
+	}
+	require.True(t, found)
+}
+
+type testBlockFetcher struct {
+	blocks map[uint32]*block.Block
+}
+
+func (f *testBlockFetcher) GetBlock(index uint32) (*block.Block, error) {
+	b, ok := f.blocks[index]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return b, nil
 }
 
 func TestInvalidNotification(t *testing.T) {
@@ -1643,3 +1889,101 @@ func TestConfigNativeUpdateHistory(t *testing.T) {
 		check(t, tc)
 	}
 }
+
+func TestBlockchain_Fork(t *testing.T) {
+	st := memoryStore{storage.NewMemoryStore()}
+	var height uint32
+	t.Run("original", func(t *testing.T) { // separate test to close the chain
+		bc := newTestChainWithCustomCfgAndStore(t, st, nil)
+		_, err := bc.genBlocks(3)
+		require.NoError(t, err)
+		height = bc.BlockHeight()
+	})
+
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	bc := newTestChainWithCustomCfgAndStore(t, st, func(c *config.Config) {
+		c.ProtocolConfiguration.StandbyCommittee = []string{hex.EncodeToString(priv.PublicKey().Bytes())}
+		c.ProtocolConfiguration.ValidatorsCount = 1
+	})
+	oldRoot := bc.GetStateModule().CurrentLocalStateRoot()
+	require.Error(t, bc.AddBlock(bc.newBlock()))
+
+	require.NoError(t, bc.Fork())
+	require.Equal(t, keys.PublicKeys{priv.PublicKey()}, bc.contracts.NEO.GetNextBlockValidatorsInternal())
+	require.Equal(t, keys.PublicKeys{priv.PublicKey()}, bc.contracts.NEO.GetCommitteeMembers())
+	require.NotEqual(t, oldRoot, bc.GetStateModule().CurrentLocalStateRoot())
+	forkHeight, err := bc.dao.GetForkHeight()
+	require.NoError(t, err)
+	require.Equal(t, height, forkHeight)
+
+	// Old validators can't sign the next block anymore.
+	require.Error(t, bc.AddBlock(bc.newBlock()))
+
+	sign := func(b *block.Block) *block.Block {
+		buf := io.NewBufBinWriter()
+		emit.Bytes(buf.BinWriter, priv.SignHashable(uint32(bc.GetConfig().Magic), b))
+		b.Script.InvocationScript = buf.Bytes()
+		return b
+	}
+	b := sign(newBlock(bc.GetConfig(), height+1, bc.CurrentBlockHash()))
+	require.NoError(t, bc.AddBlock(b))
+	b = sign(newBlock(bc.GetConfig(), height+2, bc.CurrentBlockHash()))
+	require.NoError(t, bc.AddBlock(b))
+	require.Equal(t, height+2, bc.BlockHeight())
+	require.Equal(t, keys.PublicKeys{priv.PublicKey()}, bc.contracts.NEO.GetCommitteeMembers())
+}
+
+func TestForEachNotification(t *testing.T) {
+	bc := newTestChain(t)
+	sc := util.Uint160{1, 2, 3}
+	cache := dao.NewCached(bc.dao)
+	// Enough notifications to fill a number of batches.
+	const blocks = 3 * state.NotificationLogBatchSize / 2
+	for i := uint32(0); i < blocks; i++ {
+		b := &block.Block{Header: block.Header{Index: i}}
+		aer := &state.AppExecResult{
+			Container: b.Hash(),
+			Execution: state.Execution{
+				VMState: vm.HaltState,
+				Events: []state.NotificationEvent{
+					{ScriptHash: sc, Name: "A", Item: stackitem.NewArray(nil)},
+					{ScriptHash: sc, Name: "B", Item: stackitem.NewArray(nil)},
+				},
+			},
+		}
+		require.NoError(t, bc.indexNotifications(cache, b, []*state.AppExecResult{aer}))
+	}
+	_, err := cache.Persist()
+	require.NoError(t, err)
+
+	check := func(t *testing.T, name string, end uint32, expected []string) {
+		var actual []string
+		require.NoError(t, bc.ForEachNotification(sc, name, end, func(n *state.IndexedNotification) (bool, error) {
+			require.Equal(t, end-uint32(len(actual)/2), n.Block)
+			actual = append(actual, n.Name)
+			return len(actual) < len(expected), nil
+		}))
+		require.Equal(t, expected, actual)
+	}
+	t.Run("all", func(t *testing.T) {
+		check(t, "", blocks-1, []string{"B", "A", "B", "A"})
+		check(t, "", 100, []string{"B", "A", "B"})
+		check(t, "", 0, []string{"B", "A"})
+	})
+	t.Run("by name", func(t *testing.T) {
+		var count int
+		require.NoError(t, bc.ForEachNotification(sc, "A", 150, func(n *state.IndexedNotification) (bool, error) {
+			require.Equal(t, "A", n.Name)
+			require.Equal(t, uint32(150-count), n.Block)
+			count++
+			return true, nil
+		}))
+		require.Equal(t, 151, count)
+	})
+	t.Run("unknown", func(t *testing.T) {
+		require.NoError(t, bc.ForEachNotification(sc, "C", blocks, func(n *state.IndexedNotification) (bool, error) {
+			return false, errors.New("unexpected")
+		}))
+	})
+}
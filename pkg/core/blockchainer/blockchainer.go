@@ -25,18 +25,24 @@ type Blockchainer interface {
 	AddBlock(*block.Block) error
 	CalculateClaimable(h util.Uint160, endHeight uint32) (*big.Int, error)
 	Close()
+	CompactStorage() (int64, error)
 	InitVerificationVM(v *vm.VM, getContract func(util.Uint160) (*state.Contract, error), hash util.Uint160, witness *transaction.Witness) error
 	IsTxStillRelevant(t *transaction.Transaction, txpool *mempool.Pool, isPartialTx bool) bool
 	HeaderHeight() uint32
+	FetchBlock(hash util.Uint256) (*block.Block, error)
 	GetBlock(hash util.Uint256) (*block.Block, error)
 	GetCommittee() (keys.PublicKeys, error)
 	GetContractState(hash util.Uint160) *state.Contract
 	GetContractScriptHash(id int32) (util.Uint160, error)
 	GetEnrollments() ([]state.Validator, error)
 	GetGoverningTokenBalance(acc util.Uint160) (*big.Int, uint32)
+	ForEachAddressTransaction(util.Uint160, func(*state.AddressTransaction) (bool, error)) error
+	ForEachNEP11Transfer(util.Uint160, func(*state.NEP11Transfer) (bool, error)) error
 	ForEachNEP17Transfer(util.Uint160, func(*state.NEP17Transfer) (bool, error)) error
+	ForEachNotification(util.Uint160, string, uint32, func(*state.IndexedNotification) (bool, error)) error
 	GetHeaderHash(int) util.Uint256
 	GetHeader(hash util.Uint256) (*block.Header, error)
+	GetKnownPeers() ([]byte, error)
 	CurrentHeaderHash() util.Uint256
 	CurrentBlockHash() util.Uint256
 	HasBlock(util.Uint256) bool
@@ -47,6 +53,9 @@ type Blockchainer interface {
 	GetNativeContractScriptHash(string) (util.Uint160, error)
 	GetNatives() []state.NativeContract
 	GetNextBlockValidators() ([]*keys.PublicKey, error)
+	GetNextCommittee() (keys.PublicKeys, []*big.Int, error)
+	GetNEOBalanceState(acc util.Uint160) (*state.NEOBalanceState, error)
+	GetNEP11Balances(util.Uint160) *state.NEP11Balances
 	GetNEP17Balances(util.Uint160) *state.NEP17Balances
 	GetNotaryContractScriptHash() util.Uint160
 	GetNotaryBalance(acc util.Uint160) *big.Int
@@ -55,20 +64,28 @@ type Blockchainer interface {
 	GetStandByCommittee() keys.PublicKeys
 	GetStandByValidators() keys.PublicKeys
 	GetStateModule() StateRoot
+	GetStorageChanges(index uint32) (*state.StorageChanges, error)
 	GetStorageItem(id int32, key []byte) state.StorageItem
 	GetStorageItems(id int32) (map[string]state.StorageItem, error)
 	GetTestVM(t trigger.Type, tx *transaction.Transaction, b *block.Block) *vm.VM
+	GetTestVMWithOverrides(t trigger.Type, tx *transaction.Transaction, b *block.Block, o *state.Overrides) (*vm.VM, error)
+	GetTestVMWithStorageDiff(t trigger.Type, tx *transaction.Transaction, b *block.Block, o *state.Overrides) (*vm.VM, func() ([]state.StorageDiff, error), error)
 	GetTransaction(util.Uint256) (*transaction.Transaction, uint32, error)
+	GetTransactionTrace(hash util.Uint256) (*state.TransactionTrace, error)
 	SetOracle(service services.Oracle)
+	SimulateTransactions(txs []*transaction.Transaction, b *block.Block, o *state.Overrides, gasLimit int64) ([]state.AppExecResult, *state.StorageChanges, error)
 	mempool.Feer // fee interface
 	ManagementContractHash() util.Uint160
 	PoolTx(t *transaction.Transaction, pools ...*mempool.Pool) error
+	PutKnownPeers([]byte) error
 	PoolTxWithData(t *transaction.Transaction, data interface{}, mp *mempool.Pool, feer mempool.Feer, verificationFunction func(bc Blockchainer, t *transaction.Transaction, data interface{}) error) error
 	RegisterPostBlock(f func(Blockchainer, *mempool.Pool, *block.Block))
+	SetBlockFetcher(mod services.BlockFetcher)
 	SetNotary(mod services.Notary)
 	SubscribeForBlocks(ch chan<- *block.Block)
 	SubscribeForExecutions(ch chan<- *state.AppExecResult)
 	SubscribeForNotifications(ch chan<- *state.NotificationEvent)
+	SubscribeForStorageChanges(ch chan<- *state.StorageChanges)
 	SubscribeForTransactions(ch chan<- *transaction.Transaction)
 	VerifyTx(*transaction.Transaction) error
 	VerifyWitness(util.Uint160, hash.Hashable, *transaction.Witness, int64) error
@@ -76,5 +93,6 @@ type Blockchainer interface {
 	UnsubscribeFromBlocks(ch chan<- *block.Block)
 	UnsubscribeFromExecutions(ch chan<- *state.AppExecResult)
 	UnsubscribeFromNotifications(ch chan<- *state.NotificationEvent)
+	UnsubscribeFromStorageChanges(ch chan<- *state.StorageChanges)
 	UnsubscribeFromTransactions(ch chan<- *transaction.Transaction)
 }
@@ -0,0 +1,10 @@
+package services
+
+import "github.com/nspcc-dev/neo-go/pkg/core/block"
+
+// BlockFetcher is an interface of the service providing blocks that are
+// no longer stored locally.
+type BlockFetcher interface {
+	// GetBlock returns a block with the specified index.
+	GetBlock(index uint32) (*block.Block, error)
+}
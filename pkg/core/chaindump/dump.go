@@ -1,7 +1,9 @@
 package chaindump
 
 import (
+	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/blockchainer"
@@ -32,8 +34,27 @@ func Dump(bc blockchainer.Blockchainer, w *io.BinWriter, start, count uint32) er
 // Restore restores blocks from provided reader.
 // f is called after addition of every block.
 func Restore(bc blockchainer.Blockchainer, r *io.BinReader, skip, count uint32, f func(b *block.Block) error) error {
+	return RestoreWithDecoders(bc, r, skip, count, 1, f)
+}
+
+// RestoreWithDecoders is the same as Restore, but it reads and decodes blocks
+// (which includes hashing and MerkleRoot check if blocks are to be verified)
+// in parallel with the given number of decoders. Only decoding is done in
+// parallel, blocks are still added to (and verified by) the chain sequentially
+// in the same order as they're stored, because their verification depends on
+// the chain state. The reader can be read ahead of the last block processed if
+// an error is returned. Blocks bigger than MaxBlockSize protocol setting are
+// rejected before reading them.
+func RestoreWithDecoders(bc blockchainer.Blockchainer, r *io.BinReader, skip, count uint32, decoders int, f func(b *block.Block) error) error {
+	var cfg = bc.GetConfig()
 	readBlock := func(r *io.BinReader) ([]byte, error) {
 		var size = r.ReadU32LE()
+		if r.Err != nil {
+			return nil, r.Err
+		}
+		if size > cfg.MaxBlockSize {
+			return nil, fmt.Errorf("block size %d exceeds the limit of %d", size, cfg.MaxBlockSize)
+		}
 		buf := make([]byte, size)
 		r.ReadBytes(buf)
 		return buf, r.Err
@@ -47,21 +68,62 @@ func Restore(bc blockchainer.Blockchainer, r *io.BinReader, skip, count uint32,
 		}
 	}
 
-	stateRootInHeader := bc.GetConfig().StateRootInHeader
+	if decoders < 1 {
+		decoders = 1
+	}
+	var (
+		jobs    = make(chan *restoreJob, decoders)
+		ordered = make(chan *restoreJob, 2*decoders)
+		done    = make(chan struct{})
+		wg      sync.WaitGroup
+	)
+	// Make sure reader is not used after return.
+	defer wg.Wait()
+	defer close(done)
 
-	for ; i < skip+count; i++ {
-		buf, err := readBlock(r)
-		if err != nil {
-			return err
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(jobs)
+		defer close(ordered)
+		for j := i; j < skip+count; j++ {
+			buf, err := readBlock(r)
+			job := &restoreJob{buf: buf, res: make(chan restoreResult, 1)}
+			if err != nil {
+				job.res <- restoreResult{err: err}
+			}
+			select {
+			case ordered <- job:
+			case <-done:
+				return
+			}
+			if err != nil {
+				return
+			}
+			select {
+			case jobs <- job:
+			case <-done:
+				return
+			}
 		}
-		b := block.New(stateRootInHeader)
-		r := io.NewBinReaderFromBuf(buf)
-		b.DecodeBinary(r)
-		if r.Err != nil {
-			return r.Err
+	}()
+	for w := 0; w < decoders; w++ {
+		go func() {
+			for job := range jobs {
+				b, err := decodeBlock(job.buf, cfg.StateRootInHeader, cfg.VerifyBlocks)
+				job.res <- restoreResult{b: b, err: err}
+			}
+		}()
+	}
+
+	for job := range ordered {
+		res := <-job.res
+		if res.err != nil {
+			return res.err
 		}
+		b := res.b
 		if b.Index != 0 || i != 0 || skip != 0 {
-			err = bc.AddBlock(b)
+			err := bc.AddBlock(b)
 			if err != nil {
 				return fmt.Errorf("failed to add block %d: %w", i, err)
 			}
@@ -71,6 +133,33 @@ func Restore(bc blockchainer.Blockchainer, r *io.BinReader, skip, count uint32,
 				return err
 			}
 		}
+		i++
 	}
 	return nil
 }
+
+// restoreJob is a block to be decoded by restore decoder.
+type restoreJob struct {
+	buf []byte
+	res chan restoreResult
+}
+
+// restoreResult is a block decoding result.
+type restoreResult struct {
+	b   *block.Block
+	err error
+}
+
+// decodeBlock decodes block from buf optionally checking its MerkleRoot.
+func decodeBlock(buf []byte, stateRootInHeader bool, verify bool) (*block.Block, error) {
+	b := block.New(stateRootInHeader)
+	r := io.NewBinReaderFromBuf(buf)
+	b.DecodeBinary(r)
+	if r.Err != nil {
+		return nil, r.Err
+	}
+	if verify && !b.MerkleRoot.Equals(b.ComputeMerkleRoot()) {
+		return nil, errors.New("invalid block: MerkleRoot mismatch")
+	}
+	return b, nil
+}
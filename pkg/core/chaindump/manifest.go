@@ -0,0 +1,21 @@
+package chaindump
+
+// ManifestFile is the name of chunked chain dump manifest.
+const ManifestFile = "manifest.json"
+
+// Manifest describes chunked chain dump. Every chunk is a file starting
+// with the index of its first block and the number of blocks in it followed
+// by blocks in Dump format.
+type Manifest struct {
+	ChunkSize uint32  `json:"chunksize"`
+	Chunks    []Chunk `json:"chunks"`
+}
+
+// Chunk is a single file of chunked chain dump.
+type Chunk struct {
+	Start uint32 `json:"start"`
+	Count uint32 `json:"count"`
+	// File is the path to chunk relative to dump directory (or manifest
+	// URL).
+	File string `json:"file"`
+}
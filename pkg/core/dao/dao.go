@@ -28,11 +28,17 @@ var (
 
 // DAO is a data access object.
 type DAO interface {
+	AppendAddressTransaction(acc util.Uint160, index uint32, isNew bool, tx *state.AddressTransaction) (bool, error)
 	AppendAppExecResult(aer *state.AppExecResult, buf *io.BufBinWriter) error
+	AppendNEP11Transfer(acc util.Uint160, index uint32, isNew bool, tr *state.NEP11Transfer) (bool, error)
 	AppendNEP17Transfer(acc util.Uint160, index uint32, isNew bool, tr *state.NEP17Transfer) (bool, error)
+	AppendNotification(sc util.Uint160, index uint32, isNew bool, n *state.IndexedNotification) (bool, error)
 	DeleteBlock(h util.Uint256, buf *io.BufBinWriter) error
 	DeleteContractID(id int32) error
+	DeleteStorageChanges(index uint32) error
 	DeleteStorageItem(id int32, key []byte) error
+	GetAddressTxLog(acc util.Uint160, index uint32) (*state.AddressTxLog, error)
+	GetAddressTxLogInfo(acc util.Uint160) (*state.BatchedLogInfo, error)
 	GetAndDecode(entity io.Serializable, key []byte) error
 	GetAppExecResults(hash util.Uint256, trig trigger.Type) ([]state.AppExecResult, error)
 	GetBatch() *storage.MemBatch
@@ -41,8 +47,14 @@ type DAO interface {
 	GetCurrentBlockHeight() (uint32, error)
 	GetCurrentHeaderHeight() (i uint32, h util.Uint256, err error)
 	GetHeaderHashes() ([]util.Uint256, error)
+	GetNEP11Balances(acc util.Uint160) (*state.NEP11Balances, error)
+	GetNEP11TransferLog(acc util.Uint160, index uint32) (*state.NEP11TransferLog, error)
 	GetNEP17Balances(acc util.Uint160) (*state.NEP17Balances, error)
 	GetNEP17TransferLog(acc util.Uint160, index uint32) (*state.NEP17TransferLog, error)
+	GetNotificationLog(sc util.Uint160, index uint32) (*state.NotificationLog, error)
+	GetNotificationLogInfo(sc util.Uint160) (*state.BatchedLogInfo, error)
+	GetStorageChanges(index uint32) (*state.StorageChanges, error)
+	GetTransactionTrace(hash util.Uint256) (*state.TransactionTrace, error)
 	GetStorageItem(id int32, key []byte) state.StorageItem
 	GetStorageItems(id int32) (map[string]state.StorageItem, error)
 	GetStorageItemsWithPrefix(id int32, prefix []byte) (map[string]state.StorageItem, error)
@@ -51,11 +63,19 @@ type DAO interface {
 	GetWrapped() DAO
 	HasTransaction(hash util.Uint256) error
 	Persist() (int, error)
+	PutAddressTxLog(acc util.Uint160, index uint32, lg *state.AddressTxLog) error
+	PutAddressTxLogInfo(acc util.Uint160, info *state.BatchedLogInfo) error
 	PutAppExecResult(aer *state.AppExecResult, buf *io.BufBinWriter) error
 	PutContractID(id int32, hash util.Uint160) error
 	PutCurrentHeader(hashAndIndex []byte) error
+	PutNEP11Balances(acc util.Uint160, bs *state.NEP11Balances) error
+	PutNEP11TransferLog(acc util.Uint160, index uint32, lg *state.NEP11TransferLog) error
 	PutNEP17Balances(acc util.Uint160, bs *state.NEP17Balances) error
 	PutNEP17TransferLog(acc util.Uint160, index uint32, lg *state.NEP17TransferLog) error
+	PutNotificationLog(sc util.Uint160, index uint32, lg *state.NotificationLog) error
+	PutNotificationLogInfo(sc util.Uint160, info *state.BatchedLogInfo) error
+	PutStorageChanges(changes *state.StorageChanges, buf *io.BufBinWriter) error
+	PutTransactionTrace(trace *state.TransactionTrace, buf *io.BufBinWriter) error
 	PutStorageItem(id int32, key []byte, si state.StorageItem) error
 	PutVersion(v string) error
 	Seek(id int32, prefix []byte, f func(k, v []byte))
@@ -166,6 +186,52 @@ func (dao *Simple) putNEP17Balances(acc util.Uint160, bs *state.NEP17Balances, b
 
 // -- end nep17 balances.
 
+// -- start batched logs.
+
+// getBatchedLog retrieves batched log with the given key from the cache, an
+// empty log is returned if there is no such log.
+func (dao *Simple) getBatchedLog(key []byte) (*state.BatchedLog, error) {
+	value, err := dao.Store.Get(key)
+	if err != nil {
+		if err == storage.ErrKeyNotFound {
+			return new(state.BatchedLog), nil
+		}
+		return nil, err
+	}
+	return &state.BatchedLog{Raw: value}, nil
+}
+
+// appendToBatchedLog appends a single entry to the batched log with the given
+// key, a new log is created if isNew is set. First return value signalizes that
+// log size has exceeded batchSize.
+func (dao *Simple) appendToBatchedLog(key []byte, isNew bool, e io.Serializable, batchSize int) (bool, error) {
+	lg := new(state.BatchedLog)
+	if !isNew {
+		var err error
+		lg, err = dao.getBatchedLog(key)
+		if err != nil {
+			return false, err
+		}
+	}
+	if err := lg.Append(e); err != nil {
+		return false, err
+	}
+	return lg.Size() >= batchSize, dao.Store.Put(key, lg.Raw)
+}
+
+// getBatchedLogInfo retrieves batched log info with the given key from the
+// cache, an empty info is returned if there is no such info.
+func (dao *Simple) getBatchedLogInfo(key []byte) (*state.BatchedLogInfo, error) {
+	info := new(state.BatchedLogInfo)
+	err := dao.GetAndDecode(info, key)
+	if err != nil && err != storage.ErrKeyNotFound {
+		return nil, err
+	}
+	return info, nil
+}
+
+// -- end batched logs.
+
 // -- start transfer log.
 
 func getNEP17TransferLogKey(acc util.Uint160, index uint32) []byte {
@@ -178,15 +244,8 @@ func getNEP17TransferLogKey(acc util.Uint160, index uint32) []byte {
 
 // GetNEP17TransferLog retrieves transfer log from the cache.
 func (dao *Simple) GetNEP17TransferLog(acc util.Uint160, index uint32) (*state.NEP17TransferLog, error) {
-	key := getNEP17TransferLogKey(acc, index)
-	value, err := dao.Store.Get(key)
-	if err != nil {
-		if err == storage.ErrKeyNotFound {
-			return new(state.NEP17TransferLog), nil
-		}
-		return nil, err
-	}
-	return &state.NEP17TransferLog{Raw: value}, nil
+	lg, err := dao.getBatchedLog(getNEP17TransferLogKey(acc, index))
+	return (*state.NEP17TransferLog)(lg), err
 }
 
 // PutNEP17TransferLog saves given transfer log in the cache.
@@ -198,24 +257,150 @@ func (dao *Simple) PutNEP17TransferLog(acc util.Uint160, index uint32, lg *state
 // AppendNEP17Transfer appends a single NEP17 transfer to a log.
 // First return value signalizes that log size has exceeded batch size.
 func (dao *Simple) AppendNEP17Transfer(acc util.Uint160, index uint32, isNew bool, tr *state.NEP17Transfer) (bool, error) {
-	var lg *state.NEP17TransferLog
-	if isNew {
-		lg = new(state.NEP17TransferLog)
-	} else {
-		var err error
-		lg, err = dao.GetNEP17TransferLog(acc, index)
-		if err != nil {
-			return false, err
-		}
-	}
-	if err := lg.Append(tr); err != nil {
-		return false, err
-	}
-	return lg.Size() >= state.NEP17TransferBatchSize, dao.PutNEP17TransferLog(acc, index, lg)
+	return dao.appendToBatchedLog(getNEP17TransferLogKey(acc, index), isNew, tr, state.NEP17TransferBatchSize)
 }
 
 // -- end transfer log.
 
+// -- start notification log.
+// Notification logs are identified by contract hash, but the same functions
+// are used for logs of notifications with particular names which have IDs
+// derived from the contract hash and the name.
+
+func getNotificationLogKey(sc util.Uint160, index uint32) []byte {
+	key := make([]byte, 1+util.Uint160Size+4)
+	key[0] = byte(storage.IXNotifications)
+	copy(key[1:], sc.BytesBE())
+	binary.LittleEndian.PutUint32(key[1+util.Uint160Size:], index)
+	return key
+}
+
+// GetNotificationLogInfo retrieves notification log info of the contract from
+// the cache.
+func (dao *Simple) GetNotificationLogInfo(sc util.Uint160) (*state.BatchedLogInfo, error) {
+	return dao.getBatchedLogInfo(storage.AppendPrefix(storage.IXNotifications, sc.BytesBE()))
+}
+
+// PutNotificationLogInfo saves notification log info of the contract in the
+// cache.
+func (dao *Simple) PutNotificationLogInfo(sc util.Uint160, info *state.BatchedLogInfo) error {
+	key := storage.AppendPrefix(storage.IXNotifications, sc.BytesBE())
+	return dao.Put(info, key)
+}
+
+// GetNotificationLog retrieves contract notification log from the cache.
+func (dao *Simple) GetNotificationLog(sc util.Uint160, index uint32) (*state.NotificationLog, error) {
+	lg, err := dao.getBatchedLog(getNotificationLogKey(sc, index))
+	return (*state.NotificationLog)(lg), err
+}
+
+// PutNotificationLog saves given contract notification log in the cache.
+func (dao *Simple) PutNotificationLog(sc util.Uint160, index uint32, lg *state.NotificationLog) error {
+	key := getNotificationLogKey(sc, index)
+	return dao.Store.Put(key, lg.Raw)
+}
+
+// AppendNotification appends a single notification to a contract log.
+// First return value signalizes that log size has exceeded batch size.
+func (dao *Simple) AppendNotification(sc util.Uint160, index uint32, isNew bool, n *state.IndexedNotification) (bool, error) {
+	return dao.appendToBatchedLog(getNotificationLogKey(sc, index), isNew, n, state.NotificationLogBatchSize)
+}
+
+// -- end notification log.
+
+// -- start address transaction log.
+
+func getAddressTxLogKey(acc util.Uint160, index uint32) []byte {
+	key := make([]byte, 1+util.Uint160Size+4)
+	key[0] = byte(storage.IXAddressTxs)
+	copy(key[1:], acc.BytesBE())
+	binary.LittleEndian.PutUint32(key[1+util.Uint160Size:], index)
+	return key
+}
+
+// GetAddressTxLogInfo retrieves transaction log info of the account from the
+// cache.
+func (dao *Simple) GetAddressTxLogInfo(acc util.Uint160) (*state.BatchedLogInfo, error) {
+	return dao.getBatchedLogInfo(storage.AppendPrefix(storage.IXAddressTxs, acc.BytesBE()))
+}
+
+// PutAddressTxLogInfo saves transaction log info of the account in the cache.
+func (dao *Simple) PutAddressTxLogInfo(acc util.Uint160, info *state.BatchedLogInfo) error {
+	key := storage.AppendPrefix(storage.IXAddressTxs, acc.BytesBE())
+	return dao.Put(info, key)
+}
+
+// GetAddressTxLog retrieves account transaction log from the cache.
+func (dao *Simple) GetAddressTxLog(acc util.Uint160, index uint32) (*state.AddressTxLog, error) {
+	lg, err := dao.getBatchedLog(getAddressTxLogKey(acc, index))
+	return (*state.AddressTxLog)(lg), err
+}
+
+// PutAddressTxLog saves given account transaction log in the cache.
+func (dao *Simple) PutAddressTxLog(acc util.Uint160, index uint32, lg *state.AddressTxLog) error {
+	key := getAddressTxLogKey(acc, index)
+	return dao.Store.Put(key, lg.Raw)
+}
+
+// AppendAddressTransaction appends a single transaction to an account log.
+// First return value signalizes that log size has exceeded batch size.
+func (dao *Simple) AppendAddressTransaction(acc util.Uint160, index uint32, isNew bool, tx *state.AddressTransaction) (bool, error) {
+	return dao.appendToBatchedLog(getAddressTxLogKey(acc, index), isNew, tx, state.AddressTxLogBatchSize)
+}
+
+// -- end address transaction log.
+
+// -- start nep11 balances.
+
+// GetNEP11Balances retrieves nep11 balances from the cache.
+func (dao *Simple) GetNEP11Balances(acc util.Uint160) (*state.NEP11Balances, error) {
+	key := storage.AppendPrefix(storage.STNEP11Balances, acc.BytesBE())
+	bs := state.NewNEP11Balances()
+	err := dao.GetAndDecode(bs, key)
+	if err != nil && err != storage.ErrKeyNotFound {
+		return nil, err
+	}
+	return bs, nil
+}
+
+// PutNEP11Balances saves nep11 balances from the cache.
+func (dao *Simple) PutNEP11Balances(acc util.Uint160, bs *state.NEP11Balances) error {
+	key := storage.AppendPrefix(storage.STNEP11Balances, acc.BytesBE())
+	return dao.Put(bs, key)
+}
+
+// -- end nep11 balances.
+
+// -- start nep11 transfer log.
+
+func getNEP11TransferLogKey(acc util.Uint160, index uint32) []byte {
+	key := make([]byte, 1+util.Uint160Size+4)
+	key[0] = byte(storage.STNEP11Transfers)
+	copy(key[1:], acc.BytesBE())
+	binary.LittleEndian.PutUint32(key[1+util.Uint160Size:], index)
+	return key
+}
+
+// GetNEP11TransferLog retrieves nep11 transfer log from the cache.
+func (dao *Simple) GetNEP11TransferLog(acc util.Uint160, index uint32) (*state.NEP11TransferLog, error) {
+	lg, err := dao.getBatchedLog(getNEP11TransferLogKey(acc, index))
+	return (*state.NEP11TransferLog)(lg), err
+}
+
+// PutNEP11TransferLog saves given nep11 transfer log in the cache.
+func (dao *Simple) PutNEP11TransferLog(acc util.Uint160, index uint32, lg *state.NEP11TransferLog) error {
+	key := getNEP11TransferLogKey(acc, index)
+	return dao.Store.Put(key, lg.Raw)
+}
+
+// AppendNEP11Transfer appends a single NEP11 transfer to a log.
+// First return value signalizes that log size has exceeded batch size.
+func (dao *Simple) AppendNEP11Transfer(acc util.Uint160, index uint32, isNew bool, tr *state.NEP11Transfer) (bool, error) {
+	return dao.appendToBatchedLog(getNEP11TransferLogKey(acc, index), isNew, tr, state.NEP11TransferBatchSize)
+}
+
+// -- end nep11 transfer log.
+
 // -- start notification event.
 
 // GetAppExecResults gets application execution results with the specified trigger from the
@@ -279,6 +464,64 @@ func (dao *Simple) PutAppExecResult(aer *state.AppExecResult, buf *io.BufBinWrit
 
 // -- end notification event.
 
+// -- start storage changes.
+
+// GetStorageChanges returns contract storage changes made by the block with
+// the given index.
+func (dao *Simple) GetStorageChanges(index uint32) (*state.StorageChanges, error) {
+	changes := new(state.StorageChanges)
+	key := storage.AppendPrefixInt(storage.DataStorageDiff, int(index))
+	err := dao.GetAndDecode(changes, key)
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// PutStorageChanges stores given set of contract storage changes made by the
+// block. It can reuse given buffer for the purpose of value serialization.
+func (dao *Simple) PutStorageChanges(changes *state.StorageChanges, buf *io.BufBinWriter) error {
+	key := storage.AppendPrefixInt(storage.DataStorageDiff, int(changes.Block))
+	if buf == nil {
+		return dao.Put(changes, key)
+	}
+	return dao.putWithBuffer(changes, key, buf)
+}
+
+// DeleteStorageChanges drops contract storage changes made by the block with
+// the given index from the store.
+func (dao *Simple) DeleteStorageChanges(index uint32) error {
+	return dao.Store.Delete(storage.AppendPrefixInt(storage.DataStorageDiff, int(index)))
+}
+
+// -- end storage changes.
+
+// -- start transaction trace.
+
+// GetTransactionTrace returns execution trace of the transaction with the
+// given hash.
+func (dao *Simple) GetTransactionTrace(hash util.Uint256) (*state.TransactionTrace, error) {
+	trace := new(state.TransactionTrace)
+	key := storage.AppendPrefix(storage.DataExecTrace, hash.BytesBE())
+	err := dao.GetAndDecode(trace, key)
+	if err != nil {
+		return nil, err
+	}
+	return trace, nil
+}
+
+// PutTransactionTrace stores given transaction execution trace. It can reuse
+// given buffer for the purpose of value serialization.
+func (dao *Simple) PutTransactionTrace(trace *state.TransactionTrace, buf *io.BufBinWriter) error {
+	key := storage.AppendPrefix(storage.DataExecTrace, trace.Container.BytesBE())
+	if buf == nil {
+		return dao.Put(trace, key)
+	}
+	return dao.putWithBuffer(trace, key, buf)
+}
+
+// -- end transaction trace.
+
 // -- start storage item.
 
 // GetStorageItem returns StorageItem if it exists in the given store.
@@ -460,6 +703,27 @@ func (dao *Simple) PutVersion(v string) error {
 	return dao.Store.Put(storage.SYSVersion.Bytes(), []byte(v))
 }
 
+// GetForkHeight returns the height private chain was forked at (see
+// PutForkHeight), storage.ErrKeyNotFound is returned for chains that were
+// never forked.
+func (dao *Simple) GetForkHeight() (uint32, error) {
+	b, err := dao.Store.Get(storage.SYSForkHeight.Bytes())
+	if err != nil {
+		return 0, err
+	}
+	if len(b) != 4 {
+		return 0, errors.New("invalid fork height")
+	}
+	return binary.LittleEndian.Uint32(b), nil
+}
+
+// PutForkHeight stores the height private chain was forked at.
+func (dao *Simple) PutForkHeight(h uint32) error {
+	buf := make([]byte, 4)
+	binary.LittleEndian.PutUint32(buf, h)
+	return dao.Store.Put(storage.SYSForkHeight.Bytes(), buf)
+}
+
 // PutCurrentHeader stores current header.
 func (dao *Simple) PutCurrentHeader(hashAndIndex []byte) error {
 	return dao.Store.Put(storage.SYSCurrentHeader.Bytes(), hashAndIndex)
@@ -549,6 +813,9 @@ func (dao *Simple) DeleteBlock(h util.Uint256, w *io.BufBinWriter) error {
 		batch.Delete(key)
 		key[0] = byte(storage.STNotification)
 		batch.Delete(key)
+		key[0] = byte(storage.DataExecTrace)
+		batch.Delete(key)
+		key[0] = byte(storage.DataTransaction)
 	}
 
 	key[0] = byte(storage.STNotification)
@@ -11,6 +11,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"github.com/stretchr/testify/require"
@@ -59,6 +60,99 @@ func TestPutGetAppExecResult(t *testing.T) {
 	require.Equal(t, []state.AppExecResult{*appExecResult}, gotAppExecResult)
 }
 
+func TestPutGetDeleteStorageChanges(t *testing.T) {
+	dao := NewSimple(storage.NewMemoryStore(), false)
+	changes := &state.StorageChanges{
+		Block: 42,
+		Size:  1,
+		Storage: []state.StorageChange{
+			{State: state.StorageAdded, Key: []byte{1, 0, 0, 0, 1}, Value: []byte{2}},
+		},
+	}
+	_, err := dao.GetStorageChanges(changes.Block)
+	require.Error(t, err)
+	require.NoError(t, dao.PutStorageChanges(changes, nil))
+	actual, err := dao.GetStorageChanges(changes.Block)
+	require.NoError(t, err)
+	require.Equal(t, changes, actual)
+	require.NoError(t, dao.DeleteStorageChanges(changes.Block))
+	_, err = dao.GetStorageChanges(changes.Block)
+	require.Error(t, err)
+}
+
+func TestPutGetTransactionTrace(t *testing.T) {
+	dao := NewSimple(storage.NewMemoryStore(), false)
+	trace := &state.TransactionTrace{
+		Container:   random.Uint256(),
+		VMState:     vm.HaltState,
+		GasConsumed: 10,
+		Call: state.CallTrace{
+			Contract:    random.Uint160(),
+			GasConsumed: 10,
+			Calls:       []state.CallTrace{{Contract: random.Uint160(), Method: "method", GasConsumed: 5}},
+		},
+	}
+	_, err := dao.GetTransactionTrace(trace.Container)
+	require.Error(t, err)
+	require.NoError(t, dao.PutTransactionTrace(trace, nil))
+	actual, err := dao.GetTransactionTrace(trace.Container)
+	require.NoError(t, err)
+	require.Equal(t, trace, actual)
+}
+
+func TestAppendGetNotificationLog(t *testing.T) {
+	dao := NewSimple(storage.NewMemoryStore(), false)
+	sc := random.Uint160()
+	info, err := dao.GetNotificationLogInfo(sc)
+	require.NoError(t, err)
+	require.Equal(t, &state.BatchedLogInfo{}, info)
+
+	n := &state.IndexedNotification{
+		Block:     1,
+		Container: random.Uint256(),
+		Name:      "Event",
+		Item:      stackitem.NewArray([]stackitem.Item{stackitem.NewBool(true)}),
+	}
+	for i := 0; i < state.NotificationLogBatchSize; i++ {
+		full, err := dao.AppendNotification(sc, 0, i == 0, n)
+		require.NoError(t, err)
+		require.Equal(t, i == state.NotificationLogBatchSize-1, full)
+	}
+	lg, err := dao.GetNotificationLog(sc, 0)
+	require.NoError(t, err)
+	require.Equal(t, state.NotificationLogBatchSize, lg.Size())
+	lg, err = dao.GetNotificationLog(sc, 1)
+	require.NoError(t, err)
+	require.Equal(t, 0, lg.Size())
+
+	info = &state.BatchedLogInfo{NextBatch: 1, NewBatch: true}
+	require.NoError(t, dao.PutNotificationLogInfo(sc, info))
+	actual, err := dao.GetNotificationLogInfo(sc)
+	require.NoError(t, err)
+	require.Equal(t, info, actual)
+}
+
+func TestAppendGetAddressTxLog(t *testing.T) {
+	dao := NewSimple(storage.NewMemoryStore(), false)
+	acc := random.Uint160()
+	tx := &state.AddressTransaction{Tx: random.Uint256(), Block: 1, Timestamp: 2, Sender: true}
+	full, err := dao.AppendAddressTransaction(acc, 0, true, tx)
+	require.NoError(t, err)
+	require.False(t, full)
+	full, err = dao.AppendAddressTransaction(acc, 0, false, tx)
+	require.NoError(t, err)
+	require.False(t, full)
+	lg, err := dao.GetAddressTxLog(acc, 0)
+	require.NoError(t, err)
+	require.Equal(t, 2, lg.Size())
+
+	info := &state.BatchedLogInfo{NextBatch: 3}
+	require.NoError(t, dao.PutAddressTxLogInfo(acc, info))
+	actual, err := dao.GetAddressTxLogInfo(acc)
+	require.NoError(t, err)
+	require.Equal(t, info, actual)
+}
+
 func TestPutGetStorageItem(t *testing.T) {
 	dao := NewSimple(storage.NewMemoryStore(), false)
 	id := int32(random.Int(0, 1024))
@@ -0,0 +1,157 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/native"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
+	"github.com/nspcc-dev/neo-go/pkg/services/faucet"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+type testCaptcha struct{}
+
+func (testCaptcha) Verify(token, _ string) error {
+	if token != "ok" {
+		return errors.New("bad token")
+	}
+	return nil
+}
+
+func TestFaucet(t *testing.T) {
+	bc := newTestChain(t)
+	acc, err := wallet.NewAccount()
+	require.NoError(t, err)
+	h := acc.Contract.ScriptHash()
+	transferTokenFromMultisigAccountCheckOK(t, bc, h, bc.contracts.GAS.Hash, 1000*native.GASFactor)
+	transferTokenFromMultisigAccountCheckOK(t, bc, h, bc.contracts.NEO.Hash, 100)
+
+	dir, err := ioutil.TempDir("", "neogo.faucet")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	w := createAndWriteWallet(t, acc, path.Join(dir, "faucet.json"), "pass")
+
+	newFaucet := func(t *testing.T, captcha faucet.CaptchaVerifier) *faucet.Faucet {
+		f, err := faucet.New(faucet.Config{
+			Log: zaptest.NewLogger(t),
+			MainCfg: config.Faucet{
+				Enabled:      true,
+				Address:      "localhost",
+				UnlockWallet: config.Wallet{Path: w.Path(), Password: "pass"},
+				GASAmount:    fixedn.Fixed8FromInt64(10),
+				NEOAmount:    5,
+				Cooldown:     time.Hour,
+			},
+			Chain: bc,
+			RelayTx: func(tx *transaction.Transaction) error {
+				return bc.PoolTx(tx)
+			},
+			Captcha: captcha,
+		})
+		require.NoError(t, err)
+		return f
+	}
+	persistPool := func(t *testing.T) {
+		txs := bc.GetMemPool().GetVerifiedTransactions()
+		aers, err := persistBlock(bc, txs...)
+		require.NoError(t, err)
+		for _, aer := range aers {
+			require.Equal(t, vm.HaltState, aer.VMState, aer.FaultException)
+		}
+	}
+
+	f := newFaucet(t, nil)
+	to1, err := wallet.NewAccount()
+	require.NoError(t, err)
+	_, err = f.Send(faucet.Request{Address: to1.Address}, "1.2.3.4")
+	require.NoError(t, err)
+	persistPool(t)
+	require.Equal(t, big.NewInt(10*native.GASFactor), bc.GetUtilityTokenBalance(to1.Contract.ScriptHash()))
+	neo, _ := bc.GetGoverningTokenBalance(to1.Contract.ScriptHash())
+	require.Equal(t, big.NewInt(5), neo)
+
+	to2, err := wallet.NewAccount()
+	require.NoError(t, err)
+	t.Run("cooldown", func(t *testing.T) {
+		_, err := f.Send(faucet.Request{Address: to1.Address}, "5.6.7.8")
+		require.True(t, errors.Is(err, faucet.ErrCooldown))
+		_, err = f.Send(faucet.Request{Address: to2.Address}, "1.2.3.4")
+		require.True(t, errors.Is(err, faucet.ErrCooldown))
+	})
+	t.Run("IPv6", func(t *testing.T) {
+		f := newFaucet(t, nil)
+		to4, err := wallet.NewAccount()
+		require.NoError(t, err)
+		to5, err := wallet.NewAccount()
+		require.NoError(t, err)
+		_, err = f.Send(faucet.Request{Address: to4.Address}, "2001:db8:0:1::1")
+		require.NoError(t, err)
+		persistPool(t)
+		// The same /64 network.
+		_, err = f.Send(faucet.Request{Address: to5.Address}, "2001:db8:0:1:ffff::2")
+		require.True(t, errors.Is(err, faucet.ErrCooldown))
+	})
+	t.Run("invalid address", func(t *testing.T) {
+		_, err := f.Send(faucet.Request{Address: "addr"}, "5.6.7.8")
+		require.Error(t, err)
+	})
+	t.Run("captcha", func(t *testing.T) {
+		f := newFaucet(t, testCaptcha{})
+		_, err := f.Send(faucet.Request{Address: to2.Address, Captcha: "bad"}, "5.6.7.8")
+		require.Error(t, err)
+		_, err = f.Send(faucet.Request{Address: to2.Address, Captcha: "ok"}, "5.6.7.8")
+		require.NoError(t, err)
+		persistPool(t)
+	})
+	t.Run("HTTP", func(t *testing.T) {
+		f := newFaucet(t, nil)
+		errCh := make(chan error, 1)
+		f.Start(errCh)
+		t.Cleanup(func() { require.NoError(t, f.Shutdown()) })
+
+		resp, err := http.Get("http://" + f.Addr())
+		require.NoError(t, err)
+		var info faucet.Info
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&info))
+		resp.Body.Close()
+		require.Equal(t, acc.Address, info.Address)
+		require.Equal(t, "10", info.GAS)
+		require.Equal(t, int64(5), info.NEO)
+
+		to3, err := wallet.NewAccount()
+		require.NoError(t, err)
+		post := func(t *testing.T, req faucet.Request) (int, faucet.Response) {
+			data, err := json.Marshal(req)
+			require.NoError(t, err)
+			resp, err := http.Post("http://"+f.Addr(), "application/json", bytes.NewReader(data))
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			var r faucet.Response
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&r))
+			return resp.StatusCode, r
+		}
+		code, r := post(t, faucet.Request{Address: to3.Address})
+		require.Equal(t, http.StatusOK, code, r.Error)
+		require.NotNil(t, r.Hash)
+		require.True(t, bc.GetMemPool().ContainsKey(*r.Hash))
+
+		code, _ = post(t, faucet.Request{Address: to3.Address})
+		require.Equal(t, http.StatusTooManyRequests, code)
+		code, _ = post(t, faucet.Request{Address: "addr"})
+		require.Equal(t, http.StatusBadRequest, code)
+	})
+}
@@ -0,0 +1,123 @@
+package core
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/native"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+)
+
+// defaultGenesisSystemFee is the system fee of genesis transactions if it's
+// not specified in the configuration, it's burnt from the standby validators
+// account.
+const defaultGenesisSystemFee = 100 * native.GASFactor
+
+// createGenesisTransactions creates transactions for the genesis block
+// extensions, all of them are signed (with no signatures actually, genesis
+// block is not verified) by the standby validators multisignature account.
+func createGenesisTransactions(cfg config.Genesis, validators []*keys.PublicKey) ([]*transaction.Transaction, error) {
+	verification, err := smartcontract.CreateDefaultMultiSigRedeemScript(validators)
+	if err != nil {
+		return nil, err
+	}
+	owner := hash.Hash160(verification)
+
+	var txs []*transaction.Transaction
+	add := func(script []byte, sysFee int64) {
+		if sysFee == 0 {
+			sysFee = defaultGenesisSystemFee
+		}
+		tx := transaction.New(script, sysFee)
+		tx.Nonce = uint32(len(txs))
+		tx.ValidUntilBlock = transaction.MaxValidUntilBlockIncrement
+		tx.Signers = []transaction.Signer{{
+			Account: owner,
+			Scopes:  transaction.Global,
+		}}
+		tx.Scripts = []transaction.Witness{{
+			InvocationScript:   []byte{},
+			VerificationScript: verification,
+		}}
+		txs = append(txs, tx)
+	}
+
+	mgmt := state.CreateContractHash(util.Uint160{}, 0, nativenames.Management)
+	for i, c := range cfg.Contracts {
+		nefData, err := ioutil.ReadFile(c.NEF)
+		if err != nil {
+			return nil, fmt.Errorf("genesis contract #%d: %w", i, err)
+		}
+		if _, err := nef.FileFromBytes(nefData); err != nil {
+			return nil, fmt.Errorf("genesis contract #%d: invalid NEF: %w", i, err)
+		}
+		manifData, err := ioutil.ReadFile(c.Manifest)
+		if err != nil {
+			return nil, fmt.Errorf("genesis contract #%d: %w", i, err)
+		}
+		if err := json.Unmarshal(manifData, new(manifest.Manifest)); err != nil {
+			return nil, fmt.Errorf("genesis contract #%d: invalid manifest: %w", i, err)
+		}
+		w := io.NewBufBinWriter()
+		emit.AppCall(w.BinWriter, mgmt, "deploy", callflag.All, nefData, manifData)
+		if w.Err != nil {
+			return nil, w.Err
+		}
+		add(w.Bytes(), c.SystemFee)
+	}
+
+	for i, t := range cfg.Transfers {
+		var asset util.Uint160
+		if nativenames.IsValid(t.Asset) {
+			asset = state.CreateContractHash(util.Uint160{}, 0, t.Asset)
+		} else {
+			var err error
+			asset, err = util.Uint160DecodeStringLE(t.Asset)
+			if err != nil {
+				return nil, fmt.Errorf("genesis transfer #%d: invalid asset: %w", i, err)
+			}
+		}
+		to, err := address.StringToUint160(t.To)
+		if err != nil {
+			return nil, fmt.Errorf("genesis transfer #%d: invalid address: %w", i, err)
+		}
+		if t.Amount <= 0 {
+			return nil, fmt.Errorf("genesis transfer #%d: invalid amount %d", i, t.Amount)
+		}
+		w := io.NewBufBinWriter()
+		emit.AppCall(w.BinWriter, asset, "transfer", callflag.All, owner, to, t.Amount, nil)
+		emit.Opcodes(w.BinWriter, opcode.ASSERT)
+		if w.Err != nil {
+			return nil, w.Err
+		}
+		add(w.Bytes(), 0)
+	}
+
+	for i, t := range cfg.Transactions {
+		script, err := base64.StdEncoding.DecodeString(t.Script)
+		if err != nil {
+			return nil, fmt.Errorf("genesis transaction #%d: invalid script: %w", i, err)
+		}
+		if len(script) == 0 {
+			return nil, fmt.Errorf("genesis transaction #%d: empty script", i)
+		}
+		add(script, t.SystemFee)
+	}
+	return txs, nil
+}
@@ -0,0 +1,110 @@
+package core
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/native"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenesisExtensions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "neogo.genesistest")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		os.RemoveAll(dir)
+	})
+
+	ne, err := nef.NewFile([]byte{byte(opcode.PUSH1), byte(opcode.RET)})
+	require.NoError(t, err)
+	nefData, err := ne.Bytes()
+	require.NoError(t, err)
+	nefPath := filepath.Join(dir, "genesis.nef")
+	require.NoError(t, ioutil.WriteFile(nefPath, nefData, os.ModePerm))
+
+	m := manifest.DefaultManifest("Genesis")
+	m.ABI.Methods = []manifest.Method{{
+		Name:       "main",
+		ReturnType: smartcontract.IntegerType,
+		Safe:       true,
+	}}
+	manifData, err := json.Marshal(m)
+	require.NoError(t, err)
+	manifPath := filepath.Join(dir, "genesis.manifest.json")
+	require.NoError(t, ioutil.WriteFile(manifPath, manifData, os.ModePerm))
+
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	to := priv.GetScriptHash()
+
+	genesis := config.Genesis{
+		Contracts: []config.GenesisContract{{NEF: nefPath, Manifest: manifPath}},
+		Transfers: []config.GenesisTransfer{
+			{Asset: nativenames.Gas, To: priv.Address(), Amount: 10 * native.GASFactor},
+			{Asset: nativenames.Neo, To: priv.Address(), Amount: 1000},
+		},
+		Transactions: []config.GenesisTransaction{{
+			Script: base64.StdEncoding.EncodeToString([]byte{byte(opcode.PUSH1)}),
+		}},
+	}
+	bc := newTestChainWithCustomCfg(t, func(c *config.Config) {
+		c.ProtocolConfiguration.Genesis = genesis
+	})
+
+	b, err := bc.GetBlock(bc.GetHeaderHash(0))
+	require.NoError(t, err)
+	require.Equal(t, 4, len(b.Transactions))
+	for _, tx := range b.Transactions {
+		aers, err := bc.GetAppExecResults(tx.Hash(), trigger.Application)
+		require.NoError(t, err)
+		require.Equal(t, vm.HaltState, aers[0].VMState, aers[0].FaultException)
+	}
+
+	cs := bc.GetContractState(state.CreateContractHash(b.NextConsensus, ne.Checksum, m.Name))
+	require.NotNil(t, cs)
+	require.Equal(t, big.NewInt(10*native.GASFactor), bc.GetUtilityTokenBalance(to))
+	neo, _ := bc.GetGoverningTokenBalance(to)
+	require.Equal(t, big.NewInt(1000), neo)
+
+	t.Run("invalid", func(t *testing.T) {
+		cfg := bc.GetConfig()
+		check := func(t *testing.T, g config.Genesis) {
+			cfg.Genesis = g
+			_, err := createGenesisBlock(cfg)
+			require.Error(t, err)
+		}
+		t.Run("missing NEF", func(t *testing.T) {
+			check(t, config.Genesis{Contracts: []config.GenesisContract{{NEF: filepath.Join(dir, "missing"), Manifest: manifPath}}})
+		})
+		t.Run("invalid manifest", func(t *testing.T) {
+			check(t, config.Genesis{Contracts: []config.GenesisContract{{NEF: nefPath, Manifest: nefPath}}})
+		})
+		t.Run("invalid asset", func(t *testing.T) {
+			check(t, config.Genesis{Transfers: []config.GenesisTransfer{{Asset: "Bitcoin", To: priv.Address(), Amount: 1}}})
+		})
+		t.Run("invalid address", func(t *testing.T) {
+			check(t, config.Genesis{Transfers: []config.GenesisTransfer{{Asset: nativenames.Gas, To: "addr", Amount: 1}}})
+		})
+		t.Run("invalid amount", func(t *testing.T) {
+			check(t, config.Genesis{Transfers: []config.GenesisTransfer{{Asset: nativenames.Gas, To: priv.Address()}}})
+		})
+		t.Run("invalid script", func(t *testing.T) {
+			check(t, config.Genesis{Transactions: []config.GenesisTransaction{{Script: "!"}}})
+		})
+	})
+}
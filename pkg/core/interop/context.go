@@ -46,6 +46,7 @@ type Context struct {
 	VM            *vm.VM
 	Functions     [][]Function
 	getContract   func(dao.DAO, util.Uint160) (*state.Contract, error)
+	tracer        *callTracer
 }
 
 // NewContext returns new interop context.
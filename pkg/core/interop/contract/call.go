@@ -100,6 +100,7 @@ func callExFromNative(ic *interop.Context, caller util.Uint160, cs *state.Contra
 	ic.VM.Invocations[cs.Hash]++
 	ic.VM.LoadScriptWithCallingHash(caller, cs.NEF.Script, cs.Hash, ic.VM.Context().GetCallFlags()&f, hasReturn, uint16(len(args)))
 	ic.VM.Context().NEF = &cs.NEF
+	ic.TraceCall(cs.Hash, name)
 	for i := len(args) - 1; i >= 0; i-- {
 		ic.VM.Estack().PushVal(args[i])
 	}
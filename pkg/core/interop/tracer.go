@@ -0,0 +1,92 @@
+package interop
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+)
+
+// callFrame is a traced contract call.
+type callFrame struct {
+	ctx        *vm.Context
+	trace      state.CallTrace
+	startGas   int64
+	notifStart int
+	notifEnd   int
+	children   []*callFrame
+}
+
+// callTracer keeps a tree of contract calls made by the VM.
+type callTracer struct {
+	root  *callFrame
+	stack []*callFrame
+}
+
+// EnableCallTracing starts tracing contract calls, the entry script must
+// already be loaded into the VM. Calls are registered via TraceCall and
+// finished when their contexts are unloaded.
+func (ic *Context) EnableCallTracing() {
+	ic.tracer = new(callTracer)
+	ic.tracer.root = ic.newCallFrame(ic.VM.Context().ScriptHash(), "")
+	ic.tracer.stack = []*callFrame{ic.tracer.root}
+	ic.VM.OnUnload = func(ctx *vm.Context) {
+		st := ic.tracer.stack
+		for len(st) != 0 && st[len(st)-1].ctx == ctx {
+			ic.finishCallFrame(st[len(st)-1])
+			st = st[:len(st)-1]
+		}
+		ic.tracer.stack = st
+	}
+}
+
+// TraceCall registers a call of the contract method which has just been loaded
+// into the VM. It does nothing if tracing is not enabled.
+func (ic *Context) TraceCall(h util.Uint160, method string) {
+	if ic.tracer == nil {
+		return
+	}
+	f := ic.newCallFrame(h, method)
+	parent := ic.tracer.stack[len(ic.tracer.stack)-1]
+	parent.children = append(parent.children, f)
+	ic.tracer.stack = append(ic.tracer.stack, f)
+}
+
+// CallTrace returns a tree of calls traced so far, calls which were not finished
+// (because of VM fault) are finished with the current GAS and notifications
+// state. It returns nil if tracing is not enabled.
+func (ic *Context) CallTrace() *state.CallTrace {
+	if ic.tracer == nil {
+		return nil
+	}
+	for i := len(ic.tracer.stack) - 1; i >= 0; i-- {
+		ic.finishCallFrame(ic.tracer.stack[i])
+	}
+	ic.tracer.stack = nil
+	return &ic.tracer.root.trace
+}
+
+func (ic *Context) newCallFrame(h util.Uint160, method string) *callFrame {
+	return &callFrame{
+		ctx: ic.VM.Context(),
+		trace: state.CallTrace{
+			Contract: h,
+			Method:   method,
+		},
+		startGas:   ic.VM.GasConsumed(),
+		notifStart: len(ic.Notifications),
+	}
+}
+
+// finishCallFrame fills call trace with GAS consumed and notifications emitted
+// by the contract itself (excluding the ones emitted by nested calls).
+func (ic *Context) finishCallFrame(f *callFrame) {
+	f.trace.GasConsumed = ic.VM.GasConsumed() - f.startGas
+	f.notifEnd = len(ic.Notifications)
+	pos := f.notifStart
+	for _, c := range f.children {
+		f.trace.Notifications = append(f.trace.Notifications, ic.Notifications[pos:c.notifStart]...)
+		f.trace.Calls = append(f.trace.Calls, c.trace)
+		pos = c.notifEnd
+	}
+	f.trace.Notifications = append(f.trace.Notifications, ic.Notifications[pos:f.notifEnd]...)
+}
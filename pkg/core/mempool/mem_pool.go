@@ -71,6 +71,12 @@ type Pool struct {
 	resendThreshold uint32
 	resendFunc      func(*transaction.Transaction, interface{})
 
+	// replaceFeeIncrease and replaceMinFee are the minimum relative (in
+	// percents) and absolute network fee increases required to replace
+	// conflicting transaction.
+	replaceFeeIncrease int64
+	replaceMinFee      int64
+
 	// subscriptions for mempool events
 	subscriptionsEnabled bool
 	subscriptionsOn      atomic.Bool
@@ -436,6 +442,28 @@ func New(capacity int, payerIndex int, enableSubscriptions bool) *Pool {
 	return mp
 }
 
+// SetReplacementPolicy sets the minimum network fee increase required for
+// transaction to replace conflicting one (see Conflicts attribute), it's
+// specified both in percents of the original network fee and as an absolute
+// value (in GAS fractions), the biggest of them is used. Zero values (the
+// default) allow to replace transactions paying any bigger network fee.
+func (mp *Pool) SetReplacementPolicy(feeIncrease int, minFee int64) {
+	mp.lock.Lock()
+	defer mp.lock.Unlock()
+	mp.replaceFeeIncrease = int64(feeIncrease)
+	mp.replaceMinFee = minFee
+}
+
+// replacementFee returns the minimum network fee transaction must pay to
+// replace the given one according to the replacement policy.
+func (mp *Pool) replacementFee(tx *transaction.Transaction) int64 {
+	inc := tx.NetworkFee/100*mp.replaceFeeIncrease + tx.NetworkFee%100*mp.replaceFeeIncrease/100
+	if inc < mp.replaceMinFee {
+		inc = mp.replaceMinFee
+	}
+	return tx.NetworkFee + inc
+}
+
 // SetResendThreshold sets threshold after which transaction will be considered stale
 // and returned for retransmission by `GetStaleTransactions`.
 func (mp *Pool) SetResendThreshold(h uint32, f func(*transaction.Transaction, interface{})) {
@@ -518,8 +546,8 @@ func (mp *Pool) checkTxConflicts(tx *transaction.Transaction, fee Feer) ([]*tran
 		if conflictingHashes, ok := mp.conflicts[tx.Hash()]; ok {
 			for _, hash := range conflictingHashes {
 				existingTx := mp.verifiedMap[hash]
-				if existingTx.HasSigner(payer) && existingTx.NetworkFee > tx.NetworkFee {
-					return nil, fmt.Errorf("%w: conflicting transaction %s has bigger network fee", ErrConflictsAttribute, existingTx.Hash().StringBE())
+				if existingTx.HasSigner(payer) && tx.NetworkFee < mp.replacementFee(existingTx) {
+					return nil, fmt.Errorf("%w: conflicting transaction %s has bigger network fee (or the difference is too small)", ErrConflictsAttribute, existingTx.Hash().StringBE())
 				}
 				conflictsToBeRemoved = append(conflictsToBeRemoved, existingTx)
 			}
@@ -534,8 +562,8 @@ func (mp *Pool) checkTxConflicts(tx *transaction.Transaction, fee Feer) ([]*tran
 			if !tx.HasSigner(existingTx.Signers[mp.payerIndex].Account) {
 				return nil, fmt.Errorf("%w: not signed by the sender of conflicting transaction %s", ErrConflictsAttribute, existingTx.Hash().StringBE())
 			}
-			if existingTx.NetworkFee >= tx.NetworkFee {
-				return nil, fmt.Errorf("%w: conflicting transaction %s has bigger or equal network fee", ErrConflictsAttribute, existingTx.Hash().StringBE())
+			if existingTx.NetworkFee >= tx.NetworkFee || tx.NetworkFee < mp.replacementFee(existingTx) {
+				return nil, fmt.Errorf("%w: conflicting transaction %s has bigger or equal network fee (or the difference is too small)", ErrConflictsAttribute, existingTx.Hash().StringBE())
 			}
 			conflictsToBeRemoved = append(conflictsToBeRemoved, existingTx)
 		}
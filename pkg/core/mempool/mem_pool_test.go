@@ -549,6 +549,58 @@ func TestMempoolAddRemoveConflicts(t *testing.T) {
 	require.True(t, errors.Is(mp.Add(tx13, fs), ErrConflictsAttribute))
 }
 
+func TestMempoolReplacementPolicy(t *testing.T) {
+	mp := New(10, 0, false)
+	mp.SetReplacementPolicy(10, 20)
+	var (
+		fs           = &FeerStub{p2pSigExt: true, balance: 100000}
+		nonce uint32 = 1
+	)
+	getConflictsTx := func(netFee int64, hashes ...util.Uint256) *transaction.Transaction {
+		tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+		tx.NetworkFee = netFee
+		tx.Nonce = nonce
+		nonce++
+		tx.Signers = []transaction.Signer{{Account: util.Uint160{1, 2, 3}}}
+		for _, h := range hashes {
+			tx.Attributes = append(tx.Attributes, transaction.Attribute{
+				Type:  transaction.ConflictsT,
+				Value: &transaction.Conflicts{Hash: h},
+			})
+		}
+		return tx
+	}
+
+	require.Equal(t, int64(120), mp.replacementFee(getConflictsTx(100)))
+	require.Equal(t, int64(1100), mp.replacementFee(getConflictsTx(1000)))
+
+	t.Run("conflicts with mempooled", func(t *testing.T) {
+		tx1 := getConflictsTx(1000)
+		require.NoError(t, mp.Add(tx1, fs))
+
+		// Bigger fee, but not enough to replace tx1.
+		tx2 := getConflictsTx(1099, tx1.Hash())
+		require.True(t, errors.Is(mp.Add(tx2, fs), ErrConflictsAttribute))
+		require.True(t, mp.ContainsKey(tx1.Hash()))
+
+		tx3 := getConflictsTx(1100, tx1.Hash())
+		require.NoError(t, mp.Add(tx3, fs))
+		require.False(t, mp.ContainsKey(tx1.Hash()))
+		require.True(t, mp.ContainsKey(tx3.Hash()))
+	})
+	t.Run("mempooled conflicts with it", func(t *testing.T) {
+		tx5 := getConflictsTx(119)
+		tx6 := getConflictsTx(120)
+		tx4 := getConflictsTx(100, tx5.Hash(), tx6.Hash())
+		require.NoError(t, mp.Add(tx4, fs))
+
+		// Absolute increase is bigger than relative one here.
+		require.True(t, errors.Is(mp.Add(tx5, fs), ErrConflictsAttribute))
+		require.NoError(t, mp.Add(tx6, fs))
+		require.False(t, mp.ContainsKey(tx4.Hash()))
+	})
+}
+
 func TestMempoolAddWithDataGetData(t *testing.T) {
 	var (
 		smallNetFee int64 = 3
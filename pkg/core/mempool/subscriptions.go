@@ -1,6 +1,9 @@
 package mempool
 
 import (
+	"encoding/json"
+	"errors"
+
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 )
 
@@ -14,6 +17,51 @@ const (
 	TransactionRemoved EventType = 0x02
 )
 
+// String is a Stringer implementation.
+func (e EventType) String() string {
+	switch e {
+	case TransactionAdded:
+		return "added"
+	case TransactionRemoved:
+		return "removed"
+	default:
+		return "unknown"
+	}
+}
+
+// GetEventTypeFromString converts input string into an EventType if it's possible.
+func GetEventTypeFromString(s string) (EventType, error) {
+	switch s {
+	case "added":
+		return TransactionAdded, nil
+	case "removed":
+		return TransactionRemoved, nil
+	default:
+		return 0, errors.New("invalid event type name")
+	}
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (e EventType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (e *EventType) UnmarshalJSON(b []byte) error {
+	var s string
+
+	err := json.Unmarshal(b, &s)
+	if err != nil {
+		return err
+	}
+	id, err := GetEventTypeFromString(s)
+	if err != nil {
+		return err
+	}
+	*e = id
+	return nil
+}
+
 // Event represents one of mempool events: transaction was added or removed from mempool.
 type Event struct {
 	Type EventType
@@ -1,9 +1,11 @@
 package mempool
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 
+	"github.com/nspcc-dev/neo-go/internal/testserdes"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
@@ -95,3 +97,12 @@ func TestSubscriptions(t *testing.T) {
 		require.Equal(t, Event{Type: TransactionAdded, Tx: txs[3]}, event2)
 	})
 }
+
+func TestEventType_MarshalJSON(t *testing.T) {
+	for _, e := range []EventType{TransactionAdded, TransactionRemoved} {
+		testserdes.MarshalUnmarshalJSON(t, &e, new(EventType))
+	}
+	var e EventType
+	require.Error(t, json.Unmarshal([]byte(`"unknown"`), &e))
+	require.Error(t, json.Unmarshal([]byte(`1`), &e))
+}
@@ -0,0 +1,64 @@
+package core
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"go.uber.org/zap"
+)
+
+// SaveMemPool writes all verified transactions from the memory pool into the
+// file specified, they can be restored later with LoadMemPool. The file is
+// replaced atomically if it already exists.
+func (bc *Blockchain) SaveMemPool(path string) error {
+	w := io.NewBufBinWriter()
+	w.WriteU32LE(uint32(bc.config.Magic))
+	w.WriteArray(bc.memPool.GetVerifiedTransactions())
+	if w.Err != nil {
+		return w.Err
+	}
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, w.Bytes(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// LoadMemPool reads transactions saved with SaveMemPool from the given file
+// and tries to add them to the memory pool. Every transaction is verified
+// against the current chain state, invalid ones are silently dropped. It
+// returns the number of transactions added, missing file is not an error.
+func (bc *Blockchain) LoadMemPool(path string) (int, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	r := io.NewBinReaderFromBuf(data)
+	magic := netmode.Magic(r.ReadU32LE())
+	var txes []*transaction.Transaction
+	r.ReadArray(&txes)
+	if r.Err != nil {
+		return 0, fmt.Errorf("failed to decode mempool dump: %w", r.Err)
+	}
+	if magic != bc.config.Magic {
+		return 0, fmt.Errorf("mempool dump belongs to %s network", magic)
+	}
+
+	var added int
+	for _, tx := range txes {
+		if err := bc.PoolTx(tx); err != nil {
+			bc.log.Debug("transaction from mempool dump is not pooled",
+				zap.Stringer("hash", tx.Hash()), zap.Error(err))
+			continue
+		}
+		added++
+	}
+	return added, nil
+}
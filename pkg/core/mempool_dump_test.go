@@ -0,0 +1,63 @@
+package core
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/internal/testchain"
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveLoadMemPool(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "neogo.mempooldump")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		os.RemoveAll(tmpDir)
+	})
+	dumpPath := path.Join(tmpDir, "mempool.dump")
+
+	bc := newTestChain(t)
+	txes := make([]*transaction.Transaction, 4)
+	for i := range txes {
+		txes[i] = bc.newTestTx(testchain.MultisigScriptHash(), []byte{byte(opcode.PUSH1)})
+		require.NoError(t, testchain.SignTx(bc, txes[i]))
+		require.NoError(t, bc.PoolTx(txes[i]))
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		n, err := bc.LoadMemPool(path.Join(tmpDir, "missing"))
+		require.NoError(t, err)
+		require.Equal(t, 0, n)
+	})
+	require.NoError(t, bc.SaveMemPool(dumpPath))
+
+	// One of the transactions is persisted, so it's not valid anymore.
+	other := newTestChain(t)
+	require.NoError(t, other.AddBlock(other.newBlock(txes[0])))
+	n, err := other.LoadMemPool(dumpPath)
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+	for _, tx := range txes[1:] {
+		require.True(t, other.GetMemPool().ContainsKey(tx.Hash()))
+	}
+
+	t.Run("other network", func(t *testing.T) {
+		bc := newTestChainWithCustomCfg(t, func(c *config.Config) {
+			c.ProtocolConfiguration.Magic = netmode.PrivNet
+		})
+		_, err := bc.LoadMemPool(dumpPath)
+		require.Error(t, err)
+	})
+	t.Run("invalid file", func(t *testing.T) {
+		invalidPath := path.Join(tmpDir, "invalid.dump")
+		require.NoError(t, ioutil.WriteFile(invalidPath, []byte{1, 2, 3}, 0644))
+		_, err := bc.LoadMemPool(invalidPath)
+		require.Error(t, err)
+	})
+}
@@ -2,6 +2,8 @@ package mpt
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/storage"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
@@ -59,9 +61,28 @@ func (t *Trie) getProof(curr Node, path []byte, proofs *[][]byte) (Node, error)
 	return nil, ErrNotFound
 }
 
+// ErrInvalidProof is returned when proof doesn't prove the key to belong to
+// the MPT with the specified root hash.
+var ErrInvalidProof = errors.New("invalid proof")
+
 // VerifyProof verifies that path indeed belongs to a MPT with the specified root hash.
 // It also returns value for the key.
 func VerifyProof(rh util.Uint256, key []byte, proofs [][]byte) ([]byte, bool) {
+	bs, err := CheckProof(rh, key, proofs)
+	return bs, err == nil
+}
+
+// CheckProof is the same as VerifyProof, but it returns an error (wrapping
+// ErrInvalidProof) describing the problem if proof is not valid. It only
+// needs trusted root hash, so it can be used by light clients to check
+// values received from untrusted nodes.
+func CheckProof(rh util.Uint256, key []byte, proofs [][]byte) ([]byte, error) {
+	if len(key) > MaxKeyLength {
+		return nil, fmt.Errorf("%w: key is too big", ErrInvalidProof)
+	}
+	if len(proofs) == 0 {
+		return nil, fmt.Errorf("%w: no proof nodes", ErrInvalidProof)
+	}
 	path := toNibbles(key)
 	tr := NewTrie(NewHashNode(rh), false, storage.NewMemCachedStore(storage.NewMemoryStore()))
 	for i := range proofs {
@@ -70,5 +91,8 @@ func VerifyProof(rh util.Uint256, key []byte, proofs [][]byte) ([]byte, bool) {
 		_ = tr.Store.Put(makeStorageKey(h[:]), proofs[i])
 	}
 	_, bs, err := tr.getWithPath(tr.root, path)
-	return bs, err == nil
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidProof, err)
+	}
+	return bs, nil
 }
@@ -1,6 +1,7 @@
 package mpt
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -71,3 +72,31 @@ func TestVerifyProof(t *testing.T) {
 		require.Equal(t, []byte("somevalue"), v)
 	})
 }
+
+func TestCheckProof(t *testing.T) {
+	tr := newProofTrie(t)
+	key := []byte{0x12, 0x32}
+	proof, err := tr.GetProof(key)
+	require.NoError(t, err)
+
+	v, err := CheckProof(tr.root.Hash(), key, proof)
+	require.NoError(t, err)
+	require.Equal(t, []byte("value2"), v)
+
+	t.Run("WrongKey", func(t *testing.T) {
+		_, err := CheckProof(tr.root.Hash(), []byte{0x12, 0x31}, proof)
+		require.True(t, errors.Is(err, ErrInvalidProof), "got: %v", err)
+	})
+	t.Run("WrongRoot", func(t *testing.T) {
+		_, err := CheckProof(tr.root.Hash().Reverse(), key, proof)
+		require.True(t, errors.Is(err, ErrInvalidProof), "got: %v", err)
+	})
+	t.Run("MissingNode", func(t *testing.T) {
+		_, err := CheckProof(tr.root.Hash(), key, proof[:len(proof)-1])
+		require.True(t, errors.Is(err, ErrInvalidProof), "got: %v", err)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		_, err := CheckProof(tr.root.Hash(), key, nil)
+		require.True(t, errors.Is(err, ErrInvalidProof), "got: %v", err)
+	})
+}
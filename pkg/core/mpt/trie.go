@@ -18,6 +18,11 @@ type Trie struct {
 	root            Node
 	refcountEnabled bool
 	refcount        map[util.Uint256]*cachedNode
+
+	// keepStale makes Flush keep nodes whose reference count dropped to
+	// zero in the storage and collect their hashes in stale.
+	keepStale bool
+	stale     []util.Uint256
 }
 
 type cachedNode struct {
@@ -356,6 +361,23 @@ func makeStorageKey(mptKey []byte) []byte {
 	return append([]byte{byte(storage.DataMPT)}, mptKey...)
 }
 
+// KeepStaleNodes makes t keep nodes that are no longer referenced in the
+// storage (with zero reference count) instead of deleting them, so that older
+// states remain accessible until these nodes are removed explicitly. Hashes of
+// such nodes can be retrieved via StaleNodes. It only makes sense when
+// reference counting is enabled.
+func (t *Trie) KeepStaleNodes(keep bool) {
+	t.keepStale = keep
+}
+
+// StaleNodes returns hashes of nodes that became unreferenced during flushes
+// made since the previous StaleNodes call.
+func (t *Trie) StaleNodes() []util.Uint256 {
+	stale := t.stale
+	t.stale = nil
+	return stale
+}
+
 // Flush puts every node in the trie except Hash ones to the storage.
 // Because we care only about block-level changes, there is no need to put every
 // new node to storage. Normally, flush should be called with every StateRoot persist, i.e.
@@ -406,8 +428,12 @@ func (t *Trie) updateRefCount(h util.Uint256) int32 {
 	case cnt < 0:
 		// BUG: negative reference count
 		panic(fmt.Sprintf("negative reference count: %s new %d, upd %d", h.StringBE(), cnt, t.refcount[h]))
-	case cnt == 0:
+	case cnt == 0 && !t.keepStale:
 		_ = t.Store.Delete(key)
+	case cnt == 0:
+		binary.LittleEndian.PutUint32(data[len(data)-4:], 0)
+		_ = t.Store.Put(key, data)
+		t.stale = append(t.stale, h)
 	default:
 		binary.LittleEndian.PutUint32(data[len(data)-4:], uint32(cnt))
 		_ = t.Store.Put(key, data)
@@ -78,6 +78,33 @@ func TestTrie_Refcount(t *testing.T) {
 	})
 }
 
+func TestTrie_KeepStaleNodes(t *testing.T) {
+	tr := NewTrie(nil, true, newTestStore())
+	tr.KeepStaleNodes(true)
+	require.NoError(t, tr.Put([]byte{0x11}, []byte{1}))
+	tr.Flush()
+	require.Empty(t, tr.StaleNodes())
+	oldRoot := tr.StateRoot()
+
+	require.NoError(t, tr.Put([]byte{0x11}, []byte{2}))
+	tr.Flush()
+	stale := tr.StaleNodes()
+	require.Equal(t, 2, len(stale)) // Extension and leaf.
+	require.Contains(t, stale, oldRoot)
+	require.Empty(t, tr.StaleNodes())
+	tr.testHas(t, []byte{0x11}, []byte{2})
+
+	// Old state is still accessible.
+	old := NewTrie(NewHashNode(oldRoot), true, tr.Store)
+	old.testHas(t, []byte{0x11}, []byte{1})
+
+	// Stale node can be referenced again.
+	require.NoError(t, tr.Put([]byte{0x11}, []byte{1}))
+	tr.Flush()
+	require.Equal(t, oldRoot, tr.StateRoot())
+	tr.testHas(t, []byte{0x11}, []byte{1})
+}
+
 func TestTrie_PutIntoBranchNode(t *testing.T) {
 	b := NewBranchNode()
 	l := NewLeafNode([]byte{0x8})
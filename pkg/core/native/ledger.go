@@ -1,6 +1,7 @@
 package native
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"math/big"
@@ -110,8 +111,8 @@ func (l *Ledger) currentIndex(ic *interop.Context, _ []stackitem.Item) stackitem
 // getBlock implements getBlock SC method.
 func (l *Ledger) getBlock(ic *interop.Context, params []stackitem.Item) stackitem.Item {
 	hash := getBlockHashFromItem(ic.Chain, params[0])
-	block, err := ic.Chain.GetBlock(hash)
-	if err != nil || !isTraceableBlock(ic.Chain, block.Index) {
+	block, err := getTraceableBlock(ic.Chain, hash)
+	if err != nil {
 		return stackitem.Null{}
 	}
 	return BlockToStackItem(block)
@@ -140,8 +141,8 @@ func (l *Ledger) getTransactionHeight(ic *interop.Context, params []stackitem.It
 func (l *Ledger) getTransactionFromBlock(ic *interop.Context, params []stackitem.Item) stackitem.Item {
 	hash := getBlockHashFromItem(ic.Chain, params[0])
 	index := toUint32(params[1])
-	block, err := ic.Chain.GetBlock(hash)
-	if err != nil || !isTraceableBlock(ic.Chain, block.Index) {
+	block, err := getTraceableBlock(ic.Chain, hash)
+	if err != nil {
 		return stackitem.Null{}
 	}
 	if index >= uint32(len(block.Transactions)) {
@@ -150,6 +151,19 @@ func (l *Ledger) getTransactionFromBlock(ic *interop.Context, params []stackitem
 	return TransactionToStackItem(block.Transactions[index])
 }
 
+// getTraceableBlock returns the block with the given hash if it's traceable,
+// only local header is checked for untraceable ones.
+func getTraceableBlock(bc blockchainer.Blockchainer, hash util.Uint256) (*block.Block, error) {
+	h, err := bc.GetHeader(hash)
+	if err != nil {
+		return nil, err
+	}
+	if !isTraceableBlock(bc, h.Index) {
+		return nil, errors.New("block is not traceable")
+	}
+	return bc.GetBlock(hash)
+}
+
 // isTraceableBlock defines whether we're able to give information about
 // the block with index specified.
 func isTraceableBlock(bc blockchainer.Blockchainer, index uint32) bool {
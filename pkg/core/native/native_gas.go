@@ -22,7 +22,9 @@ const gasContractID = -6
 
 // GASFactor is a divisor for finding GAS integral value.
 const GASFactor = NEOTotalSupply
-const initialGAS = 30000000
+
+// InitialGAS is the amount of GAS (in whole units) minted at genesis.
+const InitialGAS = 30000000
 
 // newGAS returns GAS native contract.
 func newGAS() *GAS {
@@ -80,7 +82,7 @@ func (g *GAS) Initialize(ic *interop.Context) error {
 	if err != nil {
 		return err
 	}
-	g.mint(ic, h, big.NewInt(initialGAS*GASFactor), false)
+	g.mint(ic, h, big.NewInt(InitialGAS*GASFactor), false)
 	return nil
 }
 
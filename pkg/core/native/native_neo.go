@@ -281,6 +281,26 @@ func (n *NEO) updateCommittee(ic *interop.Context) error {
 	return ic.DAO.PutStorageItem(n.ID, prefixCommittee, cvs.Bytes())
 }
 
+// ForkCommittee replaces current committee with the standby one from the
+// configuration, it's used to fork private chain from the existing state.
+// Voter turnout is reset, so the standby committee is kept until enough
+// votes are collected again.
+func (n *NEO) ForkCommittee(bc blockchainer.Blockchainer, d dao.DAO) error {
+	err := d.PutStorageItem(n.ID, []byte{prefixVotersCount}, state.StorageItem{})
+	if err != nil {
+		return err
+	}
+	_, cvs, err := n.computeCommitteeMembers(bc, d)
+	if err != nil {
+		return err
+	}
+	if err := n.updateCache(cvs, bc); err != nil {
+		return err
+	}
+	n.votesChanged.Store(false)
+	return d.PutStorageItem(n.ID, prefixCommittee, cvs.Bytes())
+}
+
 // ShouldUpdateCommittee returns true if committee is updated at block h.
 func ShouldUpdateCommittee(h uint32, bc blockchainer.Blockchainer) bool {
 	cfg := bc.GetConfig()
@@ -879,6 +899,40 @@ func (n *NEO) ComputeNextBlockValidators(bc blockchainer.Blockchainer, d dao.DAO
 	return result, nil
 }
 
+// ComputeNextCommittee returns committee members that would be elected if
+// committee was updated now. They're ordered by votes (so the first
+// ValidatorsCount of them are validators) and returned along with the
+// estimated amount of GAS per block distributed among voters of every member.
+func (n *NEO) ComputeNextCommittee(bc blockchainer.Blockchainer, d dao.DAO) (keys.PublicKeys, []*big.Int, error) {
+	pubs, _, err := n.computeCommitteeMembers(bc, d)
+	if err != nil {
+		return nil, nil, err
+	}
+	cfg := bc.GetConfig()
+	committeeSize := len(cfg.StandbyCommittee)
+	// Voters get their reward for the whole epoch at committee update, see
+	// PostPersist.
+	reward := new(big.Int).Mul(n.GetGASPerBlock(d, bc.BlockHeight()+1), big.NewInt(voterRewardRatio))
+	reward.Div(reward, big.NewInt(int64(100*(committeeSize+cfg.ValidatorsCount))))
+	rewards := make([]*big.Int, len(pubs))
+	for i := range pubs {
+		rewards[i] = new(big.Int).Set(reward)
+		if i < cfg.ValidatorsCount {
+			rewards[i].Lsh(rewards[i], 1)
+		}
+	}
+	return pubs.Copy(), rewards, nil
+}
+
+// GetAccountState returns NEO balance state of the specified account.
+func (n *NEO) GetAccountState(d dao.DAO, acc util.Uint160) (*state.NEOBalanceState, error) {
+	si := d.GetStorageItem(n.ID, makeAccountKey(acc))
+	if si == nil {
+		return nil, storage.ErrKeyNotFound
+	}
+	return state.NEOBalanceStateFromBytes(si)
+}
+
 func (n *NEO) getCommittee(ic *interop.Context, _ []stackitem.Item) stackitem.Item {
 	pubs := n.GetCommitteeMembers()
 	sort.Sort(pubs)
@@ -133,6 +133,27 @@ func TestNEO_Vote(t *testing.T) {
 	pubs = neo.GetNextBlockValidatorsInternal()
 	require.EqualValues(t, sortedCandidates, pubs)
 
+	t.Run("next committee", func(t *testing.T) {
+		committee, rewards, err := neo.ComputeNextCommittee(bc, ic.DAO)
+		require.NoError(t, err)
+		require.Equal(t, candidates, committee)
+		require.Equal(t, len(committee), len(rewards))
+
+		reward := new(big.Int).Mul(neo.GetGASPerBlock(ic.DAO, bc.BlockHeight()+1), big.NewInt(80))
+		reward.Div(reward, big.NewInt(int64(100*freq)))
+		for i := range rewards {
+			if i < testchain.ValidatorsCount {
+				require.Equal(t, new(big.Int).Mul(reward, big.NewInt(2)), rewards[i])
+			} else {
+				require.Equal(t, reward, rewards[i])
+			}
+		}
+
+		acc, err := neo.GetAccountState(ic.DAO, h)
+		require.NoError(t, err)
+		require.Equal(t, candidates[0], acc.VoteTo)
+	})
+
 	t.Run("check voter rewards", func(t *testing.T) {
 		gasBalance := make([]*big.Int, len(accs))
 		neoBalance := make([]*big.Int, len(accs))
@@ -35,6 +35,12 @@ func getOracleConfig(t *testing.T, bc *Blockchain, w, pass string) oracle.Config
 		Network: netmode.UnitTestNet,
 		MainCfg: config.OracleConfiguration{
 			RefreshInterval: time.Second,
+			MaxResponseSize: 2 * transaction.MaxOracleResultSize,
+			IPFS:            config.IPFSConfiguration{Gateway: "https://ipfs.gateway"},
+			HTTPHeaders: []config.OracleHeaders{{
+				URLPrefix: "https://get.header",
+				Headers:   map[string]string{"X-Api-Key": "secret"},
+			}},
 			UnlockWallet: config.Wallet{
 				Path:     path.Join(oracleModulePath, w),
 				Password: pass,
@@ -146,6 +152,11 @@ func TestOracle(t *testing.T) {
 	flt := "Values[1]"
 	putOracleRequest(t, cs.Hash, bc, "https://get.filter", &flt, "handle", []byte{}, 10_000_000)
 	putOracleRequest(t, cs.Hash, bc, "https://get.filterinv", &flt, "handle", []byte{}, 10_000_000)
+	putOracleRequest(t, cs.Hash, bc, "https://get.header", nil, "handle", []byte{}, 10_000_000)
+	putOracleRequest(t, cs.Hash, bc, "ipfs://QmCID/file", nil, "handle", []byte{}, 10_000_000)
+	fltBig := "Values[0]"
+	putOracleRequest(t, cs.Hash, bc, "https://get.bigfilter", &fltBig, "handle", []byte{}, 10_000_000)
+	putOracleRequest(t, cs.Hash, bc, "https://get.huge", nil, "handle", []byte{}, 10_000_000)
 
 	checkResp := func(t *testing.T, id uint64, resp *transaction.OracleResponse) *state.OracleRequest {
 		req, err := oracleCtr.GetRequestInternal(bc.dao, id)
@@ -262,6 +273,33 @@ func TestOracle(t *testing.T) {
 			})
 		})
 	})
+	t.Run("WithHeaders", func(t *testing.T) {
+		checkResp(t, 11, &transaction.OracleResponse{
+			ID:     11,
+			Code:   transaction.Success,
+			Result: []byte("secret"),
+		})
+	})
+	t.Run("IPFS", func(t *testing.T) {
+		checkResp(t, 12, &transaction.OracleResponse{
+			ID:     12,
+			Code:   transaction.Success,
+			Result: []byte{5, 6, 7},
+		})
+	})
+	t.Run("BigWithFilter", func(t *testing.T) {
+		checkResp(t, 13, &transaction.OracleResponse{
+			ID:     13,
+			Code:   transaction.Success,
+			Result: []byte(`["a"]`),
+		})
+	})
+	t.Run("Huge", func(t *testing.T) {
+		checkResp(t, 14, &transaction.OracleResponse{
+			ID:   14,
+			Code: transaction.ResponseTooLarge,
+		})
+	})
 }
 
 func TestOracleFull(t *testing.T) {
@@ -323,16 +361,22 @@ type (
 	testResponse struct {
 		code int
 		body []byte
+		// header is the name of request header returned as a body if set.
+		header string
 	}
 )
 
-// Get implements oracle.HTTPClient interface.
-func (c *httpClient) Get(url string) (*http.Response, error) {
-	resp, ok := c.responses[url]
+// Do implements oracle.HTTPClient interface.
+func (c *httpClient) Do(req *http.Request) (*http.Response, error) {
+	resp, ok := c.responses[req.URL.String()]
 	if ok {
+		body := resp.body
+		if resp.header != "" {
+			body = []byte(req.Header.Get(resp.header))
+		}
 		return &http.Response{
 			StatusCode: resp.code,
-			Body:       newResponseBody(resp.body),
+			Body:       newResponseBody(body),
 		}, nil
 	}
 	return nil, errors.New("error during request")
@@ -381,6 +425,22 @@ func newDefaultHTTPClient() oracle.HTTPClient {
 				code: http.StatusOK,
 				body: []byte{0xFF},
 			},
+			"https://get.header": {
+				code:   http.StatusOK,
+				header: "X-Api-Key",
+			},
+			"https://ipfs.gateway/ipfs/QmCID/file": {
+				code: http.StatusOK,
+				body: []byte{5, 6, 7},
+			},
+			"https://get.bigfilter": {
+				code: http.StatusOK,
+				body: []byte(`{"Values":["a"],"Pad":"` + strings.Repeat("x", transaction.MaxOracleResultSize) + `"}`),
+			},
+			"https://get.huge": {
+				code: http.StatusOK,
+				body: make([]byte, 2*transaction.MaxOracleResultSize+1),
+			},
 		},
 	}
 }
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -30,6 +31,40 @@ var (
 			Namespace: "neogo",
 		},
 	)
+	//contractStorageItems prometheus metric.
+	contractStorageItems = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Help:      "Number of storage items per contract",
+			Name:      "contract_storage_items",
+			Namespace: "neogo",
+		},
+		[]string{"contract"},
+	)
+	//contractStorageBytes prometheus metric.
+	contractStorageBytes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Help:      "Size of storage items (keys and values) per contract in bytes",
+			Name:      "contract_storage_bytes",
+			Namespace: "neogo",
+		},
+		[]string{"contract"},
+	)
+	//witnessCacheHits prometheus metric.
+	witnessCacheHits = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Help:      "Number of in-block transactions with witnesses verified on mempool admission",
+			Name:      "witness_cache_hits",
+			Namespace: "neogo",
+		},
+	)
+	//witnessCacheMisses prometheus metric.
+	witnessCacheMisses = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Help:      "Number of in-block transactions that had to be verified again",
+			Name:      "witness_cache_misses",
+			Namespace: "neogo",
+		},
+	)
 )
 
 func init() {
@@ -37,6 +72,10 @@ func init() {
 		blockHeight,
 		persistedHeight,
 		headerHeight,
+		contractStorageItems,
+		contractStorageBytes,
+		witnessCacheHits,
+		witnessCacheMisses,
 	)
 }
 
@@ -51,3 +90,13 @@ func updateHeaderHeightMetric(hHeight int) {
 func updateBlockHeightMetric(bHeight uint32) {
 	blockHeight.Set(float64(bHeight))
 }
+
+func updateContractStorageMetric(h util.Uint160, items, size int64) {
+	contractStorageItems.WithLabelValues(h.StringLE()).Set(float64(items))
+	contractStorageBytes.WithLabelValues(h.StringLE()).Set(float64(size))
+}
+
+func deleteContractStorageMetric(h util.Uint160) {
+	contractStorageItems.DeleteLabelValues(h.StringLE())
+	contractStorageBytes.DeleteLabelValues(h.StringLE())
+}
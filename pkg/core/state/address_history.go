@@ -0,0 +1,64 @@
+package state
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// AddressTxLogBatchSize is the maximum number of entries for AddressTxLog.
+const AddressTxLogBatchSize = 128
+
+// AddressTxLog is a log of transactions signed by the specific account.
+type AddressTxLog struct {
+	Raw []byte
+}
+
+// AddressTransaction is a single transaction stored in the account
+// transaction log.
+type AddressTransaction struct {
+	// Tx is the hash of the transaction.
+	Tx util.Uint256
+	// Block is the index of the block transaction is included in.
+	Block uint32
+	// Timestamp is the timestamp of the block.
+	Timestamp uint64
+	// Sender is true if the account is the sender (the first signer) of the
+	// transaction.
+	Sender bool
+}
+
+// EncodeBinary implements io.Serializable interface.
+func (t *AddressTransaction) EncodeBinary(w *io.BinWriter) {
+	w.WriteBytes(t.Tx[:])
+	w.WriteU32LE(t.Block)
+	w.WriteU64LE(t.Timestamp)
+	w.WriteBool(t.Sender)
+}
+
+// DecodeBinary implements io.Serializable interface.
+func (t *AddressTransaction) DecodeBinary(r *io.BinReader) {
+	r.ReadBytes(t.Tx[:])
+	t.Block = r.ReadU32LE()
+	t.Timestamp = r.ReadU64LE()
+	t.Sender = r.ReadBool()
+}
+
+// Append appends single transaction to a log.
+func (lg *AddressTxLog) Append(e *AddressTransaction) error {
+	return (*BatchedLog)(lg).Append(e)
+}
+
+// ForEach iterates over transaction log from the newest entry to the oldest
+// one returning on first error.
+func (lg *AddressTxLog) ForEach(f func(*AddressTransaction) (bool, error)) (bool, error) {
+	return (*BatchedLog)(lg).ForEach(func() io.Serializable {
+		return new(AddressTransaction)
+	}, func(e io.Serializable) (bool, error) {
+		return f(e.(*AddressTransaction))
+	})
+}
+
+// Size returns an amount of transactions written in log.
+func (lg *AddressTxLog) Size() int {
+	return (*BatchedLog)(lg).Size()
+}
@@ -0,0 +1,47 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/internal/random"
+	"github.com/nspcc-dev/neo-go/internal/testserdes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddressTxLog_Append(t *testing.T) {
+	expected := make([]*AddressTransaction, 4)
+	for i := range expected {
+		expected[i] = &AddressTransaction{
+			Tx:        random.Uint256(),
+			Block:     uint32(i),
+			Timestamp: uint64(i * 15000),
+			Sender:    i%2 == 0,
+		}
+	}
+
+	lg := new(AddressTxLog)
+	for _, tx := range expected {
+		require.NoError(t, lg.Append(tx))
+	}
+	require.Equal(t, len(expected), lg.Size())
+
+	i := len(expected) - 1
+	cont, err := lg.ForEach(func(tx *AddressTransaction) (bool, error) {
+		require.Equal(t, expected[i], tx)
+		i--
+		return true, nil
+	})
+	require.NoError(t, err)
+	require.True(t, cont)
+	require.Equal(t, -1, i)
+}
+
+func TestAddressTransaction_EncodeBinary(t *testing.T) {
+	expected := &AddressTransaction{
+		Tx:        random.Uint256(),
+		Block:     12345,
+		Timestamp: 54321,
+		Sender:    true,
+	}
+	testserdes.EncodeDecodeBinary(t, expected, new(AddressTransaction))
+}
@@ -0,0 +1,87 @@
+package state
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/io"
+)
+
+// BatchedLog is a serialized batch of log entries (transfers, notifications
+// or transactions), the first byte of it is the number of entries. All
+// specific logs (like NEP17TransferLog) have the same layout and can be
+// converted to BatchedLog.
+type BatchedLog struct {
+	Raw []byte
+}
+
+// BatchedLogInfo contains info about a set of batches of a single log.
+type BatchedLogInfo struct {
+	// NextBatch stores an index of the next batch.
+	NextBatch uint32
+	// NewBatch is true if batch with the `NextBatch` index should be created.
+	NewBatch bool
+}
+
+// EncodeBinary implements io.Serializable interface.
+func (i *BatchedLogInfo) EncodeBinary(w *io.BinWriter) {
+	w.WriteU32LE(i.NextBatch)
+	w.WriteBool(i.NewBatch)
+}
+
+// DecodeBinary implements io.Serializable interface.
+func (i *BatchedLogInfo) DecodeBinary(r *io.BinReader) {
+	i.NextBatch = r.ReadU32LE()
+	i.NewBatch = r.ReadBool()
+}
+
+// Append appends single entry to a log.
+func (lg *BatchedLog) Append(e io.Serializable) error {
+	w := io.NewBufBinWriter()
+	// The first entry, set up counter.
+	if len(lg.Raw) == 0 {
+		w.WriteB(1)
+	}
+	e.EncodeBinary(w.BinWriter)
+	if w.Err != nil {
+		return w.Err
+	}
+	if len(lg.Raw) != 0 {
+		lg.Raw[0]++
+	}
+	lg.Raw = append(lg.Raw, w.Bytes()...)
+	return nil
+}
+
+// ForEach iterates over log entries from the newest to the oldest one
+// returning on first error. Entries are decoded into values returned by
+// newEntry.
+func (lg *BatchedLog) ForEach(newEntry func() io.Serializable, f func(io.Serializable) (bool, error)) (bool, error) {
+	if lg == nil || len(lg.Raw) == 0 {
+		return true, nil
+	}
+	entries := make([]io.Serializable, lg.Size())
+	r := io.NewBinReaderFromBuf(lg.Raw[1:])
+	for i := range entries {
+		entries[i] = newEntry()
+		entries[i].DecodeBinary(r)
+	}
+	if r.Err != nil {
+		return false, r.Err
+	}
+	for i := len(entries) - 1; i >= 0; i-- {
+		cont, err := f(entries[i])
+		if err != nil {
+			return false, err
+		}
+		if !cont {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// Size returns an amount of entries written in log.
+func (lg *BatchedLog) Size() int {
+	if len(lg.Raw) == 0 {
+		return 0
+	}
+	return int(lg.Raw[0])
+}
@@ -0,0 +1,20 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/internal/testserdes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchedLogInfo_EncodeBinary(t *testing.T) {
+	testserdes.EncodeDecodeBinary(t, &BatchedLogInfo{NextBatch: 42, NewBatch: true}, new(BatchedLogInfo))
+}
+
+func TestBatchedLog_Empty(t *testing.T) {
+	var lg *BatchedLog
+	require.Equal(t, 0, new(BatchedLog).Size())
+	cont, err := lg.ForEach(nil, nil)
+	require.NoError(t, err)
+	require.True(t, cont)
+}
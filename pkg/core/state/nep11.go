@@ -0,0 +1,155 @@
+package state
+
+import (
+	"math/big"
+
+	"github.com/nspcc-dev/neo-go/pkg/encoding/bigint"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+)
+
+// NEP11TransferBatchSize is the maximum number of entries for NEP11TransferLog.
+const NEP11TransferBatchSize = 128
+
+// MaxNEP11TokenIDLen is the maximum length of NEP11 token ID.
+const MaxNEP11TokenIDLen = 64
+
+// NEP11Tracker contains info about a single token of the account in a NEP11
+// contract.
+type NEP11Tracker struct {
+	// Balance is the current balance of the token, it's 1 for non-divisible
+	// tokens.
+	Balance big.Int
+	// LastUpdatedBlock is a number of block when last `transfer` of this
+	// token to or from the account occurred.
+	LastUpdatedBlock uint32
+}
+
+// NEP11TransferLog is a log of NEP11 token transfers for the specific command.
+type NEP11TransferLog struct {
+	Raw []byte
+}
+
+// NEP11Transfer represents a single NEP11 Transfer event.
+type NEP11Transfer struct {
+	NEP17Transfer
+	// ID is the token ID.
+	ID []byte
+}
+
+// NEP11Balances is a map of the NEP11 contract IDs to the sets of tokens
+// owned by account (and their balances).
+type NEP11Balances struct {
+	Trackers map[int32]map[string]NEP11Tracker
+	// NextTransferBatch stores an index of the next transfer batch.
+	NextTransferBatch uint32
+	// NewBatch is true if batch with the `NextTransferBatch` index should be created.
+	NewBatch bool
+}
+
+// NewNEP11Balances returns new NEP11Balances.
+func NewNEP11Balances() *NEP11Balances {
+	return &NEP11Balances{
+		Trackers: make(map[int32]map[string]NEP11Tracker),
+	}
+}
+
+// DecodeBinary implements io.Serializable interface.
+func (bs *NEP11Balances) DecodeBinary(r *io.BinReader) {
+	bs.NextTransferBatch = r.ReadU32LE()
+	bs.NewBatch = r.ReadBool()
+	lenBalances := r.ReadVarUint()
+	m := make(map[int32]map[string]NEP11Tracker, lenBalances)
+	for i := 0; i < int(lenBalances); i++ {
+		key := int32(r.ReadU32LE())
+		lenTokens := r.ReadVarUint()
+		tokens := make(map[string]NEP11Tracker, lenTokens)
+		for j := 0; j < int(lenTokens); j++ {
+			id := r.ReadVarBytes(MaxNEP11TokenIDLen)
+			var tr NEP11Tracker
+			tr.DecodeBinary(r)
+			tokens[string(id)] = tr
+		}
+		m[key] = tokens
+	}
+	bs.Trackers = m
+}
+
+// EncodeBinary implements io.Serializable interface.
+func (bs *NEP11Balances) EncodeBinary(w *io.BinWriter) {
+	w.WriteU32LE(bs.NextTransferBatch)
+	w.WriteBool(bs.NewBatch)
+	w.WriteVarUint(uint64(len(bs.Trackers)))
+	for k, tokens := range bs.Trackers {
+		w.WriteU32LE(uint32(k))
+		w.WriteVarUint(uint64(len(tokens)))
+		for id, v := range tokens {
+			w.WriteVarBytes([]byte(id))
+			v.EncodeBinary(w)
+		}
+	}
+}
+
+// Append appends single transfer to a log.
+func (lg *NEP11TransferLog) Append(tr *NEP11Transfer) error {
+	return (*BatchedLog)(lg).Append(tr)
+}
+
+// ForEach iterates over transfer log from the newest entry to the oldest
+// one returning on first error.
+func (lg *NEP11TransferLog) ForEach(f func(*NEP11Transfer) (bool, error)) (bool, error) {
+	return (*BatchedLog)(lg).ForEach(func() io.Serializable {
+		return new(NEP11Transfer)
+	}, func(e io.Serializable) (bool, error) {
+		return f(e.(*NEP11Transfer))
+	})
+}
+
+// Size returns an amount of transfers written in log.
+func (lg *NEP11TransferLog) Size() int {
+	return (*BatchedLog)(lg).Size()
+}
+
+// EncodeBinary implements io.Serializable interface.
+func (t *NEP11Tracker) EncodeBinary(w *io.BinWriter) {
+	w.WriteVarBytes(bigint.ToBytes(&t.Balance))
+	w.WriteU32LE(t.LastUpdatedBlock)
+}
+
+// DecodeBinary implements io.Serializable interface.
+func (t *NEP11Tracker) DecodeBinary(r *io.BinReader) {
+	t.Balance = *bigint.FromBytes(r.ReadVarBytes(bigint.MaxBytesLen))
+	t.LastUpdatedBlock = r.ReadU32LE()
+}
+
+// EncodeBinary implements io.Serializable interface.
+func (t *NEP11Transfer) EncodeBinary(w *io.BinWriter) {
+	t.NEP17Transfer.EncodeBinary(w)
+	w.WriteVarBytes(t.ID)
+}
+
+// DecodeBinary implements io.Serializable interface.
+func (t *NEP11Transfer) DecodeBinary(r *io.BinReader) {
+	t.NEP17Transfer.DecodeBinary(r)
+	t.ID = r.ReadVarBytes(MaxNEP11TokenIDLen)
+}
+
+// Add adds amount (which can be negative) to the balance of the given token
+// updating its last updated block. Tokens with zero balance are removed.
+func (bs *NEP11Balances) Add(asset int32, id []byte, amount *big.Int, index uint32) {
+	tokens := bs.Trackers[asset]
+	if tokens == nil {
+		tokens = make(map[string]NEP11Tracker)
+		bs.Trackers[asset] = tokens
+	}
+	tr := tokens[string(id)]
+	tr.Balance = *new(big.Int).Add(&tr.Balance, amount)
+	tr.LastUpdatedBlock = index
+	if tr.Balance.Sign() == 0 {
+		delete(tokens, string(id))
+		if len(tokens) == 0 {
+			delete(bs.Trackers, asset)
+		}
+		return
+	}
+	tokens[string(id)] = tr
+}
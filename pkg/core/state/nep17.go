@@ -89,53 +89,21 @@ func (bs *NEP17Balances) EncodeBinary(w *io.BinWriter) {
 
 // Append appends single transfer to a log.
 func (lg *NEP17TransferLog) Append(tr *NEP17Transfer) error {
-	w := io.NewBufBinWriter()
-	// The first entry, set up counter.
-	if len(lg.Raw) == 0 {
-		w.WriteB(1)
-	}
-	tr.EncodeBinary(w.BinWriter)
-	if w.Err != nil {
-		return w.Err
-	}
-	if len(lg.Raw) != 0 {
-		lg.Raw[0]++
-	}
-	lg.Raw = append(lg.Raw, w.Bytes()...)
-	return nil
+	return (*BatchedLog)(lg).Append(tr)
 }
 
 // ForEach iterates over transfer log returning on first error.
 func (lg *NEP17TransferLog) ForEach(f func(*NEP17Transfer) (bool, error)) (bool, error) {
-	if lg == nil || len(lg.Raw) == 0 {
-		return true, nil
-	}
-	transfers := make([]NEP17Transfer, lg.Size())
-	r := io.NewBinReaderFromBuf(lg.Raw[1:])
-	for i := 0; i < lg.Size(); i++ {
-		transfers[i].DecodeBinary(r)
-	}
-	if r.Err != nil {
-		return false, r.Err
-	}
-	for i := len(transfers) - 1; i >= 0; i-- {
-		cont, err := f(&transfers[i])
-		if err != nil {
-			return false, err
-		}
-		if !cont {
-			return false, nil
-		}
-	}
-	return true, nil
+	return (*BatchedLog)(lg).ForEach(func() io.Serializable {
+		return new(NEP17Transfer)
+	}, func(e io.Serializable) (bool, error) {
+		return f(e.(*NEP17Transfer))
+	})
 }
 
 // Size returns an amount of transfer written in log.
 func (lg *NEP17TransferLog) Size() int {
-	if len(lg.Raw) == 0 {
-		return 0
-	}
-	return int(lg.Raw[0])
+	return (*BatchedLog)(lg).Size()
 }
 
 // EncodeBinary implements io.Serializable interface.
@@ -0,0 +1,89 @@
+package state
+
+import (
+	"errors"
+
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+// NotificationLogBatchSize is the maximum number of entries for NotificationLog.
+const NotificationLogBatchSize = 128
+
+// NotificationLog is a log of notifications emitted by the specific contract.
+type NotificationLog struct {
+	Raw []byte
+}
+
+// IndexedNotification is a single notification stored in the contract
+// notification log.
+type IndexedNotification struct {
+	// Block is the index of the block notification was emitted in.
+	Block uint32
+	// Container is the hash of the transaction (or block for
+	// OnPersist/PostPersist triggers) notification was emitted by.
+	Container util.Uint256
+	Name      string
+	Item      *stackitem.Array
+}
+
+// EncodeBinary implements io.Serializable interface.
+func (n *IndexedNotification) EncodeBinary(w *io.BinWriter) {
+	w.WriteU32LE(n.Block)
+	w.WriteBytes(n.Container[:])
+	w.WriteString(n.Name)
+	stackitem.EncodeBinaryStackItem(n.Item, w)
+}
+
+// DecodeBinary implements io.Serializable interface.
+func (n *IndexedNotification) DecodeBinary(r *io.BinReader) {
+	n.Block = r.ReadU32LE()
+	r.ReadBytes(n.Container[:])
+	n.Name = r.ReadString()
+	item := stackitem.DecodeBinaryStackItem(r)
+	if r.Err != nil {
+		return
+	}
+	arr, ok := item.Value().([]stackitem.Item)
+	if !ok {
+		r.Err = errors.New("Array or Struct expected")
+		return
+	}
+	n.Item = stackitem.NewArray(arr)
+}
+
+// Append appends single notification to a log.
+func (lg *NotificationLog) Append(e *IndexedNotification) error {
+	return (*BatchedLog)(lg).Append(e)
+}
+
+// ForEach iterates over notification log from the newest entry to the oldest
+// one returning on first error.
+func (lg *NotificationLog) ForEach(f func(*IndexedNotification) (bool, error)) (bool, error) {
+	return (*BatchedLog)(lg).ForEach(func() io.Serializable {
+		return new(IndexedNotification)
+	}, func(e io.Serializable) (bool, error) {
+		return f(e.(*IndexedNotification))
+	})
+}
+
+// First returns the oldest notification of the log, it's nil if the log is
+// empty.
+func (lg *NotificationLog) First() (*IndexedNotification, error) {
+	if len(lg.Raw) == 0 {
+		return nil, nil
+	}
+	n := new(IndexedNotification)
+	r := io.NewBinReaderFromBuf(lg.Raw[1:])
+	n.DecodeBinary(r)
+	if r.Err != nil {
+		return nil, r.Err
+	}
+	return n, nil
+}
+
+// Size returns an amount of notifications written in log.
+func (lg *NotificationLog) Size() int {
+	return (*BatchedLog)(lg).Size()
+}
@@ -0,0 +1,70 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/internal/random"
+	"github.com/nspcc-dev/neo-go/internal/testserdes"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationLog_Append(t *testing.T) {
+	expected := make([]*IndexedNotification, 4)
+	for i := range expected {
+		expected[i] = &IndexedNotification{
+			Block:     uint32(i),
+			Container: random.Uint256(),
+			Name:      "Event",
+			Item:      stackitem.NewArray([]stackitem.Item{stackitem.NewBool(true)}),
+		}
+	}
+
+	lg := new(NotificationLog)
+	first, err := lg.First()
+	require.NoError(t, err)
+	require.Nil(t, first)
+	for _, n := range expected {
+		require.NoError(t, lg.Append(n))
+	}
+	require.Equal(t, len(expected), lg.Size())
+	first, err = lg.First()
+	require.NoError(t, err)
+	require.Equal(t, expected[0], first)
+
+	i := len(expected) - 1
+	cont, err := lg.ForEach(func(n *IndexedNotification) (bool, error) {
+		require.Equal(t, expected[i], n)
+		i--
+		return true, nil
+	})
+	require.NoError(t, err)
+	require.True(t, cont)
+	require.Equal(t, -1, i)
+
+	cont, err = lg.ForEach(func(n *IndexedNotification) (bool, error) {
+		return false, nil
+	})
+	require.NoError(t, err)
+	require.False(t, cont)
+}
+
+func TestIndexedNotification_EncodeBinary(t *testing.T) {
+	t.Run("good", func(t *testing.T) {
+		n := &IndexedNotification{
+			Block:     12345,
+			Container: random.Uint256(),
+			Name:      "Transfer",
+			Item:      stackitem.NewArray([]stackitem.Item{stackitem.NewByteArray([]byte{1, 2, 3})}),
+		}
+		testserdes.EncodeDecodeBinary(t, n, new(IndexedNotification))
+	})
+	t.Run("not an array", func(t *testing.T) {
+		n := &IndexedNotification{Name: "Event", Item: stackitem.NewArray(nil)}
+		data, err := testserdes.EncodeBinary(n)
+		require.NoError(t, err)
+		// Replace Array item type with Boolean.
+		data[len(data)-2] = byte(stackitem.BooleanT)
+		require.Error(t, testserdes.DecodeBinary(data, new(IndexedNotification)))
+	})
+}
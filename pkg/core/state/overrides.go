@@ -0,0 +1,34 @@
+package state
+
+import (
+	"math/big"
+
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// Overrides are the chain state changes applied before test invocation, they
+// allow to simulate transactions in a state that differs from the current
+// one without deploying or sending anything.
+type Overrides struct {
+	Storage  []StorageOverride `json:"storage"`
+	Balances []BalanceOverride `json:"balances"`
+	// Witnesses are the accounts witness checks succeed for in any context,
+	// they're added to transaction signers with Global scope.
+	Witnesses []util.Uint160 `json:"witnesses"`
+}
+
+// StorageOverride is a contract storage item to put, nil value deletes the
+// item.
+type StorageOverride struct {
+	Contract util.Uint160 `json:"contract"`
+	Key      []byte       `json:"key"`
+	Value    []byte       `json:"value"`
+}
+
+// BalanceOverride is the balance of account in native NEP-17 token (NEO or
+// GAS), token total supply is adjusted accordingly.
+type BalanceOverride struct {
+	Token   util.Uint160 `json:"token"`
+	Account util.Uint160 `json:"account"`
+	Amount  *big.Int     `json:"amount"`
+}
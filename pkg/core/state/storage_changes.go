@@ -0,0 +1,71 @@
+package state
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// Storage change states.
+const (
+	StorageAdded   = "Added"
+	StorageChanged = "Changed"
+	StorageDeleted = "Deleted"
+)
+
+// StorageChange is a single contract storage item change made by a block.
+// Key contains contract ID (4 bytes LE) followed by the item key, the same
+// way it's done for MPT keys.
+type StorageChange struct {
+	State string `json:"state"`
+	Key   []byte `json:"key"`
+	Value []byte `json:"value,omitempty"`
+}
+
+// StorageChanges is a set of contract storage changes made by a block. Its
+// JSON representation is compatible with the storage dumps format of
+// https://github.com/NeoResearch/neo-storage-audit/.
+type StorageChanges struct {
+	Block   uint32          `json:"block"`
+	Size    int             `json:"size"`
+	Storage []StorageChange `json:"storage"`
+}
+
+// StorageDiff is a contract storage item change made by a test invocation.
+// Old value is missing for items added and new value is missing for items
+// deleted.
+type StorageDiff struct {
+	Contract util.Uint160 `json:"contract"`
+	Key      []byte       `json:"key"`
+	Old      []byte       `json:"oldvalue,omitempty"`
+	New      []byte       `json:"newvalue,omitempty"`
+}
+
+// EncodeBinary implements io.Serializable interface.
+func (c *StorageChange) EncodeBinary(w *io.BinWriter) {
+	w.WriteString(c.State)
+	w.WriteVarBytes(c.Key)
+	w.WriteVarBytes(c.Value)
+}
+
+// DecodeBinary implements io.Serializable interface.
+func (c *StorageChange) DecodeBinary(r *io.BinReader) {
+	c.State = r.ReadString()
+	c.Key = r.ReadVarBytes()
+	c.Value = r.ReadVarBytes()
+	if len(c.Value) == 0 {
+		c.Value = nil
+	}
+}
+
+// EncodeBinary implements io.Serializable interface.
+func (cs *StorageChanges) EncodeBinary(w *io.BinWriter) {
+	w.WriteU32LE(cs.Block)
+	w.WriteArray(cs.Storage)
+}
+
+// DecodeBinary implements io.Serializable interface.
+func (cs *StorageChanges) DecodeBinary(r *io.BinReader) {
+	cs.Block = r.ReadU32LE()
+	r.ReadArray(&cs.Storage)
+	cs.Size = len(cs.Storage)
+}
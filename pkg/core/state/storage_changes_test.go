@@ -0,0 +1,30 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/internal/testserdes"
+)
+
+func TestStorageChanges_EncodeDecodeBinary(t *testing.T) {
+	cs := &StorageChanges{
+		Block: 42,
+		Size:  2,
+		Storage: []StorageChange{
+			{State: StorageAdded, Key: []byte{1, 0, 0, 0, 1}, Value: []byte{2}},
+			{State: StorageDeleted, Key: []byte{1, 0, 0, 0, 2}},
+		},
+	}
+	testserdes.EncodeDecodeBinary(t, cs, new(StorageChanges))
+}
+
+func TestStorageChanges_MarshalUnmarshalJSON(t *testing.T) {
+	cs := &StorageChanges{
+		Block: 42,
+		Size:  1,
+		Storage: []StorageChange{
+			{State: StorageChanged, Key: []byte{1, 0, 0, 0, 1}, Value: []byte{2}},
+		},
+	}
+	testserdes.MarshalUnmarshalJSON(t, cs, new(StorageChanges))
+}
@@ -0,0 +1,77 @@
+package state
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+)
+
+// CallTrace is a single contract invocation made during transaction execution.
+// Notifications only include events emitted by the contract itself while
+// GasConsumed also includes GAS spent by nested calls.
+type CallTrace struct {
+	Contract      util.Uint160        `json:"contract"`
+	Method        string              `json:"method,omitempty"`
+	GasConsumed   int64               `json:"gasconsumed,string"`
+	Notifications []NotificationEvent `json:"notifications,omitempty"`
+	Calls         []CallTrace         `json:"calls,omitempty"`
+}
+
+// TransactionTrace is an execution trace of the transaction containing a tree
+// of contract calls starting from the transaction script and storage changes
+// made by it.
+type TransactionTrace struct {
+	Container      util.Uint256    `json:"txid"`
+	VMState        vm.State        `json:"vmstate"`
+	GasConsumed    int64           `json:"gasconsumed,string"`
+	FaultException string          `json:"exception,omitempty"`
+	Call           CallTrace       `json:"call"`
+	Storage        []StorageChange `json:"storage,omitempty"`
+}
+
+// EncodeBinary implements io.Serializable interface.
+func (c *CallTrace) EncodeBinary(w *io.BinWriter) {
+	c.Contract.EncodeBinary(w)
+	w.WriteString(c.Method)
+	w.WriteU64LE(uint64(c.GasConsumed))
+	w.WriteArray(c.Notifications)
+	w.WriteArray(c.Calls)
+}
+
+// DecodeBinary implements io.Serializable interface.
+func (c *CallTrace) DecodeBinary(r *io.BinReader) {
+	c.Contract.DecodeBinary(r)
+	c.Method = r.ReadString()
+	c.GasConsumed = int64(r.ReadU64LE())
+	r.ReadArray(&c.Notifications)
+	if len(c.Notifications) == 0 {
+		c.Notifications = nil
+	}
+	r.ReadArray(&c.Calls)
+	if len(c.Calls) == 0 {
+		c.Calls = nil
+	}
+}
+
+// EncodeBinary implements io.Serializable interface.
+func (t *TransactionTrace) EncodeBinary(w *io.BinWriter) {
+	t.Container.EncodeBinary(w)
+	w.WriteB(byte(t.VMState))
+	w.WriteU64LE(uint64(t.GasConsumed))
+	w.WriteString(t.FaultException)
+	t.Call.EncodeBinary(w)
+	w.WriteArray(t.Storage)
+}
+
+// DecodeBinary implements io.Serializable interface.
+func (t *TransactionTrace) DecodeBinary(r *io.BinReader) {
+	t.Container.DecodeBinary(r)
+	t.VMState = vm.State(r.ReadB())
+	t.GasConsumed = int64(r.ReadU64LE())
+	t.FaultException = r.ReadString()
+	t.Call.DecodeBinary(r)
+	r.ReadArray(&t.Storage)
+	if len(t.Storage) == 0 {
+		t.Storage = nil
+	}
+}
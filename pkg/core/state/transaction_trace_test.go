@@ -0,0 +1,59 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/internal/random"
+	"github.com/nspcc-dev/neo-go/internal/testserdes"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+func newTestTransactionTrace() *TransactionTrace {
+	return &TransactionTrace{
+		Container:   random.Uint256(),
+		VMState:     vm.HaltState,
+		GasConsumed: 300,
+		Call: CallTrace{
+			Contract:    random.Uint160(),
+			GasConsumed: 300,
+			Calls: []CallTrace{
+				{
+					Contract:    random.Uint160(),
+					Method:      "transfer",
+					GasConsumed: 200,
+					Notifications: []NotificationEvent{{
+						ScriptHash: random.Uint160(),
+						Name:       "Transfer",
+						Item:       stackitem.NewArray([]stackitem.Item{stackitem.NewBool(true)}),
+					}},
+				},
+				{
+					Contract:    random.Uint160(),
+					Method:      "balanceOf",
+					GasConsumed: 50,
+				},
+			},
+		},
+		Storage: []StorageChange{
+			{State: StorageChanged, Key: []byte{1, 0, 0, 0, 1}, Value: []byte{2}},
+		},
+	}
+}
+
+func TestTransactionTrace_EncodeDecodeBinary(t *testing.T) {
+	t.Run("halt", func(t *testing.T) {
+		testserdes.EncodeDecodeBinary(t, newTestTransactionTrace(), new(TransactionTrace))
+	})
+	t.Run("fault", func(t *testing.T) {
+		tr := newTestTransactionTrace()
+		tr.VMState = vm.FaultState
+		tr.FaultException = "at instruction 42 (THROW): unhandled exception"
+		tr.Storage = nil
+		testserdes.EncodeDecodeBinary(t, tr, new(TransactionTrace))
+	})
+}
+
+func TestTransactionTrace_MarshalUnmarshalJSON(t *testing.T) {
+	testserdes.MarshalUnmarshalJSON(t, newTestTransactionTrace(), new(TransactionTrace))
+}
@@ -0,0 +1,89 @@
+package stateroot
+
+import (
+	"encoding/binary"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// keepStaleNodes returns true if MPT nodes that are no longer referenced by
+// the latest state should be kept for StateRetentionBlocks.
+func (s *Module) keepStaleNodes() bool {
+	cfg := s.bc.GetConfig()
+	return cfg.KeepOnlyLatestState && cfg.StateRetentionBlocks != 0
+}
+
+// processStaleNodes records nodes that became stale at the given height and
+// removes nodes that have been stale for StateRetentionBlocks since then. Each
+// stale node has a mark with the height it became stale at, so nodes that
+// were referenced again and then became stale once more are only removed
+// after the retention period for their latest height ends.
+func (s *Module) processStaleNodes(store *storage.MemCachedStore, index uint32, stale []util.Uint256) error {
+	if len(stale) != 0 {
+		list := make([]byte, 0, len(stale)*util.Uint256Size)
+		mark := make([]byte, 4)
+		binary.LittleEndian.PutUint32(mark, index)
+		for _, h := range stale {
+			list = append(list, h.BytesBE()...)
+			if err := store.Put(makeStaleNodeKey(h.BytesBE()), mark); err != nil {
+				return err
+			}
+		}
+		if err := store.Put(makeStaleListKey(index), list); err != nil {
+			return err
+		}
+	}
+
+	retention := s.bc.GetConfig().StateRetentionBlocks
+	if index < retention {
+		return nil
+	}
+	height := index - retention
+	listKey := makeStaleListKey(height)
+	list, err := store.Get(listKey)
+	if err != nil {
+		if err == storage.ErrKeyNotFound {
+			return nil
+		}
+		return err
+	}
+	for i := 0; i+util.Uint256Size <= len(list); i += util.Uint256Size {
+		h := list[i : i+util.Uint256Size]
+		markKey := makeStaleNodeKey(h)
+		mark, err := store.Get(markKey)
+		if err != nil || binary.LittleEndian.Uint32(mark) != height {
+			// Node has become stale later again, it's in another list.
+			continue
+		}
+		if err := store.Delete(markKey); err != nil {
+			return err
+		}
+		nodeKey := append([]byte{byte(storage.DataMPT)}, h...)
+		data, err := store.Get(nodeKey)
+		if err != nil || binary.LittleEndian.Uint32(data[len(data)-4:]) != 0 {
+			// Node is referenced again.
+			continue
+		}
+		if err := store.Delete(nodeKey); err != nil {
+			return err
+		}
+	}
+	return store.Delete(listKey)
+}
+
+func makeStaleListKey(index uint32) []byte {
+	key := make([]byte, 6)
+	key[0] = byte(storage.DataMPT)
+	key[1] = prefixStaleList
+	binary.BigEndian.PutUint32(key[2:], index)
+	return key
+}
+
+func makeStaleNodeKey(h []byte) []byte {
+	key := make([]byte, 2+util.Uint256Size)
+	key[0] = byte(storage.DataMPT)
+	key[1] = prefixStaleNode
+	copy(key[2:], h)
+	return key
+}
@@ -56,10 +56,16 @@ func NewModule(bc blockchainer.Blockchainer, log *zap.Logger, s *storage.MemCach
 
 // GetStateProof returns proof of having key in the MPT with the specified root.
 func (s *Module) GetStateProof(root util.Uint256, key []byte) ([][]byte, error) {
-	tr := mpt.NewTrie(mpt.NewHashNode(root), false, storage.NewMemCachedStore(s.Store))
+	tr := mpt.NewTrie(mpt.NewHashNode(root), s.bc.GetConfig().KeepOnlyLatestState, storage.NewMemCachedStore(s.Store))
 	return tr.GetProof(key)
 }
 
+// GetState returns value stored by the key in the state with the given root.
+func (s *Module) GetState(root util.Uint256, key []byte) ([]byte, error) {
+	tr := mpt.NewTrie(mpt.NewHashNode(root), s.bc.GetConfig().KeepOnlyLatestState, storage.NewMemCachedStore(s.Store))
+	return tr.Get(key)
+}
+
 // GetStateRoot returns state root for a given height.
 func (s *Module) GetStateRoot(height uint32) (*state.MPTRoot, error) {
 	return s.getStateRoot(makeStateRootKey(height))
@@ -85,6 +91,7 @@ func (s *Module) Init(height uint32, enableRefCount bool) error {
 	var gcKey = []byte{byte(storage.DataMPT), prefixGC}
 	if height == 0 {
 		s.mpt = mpt.NewTrie(nil, enableRefCount, s.Store)
+		s.mpt.KeepStaleNodes(s.keepStaleNodes())
 		var val byte
 		if enableRefCount {
 			val = 1
@@ -106,6 +113,7 @@ func (s *Module) Init(height uint32, enableRefCount bool) error {
 	s.currentLocal.Store(r.Root)
 	s.localHeight.Store(r.Index)
 	s.mpt = mpt.NewTrie(mpt.NewHashNode(r.Root), enableRefCount, s.Store)
+	s.mpt.KeepStaleNodes(s.keepStaleNodes())
 	return nil
 }
 
@@ -117,6 +125,11 @@ func (s *Module) AddMPTBatch(index uint32, b mpt.Batch, cache *storage.MemCached
 		return nil, nil, err
 	}
 	mpt.Flush()
+	if s.keepStaleNodes() {
+		if err := s.processStaleNodes(cache, index, mpt.StaleNodes()); err != nil {
+			return nil, nil, err
+		}
+	}
 	sr := &state.MPTRoot{
 		Index: index,
 		Root:  mpt.StateRoot(),
@@ -11,8 +11,20 @@ var stateHeight = prometheus.NewGauge(
 	},
 )
 
+// stateMismatches prometheus metric.
+var stateMismatches = prometheus.NewCounter(
+	prometheus.CounterOpts{
+		Help:      "Number of local state roots not matching the ones signed by state validators",
+		Name:      "state_root_mismatches",
+		Namespace: "neogo",
+	},
+)
+
 func init() {
-	prometheus.MustRegister(stateHeight)
+	prometheus.MustRegister(
+		stateHeight,
+		stateMismatches,
+	)
 }
 
 func updateStateHeightMetric(sHeight uint32) {
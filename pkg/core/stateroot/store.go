@@ -20,6 +20,8 @@ const (
 	prefixGC        = 0x01
 	prefixLocal     = 0x02
 	prefixValidated = 0x03
+	prefixStaleList = 0x04
+	prefixStaleNode = 0x05
 )
 
 func (s *Module) addLocalStateRoot(store *storage.MemCachedStore, sr *state.MPTRoot) error {
@@ -69,6 +71,7 @@ func (s *Module) AddStateRoot(sr *state.MPTRoot) error {
 		return err
 	}
 	if !local.Root.Equals(sr.Root) {
+		stateMismatches.Inc()
 		return fmt.Errorf("%w at block %d: %v vs %v", ErrStateMismatch, sr.Index, local.Root, sr.Root)
 	}
 	if len(local.Witness) != 0 {
@@ -8,11 +8,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/nspcc-dev/neo-go/internal/random"
 	"github.com/nspcc-dev/neo-go/internal/testserdes"
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/noderoles"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	corestateroot "github.com/nspcc-dev/neo-go/pkg/core/stateroot"
 	"github.com/nspcc-dev/neo-go/pkg/core/storage"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
@@ -123,6 +125,19 @@ func TestStateRoot(t *testing.T) {
 		require.True(t, errors.Is(err, ErrWitnessHashMismatch), "got: %v", err)
 		require.EqualValues(t, 0, srv.CurrentValidatedHeight())
 	})
+	t.Run("mismatch", func(t *testing.T) {
+		var mismatchErr error
+		srv.SetOnMismatch(func(err error) { mismatchErr = err })
+		defer srv.SetOnMismatch(nil)
+
+		r, err := srv.GetStateRoot(updateIndex + 1)
+		require.NoError(t, err)
+		r.Root = random.Uint256()
+		data := testSignStateRoot(t, r, pubs, accs...)
+		require.NoError(t, srv.OnPayload(&payload.Extensible{Data: data}))
+		require.True(t, errors.Is(mismatchErr, corestateroot.ErrStateMismatch), "got: %v", mismatchErr)
+		require.EqualValues(t, 0, srv.CurrentValidatedHeight())
+	})
 
 	r, err = srv.GetStateRoot(updateIndex + 1)
 	require.NoError(t, err)
@@ -251,3 +266,33 @@ func checkVoteBroadcasted(t *testing.T, bc *Blockchain, p *payload.Extensible,
 	require.True(t, len(pubs) > int(valIndex))
 	require.True(t, pubs[valIndex].VerifyHashable(vote.Signature, uint32(netmode.UnitTestNet), r))
 }
+
+func TestStateRetention(t *testing.T) {
+	const retention = 2
+	bc := newTestChainWithCustomCfg(t, func(c *config.Config) {
+		c.ProtocolConfiguration.KeepOnlyLatestState = true
+		c.ProtocolConfiguration.StateRetentionBlocks = retention
+	})
+	_, err := bc.genBlocks(5)
+	require.NoError(t, err)
+
+	height := bc.BlockHeight()
+	for i := uint32(0); i <= height; i++ {
+		sr, err := bc.GetStateModule().GetStateRoot(i)
+		require.NoError(t, err)
+		_, err = bc.dao.Store.Get(append([]byte{byte(storage.DataMPT)}, sr.Root.BytesBE()...))
+		if i+retention >= height {
+			require.NoError(t, err, "root at %d should be kept", i)
+		} else {
+			require.Error(t, err, "root at %d should be removed", i)
+		}
+	}
+
+	t.Run("without KeepOnlyLatestState", func(t *testing.T) {
+		cfg, err := config.Load("../../config", netmode.UnitTestNet)
+		require.NoError(t, err)
+		cfg.ProtocolConfiguration.StateRetentionBlocks = retention
+		_, err = NewBlockchain(storage.NewMemoryStore(), cfg.ProtocolConfiguration, zaptest.NewLogger(t))
+		require.Error(t, err)
+	})
+}
@@ -2,8 +2,10 @@ package storage
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"os"
+	"sync"
 
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/syndtr/goleveldb/leveldb/util"
@@ -13,6 +15,9 @@ import (
 // BoltDBOptions configuration for boltdb.
 type BoltDBOptions struct {
 	FilePath string `yaml:"FilePath"`
+	// ReadOnly opens the database file with a shared lock and without
+	// creating it (or the root bucket), every write attempt fails then.
+	ReadOnly bool `yaml:"ReadOnly"`
 }
 
 // Bucket represents bucket used in boltdb to store all the data.
@@ -21,7 +26,9 @@ var Bucket = []byte("DB")
 // BoltDBStore it is the storage implementation for storing and retrieving
 // blockchain data.
 type BoltDBStore struct {
-	db *bbolt.DB
+	// mtx protects db from being replaced by Compact while in use.
+	mtx sync.RWMutex
+	db  *bbolt.DB
 }
 
 // NewBoltDBStore returns a new ready to use BoltDB storage with created bucket.
@@ -29,13 +36,28 @@ func NewBoltDBStore(cfg BoltDBOptions) (*BoltDBStore, error) {
 	var opts *bbolt.Options       // should be exposed via BoltDBOptions if anything needed
 	fileMode := os.FileMode(0600) // should be exposed via BoltDBOptions if anything needed
 	fileName := cfg.FilePath
-	if err := io.MakeDirForFile(fileName, "BoltDB"); err != nil {
+	if cfg.ReadOnly {
+		opts = &bbolt.Options{ReadOnly: true}
+	} else if err := io.MakeDirForFile(fileName, "BoltDB"); err != nil {
 		return nil, err
 	}
 	db, err := bbolt.Open(fileName, fileMode, opts)
 	if err != nil {
 		return nil, err
 	}
+	if cfg.ReadOnly {
+		err = db.View(func(tx *bbolt.Tx) error {
+			if tx.Bucket(Bucket) == nil {
+				return errors.New("root bucket is missing")
+			}
+			return nil
+		})
+		if err != nil {
+			_ = db.Close()
+			return nil, err
+		}
+		return &BoltDBStore{db: db}, nil
+	}
 	err = db.Update(func(tx *bbolt.Tx) error {
 		_, err = tx.CreateBucketIfNotExists(Bucket)
 		if err != nil {
@@ -49,6 +71,8 @@ func NewBoltDBStore(cfg BoltDBOptions) (*BoltDBStore, error) {
 
 // Put implements the Store interface.
 func (s *BoltDBStore) Put(key, value []byte) error {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
 	return s.db.Update(func(tx *bbolt.Tx) error {
 		b := tx.Bucket(Bucket)
 		err := b.Put(key, value)
@@ -58,6 +82,8 @@ func (s *BoltDBStore) Put(key, value []byte) error {
 
 // Get implements the Store interface.
 func (s *BoltDBStore) Get(key []byte) (val []byte, err error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
 	err = s.db.View(func(tx *bbolt.Tx) error {
 		b := tx.Bucket(Bucket)
 		val = b.Get(key)
@@ -77,6 +103,8 @@ func (s *BoltDBStore) Get(key []byte) (val []byte, err error) {
 
 // Delete implements the Store interface.
 func (s *BoltDBStore) Delete(key []byte) error {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
 	return s.db.Update(func(tx *bbolt.Tx) error {
 		b := tx.Bucket(Bucket)
 		return b.Delete(key)
@@ -85,6 +113,8 @@ func (s *BoltDBStore) Delete(key []byte) error {
 
 // PutBatch implements the Store interface.
 func (s *BoltDBStore) PutBatch(batch Batch) error {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
 	return s.db.Batch(func(tx *bbolt.Tx) error {
 		b := tx.Bucket(Bucket)
 		for k, v := range batch.(*MemoryBatch).mem {
@@ -105,6 +135,8 @@ func (s *BoltDBStore) PutBatch(batch Batch) error {
 
 // Seek implements the Store interface.
 func (s *BoltDBStore) Seek(key []byte, f func(k, v []byte)) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
 	err := s.db.View(func(tx *bbolt.Tx) error {
 		c := tx.Bucket(Bucket).Cursor()
 		prefix := util.BytesPrefix(key)
@@ -124,7 +156,106 @@ func (s *BoltDBStore) Batch() Batch {
 	return newMemoryBatch()
 }
 
+// Compact implements the Compactor interface. BoltDB can't reclaim space
+// in-place, so all data is copied to the new file that then replaces the
+// original one, every other operation is blocked until it's done.
+func (s *BoltDBStore) Compact() (int64, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.db.IsReadOnly() {
+		return 0, bbolt.ErrDatabaseReadOnly
+	}
+	fileName := s.db.Path()
+	before, err := diskUsage(fileName)
+	if err != nil {
+		return 0, err
+	}
+	tmpName := fileName + ".compact"
+	dst, err := bbolt.Open(tmpName, os.FileMode(0600), nil)
+	if err != nil {
+		return 0, err
+	}
+	err = copyBoltBucket(dst, s.db)
+	if cerr := dst.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		_ = os.Remove(tmpName)
+		return 0, fmt.Errorf("failed to copy data: %w", err)
+	}
+	if err = s.db.Close(); err != nil {
+		_ = os.Remove(tmpName)
+		return 0, s.reopen(fileName, fmt.Errorf("failed to close DB: %w", err))
+	}
+	if err = os.Rename(tmpName, fileName); err != nil {
+		_ = os.Remove(tmpName)
+		return 0, s.reopen(fileName, fmt.Errorf("failed to replace DB file: %w", err))
+	}
+	if err = s.reopen(fileName, nil); err != nil {
+		return 0, err
+	}
+	after, err := diskUsage(fileName)
+	if err != nil {
+		return 0, err
+	}
+	return before - after, nil
+}
+
+// reopen opens fileName as the store DB after Compact has closed it, so that
+// the store remains usable whatever happened during compaction. It returns
+// cause (if any) or reopening error.
+func (s *BoltDBStore) reopen(fileName string, cause error) error {
+	db, err := bbolt.Open(fileName, os.FileMode(0600), nil)
+	if err != nil {
+		if cause != nil {
+			return fmt.Errorf("%w (failed to reopen DB: %v)", cause, err)
+		}
+		return fmt.Errorf("failed to reopen DB: %w", err)
+	}
+	s.db = db
+	return cause
+}
+
+// copyBoltBucket copies data bucket from src to dst using a number of
+// transactions to keep memory usage low.
+func copyBoltBucket(dst, src *bbolt.DB) error {
+	const keysPerTx = 64 * 1024
+
+	err := dst.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(Bucket)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return src.View(func(stx *bbolt.Tx) error {
+		c := stx.Bucket(Bucket).Cursor()
+		k, v := c.First()
+		for k != nil {
+			err := dst.Update(func(tx *bbolt.Tx) error {
+				b := tx.Bucket(Bucket)
+				// Keys are added in order, so pages can be filled completely.
+				b.FillPercent = 1.0
+				for i := 0; k != nil && i < keysPerTx; i++ {
+					if err := b.Put(k, v); err != nil {
+						return err
+					}
+					k, v = c.Next()
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 // Close releases all db resources.
 func (s *BoltDBStore) Close() error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
 	return s.db.Close()
 }
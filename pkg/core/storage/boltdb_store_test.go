@@ -3,6 +3,7 @@ package storage
 import (
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -21,3 +22,26 @@ func newBoltStoreForTesting(t *testing.T) Store {
 	require.NoError(t, err)
 	return boltDBStore
 }
+
+func TestBoltDBReadOnly(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test_bolt_db")
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, os.RemoveAll(dir)) })
+	fileName := filepath.Join(dir, "db.bolt")
+
+	_, err = NewBoltDBStore(BoltDBOptions{FilePath: fileName, ReadOnly: true})
+	require.Error(t, err, "missing DB can't be opened in read-only mode")
+
+	s, err := NewBoltDBStore(BoltDBOptions{FilePath: fileName})
+	require.NoError(t, err)
+	require.NoError(t, s.Put([]byte{1}, []byte{2}))
+	require.NoError(t, s.Close())
+
+	ro, err := NewBoltDBStore(BoltDBOptions{FilePath: fileName, ReadOnly: true})
+	require.NoError(t, err)
+	v, err := ro.Get([]byte{1})
+	require.NoError(t, err)
+	require.Equal(t, []byte{2}, v)
+	require.Error(t, ro.Put([]byte{3}, []byte{4}))
+	require.NoError(t, ro.Close())
+}
@@ -0,0 +1,21 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// diskUsage returns the total size of the file or directory given.
+func diskUsage(path string) (int64, error) {
+	var size int64
+	err := filepath.Walk(path, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
@@ -10,6 +10,9 @@ import (
 // LevelDBOptions configuration for LevelDB.
 type LevelDBOptions struct {
 	DataDirectoryPath string `yaml:"DataDirectoryPath"`
+	// ReadOnly opens the database without write access, it must already
+	// exist and every write attempt fails with leveldb.ErrReadOnly.
+	ReadOnly bool `yaml:"ReadOnly"`
 }
 
 // LevelDBStore is the official storage implementation for storing and retrieving
@@ -25,6 +28,10 @@ func NewLevelDBStore(cfg LevelDBOptions) (*LevelDBStore, error) {
 	var opts = new(opt.Options) // should be exposed via LevelDBOptions if anything needed
 
 	opts.Filter = filter.NewBloomFilter(10)
+	if cfg.ReadOnly {
+		opts.ReadOnly = true
+		opts.ErrorIfMissing = true
+	}
 	db, err := leveldb.OpenFile(cfg.DataDirectoryPath, opts)
 	if err != nil {
 		return nil, err
@@ -76,6 +83,23 @@ func (s *LevelDBStore) Batch() Batch {
 	return new(leveldb.Batch)
 }
 
+// Compact implements the Compactor interface, it compacts the whole key range.
+func (s *LevelDBStore) Compact() (int64, error) {
+	before, err := diskUsage(s.path)
+	if err != nil {
+		return 0, err
+	}
+	err = s.db.CompactRange(util.Range{})
+	if err != nil {
+		return 0, err
+	}
+	after, err := diskUsage(s.path)
+	if err != nil {
+		return 0, err
+	}
+	return before - after, nil
+}
+
 // Close implements the Store interface.
 func (s *LevelDBStore) Close() error {
 	return s.db.Close()
@@ -39,3 +39,25 @@ func newLevelDBForTesting(t *testing.T) Store {
 	tldb := &tempLevelDB{LevelDBStore: *newLevelStore, dir: ldbDir}
 	return tldb
 }
+
+func TestLevelDBReadOnly(t *testing.T) {
+	ldbDir, err := ioutil.TempDir(os.TempDir(), "testleveldb")
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, os.RemoveAll(ldbDir)) })
+
+	_, err = NewLevelDBStore(LevelDBOptions{DataDirectoryPath: ldbDir, ReadOnly: true})
+	require.Error(t, err, "missing DB can't be opened in read-only mode")
+
+	s, err := NewLevelDBStore(LevelDBOptions{DataDirectoryPath: ldbDir})
+	require.NoError(t, err)
+	require.NoError(t, s.Put([]byte{1}, []byte{2}))
+	require.NoError(t, s.Close())
+
+	ro, err := NewLevelDBStore(LevelDBOptions{DataDirectoryPath: ldbDir, ReadOnly: true})
+	require.NoError(t, err)
+	v, err := ro.Get([]byte{1})
+	require.NoError(t, err)
+	require.Equal(t, []byte{2}, v)
+	require.Error(t, ro.Put([]byte{3}, []byte{4}))
+	require.NoError(t, ro.Close())
+}
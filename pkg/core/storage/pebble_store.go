@@ -0,0 +1,108 @@
+package storage
+
+import (
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/bloom"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// PebbleDBOptions configuration for PebbleDB.
+type PebbleDBOptions struct {
+	DataDirectoryPath string `yaml:"DataDirectoryPath"`
+}
+
+// PebbleDBStore is an implementation of Store interface backed by
+// github.com/cockroachdb/pebble.
+type PebbleDBStore struct {
+	db *pebble.DB
+}
+
+// PebbleDBBatch is a wrapper around pebble.Batch compatible with Batch
+// interface.
+type PebbleDBBatch struct {
+	batch *pebble.Batch
+}
+
+// Put implements the Batch interface.
+func (b *PebbleDBBatch) Put(key, value []byte) {
+	// pebble.Batch copies key and value, so it's safe to reuse them.
+	_ = b.batch.Set(key, value, nil)
+}
+
+// Delete implements the Batch interface.
+func (b *PebbleDBBatch) Delete(key []byte) {
+	_ = b.batch.Delete(key, nil)
+}
+
+// NewPebbleDBStore returns a new PebbleDBStore object that will
+// initialize the database found at the given path.
+func NewPebbleDBStore(cfg PebbleDBOptions) (*PebbleDBStore, error) {
+	var opts = &pebble.Options{ // should be exposed via PebbleDBOptions if anything needed
+		Levels: []pebble.LevelOptions{{FilterPolicy: bloom.FilterPolicy(10)}},
+	}
+
+	db, err := pebble.Open(cfg.DataDirectoryPath, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PebbleDBStore{
+		db: db,
+	}, nil
+}
+
+// Put implements the Store interface.
+func (s *PebbleDBStore) Put(key, value []byte) error {
+	return s.db.Set(key, value, pebble.NoSync)
+}
+
+// Get implements the Store interface.
+func (s *PebbleDBStore) Get(key []byte) ([]byte, error) {
+	value, closer, err := s.db.Get(key)
+	if err == pebble.ErrNotFound {
+		return nil, ErrKeyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	// Value is only valid until closer is closed.
+	res := make([]byte, len(value))
+	copy(res, value)
+	return res, closer.Close()
+}
+
+// Delete implements the Store interface.
+func (s *PebbleDBStore) Delete(key []byte) error {
+	return s.db.Delete(key, pebble.NoSync)
+}
+
+// PutBatch implements the Store interface.
+func (s *PebbleDBStore) PutBatch(batch Batch) error {
+	pebbleBatch := batch.(*PebbleDBBatch)
+	defer pebbleBatch.batch.Close()
+	return pebbleBatch.batch.Commit(pebble.NoSync)
+}
+
+// Seek implements the Store interface.
+func (s *PebbleDBStore) Seek(key []byte, f func(k, v []byte)) {
+	rng := util.BytesPrefix(key)
+	iter := s.db.NewIter(&pebble.IterOptions{
+		LowerBound: rng.Start,
+		UpperBound: rng.Limit,
+	})
+	for iter.First(); iter.Valid(); iter.Next() {
+		f(iter.Key(), iter.Value())
+	}
+	_ = iter.Close()
+}
+
+// Batch implements the Batch interface and returns a pebble
+// compatible Batch.
+func (s *PebbleDBStore) Batch() Batch {
+	return &PebbleDBBatch{batch: s.db.NewBatch()}
+}
+
+// Close implements the Store interface.
+func (s *PebbleDBStore) Close() error {
+	return s.db.Close()
+}
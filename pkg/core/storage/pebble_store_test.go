@@ -0,0 +1,44 @@
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type tempPebbleDB struct {
+	*PebbleDBStore
+	dir string
+}
+
+func (tpdb *tempPebbleDB) Close() error {
+	err := tpdb.PebbleDBStore.Close()
+	// Make test fail if failed to cleanup, even though technically it's
+	// not a PebbleDBStore problem.
+	osErr := os.RemoveAll(tpdb.dir)
+	if osErr != nil {
+		return osErr
+	}
+	return err
+}
+
+func newPebbleDBForTesting(t *testing.T) Store {
+	pdbDir, err := ioutil.TempDir(os.TempDir(), "testpebbledb")
+	require.Nil(t, err, "failed to setup temporary directory")
+
+	dbConfig := DBConfiguration{
+		Type: "pebble",
+		PebbleDBOptions: PebbleDBOptions{
+			DataDirectoryPath: pdbDir,
+		},
+	}
+	newPebbleStore, err := NewPebbleDBStore(dbConfig.PebbleDBOptions)
+	require.Nil(t, err, "NewPebbleDBStore error")
+	tpdb := &tempPebbleDB{
+		PebbleDBStore: newPebbleStore,
+		dir:           pdbDir,
+	}
+	return tpdb
+}
@@ -11,15 +11,23 @@ const (
 	DataBlock        KeyPrefix = 0x01
 	DataTransaction  KeyPrefix = 0x02
 	DataMPT          KeyPrefix = 0x03
+	DataStorageDiff  KeyPrefix = 0x04
+	DataExecTrace    KeyPrefix = 0x05
 	STAccount        KeyPrefix = 0x40
 	STNotification   KeyPrefix = 0x4d
 	STContractID     KeyPrefix = 0x51
 	STStorage        KeyPrefix = 0x70
 	STNEP17Transfers KeyPrefix = 0x72
 	STNEP17Balances  KeyPrefix = 0x73
+	STNEP11Transfers KeyPrefix = 0x74
+	STNEP11Balances  KeyPrefix = 0x75
 	IXHeaderHashList KeyPrefix = 0x80
+	IXNotifications  KeyPrefix = 0x81
+	IXAddressTxs     KeyPrefix = 0x82
 	SYSCurrentBlock  KeyPrefix = 0xc0
 	SYSCurrentHeader KeyPrefix = 0xc1
+	SYSKnownPeers    KeyPrefix = 0xc2
+	SYSForkHeight    KeyPrefix = 0xc3
 	SYSVersion       KeyPrefix = 0xf0
 )
 
@@ -50,6 +58,14 @@ type (
 		Close() error
 	}
 
+	// Compactor is implemented by Store backends that are able to reclaim
+	// disk space occupied by deleted data. Compact can be called while the
+	// Store is in use, it returns the number of bytes reclaimed which can
+	// be negative if the database has grown during compaction.
+	Compactor interface {
+		Compact() (int64, error)
+	}
+
 	// Batch represents an abstraction on top of batch operations.
 	// Each Store implementation is responsible of casting a Batch
 	// to its appropriate type.
@@ -100,6 +116,8 @@ func NewStore(cfg DBConfiguration) (Store, error) {
 		store, err = NewBoltDBStore(cfg.BoltDBOptions)
 	case "badgerdb":
 		store, err = NewBadgerDBStore(cfg.BadgerDBOptions)
+	case "pebble":
+		store, err = NewPebbleDBStore(cfg.PebbleDBOptions)
 	default:
 		return nil, fmt.Errorf("unknown storage: %s", cfg.Type)
 	}
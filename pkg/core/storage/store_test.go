@@ -15,6 +15,7 @@ var (
 		IXHeaderHashList,
 		SYSCurrentBlock,
 		SYSCurrentHeader,
+		SYSKnownPeers,
 		SYSVersion,
 	}
 
@@ -26,6 +27,7 @@ var (
 		0x80,
 		0xc0,
 		0xc1,
+		0xc2,
 		0xf0,
 	}
 )
@@ -148,6 +148,32 @@ func testStorePutAndDelete(t *testing.T, s Store) {
 	require.NoError(t, s.Close())
 }
 
+func testStoreCompact(t *testing.T, s Store) {
+	c, ok := s.(Compactor)
+	if !ok {
+		require.NoError(t, s.Close())
+		return
+	}
+	for i := 0; i < 1000; i++ {
+		require.NoError(t, s.Put([]byte{byte(i >> 8), byte(i)}, make([]byte, 100)))
+	}
+	for i := 0; i < 1000; i += 2 {
+		require.NoError(t, s.Delete([]byte{byte(i >> 8), byte(i)}))
+	}
+	_, err := c.Compact()
+	require.NoError(t, err)
+	for i := 0; i < 1000; i++ {
+		_, err := s.Get([]byte{byte(i >> 8), byte(i)})
+		if i%2 == 0 {
+			require.Equal(t, ErrKeyNotFound, err)
+		} else {
+			require.NoError(t, err)
+		}
+	}
+	require.NoError(t, s.Put([]byte{0xff}, []byte{1}))
+	require.NoError(t, s.Close())
+}
+
 func testStorePutBatchWithDelete(t *testing.T, s Store) {
 	var (
 		toBeStored = map[string][]byte{
@@ -225,11 +251,12 @@ func TestAllDBs(t *testing.T) {
 		{"Memory", newMemoryStoreForTesting},
 		{"RedisDB", newRedisStoreForTesting},
 		{"BadgerDB", newBadgerDBForTesting},
+		{"PebbleDB", newPebbleDBForTesting},
 	}
 	var tests = []dbTestFunction{testStoreClose, testStorePutAndGet,
 		testStoreGetNonExistent, testStorePutBatch, testStoreSeek,
 		testStoreDeleteNonExistent, testStorePutAndDelete,
-		testStorePutBatchWithDelete}
+		testStorePutBatchWithDelete, testStoreCompact}
 	for _, db := range DBs {
 		for _, test := range tests {
 			s := db.create(t)
@@ -0,0 +1,55 @@
+package core
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+)
+
+// NewStorageChanges converts storage batch of the block with the given index
+// into a set of contract storage changes (in batch order).
+func NewStorageChanges(index uint32, batch *storage.MemBatch) *state.StorageChanges {
+	ops := make([]state.StorageChange, 0, len(batch.Put)+len(batch.Deleted))
+	for i := range batch.Put {
+		key := batch.Put[i].Key
+		if len(key) == 0 || key[0] != byte(storage.STStorage) {
+			continue
+		}
+		op := state.StorageAdded
+		if batch.Put[i].Exists {
+			op = state.StorageChanged
+		}
+		ops = append(ops, state.StorageChange{
+			State: op,
+			Key:   key[1:],
+			Value: batch.Put[i].Value,
+		})
+	}
+	for i := range batch.Deleted {
+		key := batch.Deleted[i].Key
+		if len(key) == 0 || key[0] != byte(storage.STStorage) || !batch.Deleted[i].Exists {
+			continue
+		}
+		ops = append(ops, state.StorageChange{
+			State: state.StorageDeleted,
+			Key:   key[1:],
+		})
+	}
+	return &state.StorageChanges{
+		Block:   index,
+		Size:    len(ops),
+		Storage: ops,
+	}
+}
+
+// newStorageChanges converts storage batch of the block with the given index
+// into a set of contract storage changes sorted by key.
+func newStorageChanges(index uint32, batch *storage.MemBatch) *state.StorageChanges {
+	sc := NewStorageChanges(index, batch)
+	sort.Slice(sc.Storage, func(i, j int) bool {
+		return bytes.Compare(sc.Storage[i].Key, sc.Storage[j].Key) < 0
+	})
+	return sc
+}
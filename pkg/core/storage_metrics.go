@@ -0,0 +1,123 @@
+package core
+
+import (
+	"encoding/binary"
+	"sync"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// storageStats tracks per-contract storage usage for monitoring purposes.
+// It's updated with every block stored and published on persist.
+type storageStats struct {
+	lock      sync.Mutex
+	contracts map[int32]*contractStorageStats
+}
+
+// contractStorageStats is a storage usage of a single contract. Size includes
+// keys (without storage prefix) and values.
+type contractStorageStats struct {
+	hash     util.Uint160
+	resolved bool
+	changed  bool
+	items    int64
+	size     int64
+}
+
+// newStorageStats collects storage usage statistics for all contracts.
+func newStorageStats(s storage.Store) *storageStats {
+	st := &storageStats{contracts: make(map[int32]*contractStorageStats)}
+	s.Seek(storage.STStorage.Bytes(), func(k, v []byte) {
+		if cs := st.get(k); cs != nil {
+			cs.items++
+			cs.size += int64(len(k) - 1 + len(v))
+		}
+	})
+	return st
+}
+
+// get returns statistics for contract owning the given storage key (with
+// prefix), nil is returned for malformed keys.
+func (st *storageStats) get(key []byte) *contractStorageStats {
+	if len(key) < 5 {
+		return nil
+	}
+	id := int32(binary.LittleEndian.Uint32(key[1:]))
+	cs, ok := st.contracts[id]
+	if !ok {
+		cs = new(contractStorageStats)
+		st.contracts[id] = cs
+	}
+	cs.changed = true
+	return cs
+}
+
+// apply updates statistics using the given batch of changes. getOld is used
+// to retrieve values that are to be replaced or deleted.
+func (st *storageStats) apply(batch *storage.MemBatch, getOld func([]byte) ([]byte, error)) {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	for _, kv := range batch.Put {
+		if len(kv.Key) == 0 || kv.Key[0] != byte(storage.STStorage) {
+			continue
+		}
+		cs := st.get(kv.Key)
+		if cs == nil {
+			continue
+		}
+		if kv.Exists {
+			old, err := getOld(kv.Key)
+			if err == nil {
+				cs.size += int64(len(kv.Value) - len(old))
+				continue
+			}
+		}
+		cs.items++
+		cs.size += int64(len(kv.Key) - 1 + len(kv.Value))
+	}
+	for _, kv := range batch.Deleted {
+		if len(kv.Key) == 0 || kv.Key[0] != byte(storage.STStorage) || !kv.Exists {
+			continue
+		}
+		cs := st.get(kv.Key)
+		if cs == nil {
+			continue
+		}
+		old, err := getOld(kv.Key)
+		if err != nil {
+			continue
+		}
+		cs.items--
+		cs.size -= int64(len(kv.Key) - 1 + len(old))
+	}
+}
+
+// publish updates metrics for contracts changed since the last call.
+// getHash is used to get contract hash by its ID.
+func (st *storageStats) publish(getHash func(int32) (util.Uint160, error)) {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	for id, cs := range st.contracts {
+		if !cs.changed {
+			continue
+		}
+		if !cs.resolved {
+			h, err := getHash(id)
+			if err != nil {
+				// Contract is not yet persisted or already destroyed.
+				continue
+			}
+			cs.hash, cs.resolved = h, true
+		}
+		cs.changed = false
+		if cs.items <= 0 {
+			deleteContractStorageMetric(cs.hash)
+			delete(st.contracts, id)
+			continue
+		}
+		updateContractStorageMetric(cs.hash, cs.items, cs.size)
+	}
+}
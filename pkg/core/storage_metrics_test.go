@@ -0,0 +1,62 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageStats(t *testing.T) {
+	key := func(id int32, k byte) []byte {
+		return append(storage.AppendPrefixInt(storage.STStorage, int(id)), k)
+	}
+	hashes := map[int32]util.Uint160{
+		1: {0xff, 1},
+		3: {0xff, 3},
+	}
+	getHash := func(id int32) (util.Uint160, error) {
+		h, ok := hashes[id]
+		if !ok {
+			return util.Uint160{}, errors.New("not found")
+		}
+		return h, nil
+	}
+	check := func(id int32, items, size int64) {
+		h := hashes[id]
+		require.Equal(t, float64(items), testutil.ToFloat64(contractStorageItems.WithLabelValues(h.StringLE())))
+		require.Equal(t, float64(size), testutil.ToFloat64(contractStorageBytes.WithLabelValues(h.StringLE())))
+	}
+
+	s := storage.NewMemoryStore()
+	require.NoError(t, s.Put(key(1, 1), []byte{1, 2, 3}))
+	require.NoError(t, s.Put(key(1, 2), []byte{1}))
+	require.NoError(t, s.Put(key(2, 1), []byte{1}))
+	require.NoError(t, s.Put([]byte{byte(storage.SYSVersion)}, []byte{1, 2, 3}))
+
+	st := newStorageStats(s)
+	st.publish(getHash)
+	check(1, 2, 14)
+	require.True(t, st.contracts[2].changed, "unresolved contract must be published later")
+
+	hashes[2] = util.Uint160{0xff, 2}
+	st.apply(&storage.MemBatch{
+		Put: []storage.KeyValue{
+			{Key: key(1, 1), Value: []byte{1}, Exists: true},
+			{Key: key(3, 1), Value: []byte{1, 2}},
+		},
+		Deleted: []storage.KeyValue{
+			{Key: key(1, 2), Exists: true},
+			{Key: key(2, 1), Exists: true},
+			{Key: key(3, 2)},
+		},
+	}, s.Get)
+	st.publish(getHash)
+	check(1, 1, 6)
+	check(3, 1, 7)
+	require.NotContains(t, st.contracts, int32(2))
+	require.False(t, contractStorageItems.DeleteLabelValues(hashes[2].StringLE()))
+}
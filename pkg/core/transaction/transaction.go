@@ -21,7 +21,8 @@ const (
 	MaxTransactionSize = 102400
 	// MaxValidUntilBlockIncrement is the upper increment size of blockhain height in blocks
 	// exceeding that a transaction should fail validation. It is set to estimated daily number
-	// of blocks with 15s interval.
+	// of blocks with 15s interval, the actual value depends on the protocol configuration
+	// (see config.ProtocolConfiguration.MaxValidUntilBlockIncrement).
 	MaxValidUntilBlockIncrement = 5760
 	// MaxAttributes is maximum number of attributes including signers that can be contained
 	// within a transaction. It is set to be 16.
@@ -37,6 +37,11 @@ func createGenesisBlock(cfg config.ProtocolConfiguration) (*block.Block, error)
 		return nil, err
 	}
 
+	txs, err := createGenesisTransactions(cfg.Genesis, validators)
+	if err != nil {
+		return nil, err
+	}
+
 	base := block.Header{
 		Version:       0,
 		PrevHash:      util.Uint256{},
@@ -54,6 +59,7 @@ func createGenesisBlock(cfg config.ProtocolConfiguration) (*block.Block, error)
 		Header:       base,
 		Transactions: []*transaction.Transaction{},
 	}
+	b.Transactions = append(b.Transactions, txs...)
 	b.RebuildMerkleRoot()
 
 	return b, nil
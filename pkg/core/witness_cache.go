@@ -0,0 +1,104 @@
+package core
+
+import (
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+)
+
+// witnessCache remembers transactions that have successfully passed witness
+// verification when being added to the mempool, so that they're not verified
+// again when the same transaction comes in a block. Transaction hash doesn't
+// cover witnesses, so their digest is stored along with the hash and the cache
+// only hits if witnesses are the same. Only transactions with standard
+// (signature and multisignature) witnesses are cached, their verification
+// doesn't depend on any contract state, but it still depends on the policy
+// (verification GAS limit and prices), so policy values are stored along with
+// the digest and the cache misses if they've been changed.
+type witnessCache struct {
+	cache *lru.Cache
+}
+
+// witnessPolicy is a set of policy values verification of standard witnesses
+// depends on.
+type witnessPolicy struct {
+	execFeeFactor      int64
+	feePerByte         int64
+	maxVerificationGas int64
+}
+
+// witnessEntry is a witness cache element.
+type witnessEntry struct {
+	digest util.Uint256
+	policy witnessPolicy
+}
+
+// newWitnessCache creates a cache able to hold the given number of
+// transactions.
+func newWitnessCache(size int) *witnessCache {
+	c, _ := lru.New(size)
+	return &witnessCache{cache: c}
+}
+
+// witnessDigest returns a hash of all transaction witnesses.
+func witnessDigest(t *transaction.Transaction) util.Uint256 {
+	w := io.NewBufBinWriter()
+	w.WriteArray(t.Scripts)
+	return hash.Sha256(w.Bytes())
+}
+
+// hasStandardWitnesses checks whether all transaction witnesses are standard
+// signature or multisignature ones with push-only invocation scripts, so
+// that their verification result can't change with the chain state.
+func hasStandardWitnesses(t *transaction.Transaction) bool {
+	if len(t.Scripts) != len(t.Signers) {
+		return false
+	}
+	for i := range t.Scripts {
+		if !vm.IsStandardContract(t.Scripts[i].VerificationScript) ||
+			!isPushOnly(t.Scripts[i].InvocationScript) {
+			return false
+		}
+	}
+	return true
+}
+
+// isPushOnly checks whether the script only pushes constants on the stack.
+func isPushOnly(script []byte) bool {
+	ctx := vm.NewContext(script)
+	for ctx.NextIP() < len(script) {
+		op, _, err := ctx.Next()
+		if err != nil || op > opcode.PUSH16 {
+			return false
+		}
+	}
+	return true
+}
+
+// add marks witnesses of the given transaction as verified with the given
+// policy if they're standard.
+func (c *witnessCache) add(t *transaction.Transaction, p witnessPolicy) {
+	if hasStandardWitnesses(t) {
+		c.cache.Add(t.Hash(), witnessEntry{digest: witnessDigest(t), policy: p})
+	}
+}
+
+// verified checks whether the transaction with the same witnesses was
+// successfully verified before with the same policy, it updates cache hit
+// metrics.
+func (c *witnessCache) verified(t *transaction.Transaction, p witnessPolicy) bool {
+	v, ok := c.cache.Get(t.Hash())
+	if ok {
+		e := v.(witnessEntry)
+		if e.policy == p && e.digest.Equals(witnessDigest(t)) {
+			witnessCacheHits.Inc()
+			return true
+		}
+	}
+	witnessCacheMisses.Inc()
+	return false
+}
@@ -0,0 +1,79 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/internal/testchain"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWitnessCache(t *testing.T) {
+	bc := newTestChain(t)
+	newTx := func() *transaction.Transaction {
+		tx := bc.newTestTx(testchain.MultisigScriptHash(), []byte{byte(opcode.PUSH1)})
+		require.NoError(t, testchain.SignTx(bc, tx))
+		return tx
+	}
+
+	t.Run("unknown", func(t *testing.T) {
+		misses := testutil.ToFloat64(witnessCacheMisses)
+		require.False(t, bc.witnesses.verified(newTx(), bc.witnessPolicy()))
+		require.Equal(t, misses+1, testutil.ToFloat64(witnessCacheMisses))
+	})
+	t.Run("pooled", func(t *testing.T) {
+		tx := newTx()
+		require.NoError(t, bc.PoolTx(tx))
+
+		hits := testutil.ToFloat64(witnessCacheHits)
+		require.True(t, bc.witnesses.verified(tx, bc.witnessPolicy()))
+		require.Equal(t, hits+1, testutil.ToFloat64(witnessCacheHits))
+	})
+	t.Run("different witness", func(t *testing.T) {
+		tx := newTx()
+		require.NoError(t, bc.PoolTx(tx))
+
+		cp := *tx
+		cp.Scripts = []transaction.Witness{{
+			InvocationScript:   append([]byte{}, tx.Scripts[0].InvocationScript...),
+			VerificationScript: tx.Scripts[0].VerificationScript,
+		}}
+		cp.Scripts[0].InvocationScript[10] ^= 0xff
+		require.Equal(t, tx.Hash(), cp.Hash())
+		require.False(t, bc.witnesses.verified(&cp, bc.witnessPolicy()))
+	})
+	t.Run("policy change", func(t *testing.T) {
+		tx := newTx()
+		require.NoError(t, bc.PoolTx(tx))
+
+		p := bc.witnessPolicy()
+		p.execFeeFactor++
+		require.False(t, bc.witnesses.verified(tx, p))
+	})
+	t.Run("non-standard", func(t *testing.T) {
+		tx := newTx()
+		cp := *tx
+		cp.Scripts = []transaction.Witness{{
+			InvocationScript:   append([]byte{byte(opcode.PUSH1), byte(opcode.DROP)}, tx.Scripts[0].InvocationScript...),
+			VerificationScript: tx.Scripts[0].VerificationScript,
+		}}
+		bc.witnesses.add(&cp, bc.witnessPolicy())
+		require.False(t, bc.witnesses.verified(&cp, bc.witnessPolicy()))
+
+		cp.Scripts = []transaction.Witness{{}}
+		bc.witnesses.add(&cp, bc.witnessPolicy())
+		require.False(t, bc.witnesses.verified(&cp, bc.witnessPolicy()))
+	})
+	t.Run("block", func(t *testing.T) {
+		tx := newTx()
+		require.NoError(t, bc.PoolTx(tx))
+		// Evicted from the mempool, but still known to be valid.
+		bc.memPool.Remove(tx.Hash(), bc)
+
+		hits := testutil.ToFloat64(witnessCacheHits)
+		require.NoError(t, bc.AddBlock(bc.newBlock(tx)))
+		require.Equal(t, hits+1, testutil.ToFloat64(witnessCacheHits))
+	})
+}
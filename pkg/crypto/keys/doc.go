@@ -1,4 +1,5 @@
 /*
-Package keys wraps public/private keys and implements NEP-2 and WIF.
+Package keys wraps public/private keys and implements NEP-2, WIF, BIP-39
+mnemonics and hierarchical deterministic keys derivation.
 */
 package keys
@@ -0,0 +1,148 @@
+package keys
+
+import (
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+const (
+	// HardenedKeyStart is the index of the first hardened child key.
+	HardenedKeyStart = 0x80000000
+
+	// NeoCoinType is SLIP-44 coin type of Neo.
+	NeoCoinType = 888
+)
+
+// hdSeedKey is the HMAC key used to derive master key from the seed for
+// secp256r1 curve, see SLIP-10.
+var hdSeedKey = []byte("Nist256p1 seed")
+
+// HDKey is a hierarchical deterministic (BIP-32) Secp256r1 key derived
+// according to SLIP-10.
+type HDKey struct {
+	PrivateKey *PrivateKey
+	ChainCode  []byte
+	Depth      byte
+	Index      uint32
+}
+
+// NeoDerivationPath returns BIP-44 derivation path for the given account and
+// address index using Neo coin type (m/44'/888'/account'/0/index).
+func NeoDerivationPath(account uint32, index uint32) string {
+	return fmt.Sprintf("m/44'/%d'/%d'/0/%d", NeoCoinType, account, index)
+}
+
+// NewMasterHDKey creates master HD key from the seed (see MnemonicToSeed).
+func NewMasterHDKey(seed []byte) (*HDKey, error) {
+	if len(seed) < 16 || len(seed) > 64 {
+		return nil, fmt.Errorf("invalid seed length: %d", len(seed))
+	}
+	data := seed
+	for {
+		mac := hmac.New(sha512.New, hdSeedKey)
+		mac.Write(data)
+		i := mac.Sum(nil)
+		k, err := hdKeyFromParts(i[:32], i[32:], 0, 0)
+		if err == nil {
+			return k, nil
+		}
+		data = i
+	}
+}
+
+// Child derives child key with the given index, indexes starting from
+// HardenedKeyStart correspond to hardened keys.
+func (k *HDKey) Child(index uint32) (*HDKey, error) {
+	if k.Depth == 255 {
+		return nil, errors.New("maximum depth reached")
+	}
+	data := make([]byte, 37)
+	if index >= HardenedKeyStart {
+		copy(data[1:], bytesPad32(k.PrivateKey.D))
+	} else {
+		copy(data, k.PrivateKey.PublicKey().Bytes())
+	}
+	binary.BigEndian.PutUint32(data[33:], index)
+
+	n := elliptic.P256().Params().N
+	for {
+		mac := hmac.New(sha512.New, k.ChainCode)
+		mac.Write(data)
+		i := mac.Sum(nil)
+		il := new(big.Int).SetBytes(i[:32])
+		if il.Cmp(n) < 0 {
+			il.Add(il, k.PrivateKey.D)
+			il.Mod(il, n)
+			if il.Sign() != 0 {
+				return hdKeyFromParts(bytesPad32(il), i[32:], k.Depth+1, index)
+			}
+		}
+		// Invalid key, SLIP-10 proceeds with the next iteration.
+		data[0] = 1
+		copy(data[1:33], i[32:])
+	}
+}
+
+// Derive derives key using the given path like "m/44'/888'/0'/0/0" (where
+// apostrophe or "h" suffix marks hardened index). The key must be a master
+// one.
+func (k *HDKey) Derive(path string) (*HDKey, error) {
+	if k.Depth != 0 {
+		return nil, errors.New("not a master key")
+	}
+	parts := strings.Split(path, "/")
+	if parts[0] != "m" {
+		return nil, fmt.Errorf("invalid path: %s", path)
+	}
+	res := k
+	for _, p := range parts[1:] {
+		var index uint32
+		if strings.HasSuffix(p, "'") || strings.HasSuffix(p, "h") {
+			index = HardenedKeyStart
+			p = p[:len(p)-1]
+		}
+		n, err := strconv.ParseUint(p, 10, 32)
+		if err != nil || n >= HardenedKeyStart {
+			return nil, fmt.Errorf("invalid path element: %s", p)
+		}
+		res, err = res.Child(index + uint32(n))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// hdKeyFromParts creates HDKey from the private key and chain code, it fails
+// if the private key is not valid.
+func hdKeyFromParts(priv []byte, chainCode []byte, depth byte, index uint32) (*HDKey, error) {
+	d := new(big.Int).SetBytes(priv)
+	if d.Sign() == 0 || d.Cmp(elliptic.P256().Params().N) >= 0 {
+		return nil, errors.New("invalid private key")
+	}
+	pk, err := NewPrivateKeyFromBytes(priv)
+	if err != nil {
+		return nil, err
+	}
+	return &HDKey{
+		PrivateKey: pk,
+		ChainCode:  append([]byte{}, chainCode...),
+		Depth:      depth,
+		Index:      index,
+	}, nil
+}
+
+// bytesPad32 returns 32-byte big-endian representation of n.
+func bytesPad32(n *big.Int) []byte {
+	res := make([]byte, 32)
+	b := n.Bytes()
+	copy(res[32-len(b):], b)
+	return res
+}
@@ -0,0 +1,58 @@
+package keys
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHDKeySLIP10Vector(t *testing.T) {
+	// Test vector 1 for nist256p1 from SLIP-10.
+	seed, err := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	require.NoError(t, err)
+	master, err := NewMasterHDKey(seed)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		path      string
+		chainCode string
+		priv      string
+		pub       string
+	}{
+		{"m", "beeb672fe4621673f722f38529c07392fecaa61015c80c34f29ce8b41b3cb6ea", "612091aaa12e22dd2abef664f8a01a82cae99ad7441b7ef8110424915c268bc2", "0266874dc6ade47b3ecd096745ca09bcd29638dd52c2c12117b11ed3e458cfa9e8"},
+		{"m/0'", "3460cea53e6a6bb5fb391eeef3237ffd8724bf0a40e94943c98b83825342ee11", "6939694369114c67917a182c59ddb8cafc3004e63ca5d3b84403ba8613debc0c", "0384610f5ecffe8fda089363a41f56a5c7ffc1d81b59a612d0d649b2d22355590c"},
+		{"m/0'/1", "4187afff1aafa8445010097fb99d23aee9f599450c7bd140b6826ac22ba21d0c", "284e9d38d07d21e4e281b645089a94f4cf5a5a81369acf151a1c3a57f18b2129", "03526c63f8d0b4bbbf9c80df553fe66742df4676b241dabefdef67733e070f6844"},
+		{"m/0h/1/2h", "98c7514f562e64e74170cc3cf304ee1ce54d6b6da4f880f313e8204c2a185318", "694596e8a54f252c960eb771a3c41e7e32496d03b954aeb90f61635b8e092aa7", "0359cf160040778a4b14c5f4d7b76e327ccc8c4a6086dd9451b7482b5a4972dda0"},
+	}
+	for _, tc := range testCases {
+		k, err := master.Derive(tc.path)
+		require.NoError(t, err, tc.path)
+		require.Equal(t, tc.chainCode, hex.EncodeToString(k.ChainCode), tc.path)
+		require.Equal(t, tc.priv, hex.EncodeToString(k.PrivateKey.Bytes()), tc.path)
+		require.Equal(t, tc.pub, hex.EncodeToString(k.PrivateKey.PublicKey().Bytes()), tc.path)
+	}
+}
+
+func TestHDKeyNeoPath(t *testing.T) {
+	require.Equal(t, "m/44'/888'/0'/0/0", NeoDerivationPath(0, 0))
+
+	seed, err := MnemonicToSeed("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", "")
+	require.NoError(t, err)
+	master, err := NewMasterHDKey(seed)
+	require.NoError(t, err)
+	k, err := master.Derive(NeoDerivationPath(0, 0))
+	require.NoError(t, err)
+	require.Equal(t, byte(5), k.Depth)
+	require.Equal(t, uint32(0), k.Index)
+	require.Equal(t, "38bcb1943801333aecdb9099b368ca8ea5b13a2c22862f8e1be77a46ed88738b", hex.EncodeToString(k.PrivateKey.Bytes()))
+
+	_, err = k.Derive("m/0")
+	require.Error(t, err)
+	for _, p := range []string{"", "0/1", "m/x", "m/2147483648", "m/1''"} {
+		_, err = master.Derive(p)
+		require.Error(t, err, p)
+	}
+	_, err = NewMasterHDKey([]byte{1, 2, 3})
+	require.Error(t, err)
+}
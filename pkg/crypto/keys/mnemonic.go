@@ -0,0 +1,111 @@
+package keys
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Mnemonic entropy size limits (in bits), see BIP-39.
+const (
+	MinMnemonicEntropy = 128
+	MaxMnemonicEntropy = 256
+)
+
+var (
+	bip39Words   = strings.Fields(bip39English)
+	bip39Indexes = func() map[string]int {
+		m := make(map[string]int, len(bip39Words))
+		for i, w := range bip39Words {
+			m[w] = i
+		}
+		return m
+	}()
+)
+
+// NewMnemonic generates a new random BIP-39 mnemonic (English) with the given
+// entropy size in bits, it must be a multiple of 32 between
+// MinMnemonicEntropy and MaxMnemonicEntropy.
+func NewMnemonic(bits int) (string, error) {
+	if bits < MinMnemonicEntropy || bits > MaxMnemonicEntropy || bits%32 != 0 {
+		return "", fmt.Errorf("invalid entropy size: %d", bits)
+	}
+	entropy := make([]byte, bits/8)
+	if _, err := rand.Read(entropy); err != nil {
+		return "", err
+	}
+	return MnemonicFromEntropy(entropy)
+}
+
+// MnemonicFromEntropy returns BIP-39 mnemonic (English) for the given entropy.
+func MnemonicFromEntropy(entropy []byte) (string, error) {
+	bits := len(entropy) * 8
+	if bits < MinMnemonicEntropy || bits > MaxMnemonicEntropy || bits%32 != 0 {
+		return "", fmt.Errorf("invalid entropy size: %d", bits)
+	}
+	csBits := uint(bits / 32)
+	h := sha256.Sum256(entropy)
+	n := new(big.Int).SetBytes(entropy)
+	n.Lsh(n, csBits)
+	n.Or(n, big.NewInt(int64(h[0]>>(8-csBits))))
+
+	words := make([]string, (bits+int(csBits))/11)
+	mask := big.NewInt(2047)
+	for i := len(words) - 1; i >= 0; i-- {
+		idx := new(big.Int).And(n, mask)
+		words[i] = bip39Words[idx.Int64()]
+		n.Rsh(n, 11)
+	}
+	return strings.Join(words, " "), nil
+}
+
+// MnemonicToEntropy checks BIP-39 mnemonic (English) validity (including
+// its checksum) and returns entropy it encodes.
+func MnemonicToEntropy(mnemonic string) ([]byte, error) {
+	words := strings.Fields(mnemonic)
+	total := len(words) * 11
+	if len(words)%3 != 0 || total < MinMnemonicEntropy+MinMnemonicEntropy/32 ||
+		total > MaxMnemonicEntropy+MaxMnemonicEntropy/32 {
+		return nil, fmt.Errorf("invalid number of words: %d", len(words))
+	}
+	n := new(big.Int)
+	for _, w := range words {
+		idx, ok := bip39Indexes[w]
+		if !ok {
+			return nil, fmt.Errorf("unknown word: %s", w)
+		}
+		n.Lsh(n, 11)
+		n.Or(n, big.NewInt(int64(idx)))
+	}
+	csBits := uint(total / 33)
+	cs := new(big.Int).And(n, big.NewInt(1<<csBits-1)).Int64()
+	n.Rsh(n, csBits)
+
+	entropy := make([]byte, (total-int(csBits))/8)
+	b := n.Bytes()
+	copy(entropy[len(entropy)-len(b):], b)
+	h := sha256.Sum256(entropy)
+	if int64(h[0]>>(8-csBits)) != cs {
+		return nil, errors.New("invalid mnemonic checksum")
+	}
+	return entropy, nil
+}
+
+// MnemonicToSeed checks BIP-39 mnemonic validity and returns 64-byte seed
+// derived from it and the given (optional) passphrase. This seed can be used
+// to create HD key with NewMasterHDKey.
+func MnemonicToSeed(mnemonic string, passphrase string) ([]byte, error) {
+	if _, err := MnemonicToEntropy(mnemonic); err != nil {
+		return nil, err
+	}
+	mnemonic = norm.NFKD.String(strings.Join(strings.Fields(mnemonic), " "))
+	salt := norm.NFKD.String("mnemonic" + passphrase)
+	return pbkdf2.Key([]byte(mnemonic), []byte(salt), 2048, 64, sha512.New), nil
+}
@@ -0,0 +1,65 @@
+package keys
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMnemonicVectors(t *testing.T) {
+	// Test vectors from https://github.com/trezor/python-mnemonic.
+	testCases := []struct {
+		entropy  string
+		mnemonic string
+	}{
+		{"00000000000000000000000000000000", "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"},
+		{"7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f", "legal winner thank year wave sausage worth useful legal winner thank yellow"},
+		{"0000000000000000000000000000000000000000000000000000000000000000", "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art"},
+		{"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo vote"},
+	}
+	for _, tc := range testCases {
+		entropy, err := hex.DecodeString(tc.entropy)
+		require.NoError(t, err)
+		m, err := MnemonicFromEntropy(entropy)
+		require.NoError(t, err)
+		require.Equal(t, tc.mnemonic, m)
+
+		actual, err := MnemonicToEntropy(m)
+		require.NoError(t, err)
+		require.Equal(t, entropy, actual)
+	}
+
+	seed, err := MnemonicToSeed(testCases[0].mnemonic, "TREZOR")
+	require.NoError(t, err)
+	require.Equal(t, "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04", hex.EncodeToString(seed))
+}
+
+func TestNewMnemonic(t *testing.T) {
+	for _, bits := range []int{128, 160, 192, 224, 256} {
+		m, err := NewMnemonic(bits)
+		require.NoError(t, err)
+		require.Equal(t, (bits+bits/32)/11, len(strings.Fields(m)))
+		_, err = MnemonicToSeed(m, "")
+		require.NoError(t, err)
+	}
+	for _, bits := range []int{0, 96, 129, 288} {
+		_, err := NewMnemonic(bits)
+		require.Error(t, err)
+	}
+}
+
+func TestMnemonicToEntropyErrors(t *testing.T) {
+	// Bad checksum.
+	_, err := MnemonicToEntropy("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon")
+	require.Error(t, err)
+	// Unknown word.
+	_, err = MnemonicToEntropy("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon neo")
+	require.Error(t, err)
+	// Bad length.
+	_, err = MnemonicToEntropy("abandon abandon abandon about")
+	require.Error(t, err)
+	_, err = MnemonicToSeed("abandon about", "")
+	require.Error(t, err)
+}
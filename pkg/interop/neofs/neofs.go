@@ -0,0 +1,93 @@
+/*
+Package neofs provides helpers to make NeoFS oracle requests from contract
+code. NeoFS URLs have "neofs:<Container-ID>/<Object-ID>[/<Command>/<Params>]"
+format, where container and object IDs are base58-encoded and commands allow
+to request object payload range, header or payload hash instead of the whole
+payload (see docs/oracle.md for details).
+*/
+package neofs
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/interop/native/oracle"
+	"github.com/nspcc-dev/neo-go/pkg/interop/native/policy"
+	"github.com/nspcc-dev/neo-go/pkg/interop/native/std"
+)
+
+const (
+	// MaxResultSize is the maximum size of oracle response data, ranges
+	// longer than that can't be requested.
+	MaxResultSize = 0xffff
+	// HashResultSize is the size of range hash response (JSON-encoded
+	// Uint256 string).
+	HashResultSize = 68
+)
+
+// ObjectURL returns URL of the whole object payload.
+func ObjectURL(containerID, objectID string) string {
+	return "neofs:" + containerID + "/" + objectID
+}
+
+// RangeURL returns URL of the object payload range of the specified length
+// starting at the specified offset.
+func RangeURL(containerID, objectID string, offset, length int) string {
+	return ObjectURL(containerID, objectID) + "/range/" + std.Itoa(offset, 10) + "|" + std.Itoa(length, 10)
+}
+
+// HeaderURL returns URL of the object header, it's returned in JSON format.
+func HeaderURL(containerID, objectID string) string {
+	return ObjectURL(containerID, objectID) + "/header"
+}
+
+// HashURL returns URL of the object payload checksum.
+func HashURL(containerID, objectID string) string {
+	return ObjectURL(containerID, objectID) + "/hash"
+}
+
+// RangeHashURL returns URL of SHA256 hash of the object payload range.
+func RangeHashURL(containerID, objectID string, offset, length int) string {
+	return HashURL(containerID, objectID) + "/" + std.Itoa(offset, 10) + "|" + std.Itoa(length, 10)
+}
+
+// ResponseGas returns the amount of GAS to attach to the oracle request
+// expecting a response of the specified size. It's the minimum response GAS
+// plus the price of the response data at the current fee per byte, callback
+// execution costs should be added on top of it.
+func ResponseGas(size int) int {
+	return oracle.MinimumResponseGas + size*policy.GetFeePerByte()
+}
+
+// RequestRange makes an oracle request for the object payload range, it
+// panics if length is not positive or exceeds MaxResultSize. gasForCallback
+// is the GAS needed to execute callback method, the cost of response data is
+// added to it automatically. Other parameters are the same as for
+// oracle.Request.
+func RequestRange(containerID, objectID string, offset, length int, filter []byte,
+	cb string, userData interface{}, gasForCallback int) {
+	if length <= 0 || length > MaxResultSize {
+		panic("invalid range length")
+	}
+	oracle.Request(RangeURL(containerID, objectID, offset, length), filter,
+		cb, userData, ResponseGas(length)+gasForCallback)
+}
+
+// RequestHeader makes an oracle request for the object header expecting the
+// response (after filtering if filter is specified) to be no longer than
+// maxSize bytes. gasForCallback and other parameters are the same as for
+// RequestRange.
+func RequestHeader(containerID, objectID string, filter []byte, cb string,
+	userData interface{}, maxSize int, gasForCallback int) {
+	if maxSize <= 0 || maxSize > MaxResultSize {
+		panic("invalid response size")
+	}
+	oracle.Request(HeaderURL(containerID, objectID), filter,
+		cb, userData, ResponseGas(maxSize)+gasForCallback)
+}
+
+// RequestRangeHash makes an oracle request for SHA256 hash of the object
+// payload range. Response is JSON-encoded hash, gasForCallback and other
+// parameters are the same as for RequestRange.
+func RequestRangeHash(containerID, objectID string, offset, length int,
+	cb string, userData interface{}, gasForCallback int) {
+	oracle.Request(RangeHashURL(containerID, objectID, offset, length), nil,
+		cb, userData, ResponseGas(HashResultSize)+gasForCallback)
+}
@@ -0,0 +1,16 @@
+package neogointernal
+
+// Asm emits hand-written NeoVM code after pushing args onto the stack (so
+// that the last one is on top). Code is a constant string of instructions
+// separated by spaces or newlines, every instruction is an opcode name
+// followed by its operands (like `PUSHINT8 5`, `PUSHDATA1 0x0102`,
+// `SYSCALL System.Runtime.Log` or `ISTYPE Integer`). Labels can be defined as
+// `name:` and used as operands of jump and call instructions. `//` starts a
+// comment. Code must leave exactly one item on the stack, it is returned.
+func Asm(code string, args ...interface{}) interface{} {
+	return nil
+}
+
+// AsmNoReturn is the same as Asm, but code must leave no items on the stack.
+func AsmNoReturn(code string, args ...interface{}) {
+}
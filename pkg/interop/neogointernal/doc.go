@@ -1,5 +1,6 @@
 /*
 Package neogointernal contains definitions of compiler intrinsics.
-It's not intended to be used directly by smart contracts.
+It's not intended to be used directly by smart contracts, except for Asm
+functions allowing to embed hand-written NeoVM code.
 */
 package neogointernal
@@ -0,0 +1,35 @@
+/*
+Package nep24 provides helpers for NEP-24 NFT royalty standard. It can be used
+both to implement `royaltyInfo` method in NEP-11 contract and to call this
+method of other contracts.
+*/
+package nep24
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/interop"
+	"github.com/nspcc-dev/neo-go/pkg/interop/contract"
+)
+
+// MaxRoyaltyPoints is the number of basis points (1/10000 of the sale price)
+// equivalent to 100% royalty.
+const MaxRoyaltyPoints = 10000
+
+// RoyaltyRecipient is an element of `royaltyInfo` method result, contract
+// implementing NEP-24 should return a slice of them.
+type RoyaltyRecipient struct {
+	Address interop.Hash160
+	Amount  int
+}
+
+// RoyaltyAmount calculates royalty amount for the given sale price and
+// royalty rate specified in basis points (see MaxRoyaltyPoints).
+func RoyaltyAmount(salePrice int, points int) int {
+	return salePrice * points / MaxRoyaltyPoints
+}
+
+// RoyaltyInfo invokes `royaltyInfo` method of NEP-24 contract with the given
+// hash. It returns the list of royalty recipients and amounts to be paid to
+// them for the token with the given ID sold for salePrice of royaltyToken.
+func RoyaltyInfo(h interop.Hash160, tokenID []byte, royaltyToken interop.Hash160, salePrice int) []RoyaltyRecipient {
+	return contract.Call(h, "royaltyInfo", contract.ReadStates, tokenID, royaltyToken, salePrice).([]RoyaltyRecipient)
+}
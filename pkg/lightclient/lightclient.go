@@ -0,0 +1,341 @@
+/*
+Package lightclient implements a client that only keeps the chain of block
+headers and verifies everything else (transactions, storage items) using
+merkle and MPT proofs, so that RPC nodes it uses don't need to be trusted.
+
+Header chain starts from some trusted header (like genesis block or a recent
+checkpoint) and every next header is checked to be signed by consensus nodes
+specified in the previous one. Storage items are checked against state roots
+taken either from headers (for networks with StateRootInHeader setting
+enabled) or from state validators-signed state roots.
+*/
+package lightclient
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+)
+
+// Source is a source of untrusted chain data, RPC client implements it.
+type Source interface {
+	GetBlockByHash(hash util.Uint256) (*block.Block, error)
+	GetBlockHash(index uint32) (util.Uint256, error)
+	GetBlockHeader(hash util.Uint256) (*block.Header, error)
+	GetBlockHeaderCount() (uint32, error)
+	GetNetwork() netmode.Magic
+	GetProof(stateroot util.Uint256, contract util.Uint160, key []byte) (*result.ProofWithKey, error)
+	GetStateRootByHeight(height uint32) (*state.MPTRoot, error)
+	GetTransactionHeight(hash util.Uint256) (uint32, error)
+}
+
+// Various verification errors.
+var (
+	ErrInvalidHeader    = errors.New("invalid header")
+	ErrInvalidWitness   = errors.New("invalid witness")
+	ErrUnknownHeight    = errors.New("height is not synchronized yet")
+	ErrInvalidBlock     = errors.New("block doesn't match the header")
+	ErrTxNotFound       = errors.New("transaction is not found in the block")
+	ErrNoStateRoot      = errors.New("no trusted state root")
+	ErrStorageKeyDiffer = errors.New("proof is for a different key")
+	ErrContractDiffer   = errors.New("proof is for a different contract")
+)
+
+// Client maintains verified header chain.
+type Client struct {
+	src Source
+	net uint32
+	// stateValidators are trusted state validators keys used to verify
+	// state roots if headers don't contain them.
+	stateValidators keys.PublicKeys
+
+	lock   sync.RWMutex
+	hashes []util.Uint256
+	last   *block.Header
+}
+
+// New creates a new light client with the given data source and trusted
+// header to start from.
+func New(src Source, trusted *block.Header) *Client {
+	return &Client{
+		src:    src,
+		net:    uint32(src.GetNetwork()),
+		hashes: []util.Uint256{trusted.Hash()},
+		last:   trusted,
+	}
+}
+
+// SetStateValidators sets trusted state validators keys used to verify
+// state roots in networks without StateRootInHeader setting.
+func (c *Client) SetStateValidators(pubs keys.PublicKeys) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.stateValidators = pubs
+}
+
+// Height returns the index of the latest verified header.
+func (c *Client) Height() uint32 {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.last.Index
+}
+
+// GetHeaderHash returns the hash of verified header with the given index.
+func (c *Client) GetHeaderHash(index uint32) (util.Uint256, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.headerHash(index)
+}
+
+func (c *Client) headerHash(index uint32) (util.Uint256, error) {
+	start := c.last.Index + 1 - uint32(len(c.hashes))
+	if index < start || index > c.last.Index {
+		return util.Uint256{}, fmt.Errorf("%w: %d", ErrUnknownHeight, index)
+	}
+	return c.hashes[index-start], nil
+}
+
+// Sync fetches and verifies all headers available from the source, it
+// returns the new height.
+func (c *Client) Sync() (uint32, error) {
+	count, err := c.src.GetBlockHeaderCount()
+	if err != nil {
+		return c.Height(), err
+	}
+	for h := c.Height() + 1; h < count; h++ {
+		hash, err := c.src.GetBlockHash(h)
+		if err != nil {
+			return c.Height(), err
+		}
+		hdr, err := c.src.GetBlockHeader(hash)
+		if err != nil {
+			return c.Height(), err
+		}
+		if err := c.AddHeader(hdr); err != nil {
+			return c.Height(), err
+		}
+	}
+	return c.Height(), nil
+}
+
+// AddHeader verifies the next header and adds it to the chain.
+func (c *Client) AddHeader(h *block.Header) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	prev := c.last
+	switch {
+	case h.Index != prev.Index+1:
+		return fmt.Errorf("%w: expected index %d, got %d", ErrInvalidHeader, prev.Index+1, h.Index)
+	case !h.PrevHash.Equals(prev.Hash()):
+		return fmt.Errorf("%w: previous hash mismatch", ErrInvalidHeader)
+	case h.Timestamp <= prev.Timestamp:
+		return fmt.Errorf("%w: timestamp is not increasing", ErrInvalidHeader)
+	case !h.Script.ScriptHash().Equals(prev.NextConsensus):
+		return fmt.Errorf("%w: header %d is not signed by expected consensus nodes", ErrInvalidWitness, h.Index)
+	}
+	if err := verifyWitness(c.net, h, &h.Script); err != nil {
+		return fmt.Errorf("header %d: %w", h.Index, err)
+	}
+	c.hashes = append(c.hashes, h.Hash())
+	c.last = h
+	return nil
+}
+
+// GetTransaction returns transaction with the given hash along with the
+// index of the block it's included in after checking that the block
+// matches verified header.
+func (c *Client) GetTransaction(h util.Uint256) (*transaction.Transaction, uint32, error) {
+	height, err := c.src.GetTransactionHeight(h)
+	if err != nil {
+		return nil, 0, err
+	}
+	b, err := c.GetBlock(height)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, tx := range b.Transactions {
+		if tx.Hash().Equals(h) {
+			return tx, height, nil
+		}
+	}
+	return nil, 0, fmt.Errorf("%w: %s", ErrTxNotFound, h.StringLE())
+}
+
+// GetBlock returns block with the given index after checking that it
+// matches verified header and its transactions match the merkle root.
+func (c *Client) GetBlock(index uint32) (*block.Block, error) {
+	hash, err := c.GetHeaderHash(index)
+	if err != nil {
+		return nil, err
+	}
+	b, err := c.src.GetBlockByHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	if !b.Hash().Equals(hash) {
+		return nil, fmt.Errorf("%w: hash mismatch", ErrInvalidBlock)
+	}
+	if !b.ComputeMerkleRoot().Equals(b.MerkleRoot) {
+		return nil, fmt.Errorf("%w: merkle root mismatch", ErrInvalidBlock)
+	}
+	return b, nil
+}
+
+// GetStateRoot returns trusted state root hash for the state after the
+// block with the given index is processed. It's taken from the next
+// header if headers contain state roots and from state validators-signed
+// state root otherwise.
+func (c *Client) GetStateRoot(index uint32) (util.Uint256, error) {
+	c.lock.RLock()
+	last, validators := c.last, c.stateValidators
+	c.lock.RUnlock()
+
+	if last.StateRootEnabled {
+		hash, err := c.GetHeaderHash(index + 1)
+		if err != nil {
+			return util.Uint256{}, err
+		}
+		h, err := c.src.GetBlockHeader(hash)
+		if err != nil {
+			return util.Uint256{}, err
+		}
+		if !h.Hash().Equals(hash) {
+			return util.Uint256{}, fmt.Errorf("%w: hash mismatch", ErrInvalidHeader)
+		}
+		return h.PrevStateRoot, nil
+	}
+	if len(validators) == 0 {
+		return util.Uint256{}, ErrNoStateRoot
+	}
+	r, err := c.src.GetStateRootByHeight(index)
+	if err != nil {
+		return util.Uint256{}, err
+	}
+	if r.Index != index || len(r.Witness) != 1 {
+		return util.Uint256{}, fmt.Errorf("%w: unsigned state root %d", ErrNoStateRoot, index)
+	}
+	script, err := smartcontract.CreateDefaultMultiSigRedeemScript(validators.Copy())
+	if err != nil {
+		return util.Uint256{}, err
+	}
+	if !bytes.Equal(r.Witness[0].VerificationScript, script) {
+		return util.Uint256{}, fmt.Errorf("%w: state root is not signed by trusted validators", ErrInvalidWitness)
+	}
+	if err := verifyWitness(c.net, r, &r.Witness[0]); err != nil {
+		return util.Uint256{}, fmt.Errorf("state root %d: %w", index, err)
+	}
+	return r.Root, nil
+}
+
+// GetStorage returns contract storage item for the state after the block
+// with the given index is processed, the value is checked using MPT proof
+// against trusted state root. Contract ID of the item is checked using the
+// proof of contract state.
+func (c *Client) GetStorage(index uint32, contract util.Uint160, key []byte) (*result.VerifiedStorageItem, error) {
+	root, err := c.GetStateRoot(index)
+	if err != nil {
+		return nil, err
+	}
+	csProof, err := c.src.GetProof(root, result.ManagementContractHash, result.ContractStateKey(contract))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contract state proof: %w", err)
+	}
+	csID, err := result.CheckContractStateProof(root, contract, csProof)
+	if err != nil {
+		return nil, err
+	}
+	proof, err := c.src.GetProof(root, contract, key)
+	if err != nil {
+		return nil, err
+	}
+	id, k, err := proof.StorageKey()
+	if err != nil {
+		return nil, err
+	}
+	if id != csID {
+		return nil, ErrContractDiffer
+	}
+	if !bytes.Equal(k, key) {
+		return nil, ErrStorageKeyDiffer
+	}
+	val, err := mpt.CheckProof(root, proof.Key, proof.Proof)
+	if err != nil {
+		return nil, err
+	}
+	return &result.VerifiedStorageItem{
+		ContractID: id,
+		Key:        k,
+		Value:      val,
+	}, nil
+}
+
+// verifyWitness checks standard signature or multisignature witness of
+// the given hashable item without running the VM.
+func verifyWitness(net uint32, hh hash.Hashable, w *transaction.Witness) error {
+	sigs, err := parseSignatures(w.InvocationScript)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidWitness, err)
+	}
+	if pub, ok := vm.ParseSignatureContract(w.VerificationScript); ok {
+		pk, err := keys.NewPublicKeyFromBytes(pub, elliptic.P256())
+		if err != nil || len(sigs) != 1 || !pk.VerifyHashable(sigs[0], net, hh) {
+			return fmt.Errorf("%w: bad signature", ErrInvalidWitness)
+		}
+		return nil
+	}
+	m, pubs, ok := vm.ParseMultiSigContract(w.VerificationScript)
+	if !ok {
+		return fmt.Errorf("%w: non-standard verification script", ErrInvalidWitness)
+	}
+	if len(sigs) != m {
+		return fmt.Errorf("%w: expected %d signatures, got %d", ErrInvalidWitness, m, len(sigs))
+	}
+	// Signatures are to be ordered the same way keys are, the same way
+	// CheckMultisig interop expects them.
+	var k int
+	for _, sig := range sigs {
+		for ; k < len(pubs); k++ {
+			pk, err := keys.NewPublicKeyFromBytes(pubs[k], elliptic.P256())
+			if err == nil && pk.VerifyHashable(sig, net, hh) {
+				break
+			}
+		}
+		if k == len(pubs) {
+			return fmt.Errorf("%w: bad signature", ErrInvalidWitness)
+		}
+		k++
+	}
+	return nil
+}
+
+// parseSignatures returns signatures pushed by the invocation script.
+func parseSignatures(script []byte) ([][]byte, error) {
+	var sigs [][]byte
+	ctx := vm.NewContext(script)
+	for ctx.NextIP() < len(script) {
+		op, param, err := ctx.Next()
+		if err != nil {
+			return nil, err
+		}
+		if op != opcode.PUSHDATA1 || len(param) != keys.SignatureLen {
+			return nil, errors.New("invocation script should only push signatures")
+		}
+		sigs = append(sigs, param)
+	}
+	return sigs, nil
+}
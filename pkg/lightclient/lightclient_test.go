@@ -0,0 +1,328 @@
+package lightclient
+
+import (
+	"encoding/binary"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/stretchr/testify/require"
+)
+
+type testSource struct {
+	blocks  []*block.Block
+	txs     map[util.Uint256]uint32
+	trie    *mpt.Trie
+	roots   map[uint32]*state.MPTRoot
+	replace map[uint32]*block.Block
+	// ids are contract IDs used for storage proofs.
+	ids map[util.Uint160]int32
+}
+
+func (s *testSource) GetBlockByHash(h util.Uint256) (*block.Block, error) {
+	for _, b := range s.blocks {
+		if b.Hash().Equals(h) {
+			if r, ok := s.replace[b.Index]; ok {
+				return r, nil
+			}
+			return b, nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+func (s *testSource) GetBlockHash(index uint32) (util.Uint256, error) {
+	if int(index) >= len(s.blocks) {
+		return util.Uint256{}, errors.New("not found")
+	}
+	return s.blocks[index].Hash(), nil
+}
+
+func (s *testSource) GetBlockHeader(h util.Uint256) (*block.Header, error) {
+	b, err := s.GetBlockByHash(h)
+	if err != nil {
+		return nil, err
+	}
+	return &b.Header, nil
+}
+
+func (s *testSource) GetBlockHeaderCount() (uint32, error) {
+	return uint32(len(s.blocks)), nil
+}
+
+func (s *testSource) GetNetwork() netmode.Magic {
+	return netmode.UnitTestNet
+}
+
+func (s *testSource) GetProof(root util.Uint256, h util.Uint160, key []byte) (*result.ProofWithKey, error) {
+	id := s.ids[h]
+	if h.Equals(result.ManagementContractHash) {
+		id = -1
+	}
+	skey := make([]byte, 4+len(key))
+	binary.LittleEndian.PutUint32(skey, uint32(id))
+	copy(skey[4:], key)
+	proof, err := s.trie.GetProof(skey)
+	if err != nil {
+		return nil, err
+	}
+	return &result.ProofWithKey{Key: skey, Proof: proof}, nil
+}
+
+func (s *testSource) GetStateRootByHeight(height uint32) (*state.MPTRoot, error) {
+	r, ok := s.roots[height]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return r, nil
+}
+
+func (s *testSource) GetTransactionHeight(h util.Uint256) (uint32, error) {
+	height, ok := s.txs[h]
+	if !ok {
+		return 0, errors.New("not found")
+	}
+	return height, nil
+}
+
+func newTestKeys(t *testing.T, n int) ([]*keys.PrivateKey, keys.PublicKeys, []byte) {
+	privs := make([]*keys.PrivateKey, n)
+	for i := range privs {
+		p, err := keys.NewPrivateKey()
+		require.NoError(t, err)
+		privs[i] = p
+	}
+	sort.Slice(privs, func(i, j int) bool {
+		return privs[i].PublicKey().Cmp(privs[j].PublicKey()) == -1
+	})
+	pubs := make(keys.PublicKeys, n)
+	for i := range privs {
+		pubs[i] = privs[i].PublicKey()
+	}
+	script, err := smartcontract.CreateDefaultMultiSigRedeemScript(pubs.Copy())
+	require.NoError(t, err)
+	return privs, pubs, script
+}
+
+func signWitness(t *testing.T, hh hash.Hashable, privs []*keys.PrivateKey, script []byte) transaction.Witness {
+	n := smartcontract.GetDefaultHonestNodeCount(len(privs))
+	w := io.NewBufBinWriter()
+	for i := 0; i < n; i++ {
+		emit.Bytes(w.BinWriter, privs[i].SignHashable(uint32(netmode.UnitTestNet), hh))
+	}
+	require.NoError(t, w.Err)
+	return transaction.Witness{InvocationScript: w.Bytes(), VerificationScript: script}
+}
+
+func newTestSource(t *testing.T, count int, stateRootInHeader bool) (*testSource, []*keys.PrivateKey, []byte) {
+	privs, _, script := newTestKeys(t, 4)
+	nc := hash.Hash160(script)
+	src := &testSource{
+		txs:     make(map[util.Uint256]uint32),
+		trie:    mpt.NewTrie(nil, false, storage.NewMemCachedStore(storage.NewMemoryStore())),
+		roots:   make(map[uint32]*state.MPTRoot),
+		replace: make(map[uint32]*block.Block),
+		ids:     map[util.Uint160]int32{{}: 1},
+	}
+	ne, err := nef.NewFile([]byte{byte(opcode.RET)})
+	require.NoError(t, err)
+	cs := &state.Contract{ContractBase: state.ContractBase{
+		ID:       1,
+		NEF:      *ne,
+		Manifest: *manifest.NewManifest("test"),
+	}}
+	w := io.NewBufBinWriter()
+	cs.EncodeBinary(w.BinWriter)
+	require.NoError(t, w.Err)
+	csKey := append([]byte{0xff, 0xff, 0xff, 0xff}, result.ContractStateKey(cs.Hash)...)
+	require.NoError(t, src.trie.Put(csKey, w.Bytes()))
+	require.NoError(t, src.trie.Put([]byte{1, 0, 0, 0, 'k', 'e', 'y'}, []byte("value")))
+	require.NoError(t, src.trie.Put([]byte{2, 0, 0, 0, 'k', 'e', 'y'}, []byte("other")))
+	root := src.trie.StateRoot()
+
+	for i := 0; i < count; i++ {
+		b := block.New(stateRootInHeader)
+		b.Index = uint32(i)
+		b.Timestamp = uint64(i + 1)
+		b.NextConsensus = nc
+		if stateRootInHeader {
+			b.PrevStateRoot = root
+		}
+		if i != 0 {
+			b.PrevHash = src.blocks[i-1].Hash()
+			tx := transaction.New([]byte{byte(opcode.PUSH1)}, int64(i))
+			tx.Nonce = uint32(i)
+			b.Transactions = []*transaction.Transaction{tx}
+			src.txs[tx.Hash()] = b.Index
+		}
+		b.RebuildMerkleRoot()
+		if i != 0 {
+			b.Script = signWitness(t, b, privs, script)
+		}
+		src.blocks = append(src.blocks, b)
+	}
+	return src, privs, script
+}
+
+func TestClient_Sync(t *testing.T) {
+	src, privs, script := newTestSource(t, 5, true)
+	c := New(src, &src.blocks[0].Header)
+	h, err := c.Sync()
+	require.NoError(t, err)
+	require.Equal(t, uint32(4), h)
+	for i := range src.blocks {
+		hash, err := c.GetHeaderHash(uint32(i))
+		require.NoError(t, err)
+		require.Equal(t, src.blocks[i].Hash(), hash)
+	}
+	_, err = c.GetHeaderHash(5)
+	require.True(t, errors.Is(err, ErrUnknownHeight))
+
+	newHeader := func(sign bool) *block.Header {
+		h := block.New(true).Header
+		h.Index = 5
+		h.Timestamp = 6
+		h.PrevHash = src.blocks[4].Hash()
+		h.StateRootEnabled = true
+		if sign {
+			h.Script = signWitness(t, &h, privs, script)
+		}
+		return &h
+	}
+	t.Run("bad index", func(t *testing.T) {
+		h := newHeader(true)
+		h.Index = 6
+		require.True(t, errors.Is(c.AddHeader(h), ErrInvalidHeader))
+	})
+	t.Run("unsigned", func(t *testing.T) {
+		h := newHeader(false)
+		h.Script.VerificationScript = script
+		require.True(t, errors.Is(c.AddHeader(h), ErrInvalidWitness))
+	})
+	t.Run("wrong signers", func(t *testing.T) {
+		otherPrivs, _, otherScript := newTestKeys(t, 4)
+		h := newHeader(false)
+		h.Script = signWitness(t, h, otherPrivs, otherScript)
+		require.True(t, errors.Is(c.AddHeader(h), ErrInvalidWitness))
+	})
+	t.Run("good", func(t *testing.T) {
+		require.NoError(t, c.AddHeader(newHeader(true)))
+		require.Equal(t, uint32(5), c.Height())
+	})
+}
+
+func TestClient_GetTransaction(t *testing.T) {
+	src, _, _ := newTestSource(t, 3, true)
+	c := New(src, &src.blocks[0].Header)
+	_, err := c.Sync()
+	require.NoError(t, err)
+
+	expected := src.blocks[2].Transactions[0]
+	tx, h, err := c.GetTransaction(expected.Hash())
+	require.NoError(t, err)
+	require.Equal(t, uint32(2), h)
+	require.Equal(t, expected.Hash(), tx.Hash())
+
+	t.Run("forged block", func(t *testing.T) {
+		b := *src.blocks[2]
+		b.Transactions = []*transaction.Transaction{transaction.New([]byte{byte(opcode.PUSH2)}, 0), expected}
+		src.replace[2] = &b
+		defer delete(src.replace, 2)
+		_, _, err := c.GetTransaction(expected.Hash())
+		require.True(t, errors.Is(err, ErrInvalidBlock), "got: %v", err)
+	})
+}
+
+func TestClient_GetStorage(t *testing.T) {
+	src, _, _ := newTestSource(t, 3, true)
+	c := New(src, &src.blocks[0].Header)
+	_, err := c.Sync()
+	require.NoError(t, err)
+
+	item, err := c.GetStorage(1, util.Uint160{}, []byte("key"))
+	require.NoError(t, err)
+	require.Equal(t, int32(1), item.ContractID)
+	require.Equal(t, []byte("value"), item.Value)
+
+	_, err = c.GetStorage(1, util.Uint160{}, []byte("unknown"))
+	require.Error(t, err)
+
+	src.ids[util.Uint160{}] = 2
+	_, err = c.GetStorage(1, util.Uint160{}, []byte("key"))
+	require.True(t, errors.Is(err, ErrContractDiffer), "got: %v", err)
+	src.ids[util.Uint160{}] = 1
+
+	_, err = c.GetStorage(1, util.Uint160{1, 2, 3}, []byte("key"))
+	require.Error(t, err)
+
+	_, err = c.GetStateRoot(2)
+	require.True(t, errors.Is(err, ErrUnknownHeight))
+}
+
+func TestClient_GetStateRootValidated(t *testing.T) {
+	src, _, _ := newTestSource(t, 3, false)
+	c := New(src, &src.blocks[0].Header)
+	_, err := c.Sync()
+	require.NoError(t, err)
+
+	_, err = c.GetStateRoot(1)
+	require.True(t, errors.Is(err, ErrNoStateRoot), "got: %v", err)
+
+	privs, pubs, script := newTestKeys(t, 4)
+	c.SetStateValidators(pubs)
+	_, err = c.GetStateRoot(1)
+	require.Error(t, err)
+
+	newRoot := func(index uint32, privs []*keys.PrivateKey, script []byte) *state.MPTRoot {
+		r := &state.MPTRoot{Index: index, Root: src.trie.StateRoot()}
+		r.Witness = []transaction.Witness{signWitness(t, r, privs, script)}
+		return r
+	}
+	src.roots[1] = newRoot(1, privs, script)
+	root, err := c.GetStateRoot(1)
+	require.NoError(t, err)
+	require.Equal(t, src.trie.StateRoot(), root)
+
+	item, err := c.GetStorage(1, util.Uint160{}, []byte("key"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), item.Value)
+
+	t.Run("unsigned", func(t *testing.T) {
+		src.roots[2] = &state.MPTRoot{Index: 2, Root: src.trie.StateRoot()}
+		_, err := c.GetStateRoot(2)
+		require.True(t, errors.Is(err, ErrNoStateRoot), "got: %v", err)
+	})
+	t.Run("wrong index", func(t *testing.T) {
+		src.roots[2] = newRoot(1, privs, script)
+		_, err := c.GetStateRoot(2)
+		require.True(t, errors.Is(err, ErrNoStateRoot), "got: %v", err)
+	})
+	t.Run("wrong signers", func(t *testing.T) {
+		otherPrivs, _, otherScript := newTestKeys(t, 4)
+		src.roots[2] = newRoot(2, otherPrivs, otherScript)
+		_, err := c.GetStateRoot(2)
+		require.True(t, errors.Is(err, ErrInvalidWitness), "got: %v", err)
+	})
+	t.Run("bad signature", func(t *testing.T) {
+		otherPrivs, _, _ := newTestKeys(t, 4)
+		src.roots[2] = newRoot(2, otherPrivs, script)
+		_, err := c.GetStateRoot(2)
+		require.True(t, errors.Is(err, ErrInvalidWitness), "got: %v", err)
+	})
+}
@@ -26,7 +26,7 @@ func (cs *Capabilities) EncodeBinary(br *io.BinWriter) {
 // checkUniqueCapabilities checks whether payload capabilities have unique type.
 func (cs Capabilities) checkUniqueCapabilities() error {
 	err := errors.New("capabilities with the same type are not allowed")
-	var isFullNode, isTCP, isWS bool
+	var isFullNode, isTCP, isWS, isCompression bool
 	for _, cap := range cs {
 		switch cap.Type {
 		case FullNode:
@@ -44,6 +44,11 @@ func (cs Capabilities) checkUniqueCapabilities() error {
 				return err
 			}
 			isWS = true
+		case ExtendedCompression:
+			if isCompression {
+				return err
+			}
+			isCompression = true
 		}
 	}
 	return nil
@@ -63,6 +68,8 @@ func (c *Capability) DecodeBinary(br *io.BinReader) {
 		c.Data = &Node{}
 	case TCPServer, WSServer:
 		c.Data = &Server{}
+	case ExtendedCompression:
+		c.Data = &Empty{}
 	default:
 		br.Err = errors.New("unknown node capability type")
 		return
@@ -95,6 +102,15 @@ func (n *Node) EncodeBinary(bw *io.BinWriter) {
 	bw.WriteU32LE(n.StartHeight)
 }
 
+// Empty represents capability without any data.
+type Empty struct{}
+
+// DecodeBinary implements Serializable interface.
+func (e *Empty) DecodeBinary(br *io.BinReader) {}
+
+// EncodeBinary implements Serializable interface.
+func (e *Empty) EncodeBinary(bw *io.BinWriter) {}
+
 // Server represents TCP or WS server capability with port
 type Server struct {
 	// Port is the port this server is listening on
@@ -10,4 +10,9 @@ const (
 	WSServer Type = 0x02
 	// FullNode represents full node capability type
 	FullNode Type = 0x10
+	// ExtendedCompression represents NeoGo-specific capability of node
+	// accepting compressed headers. Nodes not aware of it
+	// (including C# ones and older NeoGo versions) reject handshakes with
+	// it, so it should only be used in networks where all nodes support it.
+	ExtendedCompression Type = 0xf0
 )
@@ -0,0 +1,213 @@
+package network
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"go.uber.org/zap"
+)
+
+// headerWindow is the number of headers above the current header height that
+// can be requested (and stored) at the same time when syncing in headers-first
+// mode.
+const headerWindow = 4 * payload.MaxHeadersAllowed
+
+const (
+	// maxPeerHeaderRequests is the maximum number of header ranges tracked
+	// as requested from a single peer, the oldest ones are forgotten.
+	maxPeerHeaderRequests = headerWindow / payload.MaxHeadersAllowed
+	// maxQueuedHeaders is the maximum number of header batches kept in the
+	// queue, other ones are dropped until the queue is drained.
+	maxQueuedHeaders = 2 * headerWindow / payload.MaxHeadersAllowed
+)
+
+// headerQueue keeps header batches received out of order until they can be
+// added to the chain. It also tracks header ranges requested from peers, so
+// that only requested batches are accepted.
+type headerQueue struct {
+	lock      sync.Mutex
+	batches   map[uint32]queuedHeaders
+	requested map[Peer][]uint32
+}
+
+// queuedHeaders is a header batch along with the peer that sent it.
+type queuedHeaders struct {
+	peer    Peer
+	headers *payload.Headers
+}
+
+func newHeaderQueue() *headerQueue {
+	return &headerQueue{
+		batches:   make(map[uint32]queuedHeaders),
+		requested: make(map[Peer][]uint32),
+	}
+}
+
+// request remembers that headers starting from the given index were requested
+// from the peer.
+func (q *headerQueue) request(p Peer, start uint32) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	starts := q.requested[p]
+	for _, s := range starts {
+		if s == start {
+			return
+		}
+	}
+	if len(starts) >= maxPeerHeaderRequests {
+		starts = starts[1:]
+	}
+	q.requested[p] = append(starts, start)
+}
+
+// accept checks whether headers starting from the given index were requested
+// from the peer and forgets this request if so.
+func (q *headerQueue) accept(p Peer, start uint32) bool {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	starts := q.requested[p]
+	for i, s := range starts {
+		if s == start {
+			q.requested[p] = append(starts[:i:i], starts[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// removePeer drops requests tracked for the peer and batches received from it.
+func (q *headerQueue) removePeer(p Peer) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	delete(q.requested, p)
+	for i, b := range q.batches {
+		if b.peer == p {
+			delete(q.batches, i)
+		}
+	}
+}
+
+// put stores the batch received from the peer, it's dropped if there is
+// another one with the same starting index or the queue is full.
+func (q *headerQueue) put(p Peer, h *payload.Headers) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	first := h.Hdrs[0].Index
+	if _, ok := q.batches[first]; ok || len(q.batches) >= maxQueuedHeaders {
+		return
+	}
+	q.batches[first] = queuedHeaders{peer: p, headers: h}
+}
+
+// popNext returns the batch that can be added after the given header height
+// (and the peer it was received from), outdated batches are dropped in
+// process.
+func (q *headerQueue) popNext(height uint32) (Peer, *payload.Headers) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	indexes := make([]uint32, 0, len(q.batches))
+	for i := range q.batches {
+		indexes = append(indexes, i)
+	}
+	sort.Slice(indexes, func(i, j int) bool { return indexes[i] < indexes[j] })
+	for _, i := range indexes {
+		if i > height+1 {
+			break
+		}
+		b := q.batches[i]
+		delete(q.batches, i)
+		if b.headers.Hdrs[len(b.headers.Hdrs)-1].Index > height {
+			return b.peer, b.headers
+		}
+	}
+	return nil, nil
+}
+
+// useHeadersFirst answers whether headers should be synchronized ahead of
+// blocks.
+func (s *Server) useHeadersFirst() bool {
+	// Headers can't be verified ahead of blocks with state roots in them.
+	return s.HeadersFirstSync && !s.stateRootInHeader
+}
+
+// requestHeaders sends a CMDGetHeaders message to the peer. Different header
+// ranges are requested from different peers (similar to requestBlocks), but
+// no more than headerWindow headers above the current header height.
+func (s *Server) requestHeaders(p Peer) error {
+	var (
+		currHeight = s.chain.HeaderHeight()
+		peerHeight = p.LastBlockIndex()
+		needHeight uint32
+	)
+	// lastRequestedHeader can only be increased.
+	for {
+		old := s.lastRequestedHeader.Load()
+		if old <= currHeight {
+			needHeight = currHeight + 1
+			if !s.lastRequestedHeader.CAS(old, needHeight) {
+				continue
+			}
+		} else if old < currHeight+(headerWindow-payload.MaxHeadersAllowed) {
+			needHeight = currHeight + 1
+			if peerHeight > old+payload.MaxHeadersAllowed {
+				needHeight = old + payload.MaxHeadersAllowed
+				if !s.lastRequestedHeader.CAS(old, needHeight) {
+					continue
+				}
+			}
+		} else {
+			// The window is full, the lowest range is the most important
+			// one (it might have been lost).
+			needHeight = currHeight + 1
+		}
+		break
+	}
+	s.headerQueue.request(p, needHeight)
+	return p.EnqueueP2PMessage(NewMessage(CMDGetHeaders, payload.NewGetBlockByIndex(needHeight, -1)))
+}
+
+// handleHeadersCmd processes headers received from the peer. Only ranges
+// requested from this peer are accepted. Headers following the current header
+// height are added to the chain, other ones within headerWindow are queued
+// until missing headers arrive. Batches are attributed to the peer that sent
+// them, so it's disconnected (and penalized) if they're invalid.
+func (s *Server) handleHeadersCmd(p Peer, h *payload.Headers) error {
+	if !s.useHeadersFirst() || len(h.Hdrs) == 0 {
+		return nil
+	}
+	var (
+		height = s.chain.HeaderHeight()
+		first  = h.Hdrs[0].Index
+	)
+	if !s.headerQueue.accept(p, first) {
+		s.log.Debug("unrequested headers", zap.Stringer("addr", p.RemoteAddr()),
+			zap.Uint32("start", first))
+		return nil
+	}
+	if first > height+1 {
+		if first <= height+headerWindow {
+			s.headerQueue.put(p, h)
+		}
+		return nil
+	}
+	if err := s.chain.AddHeaders(h.Hdrs...); err != nil {
+		return violationError{fmt.Errorf("invalid headers: %w", err)}
+	}
+	for peer, next := s.headerQueue.popNext(s.chain.HeaderHeight()); next != nil; peer, next = s.headerQueue.popNext(s.chain.HeaderHeight()) {
+		if err := s.chain.AddHeaders(next.Hdrs...); err != nil {
+			s.log.Debug("failed to add queued headers",
+				zap.Uint32("start", next.Hdrs[0].Index), zap.Error(err))
+			if peer != p {
+				peer.Disconnect(violationError{fmt.Errorf("invalid headers: %w", err)})
+				continue
+			}
+			return violationError{fmt.Errorf("invalid headers: %w", err)}
+		}
+	}
+	if p.LastBlockIndex() > s.chain.HeaderHeight() {
+		return s.requestHeaders(p)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package network
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/stretchr/testify/require"
+)
+
+func newHeadersBatch(start, count uint32) *payload.Headers {
+	h := &payload.Headers{Hdrs: make([]*block.Header, count)}
+	for i := range h.Hdrs {
+		h.Hdrs[i] = &block.Header{Index: start + uint32(i)}
+	}
+	return h
+}
+
+func TestHeaderQueue(t *testing.T) {
+	q := newHeaderQueue()
+	p1, p2 := newLocalPeer(t, nil), newLocalPeer(t, nil)
+	popNext := func(height uint32) *payload.Headers {
+		_, h := q.popNext(height)
+		return h
+	}
+	require.Nil(t, popNext(0))
+
+	b1 := newHeadersBatch(1, 10)
+	b2 := newHeadersBatch(11, 10)
+	b3 := newHeadersBatch(31, 10)
+	q.put(p1, b3)
+	q.put(p1, b2)
+	q.put(p2, newHeadersBatch(11, 5)) // Duplicate start is ignored.
+	require.Nil(t, popNext(0))
+	q.put(p2, b1)
+	p, h := q.popNext(0)
+	require.Equal(t, b1, h)
+	require.Equal(t, p2, p)
+	p, h = q.popNext(10)
+	require.Equal(t, b2, h)
+	require.Equal(t, p1, p)
+	require.Nil(t, popNext(20))
+
+	// Outdated batches are dropped.
+	q.put(p1, newHeadersBatch(21, 5))
+	require.Equal(t, b3, popNext(30))
+	require.Equal(t, 0, len(q.batches))
+
+	t.Run("limit", func(t *testing.T) {
+		for i := 0; i < maxQueuedHeaders+1; i++ {
+			q.put(p1, newHeadersBatch(uint32(i*10+1), 10))
+		}
+		require.Equal(t, maxQueuedHeaders, len(q.batches))
+		q.put(p2, newHeadersBatch(1000, 10))
+		require.Equal(t, maxQueuedHeaders, len(q.batches))
+
+		q.removePeer(p1)
+		require.Equal(t, 0, len(q.batches))
+	})
+}
+
+func TestHeaderQueueRequests(t *testing.T) {
+	q := newHeaderQueue()
+	p1, p2 := newLocalPeer(t, nil), newLocalPeer(t, nil)
+
+	q.request(p1, 1)
+	q.request(p1, 2001)
+	q.request(p1, 2001) // Duplicate.
+	require.False(t, q.accept(p2, 1))
+	require.False(t, q.accept(p1, 4001))
+	require.True(t, q.accept(p1, 2001))
+	require.False(t, q.accept(p1, 2001))
+	require.True(t, q.accept(p1, 1))
+
+	// The oldest requests are forgotten.
+	for i := 0; i < maxPeerHeaderRequests+1; i++ {
+		q.request(p2, uint32(i))
+	}
+	require.False(t, q.accept(p2, 0))
+	require.True(t, q.accept(p2, 1))
+
+	q.removePeer(p2)
+	require.False(t, q.accept(p2, 2))
+}
+
+func TestRequestHeaders(t *testing.T) {
+	s := newTestServer(t, ServerConfig{HeadersFirstSync: true})
+	require.True(t, s.useHeadersFirst())
+
+	var requests []*Message
+	ps := make([]*localPeer, 5)
+	for i := range ps {
+		ps[i] = newLocalPeer(t, s)
+		ps[i].lastBlockIndex = 100000
+		ps[i].messageHandler = func(t *testing.T, msg *Message) {
+			requests = append(requests, msg)
+		}
+	}
+	for i := range ps {
+		require.NoError(t, s.requestBlocks(ps[i]))
+	}
+	// Headers only, no blocks known to fetch.
+	expected := []uint32{1, 2001, 4001, 6001, 1}
+	require.Equal(t, len(expected), len(requests))
+	for i := range requests {
+		require.Equal(t, CMDGetHeaders, requests[i].Command)
+		require.Equal(t, expected[i], requests[i].Payload.(*payload.GetBlockByIndex).IndexStart)
+	}
+
+	s.stateRootInHeader = true
+	require.False(t, s.useHeadersFirst())
+}
+
+func TestHandleHeadersUnrequested(t *testing.T) {
+	s := newTestServer(t, ServerConfig{HeadersFirstSync: true})
+	p := newLocalPeer(t, s)
+	// Chain is not touched for unrequested batches.
+	require.NoError(t, s.handleHeadersCmd(p, newHeadersBatch(1, 10)))
+	require.NoError(t, s.handleHeadersCmd(p, newHeadersBatch(2001, 10)))
+	require.Equal(t, 0, len(s.headerQueue.batches))
+}
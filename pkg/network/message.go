@@ -32,6 +32,11 @@ type Message struct {
 	// StateRootInHeader specifies if state root is included in block header.
 	// This is needed for correct decoding.
 	StateRootInHeader bool
+
+	// extendedCompression allows to compress headers that are not
+	// compressed by default, it can only be used for peers having
+	// capability.ExtendedCompression.
+	extendedCompression bool
 }
 
 // MessageFlag represents compression level of message payload
@@ -208,25 +213,31 @@ func (m *Message) tryCompressPayload() error {
 		return buf.Err
 	}
 	compressedPayload := buf.Bytes()
-	if m.Flags&Compressed == 0 {
-		switch m.Payload.(type) {
-		case *payload.Headers, *payload.MerkleBlock, payload.NullPayload,
-			*payload.Inventory:
-			break
-		default:
-			size := len(compressedPayload)
-			// try compression
-			if size > CompressionMinSize {
-				c, err := compress(compressedPayload)
-				if err == nil {
-					compressedPayload = c
-					m.Flags |= Compressed
-				} else {
-					return err
-				}
+	if m.Flags&Compressed == 0 && m.shouldCompress() {
+		size := len(compressedPayload)
+		// try compression
+		if size > CompressionMinSize {
+			c, err := compress(compressedPayload)
+			if err == nil {
+				compressedPayload = c
+				m.Flags |= Compressed
+			} else {
+				return err
 			}
 		}
 	}
 	m.compressedPayload = compressedPayload
 	return nil
 }
+
+// shouldCompress returns true if message payload can be compressed.
+func (m *Message) shouldCompress() bool {
+	switch m.Payload.(type) {
+	case *payload.MerkleBlock, payload.NullPayload, *payload.Inventory:
+		return false
+	case *payload.Headers:
+		return m.extendedCompression
+	default:
+		return true
+	}
+}
@@ -71,6 +71,18 @@ func TestEncodeDecodeHeaders(t *testing.T) {
 	uncompressed, err := testserdes.EncodeBinary(expected.Payload)
 	require.NoError(t, err)
 	require.Equal(t, len(expected.compressedPayload), len(uncompressed))
+
+	t.Run("extended compression", func(t *testing.T) {
+		expected := NewMessage(CMDHeaders, headers)
+		expected.extendedCompression = true
+		data, err := testserdes.Encode(expected)
+		require.NoError(t, err)
+		actual := &Message{}
+		require.NoError(t, testserdes.Decode(data, actual))
+		require.True(t, expected.Flags&Compressed != 0)
+		require.Equal(t, expected.Payload, actual.Payload)
+		require.True(t, len(expected.compressedPayload) < len(uncompressed))
+	})
 }
 
 func TestEncodeDecodeGetAddr(t *testing.T) {
@@ -0,0 +1,85 @@
+package network
+
+import (
+	"net"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/network/nat"
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultNATLifetime is the default port mapping lifetime.
+	defaultNATLifetime = time.Hour
+	// natRetryInterval is the time to wait before retrying failed port
+	// mapping request.
+	natRetryInterval = time.Minute
+)
+
+// mapPortLoop maps node's P2P port on NAT-PMP gateway and renews the mapping
+// until the server is shut down, the mapping is deleted then.
+func (s *Server) mapPortLoop() {
+	var gw net.IP
+	if s.NAT.Gateway != "" {
+		gw = net.ParseIP(s.NAT.Gateway)
+		if gw == nil {
+			s.log.Error("invalid NAT gateway address", zap.String("gateway", s.NAT.Gateway))
+			return
+		}
+	}
+	if s.ServerConfig.Port == 0 {
+		s.log.Warn("can't map random P2P port on NAT gateway")
+		return
+	}
+	c, err := nat.NewClient(gw)
+	if err != nil {
+		s.log.Error("can't create NAT-PMP client", zap.Error(err))
+		return
+	}
+
+	var (
+		port     = s.ServerConfig.Port
+		lifetime = s.NAT.Lifetime
+		mapped   bool
+	)
+	if lifetime <= 0 {
+		lifetime = defaultNATLifetime
+	}
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-s.quit:
+			if mapped {
+				if err := c.DeletePortMapping(nat.TCP, port); err != nil {
+					s.log.Warn("failed to delete NAT port mapping", zap.Error(err))
+				}
+			}
+			return
+		case <-timer.C:
+		}
+		m, err := c.AddPortMapping(nat.TCP, port, port, lifetime)
+		if err != nil {
+			s.log.Warn("failed to map port on NAT gateway", zap.Error(err))
+			timer.Reset(natRetryInterval)
+			continue
+		}
+		if !mapped {
+			mapped = true
+			fields := []zap.Field{zap.Uint16("port", m.ExternalPort), zap.Duration("lifetime", m.Lifetime)}
+			if ip, err := c.ExternalAddress(); err == nil {
+				fields = append(fields, zap.Stringer("address", ip))
+			}
+			s.log.Info("mapped P2P port on NAT gateway", fields...)
+			if m.ExternalPort != port {
+				s.log.Warn("NAT gateway mapped P2P port to a different external port, inbound connections may not work",
+					zap.Uint16("internal", port), zap.Uint16("external", m.ExternalPort))
+			}
+		}
+		renew := m.Lifetime / 2
+		if renew <= 0 {
+			renew = natRetryInterval
+		}
+		timer.Reset(renew)
+	}
+}
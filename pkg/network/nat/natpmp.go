@@ -0,0 +1,203 @@
+/*
+Package nat implements NAT-PMP (RFC 6886) client that can be used to map
+node's P2P port on the home router.
+*/
+package nat
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	// natPMPPort is the port NAT-PMP gateway listens on.
+	natPMPPort = 5351
+	// initialTimeout is the timeout for the first request attempt, it's
+	// doubled for every subsequent one.
+	initialTimeout = 250 * time.Millisecond
+	// maxAttempts is the number of request attempts made before giving up.
+	maxAttempts = 5
+
+	opExternalAddress = 0
+	opMapUDP          = 1
+	opMapTCP          = 2
+)
+
+// Protocols that can be mapped.
+const (
+	TCP = "tcp"
+	UDP = "udp"
+)
+
+// ErrNoGateway is returned when default gateway can't be determined.
+var ErrNoGateway = errors.New("can't determine default gateway")
+
+// resultErrors are descriptions of NAT-PMP result codes.
+var resultErrors = map[uint16]string{
+	1: "unsupported version",
+	2: "not authorized/refused",
+	3: "network failure",
+	4: "out of resources",
+	5: "unsupported opcode",
+}
+
+// Client is NAT-PMP client.
+type Client struct {
+	addr string
+}
+
+// Mapping is port mapping created by the gateway.
+type Mapping struct {
+	Protocol     string
+	InternalPort uint16
+	ExternalPort uint16
+	Lifetime     time.Duration
+}
+
+// NewClient returns a client for the given gateway, if gateway is nil default
+// one is used (this is only supported on Linux).
+func NewClient(gateway net.IP) (*Client, error) {
+	if gateway == nil {
+		var err error
+		gateway, err = DefaultGateway()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &Client{addr: net.JoinHostPort(gateway.String(), fmt.Sprint(natPMPPort))}, nil
+}
+
+// ExternalAddress returns external (public) address of the gateway.
+func (c *Client) ExternalAddress() (net.IP, error) {
+	resp, err := c.call([]byte{0, opExternalAddress}, 12)
+	if err != nil {
+		return nil, err
+	}
+	return net.IPv4(resp[8], resp[9], resp[10], resp[11]), nil
+}
+
+// AddPortMapping requests mapping of the given internal port to the external
+// one for the specified lifetime. Gateway can choose a different external
+// port (and lifetime), so the result should be checked.
+func (c *Client) AddPortMapping(protocol string, internal, external uint16, lifetime time.Duration) (*Mapping, error) {
+	var op byte
+	switch protocol {
+	case TCP:
+		op = opMapTCP
+	case UDP:
+		op = opMapUDP
+	default:
+		return nil, fmt.Errorf("unsupported protocol: %s", protocol)
+	}
+	req := make([]byte, 12)
+	req[1] = op
+	binary.BigEndian.PutUint16(req[4:], internal)
+	binary.BigEndian.PutUint16(req[6:], external)
+	binary.BigEndian.PutUint32(req[8:], uint32(lifetime/time.Second))
+	resp, err := c.call(req, 16)
+	if err != nil {
+		return nil, err
+	}
+	return &Mapping{
+		Protocol:     protocol,
+		InternalPort: binary.BigEndian.Uint16(resp[8:]),
+		ExternalPort: binary.BigEndian.Uint16(resp[10:]),
+		Lifetime:     time.Duration(binary.BigEndian.Uint32(resp[12:])) * time.Second,
+	}, nil
+}
+
+// DeletePortMapping deletes mapping for the given internal port.
+func (c *Client) DeletePortMapping(protocol string, internal uint16) error {
+	_, err := c.AddPortMapping(protocol, internal, 0, 0)
+	return err
+}
+
+// call sends request to the gateway retrying it with increasing timeouts and
+// returns a response of the given size after checking its header.
+func (c *Client) call(req []byte, size int) ([]byte, error) {
+	conn, err := net.Dial("udp", c.addr)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	var (
+		timeout = initialTimeout
+		resp    = make([]byte, 16)
+	)
+	for i := 0; i < maxAttempts; i++ {
+		if _, err = conn.Write(req); err != nil {
+			return nil, err
+		}
+		if err = conn.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+			return nil, err
+		}
+		var n int
+		n, err = conn.Read(resp)
+		if err != nil {
+			var ne net.Error
+			if errors.As(err, &ne) && ne.Timeout() {
+				timeout *= 2
+				continue
+			}
+			return nil, err
+		}
+		// Skip responses to other requests.
+		if n < size || resp[0] != 0 || resp[1] != req[1]|0x80 {
+			continue
+		}
+		code := binary.BigEndian.Uint16(resp[2:])
+		if code != 0 {
+			if msg, ok := resultErrors[code]; ok {
+				return nil, fmt.Errorf("gateway error: %s", msg)
+			}
+			return nil, fmt.Errorf("gateway error: code %d", code)
+		}
+		return resp[:size], nil
+	}
+	if err == nil {
+		err = errors.New("no valid response")
+	}
+	return nil, fmt.Errorf("NAT-PMP request failed: %w", err)
+}
+
+// DefaultGateway returns default IPv4 gateway address, it's only supported on
+// Linux.
+func DefaultGateway() (net.IP, error) {
+	f, err := os.Open("/proc/net/route")
+	if err != nil {
+		return nil, ErrNoGateway
+	}
+	defer f.Close()
+	return parseRoutes(f)
+}
+
+// parseRoutes finds default gateway in Linux /proc/net/route table.
+func parseRoutes(r io.Reader) (net.IP, error) {
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) < 3 || fields[1] != "00000000" {
+			continue
+		}
+		b, err := hex.DecodeString(fields[2])
+		if err != nil || len(b) != 4 {
+			continue
+		}
+		// Addresses are in host (little-endian) byte order.
+		ip := net.IPv4(b[3], b[2], b[1], b[0])
+		if ip.IsUnspecified() {
+			continue
+		}
+		return ip, nil
+	}
+	return nil, ErrNoGateway
+}
@@ -0,0 +1,93 @@
+package nat
+
+import (
+	"encoding/binary"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// startGateway starts fake NAT-PMP gateway.
+func startGateway(t *testing.T, code uint16) *Client {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, 16)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			if n < 2 {
+				continue
+			}
+			var resp []byte
+			switch buf[1] {
+			case opExternalAddress:
+				resp = make([]byte, 12)
+				copy(resp[8:], []byte{203, 0, 113, 7})
+			case opMapTCP, opMapUDP:
+				resp = make([]byte, 16)
+				copy(resp[8:], buf[4:6])
+				ext := binary.BigEndian.Uint16(buf[6:])
+				if ext != 0 {
+					ext++
+				}
+				binary.BigEndian.PutUint16(resp[10:], ext)
+				copy(resp[12:], buf[8:12])
+			default:
+				continue
+			}
+			resp[1] = buf[1] | 0x80
+			binary.BigEndian.PutUint16(resp[2:], code)
+			_, _ = conn.WriteTo(resp, addr)
+		}
+	}()
+	return &Client{addr: conn.LocalAddr().String()}
+}
+
+func TestClient(t *testing.T) {
+	c := startGateway(t, 0)
+
+	ip, err := c.ExternalAddress()
+	require.NoError(t, err)
+	require.Equal(t, "203.0.113.7", ip.String())
+
+	m, err := c.AddPortMapping(TCP, 20333, 20333, time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, &Mapping{
+		Protocol:     TCP,
+		InternalPort: 20333,
+		ExternalPort: 20334,
+		Lifetime:     time.Hour,
+	}, m)
+
+	require.NoError(t, c.DeletePortMapping(TCP, 20333))
+
+	_, err = c.AddPortMapping("sctp", 20333, 20333, time.Hour)
+	require.Error(t, err)
+
+	t.Run("error code", func(t *testing.T) {
+		c := startGateway(t, 2)
+		_, err := c.AddPortMapping(TCP, 20333, 20333, time.Hour)
+		require.Error(t, err)
+	})
+}
+
+func TestParseRoutes(t *testing.T) {
+	const routes = `Iface	Destination	Gateway 	Flags	RefCnt	Use	Metric	Mask		MTU	Window	IRTT
+eth0	0000A8C0	00000000	0001	0	0	0	00FFFFFF	0	0	0
+eth0	00000000	0100A8C0	0003	0	0	0	00000000	0	0	0
+`
+	ip, err := parseRoutes(strings.NewReader(routes))
+	require.NoError(t, err)
+	require.Equal(t, "192.168.0.1", ip.String())
+
+	_, err = parseRoutes(strings.NewReader(strings.SplitN(routes, "\n", 3)[0]))
+	require.Equal(t, ErrNoGateway, err)
+}
@@ -0,0 +1,203 @@
+package network
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/network/capability"
+	"go.uber.org/zap"
+)
+
+const (
+	// maxKnownPeers is the maximum number of peers stored in the peer book.
+	maxKnownPeers = 1000
+	// knownPeerMaxAge is the time after which peer not seen is forgotten.
+	knownPeerMaxAge = 7 * 24 * time.Hour
+	// peerBookSaveInterval is the interval between peer book saves.
+	peerBookSaveInterval = 10 * time.Minute
+)
+
+// knownPeer is a peer address book entry.
+type knownPeer struct {
+	Address string
+	// LastSeen is the time of the last successful handshake (in
+	// milliseconds).
+	LastSeen uint64
+	// Connections is the number of successful handshakes with the peer.
+	Connections uint32
+}
+
+// peerBook keeps track of peers node successfully connected to, so that it
+// can reconnect to them after restart. Only peers accepting connections are
+// stored there and the number of entries never exceeds maxKnownPeers.
+type peerBook struct {
+	lock  sync.Mutex
+	peers map[string]*knownPeer
+}
+
+// EncodeBinary implements io.Serializable interface.
+func (p *knownPeer) EncodeBinary(w *io.BinWriter) {
+	w.WriteString(p.Address)
+	w.WriteU64LE(p.LastSeen)
+	w.WriteU32LE(p.Connections)
+}
+
+// DecodeBinary implements io.Serializable interface.
+func (p *knownPeer) DecodeBinary(r *io.BinReader) {
+	p.Address = r.ReadString()
+	p.LastSeen = r.ReadU64LE()
+	p.Connections = r.ReadU32LE()
+}
+
+func newPeerBook() *peerBook {
+	return &peerBook{
+		peers: make(map[string]*knownPeer),
+	}
+}
+
+func toMillis(t time.Time) uint64 {
+	return uint64(t.UnixNano() / int64(time.Millisecond))
+}
+
+// seen registers successful handshake with the peer at the given address,
+// the least recently seen peer is forgotten if the book is full.
+func (b *peerBook) seen(addr string, now time.Time) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	p, ok := b.peers[addr]
+	if !ok {
+		if len(b.peers) >= maxKnownPeers {
+			b.evict()
+		}
+		p = &knownPeer{Address: addr}
+		b.peers[addr] = p
+	}
+	p.LastSeen = toMillis(now)
+	p.Connections++
+}
+
+// evict drops the worst (least recently seen and connected to less often)
+// entry from the book, it must be called with the lock held.
+func (b *peerBook) evict() {
+	var worst *knownPeer
+	for _, p := range b.peers {
+		if worst == nil || p.LastSeen < worst.LastSeen ||
+			(p.LastSeen == worst.LastSeen && p.Connections < worst.Connections) {
+			worst = p
+		}
+	}
+	if worst != nil {
+		delete(b.peers, worst.Address)
+	}
+}
+
+// list returns the peers seen within knownPeerMaxAge (but no more than
+// maxKnownPeers of them) starting with the best ones (seen recently and
+// connected to more often), outdated entries are dropped.
+func (b *peerBook) list(now time.Time) []knownPeer {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	var (
+		minSeen = toMillis(now.Add(-knownPeerMaxAge))
+		res     = make([]knownPeer, 0, len(b.peers))
+	)
+	for addr, p := range b.peers {
+		if p.LastSeen < minSeen {
+			delete(b.peers, addr)
+			continue
+		}
+		res = append(res, *p)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].LastSeen != res[j].LastSeen {
+			return res[i].LastSeen > res[j].LastSeen
+		}
+		return res[i].Connections > res[j].Connections
+	})
+	if len(res) > maxKnownPeers {
+		for _, p := range res[maxKnownPeers:] {
+			delete(b.peers, p.Address)
+		}
+		res = res[:maxKnownPeers]
+	}
+	return res
+}
+
+// bytes returns serialized peer book.
+func (b *peerBook) bytes(now time.Time) ([]byte, error) {
+	w := io.NewBufBinWriter()
+	w.WriteArray(b.list(now))
+	if w.Err != nil {
+		return nil, w.Err
+	}
+	return w.Bytes(), nil
+}
+
+// load restores peer book from the data produced by bytes.
+func (b *peerBook) load(data []byte) error {
+	var peers []knownPeer
+	r := io.NewBinReaderFromBuf(data)
+	r.ReadArray(&peers, maxKnownPeers)
+	if r.Err != nil {
+		return r.Err
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	for i := range peers {
+		b.peers[peers[i].Address] = &peers[i]
+	}
+	return nil
+}
+
+// rememberPeer adds handshaked peer to the peer book if it accepts incoming
+// connections (advertises TCP server capability). Other inbound peers connect
+// from ephemeral ports, so there is no point in storing their addresses.
+func (s *Server) rememberPeer(p Peer) {
+	v := p.Version()
+	if v == nil {
+		return
+	}
+	for _, c := range v.Capabilities {
+		if c.Type == capability.TCPServer && c.Data.(*capability.Server).Port != 0 {
+			s.peerBook.seen(p.PeerAddr().String(), time.Now())
+			return
+		}
+	}
+}
+
+// loadKnownPeers restores peer book from the DB and adds peers from it to the
+// discovery pool (best ones first), so that seed nodes are only used if none
+// of known peers are available.
+func (s *Server) loadKnownPeers() {
+	data, err := s.chain.GetKnownPeers()
+	if err == nil && data != nil {
+		err = s.peerBook.load(data)
+	}
+	if err != nil {
+		s.log.Warn("failed to load known peers", zap.Error(err))
+		return
+	}
+	peers := s.peerBook.list(time.Now())
+	if len(peers) == 0 {
+		return
+	}
+	addrs := make([]string, len(peers))
+	for i := range peers {
+		addrs[i] = peers[i].Address
+	}
+	s.discovery.BackFill(addrs...)
+	s.log.Info("restored known peers", zap.Int("count", len(addrs)))
+}
+
+// saveKnownPeers saves peer book to the DB.
+func (s *Server) saveKnownPeers() {
+	data, err := s.peerBook.bytes(time.Now())
+	if err == nil {
+		err = s.chain.PutKnownPeers(data)
+	}
+	if err != nil {
+		s.log.Warn("failed to save known peers", zap.Error(err))
+	}
+}
@@ -0,0 +1,113 @@
+package network
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/network/capability"
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerBook(t *testing.T) {
+	now := time.Now()
+	b := newPeerBook()
+	b.seen("10.0.0.1:20333", now.Add(-knownPeerMaxAge-time.Second))
+	b.seen("10.0.0.2:20333", now.Add(-time.Hour))
+	b.seen("10.0.0.3:20333", now)
+	b.seen("10.0.0.4:20333", now)
+	b.seen("10.0.0.4:20333", now)
+
+	expected := []knownPeer{
+		{Address: "10.0.0.4:20333", LastSeen: toMillis(now), Connections: 2},
+		{Address: "10.0.0.3:20333", LastSeen: toMillis(now), Connections: 1},
+		{Address: "10.0.0.2:20333", LastSeen: toMillis(now.Add(-time.Hour)), Connections: 1},
+	}
+	require.Equal(t, expected, b.list(now))
+
+	data, err := b.bytes(now)
+	require.NoError(t, err)
+
+	restored := newPeerBook()
+	require.NoError(t, restored.load(data))
+	require.Equal(t, expected, restored.list(now))
+
+	require.Error(t, restored.load([]byte{0xff}))
+}
+
+func TestPeerBookLimit(t *testing.T) {
+	now := time.Now()
+	b := newPeerBook()
+	b.seen("10.0.0.1:20333", now.Add(-time.Hour))
+	for i := 1; i < maxKnownPeers; i++ {
+		b.seen(fmt.Sprintf("10.0.1.%d:%d", i%256, 20000+i), now)
+	}
+	require.Equal(t, maxKnownPeers, len(b.peers))
+
+	b.seen("10.0.0.2:20333", now)
+	require.Equal(t, maxKnownPeers, len(b.peers))
+	require.NotContains(t, b.peers, "10.0.0.1:20333")
+	require.Contains(t, b.peers, "10.0.0.2:20333")
+}
+
+func TestServerRememberPeer(t *testing.T) {
+	s := newTestServer(t, ServerConfig{})
+
+	p := newLocalPeer(t, s)
+	s.rememberPeer(p)
+	require.Equal(t, 0, len(s.peerBook.peers))
+
+	p.version = &payload.Version{}
+	s.rememberPeer(p)
+	require.Equal(t, 0, len(s.peerBook.peers))
+
+	p.version.Capabilities = capability.Capabilities{
+		{Type: capability.TCPServer, Data: &capability.Server{Port: 20333}},
+	}
+	s.rememberPeer(p)
+	require.Contains(t, s.peerBook.peers, p.PeerAddr().String())
+}
+
+func TestServerKnownPeers(t *testing.T) {
+	s := newTestServer(t, ServerConfig{})
+	s.peerBook.seen("10.0.0.1:20333", time.Now().Add(-time.Minute))
+	s.peerBook.seen("10.0.0.2:20333", time.Now())
+	s.saveKnownPeers()
+
+	s2, err := newServerFromConstructors(ServerConfig{}, s.chain, s.log,
+		newFakeTransp, newFakeConsensus, newTestDiscovery)
+	require.NoError(t, err)
+	t.Cleanup(s2.discovery.Close)
+	s2.loadKnownPeers()
+	require.Equal(t, []string{"10.0.0.2:20333", "10.0.0.1:20333"}, s2.discovery.(*testDiscovery).backfill)
+}
+
+func TestRequestAddrs(t *testing.T) {
+	s := newTestServer(t, ServerConfig{})
+	ps := []*localPeer{newLocalPeer(t, s), newLocalPeer(t, s), newLocalPeer(t, s)}
+	for i, p := range ps {
+		p.netaddr.Port = i + 1
+		p.handshaked = i != 0
+		s.peers[p] = true
+	}
+
+	sent := func() int {
+		var n int
+		for _, p := range ps {
+			n += p.getAddrSent
+		}
+		return n
+	}
+	s.requestAddrs()
+	require.Equal(t, 1, sent())
+	require.Equal(t, 0, ps[0].getAddrSent)
+
+	// Too early for the next one.
+	s.requestAddrs()
+	require.Equal(t, 1, sent())
+
+	s.lastAddrRequest = time.Now().Add(-addrRequestInterval)
+	s.requestAddrs()
+	require.Equal(t, 2, sent())
+}
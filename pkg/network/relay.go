@@ -0,0 +1,101 @@
+package network
+
+import (
+	"time"
+)
+
+const (
+	// defaultRelayBatchInterval is the default time transactions are
+	// accumulated for before being announced.
+	defaultRelayBatchInterval = 50 * time.Millisecond
+	// defaultRelayBatchSize is the default number of transactions announced
+	// in a single inventory message.
+	defaultRelayBatchSize = 32
+	// defaultRelayBehindThreshold is the default number of blocks node can
+	// lag behind its peers before relaying is throttled.
+	defaultRelayBehindThreshold = 2
+	// maxRelayThrottle limits the relay bandwidth reduction factor, so that
+	// peers reporting bogus heights can't stop relaying completely.
+	maxRelayThrottle = 16
+	// maxRelayPending is the maximum number of transactions waiting to be
+	// announced, transactions exceeding it are dropped (mempool rebroadcasts
+	// stale transactions anyway).
+	maxRelayPending = 4096
+)
+
+// relayLimiter is a token bucket limiting outbound transaction relay traffic.
+// It can go into debt, so that transactions bigger than the bucket capacity
+// can still be sent.
+type relayLimiter struct {
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+// newRelayLimiter returns a limiter for the given rate (in bytes per second)
+// or nil if rate is not positive (no limit).
+func newRelayLimiter(rate int) *relayLimiter {
+	if rate <= 0 {
+		return nil
+	}
+	return &relayLimiter{
+		rate:   float64(rate),
+		tokens: float64(rate),
+	}
+}
+
+// allow checks whether n bytes can be sent at the moment now and accounts for
+// them if so. Bucket is refilled throttle times slower than the configured
+// rate. nil limiter allows everything.
+func (l *relayLimiter) allow(n int, now time.Time, throttle int) bool {
+	if l == nil {
+		return true
+	}
+	if throttle < 1 {
+		throttle = 1
+	}
+	if !l.last.IsZero() && now.After(l.last) {
+		l.tokens += now.Sub(l.last).Seconds() * l.rate / float64(throttle)
+		if l.tokens > l.rate {
+			l.tokens = l.rate
+		}
+	}
+	l.last = now
+	if l.tokens < 0 {
+		return false
+	}
+	l.tokens -= float64(n)
+	return true
+}
+
+// relayThrottle returns transaction relay bandwidth reduction factor based on
+// how far behind the best known peer node is, 1 means no throttling.
+func relayThrottle(height, best, threshold uint32) int {
+	if best <= height || best-height <= threshold {
+		return 1
+	}
+	f := best - height - threshold + 1
+	if f > maxRelayThrottle {
+		return maxRelayThrottle
+	}
+	return int(f)
+}
+
+// relayPeersInfo returns the number of full node peers transactions are
+// announced to and the best block height they report.
+func (s *Server) relayPeersInfo() (int, uint32) {
+	var (
+		n    int
+		best uint32
+	)
+	for p := range s.Peers() {
+		if !p.Handshaked() || !p.IsFullNode() {
+			continue
+		}
+		n++
+		if h := p.LastBlockIndex(); h > best {
+			best = h
+		}
+	}
+	return n, best
+}
@@ -0,0 +1,54 @@
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelayLimiter(t *testing.T) {
+	t.Run("unlimited", func(t *testing.T) {
+		l := newRelayLimiter(0)
+		require.Nil(t, l)
+		require.True(t, l.allow(1<<30, time.Now(), 1))
+	})
+
+	now := time.Now()
+	l := newRelayLimiter(1000)
+	require.True(t, l.allow(600, now, 1))
+	require.True(t, l.allow(600, now, 1)) // goes into debt
+	require.False(t, l.allow(1, now, 1))
+
+	now = now.Add(100 * time.Millisecond) // +100, still in debt
+	require.False(t, l.allow(1, now, 1))
+	now = now.Add(200 * time.Millisecond) // +200
+	require.True(t, l.allow(100, now, 1))
+
+	t.Run("capacity", func(t *testing.T) {
+		l := newRelayLimiter(1000)
+		now := time.Now()
+		require.True(t, l.allow(1000, now, 1))
+		now = now.Add(time.Hour)
+		require.True(t, l.allow(1001, now, 1))
+		require.False(t, l.allow(1, now, 1))
+	})
+	t.Run("throttled", func(t *testing.T) {
+		l := newRelayLimiter(1000)
+		now := time.Now()
+		require.True(t, l.allow(1100, now, 4))
+		now = now.Add(200 * time.Millisecond) // +50 only
+		require.False(t, l.allow(1, now, 4))
+		now = now.Add(200 * time.Millisecond)
+		require.True(t, l.allow(1, now, 4))
+	})
+}
+
+func TestRelayThrottle(t *testing.T) {
+	require.Equal(t, 1, relayThrottle(10, 0, 2))
+	require.Equal(t, 1, relayThrottle(10, 10, 2))
+	require.Equal(t, 1, relayThrottle(10, 12, 2))
+	require.Equal(t, 2, relayThrottle(10, 13, 2))
+	require.Equal(t, 11, relayThrottle(10, 22, 2))
+	require.Equal(t, maxRelayThrottle, relayThrottle(10, 1000, 2))
+}
@@ -0,0 +1,222 @@
+package network
+
+import (
+	"net"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// banScore is the score peer gets banned at.
+	banScore = 100
+	// banDuration is the time banned peer is not allowed to connect for.
+	banDuration = 10 * time.Minute
+	// scoreDecayInterval is the time it takes for peer score to decrease
+	// by one point.
+	scoreDecayInterval = 10 * time.Second
+
+	// violationPenalty is added for protocol violations (invalid or
+	// unexpected messages).
+	violationPenalty = 50
+	// slowPenalty is added for not answering the ping in time.
+	slowPenalty = 20
+	// staleHeightLag is the number of blocks peer should be behind us to
+	// be considered stale if its height doesn't change.
+	staleHeightLag = 100
+	// staleDisconnectChecks is the number of consecutive ping intervals
+	// peer can stay stale for before being disconnected. Stale peers are
+	// not penalized, they can just be slow to synchronize.
+	staleDisconnectChecks = 10
+)
+
+// PeerScore is peer reputation data, it's tracked per peer IP address.
+type PeerScore struct {
+	Address       string
+	Score         int
+	Violations    int
+	SlowResponses int
+	StaleHeights  int
+	// BannedUntil is the ban expiration time, it's zero for peers that
+	// are not banned.
+	BannedUntil time.Time
+}
+
+// violationError wraps errors caused by peer misbehaviour (protocol
+// violations), message handlers return it explicitly, other errors (like
+// timeouts or failures to send something to the peer) don't affect the score.
+type violationError struct {
+	err error
+}
+
+// peerScores tracks reputation of peers.
+type peerScores struct {
+	lock   sync.Mutex
+	scores map[string]*peerScore
+}
+
+type peerScore struct {
+	PeerScore
+	updated    time.Time
+	lastHeight uint32
+	heightSet  bool
+	// staleChecks is the number of consecutive checks peer was stale at.
+	staleChecks int
+}
+
+func (e violationError) Error() string {
+	return e.err.Error()
+}
+
+func (e violationError) Unwrap() error {
+	return e.err
+}
+
+func newPeerScores() *peerScores {
+	return &peerScores{
+		scores: make(map[string]*peerScore),
+	}
+}
+
+// peerHost returns the host part of peer address which is used as a key for
+// scores (peers can connect from different ports).
+func peerHost(addr net.Addr) string {
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return addr.String()
+	}
+	return host
+}
+
+// get returns score for the given host applying decay to it, it creates a new
+// entry if create is true. It must be called with the lock held.
+func (ps *peerScores) get(host string, now time.Time, create bool) *peerScore {
+	sc, ok := ps.scores[host]
+	if !ok {
+		if !create {
+			return nil
+		}
+		sc = &peerScore{PeerScore: PeerScore{Address: host}, updated: now}
+		ps.scores[host] = sc
+		return sc
+	}
+	if d := now.Sub(sc.updated); d >= scoreDecayInterval {
+		steps := int(d / scoreDecayInterval)
+		sc.Score -= steps
+		if sc.Score < 0 {
+			sc.Score = 0
+		}
+		sc.updated = sc.updated.Add(time.Duration(steps) * scoreDecayInterval)
+	}
+	if !sc.BannedUntil.IsZero() && !now.Before(sc.BannedUntil) {
+		sc.BannedUntil = time.Time{}
+	}
+	return sc
+}
+
+// add adds points to the score and bans the peer if needed, it returns true if
+// the peer got banned. It must be called with the lock held.
+func (sc *peerScore) add(points int, now time.Time) bool {
+	if !sc.BannedUntil.IsZero() {
+		return false
+	}
+	sc.Score += points
+	if sc.Score < banScore {
+		return false
+	}
+	sc.Score = 0
+	sc.BannedUntil = now.Add(banDuration)
+	return true
+}
+
+// violation penalizes host for protocol violation and returns true if it got
+// banned as a result of that.
+func (ps *peerScores) violation(host string, now time.Time) bool {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+	sc := ps.get(host, now, true)
+	sc.Violations++
+	return sc.add(violationPenalty, now)
+}
+
+// slowResponse penalizes host for slow response and returns true if it got
+// banned as a result of that.
+func (ps *peerScores) slowResponse(host string, now time.Time) bool {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+	sc := ps.get(host, now, true)
+	sc.SlowResponses++
+	return sc.add(slowPenalty, now)
+}
+
+// checkHeight tracks the height reported by host. Height going backwards is
+// a protocol violation, so host is penalized for that and banned is true if
+// it got banned as a result of that. Peers that stay more than staleHeightLag
+// blocks behind ours without making any progress for staleDisconnectChecks
+// consecutive checks are not penalized, but drop is returned as true for
+// them, so that the connection can be used for some other peer.
+func (ps *peerScores) checkHeight(host string, height uint32, ourHeight uint32, now time.Time) (drop bool, banned bool) {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+	sc := ps.get(host, now, true)
+	var (
+		backwards = sc.heightSet && height < sc.lastHeight
+		stale     = sc.heightSet && sc.lastHeight == height && ourHeight > height+staleHeightLag
+	)
+	sc.lastHeight = height
+	sc.heightSet = true
+	if backwards {
+		sc.staleChecks = 0
+		sc.Violations++
+		return false, sc.add(violationPenalty, now)
+	}
+	if !stale {
+		sc.staleChecks = 0
+		return false, false
+	}
+	sc.StaleHeights++
+	sc.staleChecks++
+	return sc.staleChecks >= staleDisconnectChecks, false
+}
+
+// disconnected resets height tracking for host and drops its entry if there is
+// nothing else to keep for it.
+func (ps *peerScores) disconnected(host string, now time.Time) {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+	sc := ps.get(host, now, false)
+	if sc == nil {
+		return
+	}
+	sc.heightSet = false
+	sc.staleChecks = 0
+	if sc.Score == 0 && sc.BannedUntil.IsZero() {
+		delete(ps.scores, host)
+	}
+}
+
+// isBanned checks whether host is banned at the moment.
+func (ps *peerScores) isBanned(host string, now time.Time) bool {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+	sc := ps.get(host, now, false)
+	return sc != nil && !sc.BannedUntil.IsZero()
+}
+
+// list returns all tracked scores sorted by address. Entries with zero score
+// that are not banned and have no history are dropped in process.
+func (ps *peerScores) list(now time.Time) []PeerScore {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+	res := make([]PeerScore, 0, len(ps.scores))
+	for host := range ps.scores {
+		sc := ps.get(host, now, false)
+		if sc.Score == 0 && sc.BannedUntil.IsZero() && !sc.heightSet {
+			delete(ps.scores, host)
+			continue
+		}
+		res = append(res, sc.PeerScore)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Address < res[j].Address })
+	return res
+}
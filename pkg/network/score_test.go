@@ -0,0 +1,130 @@
+package network
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerScores(t *testing.T) {
+	const host = "10.0.0.1"
+
+	now := time.Now()
+	ps := newPeerScores()
+	require.False(t, ps.isBanned(host, now))
+	require.Equal(t, 0, len(ps.list(now)))
+
+	require.False(t, ps.slowResponse(host, now))
+	require.False(t, ps.violation(host, now))
+	require.Equal(t, []PeerScore{{
+		Address:       host,
+		Score:         slowPenalty + violationPenalty,
+		Violations:    1,
+		SlowResponses: 1,
+	}}, ps.list(now))
+
+	t.Run("decay", func(t *testing.T) {
+		ps := newPeerScores()
+		require.False(t, ps.slowResponse(host, now))
+		sc := ps.list(now.Add(5 * scoreDecayInterval))
+		require.Equal(t, 1, len(sc))
+		require.Equal(t, slowPenalty-5, sc[0].Score)
+		require.Equal(t, 0, len(ps.list(now.Add(slowPenalty*scoreDecayInterval))))
+	})
+
+	require.True(t, ps.violation(host, now))
+	require.True(t, ps.isBanned(host, now))
+	require.True(t, ps.isBanned(host, now.Add(banDuration-time.Second)))
+	sc := ps.list(now)
+	require.Equal(t, 1, len(sc))
+	require.Equal(t, 0, sc[0].Score)
+	require.Equal(t, 2, sc[0].Violations)
+	require.Equal(t, now.Add(banDuration), sc[0].BannedUntil)
+
+	// No penalties for banned peers.
+	require.False(t, ps.violation(host, now))
+
+	now = now.Add(banDuration)
+	require.False(t, ps.isBanned(host, now))
+	ps.disconnected(host, now)
+	require.Equal(t, 0, len(ps.list(now)))
+}
+
+func TestPeerScoresStaleHeight(t *testing.T) {
+	const host = "10.0.0.1"
+
+	now := time.Now()
+	ps := newPeerScores()
+	checkHeight := func(height, ourHeight uint32) (bool, bool) {
+		return ps.checkHeight(host, height, ourHeight, now)
+	}
+	drop, banned := checkHeight(10, 1000)
+	require.False(t, drop || banned)
+	drop, banned = checkHeight(10, 1000)
+	require.False(t, drop || banned)
+	require.Equal(t, 0, ps.list(now)[0].Score)
+	require.Equal(t, 1, ps.list(now)[0].StaleHeights)
+
+	// Moving forward or being close is OK.
+	drop, banned = checkHeight(20, 1000)
+	require.False(t, drop || banned)
+	drop, banned = checkHeight(950, 1000)
+	require.False(t, drop || banned)
+	drop, banned = checkHeight(950, 1000)
+	require.False(t, drop || banned)
+	require.Equal(t, 1, ps.list(now)[0].StaleHeights)
+
+	// Stale peers are dropped after a number of checks, but not banned.
+	for i := 0; i < staleDisconnectChecks; i++ {
+		drop, banned = checkHeight(950, 2000)
+		require.False(t, banned)
+		require.Equal(t, i == staleDisconnectChecks-1, drop)
+	}
+	require.Equal(t, 0, ps.list(now)[0].Score)
+	require.False(t, ps.isBanned(host, now))
+
+	// Height going backwards is a violation.
+	for i := 0; i < banScore/violationPenalty && !banned; i++ {
+		drop, banned = checkHeight(uint32(900-i), 2000)
+		require.False(t, drop)
+	}
+	require.True(t, banned)
+	require.True(t, ps.isBanned(host, now))
+	require.Equal(t, banScore/violationPenalty, ps.list(now)[0].Violations)
+
+	// Reconnected peer can start from any height.
+	ps.disconnected(host, now)
+	drop, banned = checkHeight(100, 2000)
+	require.False(t, drop || banned)
+	require.Equal(t, banScore/violationPenalty, ps.list(now)[0].Violations)
+}
+
+func TestServerBansPeer(t *testing.T) {
+	s := newTestServer(t, ServerConfig{MaxPeers: 10})
+	ch := startWithChannel(s)
+	t.Cleanup(func() {
+		s.Shutdown()
+		<-ch
+	})
+
+	for i := 0; i < banScore/violationPenalty; i++ {
+		p := newLocalPeer(t, s)
+		s.register <- p
+		require.Eventually(t, func() bool { return 1 == s.PeerCount() }, time.Second, time.Millisecond*10)
+		s.unregister <- peerDrop{p, violationError{errors.New("bad message")}}
+		require.Eventually(t, func() bool { return 0 == s.PeerCount() }, time.Second, time.Millisecond*10)
+	}
+	scores := s.PeerScores()
+	require.Equal(t, 1, len(scores))
+	require.Equal(t, banScore/violationPenalty, scores[0].Violations)
+	require.False(t, scores[0].BannedUntil.IsZero())
+
+	p := newLocalPeer(t, s)
+	s.register <- p
+	require.Eventually(t, func() bool {
+		err, ok := p.droppedWith.Load().(error)
+		return ok && errors.Is(err, errPeerBanned)
+	}, time.Second, time.Millisecond*10)
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/network/capability"
 	"github.com/nspcc-dev/neo-go/pkg/network/extpool"
 	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/services/blockfetcher"
 	"github.com/nspcc-dev/neo-go/pkg/services/notary"
 	"github.com/nspcc-dev/neo-go/pkg/services/oracle"
 	"github.com/nspcc-dev/neo-go/pkg/services/stateroot"
@@ -35,6 +36,9 @@ const (
 	defaultMaxPeers         = 100
 	maxBlockBatch           = 200
 	minPoolCount            = 30
+	// addrRequestInterval is the minimum interval between getaddr
+	// requests, only one peer is asked at a time.
+	addrRequestInterval = 5 * time.Second
 )
 
 var (
@@ -43,9 +47,15 @@ var (
 	errInvalidHandshake = errors.New("invalid handshake")
 	errInvalidNetwork   = errors.New("invalid network")
 	errMaxPeers         = errors.New("max peers reached")
+	errPeerBanned       = errors.New("peer is banned")
+	errPeerStale        = errors.New("peer height is stale")
 	errServerShutdown   = errors.New("server shutdown")
 	errInvalidInvType   = errors.New("invalid inventory type")
 	errInvalidHashStart = errors.New("invalid requested HashStart")
+
+	// ErrNotConsensusNode is returned when consensus state is requested
+	// from the node not running consensus service.
+	ErrNotConsensusNode = errors.New("not a consensus node")
 )
 
 type (
@@ -75,9 +85,20 @@ type (
 
 		lock  sync.RWMutex
 		peers map[Peer]bool
+		// scores tracks peer reputation.
+		scores *peerScores
+		// peerBook contains peers we've successfully connected to.
+		peerBook *peerBook
+		// lastAddrRequest is the time of the last getaddr request, it's
+		// only used by the run loop.
+		lastAddrRequest time.Time
 
 		// lastRequestedHeight contains last requested height.
 		lastRequestedHeight atomic.Uint32
+		// lastRequestedHeader contains last requested header height.
+		lastRequestedHeader atomic.Uint32
+		// headerQueue stores headers received ahead of time.
+		headerQueue *headerQueue
 
 		register   chan Peer
 		unregister chan peerDrop
@@ -90,6 +111,11 @@ type (
 		oracle    *oracle.Oracle
 		stateRoot stateroot.Service
 
+		// archiveSync is the archive synchronization service, P2P block
+		// requests are disabled while archiveSyncing is set.
+		archiveSync    *blockfetcher.ArchiveSyncer
+		archiveSyncing atomic.Bool
+
 		log *zap.Logger
 	}
 
@@ -131,6 +157,9 @@ func newServerFromConstructors(config ServerConfig, chain blockchainer.Blockchai
 		register:          make(chan Peer),
 		unregister:        make(chan peerDrop),
 		peers:             make(map[Peer]bool),
+		scores:            newPeerScores(),
+		peerBook:          newPeerBook(),
+		headerQueue:       newHeaderQueue(),
 		syncReached:       atomic.NewBool(false),
 		extensiblePool:    extpool.New(chain),
 		log:               log,
@@ -138,7 +167,7 @@ func newServerFromConstructors(config ServerConfig, chain blockchainer.Blockchai
 	}
 	if chain.P2PSigExtensionsEnabled() {
 		s.notaryFeer = NewNotaryFeer(chain)
-		s.notaryRequestPool = mempool.New(chain.GetConfig().P2PNotaryRequestPayloadPoolSize, 1, config.P2PNotaryCfg.Enabled)
+		s.notaryRequestPool = mempool.New(chain.GetConfig().P2PNotaryRequestPayloadPoolSize, 1, true)
 		chain.RegisterPostBlock(func(bc blockchainer.Blockchainer, txpool *mempool.Pool, _ *block.Block) {
 			s.notaryRequestPool.RemoveStale(func(t *transaction.Transaction) bool {
 				return bc.IsTxStillRelevant(t, txpool, true)
@@ -203,6 +232,22 @@ func newServerFromConstructors(config ServerConfig, chain blockchainer.Blockchai
 		chain.SetOracle(orc)
 	}
 
+	if config.NeoFSBlockFetcherCfg.Enabled {
+		bf, err := blockfetcher.New(config.NeoFSBlockFetcherCfg, chain.GetConfig().StateRootInHeader, log)
+		if err != nil {
+			return nil, fmt.Errorf("can't initialize NeoFS block fetcher: %w", err)
+		}
+		chain.SetBlockFetcher(bf)
+	}
+
+	if config.ArchiveSyncCfg.Enabled {
+		as, err := blockfetcher.NewArchiveSyncer(config.ArchiveSyncCfg, chain, log, s.getMaxPeerHeight)
+		if err != nil {
+			return nil, fmt.Errorf("can't initialize archive synchronization: %w", err)
+		}
+		s.archiveSync = as
+	}
+
 	srv, err := newConsensus(consensus.Config{
 		Logger:                log,
 		Broadcast:             s.handleNewPayload,
@@ -210,6 +255,7 @@ func newServerFromConstructors(config ServerConfig, chain blockchainer.Blockchai
 		ProtocolConfiguration: chain.GetConfig(),
 		RequestTx:             s.requestTx,
 		Wallet:                config.Wallet,
+		RecoveryFile:          config.ConsensusStateFile,
 
 		TimePerBlock: config.TimePerBlock,
 	})
@@ -261,17 +307,60 @@ func (s *Server) Start(errChan chan error) {
 		zap.Uint32("blockHeight", s.chain.BlockHeight()),
 		zap.Uint32("headerHeight", s.chain.HeaderHeight()))
 
+	if s.StateRootCfg.HaltOnMismatch {
+		s.stateRoot.SetOnMismatch(func(err error) {
+			select {
+			case errChan <- err:
+			case <-s.quit:
+			}
+		})
+	}
+	if s.notaryRequestPool != nil {
+		s.notaryRequestPool.RunSubscriptions()
+	}
 	s.tryStartServices()
 	s.initStaleMemPools()
 
 	go s.broadcastTxLoop()
 	go s.relayBlocksLoop()
 	go s.bQueue.run()
+	if s.archiveSync != nil {
+		s.archiveSyncing.Store(true)
+		go s.runArchiveSync()
+	}
+	s.loadKnownPeers()
+	if s.NAT.Enabled {
+		go s.mapPortLoop()
+	}
 	go s.transport.Accept()
 	setServerAndNodeVersions(s.UserAgent, strconv.FormatUint(uint64(s.id), 10))
 	s.run()
 }
 
+// runArchiveSync synchronizes the chain using archives and then enables P2P
+// block requests (they're sent on the next ping exchange).
+func (s *Server) runArchiveSync() {
+	if err := s.archiveSync.Run(); err != nil {
+		s.log.Error("archive synchronization failed", zap.Error(err))
+	}
+	s.archiveSyncing.Store(false)
+}
+
+// getMaxPeerHeight returns the highest block height known to handshaked
+// peers.
+func (s *Server) getMaxPeerHeight() uint32 {
+	var h uint32
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	for p := range s.peers {
+		if p.Handshaked() && p.LastBlockIndex() > h {
+			h = p.LastBlockIndex()
+		}
+	}
+	return h
+}
+
 // Shutdown disconnects all peers and stops listening.
 func (s *Server) Shutdown() {
 	s.log.Info("shutting down server", zap.Int("peers", s.PeerCount()))
@@ -281,6 +370,10 @@ func (s *Server) Shutdown() {
 	for p := range s.Peers() {
 		p.Disconnect(errServerShutdown)
 	}
+	s.saveKnownPeers()
+	if s.archiveSync != nil {
+		s.archiveSync.Shutdown()
+	}
 	s.bQueue.discard()
 	if s.StateRootCfg.Enabled {
 		s.stateRoot.Shutdown()
@@ -290,6 +383,8 @@ func (s *Server) Shutdown() {
 	}
 	if s.notaryModule != nil {
 		s.notaryModule.Stop()
+	}
+	if s.notaryRequestPool != nil {
 		s.notaryRequestPool.StopSubscriptions()
 	}
 	close(s.quit)
@@ -300,11 +395,26 @@ func (s *Server) GetOracle() *oracle.Oracle {
 	return s.oracle
 }
 
+// GetNotaryPool returns P2PNotaryRequest payload pool, it's nil if
+// P2PSigExtensions are disabled.
+func (s *Server) GetNotaryPool() *mempool.Pool {
+	return s.notaryRequestPool
+}
+
 // GetStateRoot returns state root service instance.
 func (s *Server) GetStateRoot() stateroot.Service {
 	return s.stateRoot
 }
 
+// GetConsensusState returns a snapshot of consensus process state, an error
+// is returned if the node is not a consensus one.
+func (s *Server) GetConsensusState() (consensus.State, error) {
+	if s.Wallet == nil {
+		return consensus.State{}, ErrNotConsensusNode
+	}
+	return s.consensus.GetState(), nil
+}
+
 // UnconnectedPeers returns a list of peers that are in the discovery peer list
 // but are not connected to the server.
 func (s *Server) UnconnectedPeers() []string {
@@ -338,7 +448,7 @@ func (s *Server) run() {
 			s.discovery.RequestRemote(s.AttemptConnPeers)
 		}
 		if s.discovery.PoolCount() < minPoolCount {
-			s.broadcastHPMessage(NewMessage(CMDGetAddr, payload.NewNullPayload()))
+			s.requestAddrs()
 		}
 		select {
 		case <-s.quit:
@@ -349,7 +459,10 @@ func (s *Server) run() {
 			s.lock.Unlock()
 			peerCount := s.PeerCount()
 			s.log.Info("new peer connected", zap.Stringer("addr", p.RemoteAddr()), zap.Int("peerCount", peerCount))
-			if peerCount > s.MaxPeers {
+			if s.scores.isBanned(peerHost(p.RemoteAddr()), time.Now()) {
+				// It will send us unregister signal.
+				go p.Disconnect(errPeerBanned)
+			} else if peerCount > s.MaxPeers {
 				s.lock.RLock()
 				// Pick a random peer and drop connection to it.
 				for peer := range s.peers {
@@ -366,11 +479,13 @@ func (s *Server) run() {
 			if s.peers[drop.peer] {
 				delete(s.peers, drop.peer)
 				s.lock.Unlock()
+				s.headerQueue.removePeer(drop.peer)
 				s.log.Warn("peer disconnected",
 					zap.Stringer("addr", drop.peer.RemoteAddr()),
 					zap.String("reason", drop.reason.Error()),
 					zap.Int("peerCount", s.PeerCount()))
 				addr := drop.peer.PeerAddr().String()
+				banned := s.scoreDrop(drop)
 				if drop.reason == errIdenticalID {
 					s.discovery.RegisterBadAddr(addr)
 				} else if drop.reason == errAlreadyConnected {
@@ -394,6 +509,8 @@ func (s *Server) run() {
 						s.discovery.UnregisterConnectedAddr(addr)
 						s.discovery.BackFill(addr)
 					}
+				} else if banned || drop.reason == errPeerBanned || drop.reason == errPeerStale {
+					s.discovery.UnregisterConnectedAddr(addr)
 				} else {
 					s.discovery.UnregisterConnectedAddr(addr)
 					s.discovery.BackFill(addr)
@@ -409,9 +526,63 @@ func (s *Server) run() {
 	}
 }
 
+// requestAddrs sends getaddr request to a random peer if enough time has
+// passed since the previous request, so that peers are not flooded with
+// requests and address lists are collected from different peers over time.
+func (s *Server) requestAddrs() {
+	now := time.Now()
+	if now.Sub(s.lastAddrRequest) < addrRequestInterval {
+		return
+	}
+	pkt, err := NewMessage(CMDGetAddr, payload.NewNullPayload()).Bytes()
+	if err != nil {
+		return
+	}
+	// Map iteration order is random.
+	for p := range s.Peers() {
+		if !p.Handshaked() {
+			continue
+		}
+		if err := p.EnqueueHPPacket(false, pkt); err == nil {
+			p.AddGetAddrSent()
+			s.lastAddrRequest = now
+			return
+		}
+	}
+}
+
+// scoreDrop updates the score of the peer disconnected with the given reason
+// and returns true if the peer got banned as a result of that.
+func (s *Server) scoreDrop(drop peerDrop) bool {
+	var (
+		host   = peerHost(drop.peer.RemoteAddr())
+		now    = time.Now()
+		banned bool
+		v      violationError
+	)
+	switch {
+	case errors.As(drop.reason, &v):
+		banned = s.scores.violation(host, now)
+	case drop.reason == errPingPong:
+		banned = s.scores.slowResponse(host, now)
+	}
+	s.scores.disconnected(host, now)
+	if banned {
+		s.log.Info("peer banned", zap.String("addr", host), zap.Duration("duration", banDuration))
+	}
+	return banned
+}
+
+// PeerScores returns reputation data of known peers.
+func (s *Server) PeerScores() []PeerScore {
+	return s.scores.list(time.Now())
+}
+
 // runProto is a goroutine that manages server-wide protocol events.
 func (s *Server) runProto() {
 	pingTimer := time.NewTimer(s.PingInterval)
+	saveTicker := time.NewTicker(peerBookSaveInterval)
+	defer saveTicker.Stop()
 	for {
 		prevHeight := s.chain.BlockHeight()
 		select {
@@ -419,12 +590,28 @@ func (s *Server) runProto() {
 			return
 		case <-pingTimer.C:
 			if s.chain.BlockHeight() == prevHeight {
+				now := time.Now()
 				// Get a copy of s.peers to avoid holding a lock while sending.
 				for peer := range s.Peers() {
+					if peer.Handshaked() {
+						drop, banned := s.scores.checkHeight(peerHost(peer.RemoteAddr()),
+							peer.LastBlockIndex(), prevHeight, now)
+						if banned {
+							s.log.Info("peer banned for height going backwards", zap.Stringer("addr", peer.RemoteAddr()))
+							peer.Disconnect(errPeerBanned)
+							continue
+						}
+						if drop {
+							peer.Disconnect(errPeerStale)
+							continue
+						}
+					}
 					_ = peer.SendPing(NewMessage(CMDPing, payload.NewPing(s.id, s.chain.HeaderHeight())))
 				}
 			}
 			pingTimer.Reset(s.PingInterval)
+		case <-saveTicker.C:
+			s.saveKnownPeers()
 		}
 	}
 }
@@ -446,7 +633,6 @@ func (s *Server) tryStartServices() {
 			go s.oracle.Run()
 		}
 		if s.notaryModule != nil {
-			s.notaryRequestPool.RunSubscriptions()
 			go s.notaryModule.Run()
 		}
 	}
@@ -513,6 +699,12 @@ func (s *Server) getVersionMsg() (*Message, error) {
 			},
 		})
 	}
+	if s.ExtendedCompression {
+		capabilities = append(capabilities, capability.Capability{
+			Type: capability.ExtendedCompression,
+			Data: &capability.Empty{},
+		})
+	}
 	payload := payload.NewVersion(
 		s.Net,
 		s.id,
@@ -522,6 +714,24 @@ func (s *Server) getVersionMsg() (*Message, error) {
 	return NewMessage(CMDVersion, payload), nil
 }
 
+// canCompressFor checks whether extended compression is enabled and supported
+// by the peer.
+func (s *Server) canCompressFor(p Peer) bool {
+	if !s.ExtendedCompression {
+		return false
+	}
+	ver := p.Version()
+	if ver == nil {
+		return false
+	}
+	for _, c := range ver.Capabilities {
+		if c.Type == capability.ExtendedCompression {
+			return true
+		}
+	}
+	return false
+}
+
 // IsInSync answers the question of whether the server is in sync with the
 // network or not (at least how the server itself sees it). The server operates
 // with the data that it has, the number of peers (that has to be more than
@@ -560,7 +770,7 @@ func (s *Server) IsInSync() bool {
 func (s *Server) handleVersionCmd(p Peer, version *payload.Version) error {
 	err := p.HandleVersion(version)
 	if err != nil {
-		return err
+		return violationError{err}
 	}
 	if s.id == version.Nonce {
 		return errIdenticalID
@@ -612,7 +822,7 @@ func (s *Server) handlePing(p Peer, ping *payload.Ping) error {
 func (s *Server) handlePong(p Peer, pong *payload.Ping) error {
 	err := p.HandlePong(pong)
 	if err != nil {
-		return err
+		return violationError{err}
 	}
 	if s.chain.BlockHeight() < pong.LastBlockIndex {
 		return s.requestBlocks(p)
@@ -689,7 +899,7 @@ func (s *Server) handleGetDataCmd(p Peer, inv *payload.Inventory) error {
 				notFound = append(notFound, hash)
 			}
 		case payload.BlockType:
-			b, err := s.chain.GetBlock(hash)
+			b, err := s.chain.FetchBlock(hash)
 			if err == nil {
 				msg = NewMessage(CMDBlock, b)
 			} else {
@@ -764,7 +974,7 @@ func (s *Server) handleGetBlockByIndexCmd(p Peer, gbd *payload.GetBlockByIndex)
 		if hash.Equals(util.Uint256{}) {
 			break
 		}
-		b, err := s.chain.GetBlock(hash)
+		b, err := s.chain.FetchBlock(hash)
 		if err != nil {
 			break
 		}
@@ -802,6 +1012,7 @@ func (s *Server) handleGetHeadersCmd(p Peer, gh *payload.GetBlockByIndex) error
 		return nil
 	}
 	msg := NewMessage(CMDHeaders, &resp)
+	msg.extendedCompression = s.canCompressFor(p)
 	return p.EnqueueP2PMessage(msg)
 }
 
@@ -826,7 +1037,7 @@ func (s *Server) handleExtensibleCmd(e *payload.Extensible) error {
 			return err
 		}
 	default:
-		return errors.New("invalid category")
+		return violationError{errors.New("invalid category")}
 	}
 
 	msg := NewMessage(CMDInv, payload.NewInventory(payload.ExtensibleType, []util.Uint256{e.Hash()}))
@@ -853,7 +1064,7 @@ func (s *Server) handleTxCmd(tx *transaction.Transaction) error {
 // handleP2PNotaryRequestCmd process received P2PNotaryRequest payload.
 func (s *Server) handleP2PNotaryRequestCmd(r *payload.P2PNotaryRequest) error {
 	if !s.chain.P2PSigExtensionsEnabled() {
-		return errors.New("P2PNotaryRequestCMD was received, but P2PSignatureExtensions are disabled")
+		return violationError{errors.New("P2PNotaryRequestCMD was received, but P2PSignatureExtensions are disabled")}
 	}
 	// It's OK for it to fail for various reasons like request already existing
 	// in the pool.
@@ -903,7 +1114,7 @@ func (s *Server) broadcastP2PNotaryRequestPayload(_ *transaction.Transaction, da
 // handleAddrCmd will process received addresses.
 func (s *Server) handleAddrCmd(p Peer, addrs *payload.AddressList) error {
 	if !p.CanProcessAddr() {
-		return errors.New("unexpected addr received")
+		return violationError{errors.New("unexpected addr received")}
 	}
 	dups := make(map[string]bool)
 	for _, a := range addrs.Addrs {
@@ -942,10 +1153,28 @@ func (s *Server) handleGetAddrCmd(p Peer) error {
 // 1. Block range is divided into chunks of payload.MaxHashesCount.
 // 2. Send requests for chunk in increasing order.
 // 3. After all requests were sent, request random height.
+// In headers-first mode headers are requested first and blocks are only
+// requested up to the current header height. Nothing is requested while
+// the chain is being synchronized from archives.
 func (s *Server) requestBlocks(p Peer) error {
+	if s.archiveSyncing.Load() {
+		return nil
+	}
 	var currHeight = s.chain.BlockHeight()
 	var peerHeight = p.LastBlockIndex()
 	var needHeight uint32
+	if s.useHeadersFirst() {
+		hdrHeight := s.chain.HeaderHeight()
+		if peerHeight > hdrHeight {
+			if err := s.requestHeaders(p); err != nil {
+				return err
+			}
+			peerHeight = hdrHeight
+		}
+		if peerHeight <= currHeight {
+			return nil
+		}
+	}
 	// lastRequestedHeight can only be increased.
 	for {
 		old := s.lastRequestedHeight.Load()
@@ -968,6 +1197,9 @@ func (s *Server) requestBlocks(p Peer) error {
 		}
 		break
 	}
+	if s.useHeadersFirst() && needHeight > peerHeight {
+		return nil
+	}
 	payload := payload.NewGetBlockByIndex(needHeight, -1)
 	return p.EnqueueP2PMessage(NewMessage(CMDGetBlockByIndex, payload))
 }
@@ -981,7 +1213,7 @@ func (s *Server) handleMessage(peer Peer, msg *Message) error {
 	if peer.Handshaked() {
 		if inv, ok := msg.Payload.(*payload.Inventory); ok {
 			if !inv.Type.Valid(s.chain.P2PSigExtensionsEnabled()) || len(inv.Hashes) == 0 {
-				return errInvalidInvType
+				return violationError{errInvalidInvType}
 			}
 		}
 		switch msg.Command {
@@ -1003,6 +1235,9 @@ func (s *Server) handleMessage(peer Peer, msg *Message) error {
 		case CMDGetHeaders:
 			gh := msg.Payload.(*payload.GetBlockByIndex)
 			return s.handleGetHeadersCmd(peer, gh)
+		case CMDHeaders:
+			h := msg.Payload.(*payload.Headers)
+			return s.handleHeadersCmd(peer, h)
 		case CMDInv:
 			inventory := msg.Payload.(*payload.Inventory)
 			return s.handleInvCmd(peer, inventory)
@@ -1028,7 +1263,7 @@ func (s *Server) handleMessage(peer Peer, msg *Message) error {
 			pong := msg.Payload.(*payload.Ping)
 			return s.handlePong(peer, pong)
 		case CMDVersion, CMDVerack:
-			return fmt.Errorf("received '%s' after the handshake", msg.Command.String())
+			return violationError{fmt.Errorf("received '%s' after the handshake", msg.Command.String())}
 		}
 	} else {
 		switch msg.Command {
@@ -1038,13 +1273,14 @@ func (s *Server) handleMessage(peer Peer, msg *Message) error {
 		case CMDVerack:
 			err := peer.HandleVersionAck()
 			if err != nil {
-				return err
+				return violationError{err}
 			}
 			go peer.StartProtocol()
 
+			s.rememberPeer(peer)
 			s.tryStartServices()
 		default:
-			return fmt.Errorf("received '%s' during handshake", msg.Command.String())
+			return violationError{fmt.Errorf("received '%s' during handshake", msg.Command.String())}
 		}
 	}
 	return nil
@@ -1224,14 +1460,28 @@ func (s *Server) initStaleMemPools() {
 }
 
 // broadcastTxLoop is a loop for batching and sending
-// transactions hashes in an INV payload.
+// transactions hashes in an INV payload. Batch parameters and
+// bandwidth limits are taken from TxRelay configuration, when
+// the limit is set relaying is additionally throttled if the
+// node is behind its peers.
 func (s *Server) broadcastTxLoop() {
-	const (
-		batchTime = time.Millisecond * 50
-		batchSize = 32
+	var (
+		batchTime = s.TxRelay.BatchInterval
+		batchSize = s.TxRelay.BatchSize
+		threshold = s.TxRelay.BehindThreshold
+		limiter   = newRelayLimiter(s.TxRelay.MaxBandwidth)
 	)
+	if batchTime <= 0 {
+		batchTime = defaultRelayBatchInterval
+	}
+	if batchSize <= 0 {
+		batchSize = defaultRelayBatchSize
+	}
+	if threshold == 0 {
+		threshold = defaultRelayBehindThreshold
+	}
 
-	txs := make([]util.Uint256, 0, batchSize)
+	txs := make([]*transaction.Transaction, 0, batchSize)
 	var timer *time.Timer
 
 	timerCh := func() <-chan time.Time {
@@ -1242,10 +1492,37 @@ func (s *Server) broadcastTxLoop() {
 	}
 
 	broadcast := func() {
-		s.broadcastTxHashes(txs)
-		txs = txs[:0]
+		n := len(txs)
+		if n > batchSize {
+			n = batchSize
+		}
+		if limiter != nil {
+			peers, best := s.relayPeersInfo()
+			throttle := relayThrottle(s.chain.BlockHeight(), best, threshold)
+			now := time.Now()
+			var i int
+			for ; i < n; i++ {
+				// Worst case is every peer requesting the transaction.
+				if !limiter.allow(txs[i].Size()*peers, now, throttle) {
+					break
+				}
+			}
+			n = i
+		}
+		if n > 0 {
+			hs := make([]util.Uint256, n)
+			for i := range hs {
+				hs[i] = txs[i].Hash()
+			}
+			s.broadcastTxHashes(hs)
+			txs = append(txs[:0], txs[n:]...)
+		}
 		if timer != nil {
 			timer.Stop()
+			timer = nil
+		}
+		if len(txs) > 0 {
+			timer = time.NewTimer(batchTime)
 		}
 	}
 
@@ -1260,17 +1537,26 @@ func (s *Server) broadcastTxLoop() {
 					break loop
 				}
 			}
+			if timer != nil {
+				timer.Stop()
+			}
 			return
 		case <-timerCh():
+			timer = nil
 			if len(txs) > 0 {
 				broadcast()
 			}
 		case tx := <-s.transactions:
-			if len(txs) == 0 {
+			if len(txs) >= maxRelayPending {
+				s.log.Debug("transaction relay queue is full, dropping",
+					zap.Stringer("hash", tx.Hash()))
+				continue
+			}
+			if timer == nil {
 				timer = time.NewTimer(batchTime)
 			}
 
-			txs = append(txs, tx.Hash())
+			txs = append(txs, tx)
 			if len(txs) == batchSize {
 				broadcast()
 			}
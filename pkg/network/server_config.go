@@ -64,6 +64,9 @@ type (
 		// Wallet is a wallet configuration.
 		Wallet *config.Wallet
 
+		// ConsensusStateFile is the file consensus round state is saved to.
+		ConsensusStateFile string
+
 		// TimePerBlock is an interval which should pass between two successive blocks.
 		TimePerBlock time.Duration
 
@@ -75,6 +78,26 @@ type (
 
 		// StateRootCfg is stateroot module configuration.
 		StateRootCfg config.StateRoot
+
+		// TxRelay is transaction relay configuration.
+		TxRelay config.TxRelay
+
+		// NAT is NAT traversal configuration.
+		NAT config.NAT
+
+		// ExtendedCompression enables compression of headers for peers
+		// supporting it.
+		ExtendedCompression bool
+
+		// HeadersFirstSync makes node fetch and verify headers from
+		// peers ahead of blocks.
+		HeadersFirstSync bool
+
+		// NeoFSBlockFetcherCfg is NeoFS block fetcher configuration.
+		NeoFSBlockFetcherCfg config.NeoFSBlockFetcher
+
+		// ArchiveSyncCfg is archive synchronization configuration.
+		ArchiveSyncCfg config.ArchiveSync
 	}
 )
 
@@ -90,23 +113,30 @@ func NewServerConfig(cfg config.Config) ServerConfig {
 	}
 
 	return ServerConfig{
-		UserAgent:         cfg.GenerateUserAgent(),
-		Address:           appConfig.Address,
-		Port:              appConfig.NodePort,
-		Net:               protoConfig.Magic,
-		Relay:             appConfig.Relay,
-		Seeds:             protoConfig.SeedList,
-		DialTimeout:       appConfig.DialTimeout * time.Second,
-		ProtoTickInterval: appConfig.ProtoTickInterval * time.Second,
-		PingInterval:      appConfig.PingInterval * time.Second,
-		PingTimeout:       appConfig.PingTimeout * time.Second,
-		MaxPeers:          appConfig.MaxPeers,
-		AttemptConnPeers:  appConfig.AttemptConnPeers,
-		MinPeers:          appConfig.MinPeers,
-		Wallet:            wc,
-		TimePerBlock:      time.Duration(protoConfig.SecondsPerBlock) * time.Second,
-		OracleCfg:         appConfig.Oracle,
-		P2PNotaryCfg:      appConfig.P2PNotary,
-		StateRootCfg:      appConfig.StateRoot,
+		UserAgent:            cfg.GenerateUserAgent(),
+		Address:              appConfig.Address,
+		Port:                 appConfig.NodePort,
+		Net:                  protoConfig.Magic,
+		Relay:                appConfig.Relay,
+		Seeds:                protoConfig.SeedList,
+		DialTimeout:          appConfig.DialTimeout * time.Second,
+		ProtoTickInterval:    appConfig.ProtoTickInterval * time.Second,
+		PingInterval:         appConfig.PingInterval * time.Second,
+		PingTimeout:          appConfig.PingTimeout * time.Second,
+		MaxPeers:             appConfig.MaxPeers,
+		AttemptConnPeers:     appConfig.AttemptConnPeers,
+		MinPeers:             appConfig.MinPeers,
+		Wallet:               wc,
+		ConsensusStateFile:   appConfig.ConsensusStateFile,
+		TimePerBlock:         time.Duration(protoConfig.SecondsPerBlock) * time.Second,
+		OracleCfg:            appConfig.Oracle,
+		P2PNotaryCfg:         appConfig.P2PNotary,
+		StateRootCfg:         appConfig.StateRoot,
+		TxRelay:              appConfig.TxRelay,
+		NAT:                  appConfig.NAT,
+		ExtendedCompression:  appConfig.ExtendedCompression,
+		HeadersFirstSync:     appConfig.HeadersFirstSync,
+		NeoFSBlockFetcherCfg: appConfig.NeoFSBlockFetcher,
+		ArchiveSyncCfg:       appConfig.ArchiveSync,
 	}
 }
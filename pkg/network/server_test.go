@@ -44,6 +44,7 @@ func (f *fakeConsensus) Shutdown()                                     { f.stopp
 func (f *fakeConsensus) OnPayload(p *payload.Extensible)               { f.payloads = append(f.payloads, p) }
 func (f *fakeConsensus) OnTransaction(tx *transaction.Transaction)     { f.txs = append(f.txs, tx) }
 func (f *fakeConsensus) GetPayload(h util.Uint256) *payload.Extensible { panic("implement me") }
+func (f *fakeConsensus) GetState() consensus.State                     { return consensus.State{} }
 
 func TestNewServer(t *testing.T) {
 	bc := &fakechain.FakeChain{}
@@ -442,7 +443,9 @@ func TestConsensus(t *testing.T) {
 		pl.Category = "invalid"
 		pl.ValidBlockEnd = s.chain.BlockHeight() + 1
 		msg := NewMessage(CMDExtensible, pl)
-		require.Error(t, s.handleMessage(p, msg))
+		err := s.handleMessage(p, msg)
+		var v violationError
+		require.True(t, errors.As(err, &v))
 	})
 }
 
@@ -900,3 +903,24 @@ func TestVerifyNotaryRequest(t *testing.T) {
 		require.NoError(t, verifyNotaryRequest(bc, nil, r))
 	})
 }
+
+func TestExtendedCompression(t *testing.T) {
+	s := newTestServer(t, ServerConfig{ExtendedCompression: true})
+	msg, err := s.getVersionMsg()
+	require.NoError(t, err)
+	caps := msg.Payload.(*payload.Version).Capabilities
+	require.Equal(t, capability.ExtendedCompression, caps[len(caps)-1].Type)
+
+	p := newLocalPeer(t, s)
+	require.False(t, s.canCompressFor(p))
+	p.version = &payload.Version{Capabilities: capability.Capabilities{
+		{Type: capability.TCPServer, Data: &capability.Server{Port: 20333}},
+	}}
+	require.False(t, s.canCompressFor(p))
+	p.version.Capabilities = append(p.version.Capabilities,
+		capability.Capability{Type: capability.ExtendedCompression, Data: &capability.Empty{}})
+	require.True(t, s.canCompressFor(p))
+
+	s.ExtendedCompression = false
+	require.False(t, s.canCompressFor(p))
+}
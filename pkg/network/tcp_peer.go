@@ -195,7 +195,7 @@ func (p *TCPPeer) handleQueues() {
 	var p2pSkipCounter uint32
 	const p2pSkipDivisor = 4
 
-	var writeTimeout = time.Duration(p.server.chain.GetConfig().SecondsPerBlock) * time.Second
+	var writeTimeout = p.server.chain.GetConfig().TimePerBlock(p.server.chain.BlockHeight())
 	for {
 		var msg []byte
 
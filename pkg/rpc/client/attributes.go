@@ -0,0 +1,101 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// NewConflictsAttribute returns Conflicts attribute marking the transaction
+// with the specified hash as conflicting with the one it's added to. This
+// attribute requires P2PSignatureExtensions to be enabled on the network.
+func NewConflictsAttribute(hash util.Uint256) transaction.Attribute {
+	return transaction.Attribute{
+		Type:  transaction.ConflictsT,
+		Value: &transaction.Conflicts{Hash: hash},
+	}
+}
+
+// NewNotValidBeforeAttribute returns NotValidBefore attribute making the
+// transaction it's added to invalid before the specified height. This
+// attribute requires P2PSignatureExtensions to be enabled on the network.
+func NewNotValidBeforeAttribute(height uint32) transaction.Attribute {
+	return transaction.Attribute{
+		Type:  transaction.NotValidBeforeT,
+		Value: &transaction.NotValidBefore{Height: height},
+	}
+}
+
+// NewNotaryAssistedAttribute returns NotaryAssisted attribute with the
+// specified number of keys to be collected by the Notary service (zero for
+// fallback transactions). The transaction it's added to must be signed by the
+// Notary native contract. This attribute requires P2PSignatureExtensions to
+// be enabled on the network.
+func NewNotaryAssistedAttribute(nKeys uint8) transaction.Attribute {
+	return transaction.Attribute{
+		Type:  transaction.NotaryAssistedT,
+		Value: &transaction.NotaryAssisted{NKeys: nKeys},
+	}
+}
+
+// checkAttributes checks P2PSignatureExtensions attributes of the transaction
+// that already has its signers and ValidUntilBlock set. It returns the extra
+// network fee required for NotaryAssisted attribute (if any).
+func (c *Client) checkAttributes(tx *transaction.Transaction) (int64, error) {
+	var (
+		extraFee  int64
+		nvbFound  bool
+		naFound   bool
+		conflicts = make(map[util.Uint256]bool)
+	)
+	for _, attr := range tx.Attributes {
+		switch v := attr.Value.(type) {
+		case *transaction.Conflicts:
+			if attr.Type != transaction.ConflictsT {
+				return 0, fmt.Errorf("%w: bad type for Conflicts value", transaction.ErrInvalidAttribute)
+			}
+			if v.Hash.Equals(util.Uint256{}) {
+				return 0, fmt.Errorf("%w: empty Conflicts hash", transaction.ErrInvalidAttribute)
+			}
+			if conflicts[v.Hash] {
+				return 0, fmt.Errorf("%w: duplicating Conflicts hash %s", transaction.ErrInvalidAttribute, v.Hash.StringLE())
+			}
+			conflicts[v.Hash] = true
+		case *transaction.NotValidBefore:
+			if attr.Type != transaction.NotValidBeforeT {
+				return 0, fmt.Errorf("%w: bad type for NotValidBefore value", transaction.ErrInvalidAttribute)
+			}
+			if nvbFound {
+				return 0, fmt.Errorf("%w: multiple NotValidBefore attributes", transaction.ErrInvalidAttribute)
+			}
+			nvbFound = true
+			if v.Height >= tx.ValidUntilBlock {
+				return 0, fmt.Errorf("%w: NotValidBefore height %d is not less than ValidUntilBlock %d",
+					transaction.ErrInvalidAttribute, v.Height, tx.ValidUntilBlock)
+			}
+		case *transaction.NotaryAssisted:
+			if attr.Type != transaction.NotaryAssistedT {
+				return 0, fmt.Errorf("%w: bad type for NotaryAssisted value", transaction.ErrInvalidAttribute)
+			}
+			if naFound {
+				return 0, fmt.Errorf("%w: multiple NotaryAssisted attributes", transaction.ErrInvalidAttribute)
+			}
+			naFound = true
+			notaryHash, err := c.GetNativeContractHash(nativenames.Notary)
+			if err != nil {
+				return 0, fmt.Errorf("failed to get native Notary hash: %w", err)
+			}
+			if !tx.HasSigner(notaryHash) {
+				return 0, fmt.Errorf("%w: NotaryAssisted attribute requires Notary contract signer", transaction.ErrInvalidAttribute)
+			}
+			extraFee = (int64(v.NKeys) + 1) * transaction.NotaryServiceFeePerKey
+		}
+	}
+	if len(tx.Attributes)+len(tx.Signers) > transaction.MaxAttributes {
+		return 0, errors.New("too many attributes and signers")
+	}
+	return extraFee, nil
+}
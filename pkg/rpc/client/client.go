@@ -16,6 +16,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"go.uber.org/atomic"
 )
 
 const (
@@ -38,6 +39,11 @@ type Client struct {
 	opts              Options
 	requestF          func(*request.Raw) (*response.Raw, error)
 	cache             cache
+
+	// endpoints and current endpoint index are only used by failover
+	// client (see NewFailover).
+	endpoints []*url.URL
+	current   atomic.Int32
 }
 
 // Options defines options for the RPC client.
@@ -51,6 +57,15 @@ type Options struct {
 	CACert         string
 	DialTimeout    time.Duration
 	RequestTimeout time.Duration
+	// APIKey is passed to the server via `Authorization` header to access
+	// methods requiring authentication.
+	APIKey string
+	// HealthCheckInterval is the period of endpoints checks for failover
+	// client, no periodic checks are made if it's not set.
+	HealthCheckInterval time.Duration
+	// MaxHeightLag is the number of blocks failover client's current
+	// endpoint can lag behind the best one before it's switched.
+	MaxHeightLag uint32
 }
 
 // cache stores cache values for the RPC client methods
@@ -158,6 +173,10 @@ func (c *Client) performRequest(method string, p request.RawParams, v interface{
 }
 
 func (c *Client) makeHTTPRequest(r *request.Raw) (*response.Raw, error) {
+	return c.makeHTTPRequestTo(c.endpoint, r)
+}
+
+func (c *Client) makeHTTPRequestTo(endpoint *url.URL, r *request.Raw) (*response.Raw, error) {
 	var (
 		buf = new(bytes.Buffer)
 		raw = new(response.Raw)
@@ -167,10 +186,13 @@ func (c *Client) makeHTTPRequest(r *request.Raw) (*response.Raw, error) {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", c.endpoint.String(), buf)
+	req, err := http.NewRequest("POST", endpoint.String(), buf)
 	if err != nil {
 		return nil, err
 	}
+	if c.opts.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.opts.APIKey)
+	}
 	resp, err := c.cli.Do(req)
 	if err != nil {
 		return nil, err
@@ -11,6 +11,17 @@ Some of the methods also allow to pass a verbose bool. This will
 return a more pretty printed response from the server instead of
 a raw hex string.
 
+Native contracts
+
+Client also provides wrappers for native contract methods. Read-only methods
+are executed via invokefunction and return typed results. Methods changing
+the state (including committee-only ones like Policy setters or Management
+setMinimumDeploymentFee) are exposed as Create*Tx functions returning unsigned
+transactions that must be signed by the appropriate accounts (passed as
+cosigners) before sending. Methods that can only be called by contracts or
+the oracle service (like Management update/destroy or Oracle finish) are not
+wrapped.
+
 TODO:
 	Add missing methods to client.
 	Allow client to connect using client cert.
@@ -18,6 +29,8 @@ TODO:
 
 Supported methods
 
+	compactstorage
+	getaddresshistory
 	getapplicationlog
 	getbestblockhash
 	getblock
@@ -26,14 +39,24 @@ Supported methods
 	getblockheader
 	getblocksysfee
 	getconnectioncount
+	getconsensusstate
 	getcontractstate
+	getnep11balances
+	getnep11transfers
 	getnep17balances
 	getnep17transfers
+	getnotifications
 	getpeers
+	getproof
 	getrawmempool
 	getrawtransaction
+	getstateheight
+	getstateroot
 	getstorage
+	getstoragechanges
+	getstoragehistoric
 	gettransactionheight
+	gettransactiontrace
 	getunclaimedgas
 	getvalidators
 	getversion
@@ -43,6 +66,7 @@ Supported methods
 	sendrawtransaction
 	submitblock
 	validateaddress
+	verifyproof
 
 Unsupported methods
 
@@ -0,0 +1,152 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
+)
+
+// nonIdempotentMethods are the methods that are never retried on another
+// endpoint by failover client, because they can't be safely repeated.
+var nonIdempotentMethods = map[string]bool{
+	"sendrawtransaction":   true,
+	"submitblock":          true,
+	"submitnotaryrequest":  true,
+	"submitoracleresponse": true,
+}
+
+// NewFailover returns a new Client working with several RPC nodes (endpoints)
+// of the same network. Requests are sent to the current endpoint (the first one
+// initially) and if it can't be reached they're transparently retried on the
+// other ones (except for non-idempotent methods like sendrawtransaction),
+// the first endpoint that responds becomes the current one. If
+// HealthCheckInterval option is set, endpoints are also checked periodically
+// (until ctx is done), see CheckEndpoints.
+func NewFailover(ctx context.Context, endpoints []string, opts Options) (*Client, error) {
+	if len(endpoints) == 0 {
+		return nil, errors.New("no endpoints given")
+	}
+	cl, err := New(ctx, endpoints[0], opts)
+	if err != nil {
+		return nil, err
+	}
+	cl.endpoints = make([]*url.URL, len(endpoints))
+	for i := range endpoints {
+		cl.endpoints[i], err = url.Parse(endpoints[i])
+		if err != nil {
+			return nil, fmt.Errorf("bad endpoint #%d: %w", i, err)
+		}
+	}
+	cl.requestF = cl.makeFailoverRequest
+	if cl.opts.HealthCheckInterval > 0 {
+		go cl.checkEndpointsLoop()
+	}
+	return cl, nil
+}
+
+// Endpoint returns the endpoint currently used by failover client (or the
+// only endpoint of regular client).
+func (c *Client) Endpoint() string {
+	if len(c.endpoints) == 0 {
+		return c.endpoint.String()
+	}
+	return c.endpoints[c.current.Load()].String()
+}
+
+// CheckEndpoints requests block count from all endpoints of failover client
+// and switches to the one with the highest height if the current one is not
+// available or lags behind it for more than MaxHeightLag blocks. It returns
+// an error if none of endpoints are available.
+func (c *Client) CheckEndpoints() error {
+	if len(c.endpoints) == 0 {
+		return errors.New("not a failover client")
+	}
+	var (
+		cur        = int(c.current.Load())
+		best       = -1
+		bestHeight uint32
+		curHeight  uint32
+		curOk      bool
+		err        error
+	)
+	for i, u := range c.endpoints {
+		h, e := c.getBlockCountFrom(u)
+		if e != nil {
+			err = e
+			continue
+		}
+		if i == cur {
+			curOk, curHeight = true, h
+		}
+		if best < 0 || h > bestHeight {
+			best, bestHeight = i, h
+		}
+	}
+	if best < 0 {
+		return fmt.Errorf("no endpoints available: %w", err)
+	}
+	if !curOk || bestHeight-curHeight > c.opts.MaxHeightLag {
+		c.current.Store(int32(best))
+	}
+	return nil
+}
+
+func (c *Client) checkEndpointsLoop() {
+	ticker := time.NewTicker(c.opts.HealthCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-ticker.C:
+			_ = c.CheckEndpoints()
+		}
+	}
+}
+
+func (c *Client) getBlockCountFrom(endpoint *url.URL) (uint32, error) {
+	var r = request.Raw{
+		JSONRPC:   request.JSONRPCVersion,
+		Method:    "getblockcount",
+		RawParams: []interface{}{},
+		ID:        1,
+	}
+	raw, err := c.makeHTTPRequestTo(endpoint, &r)
+	if err != nil {
+		return 0, err
+	}
+	if raw.Error != nil {
+		return 0, raw.Error
+	}
+	var count uint32
+	err = json.Unmarshal(raw.Result, &count)
+	return count, err
+}
+
+func (c *Client) makeFailoverRequest(r *request.Raw) (*response.Raw, error) {
+	var (
+		start = int(c.current.Load())
+		raw   *response.Raw
+		err   error
+	)
+	for i := 0; i < len(c.endpoints); i++ {
+		idx := (start + i) % len(c.endpoints)
+		raw, err = c.makeHTTPRequestTo(c.endpoints[idx], r)
+		if err == nil {
+			if idx != start {
+				c.current.Store(int32(idx))
+			}
+			return raw, nil
+		}
+		if nonIdempotentMethods[r.Method] {
+			break
+		}
+	}
+	return nil, err
+}
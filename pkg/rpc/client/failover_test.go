@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+)
+
+// newBlockCountServer starts test server responding with the given block
+// count to any request and counting requests made to it.
+func newBlockCountServer(t *testing.T, count uint32) (*httptest.Server, *atomic.Int32) {
+	calls := atomic.NewInt32(0)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		calls.Inc()
+		r := request.NewRequest()
+		require.NoError(t, r.DecodeData(req.Body))
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		_, err := w.Write([]byte(`{"id":1,"jsonrpc":"2.0","result":` + strconv.FormatUint(uint64(count), 10) + `}`))
+		require.NoError(t, err)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, calls
+}
+
+func TestFailover(t *testing.T) {
+	_, err := NewFailover(context.TODO(), nil, Options{})
+	require.Error(t, err)
+
+	dead := httptest.NewServer(http.NotFoundHandler())
+	dead.Close()
+	srv1, calls1 := newBlockCountServer(t, 10)
+	srv2, calls2 := newBlockCountServer(t, 20)
+
+	c, err := NewFailover(context.TODO(), []string{dead.URL, srv1.URL, srv2.URL}, Options{MaxHeightLag: 5})
+	require.NoError(t, err)
+	require.Equal(t, dead.URL, c.Endpoint())
+
+	t.Run("retry", func(t *testing.T) {
+		count, err := c.GetBlockCount()
+		require.NoError(t, err)
+		require.Equal(t, uint32(10), count)
+		require.Equal(t, srv1.URL, c.Endpoint())
+		require.Equal(t, int32(1), calls1.Load())
+	})
+	t.Run("non-idempotent", func(t *testing.T) {
+		c.current.Store(0)
+		err := c.SubmitRawOracleResponse(request.NewRawParams(1, 2, 3, 4))
+		require.Error(t, err)
+		require.Equal(t, dead.URL, c.Endpoint())
+		require.Equal(t, int32(1), calls1.Load())
+	})
+	t.Run("check endpoints", func(t *testing.T) {
+		require.NoError(t, c.CheckEndpoints())
+		require.Equal(t, srv2.URL, c.Endpoint())
+
+		calls2.Store(0)
+		count, err := c.GetBlockCount()
+		require.NoError(t, err)
+		require.Equal(t, uint32(20), count)
+		require.Equal(t, int32(1), calls2.Load())
+	})
+	t.Run("no lag switch", func(t *testing.T) {
+		c, err := NewFailover(context.TODO(), []string{srv1.URL, srv2.URL}, Options{MaxHeightLag: 15})
+		require.NoError(t, err)
+		require.NoError(t, c.CheckEndpoints())
+		require.Equal(t, srv1.URL, c.Endpoint())
+	})
+	t.Run("all down", func(t *testing.T) {
+		c, err := NewFailover(context.TODO(), []string{dead.URL}, Options{})
+		require.NoError(t, err)
+		require.Error(t, c.CheckEndpoints())
+		_, err = c.GetBlockCount()
+		require.Error(t, err)
+	})
+	t.Run("regular client", func(t *testing.T) {
+		c, err := New(context.TODO(), srv1.URL, Options{})
+		require.NoError(t, err)
+		require.Equal(t, srv1.URL, c.Endpoint())
+		require.Error(t, c.CheckEndpoints())
+	})
+}
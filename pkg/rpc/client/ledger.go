@@ -0,0 +1,154 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+// LedgerBlock is a block representation returned by native Ledger contract,
+// it only contains header fields and the number of transactions. Fields
+// follow the order of block array elements.
+type LedgerBlock struct {
+	Hash               util.Uint256
+	Version            uint32
+	PrevHash           util.Uint256
+	MerkleRoot         util.Uint256
+	Timestamp          uint64
+	Index              uint32
+	NextConsensus      util.Uint160
+	TransactionsLength uint32
+}
+
+// LedgerTransaction is a transaction representation returned by native Ledger
+// contract, it doesn't contain signers, attributes and witnesses. Fields
+// follow the order of transaction array elements.
+type LedgerTransaction struct {
+	Hash            util.Uint256
+	Version         uint8
+	Nonce           uint32
+	Sender          util.Uint160
+	SystemFee       int64
+	NetworkFee      int64
+	ValidUntilBlock uint32
+	Script          []byte
+}
+
+// errLedgerNotFound is returned when native Ledger contract returns null for
+// the requested block or transaction.
+var errLedgerNotFound = errors.New("not found or not traceable")
+
+// LedgerCurrentHash invokes `currentHash` method on a native Ledger contract.
+func (c *Client) LedgerCurrentHash() (util.Uint256, error) {
+	res, err := c.invokeNativeMethod(nativenames.Ledger, "currentHash")
+	if err != nil {
+		return util.Uint256{}, err
+	}
+	bs, err := res.Stack[len(res.Stack)-1].TryBytes()
+	if err != nil {
+		return util.Uint256{}, err
+	}
+	return util.Uint256DecodeBytesBE(bs)
+}
+
+// LedgerCurrentIndex invokes `currentIndex` method on a native Ledger contract.
+func (c *Client) LedgerCurrentIndex() (uint32, error) {
+	res, err := c.invokeNativeMethod(nativenames.Ledger, "currentIndex")
+	if err != nil {
+		return 0, err
+	}
+	index, err := topIntFromStack(res.Stack)
+	return uint32(index), err
+}
+
+// LedgerGetBlockByIndex invokes `getBlock` method on a native Ledger contract
+// with the block index specified.
+func (c *Client) LedgerGetBlockByIndex(index uint32) (*LedgerBlock, error) {
+	return c.ledgerGetBlock(smartcontract.Parameter{Type: smartcontract.IntegerType, Value: int64(index)})
+}
+
+// LedgerGetBlockByHash invokes `getBlock` method on a native Ledger contract
+// with the block hash specified.
+func (c *Client) LedgerGetBlockByHash(hash util.Uint256) (*LedgerBlock, error) {
+	return c.ledgerGetBlock(smartcontract.Parameter{Type: smartcontract.Hash256Type, Value: hash})
+}
+
+func (c *Client) ledgerGetBlock(p smartcontract.Parameter) (*LedgerBlock, error) {
+	res, err := c.invokeNativeMethod(nativenames.Ledger, "getBlock", p)
+	if err != nil {
+		return nil, err
+	}
+	b := new(LedgerBlock)
+	if err := topLedgerItemFromStack(res, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// LedgerGetTransaction invokes `getTransaction` method on a native Ledger
+// contract.
+func (c *Client) LedgerGetTransaction(hash util.Uint256) (*LedgerTransaction, error) {
+	res, err := c.invokeNativeMethod(nativenames.Ledger, "getTransaction",
+		smartcontract.Parameter{Type: smartcontract.Hash256Type, Value: hash})
+	if err != nil {
+		return nil, err
+	}
+	return ledgerTransactionFromResult(res)
+}
+
+// LedgerGetTransactionFromBlock invokes `getTransactionFromBlock` method on a
+// native Ledger contract returning transaction with the specified index from
+// the block with the specified index.
+func (c *Client) LedgerGetTransactionFromBlock(blockIndex uint32, txIndex int) (*LedgerTransaction, error) {
+	res, err := c.invokeNativeMethod(nativenames.Ledger, "getTransactionFromBlock",
+		smartcontract.Parameter{Type: smartcontract.IntegerType, Value: int64(blockIndex)},
+		smartcontract.Parameter{Type: smartcontract.IntegerType, Value: int64(txIndex)})
+	if err != nil {
+		return nil, err
+	}
+	return ledgerTransactionFromResult(res)
+}
+
+// LedgerGetTransactionHeight invokes `getTransactionHeight` method on a native
+// Ledger contract.
+func (c *Client) LedgerGetTransactionHeight(hash util.Uint256) (uint32, error) {
+	res, err := c.invokeNativeMethod(nativenames.Ledger, "getTransactionHeight",
+		smartcontract.Parameter{Type: smartcontract.Hash256Type, Value: hash})
+	if err != nil {
+		return 0, err
+	}
+	h, err := topIntFromStack(res.Stack)
+	if err != nil {
+		return 0, err
+	}
+	if h < 0 {
+		return 0, errLedgerNotFound
+	}
+	return uint32(h), nil
+}
+
+func ledgerTransactionFromResult(res *result.Invoke) (*LedgerTransaction, error) {
+	tx := new(LedgerTransaction)
+	if err := topLedgerItemFromStack(res, tx); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// topLedgerItemFromStack converts the top stack item to v or returns
+// errLedgerNotFound if it's null.
+func topLedgerItemFromStack(res *result.Invoke, v interface{}) error {
+	item := res.Stack[len(res.Stack)-1]
+	if item.Type() == stackitem.AnyT {
+		return errLedgerNotFound
+	}
+	if err := stackitem.ToGo(item, v); err != nil {
+		return fmt.Errorf("invalid stack item: %w", err)
+	}
+	return nil
+}
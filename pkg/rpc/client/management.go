@@ -0,0 +1,78 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+)
+
+// GetMinimumDeploymentFee invokes `getMinimumDeploymentFee` method on a native
+// ContractManagement contract.
+func (c *Client) GetMinimumDeploymentFee() (int64, error) {
+	res, err := c.invokeNativeMethod(nativenames.Management, "getMinimumDeploymentFee")
+	if err != nil {
+		return 0, err
+	}
+	return topIntFromStack(res.Stack)
+}
+
+// ManagementGetContract invokes `getContract` method on a native
+// ContractManagement contract. Unlike GetContractStateByHash it returns
+// contract state as it's seen by contracts at the current height.
+func (c *Client) ManagementGetContract(hash util.Uint160) (*state.Contract, error) {
+	res, err := c.invokeNativeMethod(nativenames.Management, "getContract", smartcontract.Parameter{
+		Type:  smartcontract.Hash160Type,
+		Value: hash,
+	})
+	if err != nil {
+		return nil, err
+	}
+	item := res.Stack[len(res.Stack)-1]
+	if item.Type() == stackitem.AnyT {
+		return nil, fmt.Errorf("contract %s not found", hash.StringLE())
+	}
+	cs := new(state.Contract)
+	if err := cs.FromStackItem(item); err != nil {
+		return nil, fmt.Errorf("invalid contract state: %w", err)
+	}
+	return cs, nil
+}
+
+// CreateDeployTx creates an unsigned transaction invoking `deploy` method on
+// a native ContractManagement contract with the given NEF, manifest and data
+// (can be nil) passed to contract's `_deploy` method. acc is the sender of
+// the transaction, deployed contract hash can be calculated with
+// state.CreateContractHash using its script hash, NEF checksum and manifest
+// name. System fee includes deployment fee.
+func (c *Client) CreateDeployTx(acc *wallet.Account, ne *nef.File, m *manifest.Manifest, data interface{},
+	netFee int64, cosigners []SignerAccount) (*transaction.Transaction, error) {
+	rawNef, err := ne.Bytes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize NEF: %w", err)
+	}
+	rawManifest, err := json.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize manifest: %w", err)
+	}
+	params := []interface{}{rawNef, rawManifest}
+	if data != nil {
+		params = append(params, data)
+	}
+	return c.createNativeCallTx(nativenames.Management, "deploy", false, acc, netFee, cosigners, params...)
+}
+
+// CreateSetMinimumDeploymentFeeTx creates an unsigned transaction invoking
+// `setMinimumDeploymentFee` method on a native ContractManagement contract.
+// This method can only be successfully invoked by the committee.
+func (c *Client) CreateSetMinimumDeploymentFeeTx(acc *wallet.Account, fee int64, netFee int64, cosigners []SignerAccount) (*transaction.Transaction, error) {
+	return c.createNativeCallTx(nativenames.Management, "setMinimumDeploymentFee", false, acc, netFee, cosigners, fee)
+}
@@ -9,19 +9,36 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nnsrecords"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/noderoles"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
 )
 
 // GetOraclePrice invokes `getPrice` method on a native Oracle contract.
 func (c *Client) GetOraclePrice() (int64, error) {
-	oracleHash, err := c.GetNativeContractHash(nativenames.Notary)
+	oracleHash, err := c.GetNativeContractHash(nativenames.Oracle)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get native Oracle hash: %w", err)
 	}
 	return c.invokeNativeGetMethod(oracleHash, "getPrice")
 }
 
+// CreateSetOraclePriceTx creates an unsigned transaction invoking `setPrice`
+// method on a native Oracle contract. This method can only be successfully
+// invoked by the committee, so acc (or one of cosigners) should be committee
+// multisignature account.
+func (c *Client) CreateSetOraclePriceTx(acc *wallet.Account, price int64, netFee int64, cosigners []SignerAccount) (*transaction.Transaction, error) {
+	return c.createNativeCallTx(nativenames.Oracle, "setPrice", false, acc, netFee, cosigners, price)
+}
+
 // GetNNSPrice invokes `getPrice` method on a native NameService contract.
 func (c *Client) GetNNSPrice() (int64, error) {
 	nnsHash, err := c.GetNativeContractHash(nativenames.NameService)
@@ -40,6 +57,155 @@ func (c *Client) GetGasPerBlock() (int64, error) {
 	return c.invokeNativeGetMethod(neoHash, "getGasPerBlock")
 }
 
+// ProjectUnclaimedGas returns a projection of the amount of GAS each of the
+// given accounts can claim at the given height (use the next block index for
+// GAS that can be claimed now), all accounts are processed with a single
+// `invokescript` call. The projection is made for the current NEO balances, so
+// it's only meaningful for heights not lower than the one of the last account
+// NEO balance change (when all GAS generated before it was claimed), lower
+// heights yield zero rather than the amount that was claimable at that time.
+func (c *Client) ProjectUnclaimedGas(accs []util.Uint160, height uint32) ([]int64, error) {
+	if len(accs) == 0 {
+		return []int64{}, nil
+	}
+	neoHash, err := c.GetNativeContractHash(nativenames.Neo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get native NEO hash: %w", err)
+	}
+	w := io.NewBufBinWriter()
+	for _, acc := range accs {
+		// unclaimedGas fails for accounts that have never had NEO, so
+		// it's only called for non-zero balances.
+		claim := io.NewBufBinWriter()
+		emit.AppCall(claim.BinWriter, neoHash, "unclaimedGas", callflag.ReadStates, acc, int64(height))
+		if claim.Err != nil {
+			return nil, fmt.Errorf("failed to create unclaimedGas script: %w", claim.Err)
+		}
+		claimScript := claim.Bytes()
+		emit.AppCall(w.BinWriter, neoHash, "balanceOf", callflag.ReadStates, acc)
+		emit.Jmp(w.BinWriter, opcode.JMPIFNOTL, uint16(5+len(claimScript)+5))
+		w.WriteBytes(claimScript)
+		emit.Jmp(w.BinWriter, opcode.JMPL, 5+1)
+		emit.Opcodes(w.BinWriter, opcode.PUSH0)
+	}
+	if w.Err != nil {
+		return nil, fmt.Errorf("failed to create unclaimedGas script: %w", w.Err)
+	}
+	res, err := c.InvokeScript(w.Bytes(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if res.State != "HALT" {
+		return nil, fmt.Errorf("invocation failed: %s", res.FaultException)
+	}
+	if len(res.Stack) != len(accs) {
+		return nil, fmt.Errorf("unexpected stack length: %d instead of %d", len(res.Stack), len(accs))
+	}
+	gas := make([]int64, len(accs))
+	for i := range res.Stack {
+		bi, err := res.Stack[i].TryInteger()
+		if err != nil {
+			return nil, fmt.Errorf("invalid unclaimed GAS for account #%d: %w", i, err)
+		}
+		gas[i] = bi.Int64()
+	}
+	return gas, nil
+}
+
+// CreateClaimGasTx creates an unsigned transaction claiming GAS for all given
+// accounts (by transferring zero NEO to themselves). payer is the transaction
+// sender paying the fees (it can be one of accs), other accounts are added as
+// CalledByEntry cosigners which are returned along with the transaction to be
+// used for signing (see SignAndPushTx). The number of signers can't exceed
+// transaction.MaxAttributes.
+func (c *Client) CreateClaimGasTx(payer *wallet.Account, accs []*wallet.Account, netFee int64) (*transaction.Transaction, []SignerAccount, error) {
+	neoHash, err := c.GetNativeContractHash(nativenames.Neo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get native NEO hash: %w", err)
+	}
+	var (
+		cosigners []SignerAccount
+		w         = io.NewBufBinWriter()
+	)
+	for _, acc := range accs {
+		h, err := address.StringToUint160(acc.Address)
+		if err != nil {
+			return nil, nil, fmt.Errorf("bad account address: %w", err)
+		}
+		emit.AppCall(w.BinWriter, neoHash, "transfer", callflag.All, h, h, int64(0), nil)
+		emit.Opcodes(w.BinWriter, opcode.ASSERT)
+		if acc.Address == payer.Address {
+			continue
+		}
+		cosigners = append(cosigners, SignerAccount{
+			Signer: transaction.Signer{
+				Account: h,
+				Scopes:  transaction.CalledByEntry,
+			},
+			Account: acc,
+		})
+	}
+	if w.Err != nil {
+		return nil, nil, fmt.Errorf("failed to create claim script: %w", w.Err)
+	}
+	if len(cosigners)+1 > transaction.MaxAttributes {
+		return nil, nil, fmt.Errorf("too many signers: %d", len(cosigners)+1)
+	}
+	tx, err := c.CreateTxFromScript(w.Bytes(), payer, -1, netFee, cosigners)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tx, cosigners, nil
+}
+
+// ClaimGas claims GAS for all given accounts (that should be decrypted)
+// sending as few transactions as possible, payer is the sender of all of them
+// and it pays the fees. It returns hashes of transactions sent, if some of
+// them can't be sent hashes of the previous ones are returned along with the
+// error.
+func (c *Client) ClaimGas(payer *wallet.Account, accs []*wallet.Account, netFee int64) ([]util.Uint256, error) {
+	if !c.initDone {
+		return nil, errNetworkNotInitialized
+	}
+	var (
+		batch  []*wallet.Account
+		hashes []util.Uint256
+		// Payer is a signer of every transaction and it doesn't need a
+		// separate signer to claim its own GAS.
+		others int
+	)
+	send := func() error {
+		tx, cosigners, err := c.CreateClaimGasTx(payer, batch, netFee)
+		if err != nil {
+			return err
+		}
+		h, err := c.SignAndPushTx(tx, payer, cosigners)
+		if err != nil {
+			return err
+		}
+		hashes = append(hashes, h)
+		batch, others = batch[:0], 0
+		return nil
+	}
+	for _, acc := range accs {
+		if acc.Address != payer.Address {
+			if others == transaction.MaxAttributes-1 {
+				if err := send(); err != nil {
+					return hashes, err
+				}
+			}
+			others++
+		}
+		batch = append(batch, acc)
+	}
+	if len(batch) != 0 {
+		if err := send(); err != nil {
+			return hashes, err
+		}
+	}
+	return hashes, nil
+}
+
 // GetDesignatedByRole invokes `getDesignatedByRole` method on a native RoleManagement contract.
 func (c *Client) GetDesignatedByRole(role noderoles.Role, index uint32) (keys.PublicKeys, error) {
 	rmHash, err := c.GetNativeContractHash(nativenames.Designation)
@@ -66,6 +232,20 @@ func (c *Client) GetDesignatedByRole(role noderoles.Role, index uint32) (keys.Pu
 	return topPublicKeysFromStack(result.Stack)
 }
 
+// CreateDesignateAsRoleTx creates an unsigned transaction invoking
+// `designateAsRole` method on a native RoleManagement contract. This method
+// can only be successfully invoked by the committee.
+func (c *Client) CreateDesignateAsRoleTx(acc *wallet.Account, role noderoles.Role, pubs keys.PublicKeys, netFee int64, cosigners []SignerAccount) (*transaction.Transaction, error) {
+	if len(pubs) == 0 {
+		return nil, errors.New("no keys to designate")
+	}
+	bs := make([]interface{}, len(pubs))
+	for i := range pubs {
+		bs[i] = pubs[i].Bytes()
+	}
+	return c.createNativeCallTx(nativenames.Designation, "designateAsRole", false, acc, netFee, cosigners, int64(role), bs)
+}
+
 // NNSResolve invokes `resolve` method on a native NameService contract.
 func (c *Client) NNSResolve(name string, typ nnsrecords.Type) (string, error) {
 	if typ == nnsrecords.CNAME {
@@ -116,3 +296,60 @@ func (c *Client) NNSIsAvailable(name string) (bool, error) {
 	}
 	return topBoolFromStack(result.Stack)
 }
+
+// invokeNativeMethod invokes the method of the native contract with the
+// specified name and returns the result if it's successful and non-empty.
+func (c *Client) invokeNativeMethod(name string, operation string, params ...smartcontract.Parameter) (*result.Invoke, error) {
+	h, err := c.GetNativeContractHash(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get native %s hash: %w", name, err)
+	}
+	if params == nil {
+		params = []smartcontract.Parameter{}
+	}
+	res, err := c.InvokeFunction(h, operation, params, nil)
+	if err != nil {
+		return nil, err
+	}
+	err = getInvocationError(res)
+	if err != nil {
+		return nil, fmt.Errorf("failed to invoke %s method of native %s contract: %w", operation, name, err)
+	}
+	return res, nil
+}
+
+// createNativeCallTx creates an unsigned transaction invoking the method of
+// the native contract with the specified name and parameters, the result is
+// checked with ASSERT if assert is true. acc is the transaction sender, it's
+// included with the CalledByEntry scope by default (that is required for
+// committee and owner witness checks), cosigners can override it. System fee
+// is calculated via `invokescript` RPC, netFee is added to the network fee.
+func (c *Client) createNativeCallTx(name string, method string, assert bool, acc *wallet.Account,
+	netFee int64, cosigners []SignerAccount, params ...interface{}) (*transaction.Transaction, error) {
+	if !c.initDone {
+		return nil, errNetworkNotInitialized
+	}
+	from, err := address.StringToUint160(acc.Address)
+	if err != nil {
+		return nil, fmt.Errorf("bad account address: %w", err)
+	}
+	h, err := c.GetNativeContractHash(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get native %s hash: %w", name, err)
+	}
+	w := io.NewBufBinWriter()
+	emit.AppCall(w.BinWriter, h, method, callflag.All, params...)
+	if assert {
+		emit.Opcodes(w.BinWriter, opcode.ASSERT)
+	}
+	if w.Err != nil {
+		return nil, fmt.Errorf("failed to create %s script: %w", method, w.Err)
+	}
+	return c.CreateTxFromScript(w.Bytes(), acc, -1, netFee, append([]SignerAccount{{
+		Signer: transaction.Signer{
+			Account: from,
+			Scopes:  transaction.CalledByEntry,
+		},
+		Account: acc,
+	}}, cosigners...))
+}
@@ -113,6 +113,16 @@ func (c *Client) CreateNEP17MultiTransferTx(acc *wallet.Account, gas int64,
 // initialize network magic with Init before calling CreateTxFromScript.
 func (c *Client) CreateTxFromScript(script []byte, acc *wallet.Account, sysFee, netFee int64,
 	cosigners []SignerAccount) (*transaction.Transaction, error) {
+	return c.CreateTxFromScriptWithAttributes(script, acc, sysFee, netFee, cosigners, nil)
+}
+
+// CreateTxFromScriptWithAttributes is the same as CreateTxFromScript, but also
+// adds the specified attributes to the transaction (see NewConflictsAttribute,
+// NewNotValidBeforeAttribute and NewNotaryAssistedAttribute). Attributes are
+// checked before network fee calculation, fee for NotaryAssisted attribute is
+// added automatically.
+func (c *Client) CreateTxFromScriptWithAttributes(script []byte, acc *wallet.Account, sysFee, netFee int64,
+	cosigners []SignerAccount, attrs []transaction.Attribute) (*transaction.Transaction, error) {
 	signers, accounts, err := getSigners(acc, cosigners)
 	if err != nil {
 		return nil, fmt.Errorf("failed to construct tx signers: %w", err)
@@ -130,13 +140,20 @@ func (c *Client) CreateTxFromScript(script []byte, acc *wallet.Account, sysFee,
 
 	tx := transaction.New(script, sysFee)
 	tx.Signers = signers
+	if attrs != nil {
+		tx.Attributes = attrs
+	}
 
 	tx.ValidUntilBlock, err = c.CalculateValidUntilBlock()
 	if err != nil {
 		return nil, fmt.Errorf("failed to add validUntilBlock to transaction: %w", err)
 	}
 
-	err = c.AddNetworkFee(tx, netFee, accounts...)
+	attrFee, err := c.checkAttributes(tx)
+	if err != nil {
+		return nil, err
+	}
+	err = c.AddNetworkFee(tx, netFee+attrFee, accounts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to add network fee: %w", err)
 	}
@@ -0,0 +1,76 @@
+package client
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+// RoyaltyRecipient is a single element of NEP24 `royaltyInfo` method result.
+type RoyaltyRecipient struct {
+	Address util.Uint160
+	Amount  *big.Int
+}
+
+// NEP24RoyaltyInfo invokes `royaltyInfo` NEP24 method on a specified NEP11
+// contract for the token with the specified ID. It returns the list of royalty
+// recipients and amounts to be paid for the sale of the token for the given
+// price in the given royalty token.
+func (c *Client) NEP24RoyaltyInfo(tokenHash util.Uint160, tokenID []byte, royaltyToken util.Uint160, salePrice int64) ([]RoyaltyRecipient, error) {
+	result, err := c.InvokeFunction(tokenHash, "royaltyInfo", []smartcontract.Parameter{
+		{
+			Type:  smartcontract.ByteArrayType,
+			Value: tokenID,
+		},
+		{
+			Type:  smartcontract.Hash160Type,
+			Value: royaltyToken,
+		},
+		{
+			Type:  smartcontract.IntegerType,
+			Value: salePrice,
+		},
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	err = getInvocationError(result)
+	if err != nil {
+		return nil, err
+	}
+
+	return topRoyaltyRecipientsFromStack(result.Stack)
+}
+
+// topRoyaltyRecipientsFromStack returns the list of royalty recipients from
+// the top stack item.
+func topRoyaltyRecipientsFromStack(st []stackitem.Item) ([]RoyaltyRecipient, error) {
+	index := len(st) - 1 // top stack element is last in the array
+	items, ok := st[index].Value().([]stackitem.Item)
+	if !ok {
+		return nil, fmt.Errorf("invalid stack item type: %s", st[index].Type())
+	}
+	res := make([]RoyaltyRecipient, len(items))
+	for i, item := range items {
+		fields, ok := item.Value().([]stackitem.Item)
+		if !ok || len(fields) != 2 {
+			return nil, fmt.Errorf("invalid royalty recipient #%d: %s", i, item.Type())
+		}
+		bs, err := fields[0].TryBytes()
+		if err != nil {
+			return nil, fmt.Errorf("invalid royalty recipient #%d address: %w", i, err)
+		}
+		res[i].Address, err = util.Uint160DecodeBytesBE(bs)
+		if err != nil {
+			return nil, fmt.Errorf("invalid royalty recipient #%d address: %w", i, err)
+		}
+		res[i].Amount, err = fields[1].TryInteger()
+		if err != nil {
+			return nil, fmt.Errorf("invalid royalty recipient #%d amount: %w", i, err)
+		}
+	}
+	return res, nil
+}
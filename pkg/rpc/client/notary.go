@@ -0,0 +1,357 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+)
+
+// ErrNotaryRequestExpired is returned when neither main nor fallback
+// transaction of notary request was accepted before their ValidUntilBlock.
+var ErrNotaryRequestExpired = errors.New("notary request expired")
+
+// GetNotaryBalance invokes `balanceOf` method on native Notary contract
+// returning the amount of GAS deposited by the account.
+func (c *Client) GetNotaryBalance(acc util.Uint160) (int64, error) {
+	return c.invokeNotaryAccountMethod("balanceOf", acc)
+}
+
+// GetNotaryDepositExpiration invokes `expirationOf` method on native Notary
+// contract returning the height deposit of the account is locked until.
+func (c *Client) GetNotaryDepositExpiration(acc util.Uint160) (uint32, error) {
+	till, err := c.invokeNotaryAccountMethod("expirationOf", acc)
+	if err != nil {
+		return 0, err
+	}
+	return uint32(till), nil
+}
+
+func (c *Client) invokeNotaryAccountMethod(operation string, acc util.Uint160) (int64, error) {
+	notaryHash, err := c.GetNativeContractHash(nativenames.Notary)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get native Notary hash: %w", err)
+	}
+	result, err := c.InvokeFunction(notaryHash, operation, []smartcontract.Parameter{{
+		Type:  smartcontract.Hash160Type,
+		Value: acc,
+	}}, nil)
+	if err != nil {
+		return 0, err
+	}
+	err = getInvocationError(result)
+	if err != nil {
+		return 0, fmt.Errorf("failed to invoke %s method of native Notary contract: %w", operation, err)
+	}
+	return topIntFromStack(result.Stack)
+}
+
+// CreateNotaryDepositTx creates an unsigned transaction transferring amount
+// of GAS from acc to native Notary contract to make a deposit for the
+// specified account (acc itself if to is nil) locked till the specified
+// height. Only deposit owner can change its lock height, it's kept intact
+// for deposits made for others.
+func (c *Client) CreateNotaryDepositTx(acc *wallet.Account, to *util.Uint160, amount int64, till uint32,
+	netFee int64, cosigners []SignerAccount) (*transaction.Transaction, error) {
+	notaryHash, err := c.GetNativeContractHash(nativenames.Notary)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get native Notary hash: %w", err)
+	}
+	from, err := address.StringToUint160(acc.Address)
+	if err != nil {
+		return nil, fmt.Errorf("bad account address: %w", err)
+	}
+	var data = []interface{}{nil, int64(till)}
+	if to != nil {
+		data[0] = *to
+	}
+	return c.createNativeCallTx(nativenames.Gas, "transfer", true, acc, netFee, cosigners,
+		from, notaryHash, amount, data)
+}
+
+// CreateNotaryLockDepositUntilTx creates an unsigned transaction invoking
+// `lockDepositUntil` method on a native Notary contract to prolong acc's
+// deposit lock till the specified height.
+func (c *Client) CreateNotaryLockDepositUntilTx(acc *wallet.Account, till uint32, netFee int64, cosigners []SignerAccount) (*transaction.Transaction, error) {
+	from, err := address.StringToUint160(acc.Address)
+	if err != nil {
+		return nil, fmt.Errorf("bad account address: %w", err)
+	}
+	return c.createNativeCallTx(nativenames.Notary, "lockDepositUntil", true, acc, netFee, cosigners, from, int64(till))
+}
+
+// CreateNotaryWithdrawTx creates an unsigned transaction invoking `withdraw`
+// method on a native Notary contract to withdraw acc's unlocked deposit to the
+// specified account (acc itself if to is nil).
+func (c *Client) CreateNotaryWithdrawTx(acc *wallet.Account, to *util.Uint160, netFee int64, cosigners []SignerAccount) (*transaction.Transaction, error) {
+	from, err := address.StringToUint160(acc.Address)
+	if err != nil {
+		return nil, fmt.Errorf("bad account address: %w", err)
+	}
+	var recipient interface{}
+	if to != nil {
+		recipient = *to
+	}
+	return c.createNativeCallTx(nativenames.Notary, "withdraw", true, acc, netFee, cosigners, from, recipient)
+}
+
+// CreateSetMaxNotValidBeforeDeltaTx creates an unsigned transaction invoking
+// `setMaxNotValidBeforeDelta` method on a native Notary contract. This method
+// can only be successfully invoked by the committee.
+func (c *Client) CreateSetMaxNotValidBeforeDeltaTx(acc *wallet.Account, value uint32, netFee int64, cosigners []SignerAccount) (*transaction.Transaction, error) {
+	return c.createNativeCallTx(nativenames.Notary, "setMaxNotValidBeforeDelta", false, acc, netFee, cosigners, int64(value))
+}
+
+// checkNotaryDeposit checks that payer's deposit is enough to pay for the
+// fallback transaction and is locked for its lifetime.
+func (c *Client) checkNotaryDeposit(payer util.Uint160, fallbackTx *transaction.Transaction) error {
+	balance, err := c.GetNotaryBalance(payer)
+	if err != nil {
+		return fmt.Errorf("failed to get notary deposit: %w", err)
+	}
+	if need := fallbackTx.SystemFee + fallbackTx.NetworkFee; balance < need {
+		return fmt.Errorf("insufficient notary deposit: %d, fallback transaction needs %d", balance, need)
+	}
+	till, err := c.GetNotaryDepositExpiration(payer)
+	if err != nil {
+		return fmt.Errorf("failed to get notary deposit expiration: %w", err)
+	}
+	if fallbackTx.ValidUntilBlock >= till {
+		return fmt.Errorf("notary deposit lock expires at %d, before fallback transaction ValidUntilBlock %d", till, fallbackTx.ValidUntilBlock)
+	}
+	return nil
+}
+
+// CreateNotaryMainTx creates main transaction of notary request from the
+// given script. Cosigners are the parties signing the transaction, the first
+// one is a sender paying fees, Notary contract is added as the last signer
+// automatically. System fee is calculated if sysFee is negative, network fee
+// is calculated for all witnesses and NotaryAssisted attribute with nKeys
+// keys, netFee is added to it. The result contains dummy Notary witness and
+// empty invocation scripts for cosigners, so every party can sign it with
+// (*wallet.Account).SignTx and push with SignAndPushP2PNotaryRequest.
+func (c *Client) CreateNotaryMainTx(script []byte, sysFee int64, netFee int64, nKeys uint8, cosigners []SignerAccount) (*transaction.Transaction, error) {
+	if !c.initDone {
+		return nil, errNetworkNotInitialized
+	}
+	if len(cosigners) == 0 {
+		return nil, errors.New("no cosigners")
+	}
+	notaryHash, err := c.GetNativeContractHash(nativenames.Notary)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get native Notary hash: %w", err)
+	}
+	var (
+		signers  = make([]transaction.Signer, 0, len(cosigners)+1)
+		accounts = make([]*wallet.Account, 0, len(cosigners)+1)
+	)
+	for _, cs := range cosigners {
+		if cs.Account == nil || cs.Account.Contract == nil {
+			return nil, fmt.Errorf("cosigner %s has no verification script", cs.Signer.Account.StringLE())
+		}
+		signers = append(signers, cs.Signer)
+		accounts = append(accounts, cs.Account)
+	}
+	signers = append(signers, transaction.Signer{Account: notaryHash, Scopes: transaction.None})
+	// Don't call `verify` for Notary contract witness, because it will fail.
+	accounts = append(accounts, &wallet.Account{Contract: &wallet.Contract{Deployed: false}})
+
+	if sysFee < 0 {
+		result, err := c.InvokeScript(script, signers)
+		if err != nil {
+			return nil, fmt.Errorf("can't add system fee to transaction: %w", err)
+		}
+		if result.State != "HALT" {
+			return nil, fmt.Errorf("can't add system fee to transaction: bad vm state: %s due to an error: %s", result.State, result.FaultException)
+		}
+		sysFee = result.GasConsumed
+	}
+	tx := transaction.New(script, sysFee)
+	tx.Signers = signers
+	tx.Attributes = []transaction.Attribute{NewNotaryAssistedAttribute(nKeys)}
+	tx.ValidUntilBlock, err = c.CalculateValidUntilBlock()
+	if err != nil {
+		return nil, fmt.Errorf("failed to add validUntilBlock to transaction: %w", err)
+	}
+	notaryFee, err := c.CalculateNotaryFee(nKeys)
+	if err != nil {
+		return nil, err
+	}
+	err = c.AddNetworkFee(tx, netFee+notaryFee, accounts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add network fee: %w", err)
+	}
+	tx.Scripts = make([]transaction.Witness, len(signers))
+	for i := range cosigners {
+		if !accounts[i].Contract.Deployed {
+			tx.Scripts[i].VerificationScript = accounts[i].Contract.Script
+		}
+	}
+	tx.Scripts[len(signers)-1] = transaction.Witness{
+		InvocationScript:   append([]byte{byte(opcode.PUSHDATA1), 64}, make([]byte, 64)...),
+		VerificationScript: []byte{},
+	}
+	return tx, nil
+}
+
+// GetRawNotaryPool returns hashes of main and fallback transactions of the
+// P2PNotaryRequest payloads currently pooled by the node, fallback hashes are
+// grouped by their main transaction hash. If signer is not nil, only requests
+// with main transaction signed by it are returned.
+func (c *Client) GetRawNotaryPool(signer *util.Uint160) (*result.RawNotaryPool, error) {
+	var (
+		params = request.NewRawParams()
+		resp   = new(result.RawNotaryPool)
+	)
+	if signer != nil {
+		params = request.NewRawParams(signer.StringLE())
+	}
+	if err := c.performRequest("getrawnotarypool", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetRawNotaryTransaction returns main or fallback transaction of the pooled
+// P2PNotaryRequest payload by its hash. You should initialize network magic
+// with Init before calling GetRawNotaryTransaction.
+func (c *Client) GetRawNotaryTransaction(hash util.Uint256) (*transaction.Transaction, error) {
+	var (
+		params = request.NewRawParams(hash.StringLE())
+		resp   []byte
+	)
+	if !c.initDone {
+		return nil, errNetworkNotInitialized
+	}
+	if err := c.performRequest("getrawnotarytransaction", params, &resp); err != nil {
+		return nil, err
+	}
+	return transaction.NewTransactionFromBytes(resp)
+}
+
+// GetRawNotaryTransactionVerbose returns main or fallback transaction of the
+// pooled P2PNotaryRequest payload by its hash in JSON form. You should
+// initialize network magic with Init before calling it.
+func (c *Client) GetRawNotaryTransactionVerbose(hash util.Uint256) (*result.TransactionOutputRaw, error) {
+	var (
+		params = request.NewRawParams(hash.StringLE(), 1)
+		resp   = new(result.TransactionOutputRaw)
+	)
+	if !c.initDone {
+		return nil, errNetworkNotInitialized
+	}
+	if err := c.performRequest("getrawnotarytransaction", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// getTxExecResult returns execution result of the persisted transaction.
+func (c *Client) getTxExecResult(h util.Uint256) (*state.AppExecResult, error) {
+	log, err := c.GetApplicationLog(h, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(log.Executions) == 0 {
+		return nil, errors.New("no executions")
+	}
+	return &state.AppExecResult{Container: h, Execution: log.Executions[0]}, nil
+}
+
+// WaitNotaryRequest waits for main or fallback transaction of the request to
+// be accepted by the network and returns its execution result. The result's
+// Container allows to distinguish between them. ErrNotaryRequestExpired is
+// returned if none of them was accepted before their ValidUntilBlock.
+// Subscriptions used are internal, so events received via Notifications
+// channel are not affected by them (see Notifications).
+func (c *WSClient) WaitNotaryRequest(ctx context.Context, req *payload.P2PNotaryRequest) (*state.AppExecResult, error) {
+	var (
+		hashes = []util.Uint256{req.MainTransaction.Hash(), req.FallbackTransaction.Hash()}
+		vub    = req.MainTransaction.ValidUntilBlock
+	)
+	if req.FallbackTransaction.ValidUntilBlock > vub {
+		vub = req.FallbackTransaction.ValidUntilBlock
+	}
+	r, cleanup, err := c.receive(func(ntf Notification) bool {
+		switch ntf.Type {
+		case response.ExecutionEventID:
+			aer := ntf.Value.(*state.AppExecResult)
+			return aer.Container == hashes[0] || aer.Container == hashes[1]
+		case response.BlockEventID:
+			return ntf.Value.(*block.Block).Index > vub
+		case response.MissedEventID:
+			return true
+		}
+		return false
+	}, 1, "transaction_executed", "block_added")
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	// Transactions can already be persisted, and it can be missed by
+	// the expiration event if subscription is late.
+	findPersisted := func() *state.AppExecResult {
+		for _, h := range hashes {
+			if aer, err := c.getTxExecResult(h); err == nil {
+				return aer
+			}
+		}
+		return nil
+	}
+	expired := func() bool {
+		height, err := c.GetBlockCount()
+		return err == nil && height-1 > vub
+	}
+	if aer := findPersisted(); aer != nil {
+		return aer, nil
+	}
+	if expired() {
+		return nil, ErrNotaryRequestExpired
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-c.done:
+			return nil, errors.New("connection closed")
+		case ntf := <-r.ch:
+			if ntf.Type == response.ExecutionEventID {
+				return ntf.Value.(*state.AppExecResult), nil
+			}
+			// Execution event could have been dropped or missed.
+			if aer := findPersisted(); aer != nil {
+				return aer, nil
+			}
+			if ntf.Type == response.BlockEventID || expired() {
+				return nil, ErrNotaryRequestExpired
+			}
+		}
+	}
+}
+
+// SignAndPushP2PNotaryRequestAndWait uses SignAndPushP2PNotaryRequest to
+// create and push the request and then waits for it with WaitNotaryRequest.
+// It returns the request with the execution result of the accepted
+// transaction.
+func (c *WSClient) SignAndPushP2PNotaryRequestAndWait(ctx context.Context, mainTx *transaction.Transaction, fallbackScript []byte, fallbackSysFee int64, fallbackNetFee int64, fallbackValidFor uint32, acc *wallet.Account) (*payload.P2PNotaryRequest, *state.AppExecResult, error) {
+	req, err := c.SignAndPushP2PNotaryRequest(mainTx, fallbackScript, fallbackSysFee, fallbackNetFee, fallbackValidFor, acc)
+	if err != nil {
+		return req, nil, err
+	}
+	aer, err := c.WaitNotaryRequest(ctx, req)
+	return req, aer, err
+}
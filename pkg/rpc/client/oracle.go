@@ -0,0 +1,61 @@
+package client
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/native"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+)
+
+// HashResultSize is the size of NeoFS oracle response to range hash request
+// (JSON-encoded Uint256 string).
+const HashResultSize = 68
+
+// NeoFSObjectURL returns NeoFS oracle request URL of the whole object payload.
+// Container and object IDs are base58-encoded.
+func NeoFSObjectURL(containerID, objectID string) string {
+	return "neofs:" + containerID + "/" + objectID
+}
+
+// NeoFSRangeURL returns NeoFS oracle request URL of the object payload range
+// of the specified length starting at the specified offset.
+func NeoFSRangeURL(containerID, objectID string, offset, length uint64) string {
+	return NeoFSObjectURL(containerID, objectID) + "/range/" + formatRange(offset, length)
+}
+
+// NeoFSHeaderURL returns NeoFS oracle request URL of the object header, it's
+// returned in JSON format.
+func NeoFSHeaderURL(containerID, objectID string) string {
+	return NeoFSObjectURL(containerID, objectID) + "/header"
+}
+
+// NeoFSHashURL returns NeoFS oracle request URL of the object payload checksum.
+func NeoFSHashURL(containerID, objectID string) string {
+	return NeoFSObjectURL(containerID, objectID) + "/hash"
+}
+
+// NeoFSRangeHashURL returns NeoFS oracle request URL of SHA256 hash of the
+// object payload range.
+func NeoFSRangeHashURL(containerID, objectID string, offset, length uint64) string {
+	return NeoFSHashURL(containerID, objectID) + "/" + formatRange(offset, length)
+}
+
+func formatRange(offset, length uint64) string {
+	return strconv.FormatUint(offset, 10) + "|" + strconv.FormatUint(length, 10)
+}
+
+// CalculateOracleResponseGas returns the amount of GAS to attach to the oracle
+// request expecting a response of the specified size (in bytes). It's the
+// minimum response GAS plus the price of the response data at the current fee
+// per byte, callback execution costs should be added on top of it.
+func (c *Client) CalculateOracleResponseGas(size int) (int64, error) {
+	if size < 0 || size > transaction.MaxOracleResultSize {
+		return 0, errors.New("invalid oracle response size")
+	}
+	feePerByte, err := c.GetFeePerByte()
+	if err != nil {
+		return 0, err
+	}
+	return native.MinimumResponseGas + int64(size)*feePerByte, nil
+}
@@ -0,0 +1,20 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNeoFSURLs(t *testing.T) {
+	const (
+		cid = "C3swfg8MiMJ9bXbeFG6dWJTCoHp9hAEZkHezvbSwK1Cc"
+		oid = "3nQH1L8u3eM9jt2mZCs6MyjzdjerdSzBkXCYYj4M4Znk"
+		url = "neofs:" + cid + "/" + oid
+	)
+	require.Equal(t, url, NeoFSObjectURL(cid, oid))
+	require.Equal(t, url+"/range/13|87", NeoFSRangeURL(cid, oid, 13, 87))
+	require.Equal(t, url+"/header", NeoFSHeaderURL(cid, oid))
+	require.Equal(t, url+"/hash", NeoFSHashURL(cid, oid))
+	require.Equal(t, url+"/hash/0|1024", NeoFSRangeHashURL(cid, oid, 0, 1024))
+}
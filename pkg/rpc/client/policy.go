@@ -4,8 +4,10 @@ import (
 	"fmt"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
 )
 
 // GetFeePerByte invokes `getFeePerByte` method on a native Policy contract.
@@ -79,3 +81,41 @@ func (c *Client) IsBlocked(hash util.Uint160) (bool, error) {
 	}
 	return topBoolFromStack(result.Stack)
 }
+
+// CreateSetFeePerByteTx creates an unsigned transaction invoking
+// `setFeePerByte` method on a native Policy contract. This method can only be
+// successfully invoked by the committee, so acc (or one of cosigners) should
+// be committee multisignature account.
+func (c *Client) CreateSetFeePerByteTx(acc *wallet.Account, value int64, netFee int64, cosigners []SignerAccount) (*transaction.Transaction, error) {
+	return c.createNativeCallTx(nativenames.Policy, "setFeePerByte", false, acc, netFee, cosigners, value)
+}
+
+// CreateSetExecFeeFactorTx creates an unsigned transaction invoking
+// `setExecFeeFactor` method on a native Policy contract. This method can only
+// be successfully invoked by the committee.
+func (c *Client) CreateSetExecFeeFactorTx(acc *wallet.Account, value int64, netFee int64, cosigners []SignerAccount) (*transaction.Transaction, error) {
+	return c.createNativeCallTx(nativenames.Policy, "setExecFeeFactor", false, acc, netFee, cosigners, value)
+}
+
+// CreateSetStoragePriceTx creates an unsigned transaction invoking
+// `setStoragePrice` method on a native Policy contract. This method can only
+// be successfully invoked by the committee.
+func (c *Client) CreateSetStoragePriceTx(acc *wallet.Account, value int64, netFee int64, cosigners []SignerAccount) (*transaction.Transaction, error) {
+	return c.createNativeCallTx(nativenames.Policy, "setStoragePrice", false, acc, netFee, cosigners, value)
+}
+
+// CreateBlockAccountTx creates an unsigned transaction invoking `blockAccount`
+// method on a native Policy contract, the transaction fails if the account is
+// already blocked. This method can only be successfully invoked by the
+// committee.
+func (c *Client) CreateBlockAccountTx(acc *wallet.Account, account util.Uint160, netFee int64, cosigners []SignerAccount) (*transaction.Transaction, error) {
+	return c.createNativeCallTx(nativenames.Policy, "blockAccount", true, acc, netFee, cosigners, account)
+}
+
+// CreateUnblockAccountTx creates an unsigned transaction invoking
+// `unblockAccount` method on a native Policy contract, the transaction fails
+// if the account is not blocked. This method can only be successfully invoked
+// by the committee.
+func (c *Client) CreateUnblockAccountTx(acc *wallet.Account, account util.Uint160, netFee int64, cosigners []SignerAccount) (*transaction.Transaction, error) {
+	return c.createNativeCallTx(nativenames.Policy, "unblockAccount", true, acc, netFee, cosigners, account)
+}
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"bytes"
 	"encoding/base64"
 	"errors"
 	"fmt"
@@ -8,6 +9,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/fee"
+	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativeprices"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
@@ -42,6 +44,67 @@ func (c *Client) CalculateNetworkFee(tx *transaction.Transaction) (int64, error)
 	return resp, nil
 }
 
+// CompactStorage triggers node's database compaction and returns the amount of
+// space reclaimed. It requires admin methods to be enabled on the node.
+func (c *Client) CompactStorage() (*result.StorageCompaction, error) {
+	var (
+		params = request.NewRawParams()
+		resp   = new(result.StorageCompaction)
+	)
+	if err := c.performRequest("compactstorage", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetConsensusState returns consensus state of the node, it only works for
+// consensus nodes.
+func (c *Client) GetConsensusState() (*result.ConsensusState, error) {
+	var (
+		params = request.NewRawParams()
+		resp   = new(result.ConsensusState)
+	)
+	if err := c.performRequest("getconsensusstate", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// EstimateFees returns suggested network fees per byte of transaction based
+// on the given number of recent blocks (0 means server default) and mempool
+// contents.
+func (c *Client) EstimateFees(blocks int) (*result.FeeEstimation, error) {
+	var (
+		params = request.NewRawParams()
+		resp   = new(result.FeeEstimation)
+	)
+	if blocks != 0 {
+		params = request.NewRawParams(blocks)
+	}
+	if err := c.performRequest("estimatefees", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// VerifySource asks the node to compile the given contract source (a single
+// Go file, name is optional) and compare the result with the NEF of the
+// deployed contract with the specified hash. It only works for nodes having
+// source verification enabled.
+func (c *Client) VerifySource(h util.Uint160, source []byte, name string) (*result.SourceAttestation, error) {
+	var (
+		params = request.NewRawParams(h.StringLE(), source)
+		resp   = new(result.SourceAttestation)
+	)
+	if name != "" {
+		params = request.NewRawParams(h.StringLE(), source, name)
+	}
+	if err := c.performRequest("verifysource", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetApplicationLog returns the contract log based on the specified txid.
 func (c *Client) GetApplicationLog(hash util.Uint256, trig *trigger.Type) (*result.ApplicationLog, error) {
 	var (
@@ -57,6 +120,20 @@ func (c *Client) GetApplicationLog(hash util.Uint256, trig *trigger.Type) (*resu
 	return resp, nil
 }
 
+// GetAddressHistory returns transactions signed by the given account from the
+// newest to the oldest, they're split into pages of 100 transactions. It's
+// only supported by nodes with AddressHistoryIndex setting enabled.
+func (c *Client) GetAddressHistory(address string, page int) (*result.AddressHistory, error) {
+	var (
+		params = request.NewRawParams(address, page)
+		resp   = new(result.AddressHistory)
+	)
+	if err := c.performRequest("getaddresshistory", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetBestBlockHash returns the hash of the tallest block in the main chain.
 func (c *Client) GetBestBlockHash() (util.Uint256, error) {
 	var resp = util.Uint256{}
@@ -278,12 +355,50 @@ func (c *Client) GetNEP17Balances(address util.Uint160) (*result.NEP17Balances,
 	return resp, nil
 }
 
+// GetNEP11Balances is a wrapper for getnep11balances RPC.
+func (c *Client) GetNEP11Balances(address util.Uint160) (*result.NEP11Balances, error) {
+	params := request.NewRawParams(address.StringLE())
+	resp := new(result.NEP11Balances)
+	if err := c.performRequest("getnep11balances", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetNEP11Transfers is a wrapper for getnep11transfers RPC. Address parameter
+// is mandatory, while all the others are optional. These parameters are
+// positional in the JSON-RPC call the same way they are for getnep17transfers.
+func (c *Client) GetNEP11Transfers(address string, start, stop *uint32, limit, page *int) (*result.NEP11Transfers, error) {
+	params, err := transfersParams(address, start, stop, limit, page)
+	if err != nil {
+		return nil, err
+	}
+	resp := new(result.NEP11Transfers)
+	if err := c.performRequest("getnep11transfers", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetNEP17Transfers is a wrapper for getnep17transfers RPC. Address parameter
 // is mandatory, while all the others are optional. Start and stop parameters
 // are supported since neo-go 0.77.0 and limit and page since neo-go 0.78.0.
 // These parameters are positional in the JSON-RPC call, you can't specify limit
 // and not specify start/stop for example.
 func (c *Client) GetNEP17Transfers(address string, start, stop *uint32, limit, page *int) (*result.NEP17Transfers, error) {
+	params, err := transfersParams(address, start, stop, limit, page)
+	if err != nil {
+		return nil, err
+	}
+	resp := new(result.NEP17Transfers)
+	if err := c.performRequest("getnep17transfers", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// transfersParams makes positional parameters for get*transfers calls.
+func transfersParams(address string, start, stop *uint32, limit, page *int) (request.RawParams, error) {
 	params := request.NewRawParams(address)
 	if start != nil {
 		params.Values = append(params.Values, *start)
@@ -295,14 +410,23 @@ func (c *Client) GetNEP17Transfers(address string, start, stop *uint32, limit, p
 					params.Values = append(params.Values, *page)
 				}
 			} else if page != nil {
-				return nil, errors.New("bad parameters")
+				return params, errors.New("bad parameters")
 			}
 		} else if limit != nil || page != nil {
-			return nil, errors.New("bad parameters")
+			return params, errors.New("bad parameters")
 		}
 	} else if stop != nil || limit != nil || page != nil {
-		return nil, errors.New("bad parameters")
+		return params, errors.New("bad parameters")
 	}
+	return params, nil
+}
+
+// GetNEP17TransfersFromCursor is the same as GetNEP17Transfers, but instead
+// of page number it uses cursor returned in the Next field of the previous
+// getnep17transfers call result, which is stable against new transfers
+// being added to the log.
+func (c *Client) GetNEP17TransfersFromCursor(address string, start, stop uint32, limit int, cursor string) (*result.NEP17Transfers, error) {
+	params := request.NewRawParams(address, start, stop, limit, cursor)
 	resp := new(result.NEP17Transfers)
 	if err := c.performRequest("getnep17transfers", params, resp); err != nil {
 		return nil, err
@@ -310,6 +434,36 @@ func (c *Client) GetNEP17Transfers(address string, start, stop *uint32, limit, p
 	return resp, nil
 }
 
+// GetNotifications is a wrapper for getnotifications RPC, it returns
+// notifications of the given contract from the newest to the oldest. Empty
+// event name matches any event, other parameters are optional and positional
+// (like for GetNEP17Transfers). It's only supported by nodes with
+// NotificationsIndex setting enabled.
+func (c *Client) GetNotifications(contract util.Uint160, name string, start, stop *uint32, limit, page *int) (*result.ContractNotifications, error) {
+	params, err := transfersParams(contract.StringLE(), start, stop, limit, page)
+	if err != nil {
+		return nil, err
+	}
+	params.Values = append([]interface{}{params.Values[0], name}, params.Values[1:]...)
+	resp := new(result.ContractNotifications)
+	if err := c.performRequest("getnotifications", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetNotificationsFromCursor is the same as GetNotifications, but instead
+// of page number it uses cursor returned in the Next field of the previous
+// getnotifications call result (made with the same contract and event name).
+func (c *Client) GetNotificationsFromCursor(contract util.Uint160, name string, start, stop uint32, limit int, cursor string) (*result.ContractNotifications, error) {
+	params := request.NewRawParams(contract.StringLE(), name, start, stop, limit, cursor)
+	resp := new(result.ContractNotifications)
+	if err := c.performRequest("getnotifications", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetPeers returns the list of nodes that the node is currently connected/disconnected from.
 func (c *Client) GetPeers() (*result.GetPeers, error) {
 	var (
@@ -322,6 +476,70 @@ func (c *Client) GetPeers() (*result.GetPeers, error) {
 	return resp, nil
 }
 
+// GetPeersVerbose is the same as GetPeers, but also returns reputation
+// scores of known peers. This method is only supported by NeoGo servers.
+func (c *Client) GetPeersVerbose() (*result.GetPeers, error) {
+	var (
+		params = request.NewRawParams(true)
+		resp   = &result.GetPeers{}
+	)
+	if err := c.performRequest("getpeers", params, resp); err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
+
+// GetProof returns existence proof of storage item state by the given stateroot
+// historical contract hash and historical item key.
+func (c *Client) GetProof(stateroot util.Uint256, historicalContractHash util.Uint160, historicalKey []byte) (*result.ProofWithKey, error) {
+	var (
+		params = request.NewRawParams(stateroot.StringLE(), historicalContractHash.StringLE(), base64.StdEncoding.EncodeToString(historicalKey))
+		resp   = &result.ProofWithKey{}
+	)
+	if err := c.performRequest("getproof", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetVerifiedStorage returns contract storage item for the given trusted
+// state root. Item and its proof are retrieved via getproof RPC and the proof
+// is checked locally, so the node doesn't need to be trusted. Contract ID of
+// the item is checked using the proof of contract state.
+func (c *Client) GetVerifiedStorage(trustedRoot util.Uint256, contract util.Uint160, key []byte) (*result.VerifiedStorageItem, error) {
+	csProof, err := c.GetProof(trustedRoot, result.ManagementContractHash, result.ContractStateKey(contract))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contract state proof: %w", err)
+	}
+	csID, err := result.CheckContractStateProof(trustedRoot, contract, csProof)
+	if err != nil {
+		return nil, err
+	}
+	proof, err := c.GetProof(trustedRoot, contract, key)
+	if err != nil {
+		return nil, err
+	}
+	id, k, err := proof.StorageKey()
+	if err != nil {
+		return nil, err
+	}
+	if id != csID {
+		return nil, fmt.Errorf("%w: proof is for a different contract", mpt.ErrInvalidProof)
+	}
+	if !bytes.Equal(k, key) {
+		return nil, fmt.Errorf("%w: proof is for a different key", mpt.ErrInvalidProof)
+	}
+	val, err := mpt.CheckProof(trustedRoot, proof.Key, proof.Proof)
+	if err != nil {
+		return nil, err
+	}
+	return &result.VerifiedStorageItem{
+		ContractID: id,
+		Key:        k,
+		Value:      val,
+	}, nil
+}
+
 // GetRawMemPool returns the list of unconfirmed transactions in memory.
 func (c *Client) GetRawMemPool() ([]util.Uint256, error) {
 	var (
@@ -334,6 +552,20 @@ func (c *Client) GetRawMemPool() ([]util.Uint256, error) {
 	return *resp, nil
 }
 
+// GetRawMemPoolDetails returns verified transactions from the mempool with
+// their details (fees, sender, validity window and conflicts) sorted by
+// priority.
+func (c *Client) GetRawMemPoolDetails() (*result.RawMempool, error) {
+	var (
+		params = request.NewRawParams(true, true)
+		resp   = new(result.RawMempool)
+	)
+	if err := c.performRequest("getrawmempool", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetRawTransaction returns a transaction by hash. You should initialize network magic
 // with Init before calling GetRawTransaction.
 func (c *Client) GetRawTransaction(hash util.Uint256) (*transaction.Transaction, error) {
@@ -374,6 +606,36 @@ func (c *Client) GetRawTransactionVerbose(hash util.Uint256) (*result.Transactio
 	return resp, nil
 }
 
+// GetStateHeight returns current block height and validated state height.
+func (c *Client) GetStateHeight() (*result.StateHeight, error) {
+	var (
+		params = request.NewRawParams()
+		resp   = new(result.StateHeight)
+	)
+	if err := c.performRequest("getstateheight", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetStateRootByHeight returns state root for the specified height.
+func (c *Client) GetStateRootByHeight(height uint32) (*state.MPTRoot, error) {
+	return c.getStateRoot(request.NewRawParams(height))
+}
+
+// GetStateRootByBlockHash returns state root for block with specified hash.
+func (c *Client) GetStateRootByBlockHash(hash util.Uint256) (*state.MPTRoot, error) {
+	return c.getStateRoot(request.NewRawParams(hash.StringLE()))
+}
+
+func (c *Client) getStateRoot(params request.RawParams) (*state.MPTRoot, error) {
+	var resp = new(state.MPTRoot)
+	if err := c.performRequest("getstateroot", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetStorageByID returns the stored value, according to the contract ID and the stored key.
 func (c *Client) GetStorageByID(id int32, key []byte) ([]byte, error) {
 	return c.getStorage(request.NewRawParams(id, base64.StdEncoding.EncodeToString(key)))
@@ -392,6 +654,42 @@ func (c *Client) getStorage(params request.RawParams) ([]byte, error) {
 	return resp, nil
 }
 
+// GetStorageHistoricByID returns the value stored by the contract with the
+// given ID and the key as of the block with the given index. It's only
+// supported by nodes keeping old MPT states.
+func (c *Client) GetStorageHistoricByID(id int32, key []byte, index uint32) ([]byte, error) {
+	return c.getStorageHistoric(request.NewRawParams(id, base64.StdEncoding.EncodeToString(key), index))
+}
+
+// GetStorageHistoricByHash returns the value stored by the contract with the
+// given script hash and the key as of the block with the given index. It's only
+// supported by nodes keeping old MPT states.
+func (c *Client) GetStorageHistoricByHash(hash util.Uint160, key []byte, index uint32) ([]byte, error) {
+	return c.getStorageHistoric(request.NewRawParams(hash.StringLE(), base64.StdEncoding.EncodeToString(key), index))
+}
+
+func (c *Client) getStorageHistoric(params request.RawParams) ([]byte, error) {
+	var resp []byte
+	if err := c.performRequest("getstoragehistoric", params, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetStorageChanges returns contract storage changes made by the block with the
+// given index. It's only supported by nodes with SaveStorageChanges setting
+// enabled.
+func (c *Client) GetStorageChanges(index uint32) (*state.StorageChanges, error) {
+	var (
+		params = request.NewRawParams(index)
+		resp   = new(state.StorageChanges)
+	)
+	if err := c.performRequest("getstoragechanges", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetTransactionHeight returns the block index in which the transaction is found.
 func (c *Client) GetTransactionHeight(hash util.Uint256) (uint32, error) {
 	var (
@@ -404,6 +702,20 @@ func (c *Client) GetTransactionHeight(hash util.Uint256) (uint32, error) {
 	return resp, nil
 }
 
+// GetTransactionTrace returns execution trace (contract calls, notifications
+// and storage changes) of the transaction with the given hash. It's only
+// supported by nodes with SaveTransactionTraces setting enabled.
+func (c *Client) GetTransactionTrace(hash util.Uint256) (*state.TransactionTrace, error) {
+	var (
+		params = request.NewRawParams(hash.StringLE())
+		resp   = new(state.TransactionTrace)
+	)
+	if err := c.performRequest("gettransactiontrace", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetUnclaimedGas returns unclaimed GAS amount for the specified address.
 func (c *Client) GetUnclaimedGas(address string) (result.UnclaimedGas, error) {
 	var (
@@ -428,6 +740,23 @@ func (c *Client) GetNextBlockValidators() ([]result.Validator, error) {
 	return *resp, nil
 }
 
+// GetVotingState returns NEO candidates with their votes, current committee
+// and expected results of the next committee update. If voter is not nil,
+// its vote and estimated voting reward are also returned.
+func (c *Client) GetVotingState(voter *util.Uint160) (*result.VotingState, error) {
+	var (
+		params = request.NewRawParams()
+		resp   = new(result.VotingState)
+	)
+	if voter != nil {
+		params = request.NewRawParams(address.Uint160ToString(*voter))
+	}
+	if err := c.performRequest("getvotingstate", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetVersion returns the version information about the queried node.
 func (c *Client) GetVersion() (*result.Version, error) {
 	var (
@@ -463,6 +792,46 @@ func (c *Client) InvokeContractVerify(contract util.Uint160, params []smartcontr
 	return c.invokeSomething("invokecontractverify", p, signers, witnesses...)
 }
 
+// Simulate returns the results after running the script with the given state
+// overrides applied (see `simulate` RPC call documentation). Positive gasLimit
+// restricts the amount of GAS the script can use (it can't exceed server's
+// MaxGasInvoke anyway).
+// NOTE: this is test invoke and will not affect the blockchain.
+func (c *Client) Simulate(script []byte, o *state.Overrides, signers []transaction.Signer, gasLimit int64) (*result.Invoke, error) {
+	var p = request.NewRawParams(script, o)
+	if gasLimit > 0 {
+		if signers == nil {
+			signers = []transaction.Signer{}
+		}
+		p.Values = append(p.Values, signers, gasLimit)
+		signers = nil
+	}
+	return c.invokeSomething("simulate", p, signers)
+}
+
+// SimulateTransactions returns the results of running the given transactions
+// one by one with the given state overrides (can be nil) applied along with the
+// storage changes they make (see `simulatetransactions` RPC call
+// documentation). Transactions don't need to be signed.
+// NOTE: this is test invoke and will not affect the blockchain.
+func (c *Client) SimulateTransactions(txs []*transaction.Transaction, o *state.Overrides) (*result.BundleSimulation, error) {
+	var (
+		raw  = make([][]byte, len(txs))
+		resp = new(result.BundleSimulation)
+	)
+	for i := range txs {
+		raw[i] = txs[i].Bytes()
+	}
+	var p = request.NewRawParams(raw)
+	if o != nil {
+		p.Values = append(p.Values, o)
+	}
+	if err := c.performRequest("simulatetransactions", p, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // invokeSomething is an inner wrapper for Invoke* functions
 func (c *Client) invokeSomething(method string, p request.RawParams, signers []transaction.Signer, witnesses ...transaction.Witness) (*result.Invoke, error) {
 	var resp = new(result.Invoke)
@@ -561,9 +930,9 @@ func (c *Client) SignAndPushTx(tx *transaction.Transaction, acc *wallet.Account,
 			if signer.Account == cosigner.Signer.Account {
 				err = cosigner.Account.SignTx(c.GetNetwork(), tx)
 				if err != nil { // then account is non-contract-based and locked, but let's provide more detailed error
-					if paramNum := len(cosigner.Account.Contract.Parameters); paramNum != 0 && cosigner.Account.Contract.Deployed {
+					if ctr := cosigner.Account.Contract; ctr != nil && len(ctr.Parameters) != 0 && ctr.Deployed {
 						return txHash, fmt.Errorf("failed to add contract-based witness for signer #%d (%s): "+
-							"%d parameters must be provided to construct invocation script", i, address.Uint160ToString(signer.Account), paramNum)
+							"%d parameters must be provided to construct invocation script", i, address.Uint160ToString(signer.Account), len(ctr.Parameters))
 					}
 					return txHash, fmt.Errorf("failed to add witness for signer #%d (%s): account should be unlocked to add the signature. "+
 						"Store partially-signed transaction and then use 'wallet sign' command to cosign it", i, address.Uint160ToString(signer.Account))
@@ -620,7 +989,9 @@ func getSigners(sender *wallet.Account, cosigners []SignerAccount) ([]transactio
 // and fallback transactions using given wif to sign it. It returns the request and an error.
 // Fallback transaction is constructed from the given script using the amount of gas specified.
 // For successful fallback transaction validation at least 2*transaction.NotaryServiceFeePerKey
-// GAS should be deposited to Notary contract.
+// GAS should be deposited to Notary contract, an error is returned if account's deposit
+// is not enough to pay for the fallback or if it's unlocked before fallback's ValidUntilBlock.
+// CreateNotaryMainTx can be used to construct main transaction.
 // Main transaction should be constructed by the user. Several rules need to be met for
 // successful main transaction acceptance:
 // 1. Native Notary contract should be a signer of the main transaction.
@@ -669,18 +1040,9 @@ func (c *Client) SignAndPushP2PNotaryRequest(mainTx *transaction.Transaction, fa
 	fallbackTx.Signers = signers
 	fallbackTx.ValidUntilBlock = mainTx.ValidUntilBlock
 	fallbackTx.Attributes = []transaction.Attribute{
-		{
-			Type:  transaction.NotaryAssistedT,
-			Value: &transaction.NotaryAssisted{NKeys: 0},
-		},
-		{
-			Type:  transaction.NotValidBeforeT,
-			Value: &transaction.NotValidBefore{Height: fallbackTx.ValidUntilBlock - fallbackValidFor + 1},
-		},
-		{
-			Type:  transaction.ConflictsT,
-			Value: &transaction.Conflicts{Hash: mainTx.Hash()},
-		},
+		NewNotaryAssistedAttribute(0),
+		NewNotValidBeforeAttribute(fallbackTx.ValidUntilBlock - fallbackValidFor + 1),
+		NewConflictsAttribute(mainTx.Hash()),
 	}
 	extraNetFee, err := c.CalculateNotaryFee(0)
 	if err != nil {
@@ -693,6 +1055,9 @@ func (c *Client) SignAndPushP2PNotaryRequest(mainTx *transaction.Transaction, fa
 	if err != nil {
 		return nil, fmt.Errorf("failed to add network fee: %w", err)
 	}
+	if err = c.checkNotaryDeposit(from, fallbackTx); err != nil {
+		return nil, err
+	}
 	fallbackTx.Scripts = []transaction.Witness{
 		{
 			InvocationScript:   append([]byte{byte(opcode.PUSHDATA1), 64}, make([]byte, 64)...),
@@ -772,6 +1137,18 @@ func (c *Client) ValidateAddress(address string) error {
 	return nil
 }
 
+// VerifyProof returns value by the given stateroot and proof.
+func (c *Client) VerifyProof(stateroot util.Uint256, proof *result.ProofWithKey) ([]byte, error) {
+	var (
+		params = request.NewRawParams(stateroot.StringLE(), proof.String())
+		resp   = &result.VerifyProof{}
+	)
+	if err := c.performRequest("verifyproof", params, resp); err != nil {
+		return nil, err
+	}
+	return resp.Value, nil
+}
+
 // CalculateValidUntilBlock calculates ValidUntilBlock field for tx as
 // current blockchain height + number of validators. Number of validators
 // is the length of blockchain validators list got from GetNextBlockValidators()
@@ -811,6 +1188,9 @@ func (c *Client) AddNetworkFee(tx *transaction.Transaction, extraFee int64, accs
 	size := io.GetVarSize(tx)
 	var ef int64
 	for i, cosigner := range tx.Signers {
+		if accs[i].Contract == nil {
+			return fmt.Errorf("signer #%d account has no verification script", i)
+		}
 		if accs[i].Contract.Deployed {
 			res, err := c.InvokeContractVerify(cosigner.Account, smartcontract.Params{}, tx.Signers)
 			if err != nil {
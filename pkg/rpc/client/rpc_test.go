@@ -110,6 +110,31 @@ func getTxMoveNeo() *result.TransactionOutputRaw {
 // published in official C# JSON-RPC API v2.10.3 reference
 // (see https://docs.neo.org/docs/en-us/reference/rpc/latest-version/api.html)
 var rpcClientTestCases = map[string][]rpcClientTestCase{
+	"getaddresshistory": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetAddressHistory("NbTiM6h8r99kpRtb428XcsUk1TzKed2gTc", 1)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"address":"NbTiM6h8r99kpRtb428XcsUk1TzKed2gTc","transactions":[{"txhash":"0xcb6ddb5f99d6af4c94a6c396d5294472f2eebc91a2c933e0f527422296fa9fb2","blockindex":5,"timestamp":1600094189000,"sender":true}],"page":1,"more":false}}`,
+			result: func(c *Client) interface{} {
+				txHash, err := util.Uint256DecodeStringLE("cb6ddb5f99d6af4c94a6c396d5294472f2eebc91a2c933e0f527422296fa9fb2")
+				if err != nil {
+					panic(err)
+				}
+				return &result.AddressHistory{
+					Address: "NbTiM6h8r99kpRtb428XcsUk1TzKed2gTc",
+					Transactions: []result.AddressTransaction{{
+						TxHash:    txHash,
+						Index:     5,
+						Timestamp: 1600094189000,
+						Sender:    true,
+					}},
+					Page: 1,
+				}
+			},
+		},
+	},
 	"getapplicationlog": {
 		{
 			name: "positive",
@@ -585,6 +610,68 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 			},
 		},
 	},
+	"getnotifications": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				hash, err := util.Uint160DecodeStringLE("d2a4cff31913016155e38e474a2c06d08be276cf")
+				if err != nil {
+					panic(err)
+				}
+				start, stop, limit := uint32(1), uint32(10), 1
+				return c.GetNotifications(hash, "Transfer", &start, &stop, &limit, nil)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"contract":"0xd2a4cff31913016155e38e474a2c06d08be276cf","notifications":[{"blockindex":5,"container":"0xcb6ddb5f99d6af4c94a6c396d5294472f2eebc91a2c933e0f527422296fa9fb2","contract":"0xd2a4cff31913016155e38e474a2c06d08be276cf","eventname":"Transfer","state":{"type":"Array","value":[{"type":"Any"},{"type":"ByteString","value":"AQID"},{"type":"Integer","value":"100"}]}}]}}`,
+			result: func(c *Client) interface{} {
+				hash, err := util.Uint160DecodeStringLE("d2a4cff31913016155e38e474a2c06d08be276cf")
+				if err != nil {
+					panic(err)
+				}
+				txHash, err := util.Uint256DecodeStringLE("cb6ddb5f99d6af4c94a6c396d5294472f2eebc91a2c933e0f527422296fa9fb2")
+				if err != nil {
+					panic(err)
+				}
+				return &result.ContractNotifications{
+					Contract: hash,
+					Notifications: []result.ContractNotification{{
+						NotificationEvent: state.NotificationEvent{
+							ScriptHash: hash,
+							Name:       "Transfer",
+							Item: stackitem.NewArray([]stackitem.Item{
+								stackitem.Null{},
+								stackitem.NewByteArray([]byte{1, 2, 3}),
+								stackitem.NewBigInteger(big.NewInt(100)),
+							}),
+						},
+						Block:     5,
+						Container: txHash,
+					}},
+				}
+			},
+		},
+		{
+			name: "positive, cursor",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetNotificationsFromCursor(util.Uint160{1, 2, 3}, "", 0, 10, 1, "BQAAAAEAAAA=")
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"contract":"0x0000000000000000000000000000000000030201","notifications":[],"next":"BAAAAAAAAAA="}}`,
+			result: func(c *Client) interface{} {
+				return &result.ContractNotifications{
+					Contract:      util.Uint160{1, 2, 3},
+					Notifications: []result.ContractNotification{},
+					Next:          "BAAAAAAAAAA=",
+				}
+			},
+		},
+		{
+			name: "bad parameters",
+			invoke: func(c *Client) (interface{}, error) {
+				limit := 1
+				return c.GetNotifications(util.Uint160{}, "", nil, nil, &limit, nil)
+			},
+			fails: true,
+		},
+	},
 	"getpeers": {
 		{
 			name: "positive",
@@ -615,6 +702,103 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 				}
 			},
 		},
+		{
+			name: "verbose",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetPeersVerbose()
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":{"unconnected":[],"connected":[{"address":"127.0.0.1","port":"20335"}],"bad":[],"scores":[{"address":"127.0.0.1","score":20,"violations":0,"slowresponses":1,"staleheights":0},{"address":"172.200.0.254","score":0,"violations":2,"slowresponses":0,"staleheights":0,"banneduntil":1617242020000}]}}`,
+			result: func(c *Client) interface{} {
+				return &result.GetPeers{
+					Unconnected: result.Peers{},
+					Connected: result.Peers{
+						{
+							Address: "127.0.0.1",
+							Port:    "20335",
+						},
+					},
+					Bad: result.Peers{},
+					Scores: []result.PeerScore{
+						{
+							Address:       "127.0.0.1",
+							Score:         20,
+							SlowResponses: 1,
+						},
+						{
+							Address:     "172.200.0.254",
+							Violations:  2,
+							BannedUntil: 1617242020000,
+						},
+					},
+				}
+			},
+		},
+	},
+	"getproof": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				root, _ := util.Uint256DecodeStringLE("272002b11a6a39035c719defec3e4e6a8d1f4ae37a995b42734b5788a6977d6c")
+				cHash, _ := util.Uint160DecodeStringLE("cf76e28bd0062c4a478ee35561011319f3cfa4d2")
+				return c.GetProof(root, cHash, []byte{1, 2, 3})
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":"AwECAwECBAU="}`,
+			result: func(c *Client) interface{} {
+				return &result.ProofWithKey{
+					Key:   []byte{1, 2, 3},
+					Proof: [][]byte{{4, 5}},
+				}
+			},
+		},
+	},
+	"estimatefees": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.EstimateFees(10)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"minimum":1000,"low":1000,"medium":1500,"high":3000,"blocks":10,"mempoolsize":2}}`,
+			result: func(c *Client) interface{} {
+				return &result.FeeEstimation{
+					Minimum:     1000,
+					Low:         1000,
+					Medium:      1500,
+					High:        3000,
+					Blocks:      10,
+					MempoolSize: 2,
+				}
+			},
+		},
+	},
+	"getconsensusstate": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetConsensusState()
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"blockindex":1234,"viewnumber":1,"primaryindex":2,"myindex":0,"roundstart":1627894840919,"viewchanges":3,"preparations":1,"commits":0,"changeviews":0,"validators":[{"publickey":"02b3622bf4017bdfe317c58aed5f4c753f206b7db896046fa7d774bbc4bf7f8dc2","lastseenheight":1234,"lastseenview":1,"lostrounds":2}]}}`,
+			result: func(c *Client) interface{} {
+				pub, err := keys.NewPublicKeyFromString("02b3622bf4017bdfe317c58aed5f4c753f206b7db896046fa7d774bbc4bf7f8dc2")
+				if err != nil {
+					panic(err)
+				}
+				return &result.ConsensusState{
+					BlockIndex:   1234,
+					ViewNumber:   1,
+					PrimaryIndex: 2,
+					MyIndex:      0,
+					RoundStart:   1627894840919,
+					ViewChanges:  3,
+					Preparations: 1,
+					Validators: []result.ConsensusValidatorState{{
+						PublicKey:      *pub,
+						LastSeenHeight: 1234,
+						LastSeenView:   1,
+						LostRounds:     2,
+					}},
+				}
+			},
+		},
 	},
 	"getrawmempool": {
 		{
@@ -631,6 +815,38 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 				return []util.Uint256{hash}
 			},
 		},
+		{
+			name: "details",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetRawMemPoolDetails()
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"height":10,"verified":["0x9786cce0dddb524c40ddbdd5e31a41ed1f6b5c8a683c122f627ca4a007a7cf4e"],"unverified":[],"transactions":[{"hash":"0x9786cce0dddb524c40ddbdd5e31a41ed1f6b5c8a683c122f627ca4a007a7cf4e","sender":"NbTiM6h8r99kpRtb428XcsUk1TzKed2gTc","size":250,"sysfee":"100","netfee":"250000","feeperbyte":1000,"highpriority":false,"validuntilblock":20,"conflicts":["0xf5fbd303799f24ba247529d7544d4276cca54ea79f4b98095f2b0557313c5275"]}]}}`,
+			result: func(c *Client) interface{} {
+				hash, err := util.Uint256DecodeStringLE("9786cce0dddb524c40ddbdd5e31a41ed1f6b5c8a683c122f627ca4a007a7cf4e")
+				if err != nil {
+					panic(err)
+				}
+				conflict, err := util.Uint256DecodeStringLE("f5fbd303799f24ba247529d7544d4276cca54ea79f4b98095f2b0557313c5275")
+				if err != nil {
+					panic(err)
+				}
+				return &result.RawMempool{
+					Height:     10,
+					Verified:   []util.Uint256{hash},
+					Unverified: []util.Uint256{},
+					Transactions: []result.MempoolTransaction{{
+						Hash:            hash,
+						Sender:          "NbTiM6h8r99kpRtb428XcsUk1TzKed2gTc",
+						Size:            250,
+						SystemFee:       100,
+						NetworkFee:      250000,
+						FeePerByte:      1000,
+						ValidUntilBlock: 20,
+						Conflicts:       []util.Uint256{conflict},
+					}},
+				}
+			},
+		},
 	},
 	"getrawtransaction": {
 		{
@@ -668,6 +884,82 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 			},
 		},
 	},
+	"getstateheight": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetStateHeight()
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"blockHeight":208,"stateHeight":200}}`,
+			result: func(c *Client) interface{} {
+				return &result.StateHeight{
+					BlockHeight: 208,
+					StateHeight: 200,
+				}
+			},
+		},
+	},
+	"getstoragechanges": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetStorageChanges(5)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"block":5,"size":2,"storage":[{"state":"Changed","key":"AQAAAAE=","value":"Ag=="},{"state":"Deleted","key":"AQAAAAI="}]}}`,
+			result: func(c *Client) interface{} {
+				return &state.StorageChanges{
+					Block: 5,
+					Size:  2,
+					Storage: []state.StorageChange{
+						{State: state.StorageChanged, Key: []byte{1, 0, 0, 0, 1}, Value: []byte{2}},
+						{State: state.StorageDeleted, Key: []byte{1, 0, 0, 0, 2}},
+					},
+				}
+			},
+		},
+	},
+	"getstateroot": {
+		{
+			name: "positive, by height",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetStateRootByHeight(5)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"version":0,"index":5,"roothash":"0x65d19151694321e70c6d184b37a2bcf7af4a2c60c099af332a4f7815e3670686","witnesses":[]}}`,
+			result: func(c *Client) interface{} {
+				h, err := util.Uint256DecodeStringLE("65d19151694321e70c6d184b37a2bcf7af4a2c60c099af332a4f7815e3670686")
+				if err != nil {
+					panic(err)
+				}
+				return &state.MPTRoot{
+					Index:   5,
+					Root:    h,
+					Witness: []transaction.Witness{},
+				}
+			},
+		},
+		{
+			name: "positive, by hash",
+			invoke: func(c *Client) (interface{}, error) {
+				hash, err := util.Uint256DecodeStringLE("86fe1061140b2ea791b0739fb9732abc6e5e47de4927228a1ac41de3d93eb7cb")
+				if err != nil {
+					panic(err)
+				}
+				return c.GetStateRootByBlockHash(hash)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"version":0,"index":5,"roothash":"0x65d19151694321e70c6d184b37a2bcf7af4a2c60c099af332a4f7815e3670686","witnesses":[]}}`,
+			result: func(c *Client) interface{} {
+				h, err := util.Uint256DecodeStringLE("65d19151694321e70c6d184b37a2bcf7af4a2c60c099af332a4f7815e3670686")
+				if err != nil {
+					panic(err)
+				}
+				return &state.MPTRoot{
+					Index:   5,
+					Root:    h,
+					Witness: []transaction.Witness{},
+				}
+			},
+		},
+	},
 	"getstorage": {
 		{
 			name: "by hash, positive",
@@ -726,6 +1018,50 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 			},
 		},
 	},
+	"gettransactiontrace": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				hash, err := util.Uint256DecodeStringLE("cb6ddb5f99d6af4c94a6c396d5294472f2eebc91a2c933e0f527422296fa9fb2")
+				if err != nil {
+					panic(err)
+				}
+				return c.GetTransactionTrace(hash)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"txid":"0xcb6ddb5f99d6af4c94a6c396d5294472f2eebc91a2c933e0f527422296fa9fb2","vmstate":"HALT","gasconsumed":"2007570","call":{"contract":"0x2b1f6a3be0e2c3d8a7f1d1d6c3f1e4e0a6b2c5d4","gasconsumed":"2007570","calls":[{"contract":"0xd2a4cff31913016155e38e474a2c06d08be276cf","method":"transfer","gasconsumed":"1997780"}]},"storage":[{"state":"Changed","key":"+////xQ=","value":"Ag=="}]}}`,
+			result: func(c *Client) interface{} {
+				txHash, err := util.Uint256DecodeStringLE("cb6ddb5f99d6af4c94a6c396d5294472f2eebc91a2c933e0f527422296fa9fb2")
+				if err != nil {
+					panic(err)
+				}
+				script, err := util.Uint160DecodeStringLE("2b1f6a3be0e2c3d8a7f1d1d6c3f1e4e0a6b2c5d4")
+				if err != nil {
+					panic(err)
+				}
+				gas, err := util.Uint160DecodeStringLE("d2a4cff31913016155e38e474a2c06d08be276cf")
+				if err != nil {
+					panic(err)
+				}
+				return &state.TransactionTrace{
+					Container:   txHash,
+					VMState:     vm.HaltState,
+					GasConsumed: 2007570,
+					Call: state.CallTrace{
+						Contract:    script,
+						GasConsumed: 2007570,
+						Calls: []state.CallTrace{{
+							Contract:    gas,
+							Method:      "transfer",
+							GasConsumed: 1997780,
+						}},
+					},
+					Storage: []state.StorageChange{
+						{State: state.StorageChanged, Key: []byte{0xfb, 0xff, 0xff, 0xff, 0x14}, Value: []byte{2}},
+					},
+				}
+			},
+		},
+	},
 	"getunclaimedgas": {
 		{
 			name: "positive",
@@ -863,6 +1199,19 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 				assert.NotNil(t, res.Transaction)
 			},
 		},
+		{
+			name: "positive, NEP24 royaltyInfo",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.NEP24RoyaltyInfo(util.Uint160{}, []byte("token"), util.Uint160{}, 1000)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"script":"EA==","state":"HALT","gasconsumed":"1000000","stack":[{"type":"Array","value":[{"type":"Struct","value":[{"type":"ByteString","value":"AQIDAAAAAAAAAAAAAAAAAAAAAAA="},{"type":"Integer","value":"50"}]}]}]}}`,
+			result: func(c *Client) interface{} {
+				return []RoyaltyRecipient{{
+					Address: util.Uint160{1, 2, 3},
+					Amount:  big.NewInt(50),
+				}}
+			},
+		},
 	},
 	"invokescript": {
 		{
@@ -971,6 +1320,36 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 			},
 		},
 	},
+	"verifyproof": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				root, _ := util.Uint256DecodeStringLE("272002b11a6a39035c719defec3e4e6a8d1f4ae37a995b42734b5788a6977d6c")
+				return c.VerifyProof(root, &result.ProofWithKey{
+					Key:   []byte{1, 2, 3},
+					Proof: [][]byte{{4, 5}},
+				})
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":"BAU="}`,
+			result: func(c *Client) interface{} {
+				return []byte{4, 5}
+			},
+		},
+		{
+			name: "invalid proof",
+			invoke: func(c *Client) (interface{}, error) {
+				root, _ := util.Uint256DecodeStringLE("272002b11a6a39035c719defec3e4e6a8d1f4ae37a995b42734b5788a6977d6c")
+				return c.VerifyProof(root, &result.ProofWithKey{
+					Key:   []byte{1, 2, 3},
+					Proof: [][]byte{{4, 5}},
+				})
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":"invalid"}`,
+			result: func(c *Client) interface{} {
+				return []byte(nil)
+			},
+		},
+	},
 	"validateaddress": {
 		{
 			name: "positive",
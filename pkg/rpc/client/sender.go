@@ -0,0 +1,182 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+)
+
+// DefaultSenderMaxInFlight is the default number of transactions that can be
+// sent by Sender without waiting for their acceptance.
+const DefaultSenderMaxInFlight = 256
+
+// defaultSenderPollingInterval is the default interval of memory pool checks
+// used by Sender when in-flight limit is reached.
+const defaultSenderPollingInterval = time.Second
+
+// SenderOptions contains Sender parameters.
+type SenderOptions struct {
+	// MaxInFlight is the maximum number of sent transactions that are
+	// neither accepted to the chain nor expired yet, DefaultSenderMaxInFlight
+	// is used if not set.
+	MaxInFlight int
+	// PollingInterval is the interval between memory pool checks when
+	// MaxInFlight limit is reached, 1 second is used if not set.
+	PollingInterval time.Duration
+}
+
+// Sender creates, signs and sends transactions on behalf of a single account
+// keeping track of the ones that are still in flight (not yet accepted to the
+// chain). It assigns sequential nonces to transactions, so those never
+// collide even for the same script, and uses the same ValidUntilBlock value
+// for all transactions sent at the same height. Sends are serialized and
+// block when MaxInFlight limit is reached until some of pending transactions
+// are accepted or expire. Sender is safe for concurrent use.
+type Sender struct {
+	c    *Client
+	acc  *wallet.Account
+	opts SenderOptions
+
+	lock     sync.Mutex
+	nonce    uint32
+	height   uint32
+	vub      uint32
+	inFlight map[util.Uint256]uint32
+}
+
+// NewSender creates a new Sender for the specified account using the
+// initialized client.
+func NewSender(c *Client, acc *wallet.Account, opts SenderOptions) (*Sender, error) {
+	if !c.initDone {
+		return nil, errNetworkNotInitialized
+	}
+	if acc == nil || acc.Contract == nil {
+		return nil, errors.New("account has no verification script")
+	}
+	if opts.MaxInFlight <= 0 {
+		opts.MaxInFlight = DefaultSenderMaxInFlight
+	}
+	if opts.PollingInterval <= 0 {
+		opts.PollingInterval = defaultSenderPollingInterval
+	}
+	return &Sender{
+		c:        c,
+		acc:      acc,
+		opts:     opts,
+		nonce:    rand.Uint32(),
+		inFlight: make(map[util.Uint256]uint32),
+	}, nil
+}
+
+// Send creates a transaction with the specified script and parameters (see
+// CreateTxFromScript), signs it with Sender's account and cosigners and sends
+// it to the node. It waits for an in-flight slot if MaxInFlight limit is
+// reached, the wait can be interrupted via ctx.
+func (s *Sender) Send(ctx context.Context, script []byte, sysFee, netFee int64, cosigners []SignerAccount) (util.Uint256, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	height, err := s.wait(ctx)
+	if err != nil {
+		return util.Uint256{}, err
+	}
+	tx, err := s.c.CreateTxFromScript(script, s.acc, sysFee, netFee, cosigners)
+	if err != nil {
+		return util.Uint256{}, err
+	}
+	if s.height != height || s.vub == 0 {
+		s.height = height
+		s.vub = tx.ValidUntilBlock
+	}
+	// Both fields have fixed size, so network fee stays the same.
+	tx.ValidUntilBlock = s.vub
+	s.nonce++
+	tx.Nonce = s.nonce
+	h, err := s.c.SignAndPushTx(tx, s.acc, cosigners)
+	if err != nil {
+		return h, err
+	}
+	s.inFlight[h] = tx.ValidUntilBlock
+	return h, nil
+}
+
+// wait drops expired transactions from the in-flight set and if MaxInFlight
+// limit is reached waits for the free slot checking the memory pool for
+// accepted ones. It returns the current chain height and must be called with
+// the lock held.
+func (s *Sender) wait(ctx context.Context) (uint32, error) {
+	for {
+		count, err := s.c.GetBlockCount()
+		if err != nil {
+			return 0, fmt.Errorf("failed to get block count: %w", err)
+		}
+		height := count - 1
+		for h, vub := range s.inFlight {
+			if vub <= height {
+				delete(s.inFlight, h)
+			}
+		}
+		if len(s.inFlight) < s.opts.MaxInFlight {
+			return height, nil
+		}
+		if err := s.update(); err != nil {
+			return 0, err
+		}
+		if len(s.inFlight) < s.opts.MaxInFlight {
+			return height, nil
+		}
+		t := time.NewTimer(s.opts.PollingInterval)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return 0, fmt.Errorf("waiting for in-flight transactions: %w", ctx.Err())
+		case <-t.C:
+		}
+	}
+}
+
+// update removes transactions missing from the memory pool from the in-flight
+// set. It must be called with the lock held.
+func (s *Sender) update() error {
+	pooled, err := s.c.GetRawMemPool()
+	if err != nil {
+		return fmt.Errorf("failed to get mempool: %w", err)
+	}
+	inPool := make(map[util.Uint256]bool, len(pooled))
+	for _, h := range pooled {
+		inPool[h] = true
+	}
+	for h := range s.inFlight {
+		// Transactions missing from the pool are either accepted or
+		// dropped, both ways they're not in flight anymore.
+		if !inPool[h] {
+			delete(s.inFlight, h)
+		}
+	}
+	return nil
+}
+
+// InFlight returns the number of transactions sent but not yet known to be
+// accepted or expired. The memory pool is only checked when MaxInFlight limit
+// is reached, so accepted transactions can be counted here until then.
+func (s *Sender) InFlight() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return len(s.inFlight)
+}
+
+// IsInFlight checks whether the transaction with the specified hash was sent
+// by Sender and is still in flight. It doesn't contact the node, so the result
+// is as of the last Send (see InFlight).
+func (s *Sender) IsInFlight(h util.Uint256) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	_, ok := s.inFlight[h]
+	return ok
+}
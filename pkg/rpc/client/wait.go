@@ -0,0 +1,160 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// defaultWaitPollingInterval is the default interval between chain state
+// checks used by WaitTx.
+const defaultWaitPollingInterval = time.Second
+
+// ErrTxExpired is returned by WaitTx when the transaction wasn't accepted
+// before its ValidUntilBlock.
+var ErrTxExpired = errors.New("transaction expired")
+
+// unknownTxErrorCode is the code of RPC error returned for unknown
+// transactions.
+const unknownTxErrorCode = -100
+
+// WaitOptions contains WaitTx parameters.
+type WaitOptions struct {
+	// Confirmations is the number of blocks starting from the one containing
+	// the transaction that should be accepted by the chain. Zero and one both
+	// mean waiting for the transaction inclusion only.
+	Confirmations uint32
+	// PollingInterval is the interval between chain state checks when
+	// polling is used, 1 second is used if not set.
+	PollingInterval time.Duration
+	// Polling makes WSClient poll the node instead of using block
+	// subscription. WSClient also falls back to polling if subscription
+	// fails.
+	Polling bool
+}
+
+// WaitResult is the state of the transaction being waited for. It's returned
+// by WaitTx even if an error occurs, so that the caller can see how far the
+// transaction got before the wait was interrupted.
+type WaitResult struct {
+	// AppExecResult is the execution result of the transaction, it's nil
+	// until the transaction is accepted.
+	AppExecResult *state.AppExecResult
+	// Height is the index of the block containing the transaction.
+	Height uint32
+	// Confirmations is the number of blocks accepted starting from the one
+	// containing the transaction.
+	Confirmations uint32
+}
+
+// WaitTx waits for the transaction with the specified hash and ValidUntilBlock
+// to be accepted by the chain and confirmed by the specified number of
+// blocks (see WaitOptions). It polls the node and returns immediately if the
+// required number of confirmations is already reached. ErrTxExpired is
+// returned if the transaction is not accepted before its ValidUntilBlock,
+// other node errors interrupt the wait and are returned to the caller.
+// Context cancellation interrupts the wait returning the current state of the
+// transaction along with ctx error.
+func (c *Client) WaitTx(ctx context.Context, h util.Uint256, vub uint32, opts WaitOptions) (*WaitResult, error) {
+	if opts.PollingInterval <= 0 {
+		opts.PollingInterval = defaultWaitPollingInterval
+	}
+	res := new(WaitResult)
+	for {
+		done, err := c.checkTx(h, vub, opts.Confirmations, res)
+		if done || err != nil {
+			return res, err
+		}
+		t := time.NewTimer(opts.PollingInterval)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return res, ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// WaitTx implements Client's WaitTx using block subscription, every new block
+// triggers transaction state check. It falls back to polling if opts.Polling
+// is set or if subscription fails. Subscription is internal, so events
+// received via Notifications channel are not affected by it (see
+// Notifications).
+func (c *WSClient) WaitTx(ctx context.Context, h util.Uint256, vub uint32, opts WaitOptions) (*WaitResult, error) {
+	if opts.Polling {
+		return c.Client.WaitTx(ctx, h, vub, opts)
+	}
+	r, cleanup, err := c.receive(func(ntf Notification) bool {
+		return ntf.Type == response.BlockEventID || ntf.Type == response.MissedEventID
+	}, 1, "block_added")
+	if err != nil {
+		return c.Client.WaitTx(ctx, h, vub, opts)
+	}
+	defer cleanup()
+
+	res := new(WaitResult)
+	for {
+		// The state is checked after subscription, so blocks accepted
+		// before it are taken into account.
+		done, err := c.checkTx(h, vub, opts.Confirmations, res)
+		if done || err != nil {
+			return res, err
+		}
+		select {
+		case <-ctx.Done():
+			return res, ctx.Err()
+		case <-c.done:
+			return res, errors.New("connection closed")
+		case <-r.ch:
+		}
+	}
+}
+
+// checkTx updates the state of the transaction being waited for and returns
+// true if the required number of confirmations is reached.
+func (c *Client) checkTx(h util.Uint256, vub uint32, confirmations uint32, res *WaitResult) (bool, error) {
+	count, err := c.GetBlockCount()
+	if err != nil {
+		return false, fmt.Errorf("failed to get block count: %w", err)
+	}
+	height := count - 1
+	if res.AppExecResult == nil {
+		txHeight, err := c.GetTransactionHeight(h)
+		if err != nil {
+			if !isUnknownTxError(err) {
+				return false, fmt.Errorf("failed to get transaction height: %w", err)
+			}
+			// Chain height is requested first, so the transaction
+			// isn't in any block up to it and it can't be accepted
+			// after ValidUntilBlock.
+			if height >= vub {
+				return false, ErrTxExpired
+			}
+			return false, nil
+		}
+		aer, err := c.getTxExecResult(h)
+		if err != nil {
+			return false, fmt.Errorf("failed to get application log: %w", err)
+		}
+		res.AppExecResult = aer
+		res.Height = txHeight
+	}
+	if height >= res.Height {
+		res.Confirmations = height - res.Height + 1
+	}
+	return res.Confirmations >= confirmations, nil
+}
+
+// isUnknownTxError checks whether the error is RPC error returned for
+// transactions not accepted by the node.
+func isUnknownTxError(err error) bool {
+	var rpcErr *response.Error
+	return errors.As(err, &rpcErr) && rpcErr.Code == unknownTxErrorCode &&
+		strings.EqualFold(rpcErr.Message, "Unknown transaction")
+}
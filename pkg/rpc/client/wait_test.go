@@ -0,0 +1,19 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsUnknownTxError(t *testing.T) {
+	require.True(t, isUnknownTxError(response.NewRPCError("Unknown transaction", "", nil)))
+	require.True(t, isUnknownTxError(response.NewRPCError("unknown transaction", "", nil)))
+	require.True(t, isUnknownTxError(fmt.Errorf("wrapped: %w", response.NewRPCError("Unknown transaction", "", nil))))
+	require.False(t, isUnknownTxError(response.NewRPCError("Unknown block", "", nil)))
+	require.False(t, isUnknownTxError(response.NewInternalServerError("Unknown transaction", nil)))
+	require.False(t, isUnknownTxError(errors.New("connection refused")))
+}
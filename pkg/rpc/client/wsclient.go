@@ -4,6 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"net/http"
+	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -12,6 +15,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 )
 
@@ -26,20 +30,43 @@ type WSClient struct {
 	// it wants to use subscription mechanism, failing to do so will cause
 	// WSClient to block even regular requests. This channel is not buffered.
 	// In case of protocol error or upon connection closure this channel will
-	// be closed, so make sure to handle this.
+	// be closed, so make sure to handle this. Events of subscriptions made
+	// internally by WaitTx and WaitNotaryRequest are not sent to this channel
+	// unless there is a client's subscription for the same event type, so
+	// while they're running events not matching filters of client's
+	// subscriptions can also be received.
 	Notifications chan Notification
 
-	ws            *websocket.Conn
-	done          chan struct{}
-	responses     chan *response.Raw
-	requests      chan *request.Raw
-	shutdown      chan struct{}
+	ws        *websocket.Conn
+	done      chan struct{}
+	responses chan *response.Raw
+	requests  chan *request.Raw
+	shutdown  chan struct{}
+
+	subsLock sync.RWMutex
+	// subscriptions are client's subscriptions.
 	subscriptions map[string]bool
+	// internalSubscriptions are subscriptions made by WSClient methods
+	// for their own use.
+	internalSubscriptions map[string]bool
+	// subscriptionEvents are event types of all subscriptions.
+	subscriptionEvents map[string]response.EventID
+	receivers          map[*receiver]bool
+}
+
+// receiver is an internal consumer of events received by WSClient, it gets
+// events matching its filter via buffered channel. Events are not delivered
+// if the channel is full, so it's only suitable for events that can be
+// either coalesced or rechecked via regular requests.
+type receiver struct {
+	filter func(Notification) bool
+	ch     chan Notification
 }
 
 // Notification represents server-generated notification for client subscriptions.
-// Value can be one of block.Block, result.ApplicationLog, result.NotificationEvent
-// or transaction.Transaction based on Type.
+// Value can be one of block.Block, result.ApplicationLog, result.NotificationEvent,
+// transaction.Transaction, state.StorageChanges or result.NotaryRequestEvent
+// based on Type.
 type Notification struct {
 	Type  response.EventID
 	Value interface{}
@@ -79,8 +106,12 @@ func NewWS(ctx context.Context, endpoint string, opts Options) (*WSClient, error
 
 	cl.cli = nil
 
+	var header http.Header
+	if opts.APIKey != "" {
+		header = http.Header{"Authorization": []string{"Bearer " + opts.APIKey}}
+	}
 	dialer := websocket.Dialer{HandshakeTimeout: opts.DialTimeout}
-	ws, _, err := dialer.Dial(endpoint, nil)
+	ws, _, err := dialer.Dial(endpoint, header)
 	if err != nil {
 		return nil, err
 	}
@@ -94,6 +125,10 @@ func NewWS(ctx context.Context, endpoint string, opts Options) (*WSClient, error
 		responses:     make(chan *response.Raw),
 		requests:      make(chan *request.Raw),
 		subscriptions: make(map[string]bool),
+
+		internalSubscriptions: make(map[string]bool),
+		subscriptionEvents:    make(map[string]response.EventID),
+		receivers:             make(map[*receiver]bool),
 	}
 	go wsc.wsReader()
 	go wsc.wsWriter()
@@ -146,6 +181,10 @@ readloop:
 				val = new(state.NotificationEvent)
 			case response.ExecutionEventID:
 				val = new(state.AppExecResult)
+			case response.StorageChangesEventID:
+				val = new(state.StorageChanges)
+			case response.NotaryRequestEventID:
+				val = new(result.NotaryRequestEvent)
 			case response.MissedEventID:
 				// No value.
 			default:
@@ -159,7 +198,7 @@ readloop:
 					break
 				}
 			}
-			c.Notifications <- Notification{event, val}
+			c.notify(Notification{event, val})
 		} else if rr.RawID != nil && (rr.Error != nil || rr.Result != nil) {
 			resp := new(response.Raw)
 			resp.ID = rr.RawID
@@ -177,6 +216,80 @@ readloop:
 	close(c.Notifications)
 }
 
+// notify delivers the event to internal receivers and to Notifications
+// channel. Events that can only be generated by internal subscriptions are not
+// sent to Notifications.
+func (c *WSClient) notify(ntf Notification) {
+	var user, internal bool
+	c.subsLock.RLock()
+	for id, event := range c.subscriptionEvents {
+		if ntf.Type != response.MissedEventID && ntf.Type != event {
+			continue
+		}
+		if c.internalSubscriptions[id] {
+			internal = true
+		} else {
+			user = true
+		}
+	}
+	for r := range c.receivers {
+		if r.filter(ntf) {
+			select {
+			case r.ch <- ntf:
+			default:
+			}
+		}
+	}
+	c.subsLock.RUnlock()
+	if user || !internal {
+		c.Notifications <- ntf
+	}
+}
+
+// addReceiver registers a new internal receiver with the specified filter
+// and channel capacity.
+func (c *WSClient) addReceiver(filter func(Notification) bool, capacity int) *receiver {
+	r := &receiver{filter: filter, ch: make(chan Notification, capacity)}
+	c.subsLock.Lock()
+	c.receivers[r] = true
+	c.subsLock.Unlock()
+	return r
+}
+
+// removeReceiver unregisters the receiver, no events are sent to it after
+// that.
+func (c *WSClient) removeReceiver(r *receiver) {
+	c.subsLock.Lock()
+	delete(c.receivers, r)
+	c.subsLock.Unlock()
+}
+
+// receive makes internal subscriptions for the specified events and
+// registers a receiver with the given filter and channel capacity for them.
+// The function returned removes both and must be called once the receiver is
+// no longer needed.
+func (c *WSClient) receive(filter func(Notification) bool, capacity int, events ...string) (*receiver, func(), error) {
+	var (
+		r   = c.addReceiver(filter, capacity)
+		ids = make([]string, 0, len(events))
+	)
+	cleanup := func() {
+		for _, id := range ids {
+			_ = c.performInternalUnsubscription(id)
+		}
+		c.removeReceiver(r)
+	}
+	for _, event := range events {
+		id, err := c.performInternalSubscription(request.NewRawParams(event))
+		if err != nil {
+			cleanup()
+			return nil, nil, fmt.Errorf("failed to subscribe for %s: %w", event, err)
+		}
+		ids = append(ids, id)
+	}
+	return r, cleanup, nil
+}
+
 func (c *WSClient) wsWriter() {
 	pingTicker := time.NewTicker(wsPingPeriod)
 	defer c.ws.Close()
@@ -220,28 +333,72 @@ func (c *WSClient) makeWsRequest(r *request.Raw) (*response.Raw, error) {
 }
 
 func (c *WSClient) performSubscription(params request.RawParams) (string, error) {
+	return c.subscribe(params, false)
+}
+
+// performInternalSubscription makes subscription for WSClient's own use, its
+// events are only delivered to receivers (see notify).
+func (c *WSClient) performInternalSubscription(params request.RawParams) (string, error) {
+	return c.subscribe(params, true)
+}
+
+func (c *WSClient) subscribe(params request.RawParams, internal bool) (string, error) {
 	var resp string
 
 	if err := c.performRequest("subscribe", params, &resp); err != nil {
 		return "", err
 	}
-	c.subscriptions[resp] = true
+	c.subsLock.Lock()
+	if internal {
+		c.internalSubscriptions[resp] = true
+	} else {
+		c.subscriptions[resp] = true
+	}
+	if name, ok := params.Values[0].(string); ok {
+		if event, err := response.GetEventIDFromString(name); err == nil {
+			c.subscriptionEvents[resp] = event
+		}
+	}
+	c.subsLock.Unlock()
 	return resp, nil
 }
 
 func (c *WSClient) performUnsubscription(id string) error {
-	var resp bool
+	c.subsLock.RLock()
+	ok := c.subscriptions[id]
+	c.subsLock.RUnlock()
+	if !ok {
+		return errors.New("no subscription with this ID")
+	}
+	return c.unsubscribe(id)
+}
 
-	if !c.subscriptions[id] {
+// performInternalUnsubscription removes subscription made with
+// performInternalSubscription.
+func (c *WSClient) performInternalUnsubscription(id string) error {
+	c.subsLock.RLock()
+	ok := c.internalSubscriptions[id]
+	c.subsLock.RUnlock()
+	if !ok {
 		return errors.New("no subscription with this ID")
 	}
+	return c.unsubscribe(id)
+}
+
+func (c *WSClient) unsubscribe(id string) error {
+	var resp bool
+
 	if err := c.performRequest("unsubscribe", request.NewRawParams(id), &resp); err != nil {
 		return err
 	}
 	if !resp {
 		return errors.New("unsubscribe method returned false result")
 	}
+	c.subsLock.Lock()
 	delete(c.subscriptions, id)
+	delete(c.internalSubscriptions, id)
+	delete(c.subscriptionEvents, id)
+	c.subsLock.Unlock()
 	return nil
 }
 
@@ -279,6 +436,18 @@ func (c *WSClient) SubscribeForExecutionNotifications(contract *util.Uint160, na
 	return c.performSubscription(params)
 }
 
+// SubscribeForExecutionNotificationsWithParams is the same as
+// SubscribeForExecutionNotifications, but it also allows to filter
+// notifications by the values of their parameters (compared as byte arrays),
+// each element of params specifies parameter's index and expected value.
+func (c *WSClient) SubscribeForExecutionNotificationsWithParams(contract *util.Uint160, name *string, params []request.NotificationParameter) (string, error) {
+	ps := request.NewRawParams("notification_from_execution")
+	if contract != nil || name != nil || len(params) != 0 {
+		ps.Values = append(ps.Values, request.NotificationFilter{Contract: contract, Name: name, Parameters: params})
+	}
+	return c.performSubscription(ps)
+}
+
 // SubscribeForTransactionExecutions adds subscription for application execution
 // results generated during transaction execution to this instance of client. Can
 // be filtered by state (HALT/FAULT) to check for successful or failing
@@ -294,6 +463,31 @@ func (c *WSClient) SubscribeForTransactionExecutions(state *string) (string, err
 	return c.performSubscription(params)
 }
 
+// SubscribeForStorageChanges adds subscription for per-block contract storage
+// changes to this instance of client. Changes for every block are delivered
+// before the corresponding block_added event. They can be filtered by contract
+// ID and/or storage item key prefix, nil values are treated as missing filter
+// and only changes matching the filter are delivered.
+func (c *WSClient) SubscribeForStorageChanges(id *int32, prefix []byte) (string, error) {
+	params := request.NewRawParams("storage_changes")
+	if id != nil || prefix != nil {
+		params.Values = append(params.Values, request.StorageFilter{ID: id, Prefix: prefix})
+	}
+	return c.performSubscription(params)
+}
+
+// SubscribeForNotaryRequests adds subscription for P2PNotaryRequest payloads
+// added to or removed from the node's notary request pool to this instance of
+// client. It can be filtered by main transaction sender and/or signer, nil
+// value is treated as missing filter.
+func (c *WSClient) SubscribeForNotaryRequests(sender *util.Uint160, mainSigner *util.Uint160) (string, error) {
+	params := request.NewRawParams("notary_request_event")
+	if sender != nil || mainSigner != nil {
+		params.Values = append(params.Values, request.TxFilter{Sender: sender, Signer: mainSigner})
+	}
+	return c.performSubscription(params)
+}
+
 // Unsubscribe removes subscription for given event stream.
 func (c *WSClient) Unsubscribe(id string) error {
 	return c.performUnsubscription(id)
@@ -301,7 +495,13 @@ func (c *WSClient) Unsubscribe(id string) error {
 
 // UnsubscribeAll removes all active subscriptions of current client.
 func (c *WSClient) UnsubscribeAll() error {
+	c.subsLock.RLock()
+	ids := make([]string, 0, len(c.subscriptions))
 	for id := range c.subscriptions {
+		ids = append(ids, id)
+	}
+	c.subsLock.RUnlock()
+	for _, id := range ids {
 		err := c.performUnsubscription(id)
 		if err != nil {
 			return err
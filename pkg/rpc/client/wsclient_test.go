@@ -37,6 +37,12 @@ func TestWSClientSubscription(t *testing.T) {
 		"executions": func(wsc *WSClient) (string, error) {
 			return wsc.SubscribeForTransactionExecutions(nil)
 		},
+		"storage changes": func(wsc *WSClient) (string, error) {
+			return wsc.SubscribeForStorageChanges(nil, nil)
+		},
+		"notary requests": func(wsc *WSClient) (string, error) {
+			return wsc.SubscribeForNotaryRequests(nil, nil)
+		},
 	}
 	t.Run("good", func(t *testing.T) {
 		for name, f := range cases {
@@ -120,6 +126,7 @@ func TestWSClientEvents(t *testing.T) {
 		`{"jsonrpc":"2.0","method":"notification_from_execution","params":[{"contract":"0x1b4357bff5a01bdf2a6581247cf9ed1e24629176","eventname":"contract call","state":{"type":"Array","value":[{"type":"ByteString","value":"dHJhbnNmZXI="},{"type":"Array","value":[{"type":"ByteString","value":"dpFiJB7t+XwkgWUq3xug9b9XQxs="},{"type":"ByteString","value":"MW6FEDkBnTnfwsN9bD/uGf1YCYc="},{"type":"Integer","value":"1000"}]}]}}]}`,
 		`{"jsonrpc":"2.0","method":"transaction_executed","params":[{"container":"0xf97a72b7722c109f909a8bc16c22368c5023d85828b09b127b237aace33cf099","trigger":"Application","vmstate":"HALT","gasconsumed":"6042610","stack":[],"notifications":[{"contract":"0xe65ff7b3a02d207b584a5c27057d4e9862ef01da","eventname":"contract call","state":{"type":"Array","value":[{"type":"ByteString","value":"dHJhbnNmZXI="},{"type":"Array","value":[{"type":"ByteString","value":"MW6FEDkBnTnfwsN9bD/uGf1YCYc="},{"type":"ByteString","value":"IHKCdK+vw29DoHHTKM+j5inZy7A="},{"type":"Integer","value":"123"}]}]}},{"contract":"0xe65ff7b3a02d207b584a5c27057d4e9862ef01da","eventname":"transfer","state":{"type":"Array","value":[{"type":"ByteString","value":"MW6FEDkBnTnfwsN9bD/uGf1YCYc="},{"type":"ByteString","value":"IHKCdK+vw29DoHHTKM+j5inZy7A="},{"type":"Integer","value":"123"}]}}]}]}`,
 		fmt.Sprintf(`{"jsonrpc":"2.0","method":"block_added","params":[%s]}`, b1Verbose),
+		`{"jsonrpc":"2.0","method":"storage_changes","params":[{"block":1,"size":1,"storage":[{"state":"Added","key":"+v///xQ=","value":"QQEhAegD"}]}]}`,
 		`{"jsonrpc":"2.0","method":"event_missed","params":[]}`,
 	}
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
@@ -290,6 +297,56 @@ func TestWSFilteredSubscriptions(t *testing.T) {
 				require.Equal(t, "my_pretty_notification", *filt.Name)
 			},
 		},
+		{"notifications parameters",
+			func(t *testing.T, wsc *WSClient) {
+				name := "my_pretty_notification"
+				_, err := wsc.SubscribeForExecutionNotificationsWithParams(nil, &name, []request.NotificationParameter{
+					{Index: 1, Value: []byte{1, 2, 3}},
+				})
+				require.NoError(t, err)
+			},
+			func(t *testing.T, p *request.Params) {
+				param := p.Value(1)
+				require.NotNil(t, param)
+				require.Equal(t, request.NotificationFilterT, param.Type)
+				filt, ok := param.Value.(request.NotificationFilter)
+				require.Equal(t, true, ok)
+				require.Equal(t, "my_pretty_notification", *filt.Name)
+				require.Nil(t, filt.Contract)
+				require.Equal(t, []request.NotificationParameter{{Index: 1, Value: []byte{1, 2, 3}}}, filt.Parameters)
+			},
+		},
+		{"storage changes",
+			func(t *testing.T, wsc *WSClient) {
+				id := int32(-5)
+				_, err := wsc.SubscribeForStorageChanges(&id, []byte{0x0b})
+				require.NoError(t, err)
+			},
+			func(t *testing.T, p *request.Params) {
+				param := p.Value(1)
+				require.NotNil(t, param)
+				require.Equal(t, request.StorageFilterT, param.Type)
+				filt, ok := param.Value.(request.StorageFilter)
+				require.Equal(t, true, ok)
+				require.Equal(t, int32(-5), *filt.ID)
+				require.Equal(t, []byte{0x0b}, filt.Prefix)
+			},
+		},
+		{"notary requests",
+			func(t *testing.T, wsc *WSClient) {
+				_, err := wsc.SubscribeForNotaryRequests(nil, &util.Uint160{1, 2, 3})
+				require.NoError(t, err)
+			},
+			func(t *testing.T, p *request.Params) {
+				param := p.Value(1)
+				require.NotNil(t, param)
+				require.Equal(t, request.TxFilterT, param.Type)
+				filt, ok := param.Value.(request.TxFilter)
+				require.Equal(t, true, ok)
+				require.Nil(t, filt.Sender)
+				require.Equal(t, util.Uint160{1, 2, 3}, *filt.Signer)
+			},
+		},
 		{"executions",
 			func(t *testing.T, wsc *WSClient) {
 				state := "FAULT"
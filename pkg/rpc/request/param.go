@@ -10,6 +10,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
@@ -46,10 +47,19 @@ type (
 	}
 	// NotificationFilter is a wrapper structure representing filter used for
 	// notifications generated during transaction execution. Notifications can
-	// be filtered by contract hash and by name.
+	// be filtered by contract hash, by name and by parameter values.
 	NotificationFilter struct {
-		Contract *util.Uint160 `json:"contract,omitempty"`
-		Name     *string       `json:"name,omitempty"`
+		Contract   *util.Uint160           `json:"contract,omitempty"`
+		Name       *string                 `json:"name,omitempty"`
+		Parameters []NotificationParameter `json:"parameters,omitempty"`
+	}
+	// NotificationParameter is a filter for a single notification parameter,
+	// notification matches it if its parameter with the given index has the
+	// given value (compared as a byte string, so it's Hash160 in big-endian
+	// and integer in little-endian two's complement representation).
+	NotificationParameter struct {
+		Index int    `json:"index"`
+		Value []byte `json:"value"`
 	}
 	// ExecutionFilter is a wrapper structure used for transaction execution
 	// events. It allows to choose failing or successful transactions based
@@ -57,6 +67,13 @@ type (
 	ExecutionFilter struct {
 		State string `json:"state"`
 	}
+	// StorageFilter is a wrapper structure used for contract storage changes
+	// events. It allows to choose changes of the specific contract (by
+	// its ID) and/or with the specific item key prefix.
+	StorageFilter struct {
+		ID     *int32 `json:"id,omitempty"`
+		Prefix []byte `json:"prefix,omitempty"`
+	}
 	// SignerWithWitness represents transaction's signer with the corresponding witness.
 	SignerWithWitness struct {
 		transaction.Signer
@@ -75,7 +92,9 @@ const (
 	TxFilterT
 	NotificationFilterT
 	ExecutionFilterT
+	StorageFilterT
 	SignerWithWitnessT
+	StateOverridesT
 )
 
 var errMissingParameter = errors.New("parameter is missing")
@@ -224,6 +243,22 @@ func (p Param) GetSignerWithWitness() (SignerWithWitness, error) {
 	return c, nil
 }
 
+// GetStateOverrides returns state.Overrides value of the parameter, null is
+// treated as no overrides.
+func (p *Param) GetStateOverrides() (*state.Overrides, error) {
+	if p == nil {
+		return nil, errMissingParameter
+	}
+	if p.Type == defaultT {
+		return new(state.Overrides), nil
+	}
+	o, ok := p.Value.(state.Overrides)
+	if !ok {
+		return nil, errors.New("not state overrides")
+	}
+	return &o, nil
+}
+
 // GetSignersWithWitnesses returns a slice of SignerWithWitness with CalledByEntry
 // scope from array of Uint160 or array of serialized transaction.Signer stored
 // in the parameter.
@@ -272,7 +307,9 @@ func (p *Param) UnmarshalJSON(data []byte) error {
 		{TxFilterT, &TxFilter{}},
 		{NotificationFilterT, &NotificationFilter{}},
 		{ExecutionFilterT, &ExecutionFilter{}},
+		{StorageFilterT, &StorageFilter{}},
 		{SignerWithWitnessT, &signerWithWitnessAux{}},
+		{StateOverridesT, &state.Overrides{}},
 		{ArrayT, &[]Param{}},
 	}
 
@@ -307,6 +344,8 @@ func (p *Param) UnmarshalJSON(data []byte) error {
 				} else {
 					continue
 				}
+			case *StorageFilter:
+				p.Value = *val
 			case *signerWithWitnessAux:
 				aux := *val
 				p.Value = SignerWithWitness{
@@ -321,6 +360,8 @@ func (p *Param) UnmarshalJSON(data []byte) error {
 						VerificationScript: aux.VerificationScript,
 					},
 				}
+			case *state.Overrides:
+				p.Value = *val
 			case *[]Param:
 				p.Value = *val
 			}
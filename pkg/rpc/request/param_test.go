@@ -4,8 +4,10 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
+	"math/big"
 	"testing"
 
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
@@ -24,11 +26,15 @@ func TestParam_UnmarshalJSON(t *testing.T) {
                  {"name": "my_pretty_notification"},
                  {"contract": "f84d6a337fbc3d3a201d41da99e86b479e7a2554", "name":"my_pretty_notification"},
                  {"state": "HALT"},
+                 {"contract": "f84d6a337fbc3d3a201d41da99e86b479e7a2554", "parameters": [{"index": 3, "value": "AQI="}]},
+                 {"id": 5, "prefix": "AQI="},
                  {"account": "0xcadb3dc2faa3ef14a13b619c9a43124755aa2569"},
+                 {"witnesses": ["0xcadb3dc2faa3ef14a13b619c9a43124755aa2569"], "balances": [{"token": "f84d6a337fbc3d3a201d41da99e86b479e7a2554", "account": "0xcadb3dc2faa3ef14a13b619c9a43124755aa2569", "amount": 100}]},
                  [{"account": "0xcadb3dc2faa3ef14a13b619c9a43124755aa2569", "scopes": "Global"}]]`
 	contr, err := util.Uint160DecodeStringLE("f84d6a337fbc3d3a201d41da99e86b479e7a2554")
 	require.NoError(t, err)
 	name := "my_pretty_notification"
+	id := int32(5)
 	accountHash, err := util.Uint160DecodeStringLE("cadb3dc2faa3ef14a13b619c9a43124755aa2569")
 	require.NoError(t, err)
 	expected := Params{
@@ -103,6 +109,17 @@ func TestParam_UnmarshalJSON(t *testing.T) {
 			Type:  ExecutionFilterT,
 			Value: ExecutionFilter{State: "HALT"},
 		},
+		{
+			Type: NotificationFilterT,
+			Value: NotificationFilter{
+				Contract:   &contr,
+				Parameters: []NotificationParameter{{Index: 3, Value: []byte{1, 2}}},
+			},
+		},
+		{
+			Type:  StorageFilterT,
+			Value: StorageFilter{ID: &id, Prefix: []byte{1, 2}},
+		},
 		{
 			Type: SignerWithWitnessT,
 			Value: SignerWithWitness{
@@ -112,6 +129,17 @@ func TestParam_UnmarshalJSON(t *testing.T) {
 				},
 			},
 		},
+		{
+			Type: StateOverridesT,
+			Value: state.Overrides{
+				Balances: []state.BalanceOverride{{
+					Token:   contr,
+					Account: accountHash,
+					Amount:  big.NewInt(100),
+				}},
+				Witnesses: []util.Uint160{accountHash},
+			},
+		},
 		{
 			Type: ArrayT,
 			Value: []Param{
@@ -322,6 +350,27 @@ func TestParamGetSigner(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestParamGetStateOverrides(t *testing.T) {
+	o := state.Overrides{Witnesses: []util.Uint160{{1, 2, 3}}}
+	p := &Param{Type: StateOverridesT, Value: o}
+	actual, err := p.GetStateOverrides()
+	require.NoError(t, err)
+	require.Equal(t, &o, actual)
+
+	p = &Param{Type: defaultT}
+	actual, err = p.GetStateOverrides()
+	require.NoError(t, err)
+	require.Equal(t, new(state.Overrides), actual)
+
+	p = &Param{Type: StringT, Value: "overrides"}
+	_, err = p.GetStateOverrides()
+	require.Error(t, err)
+
+	p = nil
+	_, err = p.GetStateOverrides()
+	require.Error(t, err)
+}
+
 func TestParamGetSigners(t *testing.T) {
 	u1 := util.Uint160{1, 2, 3, 4}
 	u2 := util.Uint160{5, 6, 7, 8}
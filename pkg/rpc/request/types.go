@@ -12,8 +12,8 @@ const (
 	// JSONRPCVersion is the only JSON-RPC protocol version supported.
 	JSONRPCVersion = "2.0"
 
-	// maxBatchSize is the maximum number of request per batch.
-	maxBatchSize = 100
+	// DefaultMaxBatchSize is the default maximum number of requests per batch.
+	DefaultMaxBatchSize = 100
 )
 
 // RawParams is just a slice of abstract values, used to represent parameters
@@ -71,6 +71,12 @@ func (r Request) MarshalJSON() ([]byte, error) {
 
 // UnmarshalJSON implements json.Unmarshaler interface.
 func (r *Request) UnmarshalJSON(data []byte) error {
+	return r.UnmarshalJSONWithLimit(data, DefaultMaxBatchSize)
+}
+
+// UnmarshalJSONWithLimit is the same as UnmarshalJSON, but allows to specify
+// the maximum number of requests per batch.
+func (r *Request) UnmarshalJSONWithLimit(data []byte, maxBatchSize int) error {
 	var (
 		in    *In
 		batch Batch
@@ -91,7 +97,7 @@ func (r *Request) UnmarshalJSON(data []byte) error {
 	}
 	count := 0
 	for decoder.More() {
-		if count > maxBatchSize {
+		if count >= maxBatchSize {
 			return fmt.Errorf("the number of requests in batch shouldn't exceed %d", maxBatchSize)
 		}
 		in = &In{}
@@ -112,6 +118,12 @@ func (r *Request) UnmarshalJSON(data []byte) error {
 // DecodeData decodes the given reader into the the request
 // struct.
 func (r *Request) DecodeData(data io.ReadCloser) error {
+	return r.DecodeDataWithLimit(data, DefaultMaxBatchSize)
+}
+
+// DecodeDataWithLimit is the same as DecodeData, but allows to specify the
+// maximum number of requests per batch.
+func (r *Request) DecodeDataWithLimit(data io.ReadCloser, maxBatchSize int) error {
 	defer data.Close()
 
 	rawData := json.RawMessage{}
@@ -120,7 +132,7 @@ func (r *Request) DecodeData(data io.ReadCloser) error {
 		return fmt.Errorf("error parsing JSON payload: %w", err)
 	}
 
-	return r.UnmarshalJSON(rawData)
+	return r.UnmarshalJSONWithLimit(rawData, maxBatchSize)
 }
 
 // NewRequest creates a new Request struct.
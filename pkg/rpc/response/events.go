@@ -22,6 +22,10 @@ const (
 	NotificationEventID
 	// ExecutionEventID is used for `transaction_executed` events.
 	ExecutionEventID
+	// StorageChangesEventID is used for `storage_changes` events.
+	StorageChangesEventID
+	// NotaryRequestEventID is used for `notary_request_event` events.
+	NotaryRequestEventID
 	// MissedEventID notifies user of missed events.
 	MissedEventID EventID = 255
 )
@@ -37,6 +41,10 @@ func (e EventID) String() string {
 		return "notification_from_execution"
 	case ExecutionEventID:
 		return "transaction_executed"
+	case StorageChangesEventID:
+		return "storage_changes"
+	case NotaryRequestEventID:
+		return "notary_request_event"
 	case MissedEventID:
 		return "event_missed"
 	default:
@@ -55,6 +63,10 @@ func GetEventIDFromString(s string) (EventID, error) {
 		return NotificationEventID, nil
 	case "transaction_executed":
 		return ExecutionEventID, nil
+	case "storage_changes":
+		return StorageChangesEventID, nil
+	case "notary_request_event":
+		return NotaryRequestEventID, nil
 	case "event_missed":
 		return MissedEventID, nil
 	default:
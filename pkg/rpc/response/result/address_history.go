@@ -0,0 +1,25 @@
+package result
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// AddressHistory is a result for the getaddresshistory RPC call.
+type AddressHistory struct {
+	Address      string               `json:"address"`
+	Transactions []AddressTransaction `json:"transactions"`
+	// Page is the number of page returned.
+	Page int `json:"page"`
+	// More is true if there are more transactions on the next pages.
+	More bool `json:"more"`
+}
+
+// AddressTransaction represents a single transaction signed by the account.
+type AddressTransaction struct {
+	TxHash    util.Uint256 `json:"txhash"`
+	Index     uint32       `json:"blockindex"`
+	Timestamp uint64       `json:"timestamp"`
+	// Sender is true if the account is the sender of the transaction (its
+	// first signer).
+	Sender bool `json:"sender"`
+}
@@ -0,0 +1,13 @@
+package result
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+)
+
+// BundleSimulation is the result of `simulatetransactions` call, it contains
+// execution results of every transaction simulated and the storage changes
+// made by all of them.
+type BundleSimulation struct {
+	Executions []ApplicationLog      `json:"executions"`
+	Storage    *state.StorageChanges `json:"storage"`
+}
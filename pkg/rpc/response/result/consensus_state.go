@@ -0,0 +1,31 @@
+package result
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+)
+
+// ConsensusState is a result of getconsensusstate RPC call.
+type ConsensusState struct {
+	BlockIndex   uint32 `json:"blockindex"`
+	ViewNumber   byte   `json:"viewnumber"`
+	PrimaryIndex uint   `json:"primaryindex"`
+	// MyIndex is an index of the node in validators list, -1 if it's not
+	// a validator.
+	MyIndex int `json:"myindex"`
+	// RoundStart is a timestamp (in milliseconds) of the current view start.
+	RoundStart   uint64                    `json:"roundstart"`
+	ViewChanges  uint64                    `json:"viewchanges"`
+	Preparations int                       `json:"preparations"`
+	Commits      int                       `json:"commits"`
+	ChangeViews  int                       `json:"changeviews"`
+	Validators   []ConsensusValidatorState `json:"validators"`
+}
+
+// ConsensusValidatorState contains consensus statistics for a single
+// validator.
+type ConsensusValidatorState struct {
+	PublicKey      keys.PublicKey `json:"publickey"`
+	LastSeenHeight uint32         `json:"lastseenheight"`
+	LastSeenView   byte           `json:"lastseenview"`
+	LostRounds     uint64         `json:"lostrounds"`
+}
@@ -0,0 +1,16 @@
+package result
+
+// FeeEstimation is a result of estimatefees RPC call. All fees are network
+// fees per byte of transaction (NetworkFee divided by transaction size, in
+// GAS fractions), this is what mempool uses to prioritize transactions.
+type FeeEstimation struct {
+	// Minimum is the minimum fee per byte set by the Policy contract.
+	Minimum int64 `json:"minimum"`
+	Low     int64 `json:"low"`
+	Medium  int64 `json:"medium"`
+	High    int64 `json:"high"`
+	// Blocks is the number of recent blocks analyzed.
+	Blocks int `json:"blocks"`
+	// MempoolSize is the number of verified transactions in the mempool.
+	MempoolSize int `json:"mempoolsize"`
+}
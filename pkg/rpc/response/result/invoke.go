@@ -2,8 +2,11 @@ package result
 
 import (
 	"encoding/json"
+	"time"
 
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/vm/replay"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 )
 
@@ -16,6 +19,28 @@ type Invoke struct {
 	Stack          []stackitem.Item
 	FaultException string
 	Transaction    *transaction.Transaction
+	Diagnostics    *InvokeDiag
+}
+
+// InvokeDiag contains invocation diagnostics: execution statistics of every
+// opcode and syscall used, sorted by GAS consumed (in descending order), the
+// invocation record that can be replayed offline (or the reason it couldn't
+// be recorded) and contract storage changes the invocation would make.
+type InvokeDiag struct {
+	Opcodes     []ProfileEntry      `json:"opcodes"`
+	Syscalls    []ProfileEntry      `json:"syscalls"`
+	Record      *replay.Record      `json:"record,omitempty"`
+	RecordError string              `json:"recorderror,omitempty"`
+	Storage     []state.StorageDiff `json:"storage,omitempty"`
+}
+
+// ProfileEntry contains execution statistics of a single opcode or syscall,
+// its Time is a total execution time in nanoseconds.
+type ProfileEntry struct {
+	Name        string        `json:"name"`
+	Count       int           `json:"count"`
+	GasConsumed int64         `json:"gasconsumed,string"`
+	Time        time.Duration `json:"time"`
 }
 
 type invokeAux struct {
@@ -25,6 +50,7 @@ type invokeAux struct {
 	Stack          json.RawMessage `json:"stack"`
 	FaultException string          `json:"exception,omitempty"`
 	Transaction    []byte          `json:"tx,omitempty"`
+	Diagnostics    *InvokeDiag     `json:"diagnostics,omitempty"`
 }
 
 // MarshalJSON implements json.Marshaler.
@@ -58,6 +84,7 @@ func (r Invoke) MarshalJSON() ([]byte, error) {
 		Stack:          st,
 		FaultException: r.FaultException,
 		Transaction:    txbytes,
+		Diagnostics:    r.Diagnostics,
 	})
 }
 
@@ -93,5 +120,6 @@ func (r *Invoke) UnmarshalJSON(data []byte) error {
 	r.State = aux.State
 	r.FaultException = aux.FaultException
 	r.Transaction = tx
+	r.Diagnostics = aux.Diagnostics
 	return nil
 }
@@ -45,3 +45,36 @@ func TestInvoke_MarshalJSON(t *testing.T) {
 	require.NoError(t, json.Unmarshal(data, actual))
 	require.Equal(t, result, actual)
 }
+
+func TestInvoke_MarshalJSONDiagnostics(t *testing.T) {
+	result := &Invoke{
+		State:       "HALT",
+		GasConsumed: 60,
+		Script:      []byte{10},
+		Stack:       []stackitem.Item{},
+		Diagnostics: &InvokeDiag{
+			Opcodes: []ProfileEntry{{Name: "SYSCALL", Count: 1, GasConsumed: 30, Time: 1500}},
+			Syscalls: []ProfileEntry{
+				{Name: "System.Runtime.GetTime", Count: 1, GasConsumed: 30, Time: 1500},
+			},
+		},
+	}
+
+	data, err := json.Marshal(result)
+	require.NoError(t, err)
+	expected := `{
+		"state":"HALT",
+		"gasconsumed":"60",
+		"script":"` + base64.StdEncoding.EncodeToString(result.Script) + `",
+		"stack":[],
+		"diagnostics":{
+			"opcodes":[{"name":"SYSCALL","count":1,"gasconsumed":"30","time":1500}],
+			"syscalls":[{"name":"System.Runtime.GetTime","count":1,"gasconsumed":"30","time":1500}]
+		}
+}`
+	require.JSONEq(t, expected, string(data))
+
+	actual := new(Invoke)
+	require.NoError(t, json.Unmarshal(data, actual))
+	require.Equal(t, result, actual)
+}
@@ -3,11 +3,23 @@ package result
 import (
 	"bytes"
 	"encoding/base64"
+	"encoding/binary"
 	"encoding/json"
+	"errors"
+	"fmt"
 
+	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
+	"github.com/nspcc-dev/neo-go/pkg/core/native"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/util"
 )
 
+// ManagementContractHash is a hash of native Management contract storing
+// contract states.
+var ManagementContractHash = state.CreateContractHash(util.Uint160{}, 0, nativenames.Management)
+
 // StateHeight is a result of getstateheight RPC.
 type StateHeight struct {
 	BlockHeight uint32 `json:"blockHeight"`
@@ -20,6 +32,14 @@ type ProofWithKey struct {
 	Proof [][]byte
 }
 
+// VerifiedStorageItem is a contract storage item with value checked against
+// the trusted state root.
+type VerifiedStorageItem struct {
+	ContractID int32
+	Key        []byte
+	Value      []byte
+}
+
 // VerifyProof is a result of verifyproof RPC.
 // nil Value is considered invalid.
 type VerifyProof struct {
@@ -54,6 +74,14 @@ func (p *ProofWithKey) DecodeBinary(r *io.BinReader) {
 	}
 }
 
+// StorageKey splits proof key into contract ID and storage item key.
+func (p *ProofWithKey) StorageKey() (int32, []byte, error) {
+	if len(p.Key) < 4 {
+		return 0, nil, errors.New("proof key is too short")
+	}
+	return int32(binary.LittleEndian.Uint32(p.Key)), p.Key[4:], nil
+}
+
 // UnmarshalJSON implements json.Unmarshaler.
 func (p *ProofWithKey) UnmarshalJSON(data []byte) error {
 	var s string
@@ -106,3 +134,36 @@ func (p *VerifyProof) UnmarshalJSON(data []byte) error {
 	p.Value = b
 	return nil
 }
+
+// ContractStateKey returns Management contract storage key of the given
+// contract state, its proof (requested for ManagementContractHash) binds
+// contract hash to contract ID.
+func ContractStateKey(h util.Uint160) []byte {
+	return native.MakeContractKey(h)
+}
+
+// CheckContractStateProof checks contract state proof against the trusted
+// state root and returns the ID of the contract with the given hash.
+func CheckContractStateProof(root util.Uint256, h util.Uint160, p *ProofWithKey) (int32, error) {
+	id, k, err := p.StorageKey()
+	if err != nil {
+		return 0, err
+	}
+	if id != native.ManagementContractID || !bytes.Equal(k, ContractStateKey(h)) {
+		return 0, fmt.Errorf("%w: proof is not for contract %s state", mpt.ErrInvalidProof, h.StringLE())
+	}
+	val, err := mpt.CheckProof(root, p.Key, p.Proof)
+	if err != nil {
+		return 0, err
+	}
+	cs := new(state.Contract)
+	r := io.NewBinReaderFromBuf(val)
+	cs.DecodeBinary(r)
+	if r.Err != nil {
+		return 0, fmt.Errorf("%w: bad contract state: %v", mpt.ErrInvalidProof, r.Err)
+	}
+	if !cs.Hash.Equals(h) {
+		return 0, fmt.Errorf("%w: contract state hash mismatch", mpt.ErrInvalidProof)
+	}
+	return cs.ID, nil
+}
@@ -60,3 +60,15 @@ func TestVerifyProof_MarshalJSON(t *testing.T) {
 		testserdes.MarshalUnmarshalJSON(t, vp, &VerifyProof{[]byte{1, 2, 3}})
 	})
 }
+
+func TestProofWithKey_StorageKey(t *testing.T) {
+	p := &ProofWithKey{Key: []byte{0x05, 0, 0, 0, 'k', 'e', 'y'}}
+	id, key, err := p.StorageKey()
+	require.NoError(t, err)
+	require.Equal(t, int32(5), id)
+	require.Equal(t, []byte("key"), key)
+
+	p.Key = []byte{1, 2}
+	_, _, err = p.StorageKey()
+	require.Error(t, err)
+}
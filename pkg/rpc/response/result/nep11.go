@@ -0,0 +1,48 @@
+package result
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// NEP11Balances is a result for the getnep11balances RPC call.
+type NEP11Balances struct {
+	Balances []NEP11AssetBalance `json:"balance"`
+	Address  string              `json:"address"`
+}
+
+// NEP11AssetBalance represents balances of all tokens owned by account in the
+// single NEP11 contract.
+type NEP11AssetBalance struct {
+	Asset  util.Uint160        `json:"assethash"`
+	Tokens []NEP11TokenBalance `json:"tokens"`
+}
+
+// NEP11TokenBalance represents balance of the single NEP11 token.
+type NEP11TokenBalance struct {
+	ID          string `json:"tokenid"`
+	Amount      string `json:"amount"`
+	LastUpdated uint32 `json:"lastupdatedblock"`
+}
+
+// NEP11Transfers is a result for the getnep11transfers RPC.
+type NEP11Transfers struct {
+	Sent     []NEP11Transfer `json:"sent"`
+	Received []NEP11Transfer `json:"received"`
+	Address  string          `json:"address"`
+	// Next is an opaque cursor that can be passed instead of page number
+	// to get the next batch of transfers, it's only set if there are more
+	// transfers in the requested time frame.
+	Next string `json:"next,omitempty"`
+}
+
+// NEP11Transfer represents single NEP11 transfer event.
+type NEP11Transfer struct {
+	Timestamp   uint64       `json:"timestamp"`
+	Asset       util.Uint160 `json:"assethash"`
+	Address     string       `json:"transferaddress,omitempty"`
+	ID          string       `json:"tokenid"`
+	Amount      string       `json:"amount"`
+	Index       uint32       `json:"blockindex"`
+	NotifyIndex uint32       `json:"transfernotifyindex"`
+	TxHash      util.Uint256 `json:"txhash"`
+}
@@ -22,6 +22,10 @@ type NEP17Transfers struct {
 	Sent     []NEP17Transfer `json:"sent"`
 	Received []NEP17Transfer `json:"received"`
 	Address  string          `json:"address"`
+	// Next is an opaque cursor that can be passed instead of page number
+	// to get the next batch of transfers, it's only set if there are more
+	// transfers in the requested time frame.
+	Next string `json:"next,omitempty"`
 }
 
 // NEP17Transfer represents single NEP17 transfer event.
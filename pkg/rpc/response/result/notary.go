@@ -0,0 +1,56 @@
+package result
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/mempool"
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// RawNotaryPool represents a result of getrawnotarypool RPC call. Hashes
+// contains fallback transaction hashes of pooled P2PNotaryRequest payloads
+// grouped by their main transaction hash.
+type RawNotaryPool struct {
+	Hashes map[util.Uint256][]util.Uint256
+}
+
+// rawNotaryPoolAux is an auxiliary struct for RawNotaryPool JSON marshalling.
+type rawNotaryPoolAux struct {
+	Hashes map[string][]util.Uint256 `json:"hashes"`
+}
+
+// NotaryRequestEvent represents a P2PNotaryRequest payload added to or
+// removed from the notary request pool, it's sent via `notary_request_event`
+// notifications.
+type NotaryRequestEvent struct {
+	Type          mempool.EventType         `json:"type"`
+	NotaryRequest *payload.P2PNotaryRequest `json:"notaryrequest"`
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (p RawNotaryPool) MarshalJSON() ([]byte, error) {
+	aux := rawNotaryPoolAux{Hashes: make(map[string][]util.Uint256, len(p.Hashes))}
+	for main, fallbacks := range p.Hashes {
+		aux.Hashes["0x"+main.StringLE()] = fallbacks
+	}
+	return json.Marshal(aux)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (p *RawNotaryPool) UnmarshalJSON(data []byte) error {
+	var aux rawNotaryPoolAux
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	p.Hashes = make(map[util.Uint256][]util.Uint256, len(aux.Hashes))
+	for main, fallbacks := range aux.Hashes {
+		h, err := util.Uint256DecodeStringLE(strings.TrimPrefix(main, "0x"))
+		if err != nil {
+			return err
+		}
+		p.Hashes[h] = fallbacks
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package result
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// ContractNotifications is a result for the getnotifications RPC call.
+type ContractNotifications struct {
+	Contract      util.Uint160           `json:"contract"`
+	Notifications []ContractNotification `json:"notifications"`
+	// Next is an opaque cursor that can be passed instead of page number
+	// to get the next batch of notifications, it's only set if there are
+	// more notifications matching the request.
+	Next string `json:"next,omitempty"`
+}
+
+// ContractNotification is a notification along with the block and container
+// (transaction or block for OnPersist/PostPersist triggers) it was emitted in.
+type ContractNotification struct {
+	state.NotificationEvent
+	Block     uint32
+	Container util.Uint256
+}
+
+// contractNotificationAux is an auxiliary struct for ContractNotification JSON
+// marshalling.
+type contractNotificationAux struct {
+	Block     uint32       `json:"blockindex"`
+	Container util.Uint256 `json:"container"`
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (n ContractNotification) MarshalJSON() ([]byte, error) {
+	aux, err := json.Marshal(&contractNotificationAux{
+		Block:     n.Block,
+		Container: n.Container,
+	})
+	if err != nil {
+		return nil, err
+	}
+	ev, err := json.Marshal(n.NotificationEvent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal notification: %w", err)
+	}
+	if aux[len(aux)-1] != '}' || ev[0] != '{' {
+		return nil, errors.New("can't merge internal jsons")
+	}
+	aux[len(aux)-1] = ','
+	aux = append(aux, ev[1:]...)
+	return aux, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (n *ContractNotification) UnmarshalJSON(data []byte) error {
+	aux := new(contractNotificationAux)
+	if err := json.Unmarshal(data, aux); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, &n.NotificationEvent); err != nil {
+		return err
+	}
+	n.Block = aux.Block
+	n.Container = aux.Container
+	return nil
+}
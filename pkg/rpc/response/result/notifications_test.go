@@ -0,0 +1,27 @@
+package result
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/internal/random"
+	"github.com/nspcc-dev/neo-go/internal/testserdes"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+func TestContractNotifications_MarshalJSON(t *testing.T) {
+	h := random.Uint160()
+	ns := &ContractNotifications{
+		Contract: h,
+		Notifications: []ContractNotification{{
+			NotificationEvent: state.NotificationEvent{
+				ScriptHash: h,
+				Name:       "Event",
+				Item:       stackitem.NewArray([]stackitem.Item{stackitem.NewBool(true)}),
+			},
+			Block:     42,
+			Container: random.Uint256(),
+		}},
+	}
+	testserdes.MarshalUnmarshalJSON(t, ns, new(ContractNotifications))
+}
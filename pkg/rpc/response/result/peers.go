@@ -10,6 +10,8 @@ type (
 		Unconnected Peers `json:"unconnected"`
 		Connected   Peers `json:"connected"`
 		Bad         Peers `json:"bad"`
+		// Scores is only present in verbose mode.
+		Scores []PeerScore `json:"scores,omitempty"`
 	}
 
 	// Peers represent a slice of peers.
@@ -20,6 +22,18 @@ type (
 		Address string `json:"address"`
 		Port    string `json:"port"`
 	}
+
+	// PeerScore represents peer reputation data (tracked per IP address).
+	PeerScore struct {
+		Address       string `json:"address"`
+		Score         int    `json:"score"`
+		Violations    int    `json:"violations"`
+		SlowResponses int    `json:"slowresponses"`
+		StaleHeights  int    `json:"staleheights"`
+		// BannedUntil is ban expiration timestamp in milliseconds, it's
+		// omitted for peers that are not banned.
+		BannedUntil uint64 `json:"banneduntil,omitempty"`
+	}
 )
 
 // NewGetPeers creates a new GetPeers structure.
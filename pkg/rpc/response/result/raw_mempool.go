@@ -7,4 +7,21 @@ type RawMempool struct {
 	Height     uint32         `json:"height"`
 	Verified   []util.Uint256 `json:"verified"`
 	Unverified []util.Uint256 `json:"unverified"`
+	// Transactions contains details of verified transactions in the same
+	// (priority) order as Verified, it's only present if requested.
+	Transactions []MempoolTransaction `json:"transactions,omitempty"`
+}
+
+// MempoolTransaction contains details of a transaction from the mempool.
+type MempoolTransaction struct {
+	Hash            util.Uint256   `json:"hash"`
+	Sender          string         `json:"sender"`
+	Size            int            `json:"size"`
+	SystemFee       int64          `json:"sysfee,string"`
+	NetworkFee      int64          `json:"netfee,string"`
+	FeePerByte      int64          `json:"feeperbyte"`
+	HighPriority    bool           `json:"highpriority"`
+	NotValidBefore  uint32         `json:"notvalidbefore,omitempty"`
+	ValidUntilBlock uint32         `json:"validuntilblock"`
+	Conflicts       []util.Uint256 `json:"conflicts,omitempty"`
 }
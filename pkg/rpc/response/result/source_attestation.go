@@ -0,0 +1,37 @@
+package result
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+type (
+	// SourceAttestation is a result of contract source verification (via
+	// verifysource RPC call or CLI), it states whether the given source
+	// code compiled with the pinned compiler version produces the NEF of
+	// the deployed contract.
+	SourceAttestation struct {
+		Contract util.Uint160 `json:"contract"`
+		// Compiler is the compiler used for verification.
+		Compiler string `json:"compiler"`
+		// Sources are the hashes of source files compiled.
+		Sources []SourceFile `json:"sources"`
+		// Checksum is the checksum of the compiled NEF.
+		Checksum uint32 `json:"checksum"`
+		// ContractCompiler is the compiler specified in the deployed
+		// contract NEF.
+		ContractCompiler string `json:"contractcompiler"`
+		// ContractChecksum is the checksum of the deployed contract NEF.
+		ContractChecksum uint32 `json:"contractchecksum"`
+		// Height is the chain height the deployed contract was taken at.
+		Height   uint32 `json:"height"`
+		Verified bool   `json:"verified"`
+		// Reason explains why verification failed.
+		Reason string `json:"reason,omitempty"`
+	}
+
+	// SourceFile is a contract source file name with its SHA256 hash.
+	SourceFile struct {
+		Name string       `json:"name"`
+		Hash util.Uint256 `json:"hash"`
+	}
+)
@@ -0,0 +1,8 @@
+package result
+
+// StorageCompaction is a result of compactstorage RPC call.
+type StorageCompaction struct {
+	// Reclaimed is the number of bytes freed by the compaction, it can be
+	// negative if the database has grown during the process.
+	Reclaimed int64 `json:"reclaimed"`
+}
@@ -0,0 +1,50 @@
+package result
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// VotingState is a result of getvotingstate RPC call.
+type VotingState struct {
+	// Height is the current blockchain height.
+	Height uint32 `json:"height"`
+	// NextCommitteeUpdate is the index of the next block committee is
+	// updated at.
+	NextCommitteeUpdate uint32 `json:"nextcommitteeupdate"`
+	// Committee is the current (sorted) committee.
+	Committee keys.PublicKeys `json:"committee"`
+	// NextValidators are (sorted) validators expected to be elected at the
+	// next committee update.
+	NextValidators keys.PublicKeys   `json:"nextvalidators"`
+	Candidates     []VotingCandidate `json:"candidates"`
+	// Voter is the state of the voter requested (if any).
+	Voter *VoterState `json:"voter,omitempty"`
+}
+
+// VotingCandidate is the voting state of a single registered candidate.
+type VotingCandidate struct {
+	PublicKey keys.PublicKey `json:"publickey"`
+	Votes     int64          `json:"votes,string"`
+	// Committee is true for members of the current committee.
+	Committee bool `json:"committee"`
+	// NextCommittee and NextValidator are true for candidates expected to be
+	// elected at the next committee update.
+	NextCommittee bool `json:"nextcommittee"`
+	NextValidator bool `json:"nextvalidator"`
+	// VoterReward is the estimated amount of GAS per block distributed among
+	// candidate voters after the next committee update, it's zero for
+	// candidates not expected to be elected.
+	VoterReward int64 `json:"voterreward,string"`
+}
+
+// VoterState is the voting state of a single NEO holder.
+type VoterState struct {
+	Address util.Uint160    `json:"address"`
+	Balance int64           `json:"balance,string"`
+	VoteTo  *keys.PublicKey `json:"voteto"`
+	// RewardPerBlock is the estimated amount of GAS per block the voter gets
+	// for its vote after the next committee update.
+	RewardPerBlock int64 `json:"rewardperblock,string"`
+	Unclaimed      int64 `json:"unclaimed,string"`
+}
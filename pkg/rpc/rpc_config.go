@@ -7,14 +7,72 @@ import (
 type (
 	// Config is an RPC service configuration information
 	Config struct {
-		Address              string `yaml:"Address"`
-		Enabled              bool   `yaml:"Enabled"`
-		EnableCORSWorkaround bool   `yaml:"EnableCORSWorkaround"`
+		Address string `yaml:"Address"`
+		// Auth contains API key authentication settings for sensitive
+		// methods.
+		Auth                 AuthConfig `yaml:"Auth"`
+		Enabled              bool       `yaml:"Enabled"`
+		EnableCORSWorkaround bool       `yaml:"EnableCORSWorkaround"`
+		// EnableAdminMethods allows to use node management methods
+		// (like compactstorage or getconsensusstate) via RPC.
+		EnableAdminMethods bool `yaml:"EnableAdminMethods"`
+		// EnableSourceVerification allows to use verifysource method
+		// which compiles contract source code given by the client.
+		EnableSourceVerification bool `yaml:"EnableSourceVerification"`
+		// MaxBatchSize is the maximum number of requests per batch,
+		// request.DefaultMaxBatchSize is used if it's not set.
+		MaxBatchSize int `yaml:"MaxBatchSize"`
 		// MaxGasInvoke is a maximum amount of gas which
 		// can be spent during RPC call.
 		MaxGasInvoke fixedn.Fixed8 `yaml:"MaxGasInvoke"`
-		Port         uint16        `yaml:"Port"`
-		TLSConfig    TLSConfig     `yaml:"TLSConfig"`
+		// MaxNEP17TransfersLimit is the maximum number of transfers returned
+		// by a single getnep17transfers (or getnep11transfers) call, 1000 is
+		// used if it's not set.
+		MaxNEP17TransfersLimit int `yaml:"MaxNEP17TransfersLimit"`
+		// MaxNotificationsLimit is the maximum number of notifications
+		// returned by a single getnotifications call, 1000 is used if it's
+		// not set.
+		MaxNotificationsLimit int    `yaml:"MaxNotificationsLimit"`
+		Port                  uint16 `yaml:"Port"`
+		// RateLimit contains per-client request rate limiting settings.
+		RateLimit RateLimitConfig `yaml:"RateLimit"`
+		TLSConfig TLSConfig       `yaml:"TLSConfig"`
+	}
+
+	// AuthConfig describes API key authentication for RPC methods.
+	AuthConfig struct {
+		Enabled bool `yaml:"Enabled"`
+		// Keys is a list of accepted API keys, clients pass them via
+		// `Authorization: Bearer <key>` HTTP header.
+		Keys []string `yaml:"Keys"`
+		// Methods is a list of methods requiring authentication, by default
+		// these are node management, block and transaction submission
+		// methods and subscriptions.
+		Methods []string `yaml:"Methods"`
+	}
+
+	// RateLimitConfig describes request rate limiting applied to every
+	// client IP address.
+	RateLimitConfig struct {
+		Enabled bool `yaml:"Enabled"`
+		// RequestsPerSecond is the number of requests per second allowed
+		// for a single client, 20 is used if it's not set.
+		RequestsPerSecond float64 `yaml:"RequestsPerSecond"`
+		// Burst is the maximum number of requests a client can make at
+		// once, it's twice the RequestsPerSecond if not set.
+		Burst int `yaml:"Burst"`
+		// Methods contains additional per-method limits (in the same
+		// per-client manner). invokefunction, invokescript,
+		// invokecontractverify and verifysource are limited to 2 requests
+		// per second by default unless specified here, zero
+		// RequestsPerSecond removes method limit.
+		Methods map[string]MethodRateLimit `yaml:"Methods"`
+	}
+
+	// MethodRateLimit is a rate limit for a single RPC method.
+	MethodRateLimit struct {
+		RequestsPerSecond float64 `yaml:"RequestsPerSecond"`
+		Burst             int     `yaml:"Burst"`
 	}
 
 	// TLSConfig describes SSL/TLS configuration.
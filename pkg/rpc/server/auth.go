@@ -0,0 +1,83 @@
+package server
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/pkg/rpc"
+)
+
+// defaultAuthMethods are the methods requiring authentication if the list is
+// not configured explicitly.
+var defaultAuthMethods = []string{
+	"compactstorage",
+	"getconsensusstate",
+	"sendrawtransaction",
+	"submitblock",
+	"submitnotaryrequest",
+	"subscribe",
+}
+
+type (
+	// authenticator checks API keys for protected methods.
+	authenticator struct {
+		keys    [][]byte
+		methods map[string]bool
+	}
+
+	// requestSource describes the client request comes from.
+	requestSource struct {
+		addr       string
+		authorized bool
+	}
+)
+
+// newAuthenticator creates authenticator according to the configuration, it
+// returns nil if authentication is disabled.
+func newAuthenticator(cfg rpc.AuthConfig) *authenticator {
+	if !cfg.Enabled {
+		return nil
+	}
+	methods := cfg.Methods
+	if len(methods) == 0 {
+		methods = defaultAuthMethods
+	}
+	a := &authenticator{
+		keys:    make([][]byte, len(cfg.Keys)),
+		methods: make(map[string]bool, len(methods)),
+	}
+	for i := range cfg.Keys {
+		a.keys[i] = []byte(cfg.Keys[i])
+	}
+	for _, m := range methods {
+		a.methods[m] = true
+	}
+	return a
+}
+
+// check returns true if the HTTP request contains one of the accepted API
+// keys in its `Authorization` header.
+func (a *authenticator) check(r *http.Request) bool {
+	if a == nil {
+		return true
+	}
+	const prefix = "Bearer "
+	h := r.Header.Get("Authorization")
+	if !strings.HasPrefix(h, prefix) {
+		return false
+	}
+	key := []byte(strings.TrimPrefix(h, prefix))
+	var ok bool
+	for i := range a.keys {
+		if subtle.ConstantTimeCompare(key, a.keys[i]) == 1 {
+			ok = true
+		}
+	}
+	return ok
+}
+
+// protected returns true if the method requires authentication.
+func (a *authenticator) protected(method string) bool {
+	return a != nil && a.methods[method]
+}
@@ -0,0 +1,59 @@
+package server
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/rpc"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestAuthenticator(t *testing.T) {
+	require.Nil(t, newAuthenticator(rpc.AuthConfig{Keys: []string{"key"}}))
+	require.False(t, (*authenticator)(nil).protected("submitblock"))
+	require.True(t, (*authenticator)(nil).check(&http.Request{}))
+
+	a := newAuthenticator(rpc.AuthConfig{Enabled: true, Keys: []string{"key1", "key2"}})
+	for _, m := range defaultAuthMethods {
+		require.True(t, a.protected(m))
+	}
+	require.False(t, a.protected("getversion"))
+
+	check := func(h string) bool {
+		r := &http.Request{Header: make(http.Header)}
+		if h != "" {
+			r.Header.Set("Authorization", h)
+		}
+		return a.check(r)
+	}
+	require.True(t, check("Bearer key1"))
+	require.True(t, check("Bearer key2"))
+	require.False(t, check("Bearer key3"))
+	require.False(t, check("key1"))
+	require.False(t, check(""))
+
+	t.Run("custom methods", func(t *testing.T) {
+		a := newAuthenticator(rpc.AuthConfig{Enabled: true, Methods: []string{"getversion"}})
+		require.True(t, a.protected("getversion"))
+		require.False(t, a.protected("submitblock"))
+	})
+}
+
+func TestHandleInUnauthorized(t *testing.T) {
+	s := &Server{
+		auth: newAuthenticator(rpc.AuthConfig{Enabled: true, Keys: []string{"key"}}),
+		log:  zaptest.NewLogger(t),
+	}
+	in := &request.In{JSONRPC: request.JSONRPCVersion, Method: "submitblock", RawParams: []byte(`[]`)}
+	resp := s.handleIn(in, nil, requestSource{addr: "127.0.0.1:1234"})
+	require.NotNil(t, resp.Error)
+	require.Equal(t, int64(-32001), resp.Error.Code)
+	require.Equal(t, http.StatusUnauthorized, resp.Error.HTTPCode)
+
+	in.Method = "unknownmethod"
+	resp = s.handleIn(in, nil, requestSource{addr: "127.0.0.1:1234"})
+	require.NotNil(t, resp.Error)
+	require.Equal(t, int64(-32601), resp.Error.Code)
+}
@@ -1,20 +1,36 @@
 package server
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/big"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/nspcc-dev/neo-go/internal/testchain"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/fee"
+	"github.com/nspcc-dev/neo-go/pkg/core/mempool"
+	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
+	"github.com/nspcc-dev/neo-go/pkg/core/native"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nnsrecords"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/bigint"
 	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/client"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
@@ -542,6 +558,20 @@ func TestSignAndPushInvocationTx(t *testing.T) {
 			})
 			require.Error(t, err)
 		})
+		t.Run("signer0: sig; signer1: address-only", func(t *testing.T) {
+			pk, err := keys.NewPrivateKey()
+			require.NoError(t, err)
+			tx := transaction.New([]byte{byte(opcode.PUSH1)}, 30)
+			tx.Signers = []transaction.Signer{
+				{Account: priv0.GetScriptHash(), Scopes: transaction.CalledByEntry},
+				{Account: pk.GetScriptHash(), Scopes: transaction.CalledByEntry},
+			}
+			_, err = c.SignAndPushTx(tx, acc0, []client.SignerAccount{{
+				Signer:  tx.Signers[1],
+				Account: wallet.NewWatchOnlyAccount(pk.GetScriptHash()),
+			}})
+			require.Error(t, err)
+		})
 	})
 }
 
@@ -635,6 +665,379 @@ func TestSignAndPushP2PNotaryRequest(t *testing.T) {
 	})
 }
 
+func TestClient_NotaryDeposit(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChainAndServices(t, false, true)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	owner := testchain.PrivateKeyByID(0).GetScriptHash() // owner of the deposit in testchain
+	balance, err := c.GetNotaryBalance(owner)
+	require.NoError(t, err)
+	require.Equal(t, chain.GetNotaryBalance(owner).Int64(), balance)
+	require.True(t, balance > 0)
+	till, err := c.GetNotaryDepositExpiration(owner)
+	require.NoError(t, err)
+	require.Equal(t, chain.GetNotaryDepositExpiration(owner), till)
+
+	balance, err = c.GetNotaryBalance(util.Uint160{1, 2, 3})
+	require.NoError(t, err)
+	require.Equal(t, int64(0), balance)
+
+	t.Run("insufficient deposit", func(t *testing.T) {
+		acc, err := wallet.NewAccount()
+		require.NoError(t, err)
+		mainTx := &transaction.Transaction{
+			Attributes:      []transaction.Attribute{{Type: transaction.NotaryAssistedT, Value: &transaction.NotaryAssisted{NKeys: 1}}},
+			Script:          []byte{byte(opcode.RET)},
+			ValidUntilBlock: chain.BlockHeight() + 5,
+			Signers:         []transaction.Signer{{Account: util.Uint160{1, 5, 9}}},
+			Scripts:         []transaction.Witness{{}},
+		}
+		_, err = c.SignAndPushP2PNotaryRequest(mainTx, []byte{byte(opcode.RET)}, -1, 0, 6, acc)
+		require.Error(t, err)
+	})
+}
+
+func TestCreateNotaryMainTx(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChainAndServices(t, false, true)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+	require.NoError(t, err)
+
+	acc := wallet.NewAccountFromPrivateKey(testchain.PrivateKeyByID(0))
+	cosigners := []client.SignerAccount{{
+		Signer: transaction.Signer{
+			Account: acc.Contract.ScriptHash(),
+			Scopes:  transaction.CalledByEntry,
+		},
+		Account: acc,
+	}}
+	t.Run("client wasn't initialized", func(t *testing.T) {
+		_, err := c.CreateNotaryMainTx([]byte{byte(opcode.RET)}, -1, 0, 1, cosigners)
+		require.Error(t, err)
+	})
+	require.NoError(t, c.Init())
+	t.Run("no cosigners", func(t *testing.T) {
+		_, err := c.CreateNotaryMainTx([]byte{byte(opcode.RET)}, -1, 0, 1, nil)
+		require.Error(t, err)
+	})
+
+	tx, err := c.CreateNotaryMainTx([]byte{byte(opcode.PUSH1)}, -1, 10, 1, cosigners)
+	require.NoError(t, err)
+	require.Equal(t, []transaction.Signer{
+		cosigners[0].Signer,
+		{Account: chain.GetNotaryContractScriptHash(), Scopes: transaction.None},
+	}, tx.Signers)
+	require.Equal(t, []transaction.Attribute{{
+		Type:  transaction.NotaryAssistedT,
+		Value: &transaction.NotaryAssisted{NKeys: 1},
+	}}, tx.Attributes)
+	require.True(t, tx.SystemFee > 0)
+	require.Equal(t, 2, len(tx.Scripts))
+	require.Equal(t, acc.Contract.Script, tx.Scripts[0].VerificationScript)
+	require.Equal(t, 0, len(tx.Scripts[0].InvocationScript))
+	require.Equal(t, 66, len(tx.Scripts[1].InvocationScript))
+
+	notaryFee, err := c.CalculateNotaryFee(1)
+	require.NoError(t, err)
+	netFee, sizeDelta := fee.Calculate(chain.GetBaseExecFee(), acc.Contract.Script)
+	expected := notaryFee + netFee + 10
+	tx.Scripts = nil
+	expected += int64(io.GetVarSize(tx)+sizeDelta) * chain.FeePerByte()
+	require.Equal(t, expected, tx.NetworkFee)
+}
+
+func TestWSClient_WaitNotaryRequest(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChainAndServices(t, false, true)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	c, err := client.NewWS(context.Background(), "ws"+strings.TrimPrefix(httpSrv.URL, "http")+"/ws", client.Options{})
+	require.NoError(t, err)
+	defer c.Close()
+	require.NoError(t, c.Init())
+
+	acc := wallet.NewAccountFromPrivateKey(testchain.PrivateKeyByID(0)) // owner of the deposit in testchain
+	newRequest := func(t *testing.T, nonce uint32, validFor uint32) *payload.P2PNotaryRequest {
+		mainTx := &transaction.Transaction{
+			Nonce:           nonce,
+			Attributes:      []transaction.Attribute{{Type: transaction.NotaryAssistedT, Value: &transaction.NotaryAssisted{NKeys: 1}}},
+			Script:          []byte{byte(opcode.RET)},
+			ValidUntilBlock: chain.BlockHeight() + validFor,
+			Signers:         []transaction.Signer{{Account: util.Uint160{1, 5, 9}}},
+			Scripts:         []transaction.Witness{{}},
+		}
+		req, err := c.SignAndPushP2PNotaryRequest(mainTx, []byte{byte(opcode.RET)}, -1, 0, validFor, acc)
+		require.NoError(t, err)
+		return req
+	}
+	type waitResult struct {
+		aer *state.AppExecResult
+		err error
+	}
+	wait := func(req *payload.P2PNotaryRequest) chan waitResult {
+		ch := make(chan waitResult, 1)
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			aer, err := c.WaitNotaryRequest(ctx, req)
+			ch <- waitResult{aer, err}
+		}()
+		return ch
+	}
+
+	t.Run("fallback", func(t *testing.T) {
+		req := newRequest(t, 1, 6)
+		ch := wait(req)
+
+		w, err := wallet.NewWalletFromFile(notaryPath)
+		require.NoError(t, err)
+		ntr := w.Accounts[0]
+		require.NoError(t, ntr.Decrypt(notaryPass))
+		req.FallbackTransaction.Scripts[0] = transaction.Witness{
+			InvocationScript:   append([]byte{byte(opcode.PUSHDATA1), 64}, ntr.PrivateKey().SignHashable(uint32(testchain.Network()), req.FallbackTransaction)...),
+			VerificationScript: []byte{},
+		}
+		require.NoError(t, chain.AddBlock(testchain.NewBlock(t, chain, 1, 0, req.FallbackTransaction)))
+
+		res := <-ch
+		require.NoError(t, res.err)
+		require.Equal(t, req.FallbackTransaction.Hash(), res.aer.Container)
+		require.Equal(t, vm.HaltState, res.aer.VMState)
+
+		// Already persisted.
+		res = <-wait(req)
+		require.NoError(t, res.err)
+		require.Equal(t, req.FallbackTransaction.Hash(), res.aer.Container)
+	})
+	t.Run("expired", func(t *testing.T) {
+		req := newRequest(t, 2, 2)
+		ch := wait(req)
+		for i := 0; i < 3; i++ {
+			require.NoError(t, chain.AddBlock(testchain.NewBlock(t, chain, 1, 0)))
+		}
+		res := <-ch
+		require.True(t, errors.Is(res.err, client.ErrNotaryRequestExpired), res.err)
+	})
+}
+
+func TestWaitTx(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+	wsc, err := client.NewWS(context.Background(), "ws"+strings.TrimPrefix(httpSrv.URL, "http")+"/ws", client.Options{})
+	require.NoError(t, err)
+	defer wsc.Close()
+	require.NoError(t, wsc.Init())
+
+	acc := wallet.NewAccountFromPrivateKey(testchain.PrivateKeyByID(0))
+	newTx := func(t *testing.T) *transaction.Transaction {
+		tx, err := c.CreateTxFromScript([]byte{byte(opcode.PUSH1)}, acc, -1, 0, nil)
+		require.NoError(t, err)
+		require.NoError(t, acc.SignTx(testchain.Network(), tx))
+		return tx
+	}
+	type waitResult struct {
+		res *client.WaitResult
+		err error
+	}
+	waiters := map[string]func(context.Context, util.Uint256, uint32, client.WaitOptions) (*client.WaitResult, error){
+		"polling":   c.WaitTx,
+		"websocket": wsc.WaitTx,
+	}
+	for name, waitTx := range waiters {
+		waitTx := waitTx
+		wait := func(h util.Uint256, vub uint32, opts client.WaitOptions) chan waitResult {
+			ch := make(chan waitResult, 1)
+			go func() {
+				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				res, err := waitTx(ctx, h, vub, opts)
+				ch <- waitResult{res, err}
+			}()
+			return ch
+		}
+		opts := client.WaitOptions{PollingInterval: 10 * time.Millisecond}
+		t.Run(name, func(t *testing.T) {
+			t.Run("confirmations", func(t *testing.T) {
+				tx := newTx(t)
+				opts := opts
+				opts.Confirmations = 3
+				ch := wait(tx.Hash(), tx.ValidUntilBlock, opts)
+				require.NoError(t, chain.AddBlock(testchain.NewBlock(t, chain, 1, 0, tx)))
+				height := chain.BlockHeight()
+				for i := 0; i < 2; i++ {
+					require.NoError(t, chain.AddBlock(testchain.NewBlock(t, chain, 1, 0)))
+				}
+				r := <-ch
+				require.NoError(t, r.err)
+				require.Equal(t, tx.Hash(), r.res.AppExecResult.Container)
+				require.Equal(t, vm.HaltState, r.res.AppExecResult.VMState)
+				require.Equal(t, height, r.res.Height)
+				require.Equal(t, uint32(3), r.res.Confirmations)
+
+				// Already confirmed.
+				r = <-wait(tx.Hash(), tx.ValidUntilBlock, opts)
+				require.NoError(t, r.err)
+				require.Equal(t, uint32(3), r.res.Confirmations)
+			})
+			t.Run("partial", func(t *testing.T) {
+				tx := newTx(t)
+				require.NoError(t, chain.AddBlock(testchain.NewBlock(t, chain, 1, 0, tx)))
+				ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+				defer cancel()
+				res, err := waitTx(ctx, tx.Hash(), tx.ValidUntilBlock, client.WaitOptions{
+					Confirmations:   100,
+					PollingInterval: 10 * time.Millisecond,
+				})
+				require.True(t, errors.Is(err, context.DeadlineExceeded))
+				require.NotNil(t, res.AppExecResult)
+				require.Equal(t, uint32(1), res.Confirmations)
+			})
+			t.Run("expired", func(t *testing.T) {
+				tx := newTx(t) // never sent
+				ch := wait(tx.Hash(), chain.BlockHeight()+1, opts)
+				for i := 0; i < 2; i++ {
+					require.NoError(t, chain.AddBlock(testchain.NewBlock(t, chain, 1, 0)))
+				}
+				r := <-ch
+				require.True(t, errors.Is(r.err, client.ErrTxExpired), r.err)
+				require.Nil(t, r.res.AppExecResult)
+			})
+		})
+	}
+	t.Run("client subscriptions", func(t *testing.T) {
+		_, err := wsc.SubscribeForTransactionExecutions(nil)
+		require.NoError(t, err)
+		var (
+			stop = make(chan struct{})
+			ntfs = make(chan client.Notification, 100)
+		)
+		go func() {
+			for {
+				select {
+				case <-stop:
+					return
+				case ntf := <-wsc.Notifications:
+					ntfs <- ntf
+				}
+			}
+		}()
+		defer close(stop)
+
+		tx := newTx(t)
+		ch := make(chan error, 1)
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			_, err := wsc.WaitTx(ctx, tx.Hash(), tx.ValidUntilBlock, client.WaitOptions{})
+			ch <- err
+		}()
+		require.NoError(t, chain.AddBlock(testchain.NewBlock(t, chain, 1, 0, tx)))
+		require.NoError(t, <-ch)
+
+		// Client's events are delivered, internal block events are not.
+		for {
+			select {
+			case ntf := <-ntfs:
+				require.Equal(t, response.ExecutionEventID, ntf.Type)
+				if ntf.Value.(*state.AppExecResult).Container == tx.Hash() {
+					return
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("no execution event for the transaction")
+			}
+		}
+	})
+}
+
+func TestClient_NotaryPool(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChainAndServices(t, false, true)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+	// Network server is not started in tests.
+	pool := rpcSrv.coreServer.GetNotaryPool()
+	pool.RunSubscriptions()
+	defer pool.StopSubscriptions()
+
+	c, err := client.NewWS(context.Background(), "ws"+strings.TrimPrefix(httpSrv.URL, "http")+"/ws", client.Options{})
+	require.NoError(t, err)
+	defer c.Close()
+	require.NoError(t, c.Init())
+
+	signer := util.Uint160{1, 5, 9}
+	_, err = c.SubscribeForNotaryRequests(nil, &signer)
+	require.NoError(t, err)
+
+	acc := wallet.NewAccountFromPrivateKey(testchain.PrivateKeyByID(0)) // owner of the deposit in testchain
+	newRequest := func(t *testing.T, nonce uint32, mainSigner util.Uint160) *payload.P2PNotaryRequest {
+		mainTx := &transaction.Transaction{
+			Nonce:           nonce,
+			Attributes:      []transaction.Attribute{{Type: transaction.NotaryAssistedT, Value: &transaction.NotaryAssisted{NKeys: 1}}},
+			Script:          []byte{byte(opcode.RET)},
+			ValidUntilBlock: chain.BlockHeight() + 5,
+			Signers:         []transaction.Signer{{Account: mainSigner}},
+			Scripts:         []transaction.Witness{{}},
+		}
+		req, err := c.SignAndPushP2PNotaryRequest(mainTx, []byte{byte(opcode.RET)}, -1, 0, 5, acc)
+		require.NoError(t, err)
+		return req
+	}
+	other := newRequest(t, 1, util.Uint160{7})
+	req := newRequest(t, 2, signer)
+
+	t.Run("subscription", func(t *testing.T) {
+		select {
+		case ntf := <-c.Notifications:
+			require.Equal(t, response.NotaryRequestEventID, ntf.Type)
+			ev := ntf.Value.(*result.NotaryRequestEvent)
+			require.Equal(t, mempool.TransactionAdded, ev.Type)
+			require.Equal(t, req.MainTransaction.Hash(), ev.NotaryRequest.MainTransaction.Hash())
+			require.Equal(t, req.FallbackTransaction.Hash(), ev.NotaryRequest.FallbackTransaction.Hash())
+			require.Equal(t, req.Witness, ev.NotaryRequest.Witness)
+		case <-time.After(5 * time.Second):
+			t.Fatal("no notary request event")
+		}
+	})
+	t.Run("getrawnotarypool", func(t *testing.T) {
+		res, err := c.GetRawNotaryPool(nil)
+		require.NoError(t, err)
+		require.Equal(t, map[util.Uint256][]util.Uint256{
+			other.MainTransaction.Hash(): {other.FallbackTransaction.Hash()},
+			req.MainTransaction.Hash():   {req.FallbackTransaction.Hash()},
+		}, res.Hashes)
+
+		res, err = c.GetRawNotaryPool(&signer)
+		require.NoError(t, err)
+		require.Equal(t, map[util.Uint256][]util.Uint256{
+			req.MainTransaction.Hash(): {req.FallbackTransaction.Hash()},
+		}, res.Hashes)
+	})
+	t.Run("getrawnotarytransaction", func(t *testing.T) {
+		for _, tx := range []*transaction.Transaction{req.MainTransaction, req.FallbackTransaction} {
+			actual, err := c.GetRawNotaryTransaction(tx.Hash())
+			require.NoError(t, err)
+			require.Equal(t, tx.Hash(), actual.Hash())
+
+			verbose, err := c.GetRawNotaryTransactionVerbose(tx.Hash())
+			require.NoError(t, err)
+			require.Equal(t, tx.Hash(), verbose.Transaction.Hash())
+		}
+		_, err := c.GetRawNotaryTransaction(util.Uint256{1, 2, 3})
+		require.Error(t, err)
+	})
+}
+
 func TestCalculateNotaryFee(t *testing.T) {
 	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
 	defer chain.Close()
@@ -649,6 +1052,23 @@ func TestCalculateNotaryFee(t *testing.T) {
 	})
 }
 
+func TestCalculateOracleResponseGas(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	gas, err := c.CalculateOracleResponseGas(100)
+	require.NoError(t, err)
+	require.Equal(t, native.MinimumResponseGas+100*chain.FeePerByte(), gas)
+
+	_, err = c.CalculateOracleResponseGas(transaction.MaxOracleResultSize + 1)
+	require.Error(t, err)
+}
+
 func TestPing(t *testing.T) {
 	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
 	defer chain.Close()
@@ -716,6 +1136,283 @@ func TestCreateNEP17TransferTx(t *testing.T) {
 	require.NoError(t, v.Run())
 }
 
+func TestClient_Ledger(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	index, err := c.LedgerCurrentIndex()
+	require.NoError(t, err)
+	require.Equal(t, chain.BlockHeight(), index)
+
+	h, err := c.LedgerCurrentHash()
+	require.NoError(t, err)
+	require.Equal(t, chain.CurrentBlockHash(), h)
+
+	var b *block.Block
+	for i := uint32(1); i <= chain.BlockHeight(); i++ {
+		b, err = chain.GetBlock(chain.GetHeaderHash(int(i)))
+		require.NoError(t, err)
+		if len(b.Transactions) != 0 {
+			break
+		}
+	}
+	require.NotEqual(t, 0, len(b.Transactions))
+
+	check := func(t *testing.T, lb *client.LedgerBlock) {
+		require.Equal(t, b.Hash(), lb.Hash)
+		require.Equal(t, b.PrevHash, lb.PrevHash)
+		require.Equal(t, b.MerkleRoot, lb.MerkleRoot)
+		require.Equal(t, b.Timestamp, lb.Timestamp)
+		require.Equal(t, b.Index, lb.Index)
+		require.Equal(t, b.NextConsensus, lb.NextConsensus)
+		require.Equal(t, uint32(len(b.Transactions)), lb.TransactionsLength)
+	}
+	t.Run("GetBlockByIndex", func(t *testing.T) {
+		lb, err := c.LedgerGetBlockByIndex(b.Index)
+		require.NoError(t, err)
+		check(t, lb)
+	})
+	t.Run("GetBlockByHash", func(t *testing.T) {
+		lb, err := c.LedgerGetBlockByHash(b.Hash())
+		require.NoError(t, err)
+		check(t, lb)
+
+		_, err = c.LedgerGetBlockByHash(util.Uint256{1, 2, 3})
+		require.Error(t, err)
+	})
+
+	tx := b.Transactions[0]
+	checkTx := func(t *testing.T, ltx *client.LedgerTransaction) {
+		require.Equal(t, tx.Hash(), ltx.Hash)
+		require.Equal(t, tx.Nonce, ltx.Nonce)
+		require.Equal(t, tx.Sender(), ltx.Sender)
+		require.Equal(t, tx.SystemFee, ltx.SystemFee)
+		require.Equal(t, tx.NetworkFee, ltx.NetworkFee)
+		require.Equal(t, tx.ValidUntilBlock, ltx.ValidUntilBlock)
+		require.Equal(t, tx.Script, ltx.Script)
+	}
+	t.Run("GetTransaction", func(t *testing.T) {
+		ltx, err := c.LedgerGetTransaction(tx.Hash())
+		require.NoError(t, err)
+		checkTx(t, ltx)
+
+		_, err = c.LedgerGetTransaction(util.Uint256{1, 2, 3})
+		require.Error(t, err)
+	})
+	t.Run("GetTransactionFromBlock", func(t *testing.T) {
+		ltx, err := c.LedgerGetTransactionFromBlock(b.Index, 0)
+		require.NoError(t, err)
+		checkTx(t, ltx)
+	})
+	t.Run("GetTransactionHeight", func(t *testing.T) {
+		height, err := c.LedgerGetTransactionHeight(tx.Hash())
+		require.NoError(t, err)
+		require.Equal(t, b.Index, height)
+
+		_, err = c.LedgerGetTransactionHeight(util.Uint256{1, 2, 3})
+		require.Error(t, err)
+	})
+}
+
+func TestClient_Management(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	deployFee, err := c.GetMinimumDeploymentFee()
+	require.NoError(t, err)
+	require.Equal(t, int64(10_00000000), deployFee)
+
+	gasHash, err := c.GetNativeContractHash(nativenames.Gas)
+	require.NoError(t, err)
+	cs, err := c.ManagementGetContract(gasHash)
+	require.NoError(t, err)
+	require.Equal(t, gasHash, cs.Hash)
+	require.Equal(t, chain.GetContractState(gasHash).Manifest, cs.Manifest)
+
+	_, err = c.ManagementGetContract(util.Uint160{1, 2, 3})
+	require.Error(t, err)
+}
+
+func TestClient_CommitteeNativeTx(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	acc := wallet.NewAccountFromPrivateKey(testchain.PrivateKeyByID(0))
+	committee := &wallet.Account{
+		Address:  testchain.CommitteeAddress(),
+		Contract: &wallet.Contract{Script: testchain.CommitteeVerificationScript()},
+	}
+	cosigners := []client.SignerAccount{{
+		Signer: transaction.Signer{
+			Account: testchain.CommitteeScriptHash(),
+			Scopes:  transaction.CalledByEntry,
+		},
+		Account: committee,
+	}}
+	signAndAccept := func(t *testing.T, tx *transaction.Transaction) {
+		require.NoError(t, acc.SignTx(testchain.Network(), tx))
+		tx.Scripts = append(tx.Scripts, transaction.Witness{
+			InvocationScript:   testchain.SignCommittee(tx),
+			VerificationScript: testchain.CommitteeVerificationScript(),
+		})
+		require.NoError(t, chain.AddBlock(testchain.NewBlock(t, chain, 1, 0, tx)))
+		aer, err := chain.GetAppExecResults(tx.Hash(), trigger.Application)
+		require.NoError(t, err)
+		require.Equal(t, vm.HaltState, aer[0].VMState, aer[0].FaultException)
+	}
+
+	t.Run("SetFeePerByte", func(t *testing.T) {
+		tx, err := c.CreateSetFeePerByteTx(acc, 1500, 0, cosigners)
+		require.NoError(t, err)
+		signAndAccept(t, tx)
+
+		feePerByte, err := c.GetFeePerByte()
+		require.NoError(t, err)
+		require.Equal(t, int64(1500), feePerByte)
+	})
+	t.Run("BlockAccount", func(t *testing.T) {
+		account := util.Uint160{1, 2, 3}
+		tx, err := c.CreateBlockAccountTx(acc, account, 0, cosigners)
+		require.NoError(t, err)
+		signAndAccept(t, tx)
+
+		blocked, err := c.IsBlocked(account)
+		require.NoError(t, err)
+		require.True(t, blocked)
+
+		_, err = c.CreateBlockAccountTx(acc, account, 0, cosigners)
+		require.Error(t, err) // already blocked, the assertion fails
+
+		tx, err = c.CreateUnblockAccountTx(acc, account, 0, cosigners)
+		require.NoError(t, err)
+		signAndAccept(t, tx)
+
+		blocked, err = c.IsBlocked(account)
+		require.NoError(t, err)
+		require.False(t, blocked)
+	})
+	t.Run("SetOraclePrice", func(t *testing.T) {
+		tx, err := c.CreateSetOraclePriceTx(acc, 1_0000000, 0, cosigners)
+		require.NoError(t, err)
+		signAndAccept(t, tx)
+
+		price, err := c.GetOraclePrice()
+		require.NoError(t, err)
+		require.Equal(t, int64(1_0000000), price)
+	})
+	t.Run("not a committee", func(t *testing.T) {
+		_, err := c.CreateSetStoragePriceTx(acc, 1000, 0, nil)
+		require.Error(t, err)
+	})
+}
+
+func TestCreateTxFromScriptWithAttributes(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	acc := wallet.NewAccountFromPrivateKey(testchain.PrivateKeyByID(0))
+	script := []byte{byte(opcode.PUSH1)}
+	create := func(attrs ...transaction.Attribute) (*transaction.Transaction, error) {
+		return c.CreateTxFromScriptWithAttributes(script, acc, -1, 0, nil, attrs)
+	}
+
+	t.Run("good", func(t *testing.T) {
+		tx, err := create(
+			client.NewNotValidBeforeAttribute(chain.BlockHeight()),
+			client.NewConflictsAttribute(util.Uint256{1, 2, 3}),
+			client.NewConflictsAttribute(util.Uint256{3, 2, 1}))
+		require.NoError(t, err)
+		require.Equal(t, 3, len(tx.Attributes))
+		require.NoError(t, acc.SignTx(testchain.Network(), tx))
+		require.NoError(t, chain.VerifyTx(tx))
+	})
+	t.Run("NotValidBefore after ValidUntilBlock", func(t *testing.T) {
+		_, err := create(client.NewNotValidBeforeAttribute(chain.BlockHeight() + 100500))
+		require.True(t, errors.Is(err, transaction.ErrInvalidAttribute))
+	})
+	t.Run("multiple NotValidBefore", func(t *testing.T) {
+		_, err := create(client.NewNotValidBeforeAttribute(1), client.NewNotValidBeforeAttribute(2))
+		require.True(t, errors.Is(err, transaction.ErrInvalidAttribute))
+	})
+	t.Run("duplicating Conflicts", func(t *testing.T) {
+		_, err := create(client.NewConflictsAttribute(util.Uint256{1}), client.NewConflictsAttribute(util.Uint256{1}))
+		require.True(t, errors.Is(err, transaction.ErrInvalidAttribute))
+	})
+	t.Run("empty Conflicts", func(t *testing.T) {
+		_, err := create(client.NewConflictsAttribute(util.Uint256{}))
+		require.True(t, errors.Is(err, transaction.ErrInvalidAttribute))
+	})
+	t.Run("NotaryAssisted without Notary signer", func(t *testing.T) {
+		_, err := create(client.NewNotaryAssistedAttribute(1))
+		require.True(t, errors.Is(err, transaction.ErrInvalidAttribute))
+	})
+}
+
+func TestSender(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	acc := wallet.NewAccountFromPrivateKey(testchain.PrivateKeyByID(0))
+	s, err := client.NewSender(c, acc, client.SenderOptions{
+		MaxInFlight:     2,
+		PollingInterval: 10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	script := []byte{byte(opcode.PUSH1)}
+	h1, err := s.Send(context.Background(), script, 0, 0, nil)
+	require.NoError(t, err)
+	h2, err := s.Send(context.Background(), script, 0, 0, nil)
+	require.NoError(t, err)
+	require.NotEqual(t, h1, h2)
+	require.Equal(t, 2, s.InFlight())
+	require.True(t, s.IsInFlight(h1))
+	require.True(t, s.IsInFlight(h2))
+
+	tx1, ok := chain.GetMemPool().TryGetValue(h1)
+	require.True(t, ok)
+	tx2, ok := chain.GetMemPool().TryGetValue(h2)
+	require.True(t, ok)
+	require.Equal(t, tx1.ValidUntilBlock, tx2.ValidUntilBlock)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = s.Send(ctx, script, 0, 0, nil)
+	require.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	require.NoError(t, chain.AddBlock(testchain.NewBlock(t, chain, 1, 0, tx1, tx2)))
+	h3, err := s.Send(context.Background(), script, 0, 0, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, s.InFlight())
+	require.False(t, s.IsInFlight(h1))
+	require.True(t, s.IsInFlight(h3))
+}
+
 func TestInvokeVerify(t *testing.T) {
 	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
 	defer chain.Close()
@@ -772,6 +1469,46 @@ func TestClient_GetNativeContracts(t *testing.T) {
 	require.Equal(t, chain.GetNatives(), cs)
 }
 
+func TestClient_GetVerifiedStorage(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	r, err := chain.GetStateModule().GetStateRoot(3)
+	require.NoError(t, err)
+	h, err := util.Uint160DecodeStringLE(testContractHash)
+	require.NoError(t, err)
+
+	item, err := c.GetVerifiedStorage(r.Root, h, []byte("testkey"))
+	require.NoError(t, err)
+	require.Equal(t, chain.GetContractState(h).ID, item.ContractID)
+	require.Equal(t, []byte("testkey"), item.Key)
+	require.Equal(t, []byte("testvalue"), item.Value)
+
+	t.Run("untrusted root", func(t *testing.T) {
+		_, err := c.GetVerifiedStorage(r.Root.Reverse(), h, []byte("testkey"))
+		require.Error(t, err)
+	})
+	t.Run("contract state proof", func(t *testing.T) {
+		mgmt, err := chain.GetNativeContractScriptHash(nativenames.Management)
+		require.NoError(t, err)
+		require.Equal(t, mgmt, result.ManagementContractHash)
+
+		p, err := c.GetProof(r.Root, result.ManagementContractHash, result.ContractStateKey(h))
+		require.NoError(t, err)
+		id, err := result.CheckContractStateProof(r.Root, h, p)
+		require.NoError(t, err)
+		require.Equal(t, chain.GetContractState(h).ID, id)
+
+		_, err = result.CheckContractStateProof(r.Root, util.Uint160{1, 2, 3}, p)
+		require.True(t, errors.Is(err, mpt.ErrInvalidProof))
+	})
+}
+
 func TestClient_NEP11(t *testing.T) {
 	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
 	defer chain.Close()
@@ -860,3 +1597,353 @@ func TestClient_NNS(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestClient_VerifySource(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	src := []byte(`package foo
+	func Main() int {
+		return 42
+	}`)
+	tx, h, _, err := testchain.NewDeployTx(chain, "foo", testchain.MultisigScriptHash(), bytes.NewReader(src), nil)
+	require.NoError(t, err)
+	tx.ValidUntilBlock = chain.BlockHeight() + 1
+	require.NoError(t, testchain.SignTx(chain, tx))
+	require.NoError(t, chain.AddBlock(testchain.NewBlock(t, chain, 1, 0, tx)))
+
+	t.Run("disabled", func(t *testing.T) {
+		_, err := c.VerifySource(h, src, "")
+		var rpcErr *response.Error
+		require.True(t, errors.As(err, &rpcErr))
+		require.Equal(t, int64(-32600), rpcErr.Code)
+	})
+	rpcSrv.config.EnableSourceVerification = true
+
+	t.Run("verified", func(t *testing.T) {
+		res, err := c.VerifySource(h, src, "foo.go")
+		require.NoError(t, err)
+		require.True(t, res.Verified, res.Reason)
+		require.Equal(t, h, res.Contract)
+		require.Equal(t, chain.BlockHeight(), res.Height)
+		require.Equal(t, res.ContractCompiler, res.Compiler)
+		require.Equal(t, res.ContractChecksum, res.Checksum)
+		require.Equal(t, []result.SourceFile{{Name: "foo.go", Hash: hash.Sha256(src)}}, res.Sources)
+	})
+	t.Run("mismatch", func(t *testing.T) {
+		res, err := c.VerifySource(h, bytes.Replace(src, []byte("42"), []byte("43"), 1), "")
+		require.NoError(t, err)
+		require.False(t, res.Verified)
+		require.Equal(t, "script mismatch", res.Reason)
+		require.NotEqual(t, res.ContractChecksum, res.Checksum)
+		require.Equal(t, "contract.go", res.Sources[0].Name)
+	})
+	t.Run("unknown contract", func(t *testing.T) {
+		_, err := c.VerifySource(util.Uint160{1, 2, 3}, src, "")
+		require.Error(t, err)
+	})
+	t.Run("bad source", func(t *testing.T) {
+		_, err := c.VerifySource(h, []byte("package foo\nfunc Main() int {"), "")
+		require.Error(t, err)
+		_, err = c.VerifySource(h, src, "foo.txt")
+		require.Error(t, err)
+	})
+	t.Run("forbidden import", func(t *testing.T) {
+		_, err := c.VerifySource(h, []byte("package foo\nimport \"os\"\nfunc Main() { os.Exit(1) }"), "")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "only interop packages can be imported")
+	})
+}
+
+func TestClient_Simulate(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	src := `package foo
+	import (
+		"github.com/nspcc-dev/neo-go/pkg/interop"
+		"github.com/nspcc-dev/neo-go/pkg/interop/native/gas"
+		"github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+		"github.com/nspcc-dev/neo-go/pkg/interop/storage"
+	)
+	func Get() interface{} {
+		return storage.Get(storage.GetReadOnlyContext(), "key")
+	}
+	func Withdraw(owner, to interop.Hash160, amount int) bool {
+		if !runtime.CheckWitness(owner) {
+			return false
+		}
+		return gas.Transfer(owner, to, amount, nil)
+	}`
+	tx, h, _, err := testchain.NewDeployTx(chain, "foo", testchain.MultisigScriptHash(), strings.NewReader(src), nil)
+	require.NoError(t, err)
+	tx.ValidUntilBlock = chain.BlockHeight() + 1
+	require.NoError(t, testchain.SignTx(chain, tx))
+	require.NoError(t, chain.AddBlock(testchain.NewBlock(t, chain, 1, 0, tx)))
+
+	gasHash, err := c.GetNativeContractHash(nativenames.Gas)
+	require.NoError(t, err)
+	owner := util.Uint160{1, 2, 3}
+	to := util.Uint160{4, 5, 6}
+	w := io.NewBufBinWriter()
+	emit.AppCall(w.BinWriter, h, "withdraw", callflag.All, owner, to, int64(10))
+	emit.AppCall(w.BinWriter, gasHash, "balanceOf", callflag.ReadStates, to)
+	withdraw := w.Bytes()
+
+	t.Run("no overrides", func(t *testing.T) {
+		res, err := c.Simulate(withdraw, nil, nil, 0)
+		require.NoError(t, err)
+		require.Equal(t, "HALT", res.State, res.FaultException)
+		require.Equal(t, []stackitem.Item{stackitem.NewBool(false), stackitem.Make(0)}, res.Stack)
+	})
+	o := &state.Overrides{
+		Balances:  []state.BalanceOverride{{Token: gasHash, Account: owner, Amount: big.NewInt(100)}},
+		Witnesses: []util.Uint160{owner},
+	}
+	t.Run("balance and witness", func(t *testing.T) {
+		res, err := c.Simulate(withdraw, o, nil, 0)
+		require.NoError(t, err)
+		require.Equal(t, "HALT", res.State, res.FaultException)
+		require.Equal(t, []stackitem.Item{stackitem.NewBool(true), stackitem.Make(10)}, res.Stack)
+
+		// State is not changed.
+		res, err = c.Simulate(withdraw, nil, nil, 0)
+		require.NoError(t, err)
+		require.Equal(t, []stackitem.Item{stackitem.NewBool(false), stackitem.Make(0)}, res.Stack)
+	})
+	t.Run("witness for existing signer", func(t *testing.T) {
+		res, err := c.Simulate(withdraw, o, []transaction.Signer{{Account: owner, Scopes: transaction.None}}, 0)
+		require.NoError(t, err)
+		require.Equal(t, "HALT", res.State, res.FaultException)
+		require.Equal(t, stackitem.NewBool(true), res.Stack[0])
+	})
+	t.Run("gas limit", func(t *testing.T) {
+		res, err := c.Simulate(withdraw, o, nil, 1)
+		require.NoError(t, err)
+		require.Equal(t, "FAULT", res.State)
+	})
+	t.Run("storage", func(t *testing.T) {
+		w := io.NewBufBinWriter()
+		emit.AppCall(w.BinWriter, h, "get", callflag.All)
+		o := &state.Overrides{
+			Storage: []state.StorageOverride{{Contract: h, Key: []byte("key"), Value: []byte("value")}},
+		}
+		res, err := c.Simulate(w.Bytes(), o, nil, 0)
+		require.NoError(t, err)
+		require.Equal(t, "HALT", res.State, res.FaultException)
+		require.Equal(t, []stackitem.Item{stackitem.NewByteArray([]byte("value"))}, res.Stack)
+	})
+	t.Run("invalid overrides", func(t *testing.T) {
+		_, err := c.Simulate(withdraw, &state.Overrides{
+			Balances: []state.BalanceOverride{{Token: h, Account: owner, Amount: big.NewInt(100)}},
+		}, nil, 0)
+		require.Error(t, err)
+		_, err = c.Simulate(withdraw, &state.Overrides{
+			Balances: []state.BalanceOverride{{Token: gasHash, Account: owner, Amount: big.NewInt(-1)}},
+		}, nil, 0)
+		require.Error(t, err)
+		_, err = c.Simulate(withdraw, &state.Overrides{
+			Balances: []state.BalanceOverride{{Token: gasHash, Account: owner}},
+		}, nil, 0)
+		require.Error(t, err)
+		_, err = c.Simulate(withdraw, &state.Overrides{
+			Storage: []state.StorageOverride{{Contract: util.Uint160{1}, Key: []byte("key")}},
+		}, nil, 0)
+		require.Error(t, err)
+	})
+}
+
+func TestClient_SimulateTransactions(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	src := `package foo
+	import (
+		"github.com/nspcc-dev/neo-go/pkg/interop"
+		"github.com/nspcc-dev/neo-go/pkg/interop/native/gas"
+		"github.com/nspcc-dev/neo-go/pkg/interop/storage"
+	)
+	func Inc() int {
+		ctx := storage.GetContext()
+		n := 0
+		if v := storage.Get(ctx, "counter"); v != nil {
+			n = v.(int)
+		}
+		n++
+		storage.Put(ctx, "counter", n)
+		return n
+	}
+	func Fail() {
+		storage.Put(storage.GetContext(), "counter", 100)
+		panic("fail")
+	}
+	func Pay(from, to interop.Hash160) bool {
+		return gas.Transfer(from, to, 10, nil)
+	}`
+	tx, h, _, err := testchain.NewDeployTx(chain, "foo", testchain.MultisigScriptHash(), strings.NewReader(src), nil)
+	require.NoError(t, err)
+	tx.ValidUntilBlock = chain.BlockHeight() + 1
+	require.NoError(t, testchain.SignTx(chain, tx))
+	require.NoError(t, chain.AddBlock(testchain.NewBlock(t, chain, 1, 0, tx)))
+	cs := chain.GetContractState(h)
+	require.NotNil(t, cs)
+
+	owner := util.Uint160{1, 2, 3}
+	newTx := func(t *testing.T, method string, args ...interface{}) *transaction.Transaction {
+		w := io.NewBufBinWriter()
+		emit.AppCall(w.BinWriter, h, method, callflag.All, args...)
+		require.NoError(t, w.Err)
+		tx := transaction.New(w.Bytes(), 1_00000000)
+		tx.Signers = []transaction.Signer{{Account: owner, Scopes: transaction.CalledByEntry}}
+		tx.Scripts = []transaction.Witness{{}}
+		return tx
+	}
+	counterKey := func(v int64) state.StorageChange {
+		key := make([]byte, 4)
+		binary.LittleEndian.PutUint32(key, uint32(cs.ID))
+		return state.StorageChange{
+			State: state.StorageAdded,
+			Key:   append(key, "counter"...),
+			Value: bigint.ToBytes(big.NewInt(v)),
+		}
+	}
+
+	t.Run("dependent transactions", func(t *testing.T) {
+		txs := []*transaction.Transaction{newTx(t, "inc"), newTx(t, "fail"), newTx(t, "inc")}
+		res, err := c.SimulateTransactions(txs, nil)
+		require.NoError(t, err)
+		require.Equal(t, 3, len(res.Executions))
+		for i, st := range []vm.State{vm.HaltState, vm.FaultState, vm.HaltState} {
+			require.Equal(t, txs[i].Hash(), res.Executions[i].Container)
+			require.Equal(t, 1, len(res.Executions[i].Executions))
+			require.Equal(t, st, res.Executions[i].Executions[0].VMState)
+		}
+		require.Equal(t, []stackitem.Item{stackitem.Make(1)}, res.Executions[0].Executions[0].Stack)
+		require.NotEmpty(t, res.Executions[1].Executions[0].FaultException)
+		require.Equal(t, []stackitem.Item{stackitem.Make(2)}, res.Executions[2].Executions[0].Stack)
+		require.Equal(t, chain.BlockHeight()+1, res.Storage.Block)
+		require.Equal(t, []state.StorageChange{counterKey(2)}, res.Storage.Storage)
+
+		// State is not changed.
+		res, err = c.SimulateTransactions(txs[:1], nil)
+		require.NoError(t, err)
+		require.Equal(t, []stackitem.Item{stackitem.Make(1)}, res.Executions[0].Executions[0].Stack)
+	})
+	t.Run("overrides", func(t *testing.T) {
+		gasHash, err := c.GetNativeContractHash(nativenames.Gas)
+		require.NoError(t, err)
+		from := util.Uint160{4, 5, 6}
+		to := util.Uint160{7, 8, 9}
+		o := &state.Overrides{
+			Balances:  []state.BalanceOverride{{Token: gasHash, Account: from, Amount: big.NewInt(15)}},
+			Witnesses: []util.Uint160{from},
+		}
+		txs := []*transaction.Transaction{newTx(t, "pay", from, to), newTx(t, "pay", from, to)}
+		res, err := c.SimulateTransactions(txs, o)
+		require.NoError(t, err)
+		require.Equal(t, []stackitem.Item{stackitem.NewBool(true)}, res.Executions[0].Executions[0].Stack)
+		require.Equal(t, []stackitem.Item{stackitem.NewBool(false)}, res.Executions[1].Executions[0].Stack)
+		// Overridden balance is not a change made by transactions, while the
+		// new balance of the recipient is.
+		var added int
+		for _, ch := range res.Storage.Storage {
+			if ch.State == state.StorageAdded {
+				added++
+			}
+		}
+		require.Equal(t, 1, added)
+	})
+	t.Run("GAS limit", func(t *testing.T) {
+		tx := newTx(t, "inc")
+		tx.Script = []byte{byte(opcode.JMP), 0} // Infinite loop.
+		tx.SystemFee = math.MaxInt64
+		res, err := c.SimulateTransactions([]*transaction.Transaction{tx}, nil)
+		require.NoError(t, err)
+		ex := res.Executions[0].Executions[0]
+		require.Equal(t, vm.FaultState, ex.VMState)
+		require.True(t, ex.GasConsumed <= int64(rpcSrv.config.MaxGasInvoke))
+
+		tx = newTx(t, "inc")
+		tx.SystemFee = 0
+		res, err = c.SimulateTransactions([]*transaction.Transaction{tx}, nil)
+		require.NoError(t, err)
+		require.Equal(t, vm.FaultState, res.Executions[0].Executions[0].VMState)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := c.SimulateTransactions(nil, nil)
+		require.Error(t, err)
+		_, err = c.SimulateTransactions([]*transaction.Transaction{newTx(t, "inc")}, &state.Overrides{
+			Storage: []state.StorageOverride{{Contract: util.Uint160{1}, Key: []byte("key")}},
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestClient_ProjectUnclaimedGas(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	holder := testchain.MultisigScriptHash()
+	height := chain.BlockHeight() + 5
+	expected, err := chain.CalculateClaimable(holder, height)
+	require.NoError(t, err)
+	require.True(t, expected.Sign() > 0)
+
+	gas, err := c.ProjectUnclaimedGas([]util.Uint160{holder, {1, 2, 3}, holder}, height)
+	require.NoError(t, err)
+	require.Equal(t, []int64{expected.Int64(), 0, expected.Int64()}, gas)
+
+	gas, err = c.ProjectUnclaimedGas(nil, height)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(gas))
+}
+
+func TestClient_GetVotingState(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	holder := testchain.MultisigScriptHash()
+	vs, err := c.GetVotingState(&holder)
+	require.NoError(t, err)
+	require.Equal(t, chain.BlockHeight(), vs.Height)
+	committee, err := chain.GetCommittee()
+	require.NoError(t, err)
+	require.Equal(t, committee, vs.Committee)
+	neo, _ := chain.GetGoverningTokenBalance(holder)
+	gas, err := chain.CalculateClaimable(holder, chain.BlockHeight()+1)
+	require.NoError(t, err)
+	require.Equal(t, &result.VoterState{
+		Address:   holder,
+		Balance:   neo.Int64(),
+		Unclaimed: gas.Int64(),
+	}, vs.Voter)
+
+	vs, err = c.GetVotingState(nil)
+	require.NoError(t, err)
+	require.Nil(t, vs.Voter)
+}
@@ -0,0 +1,104 @@
+package server
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
+)
+
+const (
+	// defaultFeeBlocks is the default number of recent blocks analyzed by
+	// estimatefees.
+	defaultFeeBlocks = 20
+	// maxFeeBlocks is the maximum number of recent blocks that can be
+	// requested for estimatefees.
+	maxFeeBlocks = 100
+)
+
+// estimateFees returns suggested network fees per byte for transactions
+// based on mempool contents and recent blocks.
+func (s *Server) estimateFees(reqParams request.Params) (interface{}, *response.Error) {
+	blocks := defaultFeeBlocks
+	if p := reqParams.Value(0); p != nil {
+		n, err := p.GetInt()
+		if err != nil {
+			return nil, response.WrapErrorWithData(response.ErrInvalidParams, err)
+		}
+		if n <= 0 || n > maxFeeBlocks {
+			return nil, response.WrapErrorWithData(response.ErrInvalidParams, errors.New("invalid number of blocks"))
+		}
+		blocks = n
+	}
+
+	var (
+		height   = int(s.chain.BlockHeight())
+		analyzed int
+		included []int64
+	)
+	for ; analyzed < blocks && analyzed <= height; analyzed++ {
+		b, err := s.chain.GetBlock(s.chain.GetHeaderHash(height - analyzed))
+		if err != nil {
+			return nil, response.NewInternalServerError("failed to get block", err)
+		}
+		for _, tx := range b.Transactions {
+			included = append(included, tx.FeePerByte())
+		}
+	}
+
+	res := calculateFeeLevels(s.chain.GetPolicer().FeePerByte(),
+		s.chain.GetMemPool().GetVerifiedTransactions(),
+		int(s.chain.GetConfig().MaxTransactionsPerBlock), included)
+	res.Blocks = analyzed
+	return res, nil
+}
+
+// calculateFeeLevels computes fee levels given the minimum fee per byte,
+// pooled transactions (sorted by priority), maximum number of transactions
+// per block (0 if unlimited) and fees per byte of transactions included into
+// recent blocks. Low fee is expected to get transaction into one of the next
+// couple of blocks, medium one is enough for the next block and usually pays
+// as much as most of the recent transactions and high one outbids the top
+// half of the next block.
+func calculateFeeLevels(minFee int64, pool []*transaction.Transaction, capacity int, included []int64) *result.FeeEstimation {
+	sort.Slice(included, func(i, j int) bool { return included[i] < included[j] })
+
+	res := &result.FeeEstimation{
+		Minimum:     minFee,
+		MempoolSize: len(pool),
+	}
+	res.Low = maxFee(minFee, poolFeeAt(pool, 2*capacity))
+	res.Medium = maxFee(res.Low, percentile(included, 50), poolFeeAt(pool, capacity))
+	res.High = maxFee(res.Medium, percentile(included, 90), poolFeeAt(pool, capacity/2))
+	return res
+}
+
+// poolFeeAt returns fee per byte needed to outbid n-th transaction in the
+// pool or 0 if there are less than n transactions in it.
+func poolFeeAt(pool []*transaction.Transaction, n int) int64 {
+	if n == 0 || len(pool) < n {
+		return 0
+	}
+	return pool[n-1].FeePerByte() + 1
+}
+
+// percentile returns p-th percentile of sorted values (0 for empty slice).
+func percentile(sorted []int64, p int) int64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	return sorted[(len(sorted)-1)*p/100]
+}
+
+func maxFee(fees ...int64) int64 {
+	var res int64
+	for _, f := range fees {
+		if f > res {
+			res = f
+		}
+	}
+	return res
+}
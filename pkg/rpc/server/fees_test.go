@@ -0,0 +1,55 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculateFeeLevels(t *testing.T) {
+	newPool := func(fees ...int64) []*transaction.Transaction {
+		pool := make([]*transaction.Transaction, len(fees))
+		for i := range fees {
+			tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+			tx.Signers = []transaction.Signer{{Account: util.Uint160{1, 2, 3}}}
+			tx.Scripts = []transaction.Witness{{}}
+			tx.NetworkFee = fees[i] * int64(tx.Size())
+			pool[i] = tx
+		}
+		return pool
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		res := calculateFeeLevels(1000, nil, 512, nil)
+		require.Equal(t, int64(1000), res.Minimum)
+		require.Equal(t, int64(1000), res.Low)
+		require.Equal(t, int64(1000), res.Medium)
+		require.Equal(t, int64(1000), res.High)
+		require.Equal(t, 0, res.MempoolSize)
+	})
+	t.Run("recent blocks", func(t *testing.T) {
+		included := []int64{5000, 1000, 3000, 2000, 4000, 1000, 1000, 1000, 1000, 1000, 10000}
+		res := calculateFeeLevels(1000, newPool(1500), 512, included)
+		require.Equal(t, int64(1000), res.Low)
+		require.Equal(t, int64(1000), res.Medium)
+		require.Equal(t, int64(5000), res.High)
+		require.Equal(t, 1, res.MempoolSize)
+	})
+	t.Run("full mempool", func(t *testing.T) {
+		pool := newPool(9000, 8000, 7000, 6000, 5000, 4000, 3000, 2000)
+		res := calculateFeeLevels(1000, pool, 4, []int64{1000})
+		require.Equal(t, int64(2001), res.Low)
+		require.Equal(t, int64(6001), res.Medium)
+		require.Equal(t, int64(8001), res.High)
+		require.Equal(t, 8, res.MempoolSize)
+	})
+	t.Run("unlimited block", func(t *testing.T) {
+		res := calculateFeeLevels(1000, newPool(9000, 8000), 0, []int64{1000, 3000})
+		require.Equal(t, int64(1000), res.Low)
+		require.Equal(t, int64(1000), res.Medium)
+		require.Equal(t, int64(1000), res.High)
+	})
+}
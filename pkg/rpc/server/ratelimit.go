@@ -0,0 +1,164 @@
+package server
+
+import (
+	"math"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/rpc"
+)
+
+const (
+	// Default per-client rate limit (requests per second).
+	defaultRateLimit = 20
+
+	// Default rate limit for invocation methods (requests per second).
+	defaultInvokeRateLimit = 2
+
+	// Maximum number of tracked clients, inactive ones are dropped when it's
+	// reached and requests from new clients are rejected if that's not
+	// enough (buckets with no tokens left are never dropped, otherwise
+	// clients could reset their limits by flooding the limiter).
+	maxRateLimitKeys = 4096
+
+	// IPv6 clients are limited per /64 network, as it's usually the
+	// smallest one assigned to a single host.
+	ipv6PrefixLen = 64
+)
+
+// invokeMethods are the methods that have stricter default rate limits as
+// they're the most expensive ones to process.
+var invokeMethods = []string{"invokecontractverify", "invokefunction", "invokescript", "simulate", "simulatetransactions", "verifysource"}
+
+type (
+	// tokenBucket is a token bucket state for a single client.
+	tokenBucket struct {
+		tokens float64
+		last   time.Time
+	}
+
+	// rateLimiter limits the rate of requests per key (client address) using
+	// token bucket algorithm.
+	rateLimiter struct {
+		lock    sync.Mutex
+		rate    float64
+		burst   float64
+		buckets map[string]*tokenBucket
+	}
+
+	// rateLimits is a set of per-client and per-method limiters.
+	rateLimits struct {
+		client  *rateLimiter
+		methods map[string]*rateLimiter
+	}
+)
+
+// newRateLimiter creates a limiter allowing rate requests per second with
+// up to burst requests at once. Zero burst is replaced with twice the rate.
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	b := float64(burst)
+	if burst <= 0 {
+		b = math.Max(1, math.Ceil(2*rate))
+	}
+	return &rateLimiter{
+		rate:    rate,
+		burst:   b,
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+// allow consumes a token from the key's bucket at the given moment of time
+// and returns false if there are none left.
+func (l *rateLimiter) allow(key string, now time.Time) bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	b, ok := l.buckets[key]
+	if !ok {
+		if len(l.buckets) >= maxRateLimitKeys {
+			l.prune(now)
+			if len(l.buckets) >= maxRateLimitKeys {
+				return false
+			}
+		}
+		b = &tokenBucket{tokens: l.burst}
+		l.buckets[key] = b
+	} else {
+		b.tokens = math.Min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	}
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// prune removes buckets that are already refilled, they're equivalent to
+// missing ones. It must be called with the lock held.
+func (l *rateLimiter) prune(now time.Time) {
+	for k, b := range l.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*l.rate >= l.burst {
+			delete(l.buckets, k)
+		}
+	}
+}
+
+// newRateLimits creates limiters according to the configuration, it returns
+// nil if rate limiting is disabled.
+func newRateLimits(cfg rpc.RateLimitConfig) *rateLimits {
+	if !cfg.Enabled {
+		return nil
+	}
+	rate := cfg.RequestsPerSecond
+	if rate <= 0 {
+		rate = defaultRateLimit
+	}
+	rl := &rateLimits{
+		client:  newRateLimiter(rate, cfg.Burst),
+		methods: make(map[string]*rateLimiter),
+	}
+	for _, m := range invokeMethods {
+		rl.methods[m] = newRateLimiter(defaultInvokeRateLimit, 0)
+	}
+	for m, lim := range cfg.Methods {
+		if lim.RequestsPerSecond <= 0 {
+			delete(rl.methods, m)
+			continue
+		}
+		rl.methods[m] = newRateLimiter(lim.RequestsPerSecond, lim.Burst)
+	}
+	return rl
+}
+
+// allow checks whether the client with the given address can call the method
+// now. Any request is allowed for nil rateLimits.
+func (rl *rateLimits) allow(addr string, method string) bool {
+	if rl == nil {
+		return true
+	}
+	now := time.Now()
+	key := clientKey(addr)
+	// Requests rejected by the client-wide limit don't consume method tokens.
+	if !rl.client.allow(key, now) {
+		return false
+	}
+	l, ok := rl.methods[method]
+	return !ok || l.allow(key, now)
+}
+
+// clientKey returns the host part of the remote address, so that all
+// connections from the same IP share limits. IPv6 addresses are reduced to
+// their /64 prefix.
+func clientKey(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || ip.To4() != nil {
+		return host
+	}
+	return ip.Mask(net.CIDRMask(ipv6PrefixLen, 8*net.IPv6len)).String()
+}
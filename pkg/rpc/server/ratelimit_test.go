@@ -0,0 +1,116 @@
+package server
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter(t *testing.T) {
+	l := newRateLimiter(2, 3)
+	now := time.Now()
+
+	for i := 0; i < 3; i++ {
+		require.True(t, l.allow("a", now))
+	}
+	require.False(t, l.allow("a", now))
+	require.True(t, l.allow("b", now))
+
+	now = now.Add(500 * time.Millisecond)
+	require.True(t, l.allow("a", now))
+	require.False(t, l.allow("a", now))
+
+	now = now.Add(time.Hour)
+	for i := 0; i < 3; i++ {
+		require.True(t, l.allow("a", now))
+	}
+	require.False(t, l.allow("a", now))
+
+	t.Run("default burst", func(t *testing.T) {
+		require.Equal(t, float64(4), newRateLimiter(2, 0).burst)
+		require.Equal(t, float64(1), newRateLimiter(0.1, 0).burst)
+	})
+	t.Run("prune", func(t *testing.T) {
+		l.prune(now.Add(time.Hour))
+		require.Equal(t, 0, len(l.buckets))
+	})
+	t.Run("max keys", func(t *testing.T) {
+		// Buckets are not refilled in time to be pruned.
+		l := newRateLimiter(0.001, 1)
+		for i := 0; i < maxRateLimitKeys; i++ {
+			require.True(t, l.allow(strconv.Itoa(i), now.Add(time.Duration(i)*time.Millisecond)))
+		}
+		// Buckets in deficit are not evicted, new clients are rejected.
+		require.False(t, l.allow("new", now.Add(time.Duration(maxRateLimitKeys)*time.Millisecond)))
+		require.Equal(t, maxRateLimitKeys, len(l.buckets))
+		_, ok := l.buckets["0"]
+		require.True(t, ok)
+		_, ok = l.buckets["new"]
+		require.False(t, ok)
+
+		// Refilled ones are pruned.
+		require.True(t, l.allow("new", now.Add(time.Hour)))
+		require.Equal(t, 1, len(l.buckets))
+	})
+}
+
+func TestRateLimits(t *testing.T) {
+	require.Nil(t, newRateLimits(rpc.RateLimitConfig{}))
+	require.True(t, (*rateLimits)(nil).allow("127.0.0.1:1234", "invokescript"))
+
+	rl := newRateLimits(rpc.RateLimitConfig{
+		Enabled:           true,
+		RequestsPerSecond: 100,
+		Methods: map[string]rpc.MethodRateLimit{
+			"getversion":     {RequestsPerSecond: 0.1, Burst: 1},
+			"invokefunction": {},
+		},
+	})
+	require.Equal(t, float64(defaultInvokeRateLimit), rl.methods["invokescript"].rate)
+	_, ok := rl.methods["invokefunction"]
+	require.False(t, ok)
+
+	require.True(t, rl.allow("127.0.0.1:1234", "getversion"))
+	require.False(t, rl.allow("127.0.0.1:4321", "getversion"))
+	require.True(t, rl.allow("127.0.0.2:1234", "getversion"))
+	require.True(t, rl.allow("127.0.0.1:1234", "getblockcount"))
+
+	for i := 0; i < 2*defaultInvokeRateLimit; i++ {
+		require.True(t, rl.allow("127.0.0.1:1234", "invokescript"))
+	}
+	require.False(t, rl.allow("127.0.0.1:1234", "invokescript"))
+	require.True(t, rl.allow("127.0.0.1:1234", "invokefunction"))
+
+	t.Run("IPv6", func(t *testing.T) {
+		rl := newRateLimits(rpc.RateLimitConfig{
+			Enabled:           true,
+			RequestsPerSecond: 1,
+			Burst:             1,
+		})
+		require.True(t, rl.allow("[2001:db8:1:2::1]:1234", "getblockcount"))
+		require.False(t, rl.allow("[2001:db8:1:2:ffff::5]:1234", "getblockcount"))
+		require.True(t, rl.allow("[2001:db8:1:3::1]:1234", "getblockcount"))
+	})
+	t.Run("client limit first", func(t *testing.T) {
+		rl := newRateLimits(rpc.RateLimitConfig{
+			Enabled:           true,
+			RequestsPerSecond: 1,
+			Burst:             1,
+		})
+		require.True(t, rl.allow("127.0.0.1:1234", "getblockcount"))
+		require.False(t, rl.allow("127.0.0.1:1234", "invokescript"))
+		_, ok := rl.methods["invokescript"].buckets["127.0.0.1"]
+		require.False(t, ok)
+	})
+}
+
+func TestClientKey(t *testing.T) {
+	require.Equal(t, "127.0.0.1", clientKey("127.0.0.1:1234"))
+	require.Equal(t, "127.0.0.1", clientKey("127.0.0.1"))
+	require.Equal(t, "2001:db8:1:2::", clientKey("[2001:db8:1:2:3:4:5:6]:1234"))
+	require.Equal(t, "2001:db8:1:2::", clientKey("2001:db8:1:2:3:4:5:6"))
+	require.Equal(t, "some", clientKey("some"))
+}
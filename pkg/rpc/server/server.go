@@ -3,7 +3,9 @@ package server
 import (
 	"context"
 	"crypto/elliptic"
+	"encoding/base64"
 	"encoding/binary"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -11,6 +13,7 @@ import (
 	"math/big"
 	"net"
 	"net/http"
+	"sort"
 	"strconv"
 	"sync"
 	"time"
@@ -21,8 +24,11 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/blockchainer"
 	"github.com/nspcc-dev/neo-go/pkg/core/fee"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/core/mempool"
 	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
@@ -37,9 +43,13 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/services/oracle"
 	"github.com/nspcc-dev/neo-go/pkg/services/oracle/broadcaster"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest/standard"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/replay"
 	"go.uber.org/zap"
 )
 
@@ -55,19 +65,28 @@ type (
 		oracle           *oracle.Oracle
 		log              *zap.Logger
 		https            *http.Server
+		auth             *authenticator
+		limits           *rateLimits
 		shutdown         chan struct{}
-
-		subsLock         sync.RWMutex
-		subscribers      map[*subscriber]bool
-		subsGroup        sync.WaitGroup
-		blockSubs        int
-		executionSubs    int
-		notificationSubs int
-		transactionSubs  int
-		blockCh          chan *block.Block
-		executionCh      chan *state.AppExecResult
-		notificationCh   chan *state.NotificationEvent
-		transactionCh    chan *transaction.Transaction
+		// verifySlot limits the number of concurrent verifysource
+		// compilations.
+		verifySlot chan struct{}
+
+		subsLock          sync.RWMutex
+		subscribers       map[*subscriber]bool
+		subsGroup         sync.WaitGroup
+		blockSubs         int
+		executionSubs     int
+		notificationSubs  int
+		notaryRequestSubs int
+		storageSubs       int
+		transactionSubs   int
+		blockCh           chan *block.Block
+		executionCh       chan *state.AppExecResult
+		notificationCh    chan *state.NotificationEvent
+		notaryRequestCh   chan mempool.Event
+		storageCh         chan *state.StorageChanges
+		transactionCh     chan *transaction.Transaction
 	}
 )
 
@@ -89,46 +108,71 @@ const (
 	// connections.
 	maxSubscribers = 64
 
-	// Maximum number of elements for get*transfers requests.
-	maxTransfersLimit = 1000
+	// Default maximum number of elements for get*transfers requests.
+	defaultMaxTransfersLimit = 1000
+
+	// Default maximum number of elements for getnotifications requests.
+	defaultMaxNotificationsLimit = 1000
+
+	// Number of transactions in a single getaddresshistory page.
+	addressHistoryPageSize = 100
+	// Maximum getaddresshistory page number, it limits the number of log
+	// entries to be skipped.
+	maxAddressHistoryPage = 1000
 )
 
 var rpcHandlers = map[string]func(*Server, request.Params) (interface{}, *response.Error){
-	"calculatenetworkfee":    (*Server).calculateNetworkFee,
-	"getapplicationlog":      (*Server).getApplicationLog,
-	"getbestblockhash":       (*Server).getBestBlockHash,
-	"getblock":               (*Server).getBlock,
-	"getblockcount":          (*Server).getBlockCount,
-	"getblockhash":           (*Server).getBlockHash,
-	"getblockheader":         (*Server).getBlockHeader,
-	"getblockheadercount":    (*Server).getBlockHeaderCount,
-	"getblocksysfee":         (*Server).getBlockSysFee,
-	"getcommittee":           (*Server).getCommittee,
-	"getconnectioncount":     (*Server).getConnectionCount,
-	"getcontractstate":       (*Server).getContractState,
-	"getnativecontracts":     (*Server).getNativeContracts,
-	"getnep17balances":       (*Server).getNEP17Balances,
-	"getnep17transfers":      (*Server).getNEP17Transfers,
-	"getpeers":               (*Server).getPeers,
-	"getproof":               (*Server).getProof,
-	"getrawmempool":          (*Server).getRawMempool,
-	"getrawtransaction":      (*Server).getrawtransaction,
-	"getstateheight":         (*Server).getStateHeight,
-	"getstateroot":           (*Server).getStateRoot,
-	"getstorage":             (*Server).getStorage,
-	"gettransactionheight":   (*Server).getTransactionHeight,
-	"getunclaimedgas":        (*Server).getUnclaimedGas,
-	"getnextblockvalidators": (*Server).getNextBlockValidators,
-	"getversion":             (*Server).getVersion,
-	"invokefunction":         (*Server).invokeFunction,
-	"invokescript":           (*Server).invokescript,
-	"invokecontractverify":   (*Server).invokeContractVerify,
-	"sendrawtransaction":     (*Server).sendrawtransaction,
-	"submitblock":            (*Server).submitBlock,
-	"submitnotaryrequest":    (*Server).submitNotaryRequest,
-	"submitoracleresponse":   (*Server).submitOracleResponse,
-	"validateaddress":        (*Server).validateAddress,
-	"verifyproof":            (*Server).verifyProof,
+	"calculatenetworkfee":     (*Server).calculateNetworkFee,
+	"compactstorage":          (*Server).compactStorage,
+	"estimatefees":            (*Server).estimateFees,
+	"getaddresshistory":       (*Server).getAddressHistory,
+	"getapplicationlog":       (*Server).getApplicationLog,
+	"getbestblockhash":        (*Server).getBestBlockHash,
+	"getblock":                (*Server).getBlock,
+	"getblockcount":           (*Server).getBlockCount,
+	"getblockhash":            (*Server).getBlockHash,
+	"getblockheader":          (*Server).getBlockHeader,
+	"getblockheadercount":     (*Server).getBlockHeaderCount,
+	"getblocksysfee":          (*Server).getBlockSysFee,
+	"getcommittee":            (*Server).getCommittee,
+	"getconnectioncount":      (*Server).getConnectionCount,
+	"getconsensusstate":       (*Server).getConsensusState,
+	"getcontractstate":        (*Server).getContractState,
+	"getnativecontracts":      (*Server).getNativeContracts,
+	"getnep11balances":        (*Server).getNEP11Balances,
+	"getnep11transfers":       (*Server).getNEP11Transfers,
+	"getnep17balances":        (*Server).getNEP17Balances,
+	"getnep17transfers":       (*Server).getNEP17Transfers,
+	"getnotifications":        (*Server).getNotifications,
+	"getpeers":                (*Server).getPeers,
+	"getproof":                (*Server).getProof,
+	"getrawmempool":           (*Server).getRawMempool,
+	"getrawnotarypool":        (*Server).getRawNotaryPool,
+	"getrawnotarytransaction": (*Server).getRawNotaryTransaction,
+	"getrawtransaction":       (*Server).getrawtransaction,
+	"getstateheight":          (*Server).getStateHeight,
+	"getstateroot":            (*Server).getStateRoot,
+	"getstorage":              (*Server).getStorage,
+	"getstoragechanges":       (*Server).getStorageChanges,
+	"getstoragehistoric":      (*Server).getStorageHistoric,
+	"gettransactionheight":    (*Server).getTransactionHeight,
+	"gettransactiontrace":     (*Server).getTransactionTrace,
+	"getunclaimedgas":         (*Server).getUnclaimedGas,
+	"getnextblockvalidators":  (*Server).getNextBlockValidators,
+	"getversion":              (*Server).getVersion,
+	"getvotingstate":          (*Server).getVotingState,
+	"invokefunction":          (*Server).invokeFunction,
+	"invokescript":            (*Server).invokescript,
+	"invokecontractverify":    (*Server).invokeContractVerify,
+	"sendrawtransaction":      (*Server).sendrawtransaction,
+	"simulate":                (*Server).simulate,
+	"simulatetransactions":    (*Server).simulateTransactions,
+	"submitblock":             (*Server).submitBlock,
+	"submitnotaryrequest":     (*Server).submitNotaryRequest,
+	"submitoracleresponse":    (*Server).submitOracleResponse,
+	"validateaddress":         (*Server).validateAddress,
+	"verifysource":            (*Server).verifySource,
+	"verifyproof":             (*Server).verifyProof,
 }
 
 var rpcWsHandlers = map[string]func(*Server, request.Params, *subscriber) (interface{}, *response.Error){
@@ -161,6 +205,15 @@ func New(chain blockchainer.Blockchainer, conf rpc.Config, coreServer *network.S
 	if orc != nil {
 		orc.SetBroadcaster(broadcaster.New(orc.MainCfg, log))
 	}
+	if conf.MaxBatchSize <= 0 {
+		conf.MaxBatchSize = request.DefaultMaxBatchSize
+	}
+	if conf.MaxNEP17TransfersLimit <= 0 {
+		conf.MaxNEP17TransfersLimit = defaultMaxTransfersLimit
+	}
+	if conf.MaxNotificationsLimit <= 0 {
+		conf.MaxNotificationsLimit = defaultMaxNotificationsLimit
+	}
 	return Server{
 		Server:           httpServer,
 		chain:            chain,
@@ -171,14 +224,19 @@ func New(chain blockchainer.Blockchainer, conf rpc.Config, coreServer *network.S
 		log:              log,
 		oracle:           orc,
 		https:            tlsServer,
+		auth:             newAuthenticator(conf.Auth),
+		limits:           newRateLimits(conf.RateLimit),
 		shutdown:         make(chan struct{}),
+		verifySlot:       make(chan struct{}, 1),
 
 		subscribers: make(map[*subscriber]bool),
 		// These are NOT buffered to preserve original order of events.
-		blockCh:        make(chan *block.Block),
-		executionCh:    make(chan *state.AppExecResult),
-		notificationCh: make(chan *state.NotificationEvent),
-		transactionCh:  make(chan *transaction.Transaction),
+		blockCh:         make(chan *block.Block),
+		executionCh:     make(chan *state.AppExecResult),
+		notificationCh:  make(chan *state.NotificationEvent),
+		notaryRequestCh: make(chan mempool.Event),
+		storageCh:       make(chan *state.StorageChanges),
+		transactionCh:   make(chan *transaction.Transaction),
 	}
 }
 
@@ -253,6 +311,10 @@ func (s *Server) Shutdown() error {
 
 func (s *Server) handleHTTPRequest(w http.ResponseWriter, httpRequest *http.Request) {
 	req := request.NewRequest()
+	src := requestSource{
+		addr:       httpRequest.RemoteAddr,
+		authorized: s.auth.check(httpRequest),
+	}
 
 	if httpRequest.URL.Path == "/ws" && httpRequest.Method == "GET" {
 		// Technically there is a race between this check and
@@ -282,7 +344,7 @@ func (s *Server) handleHTTPRequest(w http.ResponseWriter, httpRequest *http.Requ
 		s.subscribers[subscr] = true
 		s.subsLock.Unlock()
 		go s.handleWsWrites(ws, resChan, subChan)
-		s.handleWsReads(ws, resChan, subscr)
+		s.handleWsReads(ws, resChan, subscr, src)
 		return
 	}
 
@@ -297,33 +359,39 @@ func (s *Server) handleHTTPRequest(w http.ResponseWriter, httpRequest *http.Requ
 		return
 	}
 
-	err := req.DecodeData(httpRequest.Body)
+	err := req.DecodeDataWithLimit(httpRequest.Body, s.config.MaxBatchSize)
 	if err != nil {
 		s.writeHTTPErrorResponse(request.NewIn(), w, response.NewParseError("Problem parsing JSON-RPC request body", err))
 		return
 	}
 
-	resp := s.handleRequest(req, nil)
+	resp := s.handleRequest(req, nil, src)
 	s.writeHTTPServerResponse(req, w, resp)
 }
 
-func (s *Server) handleRequest(req *request.Request, sub *subscriber) response.AbstractResult {
+func (s *Server) handleRequest(req *request.Request, sub *subscriber, src requestSource) response.AbstractResult {
 	if req.In != nil {
-		return s.handleIn(req.In, sub)
+		return s.handleIn(req.In, sub, src)
 	}
 	resp := make(response.AbstractBatch, len(req.Batch))
 	for i, in := range req.Batch {
-		resp[i] = s.handleIn(&in, sub)
+		resp[i] = s.handleIn(&in, sub, src)
 	}
 	return resp
 }
 
-func (s *Server) handleIn(req *request.In, sub *subscriber) response.Abstract {
+func (s *Server) handleIn(req *request.In, sub *subscriber, src requestSource) response.Abstract {
 	var res interface{}
 	var resErr *response.Error
 	if req.JSONRPC != request.JSONRPCVersion {
 		return s.packResponse(req, nil, response.NewInvalidParamsError("Problem parsing JSON", fmt.Errorf("invalid version, expected 2.0 got: '%s'", req.JSONRPC)))
 	}
+	if s.auth.protected(req.Method) && !src.authorized {
+		return s.packResponse(req, nil, response.NewUnauthorizedError(fmt.Sprintf("Method '%s' requires authentication", req.Method), nil))
+	}
+	if !s.limits.allow(src.addr, req.Method) {
+		return s.packResponse(req, nil, response.NewLimitExceededError(fmt.Sprintf("Too many requests, method '%s' rate limit exceeded", req.Method), nil))
+	}
 
 	reqParams, err := req.Params()
 	if err != nil {
@@ -396,18 +464,22 @@ drainloop:
 	}
 }
 
-func (s *Server) handleWsReads(ws *websocket.Conn, resChan chan<- response.AbstractResult, subscr *subscriber) {
+func (s *Server) handleWsReads(ws *websocket.Conn, resChan chan<- response.AbstractResult, subscr *subscriber, src requestSource) {
 	ws.SetReadLimit(wsReadLimit)
 	ws.SetReadDeadline(time.Now().Add(wsPongLimit))
 	ws.SetPongHandler(func(string) error { ws.SetReadDeadline(time.Now().Add(wsPongLimit)); return nil })
 requestloop:
 	for {
+		_, data, err := ws.ReadMessage()
+		if err != nil {
+			break
+		}
 		req := request.NewRequest()
-		err := ws.ReadJSON(req)
+		err = req.UnmarshalJSONWithLimit(data, s.config.MaxBatchSize)
 		if err != nil {
 			break
 		}
-		res := s.handleRequest(req, subscr)
+		res := s.handleRequest(req, subscr, src)
 		res.RunForErrors(func(jsonErr *response.Error) {
 			s.logRequestError(req, jsonErr)
 		})
@@ -479,7 +551,7 @@ func (s *Server) getBlock(reqParams request.Params) (interface{}, *response.Erro
 		return nil, respErr
 	}
 
-	block, err := s.chain.GetBlock(hash)
+	block, err := s.chain.FetchBlock(hash)
 	if err != nil {
 		return nil, response.NewInternalServerError(fmt.Sprintf("Problem locating block with hash: %s", hash), err)
 	}
@@ -505,6 +577,18 @@ func (s *Server) getBlockHash(reqParams request.Params) (interface{}, *response.
 	return s.chain.GetHeaderHash(num), nil
 }
 
+// compactStorage compacts node's database, it's an admin method.
+func (s *Server) compactStorage(_ request.Params) (interface{}, *response.Error) {
+	if !s.config.EnableAdminMethods {
+		return nil, response.NewInvalidRequestError("admin methods are disabled", nil)
+	}
+	reclaimed, err := s.chain.CompactStorage()
+	if err != nil {
+		return nil, response.NewInternalServerError("failed to compact storage", err)
+	}
+	return result.StorageCompaction{Reclaimed: reclaimed}, nil
+}
+
 func (s *Server) getVersion(_ request.Params) (interface{}, *response.Error) {
 	port, err := s.coreServer.Port()
 	if err != nil {
@@ -519,28 +603,192 @@ func (s *Server) getVersion(_ request.Params) (interface{}, *response.Error) {
 	}, nil
 }
 
-func (s *Server) getPeers(_ request.Params) (interface{}, *response.Error) {
+func (s *Server) getPeers(reqParams request.Params) (interface{}, *response.Error) {
 	peers := result.NewGetPeers()
 	peers.AddUnconnected(s.coreServer.UnconnectedPeers())
 	peers.AddConnected(s.coreServer.ConnectedPeers())
 	peers.AddBad(s.coreServer.BadPeers())
+	if reqParams.Value(0).GetBoolean() {
+		scores := s.coreServer.PeerScores()
+		peers.Scores = make([]result.PeerScore, len(scores))
+		for i, sc := range scores {
+			peers.Scores[i] = result.PeerScore{
+				Address:       sc.Address,
+				Score:         sc.Score,
+				Violations:    sc.Violations,
+				SlowResponses: sc.SlowResponses,
+				StaleHeights:  sc.StaleHeights,
+			}
+			if !sc.BannedUntil.IsZero() {
+				peers.Scores[i].BannedUntil = uint64(sc.BannedUntil.UnixNano() / int64(time.Millisecond))
+			}
+		}
+	}
 	return peers, nil
 }
 
+// getConsensusState returns consensus process state, it's an admin method.
+func (s *Server) getConsensusState(_ request.Params) (interface{}, *response.Error) {
+	if !s.config.EnableAdminMethods {
+		return nil, response.NewInvalidRequestError("admin methods are disabled", nil)
+	}
+	st, err := s.coreServer.GetConsensusState()
+	if err != nil {
+		if errors.Is(err, network.ErrNotConsensusNode) {
+			return nil, response.NewInvalidRequestError("not a consensus node", err)
+		}
+		return nil, response.NewInternalServerError("failed to get consensus state", err)
+	}
+	res := result.ConsensusState{
+		BlockIndex:   st.BlockIndex,
+		ViewNumber:   st.ViewNumber,
+		PrimaryIndex: st.PrimaryIndex,
+		MyIndex:      st.MyIndex,
+		ViewChanges:  st.ViewChanges,
+		Preparations: st.Preparations,
+		Commits:      st.Commits,
+		ChangeViews:  st.ChangeViews,
+		Validators:   make([]result.ConsensusValidatorState, len(st.Validators)),
+	}
+	if !st.RoundStart.IsZero() {
+		res.RoundStart = uint64(st.RoundStart.UnixNano() / int64(time.Millisecond))
+	}
+	for i, v := range st.Validators {
+		res.Validators[i] = result.ConsensusValidatorState{
+			PublicKey:      *v.PublicKey,
+			LastSeenHeight: v.LastSeenHeight,
+			LastSeenView:   v.LastSeenView,
+			LostRounds:     v.LostRounds,
+		}
+	}
+	return res, nil
+}
+
 func (s *Server) getRawMempool(reqParams request.Params) (interface{}, *response.Error) {
 	verbose := reqParams.Value(0).GetBoolean()
+	details := reqParams.Value(1).GetBoolean()
 	mp := s.chain.GetMemPool()
+	txes := mp.GetVerifiedTransactions()
 	hashList := make([]util.Uint256, 0)
-	for _, item := range mp.GetVerifiedTransactions() {
+	for _, item := range txes {
 		hashList = append(hashList, item.Hash())
 	}
-	if !verbose {
+	if !verbose && !details {
 		return hashList, nil
 	}
-	return result.RawMempool{
+	res := result.RawMempool{
 		Height:   s.chain.BlockHeight(),
 		Verified: hashList,
-	}, nil
+	}
+	if details {
+		res.Transactions = make([]result.MempoolTransaction, len(txes))
+		for i, tx := range txes {
+			res.Transactions[i] = newMempoolTransaction(tx)
+		}
+	}
+	return res, nil
+}
+
+var errP2PSigExtensionsDisabled = errors.New("'P2PSigExtensions' setting is disabled")
+
+// getNotaryPool returns P2PNotaryRequest payload pool of the network server
+// or nil if it's not available.
+func (s *Server) getNotaryPool() *mempool.Pool {
+	if s.coreServer == nil {
+		return nil
+	}
+	return s.coreServer.GetNotaryPool()
+}
+
+// getRawNotaryPool returns hashes of the P2PNotaryRequest payloads from the
+// pool grouped by main transaction hash. Requests can be filtered by the
+// main transaction signer.
+func (s *Server) getRawNotaryPool(reqParams request.Params) (interface{}, *response.Error) {
+	pool := s.getNotaryPool()
+	if pool == nil {
+		return nil, response.NewInvalidRequestError("'getrawnotarypool' is not supported", errP2PSigExtensionsDisabled)
+	}
+	var signer *util.Uint160
+	if p := reqParams.Value(0); p != nil {
+		u, err := p.GetUint160FromAddressOrHex()
+		if err != nil {
+			return nil, response.ErrInvalidParams
+		}
+		signer = &u
+	}
+	res := result.RawNotaryPool{Hashes: make(map[util.Uint256][]util.Uint256)}
+	for _, fb := range pool.GetVerifiedTransactions() {
+		data, ok := pool.TryGetData(fb.Hash())
+		if !ok {
+			continue // Removed concurrently.
+		}
+		r := data.(*payload.P2PNotaryRequest)
+		if signer != nil && !txMatches(request.TxFilter{Signer: signer}, r.MainTransaction) {
+			continue
+		}
+		main := r.MainTransaction.Hash()
+		res.Hashes[main] = append(res.Hashes[main], fb.Hash())
+	}
+	return res, nil
+}
+
+// getRawNotaryTransaction returns main or fallback transaction of the pooled
+// P2PNotaryRequest payload by its hash.
+func (s *Server) getRawNotaryTransaction(reqParams request.Params) (interface{}, *response.Error) {
+	pool := s.getNotaryPool()
+	if pool == nil {
+		return nil, response.NewInvalidRequestError("'getrawnotarytransaction' is not supported", errP2PSigExtensionsDisabled)
+	}
+	txHash, err := reqParams.Value(0).GetUint256()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	var tx *transaction.Transaction
+	if data, ok := pool.TryGetData(txHash); ok {
+		tx = data.(*payload.P2PNotaryRequest).FallbackTransaction
+	} else {
+		for _, fb := range pool.GetVerifiedTransactions() {
+			data, ok := pool.TryGetData(fb.Hash())
+			if !ok {
+				continue
+			}
+			if mainTx := data.(*payload.P2PNotaryRequest).MainTransaction; mainTx.Hash() == txHash {
+				tx = mainTx
+				break
+			}
+		}
+	}
+	if tx == nil {
+		err = fmt.Errorf("no notary request transaction %s in the pool", txHash.StringLE())
+		return nil, response.NewRPCError("Unknown transaction", err.Error(), err)
+	}
+	if reqParams.Value(1).GetBoolean() {
+		return result.NewTransactionOutputRaw(tx, nil, nil, s.chain), nil
+	}
+	return tx.Bytes(), nil
+}
+
+// newMempoolTransaction returns mempool details of the transaction.
+func newMempoolTransaction(tx *transaction.Transaction) result.MempoolTransaction {
+	res := result.MempoolTransaction{
+		Hash:            tx.Hash(),
+		Sender:          address.Uint160ToString(tx.Sender()),
+		Size:            tx.Size(),
+		SystemFee:       tx.SystemFee,
+		NetworkFee:      tx.NetworkFee,
+		FeePerByte:      tx.FeePerByte(),
+		HighPriority:    tx.HasAttribute(transaction.HighPriority),
+		ValidUntilBlock: tx.ValidUntilBlock,
+	}
+	for _, attr := range tx.Attributes {
+		switch attr.Type {
+		case transaction.NotValidBeforeT:
+			res.NotValidBefore = attr.Value.(*transaction.NotValidBefore).Height
+		case transaction.ConflictsT:
+			res.Conflicts = append(res.Conflicts, attr.Value.(*transaction.Conflicts).Hash)
+		}
+	}
+	return res
 }
 
 func (s *Server) validateAddress(reqParams request.Params) (interface{}, *response.Error) {
@@ -584,7 +832,7 @@ func (s *Server) calculateNetworkFee(reqParams request.Params) (interface{}, *re
 		}
 		if verificationScript == nil { // then it still might be a contract-based verification
 			verificationErr := fmt.Sprintf("contract verification for signer #%d failed", i)
-			res, respErr := s.runScriptInVM(trigger.Verification, tx.Scripts[i].InvocationScript, signer.Account, tx)
+			res, respErr := s.runScriptInVM(trigger.Verification, tx.Scripts[i].InvocationScript, signer.Account, tx, false)
 			if respErr != nil && errors.Is(respErr.Cause, core.ErrUnknownVerificationContract) {
 				// it's neither a contract-based verification script nor a standard witness attached to
 				// the tx, so the user did not provide enough data to calculate fee for that witness =>
@@ -683,11 +931,11 @@ func (s *Server) getNEP17Balances(ps request.Params) (interface{}, *response.Err
 	return bs, nil
 }
 
-func getTimestampsAndLimit(ps request.Params, index int) (uint64, uint64, int, int, error) {
+func getTimestampsAndLimit(ps request.Params, index int, maxLimit int) (uint64, uint64, int, int, error) {
 	var start, end uint64
 	var limit, page int
 
-	limit = maxTransfersLimit
+	limit = maxLimit
 	pStart, pEnd, pLimit, pPage := ps.Value(index), ps.Value(index+1), ps.Value(index+2), ps.Value(index+3)
 	if pPage != nil {
 		p, err := pPage.GetInt()
@@ -707,7 +955,7 @@ func getTimestampsAndLimit(ps request.Params, index int) (uint64, uint64, int, i
 		if l <= 0 {
 			return 0, 0, 0, 0, errors.New("can't use negative or zero limit")
 		}
-		if l > maxTransfersLimit {
+		if l > maxLimit {
 			return 0, 0, 0, 0, errors.New("too big limit requested")
 		}
 		limit = l
@@ -733,15 +981,126 @@ func getTimestampsAndLimit(ps request.Params, index int) (uint64, uint64, int, i
 	return start, end, limit, page, nil
 }
 
+// transfersCursor points to a position in transfer log, it's the timestamp
+// of the next transfer to return and the number of transfers with the same
+// timestamp to skip before it.
+type transfersCursor struct {
+	timestamp uint64
+	skip      uint32
+}
+
+// String returns base64-encoded cursor representation.
+func (c transfersCursor) String() string {
+	b := make([]byte, 12)
+	binary.LittleEndian.PutUint64(b, c.timestamp)
+	binary.LittleEndian.PutUint32(b[8:], c.skip)
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+// decodeTransfersCursor decodes cursor from the given parameter.
+func decodeTransfersCursor(p *request.Param) (*transfersCursor, error) {
+	s, err := p.GetString()
+	if err != nil {
+		return nil, err
+	}
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != 12 {
+		return nil, errors.New("invalid cursor length")
+	}
+	return &transfersCursor{
+		timestamp: binary.LittleEndian.Uint64(b),
+		skip:      binary.LittleEndian.Uint32(b[8:]),
+	}, nil
+}
+
+// transfersPager selects transfers from the log (iterated from newest to
+// oldest) according to the time frame, limit and page or cursor requested.
+type transfersPager struct {
+	start, end uint64
+	limit      int
+	page       int
+	cur        *transfersCursor
+	// Position of the current transfer: its timestamp and the number of
+	// transfers with this timestamp seen so far (including this one).
+	pos        transfersCursor
+	resCount   int
+	frameCount int
+	// next is set to cursor of the first transfer not returned if the
+	// limit is reached.
+	next string
+}
+
+// newTransfersPager parses get*transfers parameters starting from index 1.
+func (s *Server) newTransfersPager(ps request.Params) (*transfersPager, *response.Error) {
+	var p = new(transfersPager)
+	// Cursor can be passed instead of page number.
+	if v := ps.Value(4); v != nil && v.Type == request.StringT {
+		if _, err := v.GetInt(); err != nil {
+			p.cur, err = decodeTransfersCursor(v)
+			if err != nil {
+				return nil, response.NewInvalidParamsError("invalid cursor", err)
+			}
+			ps = ps[:4]
+		}
+	}
+	var err error
+	p.start, p.end, p.limit, p.page, err = getTimestampsAndLimit(ps, 1, s.config.MaxNEP17TransfersLimit)
+	if err != nil {
+		return nil, response.NewInvalidParamsError(err.Error(), err)
+	}
+	if p.cur != nil && p.cur.timestamp < p.end {
+		p.end = p.cur.timestamp
+	}
+	return p, nil
+}
+
+// check returns whether the transfer with the given timestamp should be
+// returned and whether the iteration should continue.
+func (p *transfersPager) check(ts uint64) (bool, bool) {
+	// Iterating from newest to oldest, not yet reached required
+	// time frame, continue looping.
+	if ts > p.end {
+		return false, true
+	}
+	// Iterating from newest to oldest, moved past required
+	// time frame, stop looping.
+	if ts < p.start {
+		return false, false
+	}
+	if p.pos.timestamp != ts {
+		p.pos = transfersCursor{timestamp: ts}
+	}
+	p.pos.skip++
+	// Using cursor, not yet reached the position it points to.
+	if p.cur != nil && ts == p.cur.timestamp && p.pos.skip <= p.cur.skip {
+		return false, true
+	}
+	p.frameCount++
+	// Using limits, not yet reached required page.
+	if p.limit != 0 && p.page*p.limit >= p.frameCount {
+		return false, true
+	}
+	// Using limits, reached limit, but there are more transfers.
+	if p.limit != 0 && p.resCount >= p.limit {
+		p.next = transfersCursor{timestamp: p.pos.timestamp, skip: p.pos.skip - 1}.String()
+		return false, false
+	}
+	p.resCount++
+	return true, true
+}
+
 func (s *Server) getNEP17Transfers(ps request.Params) (interface{}, *response.Error) {
 	u, err := ps.Value(0).GetUint160FromAddressOrHex()
 	if err != nil {
 		return nil, response.ErrInvalidParams
 	}
 
-	start, end, limit, page, err := getTimestampsAndLimit(ps, 1)
-	if err != nil {
-		return nil, response.NewInvalidParamsError(err.Error(), err)
+	pager, respErr := s.newTransfersPager(ps)
+	if respErr != nil {
+		return nil, respErr
 	}
 
 	bs := &result.NEP17Transfers{
@@ -750,32 +1109,339 @@ func (s *Server) getNEP17Transfers(ps request.Params) (interface{}, *response.Er
 		Sent:     []result.NEP17Transfer{},
 	}
 	cache := make(map[int32]util.Uint160)
-	var resCount, frameCount int
 	err = s.chain.ForEachNEP17Transfer(u, func(tr *state.NEP17Transfer) (bool, error) {
-		// Iterating from newest to oldest, not yet reached required
-		// time frame, continue looping.
-		if tr.Timestamp > end {
+		take, cont := pager.check(tr.Timestamp)
+		if !take {
+			return cont, nil
+		}
+
+		h, err := s.getHash(tr.Asset, cache)
+		if err != nil {
+			return false, err
+		}
+
+		transfer := result.NEP17Transfer{
+			Timestamp: tr.Timestamp,
+			Asset:     h,
+			Index:     tr.Block,
+			TxHash:    tr.Tx,
+		}
+		if tr.Amount.Sign() > 0 { // token was received
+			transfer.Amount = tr.Amount.String()
+			if !tr.From.Equals(util.Uint160{}) {
+				transfer.Address = address.Uint160ToString(tr.From)
+			}
+			bs.Received = append(bs.Received, transfer)
+		} else {
+			transfer.Amount = new(big.Int).Neg(&tr.Amount).String()
+			if !tr.To.Equals(util.Uint160{}) {
+				transfer.Address = address.Uint160ToString(tr.To)
+			}
+			bs.Sent = append(bs.Sent, transfer)
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, response.NewInternalServerError("invalid NEP17 transfer log", err)
+	}
+	bs.Next = pager.next
+	return bs, nil
+}
+
+var errAddressHistoryIndexDisabled = errors.New("'AddressHistoryIndex' setting is disabled")
+
+// getAddressHistory returns transactions signed by the account (the first
+// parameter) from the newest to the oldest, they're paged with the page
+// number passed as the second (optional) parameter.
+func (s *Server) getAddressHistory(ps request.Params) (interface{}, *response.Error) {
+	if !s.chain.GetConfig().AddressHistoryIndex {
+		return nil, response.NewInvalidRequestError("'getaddresshistory' is not supported", errAddressHistoryIndexDisabled)
+	}
+	u, err := ps.Value(0).GetUint160FromAddressOrHex()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	var page int
+	if p := ps.Value(1); p != nil {
+		page, err = p.GetInt()
+		if err != nil || page < 0 || page > maxAddressHistoryPage {
+			return nil, response.NewInvalidParamsError("invalid page, it should be in [0, "+strconv.Itoa(maxAddressHistoryPage)+"] range", err)
+		}
+	}
+
+	res := &result.AddressHistory{
+		Address:      address.Uint160ToString(u),
+		Transactions: []result.AddressTransaction{},
+		Page:         page,
+	}
+	skip := page * addressHistoryPageSize
+	err = s.chain.ForEachAddressTransaction(u, func(tx *state.AddressTransaction) (bool, error) {
+		if skip > 0 {
+			skip--
 			return true, nil
 		}
-		// Iterating from newest to oldest, moved past required
-		// time frame, stop looping.
-		if tr.Timestamp < start {
+		if len(res.Transactions) == addressHistoryPageSize {
+			res.More = true
+			return false, nil
+		}
+		res.Transactions = append(res.Transactions, result.AddressTransaction{
+			TxHash:    tx.Tx,
+			Index:     tx.Block,
+			Timestamp: tx.Timestamp,
+			Sender:    tx.Sender,
+		})
+		return true, nil
+	})
+	if err != nil {
+		return nil, response.NewInternalServerError("invalid address transaction log", err)
+	}
+	return res, nil
+}
+
+var errNotificationsIndexDisabled = errors.New("'NotificationsIndex' setting is disabled")
+
+// maxNotificationsPage is the maximum page number accepted by
+// getnotifications, cursor should be used to get older notifications.
+const maxNotificationsPage = 100
+
+// notificationsCursor points to a position in notification log, it's the
+// block of the next notification to return and the number of notifications
+// from this block to skip before it.
+type notificationsCursor struct {
+	block uint32
+	skip  uint32
+}
+
+// String returns base64-encoded cursor representation.
+func (c notificationsCursor) String() string {
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint32(b, c.block)
+	binary.LittleEndian.PutUint32(b[4:], c.skip)
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+// decodeNotificationsCursor decodes cursor from the given parameter.
+func decodeNotificationsCursor(p *request.Param) (*notificationsCursor, error) {
+	s, err := p.GetString()
+	if err != nil {
+		return nil, err
+	}
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != 8 {
+		return nil, errors.New("invalid cursor length")
+	}
+	return &notificationsCursor{
+		block: binary.LittleEndian.Uint32(b),
+		skip:  binary.LittleEndian.Uint32(b[4:]),
+	}, nil
+}
+
+// getNotifications returns notifications of the contract (the first
+// parameter) optionally filtered by event name and block range, the result is
+// ordered from the newest notification to the oldest one and paged with limit
+// and page number (or cursor) parameters.
+func (s *Server) getNotifications(ps request.Params) (interface{}, *response.Error) {
+	if !s.chain.GetConfig().NotificationsIndex {
+		return nil, response.NewInvalidRequestError("'getnotifications' is not supported", errNotificationsIndexDisabled)
+	}
+	h, respErr := s.contractScriptHashFromParam(ps.Value(0))
+	if respErr != nil {
+		return nil, respErr
+	}
+	var name string
+	if p := ps.Value(1); p != nil {
+		var err error
+		name, err = p.GetString()
+		if err != nil {
+			return nil, response.NewInvalidParamsError("invalid event name", err)
+		}
+	}
+	// Cursor can be passed instead of page number.
+	var cur *notificationsCursor
+	if v := ps.Value(5); v != nil && v.Type == request.StringT {
+		if _, err := v.GetInt(); err != nil {
+			cur, err = decodeNotificationsCursor(v)
+			if err != nil {
+				return nil, response.NewInvalidParamsError("invalid cursor", err)
+			}
+			ps = ps[:5]
+		}
+	}
+	var start, end uint32 = 0, s.chain.BlockHeight()
+	for i, v := range []*uint32{&start, &end} {
+		if p := ps.Value(2 + i); p != nil {
+			n, err := p.GetInt()
+			if err != nil {
+				return nil, response.NewInvalidParamsError("invalid block index", err)
+			}
+			if n < 0 || int64(n) > math.MaxUint32 {
+				return nil, response.NewInvalidParamsError("block index out of range", nil)
+			}
+			*v = uint32(n)
+		}
+	}
+	limit, page := s.config.MaxNotificationsLimit, 0
+	if p := ps.Value(4); p != nil {
+		l, err := p.GetInt()
+		if err != nil {
+			return nil, response.NewInvalidParamsError("invalid limit", err)
+		}
+		if l <= 0 || l > s.config.MaxNotificationsLimit {
+			return nil, response.NewInvalidParamsError("limit should be positive and not exceed "+strconv.Itoa(s.config.MaxNotificationsLimit), nil)
+		}
+		limit = l
+	}
+	if p := ps.Value(5); p != nil {
+		var err error
+		page, err = p.GetInt()
+		if err != nil || page < 0 || page > maxNotificationsPage {
+			return nil, response.NewInvalidParamsError("invalid page, it should be in [0, "+strconv.Itoa(maxNotificationsPage)+"] range", err)
+		}
+	}
+	if cur != nil && cur.block < end {
+		end = cur.block
+	}
+
+	res := &result.ContractNotifications{
+		Contract:      h,
+		Notifications: []result.ContractNotification{},
+	}
+	var (
+		skip = page * limit
+		// Position of the current notification: its block and the number
+		// of notifications from this block seen so far (including it).
+		pos notificationsCursor
+	)
+	err := s.chain.ForEachNotification(h, name, end, func(n *state.IndexedNotification) (bool, error) {
+		// Iterating from newest to oldest.
+		if n.Block < start {
 			return false, nil
 		}
-		frameCount++
-		// Using limits, not yet reached required page.
-		if limit != 0 && page*limit >= frameCount {
+		if name != "" && n.Name != name {
+			return true, nil
+		}
+		if pos.skip == 0 || pos.block != n.Block {
+			pos = notificationsCursor{block: n.Block}
+		}
+		pos.skip++
+		if cur != nil && n.Block == cur.block && pos.skip <= cur.skip {
+			return true, nil
+		}
+		if skip > 0 {
+			skip--
 			return true, nil
 		}
+		if len(res.Notifications) >= limit {
+			res.Next = notificationsCursor{block: pos.block, skip: pos.skip - 1}.String()
+			return false, nil
+		}
+		res.Notifications = append(res.Notifications, result.ContractNotification{
+			NotificationEvent: state.NotificationEvent{
+				ScriptHash: h,
+				Name:       n.Name,
+				Item:       n.Item,
+			},
+			Block:     n.Block,
+			Container: n.Container,
+		})
+		return true, nil
+	})
+	if err != nil {
+		return nil, response.NewInternalServerError("invalid notification log", err)
+	}
+	return res, nil
+}
+
+func (s *Server) getNEP11Balances(ps request.Params) (interface{}, *response.Error) {
+	u, err := ps.Value(0).GetUint160FromAddressOrHex()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+
+	as := s.chain.GetNEP11Balances(u)
+	bs := &result.NEP11Balances{
+		Address:  address.Uint160ToString(u),
+		Balances: []result.NEP11AssetBalance{},
+	}
+	if as != nil {
+		cache := make(map[int32]util.Uint160)
+		for id, tokens := range as.Trackers {
+			h, err := s.getHash(id, cache)
+			if err != nil {
+				continue
+			}
+			if !s.isNEP11(h) {
+				continue
+			}
+			bal := result.NEP11AssetBalance{
+				Asset:  h,
+				Tokens: make([]result.NEP11TokenBalance, 0, len(tokens)),
+			}
+			for tokenID, tr := range tokens {
+				bal.Tokens = append(bal.Tokens, result.NEP11TokenBalance{
+					ID:          hex.EncodeToString([]byte(tokenID)),
+					Amount:      tr.Balance.String(),
+					LastUpdated: tr.LastUpdatedBlock,
+				})
+			}
+			sort.Slice(bal.Tokens, func(i, j int) bool {
+				return bal.Tokens[i].ID < bal.Tokens[j].ID
+			})
+			bs.Balances = append(bs.Balances, bal)
+		}
+		sort.Slice(bs.Balances, func(i, j int) bool {
+			return bs.Balances[i].Asset.Less(bs.Balances[j].Asset)
+		})
+	}
+	return bs, nil
+}
 
+func (s *Server) getNEP11Transfers(ps request.Params) (interface{}, *response.Error) {
+	u, err := ps.Value(0).GetUint160FromAddressOrHex()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+
+	pager, respErr := s.newTransfersPager(ps)
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	bs := &result.NEP11Transfers{
+		Address:  address.Uint160ToString(u),
+		Received: []result.NEP11Transfer{},
+		Sent:     []result.NEP11Transfer{},
+	}
+	var (
+		cache  = make(map[int32]util.Uint160)
+		nep11s = make(map[int32]bool)
+	)
+	err = s.chain.ForEachNEP11Transfer(u, func(tr *state.NEP11Transfer) (bool, error) {
 		h, err := s.getHash(tr.Asset, cache)
 		if err != nil {
 			return false, err
 		}
+		isNEP11, ok := nep11s[tr.Asset]
+		if !ok {
+			isNEP11 = s.isNEP11(h)
+			nep11s[tr.Asset] = isNEP11
+		}
+		if !isNEP11 {
+			return true, nil
+		}
 
-		transfer := result.NEP17Transfer{
+		take, cont := pager.check(tr.Timestamp)
+		if !take {
+			return cont, nil
+		}
+
+		transfer := result.NEP11Transfer{
 			Timestamp: tr.Timestamp,
 			Asset:     h,
+			ID:        hex.EncodeToString(tr.ID),
 			Index:     tr.Block,
 			TxHash:    tr.Tx,
 		}
@@ -792,20 +1458,31 @@ func (s *Server) getNEP17Transfers(ps request.Params) (interface{}, *response.Er
 			}
 			bs.Sent = append(bs.Sent, transfer)
 		}
-
-		resCount++
-		// Using limits, reached limit.
-		if limit != 0 && resCount >= limit {
-			return false, nil
-		}
 		return true, nil
 	})
 	if err != nil {
-		return nil, response.NewInternalServerError("invalid NEP17 transfer log", err)
+		return nil, response.NewInternalServerError("invalid NEP11 transfer log", err)
 	}
+	bs.Next = pager.next
 	return bs, nil
 }
 
+// isNEP11 checks whether the contract with the given hash declares NEP-11
+// support and complies with it. Any contract can emit 4-parameter Transfer
+// event (including NEP-17 ones), but only NEP-11 tokens are to be reported.
+func (s *Server) isNEP11(h util.Uint160) bool {
+	cs := s.chain.GetContractState(h)
+	if cs == nil {
+		return false
+	}
+	for _, st := range cs.Manifest.SupportedStandards {
+		if st == manifest.NEP11StandardName {
+			return standard.CheckABI(&cs.Manifest, manifest.NEP11StandardName) == nil
+		}
+	}
+	return false
+}
+
 // getHash returns the hash of the contract by its ID using cache.
 func (s *Server) getHash(contractID int32, cache map[int32]util.Uint160) (util.Uint160, error) {
 	if d, ok := cache[contractID]; ok {
@@ -899,8 +1576,23 @@ func makeStorageKey(id int32, key []byte) []byte {
 
 var errKeepOnlyLatestState = errors.New("'KeepOnlyLatestState' setting is enabled")
 
+// keepsOnlyLatestState returns true if the node doesn't keep any MPT state
+// except the latest one.
+func (s *Server) keepsOnlyLatestState() bool {
+	cfg := s.chain.GetConfig()
+	return cfg.KeepOnlyLatestState && cfg.StateRetentionBlocks == 0
+}
+
+// isStatePruned returns true if MPT nodes of the state for the given block
+// could already have been removed because they're kept only for the last
+// StateRetentionBlocks blocks.
+func (s *Server) isStatePruned(height uint32) bool {
+	cfg := s.chain.GetConfig()
+	return cfg.KeepOnlyLatestState && uint64(height)+uint64(cfg.StateRetentionBlocks) < uint64(s.chain.BlockHeight())
+}
+
 func (s *Server) getProof(ps request.Params) (interface{}, *response.Error) {
-	if s.chain.GetConfig().KeepOnlyLatestState {
+	if s.keepsOnlyLatestState() {
 		return nil, response.NewInvalidRequestError("'getproof' is not supported", errKeepOnlyLatestState)
 	}
 	root, err := ps.Value(0).GetUint256()
@@ -922,6 +1614,9 @@ func (s *Server) getProof(ps request.Params) (interface{}, *response.Error) {
 	skey := makeStorageKey(cs.ID, key)
 	proof, err := s.chain.GetStateModule().GetStateProof(root, skey)
 	if err != nil {
+		if errors.Is(err, mpt.ErrNotFound) {
+			return nil, response.NewRPCError("Unknown storage item", err.Error(), err)
+		}
 		return nil, response.NewInternalServerError("failed to get proof", err)
 	}
 	return &result.ProofWithKey{
@@ -931,7 +1626,7 @@ func (s *Server) getProof(ps request.Params) (interface{}, *response.Error) {
 }
 
 func (s *Server) verifyProof(ps request.Params) (interface{}, *response.Error) {
-	if s.chain.GetConfig().KeepOnlyLatestState {
+	if s.keepsOnlyLatestState() {
 		return nil, response.NewInvalidRequestError("'verifyproof' is not supported", errKeepOnlyLatestState)
 	}
 	root, err := ps.Value(0).GetUint256()
@@ -1014,6 +1709,74 @@ func (s *Server) getStorage(ps request.Params) (interface{}, *response.Error) {
 	return []byte(item), nil
 }
 
+// getStorageHistoric returns contract storage item value as of the specified
+// block using MPT state for it.
+func (s *Server) getStorageHistoric(ps request.Params) (interface{}, *response.Error) {
+	if s.keepsOnlyLatestState() {
+		return nil, response.NewInvalidRequestError("'getstoragehistoric' is not supported", errKeepOnlyLatestState)
+	}
+	id, rErr := s.contractIDFromParam(ps.Value(0))
+	if rErr == response.ErrUnknown {
+		return nil, nil
+	}
+	if rErr != nil {
+		return nil, rErr
+	}
+	key, err := ps.Value(1).GetBytesBase64()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	if _, err := ps.Value(2).GetInt(); err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	height, rErr := s.blockHeightFromParam(ps.Value(2))
+	if rErr != nil {
+		return nil, rErr
+	}
+	if s.isStatePruned(uint32(height)) {
+		return nil, response.NewInvalidParamsError("unknown state", fmt.Errorf("state for block %d is pruned", height))
+	}
+	root, err := s.chain.GetStateModule().GetStateRoot(uint32(height))
+	if err != nil {
+		return nil, response.NewRPCError("Unknown state root.", "", err)
+	}
+	item, err := s.chain.GetStateModule().GetState(root.Root, makeStorageKey(id, key))
+	if errors.Is(err, mpt.ErrNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return nil, response.NewInternalServerError("failed to get historic storage item", err)
+	}
+	return item, nil
+}
+
+var errSaveStorageChangesDisabled = errors.New("'SaveStorageChanges' setting is disabled")
+
+// getStorageChanges returns contract storage changes made by the block with
+// the specified index.
+func (s *Server) getStorageChanges(reqParams request.Params) (interface{}, *response.Error) {
+	if !s.chain.GetConfig().SaveStorageChanges {
+		return nil, response.NewInvalidRequestError("'getstoragechanges' is not supported", errSaveStorageChangesDisabled)
+	}
+	param := reqParams.ValueWithType(0, request.NumberT)
+	if param == nil {
+		return nil, response.ErrInvalidParams
+	}
+	num, rErr := s.blockHeightFromParam(param)
+	if rErr != nil {
+		return nil, rErr
+	}
+	changes, err := s.chain.GetStorageChanges(uint32(num))
+	if errors.Is(err, storage.ErrKeyNotFound) {
+		err = fmt.Errorf("no storage changes for block %d: %w", num, err)
+		return nil, response.NewRPCError("Unknown storage changes", err.Error(), err)
+	}
+	if err != nil {
+		return nil, response.NewInternalServerError(fmt.Sprintf("can't get storage changes for block %d", num), err)
+	}
+	return changes, nil
+}
+
 func (s *Server) getrawtransaction(reqParams request.Params) (interface{}, *response.Error) {
 	txHash, err := reqParams.Value(0).GetUint256()
 	if err != nil {
@@ -1059,6 +1822,26 @@ func (s *Server) getTransactionHeight(ps request.Params) (interface{}, *response
 	return height, nil
 }
 
+var errSaveTransactionTracesDisabled = errors.New("'SaveTransactionTraces' setting is disabled")
+
+// getTransactionTrace returns execution trace of the transaction with the
+// specified hash.
+func (s *Server) getTransactionTrace(ps request.Params) (interface{}, *response.Error) {
+	if !s.chain.GetConfig().SaveTransactionTraces {
+		return nil, response.NewInvalidRequestError("'gettransactiontrace' is not supported", errSaveTransactionTracesDisabled)
+	}
+	h, err := ps.Value(0).GetUint256()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	trace, err := s.chain.GetTransactionTrace(h)
+	if err != nil {
+		err = fmt.Errorf("no execution trace for transaction %s: %w", h.StringLE(), err)
+		return nil, response.NewRPCError("Unknown transaction trace", err.Error(), err)
+	}
+	return trace, nil
+}
+
 // getContractState returns contract state (contract information, according to the contract script hash,
 // contract id or native contract name).
 func (s *Server) getContractState(reqParams request.Params) (interface{}, *response.Error) {
@@ -1090,7 +1873,7 @@ func (s *Server) getBlockSysFee(reqParams request.Params) (interface{}, *respons
 	}
 
 	headerHash := s.chain.GetHeaderHash(num)
-	block, errBlock := s.chain.GetBlock(headerHash)
+	block, errBlock := s.chain.FetchBlock(headerHash)
 	if errBlock != nil {
 		return 0, response.NewRPCError(errBlock.Error(), "", nil)
 	}
@@ -1175,6 +1958,89 @@ func (s *Server) getNextBlockValidators(_ request.Params) (interface{}, *respons
 	return res, nil
 }
 
+// getVotingState returns candidates with their votes, current committee and
+// expected results of the next committee update along with the state of the
+// voter specified (if any).
+func (s *Server) getVotingState(ps request.Params) (interface{}, *response.Error) {
+	var voter *util.Uint160
+	if p := ps.Value(0); p != nil {
+		u, err := p.GetUint160FromAddressOrHex()
+		if err != nil {
+			return nil, response.ErrInvalidParams
+		}
+		voter = &u
+	}
+	committee, err := s.chain.GetCommittee()
+	if err != nil {
+		return nil, response.NewInternalServerError("can't get committee members", err)
+	}
+	nextCommittee, rewards, err := s.chain.GetNextCommittee()
+	if err != nil {
+		return nil, response.NewInternalServerError("can't compute next committee", err)
+	}
+	enrollments, err := s.chain.GetEnrollments()
+	if err != nil {
+		return nil, response.NewInternalServerError("can't get enrollments", err)
+	}
+	var (
+		cfg            = s.chain.GetConfig()
+		committeeSize  = uint32(len(cfg.StandbyCommittee))
+		height         = s.chain.BlockHeight()
+		nextValidators = nextCommittee[:cfg.ValidatorsCount].Copy()
+		res            = &result.VotingState{
+			Height:              height,
+			NextCommitteeUpdate: (height/committeeSize + 1) * committeeSize,
+			Committee:           committee,
+			NextValidators:      nextValidators,
+			Candidates:          make([]result.VotingCandidate, 0, len(enrollments)),
+		}
+		// Voters reward and votes of candidates expected to be elected.
+		voterRewards = make(map[string][2]*big.Int)
+	)
+	sort.Sort(res.NextValidators)
+	for _, v := range enrollments {
+		c := result.VotingCandidate{
+			PublicKey:     *v.Key,
+			Votes:         v.Votes.Int64(),
+			Committee:     committee.Contains(v.Key),
+			NextValidator: nextValidators.Contains(v.Key),
+		}
+		for i := range nextCommittee {
+			if nextCommittee[i].Equal(v.Key) {
+				c.NextCommittee = true
+				c.VoterReward = rewards[i].Int64()
+				voterRewards[string(v.Key.Bytes())] = [2]*big.Int{rewards[i], v.Votes}
+				break
+			}
+		}
+		res.Candidates = append(res.Candidates, c)
+	}
+	if voter != nil {
+		vs := &result.VoterState{Address: *voter}
+		acc, err := s.chain.GetNEOBalanceState(*voter)
+		if err != nil && !errors.Is(err, storage.ErrKeyNotFound) {
+			return nil, response.NewInternalServerError("can't get voter state", err)
+		}
+		if acc != nil && acc.Balance.Sign() > 0 {
+			vs.Balance = acc.Balance.Int64()
+			vs.VoteTo = acc.VoteTo
+			if acc.VoteTo != nil {
+				if r, ok := voterRewards[string(acc.VoteTo.Bytes())]; ok && r[1].Sign() > 0 {
+					reward := new(big.Int).Mul(&acc.Balance, r[0])
+					vs.RewardPerBlock = reward.Quo(reward, r[1]).Int64()
+				}
+			}
+			gas, err := s.chain.CalculateClaimable(*voter, height+1)
+			if err != nil {
+				return nil, response.NewInternalServerError("can't calculate claimable", err)
+			}
+			vs.Unclaimed = gas.Int64()
+		}
+		res.Voter = vs
+	}
+	return res, nil
+}
+
 // getCommittee returns the current list of NEO committee members
 func (s *Server) getCommittee(_ request.Params) (interface{}, *response.Error) {
 	keys, err := s.chain.GetCommittee()
@@ -1192,6 +2058,9 @@ func (s *Server) invokeFunction(reqParams request.Params) (interface{}, *respons
 	}
 	tx := &transaction.Transaction{}
 	checkWitnessHashesIndex := len(reqParams)
+	if checkWitnessHashesIndex > 4 {
+		checkWitnessHashesIndex = 4
+	}
 	if checkWitnessHashesIndex > 3 {
 		signers, _, err := reqParams[3].GetSignersWithWitnesses()
 		if err != nil {
@@ -1208,7 +2077,7 @@ func (s *Server) invokeFunction(reqParams request.Params) (interface{}, *respons
 		return nil, response.NewInternalServerError("can't create invocation script", err)
 	}
 	tx.Script = script
-	return s.runScriptInVM(trigger.Application, script, util.Uint160{}, tx)
+	return s.runScriptInVM(trigger.Application, script, util.Uint160{}, tx, reqParams.Value(4).GetBoolean())
 }
 
 // invokescript implements the `invokescript` RPC call.
@@ -1234,7 +2103,7 @@ func (s *Server) invokescript(reqParams request.Params) (interface{}, *response.
 		tx.Signers = []transaction.Signer{{Account: util.Uint160{}, Scopes: transaction.None}}
 	}
 	tx.Script = script
-	return s.runScriptInVM(trigger.Application, script, util.Uint160{}, tx)
+	return s.runScriptInVM(trigger.Application, script, util.Uint160{}, tx, reqParams.Value(2).GetBoolean())
 }
 
 // invokeContractVerify implements the `invokecontractverify` RPC call.
@@ -1272,28 +2141,54 @@ func (s *Server) invokeContractVerify(reqParams request.Params) (interface{}, *r
 		tx.Scripts = []transaction.Witness{{InvocationScript: invocationScript, VerificationScript: []byte{}}}
 	}
 
-	return s.runScriptInVM(trigger.Verification, invocationScript, scriptHash, tx)
+	return s.runScriptInVM(trigger.Verification, invocationScript, scriptHash, tx, false)
 }
 
 // runScriptInVM runs given script in a new test VM and returns the invocation
 // result. The script is either a simple script in case of `application` trigger
 // witness invocation script in case of `verification` trigger (it pushes `verify`
 // arguments on stack before verification). In case of contract verification
-// contractScriptHash should be specified.
-func (s *Server) runScriptInVM(t trigger.Type, script []byte, contractScriptHash util.Uint160, tx *transaction.Transaction) (*result.Invoke, *response.Error) {
+// contractScriptHash should be specified. If diag is set, execution
+// diagnostics are collected and returned along with the result.
+func (s *Server) runScriptInVM(t trigger.Type, script []byte, contractScriptHash util.Uint160, tx *transaction.Transaction, diag bool) (*result.Invoke, *response.Error) {
+	return s.runScriptInVMWithOverrides(t, script, contractScriptHash, tx, diag, nil, 0)
+}
+
+// runScriptInVMWithOverrides is the same as runScriptInVM, but it applies
+// state overrides (if not nil) before running the script and uses the given
+// GAS limit if it's positive and less than MaxGasInvoke.
+func (s *Server) runScriptInVMWithOverrides(t trigger.Type, script []byte, contractScriptHash util.Uint160,
+	tx *transaction.Transaction, diag bool, o *state.Overrides, gasLimit int64) (*result.Invoke, *response.Error) {
 	// When transferring funds, script execution does no auto GAS claim,
 	// because it depends on persisting tx height.
 	// This is why we provide block here.
-	b := block.New(s.stateRootEnabled)
-	b.Index = s.chain.BlockHeight() + 1
-	hdr, err := s.chain.GetHeader(s.chain.GetHeaderHash(int(s.chain.BlockHeight())))
+	b, err := s.getFakeNextBlock()
 	if err != nil {
 		return nil, response.NewInternalServerError("can't get last block", err)
 	}
-	b.Timestamp = hdr.Timestamp + uint64(s.chain.GetConfig().SecondsPerBlock*int(time.Second/time.Millisecond))
 
-	vm := s.chain.GetTestVM(t, tx, b)
+	var (
+		vm      *vm.VM
+		getDiff func() ([]state.StorageDiff, error)
+	)
+	switch {
+	case diag && t == trigger.Application:
+		vm, getDiff, err = s.chain.GetTestVMWithStorageDiff(t, tx, b, o)
+	case o != nil:
+		vm, err = s.chain.GetTestVMWithOverrides(t, tx, b, o)
+	default:
+		vm = s.chain.GetTestVM(t, tx, b)
+	}
+	if err != nil {
+		return nil, response.WrapErrorWithData(response.ErrInvalidParams, err)
+	}
 	vm.GasLimit = int64(s.config.MaxGasInvoke)
+	if gasLimit > 0 && gasLimit < vm.GasLimit {
+		vm.GasLimit = gasLimit
+	}
+	if diag {
+		vm.EnableProfiling()
+	}
 	if t == trigger.Verification {
 		// We need this special case because witnesses verification is not the simple System.Contract.Call,
 		// and we need to define exactly the amount of gas consumed for a contract witness verification.
@@ -1315,6 +2210,10 @@ func (s *Server) runScriptInVM(t trigger.Type, script []byte, contractScriptHash
 	} else {
 		vm.LoadScriptWithFlags(script, callflag.All)
 	}
+	var rec *replay.Recorder
+	if diag && t == trigger.Application {
+		rec = replay.NewRecorder(vm)
+	}
 	err = vm.Run()
 	var faultException string
 	if err != nil {
@@ -1327,9 +2226,78 @@ func (s *Server) runScriptInVM(t trigger.Type, script []byte, contractScriptHash
 		Stack:          vm.Estack().ToArray(),
 		FaultException: faultException,
 	}
+	if diag {
+		result.Diagnostics = profileToDiag(vm.Profile())
+	}
+	if rec != nil {
+		// Invocations that can't be recorded are returned without the
+		// record, but with the reason for that.
+		result.Diagnostics.Record, err = rec.Record()
+		if err != nil {
+			result.Diagnostics.RecordError = err.Error()
+		}
+	}
+	if getDiff != nil {
+		result.Diagnostics.Storage, err = getDiff()
+		if err != nil {
+			return nil, response.NewInternalServerError("can't get storage changes", err)
+		}
+	}
 	return result, nil
 }
 
+// getFakeNextBlock returns an empty block following the current chain tip
+// to be used for test invocations.
+func (s *Server) getFakeNextBlock() (*block.Block, error) {
+	b := block.New(s.stateRootEnabled)
+	b.Index = s.chain.BlockHeight() + 1
+	hdr, err := s.chain.GetHeader(s.chain.GetHeaderHash(int(s.chain.BlockHeight())))
+	if err != nil {
+		return nil, err
+	}
+	b.Timestamp = hdr.Timestamp + uint64(s.chain.GetConfig().TimePerBlock(b.Index)/time.Millisecond)
+	return b, nil
+}
+
+// profileToDiag converts VM execution statistics into invocation diagnostics.
+func profileToDiag(p *vm.Profile) *result.InvokeDiag {
+	diag := &result.InvokeDiag{
+		Opcodes:  make([]result.ProfileEntry, 0, len(p.Opcodes)),
+		Syscalls: make([]result.ProfileEntry, 0, len(p.Syscalls)),
+	}
+	for op, e := range p.Opcodes {
+		diag.Opcodes = append(diag.Opcodes, newProfileEntry(op.String(), e))
+	}
+	for id, e := range p.Syscalls {
+		name, err := interopnames.FromID(id)
+		if err != nil {
+			name = fmt.Sprintf("0x%08x", id)
+		}
+		diag.Syscalls = append(diag.Syscalls, newProfileEntry(name, e))
+	}
+	sortProfileEntries(diag.Opcodes)
+	sortProfileEntries(diag.Syscalls)
+	return diag
+}
+
+func newProfileEntry(name string, e *vm.ProfileEntry) result.ProfileEntry {
+	return result.ProfileEntry{
+		Name:        name,
+		Count:       e.Count,
+		GasConsumed: e.GasConsumed,
+		Time:        e.Time,
+	}
+}
+
+func sortProfileEntries(es []result.ProfileEntry) {
+	sort.Slice(es, func(i, j int) bool {
+		if es[i].GasConsumed != es[j].GasConsumed {
+			return es[i].GasConsumed > es[j].GasConsumed
+		}
+		return es[i].Name < es[j].Name
+	})
+}
+
 // submitBlock broadcasts a raw block over the NEO network.
 func (s *Server) submitBlock(reqParams request.Params) (interface{}, *response.Error) {
 	blockBytes, err := reqParams.ValueWithType(0, request.StringT).GetBytesBase64()
@@ -1471,9 +2439,20 @@ func (s *Server) subscribe(reqParams request.Params, sub *subscriber) (interface
 			if p.Type != request.ExecutionFilterT {
 				return nil, response.ErrInvalidParams
 			}
+		case response.StorageChangesEventID:
+			if p.Type != request.StorageFilterT {
+				return nil, response.ErrInvalidParams
+			}
+		case response.NotaryRequestEventID:
+			if p.Type != request.TxFilterT {
+				return nil, response.ErrInvalidParams
+			}
 		}
 		filter = p.Value
 	}
+	if event == response.NotaryRequestEventID && s.getNotaryPool() == nil {
+		return nil, response.NewInvalidRequestError("'notary_request_event' subscription is not supported", errP2PSigExtensionsDisabled)
+	}
 
 	s.subsLock.Lock()
 	defer s.subsLock.Unlock()
@@ -1522,9 +2501,38 @@ func (s *Server) subscribeToChannel(event response.EventID) {
 			s.chain.SubscribeForExecutions(s.executionCh)
 		}
 		s.executionSubs++
+	case response.StorageChangesEventID:
+		if s.storageSubs == 0 {
+			s.chain.SubscribeForStorageChanges(s.storageCh)
+		}
+		s.storageSubs++
+	case response.NotaryRequestEventID:
+		if s.notaryRequestSubs == 0 {
+			s.getNotaryPool().SubscribeForTransactions(s.notaryRequestCh)
+		}
+		s.notaryRequestSubs++
 	}
 }
 
+// prepareNotification marshals notification into websocket message.
+func (s *Server) prepareNotification(resp *response.Notification) (*websocket.PreparedMessage, error) {
+	b, err := json.Marshal(resp)
+	if err != nil {
+		s.log.Error("failed to marshal notification",
+			zap.Error(err),
+			zap.String("type", resp.Event.String()))
+		return nil, err
+	}
+	msg, err := websocket.NewPreparedMessage(websocket.TextMessage, b)
+	if err != nil {
+		s.log.Error("failed to prepare notification message",
+			zap.Error(err),
+			zap.String("type", resp.Event.String()))
+		return nil, err
+	}
+	return msg, nil
+}
+
 // unsubscribe handles unsubscription requests from websocket clients.
 func (s *Server) unsubscribe(reqParams request.Params, sub *subscriber) (interface{}, *response.Error) {
 	id, err := reqParams.Value(0).GetInt()
@@ -1568,6 +2576,16 @@ func (s *Server) unsubscribeFromChannel(event response.EventID) {
 		if s.executionSubs == 0 {
 			s.chain.UnsubscribeFromExecutions(s.executionCh)
 		}
+	case response.StorageChangesEventID:
+		s.storageSubs--
+		if s.storageSubs == 0 {
+			s.chain.UnsubscribeFromStorageChanges(s.storageCh)
+		}
+	case response.NotaryRequestEventID:
+		s.notaryRequestSubs--
+		if s.notaryRequestSubs == 0 {
+			s.getNotaryPool().UnsubscribeFromTransactions(s.notaryRequestCh)
+		}
 	}
 }
 
@@ -1608,6 +2626,15 @@ chloop:
 		case tx := <-s.transactionCh:
 			resp.Event = response.TransactionEventID
 			resp.Payload[0] = tx
+		case changes := <-s.storageCh:
+			resp.Event = response.StorageChangesEventID
+			resp.Payload[0] = changes
+		case e := <-s.notaryRequestCh:
+			resp.Event = response.NotaryRequestEventID
+			resp.Payload[0] = &result.NotaryRequestEvent{
+				Type:          e.Type,
+				NotaryRequest: e.Data.(*payload.P2PNotaryRequest),
+			}
 		}
 		s.subsLock.RLock()
 	subloop:
@@ -1617,24 +2644,24 @@ chloop:
 			}
 			for i := range sub.feeds {
 				if sub.feeds[i].Matches(&resp) {
-					if msg == nil {
-						b, err = json.Marshal(resp)
+					var subMsg *websocket.PreparedMessage
+					if filtered := sub.feeds[i].FilterPayload(&resp); filtered != nil {
+						// Filtered payload is specific to this feed.
+						subMsg, err = s.prepareNotification(filtered)
 						if err != nil {
-							s.log.Error("failed to marshal notification",
-								zap.Error(err),
-								zap.String("type", resp.Event.String()))
 							break subloop
 						}
-						msg, err = websocket.NewPreparedMessage(websocket.TextMessage, b)
-						if err != nil {
-							s.log.Error("failed to prepare notification message",
-								zap.Error(err),
-								zap.String("type", resp.Event.String()))
-							break subloop
+					} else {
+						if msg == nil {
+							msg, err = s.prepareNotification(&resp)
+							if err != nil {
+								break subloop
+							}
 						}
+						subMsg = msg
 					}
 					select {
-					case sub.writer <- msg:
+					case sub.writer <- subMsg:
 					default:
 						sub.overflown.Store(true)
 						// MissedEvent is to be delivered eventually.
@@ -1660,6 +2687,10 @@ chloop:
 	s.chain.UnsubscribeFromTransactions(s.transactionCh)
 	s.chain.UnsubscribeFromNotifications(s.notificationCh)
 	s.chain.UnsubscribeFromExecutions(s.executionCh)
+	s.chain.UnsubscribeFromStorageChanges(s.storageCh)
+	if pool := s.getNotaryPool(); pool != nil {
+		pool.UnsubscribeFromTransactions(s.notaryRequestCh)
+	}
 	s.subsLock.Unlock()
 drainloop:
 	for {
@@ -1667,6 +2698,8 @@ drainloop:
 		case <-s.blockCh:
 		case <-s.executionCh:
 		case <-s.notificationCh:
+		case <-s.notaryRequestCh:
+		case <-s.storageCh:
 		case <-s.transactionCh:
 		default:
 			break drainloop
@@ -1678,6 +2711,8 @@ drainloop:
 	close(s.transactionCh)
 	close(s.notificationCh)
 	close(s.executionCh)
+	close(s.notaryRequestCh)
+	close(s.storageCh)
 }
 
 func (s *Server) blockHeightFromParam(param *request.Param) (int, *response.Error) {
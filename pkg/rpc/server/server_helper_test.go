@@ -36,6 +36,10 @@ func getUnitTestChain(t *testing.T, enableOracle bool, enableNotary bool) (*core
 
 	memoryStore := storage.NewMemoryStore()
 	logger := zaptest.NewLogger(t)
+	cfg.ProtocolConfiguration.SaveStorageChanges = true
+	cfg.ProtocolConfiguration.SaveTransactionTraces = true
+	cfg.ProtocolConfiguration.NotificationsIndex = true
+	cfg.ProtocolConfiguration.AddressHistoryIndex = true
 	if enableNotary {
 		cfg.ProtocolConfiguration.P2PSigExtensions = true
 		cfg.ProtocolConfiguration.P2PNotaryRequestPayloadPoolSize = 1000
@@ -3,7 +3,9 @@ package server
 import (
 	"bytes"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"math/big"
@@ -17,17 +19,21 @@ import (
 	"time"
 
 	"github.com/gorilla/websocket"
+	"github.com/nspcc-dev/neo-go/internal/fakechain"
 	"github.com/nspcc-dev/neo-go/internal/testchain"
 	"github.com/nspcc-dev/neo-go/internal/testserdes"
 	"github.com/nspcc-dev/neo-go/pkg/core"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/fee"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
 	rpc2 "github.com/nspcc-dev/neo-go/pkg/services/oracle/broadcaster"
@@ -36,6 +42,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/replay"
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -70,6 +77,87 @@ const verifyWithArgsContractAVM = "VwIDeAwLZ29vZF9zdHJpbmeXJA15FSgJehHbIJciBRHbI
 const invokescriptContractAVM = "VwcADBQBDAMOBQYMDQIODw0DDgcJAAAAANswcGhB+CfsjCGqJgQRQAwUDQ8DAgkAAgEDBwMEBQIBAA4GDAnbMHFpQfgn7IwhqiYEEkATQA=="
 
 var rpcTestCases = map[string][]rpcTestCase{
+	"getaddresshistory": {
+		{
+			name:   "positive",
+			params: `["` + testchain.MultisigAddress() + `"]`,
+			result: func(e *executor) interface{} { return &result.AddressHistory{} },
+			check: func(t *testing.T, e *executor, res interface{}) {
+				h, ok := res.(*result.AddressHistory)
+				require.True(t, ok)
+				require.Equal(t, testchain.MultisigAddress(), h.Address)
+				require.Equal(t, 0, h.Page)
+
+				var expected []state.AddressTransaction
+				require.NoError(t, e.chain.ForEachAddressTransaction(testchain.MultisigScriptHash(), func(tx *state.AddressTransaction) (bool, error) {
+					expected = append(expected, *tx)
+					return true, nil
+				}))
+				require.NotEmpty(t, expected)
+				require.Equal(t, len(expected) > 100, h.More)
+				if len(expected) > 100 {
+					expected = expected[:100]
+				}
+				require.Equal(t, len(expected), len(h.Transactions))
+				for i := range expected {
+					require.Equal(t, expected[i].Tx, h.Transactions[i].TxHash)
+					require.Equal(t, expected[i].Block, h.Transactions[i].Index)
+				}
+
+				txHash, err := util.Uint256DecodeStringLE(deploymentTxHash)
+				require.NoError(t, err)
+				tx, height, err := e.chain.GetTransaction(txHash)
+				require.NoError(t, err)
+				require.Equal(t, testchain.MultisigScriptHash(), tx.Sender())
+				var found bool
+				for _, atx := range h.Transactions {
+					if atx.TxHash == txHash {
+						found = true
+						require.True(t, atx.Sender)
+						require.Equal(t, height, atx.Index)
+					}
+				}
+				require.True(t, found)
+			},
+		},
+		{
+			name:   "positive, unknown account, big page",
+			params: `["` + util.Uint160{1, 2, 3}.StringLE() + `", 1000]`,
+			result: func(e *executor) interface{} { return &result.AddressHistory{} },
+			check: func(t *testing.T, e *executor, res interface{}) {
+				h, ok := res.(*result.AddressHistory)
+				require.True(t, ok)
+				require.Equal(t, 1000, h.Page)
+				require.Equal(t, 0, len(h.Transactions))
+				require.False(t, h.More)
+			},
+		},
+		{
+			name:   "too big page",
+			params: `["` + util.Uint160{1, 2, 3}.StringLE() + `", 1001]`,
+			fail:   true,
+		},
+		{
+			name:   "no params",
+			params: `[]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid address",
+			params: `["notanaddress"]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid page",
+			params: `["` + testchain.MultisigAddress() + `", "page"]`,
+			fail:   true,
+		},
+		{
+			name:   "negative page",
+			params: `["` + testchain.MultisigAddress() + `", -1]`,
+			fail:   true,
+		},
+	},
 	"getapplicationlog": {
 		{
 			name:   "positive",
@@ -215,6 +303,47 @@ var rpcTestCases = map[string][]rpcTestCase{
 		},
 	},
 
+	"getnep11balances": {
+		{
+			name:   "no params",
+			params: `[]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid address",
+			params: `["notahex"]`,
+			fail:   true,
+		},
+		{
+			name:   "positive",
+			params: `["` + testchain.PrivateKeyByID(0).Address() + `"]`,
+			result: func(e *executor) interface{} { return &result.NEP11Balances{} },
+			check:  checkNep11Balances,
+		},
+	},
+	"getnep11transfers": {
+		{
+			name:   "no params",
+			params: `[]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid address",
+			params: `["notahex"]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid limit",
+			params: `["` + testchain.PrivateKeyByID(0).Address() + `", "1", "2", "0"]`,
+			fail:   true,
+		},
+		{
+			name:   "positive",
+			params: `["` + testchain.PrivateKeyByID(0).Address() + `", 0]`,
+			result: func(e *executor) interface{} { return &result.NEP11Transfers{} },
+			check:  checkNep11Transfers,
+		},
+	},
 	"getnep17balances": {
 		{
 			name:   "no params",
@@ -285,6 +414,11 @@ var rpcTestCases = map[string][]rpcTestCase{
 			params: `["` + testchain.PrivateKeyByID(0).Address() + `", "1", "2", "3", "jajaja"]`,
 			fail:   true,
 		},
+		{
+			name:   "invalid cursor",
+			params: `["` + testchain.PrivateKeyByID(0).Address() + `", "1", "2", "3", "AAAA"]`,
+			fail:   true,
+		},
 		{
 			name:   "positive",
 			params: `["` + testchain.PrivateKeyByID(0).Address() + `", 0]`,
@@ -298,6 +432,154 @@ var rpcTestCases = map[string][]rpcTestCase{
 			check:  checkNep17Transfers,
 		},
 	},
+	"getnotifications": {
+		{
+			name:   "positive, all",
+			params: `["GasToken"]`,
+			result: func(e *executor) interface{} { return &result.ContractNotifications{} },
+			check: func(t *testing.T, e *executor, res interface{}) {
+				ns, ok := res.(*result.ContractNotifications)
+				require.True(t, ok)
+				gas := e.chain.UtilityTokenHash()
+				require.Equal(t, gas, ns.Contract)
+				var count int
+				require.NoError(t, e.chain.ForEachNotification(gas, "", e.chain.BlockHeight(), func(*state.IndexedNotification) (bool, error) {
+					count++
+					return true, nil
+				}))
+				require.Equal(t, count, len(ns.Notifications))
+				require.True(t, count > 1)
+				for i, n := range ns.Notifications {
+					require.Equal(t, gas, n.ScriptHash)
+					if i > 0 {
+						require.True(t, n.Block <= ns.Notifications[i-1].Block)
+					}
+				}
+			},
+		},
+		{
+			name:   "positive, event name, block range and limit",
+			params: `["` + testContractHash + `", "Transfer", 0, 100500, 1]`,
+			result: func(e *executor) interface{} { return &result.ContractNotifications{} },
+			check: func(t *testing.T, e *executor, res interface{}) {
+				ns, ok := res.(*result.ContractNotifications)
+				require.True(t, ok)
+				require.Equal(t, 1, len(ns.Notifications))
+				n := ns.Notifications[0]
+				require.Equal(t, "Transfer", n.Name)
+				_, height, err := e.chain.GetTransaction(n.Container)
+				require.NoError(t, err)
+				require.Equal(t, height, n.Block)
+				aers, err := e.chain.GetAppExecResults(n.Container, trigger.Application)
+				require.NoError(t, err)
+				require.Equal(t, 1, len(aers))
+				var found bool
+				for _, ev := range aers[0].Events {
+					found = found || (ev.ScriptHash == n.ScriptHash && ev.Name == n.Name)
+				}
+				require.True(t, found)
+			},
+		},
+		{
+			name:   "positive, second page",
+			params: `["` + testContractHash + `", "", 0, 100500, 1, 1]`,
+			result: func(e *executor) interface{} { return &result.ContractNotifications{} },
+			check: func(t *testing.T, e *executor, res interface{}) {
+				ns, ok := res.(*result.ContractNotifications)
+				require.True(t, ok)
+				h, err := util.Uint160DecodeStringLE(testContractHash)
+				require.NoError(t, err)
+				var expected []state.IndexedNotification
+				require.NoError(t, e.chain.ForEachNotification(h, "", e.chain.BlockHeight(), func(n *state.IndexedNotification) (bool, error) {
+					expected = append(expected, *n)
+					return len(expected) < 2, nil
+				}))
+				require.Equal(t, 2, len(expected))
+				require.Equal(t, 1, len(ns.Notifications))
+				require.Equal(t, expected[1].Container, ns.Notifications[0].Container)
+				require.Equal(t, expected[1].Name, ns.Notifications[0].Name)
+			},
+		},
+		{
+			name:   "positive, cursor",
+			params: `["GasToken", "", 0, 100500, 1, "` + notificationsCursor{block: 1, skip: 0}.String() + `"]`,
+			result: func(e *executor) interface{} { return &result.ContractNotifications{} },
+			check: func(t *testing.T, e *executor, res interface{}) {
+				ns, ok := res.(*result.ContractNotifications)
+				require.True(t, ok)
+				gas := e.chain.UtilityTokenHash()
+				var expected []state.IndexedNotification
+				require.NoError(t, e.chain.ForEachNotification(gas, "", 1, func(n *state.IndexedNotification) (bool, error) {
+					expected = append(expected, *n)
+					return len(expected) < 2, nil
+				}))
+				require.Equal(t, 2, len(expected))
+				require.Equal(t, 1, len(ns.Notifications))
+				require.Equal(t, expected[0].Container, ns.Notifications[0].Container)
+				require.Equal(t, expected[0].Name, ns.Notifications[0].Name)
+				next := notificationsCursor{block: expected[1].Block}
+				if expected[1].Block == expected[0].Block {
+					next.skip = 1
+				}
+				require.Equal(t, next.String(), ns.Next)
+			},
+		},
+		{
+			name:   "unknown event",
+			params: `["GasToken", "Unknown"]`,
+			result: func(e *executor) interface{} { return &result.ContractNotifications{} },
+			check: func(t *testing.T, e *executor, res interface{}) {
+				ns, ok := res.(*result.ContractNotifications)
+				require.True(t, ok)
+				require.Equal(t, 0, len(ns.Notifications))
+			},
+		},
+		{
+			name:   "no params",
+			params: `[]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid event name",
+			params: `["GasToken", 1]`,
+			fail:   true,
+		},
+		{
+			name:   "negative block",
+			params: `["GasToken", "", -1]`,
+			fail:   true,
+		},
+		{
+			name:   "zero limit",
+			params: `["GasToken", "", 0, 10, 0]`,
+			fail:   true,
+		},
+		{
+			name:   "too big limit",
+			params: `["GasToken", "", 0, 10, 1001]`,
+			fail:   true,
+		},
+		{
+			name:   "negative page",
+			params: `["GasToken", "", 0, 10, 10, -1]`,
+			fail:   true,
+		},
+		{
+			name:   "too big page",
+			params: `["GasToken", "", 0, 10, 10, 101]`,
+			fail:   true,
+		},
+		{
+			name:   "too big block",
+			params: `["GasToken", "", 0, 4294967296]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid cursor",
+			params: `["GasToken", "", 0, 10, 10, "AQID"]`,
+			fail:   true,
+		},
+	},
 	"getproof": {
 		{
 			name:   "no params",
@@ -384,6 +666,44 @@ var rpcTestCases = map[string][]rpcTestCase{
 			fail:   true,
 		},
 	},
+	"getstoragehistoric": {
+		{
+			name:   "positive",
+			params: fmt.Sprintf(`["%s", "dGVzdGtleQ==", 3]`, testContractHash),
+			result: func(e *executor) interface{} {
+				v := base64.StdEncoding.EncodeToString([]byte("testvalue"))
+				return &v
+			},
+		},
+		{
+			name:   "before put",
+			params: fmt.Sprintf(`["%s", "dGVzdGtleQ==", 2]`, testContractHash),
+			result: func(e *executor) interface{} {
+				v := ""
+				return &v
+			},
+		},
+		{
+			name:   "no block index",
+			params: fmt.Sprintf(`["%s", "dGVzdGtleQ=="]`, testContractHash),
+			fail:   true,
+		},
+		{
+			name:   "bad block index",
+			params: fmt.Sprintf(`["%s", "dGVzdGtleQ==", 100500]`, testContractHash),
+			fail:   true,
+		},
+		{
+			name:   "invalid key",
+			params: fmt.Sprintf(`["%s", "notabase64$", 3]`, testContractHash),
+			fail:   true,
+		},
+		{
+			name:   "invalid hash",
+			params: `["notahex", "dGVzdGtleQ==", 3]`,
+			fail:   true,
+		},
+	},
 	"getbestblockhash": {
 		{
 			params: "[]",
@@ -506,6 +826,97 @@ var rpcTestCases = map[string][]rpcTestCase{
 			},
 		},
 	},
+	"compactstorage": {
+		{
+			name:   "admin methods disabled",
+			params: "[]",
+			fail:   true,
+		},
+	},
+	"verifysource": {
+		{
+			name:   "source verification disabled",
+			params: `["` + testContractHash + `", "cGFja2FnZSBmb28="]`,
+			fail:   true,
+		},
+	},
+	"estimatefees": {
+		{
+			name:   "positive",
+			params: "[]",
+			result: func(e *executor) interface{} { return &result.FeeEstimation{} },
+			check: func(t *testing.T, e *executor, res interface{}) {
+				fees, ok := res.(*result.FeeEstimation)
+				require.True(t, ok)
+				require.Equal(t, e.chain.FeePerByte(), fees.Minimum)
+				require.Equal(t, defaultFeeBlocks, fees.Blocks)
+				require.True(t, fees.Low >= fees.Minimum)
+				require.True(t, fees.Medium >= fees.Low)
+				require.True(t, fees.High >= fees.Medium)
+			},
+		},
+		{
+			name:   "positive, block count",
+			params: "[3]",
+			result: func(e *executor) interface{} { return &result.FeeEstimation{} },
+			check: func(t *testing.T, e *executor, res interface{}) {
+				fees, ok := res.(*result.FeeEstimation)
+				require.True(t, ok)
+				require.Equal(t, 3, fees.Blocks)
+			},
+		},
+		{
+			name:   "zero blocks",
+			params: "[0]",
+			fail:   true,
+		},
+		{
+			name:   "too many blocks",
+			params: "[101]",
+			fail:   true,
+		},
+		{
+			name:   "invalid block count",
+			params: `["one"]`,
+			fail:   true,
+		},
+	},
+	"getstoragechanges": {
+		{
+			name:   "positive",
+			params: "[1]",
+			result: func(e *executor) interface{} { return &state.StorageChanges{} },
+			check: func(t *testing.T, e *executor, res interface{}) {
+				changes, ok := res.(*state.StorageChanges)
+				require.True(t, ok)
+				expected, err := e.chain.GetStorageChanges(1)
+				require.NoError(t, err)
+				require.Equal(t, expected, changes)
+				require.Equal(t, uint32(1), changes.Block)
+				require.NotEmpty(t, changes.Storage)
+			},
+		},
+		{
+			name:   "no params",
+			params: `[]`,
+			fail:   true,
+		},
+		{
+			name:   "string height",
+			params: `["first"]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid number height",
+			params: `[-2]`,
+			fail:   true,
+		},
+		{
+			name:   "future block",
+			params: `[100500]`,
+			fail:   true,
+		},
+	},
 	"getblocksysfee": {
 		{
 			name:   "positive",
@@ -556,6 +967,13 @@ var rpcTestCases = map[string][]rpcTestCase{
 			},
 		},
 	},
+	"getconsensusstate": {
+		{
+			name:   "admin methods disabled",
+			params: "[]",
+			fail:   true,
+		},
+	},
 	"getnativecontracts": {
 		{
 			params: "[]",
@@ -584,6 +1002,17 @@ var rpcTestCases = map[string][]rpcTestCase{
 				}
 			},
 		},
+		{
+			name:   "verbose",
+			params: "[true]",
+			result: func(*executor) interface{} {
+				return &result.GetPeers{
+					Unconnected: []result.Peer{},
+					Connected:   []result.Peer{},
+					Bad:         []result.Peer{},
+				}
+			},
+		},
 	},
 	"getrawtransaction": {
 		{
@@ -632,6 +1061,48 @@ var rpcTestCases = map[string][]rpcTestCase{
 			fail:   true,
 		},
 	},
+	"gettransactiontrace": {
+		{
+			name:   "positive",
+			params: `["` + deploymentTxHash + `"]`,
+			result: func(e *executor) interface{} { return &state.TransactionTrace{} },
+			check: func(t *testing.T, e *executor, resp interface{}) {
+				trace, ok := resp.(*state.TransactionTrace)
+				require.True(t, ok)
+				h, err := util.Uint256DecodeStringLE(deploymentTxHash)
+				require.NoError(t, err)
+				expected, err := e.chain.GetTransactionTrace(h)
+				require.NoError(t, err)
+				require.Equal(t, h, trace.Container)
+				require.Equal(t, vm.HaltState, trace.VMState)
+				require.Equal(t, expected.GasConsumed, trace.GasConsumed)
+				require.Equal(t, expected.Storage, trace.Storage)
+				require.Equal(t, 1, len(trace.Call.Calls))
+				deploy := trace.Call.Calls[0]
+				require.Equal(t, e.chain.ManagementContractHash(), deploy.Contract)
+				require.Equal(t, "deploy", deploy.Method)
+				require.True(t, deploy.GasConsumed > 0)
+				require.True(t, deploy.GasConsumed < trace.GasConsumed)
+				require.NotEmpty(t, deploy.Notifications)
+				require.NotEmpty(t, trace.Storage)
+			},
+		},
+		{
+			name:   "no params",
+			params: `[]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid hash",
+			params: `["notahex"]`,
+			fail:   true,
+		},
+		{
+			name:   "missing hash",
+			params: `["` + util.Uint256{}.String() + `"]`,
+			fail:   true,
+		},
+	},
 	"getunclaimedgas": {
 		{
 			name:   "no params",
@@ -697,6 +1168,49 @@ var rpcTestCases = map[string][]rpcTestCase{
 			},
 		},
 	},
+	"getvotingstate": {
+		{
+			name:   "no voter",
+			params: "[]",
+			result: func(*executor) interface{} { return &result.VotingState{} },
+			check: func(t *testing.T, e *executor, vs interface{}) {
+				res, ok := vs.(*result.VotingState)
+				require.True(t, ok)
+				// it's a test chain without candidates, so standby committee is used
+				committee := e.chain.GetStandByCommittee()
+				sort.Sort(committee)
+				validators := e.chain.GetStandByValidators()
+				sort.Sort(validators)
+				size := uint32(len(committee))
+				require.Equal(t, e.chain.BlockHeight(), res.Height)
+				require.Equal(t, (res.Height/size+1)*size, res.NextCommitteeUpdate)
+				require.Equal(t, committee, res.Committee)
+				require.Equal(t, validators, res.NextValidators)
+				require.Equal(t, 0, len(res.Candidates))
+				require.Nil(t, res.Voter)
+			},
+		},
+		{
+			name:   "voter",
+			params: `["` + testchain.MultisigAddress() + `"]`,
+			result: func(*executor) interface{} { return &result.VotingState{} },
+			check: func(t *testing.T, e *executor, vs interface{}) {
+				res, ok := vs.(*result.VotingState)
+				require.True(t, ok)
+				neo, _ := e.chain.GetGoverningTokenBalance(testchain.MultisigScriptHash())
+				require.Equal(t, &result.VoterState{
+					Address:   testchain.MultisigScriptHash(),
+					Balance:   neo.Int64(),
+					Unclaimed: 7000,
+				}, res.Voter)
+			},
+		},
+		{
+			name:   "invalid address",
+			params: `["invalid"]`,
+			fail:   true,
+		},
+	},
 	"invokefunction": {
 		{
 			name:   "positive",
@@ -708,6 +1222,58 @@ var rpcTestCases = map[string][]rpcTestCase{
 				assert.NotNil(t, res.Script)
 				assert.NotEqual(t, "", res.State)
 				assert.NotEqual(t, 0, res.GasConsumed)
+				assert.Nil(t, res.Diagnostics)
+			},
+		},
+		{
+			name:   "positive, with diagnostics",
+			params: `["50befd26fdf6e4d957c11e078b24ebce6291456f", "test", [], [], true]`,
+			result: func(e *executor) interface{} { return &result.Invoke{} },
+			check: func(t *testing.T, e *executor, inv interface{}) {
+				res, ok := inv.(*result.Invoke)
+				require.True(t, ok)
+				require.NotNil(t, res.Diagnostics)
+				require.NotEqual(t, 0, len(res.Diagnostics.Opcodes))
+				var gas int64
+				for i, op := range res.Diagnostics.Opcodes {
+					if i > 0 {
+						require.True(t, res.Diagnostics.Opcodes[i-1].GasConsumed >= op.GasConsumed)
+					}
+					require.NotEqual(t, 0, op.Count)
+					gas += op.GasConsumed
+				}
+				require.Equal(t, res.GasConsumed, gas)
+
+				require.NotNil(t, res.Diagnostics.Record)
+				v := vm.New()
+				v.GasLimit = -1
+				replay.Load(v, res.Diagnostics.Record)
+				err := v.Run()
+				require.Equal(t, res.State, v.State().String())
+				if res.FaultException != "" {
+					require.Error(t, err)
+					require.Equal(t, res.FaultException, err.Error())
+				}
+				require.Equal(t, len(res.Stack), v.Estack().Len())
+			},
+		},
+		{
+			name:   "positive, with diagnostics, storage changes",
+			params: fmt.Sprintf(`["%s", "putValue", [{"type": "ByteArray", "value": "dGVzdGtleQ=="}, {"type": "ByteArray", "value": "bmV3"}], [], true]`, testContractHash),
+			result: func(e *executor) interface{} { return &result.Invoke{} },
+			check: func(t *testing.T, e *executor, inv interface{}) {
+				res, ok := inv.(*result.Invoke)
+				require.True(t, ok)
+				require.Equal(t, "HALT", res.State, res.FaultException)
+				require.NotNil(t, res.Diagnostics)
+				h, err := util.Uint160DecodeStringLE(testContractHash)
+				require.NoError(t, err)
+				require.Equal(t, []state.StorageDiff{{
+					Contract: h,
+					Key:      []byte("testkey"),
+					Old:      []byte("testvalue"),
+					New:      []byte("new"),
+				}}, res.Diagnostics.Storage)
 			},
 		},
 		{
@@ -744,6 +1310,18 @@ var rpcTestCases = map[string][]rpcTestCase{
 				assert.NotEqual(t, 0, res.GasConsumed)
 			},
 		},
+		{
+			name:   "positive, with diagnostics",
+			params: `["UcVrDUhlbGxvLCB3b3JsZCFoD05lby5SdW50aW1lLkxvZ2FsdWY=", [], true]`,
+			result: func(e *executor) interface{} { return &result.Invoke{} },
+			check: func(t *testing.T, e *executor, inv interface{}) {
+				res, ok := inv.(*result.Invoke)
+				require.True(t, ok)
+				require.NotNil(t, res.Diagnostics)
+				require.NotEqual(t, 0, len(res.Diagnostics.Opcodes))
+				require.NotNil(t, res.Diagnostics.Syscalls)
+			},
+		},
 		{
 			name: "positive, good witness",
 			// script is base64-encoded `invokescript_contract.avm` representation, hashes are hex-encoded LE bytes of hashes used in the contract with `0x` prefix
@@ -809,6 +1387,94 @@ var rpcTestCases = map[string][]rpcTestCase{
 			fail:   true,
 		},
 	},
+	"simulate": {
+		{
+			name:   "positive, witness overrides",
+			params: fmt.Sprintf(`["%s",{"witnesses":["0x0000000009070e030d0f0e020d0c06050e030c01","0x090c060e00010205040307030102000902030f0d"]}]`, invokescriptContractAVM),
+			result: func(e *executor) interface{} { return &result.Invoke{} },
+			check: func(t *testing.T, e *executor, inv interface{}) {
+				res, ok := inv.(*result.Invoke)
+				require.True(t, ok)
+				assert.Equal(t, "HALT", res.State)
+				require.Equal(t, 1, len(res.Stack))
+				require.Equal(t, big.NewInt(3), res.Stack[0].Value())
+			},
+		},
+		{
+			name:   "positive, no overrides",
+			params: fmt.Sprintf(`["%s",null]`, invokescriptContractAVM),
+			result: func(e *executor) interface{} { return &result.Invoke{} },
+			check: func(t *testing.T, e *executor, inv interface{}) {
+				res, ok := inv.(*result.Invoke)
+				require.True(t, ok)
+				assert.Equal(t, "HALT", res.State)
+				require.Equal(t, 1, len(res.Stack))
+				require.Equal(t, big.NewInt(1), res.Stack[0].Value())
+			},
+		},
+		{
+			name:   "positive, GAS limit",
+			params: fmt.Sprintf(`["%s",null,[],1]`, invokescriptContractAVM),
+			result: func(e *executor) interface{} { return &result.Invoke{} },
+			check: func(t *testing.T, e *executor, inv interface{}) {
+				res, ok := inv.(*result.Invoke)
+				require.True(t, ok)
+				assert.Equal(t, "FAULT", res.State)
+			},
+		},
+		{
+			name:   "no overrides",
+			params: fmt.Sprintf(`["%s"]`, invokescriptContractAVM),
+			fail:   true,
+		},
+		{
+			name:   "invalid script",
+			params: `["notabase64%",null]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid overrides",
+			params: fmt.Sprintf(`["%s","overrides"]`, invokescriptContractAVM),
+			fail:   true,
+		},
+		{
+			name:   "unknown storage override contract",
+			params: fmt.Sprintf(`["%s",{"storage":[{"contract":"0x0000000009070e030d0f0e020d0c06050e030c01","key":"AQI=","value":"AQI="}]}]`, invokescriptContractAVM),
+			fail:   true,
+		},
+		{
+			name:   "invalid GAS limit",
+			params: fmt.Sprintf(`["%s",null,[],0]`, invokescriptContractAVM),
+			fail:   true,
+		},
+	},
+	"simulatetransactions": {
+		{
+			name:   "no params",
+			params: `[]`,
+			fail:   true,
+		},
+		{
+			name:   "not an array",
+			params: `["AAAA"]`,
+			fail:   true,
+		},
+		{
+			name:   "no transactions",
+			params: `[[]]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid base64",
+			params: `[["notabase64%"]]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid transaction",
+			params: `[["AAAA"]]`,
+			fail:   true,
+		},
+	},
 	"invokecontractverify": {
 		{
 			name:   "positive",
@@ -1013,6 +1679,70 @@ func TestRPC(t *testing.T) {
 	})
 }
 
+func TestGetConsensusState(t *testing.T) {
+	chain, rpcSrv, httpSrv := initClearServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	req := `{"jsonrpc": "2.0", "id": 1, "method": "getconsensusstate", "params": []}`
+	check := func(t *testing.T, data string) {
+		var resp response.Raw
+		require.NoError(t, json.Unmarshal(doRPCCallOverHTTP(req, httpSrv.URL, t), &resp))
+		require.NotNil(t, resp.Error)
+		require.Equal(t, int64(-32600), resp.Error.Code)
+		require.Equal(t, data, resp.Error.Data)
+	}
+	t.Run("admin methods disabled", func(t *testing.T) {
+		check(t, "admin methods are disabled")
+	})
+	t.Run("not a consensus node", func(t *testing.T) {
+		rpcSrv.config.EnableAdminMethods = true
+		defer func() { rpcSrv.config.EnableAdminMethods = false }()
+		check(t, "not a consensus node")
+	})
+}
+
+func TestGetStorageHistoricPruned(t *testing.T) {
+	chain := fakechain.NewFakeChain()
+	chain.KeepOnlyLatestState = true
+	chain.StateRetentionBlocks = 5
+	chain.Blockheight = 10
+	s := &Server{chain: chain}
+
+	params := request.Params{
+		{Type: request.NumberT, Value: 1},
+		{Type: request.StringT, Value: "dGVzdGtleQ=="},
+		{Type: request.NumberT, Value: 4},
+	}
+	_, respErr := s.getStorageHistoric(params)
+	require.NotNil(t, respErr)
+	require.Equal(t, response.ErrInvalidParams.Code, respErr.Code)
+	require.True(t, s.isStatePruned(4))
+	require.False(t, s.isStatePruned(5))
+}
+
+func TestGetStorageChangesErrors(t *testing.T) {
+	chain := fakechain.NewFakeChain()
+	chain.SaveStorageChanges = true
+	chain.Blockheight = 10
+	s := &Server{chain: chain}
+	params := request.Params{{Type: request.NumberT, Value: 5}}
+
+	chain.StorageChangesF = func(uint32) (*state.StorageChanges, error) {
+		return nil, storage.ErrKeyNotFound
+	}
+	_, respErr := s.getStorageChanges(params)
+	require.NotNil(t, respErr)
+	require.Equal(t, "Unknown storage changes", respErr.Message)
+
+	chain.StorageChangesF = func(uint32) (*state.StorageChanges, error) {
+		return nil, errors.New("corrupted")
+	}
+	_, respErr = s.getStorageChanges(params)
+	require.NotNil(t, respErr)
+	require.Equal(t, response.NewInternalServerError("", nil).Code, respErr.Code)
+}
+
 func TestSubmitOracle(t *testing.T) {
 	chain, rpcSrv, httpSrv := initClearServerWithServices(t, true, false)
 	defer chain.Close()
@@ -1272,6 +2002,23 @@ func testRPCProtocol(t *testing.T, doRPCCall func(string, string, *testing.T) []
 		}
 	})
 
+	t.Run("batch size limit", func(t *testing.T) {
+		makeBatch := func(n int) string {
+			reqs := make([]string, n)
+			for i := range reqs {
+				reqs[i] = fmt.Sprintf(`{"jsonrpc": "2.0", "id": %d, "method": "getblockcount", "params": []}`, i)
+			}
+			return "[" + strings.Join(reqs, ",") + "]"
+		}
+		body := doRPCCall(makeBatch(request.DefaultMaxBatchSize), httpSrv.URL, t)
+		var responses []response.Raw
+		require.NoError(t, json.Unmarshal(body, &responses))
+		require.Equal(t, request.DefaultMaxBatchSize, len(responses))
+
+		body = doRPCCall(makeBatch(request.DefaultMaxBatchSize+1), httpSrv.URL, t)
+		checkErrGetResult(t, body, true)
+	})
+
 	t.Run("getapplicationlog for block", func(t *testing.T) {
 		rpc := `{"jsonrpc": "2.0", "id": 1, "method": "getapplicationlog", "params": ["%s"]}`
 		body := doRPCCall(fmt.Sprintf(rpc, e.chain.GetHeaderHash(1).StringLE()), httpSrv.URL, t)
@@ -1353,6 +2100,16 @@ func testRPCProtocol(t *testing.T, doRPCCall func(string, string, *testing.T) []
 		vp := new(result.VerifyProof)
 		require.NoError(t, json.Unmarshal(rawRes, vp))
 		require.Equal(t, []byte("testvalue"), vp.Value)
+
+		t.Run("unknown item", func(t *testing.T) {
+			rpc := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "getproof", "params": ["%s", "%s", "%s"]}`,
+				r.Root.StringLE(), testContractHash, base64.StdEncoding.EncodeToString([]byte("missingkey")))
+			body := doRPCCall(rpc, httpSrv.URL, t)
+			var resp response.Raw
+			require.NoError(t, json.Unmarshal(body, &resp))
+			require.NotNil(t, resp.Error)
+			require.Equal(t, "Unknown storage item", resp.Error.Message)
+		})
 	})
 	t.Run("getstateroot", func(t *testing.T) {
 		testRoot := func(t *testing.T, p string) {
@@ -1493,10 +2250,29 @@ func testRPCProtocol(t *testing.T, doRPCCall func(string, string, *testing.T) []
 		require.NoErrorf(t, err, "could not parse response: %s", res)
 
 		assert.ElementsMatch(t, expected, actual)
+
+		t.Run("details", func(t *testing.T) {
+			rpc := `{"jsonrpc": "2.0", "id": 1, "method": "getrawmempool", "params": [true, true]}`
+			body := doRPCCall(rpc, httpSrv.URL, t)
+			res := checkErrGetResult(t, body, false)
+
+			var actual result.RawMempool
+			require.NoErrorf(t, json.Unmarshal(res, &actual), "could not parse response: %s", res)
+			require.Equal(t, chain.BlockHeight(), actual.Height)
+			require.Equal(t, len(actual.Verified), len(actual.Transactions))
+			txes := mp.GetVerifiedTransactions()
+			for i := range actual.Transactions {
+				require.Equal(t, actual.Verified[i], actual.Transactions[i].Hash)
+				require.Equal(t, txes[i].Hash(), actual.Transactions[i].Hash)
+				require.Equal(t, txes[i].FeePerByte(), actual.Transactions[i].FeePerByte)
+				require.Equal(t, txes[i].ValidUntilBlock, actual.Transactions[i].ValidUntilBlock)
+				require.Equal(t, address.Uint160ToString(txes[i].Sender()), actual.Transactions[i].Sender)
+			}
+		})
 	})
 
 	t.Run("getnep17transfers", func(t *testing.T) {
-		testNEP17T := func(t *testing.T, start, stop, limit, page int, sent, rcvd []int) {
+		testNEP17T := func(t *testing.T, start, stop, limit, page int, cursor string, sent, rcvd []int) *result.NEP17Transfers {
 			ps := []string{`"` + testchain.PrivateKeyByID(0).Address() + `"`}
 			if start != 0 {
 				h, err := e.chain.GetHeader(e.chain.GetHeaderHash(start))
@@ -1524,6 +2300,9 @@ func testRPCProtocol(t *testing.T, doRPCCall func(string, string, *testing.T) []
 			if page != 0 {
 				ps = append(ps, strconv.FormatInt(int64(page), 10))
 			}
+			if cursor != "" {
+				ps = append(ps, `"`+cursor+`"`)
+			}
 			p := strings.Join(ps, ", ")
 			rpc := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "getnep17transfers", "params": [%s]}`, p)
 			body := doRPCCall(rpc, httpSrv.URL, t)
@@ -1531,13 +2310,24 @@ func testRPCProtocol(t *testing.T, doRPCCall func(string, string, *testing.T) []
 			actual := new(result.NEP17Transfers)
 			require.NoError(t, json.Unmarshal(res, actual))
 			checkNep17TransfersAux(t, e, actual, sent, rcvd)
+			return actual
 		}
-		t.Run("time frame only", func(t *testing.T) { testNEP17T(t, 4, 5, 0, 0, []int{8, 9, 10, 11}, []int{2, 3}) })
-		t.Run("no res", func(t *testing.T) { testNEP17T(t, 100, 100, 0, 0, []int{}, []int{}) })
-		t.Run("limit", func(t *testing.T) { testNEP17T(t, 1, 7, 3, 0, []int{5, 6}, []int{1}) })
-		t.Run("limit 2", func(t *testing.T) { testNEP17T(t, 4, 5, 2, 0, []int{8}, []int{2}) })
-		t.Run("limit with page", func(t *testing.T) { testNEP17T(t, 1, 7, 3, 1, []int{7, 8}, []int{2}) })
-		t.Run("limit with page 2", func(t *testing.T) { testNEP17T(t, 1, 7, 3, 2, []int{9, 10}, []int{3}) })
+		t.Run("time frame only", func(t *testing.T) {
+			res := testNEP17T(t, 4, 5, 0, 0, "", []int{8, 9, 10, 11}, []int{2, 3})
+			require.Empty(t, res.Next)
+		})
+		t.Run("no res", func(t *testing.T) { testNEP17T(t, 100, 100, 0, 0, "", []int{}, []int{}) })
+		t.Run("limit", func(t *testing.T) { testNEP17T(t, 1, 7, 3, 0, "", []int{5, 6}, []int{1}) })
+		t.Run("limit 2", func(t *testing.T) { testNEP17T(t, 4, 5, 2, 0, "", []int{8}, []int{2}) })
+		t.Run("limit with page", func(t *testing.T) { testNEP17T(t, 1, 7, 3, 1, "", []int{7, 8}, []int{2}) })
+		t.Run("limit with page 2", func(t *testing.T) { testNEP17T(t, 1, 7, 3, 2, "", []int{9, 10}, []int{3}) })
+		t.Run("limit with cursor", func(t *testing.T) {
+			res := testNEP17T(t, 1, 7, 3, 0, "", []int{5, 6}, []int{1})
+			require.NotEmpty(t, res.Next)
+			res = testNEP17T(t, 1, 7, 3, 0, res.Next, []int{7, 8}, []int{2})
+			require.NotEmpty(t, res.Next)
+			testNEP17T(t, 1, 7, 3, 0, res.Next, []int{9, 10}, []int{3})
+		})
 	})
 }
 
@@ -1619,6 +2409,55 @@ func doRPCCallOverHTTP(rpcCall string, url string, t *testing.T) []byte {
 	return bytes.TrimSpace(body)
 }
 
+func checkNep11Balances(t *testing.T, e *executor, acc interface{}) {
+	res, ok := acc.(*result.NEP11Balances)
+	require.True(t, ok)
+	nnsHash, err := e.chain.GetNativeContractScriptHash(nativenames.NameService)
+	require.NoError(t, err)
+	expected := []result.NEP11AssetBalance{
+		{
+			Asset: nnsHash,
+			Tokens: []result.NEP11TokenBalance{
+				{
+					ID:          hex.EncodeToString([]byte("neo.com")),
+					Amount:      "1",
+					LastUpdated: 13,
+				},
+			},
+		},
+	}
+	require.Equal(t, testchain.PrivateKeyByID(0).Address(), res.Address)
+	require.Equal(t, expected, res.Balances)
+}
+
+func checkNep11Transfers(t *testing.T, e *executor, acc interface{}) {
+	res, ok := acc.(*result.NEP11Transfers)
+	require.True(t, ok)
+	nnsHash, err := e.chain.GetNativeContractScriptHash(nativenames.NameService)
+	require.NoError(t, err)
+
+	blockRegisterDomain, err := e.chain.GetBlock(e.chain.GetHeaderHash(13)) // register `neo.com` domain via NNS
+	require.NoError(t, err)
+	require.Equal(t, 1, len(blockRegisterDomain.Transactions))
+	txRegisterDomain := blockRegisterDomain.Transactions[0]
+
+	expected := []result.NEP11Transfer{
+		{
+			Timestamp: blockRegisterDomain.Timestamp,
+			Asset:     nnsHash,
+			Address:   "", // mint
+			ID:        hex.EncodeToString([]byte("neo.com")),
+			Amount:    "1",
+			Index:     13,
+			TxHash:    txRegisterDomain.Hash(),
+		},
+	}
+	require.Equal(t, testchain.PrivateKeyByID(0).Address(), res.Address)
+	require.Equal(t, expected, res.Received)
+	require.Equal(t, 0, len(res.Sent))
+	require.Empty(t, res.Next)
+}
+
 func checkNep17Balances(t *testing.T, e *executor, acc interface{}) {
 	res, ok := acc.(*result.NEP17Balances)
 	require.True(t, ok)
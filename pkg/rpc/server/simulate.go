@@ -0,0 +1,109 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// simulate implements the `simulate` RPC call. It's similar to invokescript,
+// but the script is run with state overrides applied and optional GAS limit
+// (bounded by MaxGasInvoke).
+func (s *Server) simulate(reqParams request.Params) (interface{}, *response.Error) {
+	if len(reqParams) < 2 {
+		return nil, response.ErrInvalidParams
+	}
+	script, err := reqParams[0].GetBytesBase64()
+	if err != nil {
+		return nil, response.WrapErrorWithData(response.ErrInvalidParams, err)
+	}
+	o, err := reqParams[1].GetStateOverrides()
+	if err != nil {
+		return nil, response.WrapErrorWithData(response.ErrInvalidParams, err)
+	}
+	tx := &transaction.Transaction{Script: script}
+	if len(reqParams) > 2 {
+		signers, _, err := reqParams[2].GetSignersWithWitnesses()
+		if err != nil {
+			return nil, response.ErrInvalidParams
+		}
+		tx.Signers = signers
+	}
+	// Witness overrides are added to signers, so the first of them becomes
+	// a sender if there are no signers.
+	if len(tx.Signers) == 0 && len(o.Witnesses) == 0 {
+		tx.Signers = []transaction.Signer{{Account: util.Uint160{}, Scopes: transaction.None}}
+	}
+	var gasLimit int64
+	if len(reqParams) > 3 {
+		gas, err := reqParams[3].GetInt()
+		if err != nil || gas <= 0 {
+			return nil, response.WrapErrorWithData(response.ErrInvalidParams, errors.New("invalid GAS limit"))
+		}
+		gasLimit = int64(gas)
+	}
+	return s.runScriptInVMWithOverrides(trigger.Application, script, util.Uint160{}, tx, false, o, gasLimit)
+}
+
+// maxSimulatedTransactions is the maximum number of transactions simulated by
+// a single `simulatetransactions` call.
+const maxSimulatedTransactions = 16
+
+// simulateTransactions implements the `simulatetransactions` RPC call. It runs
+// the given serialized transactions in order as if they were included into the
+// next block (with optional state overrides applied) and returns their results
+// along with the resulting storage changes.
+func (s *Server) simulateTransactions(reqParams request.Params) (interface{}, *response.Error) {
+	if len(reqParams) < 1 {
+		return nil, response.ErrInvalidParams
+	}
+	ps, err := reqParams[0].GetArray()
+	if err != nil {
+		return nil, response.WrapErrorWithData(response.ErrInvalidParams, err)
+	}
+	if len(ps) == 0 || len(ps) > maxSimulatedTransactions {
+		return nil, response.WrapErrorWithData(response.ErrInvalidParams,
+			fmt.Errorf("1 to %d transactions expected, got %d", maxSimulatedTransactions, len(ps)))
+	}
+	txs := make([]*transaction.Transaction, len(ps))
+	for i := range ps {
+		b, err := ps[i].GetBytesBase64()
+		if err != nil {
+			return nil, response.WrapErrorWithData(response.ErrInvalidParams, fmt.Errorf("transaction #%d: %w", i, err))
+		}
+		txs[i], err = transaction.NewTransactionFromBytes(b)
+		if err != nil {
+			return nil, response.WrapErrorWithData(response.ErrInvalidParams, fmt.Errorf("transaction #%d: %w", i, err))
+		}
+	}
+	var o *state.Overrides
+	if len(reqParams) > 1 {
+		o, err = reqParams[1].GetStateOverrides()
+		if err != nil {
+			return nil, response.WrapErrorWithData(response.ErrInvalidParams, err)
+		}
+	}
+	b, err := s.getFakeNextBlock()
+	if err != nil {
+		return nil, response.NewInternalServerError("can't get last block", err)
+	}
+	aers, changes, err := s.chain.SimulateTransactions(txs, b, o, int64(s.config.MaxGasInvoke))
+	if err != nil {
+		return nil, response.WrapErrorWithData(response.ErrInvalidParams, err)
+	}
+	res := &result.BundleSimulation{
+		Executions: make([]result.ApplicationLog, len(aers)),
+		Storage:    changes,
+	}
+	for i := range aers {
+		res.Executions[i] = result.NewApplicationLog(aers[i].Container, aers[i:i+1], trigger.All)
+	}
+	return res, nil
+}
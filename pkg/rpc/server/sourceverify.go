@@ -0,0 +1,125 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
+)
+
+const (
+	// maxSourceSize is the maximum size of contract source accepted by
+	// verifysource.
+	maxSourceSize = 1024 * 1024
+	// defaultSourceName is the source file name used by verifysource if it's
+	// not specified.
+	defaultSourceName = "contract.go"
+	// sourceVerificationTimeout is the maximum time verifysource waits for
+	// the compilation (including the time spent waiting for other
+	// compilations to finish).
+	sourceVerificationTimeout = 10 * time.Second
+)
+
+// pathDirRegexp matches directory part of file paths in compiler errors.
+var pathDirRegexp = regexp.MustCompile(`(^|[\s'"(])(?:[A-Za-z]:)?[\\/][^\s:'"]*[\\/]`)
+
+// errVerificationTimeout is returned if the source can't be compiled in time.
+var errVerificationTimeout = errors.New("source verification timed out")
+
+// verifySource compiles the given contract source and compares the result
+// with the deployed contract NEF.
+func (s *Server) verifySource(reqParams request.Params) (interface{}, *response.Error) {
+	if !s.config.EnableSourceVerification {
+		return nil, response.NewInvalidRequestError("source verification is disabled", nil)
+	}
+	h, respErr := s.contractScriptHashFromParam(reqParams.Value(0))
+	if respErr != nil {
+		return nil, respErr
+	}
+	src, err := reqParams.Value(1).GetBytesBase64()
+	if err != nil {
+		return nil, response.WrapErrorWithData(response.ErrInvalidParams, err)
+	}
+	if len(src) == 0 || len(src) > maxSourceSize {
+		return nil, response.WrapErrorWithData(response.ErrInvalidParams, errors.New("invalid source size"))
+	}
+	name := defaultSourceName
+	if p := reqParams.Value(2); p != nil {
+		name, err = p.GetString()
+		if err != nil {
+			return nil, response.WrapErrorWithData(response.ErrInvalidParams, err)
+		}
+		name = path.Base(name)
+		if !strings.HasSuffix(name, ".go") {
+			return nil, response.WrapErrorWithData(response.ErrInvalidParams, errors.New("source file name should have .go extension"))
+		}
+	}
+
+	height := s.chain.BlockHeight()
+	cs := s.chain.GetContractState(h)
+	if cs == nil {
+		return nil, response.NewRPCError("Unknown contract", "", nil)
+	}
+	res, err := s.compileAndVerify(name, src, &cs.NEF)
+	if err != nil {
+		if errors.Is(err, errVerificationTimeout) {
+			return nil, response.NewInternalServerError(err.Error(), err)
+		}
+		return nil, response.NewRPCError("Compilation failed", sanitizeCompilerError(err), err)
+	}
+	res.Contract = cs.Hash
+	res.Height = height
+	return res, nil
+}
+
+// compileAndVerify runs compiler.VerifySource for untrusted source, only one
+// compilation is performed at a time. Compilation that doesn't fit into
+// sourceVerificationTimeout is aborted and errVerificationTimeout is returned
+// immediately (the slot is released as soon as the compiler notices it).
+// Compiler panics are returned as errors.
+func (s *Server) compileAndVerify(name string, src []byte, deployed *nef.File) (*result.SourceAttestation, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), sourceVerificationTimeout)
+	defer cancel()
+	select {
+	case s.verifySlot <- struct{}{}:
+	case <-ctx.Done():
+		return nil, errVerificationTimeout
+	}
+	type verifyResult struct {
+		res *result.SourceAttestation
+		err error
+	}
+	ch := make(chan verifyResult, 1)
+	go func() {
+		defer func() { <-s.verifySlot }()
+		defer func() {
+			if r := recover(); r != nil {
+				ch <- verifyResult{err: fmt.Errorf("compiler panic: %v", r)}
+			}
+		}()
+		res, err := compiler.VerifySource(name, bytes.NewReader(src), &compiler.Options{Untrusted: true, Context: ctx}, deployed)
+		ch <- verifyResult{res, err}
+	}()
+	select {
+	case r := <-ch:
+		return r.res, r.err
+	case <-ctx.Done():
+		return nil, errVerificationTimeout
+	}
+}
+
+// sanitizeCompilerError returns compiler error message with directories
+// removed from file paths, so that node file system layout is not exposed.
+func sanitizeCompilerError(err error) string {
+	return pathDirRegexp.ReplaceAllString(err.Error(), "$1")
+}
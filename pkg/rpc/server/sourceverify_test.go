@@ -0,0 +1,39 @@
+package server
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSanitizeCompilerError(t *testing.T) {
+	testCases := map[string]string{
+		"failed to compile: /home/user/go/pkg/mod/interop/runtime/runtime.go:1:2: bad": "failed to compile: runtime.go:1:2: bad",
+		"contract.go:3:1: undefined: x":                                 "contract.go:3:1: undefined: x",
+		`C:\Users\user\contract.go:1:1: bad`:                            "contract.go:1:1: bad",
+		"open /etc/passwd: permission denied":                           "open passwd: permission denied",
+		"only interop packages can be imported, got github.com/foo/bar": "only interop packages can be imported, got github.com/foo/bar",
+	}
+	for msg, expected := range testCases {
+		require.Equal(t, expected, sanitizeCompilerError(errors.New(msg)))
+	}
+}
+
+func TestCompileAndVerify_Panic(t *testing.T) {
+	s := &Server{verifySlot: make(chan struct{}, 1)}
+	src := `package foo
+	func Main() int {
+		a := []int{0}
+		xs := []int{1, 2}
+		for a[0] = range xs {
+		}
+		return a[0]
+	}`
+	_, err := s.compileAndVerify("contract.go", []byte(src), &nef.File{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "compiler panic")
+	require.Eventually(t, func() bool { return len(s.verifySlot) == 0 }, time.Second, 10*time.Millisecond)
+}
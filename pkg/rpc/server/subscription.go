@@ -1,12 +1,17 @@
 package server
 
 import (
+	"bytes"
+	"encoding/binary"
+
 	"github.com/gorilla/websocket"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"go.uber.org/atomic"
 )
 
@@ -58,28 +63,102 @@ func (f *feed) Matches(r *response.Notification) bool {
 	case response.TransactionEventID:
 		filt := f.filter.(request.TxFilter)
 		tx := r.Payload[0].(*transaction.Transaction)
-		senderOK := filt.Sender == nil || tx.Sender().Equals(*filt.Sender)
-		signerOK := true
-		if filt.Signer != nil {
-			signerOK = false
-			for i := range tx.Signers {
-				if tx.Signers[i].Account.Equals(*filt.Signer) {
-					signerOK = true
-					break
-				}
-			}
-		}
-		return senderOK && signerOK
+		return txMatches(filt, tx)
 	case response.NotificationEventID:
 		filt := f.filter.(request.NotificationFilter)
 		notification := r.Payload[0].(*state.NotificationEvent)
 		hashOk := filt.Contract == nil || notification.ScriptHash.Equals(*filt.Contract)
 		nameOk := filt.Name == nil || notification.Name == *filt.Name
-		return hashOk && nameOk
+		return hashOk && nameOk && parametersMatch(filt.Parameters, notification.Item)
 	case response.ExecutionEventID:
 		filt := f.filter.(request.ExecutionFilter)
 		applog := r.Payload[0].(*state.AppExecResult)
 		return applog.VMState.String() == filt.State
+	case response.StorageChangesEventID:
+		filt := f.filter.(request.StorageFilter)
+		changes := r.Payload[0].(*state.StorageChanges)
+		for i := range changes.Storage {
+			if storageChangeMatches(filt, &changes.Storage[i]) {
+				return true
+			}
+		}
+		return false
+	case response.NotaryRequestEventID:
+		filt := f.filter.(request.TxFilter)
+		req := r.Payload[0].(*result.NotaryRequestEvent)
+		return txMatches(filt, req.NotaryRequest.MainTransaction)
 	}
 	return false
 }
+
+// txMatches checks transaction sender and signers against the filter.
+func txMatches(filt request.TxFilter, tx *transaction.Transaction) bool {
+	senderOK := filt.Sender == nil || tx.Sender().Equals(*filt.Sender)
+	signerOK := true
+	if filt.Signer != nil {
+		signerOK = false
+		for i := range tx.Signers {
+			if tx.Signers[i].Account.Equals(*filt.Signer) {
+				signerOK = true
+				break
+			}
+		}
+	}
+	return senderOK && signerOK
+}
+
+// FilterPayload returns notification with the payload filtered according to
+// the feed filter for events that are delivered partially (like storage
+// changes) and nil if the notification is to be sent as is.
+func (f *feed) FilterPayload(r *response.Notification) *response.Notification {
+	if f.filter == nil || f.event != response.StorageChangesEventID {
+		return nil
+	}
+	filt := f.filter.(request.StorageFilter)
+	changes := r.Payload[0].(*state.StorageChanges)
+	res := &state.StorageChanges{Block: changes.Block}
+	for i := range changes.Storage {
+		if storageChangeMatches(filt, &changes.Storage[i]) {
+			res.Storage = append(res.Storage, changes.Storage[i])
+		}
+	}
+	res.Size = len(res.Storage)
+	return &response.Notification{
+		JSONRPC: r.JSONRPC,
+		Event:   r.Event,
+		Payload: []interface{}{res},
+	}
+}
+
+// parametersMatch checks notification item against parameter filters.
+func parametersMatch(filters []request.NotificationParameter, item stackitem.Item) bool {
+	if len(filters) == 0 {
+		return true
+	}
+	arr, ok := item.Value().([]stackitem.Item)
+	if !ok {
+		return false
+	}
+	for _, filt := range filters {
+		if filt.Index < 0 || filt.Index >= len(arr) {
+			return false
+		}
+		val, err := arr[filt.Index].TryBytes()
+		if err != nil || !bytes.Equal(val, filt.Value) {
+			return false
+		}
+	}
+	return true
+}
+
+// storageChangeMatches checks storage change key (contract ID followed by
+// the item key) against the filter.
+func storageChangeMatches(filt request.StorageFilter, ch *state.StorageChange) bool {
+	if len(ch.Key) < 4 {
+		return false
+	}
+	if filt.ID != nil && int32(binary.LittleEndian.Uint32(ch.Key)) != *filt.ID {
+		return false
+	}
+	return bytes.HasPrefix(ch.Key[4:], filt.Prefix)
+}
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -83,7 +84,7 @@ func callUnsubscribe(t *testing.T, ws *websocket.Conn, msgs <-chan []byte, id st
 
 func TestSubscriptions(t *testing.T) {
 	var subIDs = make([]string, 0)
-	var subFeeds = []string{"block_added", "transaction_added", "notification_from_execution", "transaction_executed"}
+	var subFeeds = []string{"block_added", "transaction_added", "notification_from_execution", "transaction_executed", "storage_changes"}
 
 	chain, rpcSrv, c, respMsgs, finishedFlag := initCleanServerAndWSClient(t)
 
@@ -127,6 +128,10 @@ func TestSubscriptions(t *testing.T) {
 				break
 			}
 		}
+		require.Equal(t, response.StorageChangesEventID, resp.Event)
+		changes := resp.Payload[0].(map[string]interface{})
+		require.Equal(t, float64(b.Index), changes["block"])
+		resp = getNotification(t, respMsgs)
 		require.Equal(t, response.BlockEventID, resp.Event)
 	}
 
@@ -207,6 +212,32 @@ func TestFilteredSubscriptions(t *testing.T) {
 				require.Equal(t, "my_pretty_notification", n)
 			},
 		},
+		"notification matching parameter": {
+			params: `["notification_from_execution", {"name":"Transfer", "parameters":[{"index":0, "value":"` + base64.StdEncoding.EncodeToString(goodSender.BytesBE()) + `"}]}]`,
+			check: func(t *testing.T, resp *response.Notification) {
+				rmap := resp.Payload[0].(map[string]interface{})
+				require.Equal(t, response.NotificationEventID, resp.Event)
+				require.Equal(t, "Transfer", rmap["name"].(string))
+				st := rmap["state"].(map[string]interface{})
+				params := st["value"].([]interface{})
+				from := params[0].(map[string]interface{})
+				require.Equal(t, base64.StdEncoding.EncodeToString(goodSender.BytesBE()), from["value"].(string))
+			},
+		},
+		"storage matching id and prefix": {
+			params: `["storage_changes", {"id":-6, "prefix":"FA=="}]`,
+			check: func(t *testing.T, resp *response.Notification) {
+				rmap := resp.Payload[0].(map[string]interface{})
+				require.Equal(t, response.StorageChangesEventID, resp.Event)
+				changes := rmap["storage"].([]interface{})
+				require.NotEqual(t, 0, len(changes))
+				for _, ch := range changes {
+					key, err := base64.StdEncoding.DecodeString(ch.(map[string]interface{})["key"].(string))
+					require.NoError(t, err)
+					require.Equal(t, []byte{0xfa, 0xff, 0xff, 0xff, 0x14}, key[:5])
+				}
+			},
+		},
 		"execution matching": {
 			params: `["transaction_executed", {"state":"HALT"}]`,
 			check: func(t *testing.T, resp *response.Notification) {
@@ -228,6 +259,18 @@ func TestFilteredSubscriptions(t *testing.T) {
 				t.Fatal("unexpected match for contract 00112233445566778899aabbccddeeff00112233")
 			},
 		},
+		"notification parameter non-matching": {
+			params: `["notification_from_execution", {"name":"Transfer", "parameters":[{"index":0, "value":"ABEiM0RVZneImaq7zN3u/wARIjM="}]}]`,
+			check: func(t *testing.T, _ *response.Notification) {
+				t.Fatal("unexpected match for Transfer from 00112233445566778899aabbccddeeff00112233")
+			},
+		},
+		"storage non-matching": {
+			params: `["storage_changes", {"id":12345}]`,
+			check: func(t *testing.T, _ *response.Notification) {
+				t.Fatal("unexpected match for contract 12345 storage")
+			},
+		},
 		"execution non-matching": {
 			params: `["transaction_executed", {"state":"FAULT"}]`,
 			check: func(t *testing.T, _ *response.Notification) {
@@ -357,6 +400,8 @@ func TestBadSubUnsub(t *testing.T) {
 		"notification filter 2":  `{"jsonrpc": "2.0", "method": "subscribe", "params": ["notification_from_execution", "name"], "id": 1}`,
 		"execution filter 1":     `{"jsonrpc": "2.0", "method": "subscribe", "params": ["transaction_executed", "FAULT"], "id": 1}`,
 		"execution filter 2":     `{"jsonrpc": "2.0", "method": "subscribe", "params": ["transaction_executed", {"state": "STOP"}], "id": 1}`,
+		"storage changes filter": `{"jsonrpc": "2.0", "method": "subscribe", "params": ["storage_changes", {}], "id": 1}`,
+		"notary request filter":  `{"jsonrpc": "2.0", "method": "subscribe", "params": ["notary_request_event", {"state": "HALT"}], "id": 1}`,
 	}
 	var unsubCases = map[string]string{
 		"no params":         `{"jsonrpc": "2.0", "method": "unsubscribe", "params": [], "id": 1}`,
@@ -0,0 +1,201 @@
+package blockfetcher
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	gio "io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sync/atomic"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/blockchainer"
+	"github.com/nspcc-dev/neo-go/pkg/core/chaindump"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/services/oracle/neofs"
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultArchiveTimeout is the default timeout of manifest or chunk
+	// request.
+	defaultArchiveTimeout = time.Minute
+	// defaultTipDistance is the default distance to the highest peer
+	// block that stops archive synchronization.
+	defaultTipDistance = 1000
+	// maxManifestSize is the maximum size of archive manifest.
+	maxManifestSize = 16 * 1024 * 1024
+)
+
+var (
+	errArchiveStopped = errors.New("archive synchronization is stopped")
+	errTipReached     = errors.New("peers height is reached")
+)
+
+// ArchiveSyncer synchronizes the chain using chunked chain dump (the one
+// produced by `db dump --chunk` command) published via HTTP(S) or NeoFS.
+type ArchiveSyncer struct {
+	cfg         config.ArchiveSync
+	chain       blockchainer.Blockchainer
+	log         *zap.Logger
+	manifestURL *url.URL
+	// peerHeight returns the highest block known to peers, 0 if unknown.
+	peerHeight func() uint32
+	client     http.Client
+	key        *keys.PrivateKey
+	// next is the request counter used to choose NeoFS node.
+	next    uint32
+	stopped uint32
+}
+
+// NewArchiveSyncer creates a new archive synchronization service.
+func NewArchiveSyncer(cfg config.ArchiveSync, chain blockchainer.Blockchainer, log *zap.Logger, peerHeight func() uint32) (*ArchiveSyncer, error) {
+	u, err := url.Parse(cfg.ManifestURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid manifest URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid manifest URL scheme %q", u.Scheme)
+	}
+	key, err := keys.NewPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = defaultArchiveTimeout
+	}
+	if cfg.TipDistance == 0 {
+		cfg.TipDistance = defaultTipDistance
+	}
+	return &ArchiveSyncer{
+		cfg:         cfg,
+		chain:       chain,
+		log:         log,
+		manifestURL: u,
+		peerHeight:  peerHeight,
+		client:      http.Client{Timeout: cfg.Timeout},
+		key:         key,
+	}, nil
+}
+
+// Run adds blocks from archives to the chain until they're exhausted or the
+// chain is close enough to the peers height (so that P2P synchronization
+// can continue), it returns after that.
+func (s *ArchiveSyncer) Run() error {
+	rc, err := s.fetch(s.manifestURL, maxManifestSize)
+	if err != nil {
+		return fmt.Errorf("failed to get manifest: %w", err)
+	}
+	data, err := ioutil.ReadAll(gio.LimitReader(rc, maxManifestSize+1))
+	rc.Close()
+	if err != nil {
+		return fmt.Errorf("failed to get manifest: %w", err)
+	}
+	if len(data) > maxManifestSize {
+		return fmt.Errorf("manifest exceeds the limit of %d bytes", maxManifestSize)
+	}
+	m := new(chaindump.Manifest)
+	if err := json.Unmarshal(data, m); err != nil {
+		return fmt.Errorf("bad manifest: %w", err)
+	}
+	start := s.chain.BlockHeight()
+	for _, c := range m.Chunks {
+		height := s.chain.BlockHeight()
+		if c.Start+c.Count <= height+1 {
+			continue
+		}
+		if c.Start > height+1 {
+			return fmt.Errorf("archive has no block %d", height+1)
+		}
+		err := s.syncChunk(c, height+1-c.Start)
+		if errors.Is(err, errArchiveStopped) || errors.Is(err, errTipReached) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to sync chunk %s: %w", c.File, err)
+		}
+	}
+	s.log.Info("archive synchronization finished",
+		zap.Uint32("from", start),
+		zap.Uint32("to", s.chain.BlockHeight()))
+	return nil
+}
+
+// Shutdown stops archive synchronization.
+func (s *ArchiveSyncer) Shutdown() {
+	atomic.StoreUint32(&s.stopped, 1)
+}
+
+// syncChunk adds blocks from the given chunk skipping the first skip ones.
+func (s *ArchiveSyncer) syncChunk(c chaindump.Chunk, skip uint32) error {
+	ref, err := url.Parse(c.File)
+	if err != nil {
+		return err
+	}
+	// Chunk header and the given number of blocks with sizes.
+	maxSize := 8 + uint64(c.Count)*(4+uint64(s.chain.GetConfig().MaxBlockSize))
+	rc, err := s.fetch(s.manifestURL.ResolveReference(ref), maxSize)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	r := io.NewBinReaderFromIO(gio.LimitReader(rc, int64(maxSize)))
+	start, count := r.ReadU32LE(), r.ReadU32LE()
+	if r.Err != nil {
+		return r.Err
+	}
+	if start != c.Start || count != c.Count {
+		return fmt.Errorf("chunk has blocks %d-%d instead of %d-%d", start, start+count-1, c.Start, c.Start+c.Count-1)
+	}
+	s.log.Info("adding blocks from archive",
+		zap.Uint32("start", start+skip),
+		zap.Uint32("end", start+count-1))
+	return chaindump.Restore(s.chain, r, skip, count-skip, func(b *block.Block) error {
+		if atomic.LoadUint32(&s.stopped) != 0 {
+			return errArchiveStopped
+		}
+		if ph := s.peerHeight(); ph != 0 && b.Index+s.cfg.TipDistance >= ph {
+			return errTipReached
+		}
+		return nil
+	})
+}
+
+// fetch opens the resource with the given URL, resources that are known to be
+// bigger than maxSize are rejected, but callers should still limit the amount
+// of data read.
+func (s *ArchiveSyncer) fetch(u *url.URL, maxSize uint64) (gio.ReadCloser, error) {
+	if u.Scheme == neofs.URIScheme {
+		if len(s.cfg.NeoFSNodes) == 0 {
+			return nil, errors.New("no NeoFS nodes specified")
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), s.cfg.Timeout)
+		defer cancel()
+		addr := s.cfg.NeoFSNodes[int(atomic.AddUint32(&s.next, 1))%len(s.cfg.NeoFSNodes)]
+		data, err := neofs.GetPayload(ctx, s.key, u, addr, maxSize)
+		if err != nil {
+			return nil, err
+		}
+		return ioutil.NopCloser(bytes.NewReader(data)), nil
+	}
+	resp, err := s.client.Get(u.String())
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+	if resp.ContentLength > 0 && uint64(resp.ContentLength) > maxSize {
+		resp.Body.Close()
+		return nil, fmt.Errorf("resource size %d exceeds the limit of %d bytes", resp.ContentLength, maxSize)
+	}
+	return resp.Body, nil
+}
@@ -0,0 +1,100 @@
+package blockfetcher
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/internal/fakechain"
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/chaindump"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// newTestArchive returns HTTP server serving chunked dump of count empty
+// blocks with the given chunk size.
+func newTestArchive(t *testing.T, count, chunk uint32) *httptest.Server {
+	files := make(map[string][]byte)
+	m := &chaindump.Manifest{ChunkSize: chunk}
+	for start := uint32(0); start < count; start += chunk {
+		w := io.NewBufBinWriter()
+		w.WriteU32LE(start)
+		w.WriteU32LE(chunk)
+		for i := start; i < start+chunk; i++ {
+			b := block.New(false)
+			b.Index = i
+			b.Script.InvocationScript = []byte{}
+			b.Script.VerificationScript = []byte{}
+			buf := io.NewBufBinWriter()
+			b.EncodeBinary(buf.BinWriter)
+			bs := buf.Bytes()
+			w.WriteU32LE(uint32(len(bs)))
+			w.WriteBytes(bs)
+		}
+		require.NoError(t, w.Err)
+		name := "chunks/" + string(rune('a'+len(m.Chunks)))
+		files["/"+name] = w.Bytes()
+		m.Chunks = append(m.Chunks, chaindump.Chunk{Start: start, Count: chunk, File: name})
+	}
+	data, err := json.Marshal(m)
+	require.NoError(t, err)
+	files["/"+chaindump.ManifestFile] = data
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, ok := files[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(data)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestArchiveSyncer(t *testing.T) {
+	srv := newTestArchive(t, 10, 5)
+	cfg := config.ArchiveSync{
+		Enabled:     true,
+		ManifestURL: srv.URL + "/" + chaindump.ManifestFile,
+		TipDistance: 2,
+	}
+
+	_, err := NewArchiveSyncer(config.ArchiveSync{ManifestURL: "ftp://localhost"}, nil, zaptest.NewLogger(t), nil)
+	require.Error(t, err)
+
+	t.Run("all blocks", func(t *testing.T) {
+		chain := fakechain.NewFakeChain()
+		s, err := NewArchiveSyncer(cfg, chain, zaptest.NewLogger(t), func() uint32 { return 0 })
+		require.NoError(t, err)
+		require.NoError(t, s.Run())
+		require.Equal(t, uint32(9), chain.BlockHeight())
+	})
+	t.Run("peers height", func(t *testing.T) {
+		chain := fakechain.NewFakeChain()
+		s, err := NewArchiveSyncer(cfg, chain, zaptest.NewLogger(t), func() uint32 { return 7 })
+		require.NoError(t, err)
+		require.NoError(t, s.Run())
+		require.Equal(t, uint32(5), chain.BlockHeight())
+	})
+	t.Run("too big chunk", func(t *testing.T) {
+		chain := fakechain.NewFakeChain()
+		chain.ProtocolConfiguration.MaxBlockSize = 10
+		s, err := NewArchiveSyncer(cfg, chain, zaptest.NewLogger(t), func() uint32 { return 0 })
+		require.NoError(t, err)
+		require.Error(t, s.Run())
+		require.Equal(t, uint32(0), chain.BlockHeight())
+	})
+	t.Run("missing chunk", func(t *testing.T) {
+		chain := fakechain.NewFakeChain()
+		cfg := cfg
+		cfg.ManifestURL = srv.URL + "/unknown.json"
+		s, err := NewArchiveSyncer(cfg, chain, zaptest.NewLogger(t), func() uint32 { return 0 })
+		require.NoError(t, err)
+		require.Error(t, s.Run())
+	})
+}
@@ -0,0 +1,192 @@
+package blockfetcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neofs-api-go/pkg/client"
+	"github.com/nspcc-dev/neofs-api-go/pkg/container"
+	"github.com/nspcc-dev/neofs-api-go/pkg/object"
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultIndexAttribute is the default name of object attribute
+	// containing block index.
+	defaultIndexAttribute = "Index"
+	// defaultTimeout is the default timeout of NeoFS requests.
+	defaultTimeout = time.Second * 5
+	// maxConcurrentFetches is the maximum number of blocks fetched
+	// simultaneously, requests exceeding it fail immediately.
+	maxConcurrentFetches = 4
+	// cacheSize is the maximum number of fetched blocks kept in memory.
+	cacheSize = 64
+)
+
+var (
+	// ErrBlockNotFound is returned when there is no object for the requested
+	// block in the container.
+	ErrBlockNotFound = errors.New("block not found in NeoFS")
+	// ErrTooManyFetches is returned when there are already too many blocks
+	// being fetched.
+	ErrTooManyFetches = errors.New("too many blocks are being fetched")
+)
+
+// Service fetches blocks stored in NeoFS container as separate objects
+// (serialized blocks) with block index specified in object attribute.
+type Service struct {
+	cfg               config.NeoFSBlockFetcher
+	log               *zap.Logger
+	stateRootInHeader bool
+	containerID       *container.ID
+	// key is used to sign NeoFS requests, reading public container
+	// doesn't require any specific one.
+	key *keys.PrivateKey
+	// clients are NeoFS clients for every configured node.
+	clients []*client.Client
+	// next is the request counter used to choose NeoFS node.
+	next uint32
+	// sem limits the number of simultaneous fetches.
+	sem chan struct{}
+
+	cacheLock sync.Mutex
+	// cache contains recently fetched blocks, cacheOrder is the order
+	// they were added in.
+	cache      map[uint32]*block.Block
+	cacheOrder []uint32
+}
+
+// New creates a new block fetcher service.
+func New(cfg config.NeoFSBlockFetcher, stateRootInHeader bool, log *zap.Logger) (*Service, error) {
+	if len(cfg.NeoFS.Nodes) == 0 {
+		return nil, errors.New("no NeoFS nodes specified")
+	}
+	cid := container.NewID()
+	if err := cid.Parse(cfg.ContainerID); err != nil {
+		return nil, fmt.Errorf("invalid container ID: %w", err)
+	}
+	key, err := keys.NewPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	if cfg.IndexAttribute == "" {
+		cfg.IndexAttribute = defaultIndexAttribute
+	}
+	if cfg.NeoFS.Timeout == 0 {
+		cfg.NeoFS.Timeout = defaultTimeout
+	}
+	clients := make([]*client.Client, len(cfg.NeoFS.Nodes))
+	for i, addr := range cfg.NeoFS.Nodes {
+		clients[i], err = client.New(&key.PrivateKey, client.WithAddress(addr))
+		if err != nil {
+			return nil, fmt.Errorf("can't create client for %s: %w", addr, err)
+		}
+	}
+	return &Service{
+		cfg:               cfg,
+		log:               log,
+		stateRootInHeader: stateRootInHeader,
+		containerID:       cid,
+		key:               key,
+		clients:           clients,
+		sem:               make(chan struct{}, maxConcurrentFetches),
+		cache:             make(map[uint32]*block.Block),
+	}, nil
+}
+
+// GetBlock implements services.BlockFetcher interface. It searches for the
+// object with the specified index and decodes its payload as a block.
+// Recently fetched blocks are returned from the cache, no more than
+// maxConcurrentFetches requests are made to NeoFS simultaneously.
+func (s *Service) GetBlock(index uint32) (*block.Block, error) {
+	if b := s.getCached(index); b != nil {
+		return b, nil
+	}
+	select {
+	case s.sem <- struct{}{}:
+	default:
+		return nil, ErrTooManyFetches
+	}
+	defer func() { <-s.sem }()
+
+	b, err := s.fetchBlock(index)
+	if err != nil {
+		return nil, err
+	}
+	s.putCached(b)
+	return b, nil
+}
+
+func (s *Service) getCached(index uint32) *block.Block {
+	s.cacheLock.Lock()
+	defer s.cacheLock.Unlock()
+	return s.cache[index]
+}
+
+func (s *Service) putCached(b *block.Block) {
+	s.cacheLock.Lock()
+	defer s.cacheLock.Unlock()
+	if _, ok := s.cache[b.Index]; ok {
+		return
+	}
+	if len(s.cacheOrder) >= cacheSize {
+		delete(s.cache, s.cacheOrder[0])
+		s.cacheOrder = s.cacheOrder[1:]
+	}
+	s.cache[b.Index] = b
+	s.cacheOrder = append(s.cacheOrder, b.Index)
+}
+
+func (s *Service) fetchBlock(index uint32) (*block.Block, error) {
+	n := int(atomic.AddUint32(&s.next, 1)) % len(s.clients)
+	addr, c := s.cfg.NeoFS.Nodes[n], s.clients[n]
+	ctx, cancel := context.WithTimeout(context.Background(), s.cfg.NeoFS.Timeout)
+	defer cancel()
+
+	filters := object.NewSearchFilters()
+	filters.AddFilter(s.cfg.IndexAttribute, strconv.FormatUint(uint64(index), 10), object.MatchStringEqual)
+	ids, err := c.SearchObject(ctx, new(client.SearchObjectParams).
+		WithContainerID(s.containerID).WithSearchFilters(filters))
+	if err != nil {
+		return nil, fmt.Errorf("failed to search for block %d: %w", index, err)
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("%w: %d", ErrBlockNotFound, index)
+	}
+
+	objectAddr := object.NewAddress()
+	objectAddr.SetContainerID(s.containerID)
+	objectAddr.SetObjectID(ids[0])
+	obj, err := c.GetObject(ctx, new(client.GetObjectParams).WithAddress(objectAddr))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block %d: %w", index, err)
+	}
+	b, err := s.decodeBlock(obj.Payload())
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode block %d: %w", index, err)
+	}
+	if b.Index != index {
+		return nil, fmt.Errorf("object %s contains block %d instead of %d", ids[0], b.Index, index)
+	}
+	s.log.Debug("block fetched from NeoFS", zap.Uint32("index", index), zap.String("node", addr))
+	return b, nil
+}
+
+func (s *Service) decodeBlock(data []byte) (*block.Block, error) {
+	b := block.New(s.stateRootInHeader)
+	r := io.NewBinReaderFromBuf(data)
+	b.DecodeBinary(r)
+	if r.Err != nil {
+		return nil, r.Err
+	}
+	return b, nil
+}
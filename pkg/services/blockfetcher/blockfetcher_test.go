@@ -0,0 +1,78 @@
+package blockfetcher
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/internal/random"
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestNew(t *testing.T) {
+	cfg := config.NeoFSBlockFetcher{
+		Enabled:     true,
+		ContainerID: "C3swfg8MiMJ9bXbeFG6dWJTCoHp9hAEZkHezvbSwK1Cc",
+	}
+	_, err := New(cfg, false, zaptest.NewLogger(t))
+	require.Error(t, err)
+
+	cfg.NeoFS.Nodes = []string{"localhost:8080"}
+	s, err := New(cfg, false, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	require.Equal(t, defaultIndexAttribute, s.cfg.IndexAttribute)
+	require.Equal(t, defaultTimeout, s.cfg.NeoFS.Timeout)
+
+	cfg.ContainerID = "bad"
+	_, err = New(cfg, false, zaptest.NewLogger(t))
+	require.Error(t, err)
+}
+
+func TestDecodeBlock(t *testing.T) {
+	s := &Service{}
+	b := block.New(false)
+	b.Index = 42
+	b.PrevHash = random.Uint256()
+	b.Script.InvocationScript = []byte{}
+	b.Script.VerificationScript = []byte{}
+	b.RebuildMerkleRoot()
+	w := io.NewBufBinWriter()
+	b.EncodeBinary(w.BinWriter)
+	require.NoError(t, w.Err)
+
+	actual, err := s.decodeBlock(w.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, b.Hash(), actual.Hash())
+
+	_, err = s.decodeBlock(w.Bytes()[:10])
+	require.Error(t, err)
+}
+
+func TestLimits(t *testing.T) {
+	cfg := config.NeoFSBlockFetcher{
+		ContainerID: "C3swfg8MiMJ9bXbeFG6dWJTCoHp9hAEZkHezvbSwK1Cc",
+	}
+	cfg.NeoFS.Nodes = []string{"localhost:8080"}
+	s, err := New(cfg, false, zaptest.NewLogger(t))
+	require.NoError(t, err)
+
+	for i := 0; i < cacheSize+1; i++ {
+		b := block.New(false)
+		b.Index = uint32(i)
+		s.putCached(b)
+	}
+	require.Equal(t, cacheSize, len(s.cache))
+	require.Nil(t, s.getCached(0))
+	b, err := s.GetBlock(cacheSize)
+	require.NoError(t, err)
+	require.Equal(t, uint32(cacheSize), b.Index)
+
+	for i := 0; i < maxConcurrentFetches; i++ {
+		s.sem <- struct{}{}
+	}
+	_, err = s.GetBlock(0)
+	require.True(t, errors.Is(err, ErrTooManyFetches))
+}
@@ -0,0 +1,69 @@
+package faucet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// CaptchaVerifier checks CAPTCHA response token provided by the client.
+type CaptchaVerifier interface {
+	Verify(token, remoteIP string) error
+}
+
+// SiteVerifier is a CaptchaVerifier using siteverify protocol implemented
+// by reCAPTCHA and hCaptcha.
+type SiteVerifier struct {
+	url    string
+	secret string
+	client *http.Client
+}
+
+// siteVerifyResponse is siteverify endpoint response.
+type siteVerifyResponse struct {
+	Success    bool     `json:"success"`
+	ErrorCodes []string `json:"error-codes"`
+}
+
+// captchaTimeout is the timeout of verification request.
+const captchaTimeout = 10 * time.Second
+
+// NewSiteVerifier creates a verifier using the specified endpoint and secret.
+func NewSiteVerifier(endpoint, secret string) *SiteVerifier {
+	return &SiteVerifier{
+		url:    endpoint,
+		secret: secret,
+		client: &http.Client{Timeout: captchaTimeout},
+	}
+}
+
+// Verify implements CaptchaVerifier interface.
+func (s *SiteVerifier) Verify(token, remoteIP string) error {
+	if token == "" {
+		return errors.New("no CAPTCHA response")
+	}
+	resp, err := s.client.PostForm(s.url, url.Values{
+		"secret":   {s.secret},
+		"response": {token},
+		"remoteip": {remoteIP},
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected verification status %d", resp.StatusCode)
+	}
+	var r siteVerifyResponse
+	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return fmt.Errorf("invalid verification response: %w", err)
+	}
+	if !r.Success {
+		return fmt.Errorf("rejected: %s", strings.Join(r.ErrorCodes, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,319 @@
+package faucet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/blockchainer"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"go.uber.org/zap"
+)
+
+type (
+	// Faucet is a service transferring configured amounts of GAS and NEO
+	// from its wallet to accounts requested via HTTP.
+	Faucet struct {
+		Config
+		http *http.Server
+
+		account *wallet.Account
+		gasHash util.Uint160
+		neoHash util.Uint160
+
+		// lock protects cooldown maps and serializes transaction creation.
+		lock     sync.Mutex
+		lastIP   map[string]time.Time
+		lastAddr map[util.Uint160]time.Time
+	}
+
+	// Config contains faucet service parameters.
+	Config struct {
+		Log     *zap.Logger
+		MainCfg config.Faucet
+		Chain   blockchainer.Blockchainer
+		// RelayTx is used to relay transactions created by the faucet.
+		RelayTx func(*transaction.Transaction) error
+		// Captcha verifies CAPTCHA responses, siteverify client is used
+		// if it's nil and CAPTCHA is enabled.
+		Captcha CaptchaVerifier
+	}
+
+	// Info is the faucet information returned for GET requests.
+	Info struct {
+		Address  string `json:"address"`
+		GAS      string `json:"gas"`
+		NEO      int64  `json:"neo"`
+		Cooldown int64  `json:"cooldown"`
+		Captcha  bool   `json:"captcha"`
+		// GASBalance and NEOBalance are the remaining faucet funds.
+		GASBalance string `json:"gasbalance"`
+		NEOBalance string `json:"neobalance"`
+	}
+
+	// Request is the faucet request sent via POST.
+	Request struct {
+		Address string `json:"address"`
+		Captcha string `json:"captcha,omitempty"`
+	}
+
+	// Response is the faucet response with the transaction hash on success
+	// or an error message.
+	Response struct {
+		Hash  *util.Uint256 `json:"hash,omitempty"`
+		Error string        `json:"error,omitempty"`
+	}
+)
+
+const (
+	// defaultCooldown is the default interval between requests from the
+	// same IP address or to the same account.
+	defaultCooldown = time.Hour
+
+	// maxRequestSize is the maximum size of request body.
+	maxRequestSize = 4096
+
+	// maxTrackedKeys is the number of tracked IP addresses and accounts
+	// after which expired entries are dropped. If there are still too many
+	// of them, requests from new clients and to new accounts are rejected
+	// until some entries expire.
+	maxTrackedKeys = 4096
+
+	// ipv6PrefixLen is the length of IPv6 prefix used as a client key, a
+	// single client usually has the whole /64 network.
+	ipv6PrefixLen = 64
+)
+
+var (
+	// ErrCooldown is returned when the request is made before cooldown
+	// period expiration.
+	ErrCooldown = errors.New("too many requests, try again later")
+
+	// errBadRequest wraps errors caused by invalid client requests.
+	errBadRequest = errors.New("bad request")
+)
+
+// New creates a new faucet service.
+func New(cfg Config) (*Faucet, error) {
+	if cfg.MainCfg.GASAmount < 0 || cfg.MainCfg.NEOAmount < 0 {
+		return nil, errors.New("negative faucet amount")
+	}
+	if cfg.MainCfg.GASAmount == 0 && cfg.MainCfg.NEOAmount == 0 {
+		return nil, errors.New("faucet amounts are not set")
+	}
+	if cfg.MainCfg.Cooldown == 0 {
+		cfg.MainCfg.Cooldown = defaultCooldown
+	}
+	if cfg.Captcha == nil && cfg.MainCfg.Captcha.Enabled {
+		if cfg.MainCfg.Captcha.URL == "" {
+			return nil, errors.New("CAPTCHA verification URL is not set")
+		}
+		cfg.Captcha = NewSiteVerifier(cfg.MainCfg.Captcha.URL, cfg.MainCfg.Captcha.Secret)
+	}
+
+	w, err := wallet.NewWalletFromFile(cfg.MainCfg.UnlockWallet.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer w.Close()
+
+	f := &Faucet{
+		Config:   cfg,
+		lastIP:   make(map[string]time.Time),
+		lastAddr: make(map[util.Uint160]time.Time),
+	}
+	for _, acc := range w.Accounts {
+		if acc.Contract == nil {
+			continue
+		}
+		if err := acc.Decrypt(cfg.MainCfg.UnlockWallet.Password); err == nil {
+			f.account = acc
+			break
+		}
+	}
+	if f.account == nil {
+		return nil, errors.New("no wallet account could be unlocked")
+	}
+	if f.gasHash, err = cfg.Chain.GetNativeContractScriptHash(nativenames.Gas); err != nil {
+		return nil, err
+	}
+	if f.neoHash, err = cfg.Chain.GetNativeContractScriptHash(nativenames.Neo); err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", f.handle)
+	f.http = &http.Server{
+		Addr:    net.JoinHostPort(cfg.MainCfg.Address, strconv.FormatUint(uint64(cfg.MainCfg.Port), 10)),
+		Handler: mux,
+	}
+	return f, nil
+}
+
+// Start runs faucet HTTP server.
+func (f *Faucet) Start(errChan chan error) {
+	f.Log.Info("starting faucet", zap.String("endpoint", f.http.Addr),
+		zap.String("account", f.account.Address))
+	ln, err := net.Listen("tcp", f.http.Addr)
+	if err != nil {
+		errChan <- err
+		return
+	}
+	f.http.Addr = ln.Addr().String() // set Addr to the actual address
+	go func() {
+		err := f.http.Serve(ln)
+		if err != http.ErrServerClosed {
+			f.Log.Error("failed to start faucet", zap.Error(err))
+			errChan <- err
+		}
+	}()
+}
+
+// Shutdown stops faucet HTTP server.
+func (f *Faucet) Shutdown() error {
+	f.Log.Info("shutting down faucet", zap.String("endpoint", f.http.Addr))
+	return f.http.Shutdown(context.Background())
+}
+
+// Addr returns the address faucet server listens on.
+func (f *Faucet) Addr() string {
+	return f.http.Addr
+}
+
+func (f *Faucet) handle(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		f.writeJSON(w, http.StatusOK, f.info())
+	case http.MethodPost:
+		var req Request
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize)).Decode(&req); err != nil {
+			f.writeJSON(w, http.StatusBadRequest, Response{Error: "invalid request"})
+			return
+		}
+		ip, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			ip = r.RemoteAddr
+		}
+		h, err := f.Send(req, ip)
+		switch {
+		case err == nil:
+			f.writeJSON(w, http.StatusOK, Response{Hash: &h})
+		case errors.Is(err, ErrCooldown):
+			f.writeJSON(w, http.StatusTooManyRequests, Response{Error: err.Error()})
+		case errors.Is(err, errBadRequest):
+			f.writeJSON(w, http.StatusBadRequest, Response{Error: err.Error()})
+		default:
+			f.Log.Warn("faucet request failed", zap.String("address", req.Address), zap.Error(err))
+			f.writeJSON(w, http.StatusInternalServerError, Response{Error: err.Error()})
+		}
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		f.writeJSON(w, http.StatusMethodNotAllowed, Response{Error: "method not allowed"})
+	}
+}
+
+func (f *Faucet) writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		f.Log.Debug("failed to write faucet response", zap.Error(err))
+	}
+}
+
+func (f *Faucet) info() Info {
+	h := f.account.Contract.ScriptHash()
+	neo, _ := f.Chain.GetGoverningTokenBalance(h)
+	return Info{
+		Address:    f.account.Address,
+		GAS:        f.MainCfg.GASAmount.String(),
+		NEO:        f.MainCfg.NEOAmount,
+		Cooldown:   int64(f.MainCfg.Cooldown / time.Second),
+		Captcha:    f.Captcha != nil,
+		GASBalance: f.Chain.GetUtilityTokenBalance(h).String(),
+		NEOBalance: neo.String(),
+	}
+}
+
+// Send checks the request, creates and relays transfer transaction to the
+// requested account. ip is the client address used for rate limiting, IPv6
+// addresses are limited per /64 network.
+func (f *Faucet) Send(req Request, ip string) (util.Uint256, error) {
+	to, err := address.StringToUint160(req.Address)
+	if err != nil {
+		return util.Uint256{}, fmt.Errorf("%w: invalid address", errBadRequest)
+	}
+	if f.Captcha != nil {
+		if err := f.Captcha.Verify(req.Captcha, ip); err != nil {
+			return util.Uint256{}, fmt.Errorf("%w: CAPTCHA verification failed: %v", errBadRequest, err)
+		}
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	now := time.Now()
+	key := clientKey(ip)
+	f.prune(now)
+	lastIP, okIP := f.lastIP[key]
+	lastAddr, okAddr := f.lastAddr[to]
+	if f.inCooldown(lastIP, now) || f.inCooldown(lastAddr, now) ||
+		(!okIP && len(f.lastIP) >= maxTrackedKeys) || (!okAddr && len(f.lastAddr) >= maxTrackedKeys) {
+		return util.Uint256{}, ErrCooldown
+	}
+	tx, err := f.makeTx(to)
+	if err != nil {
+		return util.Uint256{}, err
+	}
+	if err := f.RelayTx(tx); err != nil {
+		return util.Uint256{}, fmt.Errorf("can't relay transaction: %w", err)
+	}
+	f.lastIP[key] = now
+	f.lastAddr[to] = now
+	f.Log.Info("faucet transfer sent", zap.String("to", req.Address),
+		zap.Stringer("tx", tx.Hash()))
+	return tx.Hash(), nil
+}
+
+func (f *Faucet) inCooldown(last, now time.Time) bool {
+	return !last.IsZero() && now.Sub(last) < f.MainCfg.Cooldown
+}
+
+// prune removes expired cooldown entries if there are too many of them, it
+// must be called with the lock held.
+func (f *Faucet) prune(now time.Time) {
+	if len(f.lastIP) >= maxTrackedKeys {
+		for k, t := range f.lastIP {
+			if !f.inCooldown(t, now) {
+				delete(f.lastIP, k)
+			}
+		}
+	}
+	if len(f.lastAddr) >= maxTrackedKeys {
+		for k, t := range f.lastAddr {
+			if !f.inCooldown(t, now) {
+				delete(f.lastAddr, k)
+			}
+		}
+	}
+}
+
+// clientKey returns the key used for the client IP address cooldown, it's
+// the address itself for IPv4 and /64 prefix for IPv6.
+func clientKey(ip string) string {
+	addr := net.ParseIP(ip)
+	if addr == nil || addr.To4() != nil {
+		return ip
+	}
+	return addr.Mask(net.CIDRMask(ipv6PrefixLen, 8*net.IPv6len)).String()
+}
@@ -0,0 +1,70 @@
+package faucet
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/fee"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+)
+
+// makeTx creates signed transaction transferring configured amounts to the
+// specified account.
+func (f *Faucet) makeTx(to util.Uint160) (*transaction.Transaction, error) {
+	from := f.account.Contract.ScriptHash()
+	w := io.NewBufBinWriter()
+	if f.MainCfg.GASAmount != 0 {
+		emit.AppCall(w.BinWriter, f.gasHash, "transfer", callflag.All, from, to, int64(f.MainCfg.GASAmount), nil)
+		emit.Opcodes(w.BinWriter, opcode.ASSERT)
+	}
+	if f.MainCfg.NEOAmount != 0 {
+		emit.AppCall(w.BinWriter, f.neoHash, "transfer", callflag.All, from, to, f.MainCfg.NEOAmount, nil)
+		emit.Opcodes(w.BinWriter, opcode.ASSERT)
+	}
+	if w.Err != nil {
+		return nil, w.Err
+	}
+
+	cfg := f.Chain.GetConfig()
+	height := f.Chain.BlockHeight()
+	tx := transaction.New(w.Bytes(), 0)
+	tx.ValidUntilBlock = height + cfg.MaxValidUntilBlockIncrement(height)
+	tx.Signers = []transaction.Signer{{
+		Account: from,
+		Scopes:  transaction.CalledByEntry,
+	}}
+
+	// Calculate system fee. Transfers depend on the height of the block
+	// transaction is included into, so the next one is provided.
+	hdr, err := f.Chain.GetHeader(f.Chain.GetHeaderHash(int(height)))
+	if err != nil {
+		return nil, fmt.Errorf("can't get last block: %w", err)
+	}
+	b := block.New(cfg.StateRootInHeader)
+	b.Index = height + 1
+	b.Timestamp = hdr.Timestamp + uint64(cfg.TimePerBlock(b.Index)/time.Millisecond)
+	v := f.Chain.GetTestVM(trigger.Application, tx, b)
+	v.GasLimit = cfg.MaxBlockSystemFee
+	v.LoadScriptWithFlags(tx.Script, callflag.All)
+	if err := v.Run(); err != nil {
+		return nil, fmt.Errorf("transfer failed (insufficient faucet balance?): %w", err)
+	}
+	tx.SystemFee = v.GasConsumed()
+
+	// Calculate network fee.
+	size := io.GetVarSize(tx)
+	netFee, sizeDelta := fee.Calculate(f.Chain.GetPolicer().GetBaseExecFee(), f.account.Contract.Script)
+	tx.NetworkFee = netFee + int64(size+sizeDelta)*f.Chain.FeePerByte()
+
+	if err := f.account.SignTx(cfg.Magic, tx); err != nil {
+		return nil, fmt.Errorf("can't sign transaction: %w", err)
+	}
+	return tx, nil
+}
@@ -2,7 +2,6 @@ package notary
 
 import (
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
-	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"go.uber.org/zap"
@@ -31,7 +30,7 @@ func (n *Notary) UpdateNotaryNodes(notaryNodes keys.PublicKeys) {
 			err := acc.Decrypt(n.Config.MainCfg.UnlockWallet.Password)
 			if err != nil {
 				n.Config.Log.Warn("can't unlock notary node account",
-					zap.String("address", address.Uint160ToString(acc.Contract.ScriptHash())),
+					zap.String("address", acc.Address),
 					zap.Error(err))
 				acc = nil
 			}
@@ -74,4 +74,10 @@ func TestUpdateNotaryNodes(t *testing.T) {
 		ntr.UpdateNotaryNodes(keys.PublicKeys{randomKey.PublicKey()})
 		require.Nil(t, ntr.currAccount)
 	})
+
+	t.Run("address-only account", func(t *testing.T) {
+		ntr.wallet.AddAccount(wallet.NewWatchOnlyAccount(randomKey.GetScriptHash()))
+		ntr.UpdateNotaryNodes(keys.PublicKeys{randomKey.PublicKey()})
+		require.Nil(t, ntr.currAccount)
+	})
 }
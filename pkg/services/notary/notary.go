@@ -46,6 +46,9 @@ type (
 		currAccount *wallet.Account
 		wallet      *wallet.Wallet
 
+		// sponsorship is the policy checked for every request.
+		sponsorship *sponsorship
+
 		mp *mempool.Pool
 		// requests channel
 		reqCh    chan mempool.Event
@@ -101,6 +104,10 @@ func NewNotary(cfg Config, net netmode.Magic, mp *mempool.Pool, onTransaction fu
 	if !haveAccount {
 		return nil, errors.New("no wallet account could be unlocked")
 	}
+	sp, err := newSponsorship(cfg.MainCfg.Sponsorship)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sponsorship policy: %w", err)
+	}
 
 	return &Notary{
 		requests:      make(map[util.Uint256]*request),
@@ -108,6 +115,7 @@ func NewNotary(cfg Config, net netmode.Magic, mp *mempool.Pool, onTransaction fu
 		Network:       net,
 		wallet:        wallet,
 		onTransaction: onTransaction,
+		sponsorship:   sp,
 		mp:            mp,
 		reqCh:         make(chan mempool.Event),
 		blocksCh:      make(chan *block.Block),
@@ -152,6 +160,11 @@ func (n *Notary) OnNewRequest(payload *payload.P2PNotaryRequest) {
 	if n.getAccount() == nil {
 		return
 	}
+	if err := n.sponsorship.check(payload, n.Config.Chain.GetNotaryContractScriptHash()); err != nil {
+		n.Config.Log.Debug("notary request is not allowed by sponsorship policy",
+			zap.Stringer("main", payload.MainTransaction.Hash()), zap.Error(err))
+		return
+	}
 
 	nvbFallback := payload.FallbackTransaction.GetAttributes(transaction.NotValidBeforeT)[0].Value.(*transaction.NotValidBefore).Height
 	nKeys := payload.MainTransaction.GetAttributes(transaction.NotaryAssistedT)[0].Value.(*transaction.NotaryAssisted).NKeys
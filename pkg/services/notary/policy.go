@@ -0,0 +1,136 @@
+package notary
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+)
+
+// sponsorship is a parsed notary sponsorship policy.
+type sponsorship struct {
+	maxFee    int64
+	signers   map[util.Uint160]bool
+	contracts map[util.Uint160]bool
+}
+
+var contractCallID = interopnames.ToID([]byte(interopnames.SystemContractCall))
+
+func newSponsorship(cfg config.NotarySponsorship) (*sponsorship, error) {
+	if cfg.MaxFee < 0 {
+		return nil, errors.New("negative MaxFee")
+	}
+	s := &sponsorship{maxFee: int64(cfg.MaxFee)}
+	if len(cfg.AllowedSigners) != 0 {
+		s.signers = make(map[util.Uint160]bool, len(cfg.AllowedSigners))
+		for _, addr := range cfg.AllowedSigners {
+			u, err := address.StringToUint160(addr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid allowed signer %s: %w", addr, err)
+			}
+			s.signers[u] = true
+		}
+	}
+	if len(cfg.AllowedContracts) != 0 {
+		s.contracts = make(map[util.Uint160]bool, len(cfg.AllowedContracts))
+		for _, h := range cfg.AllowedContracts {
+			u, err := util.Uint160DecodeStringLE(strings.TrimPrefix(h, "0x"))
+			if err != nil {
+				return nil, fmt.Errorf("invalid allowed contract %s: %w", h, err)
+			}
+			s.contracts[u] = true
+		}
+	}
+	return s, nil
+}
+
+// check returns an error if the request doesn't satisfy the policy.
+func (s *sponsorship) check(r *payload.P2PNotaryRequest, notaryHash util.Uint160) error {
+	if s.maxFee != 0 {
+		for _, tx := range []*transaction.Transaction{r.MainTransaction, r.FallbackTransaction} {
+			if fee := tx.SystemFee + tx.NetworkFee; fee > s.maxFee {
+				return fmt.Errorf("transaction %s fee %d exceeds the limit", tx.Hash().StringLE(), fee)
+			}
+		}
+	}
+	if s.signers != nil {
+		var allowed bool
+		for _, signer := range r.MainTransaction.Signers {
+			if signer.Account != notaryHash && s.signers[signer.Account] {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return errors.New("main transaction has no allowed signers")
+		}
+	}
+	if s.contracts != nil {
+		hashes, err := getCalledContracts(r.MainTransaction.Script)
+		if err != nil {
+			return err
+		}
+		for _, h := range hashes {
+			if !s.contracts[h] {
+				return fmt.Errorf("contract %s is not allowed", h.StringLE())
+			}
+		}
+	}
+	return nil
+}
+
+// getCalledContracts returns hashes of contracts called by the script. Only
+// straight-line scripts (without jumps, calls and try blocks) with the hash
+// pushed directly before System.Contract.Call are accepted, because otherwise
+// called contract can't be determined.
+func getCalledContracts(script []byte) ([]util.Uint160, error) {
+	var (
+		res  []util.Uint160
+		prev []byte
+	)
+	ctx := vm.NewContext(script)
+	for ctx.NextIP() < len(script) {
+		op, param, err := ctx.Next()
+		if err != nil {
+			return nil, err
+		}
+		if isControlFlow(op) {
+			return nil, fmt.Errorf("can't determine called contracts, script contains %s", op)
+		}
+		if op == opcode.SYSCALL && binary.LittleEndian.Uint32(param) == contractCallID {
+			if len(prev) != util.Uint160Size {
+				return nil, errors.New("can't determine called contract")
+			}
+			u, _ := util.Uint160DecodeBytesBE(prev)
+			res = append(res, u)
+		}
+		prev = nil
+		if op == opcode.PUSHDATA1 {
+			prev = param
+		}
+	}
+	return res, nil
+}
+
+// isControlFlow returns true if op can transfer control to another instruction
+// (not the next one).
+func isControlFlow(op opcode.Opcode) bool {
+	switch {
+	case op >= opcode.JMP && op <= opcode.CALLT: // Jumps and calls.
+		return true
+	case op >= opcode.TRY && op <= opcode.ENDFINALLY:
+		return true
+	case op == opcode.PUSHA:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+package notary
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSponsorship(t *testing.T) {
+	_, err := newSponsorship(config.NotarySponsorship{MaxFee: -1})
+	require.Error(t, err)
+	_, err = newSponsorship(config.NotarySponsorship{AllowedSigners: []string{"addr"}})
+	require.Error(t, err)
+	_, err = newSponsorship(config.NotarySponsorship{AllowedContracts: []string{"0x1234"}})
+	require.Error(t, err)
+	_, err = newSponsorship(config.NotarySponsorship{
+		MaxFee:           fixedn.Fixed8FromInt64(1),
+		AllowedSigners:   []string{address.Uint160ToString(util.Uint160{1})},
+		AllowedContracts: []string{"0x" + util.Uint160{2}.StringLE(), util.Uint160{3}.StringLE()},
+	})
+	require.NoError(t, err)
+}
+
+func TestSponsorshipCheck(t *testing.T) {
+	var (
+		notaryHash = util.Uint160{0xff}
+		signer     = util.Uint160{1}
+		contract   = util.Uint160{2}
+	)
+	callScript := func(t *testing.T, hashes ...util.Uint160) []byte {
+		w := io.NewBufBinWriter()
+		for _, h := range hashes {
+			emit.AppCall(w.BinWriter, h, "method", callflag.All, int64(1))
+			emit.Opcodes(w.BinWriter, opcode.DROP)
+		}
+		require.NoError(t, w.Err)
+		return w.Bytes()
+	}
+	newRequest := func(script []byte, fee int64, signers ...util.Uint160) *payload.P2PNotaryRequest {
+		main := transaction.New(script, fee)
+		main.Signers = []transaction.Signer{{Account: notaryHash}}
+		for _, s := range signers {
+			main.Signers = append(main.Signers, transaction.Signer{Account: s})
+		}
+		return &payload.P2PNotaryRequest{
+			MainTransaction:     main,
+			FallbackTransaction: transaction.New([]byte{byte(opcode.RET)}, 1),
+		}
+	}
+
+	t.Run("empty policy", func(t *testing.T) {
+		s, err := newSponsorship(config.NotarySponsorship{})
+		require.NoError(t, err)
+		require.NoError(t, s.check(newRequest([]byte{byte(opcode.PUSHT)}, 100_0000_0000, util.Uint160{5}), notaryHash))
+	})
+	s, err := newSponsorship(config.NotarySponsorship{
+		MaxFee:           fixedn.Fixed8FromInt64(1),
+		AllowedSigners:   []string{address.Uint160ToString(signer)},
+		AllowedContracts: []string{contract.StringLE()},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, s.check(newRequest(callScript(t, contract, contract), 1_0000_0000, util.Uint160{5}, signer), notaryHash))
+	t.Run("big fee", func(t *testing.T) {
+		require.Error(t, s.check(newRequest(callScript(t, contract), 1_0000_0001, signer), notaryHash))
+		r := newRequest(callScript(t, contract), 1, signer)
+		r.FallbackTransaction.NetworkFee = 1_0000_0000
+		require.Error(t, s.check(r, notaryHash))
+	})
+	t.Run("bad signer", func(t *testing.T) {
+		require.Error(t, s.check(newRequest(callScript(t, contract), 1, util.Uint160{5}), notaryHash))
+		s, err := newSponsorship(config.NotarySponsorship{
+			AllowedSigners: []string{address.Uint160ToString(notaryHash)},
+		})
+		require.NoError(t, err)
+		require.Error(t, s.check(newRequest(callScript(t, contract), 1, signer), notaryHash))
+	})
+	t.Run("bad contract", func(t *testing.T) {
+		require.Error(t, s.check(newRequest(callScript(t, contract, util.Uint160{3}), 1, signer), notaryHash))
+	})
+	t.Run("dynamic call", func(t *testing.T) {
+		w := io.NewBufBinWriter()
+		emit.Opcodes(w.BinWriter, opcode.NEWARRAY0)
+		emit.Int(w.BinWriter, int64(callflag.All))
+		emit.String(w.BinWriter, "method")
+		emit.Opcodes(w.BinWriter, opcode.DUP)
+		emit.Syscall(w.BinWriter, "System.Contract.Call")
+		require.NoError(t, w.Err)
+		require.Error(t, s.check(newRequest(w.Bytes(), 1, signer), notaryHash))
+	})
+	t.Run("jump", func(t *testing.T) {
+		w := io.NewBufBinWriter()
+		emit.Opcodes(w.BinWriter, opcode.NEWARRAY0)
+		emit.Int(w.BinWriter, int64(callflag.All))
+		emit.String(w.BinWriter, "method")
+		emit.Bytes(w.BinWriter, util.Uint160{3}.BytesBE())
+		emit.Instruction(w.BinWriter, opcode.JMP, []byte{2 + 2 + util.Uint160Size})
+		emit.Bytes(w.BinWriter, contract.BytesBE())
+		emit.Syscall(w.BinWriter, "System.Contract.Call")
+		require.NoError(t, w.Err)
+		require.Error(t, s.check(newRequest(w.Bytes(), 1, signer), notaryHash))
+	})
+	t.Run("try", func(t *testing.T) {
+		script := append([]byte{byte(opcode.TRY), 3, 0}, callScript(t, contract)...)
+		require.Error(t, s.check(newRequest(script, 1, signer), notaryHash))
+	})
+	t.Run("invalid script", func(t *testing.T) {
+		require.Error(t, s.check(newRequest([]byte{byte(opcode.PUSHDATA1), 10}, 1, signer), notaryHash))
+	})
+}
@@ -0,0 +1,129 @@
+package oracle
+
+import (
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+)
+
+// maxCacheEntries is the maximum number of cached responses, expired ones are
+// dropped when it's reached and then the oldest ones if needed.
+const maxCacheEntries = 1024
+
+type (
+	// responseCache stores successful oracle responses for a limited amount
+	// of time and deduplicates concurrent fetches of the same resource.
+	responseCache struct {
+		ttl time.Duration
+
+		lock    sync.Mutex
+		entries map[string]*cacheEntry
+	}
+
+	// cacheEntry is a response being fetched or already cached, code and
+	// result can only be accessed after done is closed.
+	cacheEntry struct {
+		done    chan struct{}
+		expires time.Time
+		code    transaction.OracleResponseCode
+		result  []byte
+	}
+)
+
+func newResponseCache(ttl time.Duration) *responseCache {
+	return &responseCache{
+		ttl:     ttl,
+		entries: make(map[string]*cacheEntry),
+	}
+}
+
+// cacheKey returns cache key for the request, different filters applied to
+// the same URL produce different results.
+func cacheKey(req *state.OracleRequest) string {
+	if req.Filter == nil {
+		return req.URL
+	}
+	return req.URL + "\x00" + *req.Filter
+}
+
+// get returns cached response for the key or calls fetch to get it. Only
+// successful responses are cached, but concurrent callers always wait for
+// the fetch already in progress and get its result.
+func (c *responseCache) get(key string, fetch func() (transaction.OracleResponseCode, []byte)) (transaction.OracleResponseCode, []byte) {
+	c.lock.Lock()
+	if e, ok := c.entries[key]; ok {
+		select {
+		case <-e.done:
+			if time.Now().Before(e.expires) {
+				c.lock.Unlock()
+				return e.code, e.result
+			}
+		default:
+			c.lock.Unlock()
+			<-e.done
+			return e.code, e.result
+		}
+	}
+	if len(c.entries) >= maxCacheEntries {
+		c.prune(time.Now())
+		for len(c.entries) >= maxCacheEntries {
+			if !c.evictOldest() {
+				break
+			}
+		}
+	}
+	e := &cacheEntry{done: make(chan struct{})}
+	c.entries[key] = e
+	c.lock.Unlock()
+
+	e.code, e.result = fetch()
+
+	c.lock.Lock()
+	if e.code == transaction.Success {
+		e.expires = time.Now().Add(c.ttl)
+	} else {
+		delete(c.entries, key)
+	}
+	close(e.done)
+	c.lock.Unlock()
+	return e.code, e.result
+}
+
+// prune removes expired entries, it must be called with the lock held.
+func (c *responseCache) prune(now time.Time) {
+	for k, e := range c.entries {
+		select {
+		case <-e.done:
+			if !now.Before(e.expires) {
+				delete(c.entries, k)
+			}
+		default:
+		}
+	}
+}
+
+// evictOldest removes fetched entry that expires first (which is the oldest
+// one as they all have the same TTL), it returns false if there are no such
+// entries. It must be called with the lock held.
+func (c *responseCache) evictOldest() bool {
+	var (
+		oldest string
+		found  bool
+		exp    time.Time
+	)
+	for k, e := range c.entries {
+		select {
+		case <-e.done:
+			if !found || e.expires.Before(exp) {
+				oldest, exp, found = k, e.expires, true
+			}
+		default:
+		}
+	}
+	if found {
+		delete(c.entries, oldest)
+	}
+	return found
+}
@@ -0,0 +1,103 @@
+package oracle
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheKey(t *testing.T) {
+	flt1, flt2 := "$.a", ""
+	keys := map[string]bool{
+		cacheKey(&state.OracleRequest{URL: "https://a.b"}):                 true,
+		cacheKey(&state.OracleRequest{URL: "https://a.b", Filter: &flt1}):  true,
+		cacheKey(&state.OracleRequest{URL: "https://a.b", Filter: &flt2}):  true,
+		cacheKey(&state.OracleRequest{URL: "https://a.bc", Filter: &flt1}): true,
+	}
+	require.Equal(t, 4, len(keys))
+}
+
+func TestResponseCache(t *testing.T) {
+	c := newResponseCache(time.Hour)
+	var calls int
+	fetch := func(code transaction.OracleResponseCode, res []byte) func() (transaction.OracleResponseCode, []byte) {
+		return func() (transaction.OracleResponseCode, []byte) {
+			calls++
+			return code, res
+		}
+	}
+
+	code, res := c.get("ok", fetch(transaction.Success, []byte{1}))
+	require.Equal(t, transaction.Success, code)
+	require.Equal(t, []byte{1}, res)
+	code, res = c.get("ok", fetch(transaction.Success, []byte{2}))
+	require.Equal(t, transaction.Success, code)
+	require.Equal(t, []byte{1}, res)
+	require.Equal(t, 1, calls)
+
+	t.Run("failures are not cached", func(t *testing.T) {
+		calls = 0
+		code, _ := c.get("fail", fetch(transaction.Error, nil))
+		require.Equal(t, transaction.Error, code)
+		code, _ = c.get("fail", fetch(transaction.NotFound, nil))
+		require.Equal(t, transaction.NotFound, code)
+		require.Equal(t, 2, calls)
+	})
+	t.Run("expiration", func(t *testing.T) {
+		c := newResponseCache(time.Millisecond)
+		calls = 0
+		c.get("ok", fetch(transaction.Success, []byte{1}))
+		time.Sleep(2 * time.Millisecond)
+		_, res := c.get("ok", fetch(transaction.Success, []byte{2}))
+		require.Equal(t, []byte{2}, res)
+		require.Equal(t, 2, calls)
+	})
+	t.Run("size limit", func(t *testing.T) {
+		c := newResponseCache(time.Hour)
+		for i := 0; i <= maxCacheEntries; i++ {
+			c.get(strconv.Itoa(i), fetch(transaction.Success, []byte{1}))
+		}
+		require.Equal(t, maxCacheEntries, len(c.entries))
+		require.Nil(t, c.entries["0"])
+		require.NotNil(t, c.entries[strconv.Itoa(maxCacheEntries)])
+	})
+	t.Run("concurrent", func(t *testing.T) {
+		var (
+			n     int
+			mtx   sync.Mutex
+			start = make(chan struct{})
+			wg    sync.WaitGroup
+		)
+		slow := func() (transaction.OracleResponseCode, []byte) {
+			<-start
+			mtx.Lock()
+			n++
+			mtx.Unlock()
+			return transaction.Success, []byte{3}
+		}
+		results := make([][]byte, 5)
+		for i := range results {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				_, results[i] = c.get("slow", slow)
+			}(i)
+		}
+		require.Eventually(t, func() bool {
+			c.lock.Lock()
+			defer c.lock.Unlock()
+			return c.entries["slow"] != nil
+		}, time.Second, time.Millisecond)
+		close(start)
+		wg.Wait()
+		for i := range results {
+			require.Equal(t, []byte{3}, results[i])
+		}
+		require.Equal(t, 1, n)
+	})
+}
@@ -33,5 +33,8 @@ func filterRequest(result []byte, req *state.OracleRequest) (transaction.OracleR
 			return transaction.Error, nil
 		}
 	}
+	if len(result) > transaction.MaxOracleResultSize {
+		return transaction.ResponseTooLarge, nil
+	}
 	return transaction.Success, result
 }
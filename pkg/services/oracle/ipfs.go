@@ -0,0 +1,26 @@
+package oracle
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// ipfsURIScheme is the name of ipfs URI scheme.
+const ipfsURIScheme = "ipfs"
+
+// ipfsGatewayURL converts ipfs://<CID>/<path> URL into gateway
+// <gateway>/ipfs/<CID>/<path> URL.
+func ipfsGatewayURL(gateway string, u *url.URL) (string, error) {
+	if u.Scheme != ipfsURIScheme {
+		return "", errors.New("invalid scheme")
+	}
+	if u.Host == "" {
+		return "", errors.New("missing CID")
+	}
+	res := strings.TrimRight(gateway, "/") + "/ipfs/" + u.Host + u.EscapedPath()
+	if u.RawQuery != "" {
+		res += "?" + u.RawQuery
+	}
+	return res, nil
+}
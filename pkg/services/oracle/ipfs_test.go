@@ -0,0 +1,35 @@
+package oracle
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIPFSGatewayURL(t *testing.T) {
+	const cid = "QmYwAPJzv5CZsnA625s3Xf2nemtYgPpHdWEz79ojWnPbdG"
+	testCases := []struct {
+		gateway, uri, expected string
+	}{
+		{"http://127.0.0.1:8080", "ipfs://" + cid, "http://127.0.0.1:8080/ipfs/" + cid},
+		{"http://127.0.0.1:8080/", "ipfs://" + cid + "/readme", "http://127.0.0.1:8080/ipfs/" + cid + "/readme"},
+		{"https://ipfs.io", "ipfs://" + cid + "/a%20b?format=raw", "https://ipfs.io/ipfs/" + cid + "/a%20b?format=raw"},
+	}
+	for _, tc := range testCases {
+		u, err := url.ParseRequestURI(tc.uri)
+		require.NoError(t, err)
+		actual, err := ipfsGatewayURL(tc.gateway, u)
+		require.NoError(t, err, tc.uri)
+		require.Equal(t, tc.expected, actual)
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, uri := range []string{"ipfs:///path", "https://" + cid} {
+			u, err := url.ParseRequestURI(uri)
+			require.NoError(t, err)
+			_, err = ipfsGatewayURL("http://127.0.0.1:8080", u)
+			require.Error(t, err, uri)
+		}
+	})
+}
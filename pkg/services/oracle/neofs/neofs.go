@@ -44,12 +44,14 @@ var (
 	ErrInvalidObject    = errors.New("object ID is invalid")
 	ErrInvalidRange     = errors.New("object range is invalid (expected 'Offset|Length')")
 	ErrInvalidCommand   = errors.New("invalid command")
+	ErrTooLarge         = errors.New("requested data is too large")
 )
 
 // Get returns neofs object from the provided url.
 // URI scheme is "neofs:<Container-ID>/<Object-ID/<Command>/<Params>".
-// If Command is not provided, full object is requested.
-func Get(ctx context.Context, priv *keys.PrivateKey, u *url.URL, addr string) ([]byte, error) {
+// If Command is not provided, full object is requested. Payloads and ranges
+// bigger than maxSize are not fetched, ErrTooLarge is returned for them.
+func Get(ctx context.Context, priv *keys.PrivateKey, u *url.URL, addr string, maxSize uint64) ([]byte, error) {
 	objectAddr, ps, err := parseNeoFSURL(u)
 	if err != nil {
 		return nil, err
@@ -62,9 +64,9 @@ func Get(ctx context.Context, priv *keys.PrivateKey, u *url.URL, addr string) ([
 
 	switch {
 	case len(ps) == 0 || ps[0] == "": // Get request
-		return getPayload(ctx, c, objectAddr)
+		return getPayload(ctx, c, objectAddr, maxSize)
 	case ps[0] == rangeCmd:
-		return getRange(ctx, c, objectAddr, ps[1:]...)
+		return getRange(ctx, c, objectAddr, maxSize, ps[1:]...)
 	case ps[0] == headerCmd:
 		return getHeader(ctx, c, objectAddr)
 	case ps[0] == hashCmd:
@@ -74,6 +76,26 @@ func Get(ctx context.Context, priv *keys.PrivateKey, u *url.URL, addr string) ([
 	}
 }
 
+// GetPayload returns raw payload of neofs object from the provided url
+// ("neofs:<Container-ID>/<Object-ID>"), unlike Get it doesn't check payload
+// to be a valid UTF-8 string and doesn't accept commands. Payloads bigger than
+// maxSize are not fetched, ErrTooLarge is returned for them.
+func GetPayload(ctx context.Context, priv *keys.PrivateKey, u *url.URL, addr string, maxSize uint64) ([]byte, error) {
+	objectAddr, ps, err := parseNeoFSURL(u)
+	if err != nil {
+		return nil, err
+	}
+	if len(ps) != 0 && ps[0] != "" {
+		return nil, ErrInvalidCommand
+	}
+
+	c, err := client.New(&priv.PrivateKey, client.WithAddress(addr))
+	if err != nil {
+		return nil, err
+	}
+	return getRawPayload(ctx, c, objectAddr, maxSize)
+}
+
 // parseNeoFSURL returns parsed neofs address.
 func parseNeoFSURL(u *url.URL) (*object.Address, []string, error) {
 	if u.Scheme != URIScheme {
@@ -101,15 +123,30 @@ func parseNeoFSURL(u *url.URL) (*object.Address, []string, error) {
 	return objectAddr, ps[2:], nil
 }
 
-func getPayload(ctx context.Context, c *client.Client, addr *object.Address) ([]byte, error) {
+func getPayload(ctx context.Context, c *client.Client, addr *object.Address, maxSize uint64) ([]byte, error) {
+	data, err := getRawPayload(ctx, c, addr, maxSize)
+	if err != nil {
+		return nil, err
+	}
+	return checkUTF8(data)
+}
+
+func getRawPayload(ctx context.Context, c *client.Client, addr *object.Address, maxSize uint64) ([]byte, error) {
+	hdr, err := c.GetObjectHeader(ctx, new(client.ObjectHeaderParams).WithAddress(addr))
+	if err != nil {
+		return nil, err
+	}
+	if hdr.PayloadSize() > maxSize {
+		return nil, fmt.Errorf("%w: payload size %d", ErrTooLarge, hdr.PayloadSize())
+	}
 	obj, err := c.GetObject(ctx, new(client.GetObjectParams).WithAddress(addr))
 	if err != nil {
 		return nil, err
 	}
-	return checkUTF8(obj.Payload())
+	return obj.Payload(), nil
 }
 
-func getRange(ctx context.Context, c *client.Client, addr *object.Address, ps ...string) ([]byte, error) {
+func getRange(ctx context.Context, c *client.Client, addr *object.Address, maxSize uint64, ps ...string) ([]byte, error) {
 	if len(ps) == 0 {
 		return nil, ErrInvalidRange
 	}
@@ -117,6 +154,9 @@ func getRange(ctx context.Context, c *client.Client, addr *object.Address, ps ..
 	if err != nil {
 		return nil, err
 	}
+	if r.GetLength() > maxSize {
+		return nil, fmt.Errorf("%w: range length %d", ErrTooLarge, r.GetLength())
+	}
 	data, err := c.ObjectPayloadRangeData(ctx, new(client.RangeDataParams).WithAddress(addr).WithRange(r))
 	if err != nil {
 		return nil, err
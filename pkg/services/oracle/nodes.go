@@ -2,7 +2,6 @@ package oracle
 
 import (
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
-	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"go.uber.org/zap"
@@ -36,7 +35,7 @@ func (o *Oracle) UpdateOracleNodes(oracleNodes keys.PublicKeys) {
 			err := acc.Decrypt(o.MainCfg.UnlockWallet.Password)
 			if err != nil {
 				o.Log.Error("can't unlock account",
-					zap.String("address", address.Uint160ToString(acc.Contract.ScriptHash())),
+					zap.String("address", acc.Address),
 					zap.Error(err))
 				o.currAccount = nil
 				return
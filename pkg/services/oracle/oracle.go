@@ -48,6 +48,8 @@ type (
 		responses map[uint64]*incompleteTx
 		// removed contains ids of requests which won't be processed further due to expiration.
 		removed map[uint64]bool
+		// cache is used to reuse responses, it's nil if caching is disabled.
+		cache *responseCache
 
 		wallet *wallet.Wallet
 	}
@@ -66,7 +68,7 @@ type (
 
 	// HTTPClient is an interface capable of doing oracle requests.
 	HTTPClient interface {
-		Get(string) (*http.Response, error)
+		Do(*http.Request) (*http.Response, error)
 	}
 
 	// Broadcaster broadcasts oracle responses.
@@ -111,10 +113,19 @@ func NewOracle(cfg Config) (*Oracle, error) {
 	if o.MainCfg.NeoFS.Timeout == 0 {
 		o.MainCfg.NeoFS.Timeout = defaultRequestTimeout
 	}
+	if o.MainCfg.IPFS.Timeout == 0 {
+		o.MainCfg.IPFS.Timeout = defaultRequestTimeout
+	}
+	if o.MainCfg.MaxResponseSize == 0 {
+		o.MainCfg.MaxResponseSize = transaction.MaxOracleResultSize
+	}
 	if o.MainCfg.MaxConcurrentRequests == 0 {
 		o.MainCfg.MaxConcurrentRequests = defaultMaxConcurrentRequests
 	}
 	o.requestCh = make(chan request, o.MainCfg.MaxConcurrentRequests)
+	if o.MainCfg.ResponseCacheTTL > 0 {
+		o.cache = newResponseCache(o.MainCfg.ResponseCacheTTL)
+	}
 	if o.MainCfg.MaxTaskTimeout == 0 {
 		o.MainCfg.MaxTaskTimeout = defaultMaxTaskTimeout
 	}
@@ -143,6 +154,7 @@ func NewOracle(cfg Config) (*Oracle, error) {
 		var client http.Client
 		client.Transport = &http.Transport{DisableKeepAlives: true}
 		client.Timeout = o.MainCfg.RequestTimeout
+		client.CheckRedirect = o.checkRedirect
 		o.Client = &client
 	}
 	if o.ResponseHandler == nil {
@@ -3,8 +3,10 @@ package oracle
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
@@ -17,6 +19,14 @@ import (
 
 const defaultMaxConcurrentRequests = 10
 
+// maxRedirects is the maximum number of redirects followed for a single
+// https request, it's the same as net/http default.
+const maxRedirects = 10
+
+// errForbiddenRedirect is returned by the redirect policy for redirects that
+// are not allowed, such requests get Forbidden response code.
+var errForbiddenRedirect = errors.New("forbidden redirect")
+
 type request struct {
 	ID  uint64
 	Req *state.OracleRequest
@@ -96,64 +106,12 @@ func (o *Oracle) processRequest(priv *keys.PrivateKey, req request) error {
 		return nil
 	}
 	resp := &transaction.OracleResponse{ID: req.ID}
-	u, err := url.ParseRequestURI(req.Req.URL)
-	if err != nil {
-		o.Log.Warn("malformed oracle request", zap.String("url", req.Req.URL), zap.Error(err))
-		resp.Code = transaction.ProtocolNotSupported
+	if o.cache != nil {
+		resp.Code, resp.Result = o.cache.get(cacheKey(req.Req), func() (transaction.OracleResponseCode, []byte) {
+			return o.fetch(priv, req, incTx.attempts)
+		})
 	} else {
-		switch u.Scheme {
-		case "https":
-			if !o.MainCfg.AllowPrivateHost {
-				err = o.URIValidator(u)
-				if err != nil {
-					o.Log.Warn("forbidden oracle request", zap.String("url", req.Req.URL))
-					resp.Code = transaction.Forbidden
-					break
-				}
-			}
-			r, err := o.Client.Get(req.Req.URL)
-			if err != nil {
-				o.Log.Warn("oracle request failed", zap.String("url", req.Req.URL), zap.Error(err))
-				resp.Code = transaction.Error
-				break
-			}
-			switch r.StatusCode {
-			case http.StatusOK:
-				result, err := readResponse(r.Body, transaction.MaxOracleResultSize)
-				if err != nil {
-					if errors.Is(err, ErrResponseTooLarge) {
-						resp.Code = transaction.ResponseTooLarge
-					} else {
-						resp.Code = transaction.Error
-					}
-					o.Log.Warn("failed to read data for oracle request", zap.String("url", req.Req.URL), zap.Error(err))
-					break
-				}
-				resp.Code, resp.Result = filterRequest(result, req.Req)
-			case http.StatusForbidden:
-				resp.Code = transaction.Forbidden
-			case http.StatusNotFound:
-				resp.Code = transaction.NotFound
-			case http.StatusRequestTimeout:
-				resp.Code = transaction.Timeout
-			default:
-				resp.Code = transaction.Error
-			}
-		case neofs.URIScheme:
-			ctx, cancel := context.WithTimeout(context.Background(), o.MainCfg.NeoFS.Timeout)
-			defer cancel()
-			index := (int(req.ID) + incTx.attempts) % len(o.MainCfg.NeoFS.Nodes)
-			res, err := neofs.Get(ctx, priv, u, o.MainCfg.NeoFS.Nodes[index])
-			if err != nil {
-				o.Log.Warn("oracle request failed", zap.String("url", req.Req.URL), zap.Error(err))
-				resp.Code = transaction.Error
-			} else {
-				resp.Code, resp.Result = filterRequest(res, req.Req)
-			}
-		default:
-			resp.Code = transaction.ProtocolNotSupported
-			o.Log.Warn("unknown oracle request scheme", zap.String("url", req.Req.URL))
-		}
+		resp.Code, resp.Result = o.fetch(priv, req, incTx.attempts)
 	}
 	o.Log.Debug("oracle request processed", zap.String("url", req.Req.URL), zap.Int("code", int(resp.Code)), zap.String("result", string(resp.Result)))
 
@@ -206,6 +164,158 @@ func (o *Oracle) processRequest(priv *keys.PrivateKey, req request) error {
 	return nil
 }
 
+// fetch gets the data for the request and returns the response code with
+// filtered result.
+func (o *Oracle) fetch(priv *keys.PrivateKey, req request, attempts int) (transaction.OracleResponseCode, []byte) {
+	u, err := url.ParseRequestURI(req.Req.URL)
+	if err != nil {
+		o.Log.Warn("malformed oracle request", zap.String("url", req.Req.URL), zap.Error(err))
+		return transaction.ProtocolNotSupported, nil
+	}
+	switch u.Scheme {
+	case "https":
+		if !o.MainCfg.AllowPrivateHost {
+			err = o.URIValidator(u)
+			if err != nil {
+				o.Log.Warn("forbidden oracle request", zap.String("url", req.Req.URL))
+				return transaction.Forbidden, nil
+			}
+		}
+		return o.get(context.Background(), req.Req, req.Req.URL, o.getHeaders(u))
+	case ipfsURIScheme:
+		if o.MainCfg.IPFS.Gateway == "" {
+			o.Log.Warn("ipfs gateway is not configured", zap.String("url", req.Req.URL))
+			return transaction.ProtocolNotSupported, nil
+		}
+		gwURL, err := ipfsGatewayURL(o.MainCfg.IPFS.Gateway, u)
+		if err != nil {
+			o.Log.Warn("malformed oracle request", zap.String("url", req.Req.URL), zap.Error(err))
+			return transaction.Error, nil
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), o.MainCfg.IPFS.Timeout)
+		defer cancel()
+		return o.get(ctx, req.Req, gwURL, nil)
+	case neofs.URIScheme:
+		ctx, cancel := context.WithTimeout(context.Background(), o.MainCfg.NeoFS.Timeout)
+		defer cancel()
+		index := (int(req.ID) + attempts) % len(o.MainCfg.NeoFS.Nodes)
+		res, err := neofs.Get(ctx, priv, u, o.MainCfg.NeoFS.Nodes[index], uint64(o.MainCfg.MaxResponseSize))
+		if err != nil {
+			o.Log.Warn("oracle request failed", zap.String("url", req.Req.URL), zap.Error(err))
+			if errors.Is(err, neofs.ErrTooLarge) {
+				return transaction.ResponseTooLarge, nil
+			}
+			return transaction.Error, nil
+		}
+		return filterRequest(res, req.Req)
+	default:
+		o.Log.Warn("unknown oracle request scheme", zap.String("url", req.Req.URL))
+		return transaction.ProtocolNotSupported, nil
+	}
+}
+
+// get performs HTTP GET request to the specified URL and returns filtered
+// result with the response code.
+func (o *Oracle) get(ctx context.Context, req *state.OracleRequest, uri string, headers map[string]string) (transaction.OracleResponseCode, []byte) {
+	hr, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		o.Log.Warn("malformed oracle request", zap.String("url", req.URL), zap.Error(err))
+		return transaction.Error, nil
+	}
+	for k, v := range headers {
+		hr.Header.Set(k, v)
+	}
+	r, err := o.Client.Do(hr)
+	if err != nil {
+		o.Log.Warn("oracle request failed", zap.String("url", req.URL), zap.Error(err))
+		if errors.Is(err, errForbiddenRedirect) {
+			return transaction.Forbidden, nil
+		}
+		return transaction.Error, nil
+	}
+	switch r.StatusCode {
+	case http.StatusOK:
+		result, err := readResponse(r.Body, o.MainCfg.MaxResponseSize)
+		if err != nil {
+			o.Log.Warn("failed to read data for oracle request", zap.String("url", req.URL), zap.Error(err))
+			if errors.Is(err, ErrResponseTooLarge) {
+				return transaction.ResponseTooLarge, nil
+			}
+			return transaction.Error, nil
+		}
+		return filterRequest(result, req)
+	case http.StatusForbidden:
+		r.Body.Close()
+		return transaction.Forbidden, nil
+	case http.StatusNotFound:
+		r.Body.Close()
+		return transaction.NotFound, nil
+	case http.StatusRequestTimeout:
+		r.Body.Close()
+		return transaction.Timeout, nil
+	default:
+		r.Body.Close()
+		return transaction.Error, nil
+	}
+}
+
+// getHeaders returns additional headers configured for the specified URL.
+func (o *Oracle) getHeaders(u *url.URL) map[string]string {
+	var res map[string]string
+	for _, h := range o.MainCfg.HTTPHeaders {
+		if !matchURLPrefix(u, h.URLPrefix) {
+			continue
+		}
+		if res == nil {
+			res = make(map[string]string, len(h.Headers))
+		}
+		for k, v := range h.Headers {
+			res[k] = v
+		}
+	}
+	return res
+}
+
+// matchURLPrefix checks that URL has the same scheme and host as the prefix
+// and its path starts with prefix path.
+func matchURLPrefix(u *url.URL, prefix string) bool {
+	p, err := url.Parse(prefix)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(u.Scheme, p.Scheme) &&
+		strings.EqualFold(u.Host, p.Host) &&
+		strings.HasPrefix(u.EscapedPath(), p.EscapedPath())
+}
+
+// checkRedirect is used as http.Client redirect policy. It forbids redirects
+// from HTTPS to HTTP and redirects to other hosts that are not allowed by
+// URIValidator (unless AllowPrivateHost is set). Configured headers (copied
+// from the original request) are replaced with the ones configured for the
+// new URL.
+func (o *Oracle) checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxRedirects)
+	}
+	if strings.EqualFold(via[len(via)-1].URL.Scheme, "https") && !strings.EqualFold(req.URL.Scheme, "https") {
+		return fmt.Errorf("%w: to %s", errForbiddenRedirect, req.URL.Scheme)
+	}
+	if !o.MainCfg.AllowPrivateHost && !strings.EqualFold(req.URL.Host, via[0].URL.Host) {
+		if err := o.URIValidator(req.URL); err != nil {
+			return fmt.Errorf("%w: %v", errForbiddenRedirect, err)
+		}
+	}
+	for _, h := range o.MainCfg.HTTPHeaders {
+		for k := range h.Headers {
+			req.Header.Del(k)
+		}
+	}
+	for k, v := range o.getHeaders(req.URL) {
+		req.Header.Set(k, v)
+	}
+	return nil
+}
+
 func (o *Oracle) processFailedRequest(priv *keys.PrivateKey, req request) {
 	// Request is being processed again.
 	incTx := o.getResponse(req.ID, false)
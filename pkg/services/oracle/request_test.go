@@ -0,0 +1,103 @@
+package oracle
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchURLPrefix(t *testing.T) {
+	testCases := []struct {
+		uri, prefix string
+		match       bool
+	}{
+		{"https://api.example.com/v1/data", "https://api.example.com/", true},
+		{"https://api.example.com/v1/data", "https://api.example.com/v1/", true},
+		{"https://API.example.com/v1", "https://api.example.com", true},
+		{"https://api.example.com/v2/data", "https://api.example.com/v1/", false},
+		{"https://api.example.com.evil.com/", "https://api.example.com", false},
+		{"https://api.example.com@evil.com/", "https://api.example.com", false},
+		{"https://api.example.com:8443/", "https://api.example.com/", false},
+		{"http://api.example.com/", "https://api.example.com/", false},
+	}
+	for _, tc := range testCases {
+		u, err := url.ParseRequestURI(tc.uri)
+		require.NoError(t, err)
+		require.Equal(t, tc.match, matchURLPrefix(u, tc.prefix), "%s %s", tc.uri, tc.prefix)
+	}
+}
+
+func TestCheckRedirect(t *testing.T) {
+	o := &Oracle{Config: Config{MainCfg: config.OracleConfiguration{
+		HTTPHeaders: []config.OracleHeaders{
+			{URLPrefix: "https://api.example.com/", Headers: map[string]string{"X-Api-Key": "one"}},
+			{URLPrefix: "https://other.example.com/", Headers: map[string]string{"X-Other-Key": "two"}},
+			{URLPrefix: "https://api.example.com/private/", Headers: map[string]string{"X-Private-Key": "three"}},
+		},
+	}, URIValidator: func(u *url.URL) error {
+		if u.Hostname() == "private.example.com" {
+			return errors.New("private network")
+		}
+		return nil
+	}}}
+	newRequest := func(t *testing.T, uri string) *http.Request {
+		r, err := http.NewRequest(http.MethodGet, uri, nil)
+		require.NoError(t, err)
+		return r
+	}
+	orig := newRequest(t, "https://api.example.com/data")
+
+	t.Run("same host", func(t *testing.T) {
+		r := newRequest(t, "https://api.example.com/moved")
+		r.Header.Set("X-Api-Key", "one")
+		require.NoError(t, o.checkRedirect(r, []*http.Request{orig}))
+		require.Equal(t, "one", r.Header.Get("X-Api-Key"))
+	})
+	t.Run("same host, other prefix", func(t *testing.T) {
+		priv := newRequest(t, "https://api.example.com/private/data")
+		r := newRequest(t, "https://api.example.com/public/data")
+		r.Header.Set("X-Api-Key", "one")
+		r.Header.Set("X-Private-Key", "three")
+		require.NoError(t, o.checkRedirect(r, []*http.Request{priv}))
+		require.Equal(t, "one", r.Header.Get("X-Api-Key"))
+		require.Equal(t, "", r.Header.Get("X-Private-Key"))
+	})
+	t.Run("downgrade", func(t *testing.T) {
+		r := newRequest(t, "http://api.example.com/data")
+		err := o.checkRedirect(r, []*http.Request{orig})
+		require.True(t, errors.Is(err, errForbiddenRedirect))
+	})
+	t.Run("private host", func(t *testing.T) {
+		r := newRequest(t, "https://private.example.com/")
+		err := o.checkRedirect(r, []*http.Request{orig})
+		require.True(t, errors.Is(err, errForbiddenRedirect))
+
+		o.MainCfg.AllowPrivateHost = true
+		defer func() { o.MainCfg.AllowPrivateHost = false }()
+		require.NoError(t, o.checkRedirect(r, []*http.Request{orig}))
+	})
+	t.Run("other host", func(t *testing.T) {
+		r := newRequest(t, "https://evil.com/")
+		r.Header.Set("X-Api-Key", "one")
+		require.NoError(t, o.checkRedirect(r, []*http.Request{orig}))
+		require.Equal(t, "", r.Header.Get("X-Api-Key"))
+	})
+	t.Run("other configured host", func(t *testing.T) {
+		r := newRequest(t, "https://other.example.com/")
+		r.Header.Set("X-Api-Key", "one")
+		require.NoError(t, o.checkRedirect(r, []*http.Request{orig}))
+		require.Equal(t, "", r.Header.Get("X-Api-Key"))
+		require.Equal(t, "two", r.Header.Get("X-Other-Key"))
+	})
+	t.Run("too many redirects", func(t *testing.T) {
+		via := make([]*http.Request, maxRedirects)
+		for i := range via {
+			via[i] = orig
+		}
+		require.Error(t, o.checkRedirect(newRequest(t, "https://api.example.com/"), via))
+	})
+}
@@ -17,6 +17,10 @@ import (
 // RelayCallback represents callback for sending validated state roots.
 type RelayCallback = func(*payload.Extensible)
 
+// MismatchCallback is called when local state root doesn't match the one
+// signed by state validators.
+type MismatchCallback = func(error)
+
 // AddSignature adds state root signature.
 func (s *service) AddSignature(height uint32, validatorIndex int32, sig []byte) error {
 	if !s.MainCfg.Enabled {
@@ -83,7 +87,7 @@ func (s *service) sendValidatedRoot(r *state.MPTRoot, priv *keys.PrivateKey) {
 	ep := &payload.Extensible{
 		Category:        Category,
 		ValidBlockStart: r.Index,
-		ValidBlockEnd:   r.Index + transaction.MaxValidUntilBlockIncrement,
+		ValidBlockEnd:   r.Index + s.chain.GetConfig().MaxValidUntilBlockIncrement(r.Index),
 		Sender:          priv.GetScriptHash(),
 		Data:            w.Bytes(),
 		Witness: transaction.Witness{
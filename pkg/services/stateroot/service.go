@@ -24,6 +24,7 @@ type (
 		OnPayload(p *payload.Extensible) error
 		AddSignature(height uint32, validatorIndex int32, sig []byte) error
 		GetConfig() config.StateRoot
+		SetOnMismatch(f MismatchCallback)
 		Run()
 		Shutdown()
 	}
@@ -46,6 +47,7 @@ type (
 		incompleteRoots map[uint32]*incompleteRoot
 
 		onValidatedRoot RelayCallback
+		onMismatch      MismatchCallback
 		blockCh         chan *block.Block
 		done            chan struct{}
 	}
@@ -110,6 +112,9 @@ func (s *service) OnPayload(ep *payload.Extensible) error {
 		err := s.AddStateRoot(sr)
 		if errors.Is(err, stateroot.ErrStateMismatch) {
 			s.log.Error("can't add SV-signed state root", zap.Error(err))
+			if s.onMismatch != nil {
+				s.onMismatch(err)
+			}
 			return nil
 		}
 		return err
@@ -120,6 +125,12 @@ func (s *service) OnPayload(ep *payload.Extensible) error {
 	return nil
 }
 
+// SetOnMismatch implements Service interface. It must be called before
+// any payloads are processed.
+func (s *service) SetOnMismatch(f MismatchCallback) {
+	s.onMismatch = f
+}
+
 func (s *service) updateValidators(height uint32, pubs keys.PublicKeys) {
 	s.accMtx.Lock()
 	defer s.accMtx.Unlock()
@@ -81,7 +81,7 @@ func (s *service) signAndSend(r *state.MPTRoot) error {
 	e := &payload.Extensible{
 		Category:        Category,
 		ValidBlockStart: r.Index,
-		ValidBlockEnd:   r.Index + transaction.MaxValidUntilBlockIncrement,
+		ValidBlockEnd:   r.Index + s.chain.GetConfig().MaxValidUntilBlockIncrement(r.Index),
 		Sender:          s.getAccount().PrivateKey().GetScriptHash(),
 		Data:            w.Bytes(),
 		Witness: transaction.Witness{
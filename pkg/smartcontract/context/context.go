@@ -76,6 +76,26 @@ func (c *ParameterContext) GetWitness(h util.Uint160) (*transaction.Witness, err
 	}, nil
 }
 
+// GetCompleteTransaction returns the transaction from the context with
+// witnesses for all of its signers, it fails if the context doesn't contain a
+// transaction or some of the signatures are missing.
+func (c *ParameterContext) GetCompleteTransaction() (*transaction.Transaction, error) {
+	tx, ok := c.Verifiable.(*transaction.Transaction)
+	if !ok {
+		return nil, errors.New("verifiable item is not a transaction")
+	}
+	scripts := make([]transaction.Witness, len(tx.Signers))
+	for i := range tx.Signers {
+		w, err := c.GetWitness(tx.Signers[i].Account)
+		if err != nil {
+			return nil, fmt.Errorf("can't create witness for signer #%d: %w", i, err)
+		}
+		scripts[i] = *w
+	}
+	tx.Scripts = scripts
+	return tx, nil
+}
+
 // AddSignature adds a signature for the specified contract and public key.
 func (c *ParameterContext) AddSignature(h util.Uint160, ctr *wallet.Contract, pub *keys.PublicKey, sig []byte) error {
 	item := c.getItemForContract(h, ctr)
@@ -117,6 +117,31 @@ func TestParameterContext_AddSignatureMultisig(t *testing.T) {
 	})
 }
 
+func TestParameterContext_GetCompleteTransaction(t *testing.T) {
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	tx := getContractTx()
+	tx.Signers = []transaction.Signer{{Account: priv.GetScriptHash()}}
+
+	c := NewParameterContext("Neo.Core.ContractTransaction", netmode.UnitTestNet, tx)
+	_, err = c.GetCompleteTransaction()
+	require.Error(t, err)
+
+	ctr := &wallet.Contract{
+		Script:     priv.PublicKey().GetVerificationScript(),
+		Parameters: []wallet.ContractParam{newParam(smartcontract.SignatureType, "parameter0")},
+	}
+	sig := priv.SignHashable(uint32(c.Network), tx)
+	require.NoError(t, c.AddSignature(ctr.ScriptHash(), ctr, priv.PublicKey(), sig))
+
+	actual, err := c.GetCompleteTransaction()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(actual.Scripts))
+	v := newTestVM(&actual.Scripts[0], actual)
+	require.NoError(t, v.Run())
+	require.Equal(t, true, v.Estack().Pop().Value())
+}
+
 func newTestVM(w *transaction.Witness, tx *transaction.Transaction) *vm.VM {
 	ic := &interop.Context{Network: uint32(netmode.UnitTestNet), Container: tx}
 	crypto.Register(ic)
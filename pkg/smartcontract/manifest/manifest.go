@@ -20,10 +20,18 @@ const (
 	NEP11StandardName = "NEP-11"
 	// NEP17StandardName represents the name of NEP17 smartcontract standard.
 	NEP17StandardName = "NEP-17"
+	// NEP24StandardName represents the name of NEP24 NFT royalty standard.
+	NEP24StandardName = "NEP-24"
 	// NEP11Payable represents the name of contract interface which can receive NEP-11 tokens.
 	NEP11Payable = "NEP-11-Payable"
 	// NEP17Payable represents the name of contract interface which can receive NEP-17 tokens.
 	NEP17Payable = "NEP-17-Payable"
+	// NEP26StandardName represents the name of NEP26 NEP-11 receiver standard,
+	// it's the same as NEP11Payable.
+	NEP26StandardName = "NEP-26"
+	// NEP27StandardName represents the name of NEP27 NEP-17 receiver standard,
+	// it's the same as NEP17Payable.
+	NEP27StandardName = "NEP-27"
 )
 
 // Manifest represens contract metadata.
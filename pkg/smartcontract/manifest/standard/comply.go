@@ -21,8 +21,11 @@ var (
 var checks = map[string][]*Standard{
 	manifest.NEP11StandardName: {nep11NonDivisible, nep11Divisible},
 	manifest.NEP17StandardName: {nep17},
+	manifest.NEP24StandardName: {nep24},
 	manifest.NEP11Payable:      {nep11payable},
 	manifest.NEP17Payable:      {nep17payable},
+	manifest.NEP26StandardName: {nep11payable},
+	manifest.NEP27StandardName: {nep17payable},
 }
 
 // Check checks if manifest complies with all provided standards.
@@ -36,6 +39,26 @@ func CheckABI(m *manifest.Manifest, standards ...string) error {
 	return check(m, false, standards...)
 }
 
+// Report is similar to CheckABI but returns all compliance problems found
+// instead of the first one. If a standard has several variants (like divisible
+// and non-divisible NEP-11), problems of the closest one are returned.
+func Report(m *manifest.Manifest, standards ...string) []error {
+	var res []error
+	for i := range standards {
+		var best []error
+		for j, st := range checks[standards[i]] {
+			errs := complyAll(m, false, st)
+			if j == 0 || len(errs) < len(best) {
+				best = errs
+			}
+		}
+		for j := range best {
+			res = append(res, fmt.Errorf("%s: %w", standards[i], best[j]))
+		}
+	}
+	return res
+}
+
 func check(m *manifest.Manifest, checkNames bool, standards ...string) error {
 	for i := range standards {
 		ss, ok := checks[standards[i]]
@@ -66,42 +89,53 @@ func ComplyABI(m *manifest.Manifest, st *Standard) error {
 }
 
 func comply(m *manifest.Manifest, checkNames bool, st *Standard) error {
+	if errs := complyAll(m, checkNames, st); len(errs) != 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// complyAll returns all errors found while checking m against st.
+func complyAll(m *manifest.Manifest, checkNames bool, st *Standard) []error {
+	var errs []error
 	if st.Base != nil {
-		if err := comply(m, checkNames, st.Base); err != nil {
-			return err
-		}
+		errs = append(errs, complyAll(m, checkNames, st.Base)...)
 	}
 	for _, stm := range st.ABI.Methods {
 		if err := checkMethod(m, &stm, false, checkNames); err != nil {
-			return err
+			errs = append(errs, err)
 		}
 	}
 	for _, ste := range st.ABI.Events {
 		name := ste.Name
 		ed := m.ABI.GetEvent(name)
 		if ed == nil {
-			return fmt.Errorf("%w: event '%s'", ErrEventMissing, name)
+			errs = append(errs, fmt.Errorf("%w: event '%s'", ErrEventMissing, name))
+			continue
 		} else if len(ste.Parameters) != len(ed.Parameters) {
-			return fmt.Errorf("%w: event '%s' (expected %d, got %d)", ErrInvalidParameterCount,
-				name, len(ste.Parameters), len(ed.Parameters))
+			errs = append(errs, fmt.Errorf("%w: event '%s' (expected %d, got %d)", ErrInvalidParameterCount,
+				name, len(ste.Parameters), len(ed.Parameters)))
+			continue
 		}
 		for i := range ste.Parameters {
 			if checkNames && ste.Parameters[i].Name != ed.Parameters[i].Name {
-				return fmt.Errorf("%w: event '%s'[%d] (expected %s, got %s)", ErrInvalidParameterName,
-					name, i, ste.Parameters[i].Name, ed.Parameters[i].Name)
+				errs = append(errs, fmt.Errorf("%w: event '%s'[%d] (expected %s, got %s)", ErrInvalidParameterName,
+					name, i, ste.Parameters[i].Name, ed.Parameters[i].Name))
+				break
 			}
 			if ste.Parameters[i].Type != ed.Parameters[i].Type {
-				return fmt.Errorf("%w: event '%s' (expected %s, got %s)", ErrInvalidParameterType,
-					name, ste.Parameters[i].Type, ed.Parameters[i].Type)
+				errs = append(errs, fmt.Errorf("%w: event '%s' (expected %s, got %s)", ErrInvalidParameterType,
+					name, ste.Parameters[i].Type, ed.Parameters[i].Type))
+				break
 			}
 		}
 	}
 	for _, stm := range st.Optional {
 		if err := checkMethod(m, &stm, true, checkNames); err != nil {
-			return err
+			errs = append(errs, err)
 		}
 	}
-	return nil
+	return errs
 }
 
 func checkMethod(m *manifest.Manifest, expected *manifest.Method,
@@ -129,7 +163,7 @@ func checkMethod(m *manifest.Manifest, expected *manifest.Method,
 		}
 	}
 	if expected.Safe != actual.Safe {
-		return fmt.Errorf("%w: expected %t", ErrSafeMethodMismatch, expected.Safe)
+		return fmt.Errorf("%w: '%s' (expected %t)", ErrSafeMethodMismatch, expected.Name, expected.Safe)
 	}
 	return nil
 }
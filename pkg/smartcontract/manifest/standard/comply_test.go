@@ -177,3 +177,43 @@ func TestOptional(t *testing.T) {
 		require.NoError(t, Comply(&actual, &m))
 	})
 }
+
+func TestCheckNEP24(t *testing.T) {
+	m := manifest.NewManifest("Test")
+	require.Error(t, Check(m, manifest.NEP24StandardName))
+
+	m.ABI.Methods = append(m.ABI.Methods, nep24.ABI.Methods...)
+	require.NoError(t, Check(m, manifest.NEP24StandardName))
+
+	t.Run("string token ID", func(t *testing.T) {
+		m := manifest.NewManifest("Test")
+		m.ABI.Methods = append(m.ABI.Methods, nep24.ABI.Methods[0])
+		m.ABI.Methods[0].Parameters = append([]manifest.Parameter{}, nep24.ABI.Methods[0].Parameters...)
+		m.ABI.Methods[0].Parameters[0].Type = smartcontract.StringType
+		require.True(t, errors.Is(Check(m, manifest.NEP24StandardName), ErrInvalidParameterType))
+	})
+}
+
+func TestReport(t *testing.T) {
+	m := manifest.NewManifest("Test")
+	require.Nil(t, Report(m, "unknown"))
+
+	errs := Report(m, manifest.NEP17StandardName)
+	require.Equal(t, len(decimalTokenBase.ABI.Methods)+len(nep17.ABI.Methods)+len(nep17.ABI.Events), len(errs))
+	require.True(t, errors.Is(errs[0], ErrMethodMissing))
+	require.True(t, errors.Is(errs[len(errs)-1], ErrEventMissing))
+
+	m.ABI.Methods = append(m.ABI.Methods, decimalTokenBase.ABI.Methods...)
+	m.ABI.Methods = append(m.ABI.Methods, nep17.ABI.Methods...)
+	m.ABI.Events = append(m.ABI.Events, nep17.ABI.Events...)
+	m.ABI.Methods[0].Safe = false
+	params := append([]manifest.Parameter{}, m.ABI.Events[0].Parameters...)
+	params[2].Type = smartcontract.StringType
+	m.ABI.Events[0].Parameters = params
+	errs = Report(m, manifest.NEP17StandardName, manifest.NEP27StandardName)
+	require.Equal(t, 3, len(errs))
+	require.True(t, errors.Is(errs[0], ErrSafeMethodMismatch))
+	require.True(t, errors.Is(errs[1], ErrInvalidParameterType))
+	require.True(t, errors.Is(errs[2], ErrMethodMissing))
+	require.Contains(t, errs[2].Error(), manifest.NEP27StandardName)
+}
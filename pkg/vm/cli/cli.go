@@ -16,24 +16,32 @@ import (
 
 	"github.com/abiosoft/readline"
 	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/core/fee"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/bigint"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/debugger"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/replay"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"gopkg.in/abiosoft/ishell.v2"
 )
 
 const (
-	vmKey       = "vm"
-	manifestKey = "manifest"
-	boolType    = "bool"
-	boolFalse   = "false"
-	boolTrue    = "true"
-	intType     = "int"
-	stringType  = "string"
-	exitFunc    = "exitFunc"
+	vmKey        = "vm"
+	manifestKey  = "manifest"
+	debugInfoKey = "debugInfo"
+	watchesKey   = "watches"
+	syscallKey   = "syscallHandler"
+	boolType     = "bool"
+	boolFalse    = "false"
+	boolTrue     = "true"
+	intType      = "int"
+	stringType   = "string"
+	exitFunc     = "exitFunc"
 )
 
 var commands = []*ishell.Cmd{
@@ -52,9 +60,12 @@ var commands = []*ishell.Cmd{
 	{
 		Name: "break",
 		Help: "Place a breakpoint",
-		LongHelp: `Usage: break <ip>
-<ip> is mandatory parameter, example:
-> break 12`,
+		LongHelp: `Usage: break <ip> | [<file>:]<line>
+<ip> is an instruction number, source line can be used instead of it if
+the program was loaded with debug info (file can be omitted for single-file
+programs), example:
+> break 12
+> break contract.go:21`,
 		Func: handleBreak,
 	},
 	{
@@ -78,8 +89,10 @@ var commands = []*ishell.Cmd{
 	{
 		Name: "loadnef",
 		Help: "Load a NEF-consistent script into the VM",
-		LongHelp: `Usage: loadnef <file> <manifest>
-both parameters are mandatory, example:
+		LongHelp: `Usage: loadnef <file> <manifest> [<debuginfo>]
+<file> and <manifest> parameters are mandatory, <debuginfo> is an optional
+debug info file (see 'contract compile --debug') enabling source-level
+debugging, example:
 > loadnef /path/to/script.nef /path/to/manifest.json`,
 		Func: handleLoadNEF,
 	},
@@ -107,6 +120,16 @@ both parameters are mandatory, example:
 > loadgo /path/to/file.go`,
 		Func: handleLoadGo,
 	},
+	{
+		Name: "loadrecord",
+		Help: "Load an invocation record for offline replay into the VM",
+		LongHelp: `Usage: loadrecord <file>
+<file> is mandatory parameter, it's a JSON invocation record (see 'record'
+field of invokefunction/invokescript RPC diagnostics). All syscalls and
+contract calls of the invocation reproduce their recorded results, example:
+> loadrecord /path/to/record.json`,
+		Func: handleLoadRecord,
+	},
 	{
 		Name: "parse",
 		Help: "Parse provided argument and convert it into other possible formats",
@@ -157,7 +180,8 @@ Example:
 		Name: "stepinto",
 		Help: "Stepinto instruction to take in the debugger",
 		LongHelp: `Usage: stepInto
-example:
+steps to the next source line (entering calls) if debug info is loaded or
+executes one instruction otherwise, example:
 > stepinto`,
 		Func: handleStepInto,
 	},
@@ -173,16 +197,58 @@ example:
 		Name: "stepover",
 		Help: "Stepover instruction to take in the debugger",
 		LongHelp: `Usage: stepOver
-example:
+steps to the next source line (without entering calls) if debug info is
+loaded or to the next instruction otherwise, example:
 > stepover`,
 		Func: handleStepOver,
 	},
+	{
+		Name:     "backtrace",
+		Help:     "Show invocation stack with source locations",
+		LongHelp: "Show invocation stack frames with instruction numbers and source locations (if debug info is loaded)",
+		Func:     handleBacktrace,
+	},
+	{
+		Name: "watch",
+		Help: "Add watch expression or show watched values",
+		LongHelp: `Usage: watch [<expr>]
+<expr> is either an evaluation stack item (estack[<n>]), an argument, local
+or static slot item (arg[<n>], local[<n>], static[<n>]) or a current method
+parameter name (if debug info is loaded). Values of all watch expressions
+are shown after every stop and when no expression is given, example:
+> watch local[0]`,
+		Func: handleWatch,
+	},
+	{
+		Name: "unwatch",
+		Help: "Remove watch expression",
+		LongHelp: `Usage: unwatch <n>
+<n> is a watch expression number, example:
+> unwatch 0`,
+		Func: handleUnwatch,
+	},
 	{
 		Name:     "ops",
 		Help:     "Dump opcodes of the current loaded program",
 		LongHelp: "Dump opcodes of the current loaded program",
 		Func:     handleOps,
 	},
+	{
+		Name:     "profile",
+		Help:     "Show execution statistics of the current loaded program",
+		LongHelp: "Show per-opcode execution counts, GAS consumed and time spent since the program was loaded",
+		Func:     handleProfile,
+	},
+	{
+		Name: "coverage",
+		Help: "Write source code coverage profile of the current loaded program",
+		LongHelp: `Usage: coverage <file>
+<file> is mandatory parameter, the program must be loaded with debug info.
+Coverage profile (compatible with 'go tool cover') is collected since the
+program was loaded, example:
+> coverage cover.out`,
+		Func: handleCoverage,
+	},
 }
 
 // Various errors.
@@ -213,8 +279,14 @@ func NewWithConfig(printLogo bool, onExit func(int), c *readline.Config) *VMCLI
 		shell:     ishell.NewWithConfig(c),
 		printLogo: printLogo,
 	}
+	vmcli.vm.GasLimit = -1
+	vmcli.vm.SetPriceGetter(getPrice)
+	vmcli.vm.EnableProfiling()
+	vmcli.vm.EnableCoverage()
 	vmcli.shell.Set(vmKey, vmcli.vm)
 	vmcli.shell.Set(manifestKey, new(manifest.Manifest))
+	vmcli.shell.Set(watchesKey, new([]string))
+	vmcli.shell.Set(syscallKey, vmcli.vm.SyscallHandler)
 	vmcli.shell.Set(exitFunc, onExit)
 	for _, c := range commands {
 		vmcli.shell.AddCmd(c)
@@ -245,6 +317,17 @@ func checkVMIsReady(c *ishell.Context) bool {
 	return true
 }
 
+// checkVMWasLoaded is similar to checkVMIsReady, but it also accepts VM that
+// has finished the program execution.
+func checkVMWasLoaded(c *ishell.Context) bool {
+	v := getVMFromContext(c)
+	if v == nil || !v.Ready() && !v.HasStopped() {
+		c.Err(errors.New("VM is not ready: no program loaded"))
+		return false
+	}
+	return true
+}
+
 func handleExit(c *ishell.Context) {
 	c.Println("Bye!")
 	c.Get(exitFunc).(func(int))(0)
@@ -259,6 +342,7 @@ func handleIP(c *ishell.Context) {
 	if ctx.NextIP() < ctx.LenInstr() {
 		ip, opcode := v.Context().NextInstr()
 		c.Printf("instruction pointer at %d (%s)\n", ip, opcode)
+		printLocation(c, v)
 	} else {
 		c.Println("execution has finished")
 	}
@@ -275,11 +359,14 @@ func handleBreak(c *ishell.Context) {
 	}
 	n, err := strconv.Atoi(c.Args[0])
 	if err != nil {
-		c.Err(fmt.Errorf("%w: %v", ErrInvalidParameter, err))
-		return
+		n, err = parseSourceLocation(getDebugInfoFromContext(c), c.Args[0])
+		if err != nil {
+			c.Err(err)
+			return
+		}
 	}
 
-	v.AddBreakPoint(n)
+	v.AddBreakPointAll(n)
 	c.Printf("breakpoint added at instruction %d\n", n)
 }
 
@@ -294,17 +381,28 @@ func handleLoadNEF(c *ishell.Context) {
 		c.Err(fmt.Errorf("%w: <file> <manifest>", ErrMissingParameter))
 		return
 	}
+	resetInterop(c, v)
 	if err := v.LoadFile(c.Args[0]); err != nil {
 		c.Err(err)
 		return
 	}
+	v.EnableCoverage()
 	m, err := getManifestFromFile(c.Args[1])
 	if err != nil {
 		c.Err(err)
 		return
 	}
+	var di *compiler.DebugInfo
+	if len(c.Args) > 2 {
+		di, err = getDebugInfoFromFile(c.Args[2])
+		if err != nil {
+			c.Err(err)
+			return
+		}
+	}
 	c.Printf("READY: loaded %d instructions\n", v.Context().LenInstr())
 	setManifestInContext(c, m)
+	setDebugInfoInContext(c, di)
 	changePrompt(c, v)
 }
 
@@ -319,7 +417,10 @@ func handleLoadBase64(c *ishell.Context) {
 		c.Err(fmt.Errorf("%w: %v", ErrInvalidParameter, err))
 		return
 	}
+	resetInterop(c, v)
 	v.Load(b)
+	v.EnableCoverage()
+	setDebugInfoInContext(c, nil)
 	c.Printf("READY: loaded %d instructions\n", v.Context().LenInstr())
 	changePrompt(c, v)
 }
@@ -335,7 +436,10 @@ func handleLoadHex(c *ishell.Context) {
 		c.Err(fmt.Errorf("%w: %v", ErrInvalidParameter, err))
 		return
 	}
+	resetInterop(c, v)
 	v.Load(b)
+	v.EnableCoverage()
+	setDebugInfoInContext(c, nil)
 	c.Printf("READY: loaded %d instructions\n", v.Context().LenInstr())
 	changePrompt(c, v)
 }
@@ -359,12 +463,44 @@ func handleLoadGo(c *ishell.Context) {
 		return
 	}
 	setManifestInContext(c, m)
+	setDebugInfoInContext(c, di)
 
+	resetInterop(c, v)
 	v.Load(b)
+	v.EnableCoverage()
 	c.Printf("READY: loaded %d instructions\n", v.Context().LenInstr())
 	changePrompt(c, v)
 }
 
+func handleLoadRecord(c *ishell.Context) {
+	v := getVMFromContext(c)
+	if len(c.Args) < 1 {
+		c.Err(fmt.Errorf("%w: <file>", ErrMissingParameter))
+		return
+	}
+	bs, err := ioutil.ReadFile(c.Args[0])
+	if err != nil {
+		c.Err(fmt.Errorf("%w: can't read record", ErrInvalidParameter))
+		return
+	}
+	r := new(replay.Record)
+	if err := json.Unmarshal(bs, r); err != nil {
+		c.Err(fmt.Errorf("%w: can't unmarshal record", ErrInvalidParameter))
+		return
+	}
+	replay.Load(v, r)
+	v.EnableCoverage()
+	setDebugInfoInContext(c, nil)
+	c.Printf("READY: loaded %d instructions\n", v.Context().LenInstr())
+	changePrompt(c, v)
+}
+
+// resetInterop restores VM interop handlers replaced by loadrecord.
+func resetInterop(c *ishell.Context, v *vm.VM) {
+	v.SyscallHandler = c.Get(syscallKey).(func(*vm.VM, uint32) error)
+	v.LoadToken = nil
+}
+
 func getManifestFromFile(name string) (*manifest.Manifest, error) {
 	bs, err := ioutil.ReadFile(name)
 	if err != nil {
@@ -378,6 +514,19 @@ func getManifestFromFile(name string) (*manifest.Manifest, error) {
 	return &m, nil
 }
 
+func getDebugInfoFromFile(name string) (*compiler.DebugInfo, error) {
+	bs, err := ioutil.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: can't read debug info", ErrInvalidParameter)
+	}
+
+	var di compiler.DebugInfo
+	if err := json.Unmarshal(bs, &di); err != nil {
+		return nil, fmt.Errorf("%w: can't unmarshal debug info", ErrInvalidParameter)
+	}
+	return &di, nil
+}
+
 func handleRun(c *ishell.Context) {
 	v := getVMFromContext(c)
 	m := getManifestFromContext(c)
@@ -441,6 +590,10 @@ func runVMWithHandling(c *ishell.Context, v *vm.VM) {
 	if message != "" {
 		c.Println(message)
 	}
+	if v.AtBreakpoint() {
+		printLocation(c, v)
+		printWatches(c, v)
+	}
 }
 
 func handleCont(c *ishell.Context) {
@@ -491,19 +644,23 @@ func handleStepType(c *ishell.Context, stepType string) {
 		return
 	}
 	v := getVMFromContext(c)
+	di := getDebugInfoFromContext(c)
 	var err error
-	switch stepType {
-	case "into":
-		err = v.StepInto()
-	case "out":
+	switch {
+	case stepType == "out":
 		err = v.StepOut()
-	case "over":
+	case di != nil:
+		err = debugger.StepSource(v, di, stepType == "into")
+	case stepType == "into":
+		err = v.StepInto()
+	case stepType == "over":
 		err = v.StepOver()
 	}
 	if err != nil {
 		c.Err(err)
 	} else {
 		handleIP(c)
+		printWatches(c, v)
 	}
 	changePrompt(c, v)
 }
@@ -518,6 +675,59 @@ func handleOps(c *ishell.Context) {
 	c.Println(out.String())
 }
 
+func handleProfile(c *ishell.Context) {
+	if !checkVMWasLoaded(c) {
+		return
+	}
+	v := getVMFromContext(c)
+	out := bytes.NewBuffer(nil)
+	v.PrintProfile(out)
+	c.Println(out.String())
+}
+
+func handleCoverage(c *ishell.Context) {
+	if !checkVMWasLoaded(c) {
+		return
+	}
+	if len(c.Args) != 1 {
+		c.Err(fmt.Errorf("%w: <file>", ErrMissingParameter))
+		return
+	}
+	di := getDebugInfoFromContext(c)
+	if di == nil {
+		c.Err(ErrNoDebugInfo)
+		return
+	}
+	// Only one program is loaded at a time and coverage is reset on
+	// every load, so all collected data belongs to it.
+	counts := make(map[int]int)
+	for _, m := range getVMFromContext(c).Coverage() {
+		for ip, n := range m {
+			counts[ip] += n
+		}
+	}
+	f, err := os.Create(c.Args[0])
+	if err != nil {
+		c.Err(err)
+		return
+	}
+	err = debugger.WriteCoverProfile(f, di, counts)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		c.Err(err)
+		return
+	}
+	c.Printf("coverage profile written to %s\n", c.Args[0])
+}
+
+// getPrice returns opcode price using default execution fee factor. Syscalls
+// are not priced as they can't be executed here.
+func getPrice(op opcode.Opcode, _ []byte) int64 {
+	return fee.Opcode(interop.DefaultBaseExecFee, op)
+}
+
 func changePrompt(c ishell.Actions, v *vm.VM) {
 	if v.Ready() && v.Context().NextIP() >= 0 && v.Context().NextIP() < v.Context().LenInstr() {
 		c.SetPrompt(fmt.Sprintf("NEO-GO-VM %d > ", v.Context().NextIP()))
@@ -25,6 +25,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/replay"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/atomic"
 )
@@ -317,6 +318,27 @@ func TestPrintOps(t *testing.T) {
 	e.checkNextLine(t, "10.*PUSHDATA1.*010203")
 }
 
+func TestProfile(t *testing.T) {
+	script := hex.EncodeToString([]byte{
+		byte(opcode.PUSH1), byte(opcode.PUSH2), byte(opcode.ADD),
+	})
+	e := newTestVMCLI(t)
+	e.runProg(t,
+		"profile",
+		"loadhex "+script,
+		"run",
+		"profile")
+
+	e.checkNextLine(t, "no program loaded")
+	e.checkNextLine(t, "READY: loaded \\d+ instructions")
+	e.checkStack(t, 3)
+	e.checkNextLine(t, "OPCODE.*COUNT.*GAS.*TIME")
+	e.checkNextLine(t, "ADD\\s+1\\s+240\\s")
+	e.checkNextLine(t, "PUSH1\\s+1\\s+30\\s")
+	e.checkNextLine(t, "PUSH2\\s+1\\s+30\\s")
+	e.checkNextLine(t, "RET\\s+1\\s+0\\s")
+}
+
 func TestLoadAbort(t *testing.T) {
 	e := newTestVMCLI(t)
 	e.runProg(t,
@@ -434,6 +456,158 @@ func TestStepIntoOverOut(t *testing.T) {
 	e.checkStack(t, 5)
 }
 
+func TestSourceDebugger(t *testing.T) {
+	src := "package kek\n" +
+		"func Main(a, b int) int {\n" +
+		"	c := a + b\n" +
+		"	return sum(c, 1)\n" +
+		"}\n" +
+		"func sum(x, y int) int {\n" +
+		"	z := x + y\n" +
+		"	return z\n" +
+		"}\n"
+	tmpDir := path.Join(os.TempDir(), "vmclidebugtest")
+	require.NoError(t, os.Mkdir(tmpDir, os.ModePerm))
+	t.Cleanup(func() {
+		os.RemoveAll(tmpDir)
+	})
+	filename := path.Join(tmpDir, "vmdebugtest.go")
+	require.NoError(t, ioutil.WriteFile(filename, []byte(src), os.ModePerm))
+
+	e := newTestVMCLI(t)
+	e.runProg(t,
+		"loadgo "+filename,
+		"break vmdebugtest.go:100",
+		"break vmdebugtest.go:7",
+		"watch x",
+		"run main 2 3",
+		"backtrace",
+		"stepover",
+		"stepover",
+		"unwatch 1",
+		"unwatch 0",
+		"cont")
+
+	e.checkNextLine(t, "READY: loaded \\d+ instructions")
+	e.checkError(t, ErrInvalidParameter)
+	e.checkNextLine(t, "breakpoint added at instruction \\d+")
+	e.checkNextLine(t, "#0 x = <unknown parameter>")
+
+	e.checkNextLine(t, "at breakpoint \\d+")
+	e.checkNextLine(t, "kek.sum at .*vmdebugtest.go:7")
+	e.checkNextLine(t, "7\\s+z := x \\+ y")
+	e.checkNextLine(t, `#0 x = {"type":"Integer","value":"5"}`)
+
+	e.checkNextLine(t, "#0\\s+\\d+\\s+kek.sum at .*vmdebugtest.go:7")
+	e.checkNextLine(t, "#1\\s+\\d+\\s+kek.Main at .*vmdebugtest.go:4")
+
+	e.checkNextLine(t, "instruction pointer at \\d+ \\(RET\\)")
+	e.checkNextLine(t, "kek.sum at .*vmdebugtest.go:8")
+	e.checkNextLine(t, "8\\s+return z")
+	e.checkNextLine(t, `#0 x = {"type":"Integer","value":"5"}`)
+
+	e.checkNextLine(t, "instruction pointer at \\d+ \\(RET\\)")
+	e.checkNextLine(t, "kek.Main at .*vmdebugtest.go:4")
+	e.checkNextLine(t, "4\\s+return sum\\(c, 1\\)")
+	e.checkNextLine(t, "#0 x = <unknown parameter>")
+
+	e.checkError(t, ErrInvalidParameter)
+	e.checkStack(t, 6)
+}
+
+func TestCoverage(t *testing.T) {
+	src := "package kek\n" +
+		"func Main(a int) int {\n" +
+		"	b := 0\n" +
+		"	if a > 0 {\n" +
+		"		b = 1\n" +
+		"	} else {\n" +
+		"		b = 2\n" +
+		"	}\n" +
+		"	return b\n" +
+		"}\n"
+	tmpDir := path.Join(os.TempDir(), "vmclicovertest")
+	require.NoError(t, os.Mkdir(tmpDir, os.ModePerm))
+	t.Cleanup(func() {
+		os.RemoveAll(tmpDir)
+	})
+	filename := path.Join(tmpDir, "vmcovertest.go")
+	require.NoError(t, ioutil.WriteFile(filename, []byte(src), os.ModePerm))
+	profile := path.Join(tmpDir, "cover.out")
+
+	e := newTestVMCLI(t)
+	e.runProg(t,
+		"coverage "+profile,
+		"loadhex "+hex.EncodeToString([]byte{byte(opcode.PUSH1)}),
+		"coverage "+profile,
+		"loadgo "+filename,
+		"run main 5",
+		"coverage",
+		"coverage "+profile)
+
+	e.checkNextLine(t, "no program loaded")
+	e.checkNextLine(t, "READY: loaded \\d+ instructions")
+	e.checkError(t, ErrNoDebugInfo)
+	e.checkNextLine(t, "READY: loaded \\d+ instructions")
+	e.checkStack(t, 1)
+	e.checkError(t, ErrMissingParameter)
+	e.checkNextLine(t, "coverage profile written to "+profile)
+
+	data, err := ioutil.ReadFile(profile)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Equal(t, "mode: count", lines[0])
+	require.Regexp(t, "vmcovertest.go:3\\.\\d+,3\\.\\d+ 1 1$", lines[1])
+	require.Regexp(t, "vmcovertest.go:5\\.\\d+,5\\.\\d+ 1 1$", lines[2])
+	require.Regexp(t, "vmcovertest.go:7\\.\\d+,7\\.\\d+ 1 0$", lines[3])
+	require.Regexp(t, "vmcovertest.go:9\\.\\d+,9\\.\\d+ 1 1$", lines[4])
+}
+
+func TestLoadRecord(t *testing.T) {
+	w := io.NewBufBinWriter()
+	emit.Opcodes(w.BinWriter, opcode.PUSH1)
+	emit.Syscall(w.BinWriter, interopnames.SystemRuntimeGetTime)
+	v := vm.New()
+	v.SyscallHandler = func(v *vm.VM, id uint32) error {
+		v.Estack().PushVal(1234)
+		return nil
+	}
+	v.Load(w.Bytes())
+	rec := replay.NewRecorder(v)
+	require.NoError(t, v.Run())
+	r, err := rec.Record()
+	require.NoError(t, err)
+	data, err := json.Marshal(r)
+	require.NoError(t, err)
+
+	tmpDir := path.Join(os.TempDir(), "vmclirecordtest")
+	require.NoError(t, os.Mkdir(tmpDir, os.ModePerm))
+	t.Cleanup(func() {
+		os.RemoveAll(tmpDir)
+	})
+	filename := path.Join(tmpDir, "record.json")
+	require.NoError(t, ioutil.WriteFile(filename, data, os.ModePerm))
+	badFile := path.Join(tmpDir, "bad.json")
+	require.NoError(t, ioutil.WriteFile(badFile, []byte("{"), os.ModePerm))
+
+	e := newTestVMCLI(t)
+	e.runProg(t,
+		"loadrecord",
+		"loadrecord "+badFile,
+		"loadrecord "+filename,
+		"run",
+		"loadhex "+hex.EncodeToString(w.Bytes()),
+		"run")
+
+	e.checkError(t, ErrMissingParameter)
+	e.checkError(t, ErrInvalidParameter)
+	e.checkNextLine(t, "READY: loaded \\d+ instructions")
+	e.checkStack(t, 1, 1234)
+	e.checkNextLine(t, "READY: loaded \\d+ instructions")
+	// Default handler doesn't support this syscall.
+	e.checkNextLine(t, "Error:.*")
+}
+
 // `Parse` output is written via `tabwriter` so if any problems
 // are encountered in this test, try to replace ' ' with '\\s+'.
 func TestParse(t *testing.T) {
@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/debugger"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"gopkg.in/abiosoft/ishell.v2"
+)
+
+// ErrNoDebugInfo is returned for source-level commands when the program was
+// loaded without debug information.
+var ErrNoDebugInfo = debugger.ErrNoDebugInfo
+
+func getDebugInfoFromContext(c *ishell.Context) *compiler.DebugInfo {
+	di, _ := c.Get(debugInfoKey).(*compiler.DebugInfo)
+	return di
+}
+
+func setDebugInfoInContext(c *ishell.Context, di *compiler.DebugInfo) {
+	c.Set(debugInfoKey, di)
+}
+
+func getWatchesFromContext(c *ishell.Context) *[]string {
+	return c.Get(watchesKey).(*[]string)
+}
+
+// printLocation prints source location of the next instruction (along with
+// the source code line if it's available) if there is debug info loaded.
+func printLocation(c *ishell.Context, v *vm.VM) {
+	di := getDebugInfoFromContext(c)
+	ctx := v.Context()
+	if di == nil || ctx == nil {
+		return
+	}
+	m, sp, _ := debugger.FindSeqPoint(di, ctx.NextIP())
+	if m == nil {
+		return
+	}
+	c.Println(debugger.FormatLocation(di, ctx.NextIP()))
+	if doc := debugger.Document(di, sp); doc != "" {
+		if line := debugger.SourceLine(doc, sp.StartLine); line != "" {
+			c.Printf("%d\t%s\n", sp.StartLine, line)
+		}
+	}
+}
+
+// parseSourceLocation returns the first instruction of the given source line
+// specified as <file>:<line> or just <line> if the program consists of a
+// single file.
+func parseSourceLocation(di *compiler.DebugInfo, loc string) (int, error) {
+	if di == nil {
+		return 0, ErrNoDebugInfo
+	}
+	file := ""
+	lineS := loc
+	if i := strings.LastIndexByte(loc, ':'); i >= 0 {
+		file, lineS = loc[:i], loc[i+1:]
+	}
+	line, err := strconv.Atoi(lineS)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidParameter, err)
+	}
+	ip, err := debugger.LineToIP(di, file, line)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidParameter, err)
+	}
+	return ip, nil
+}
+
+func handleBacktrace(c *ishell.Context) {
+	if !checkVMIsReady(c) {
+		return
+	}
+	v := getVMFromContext(c)
+	di := getDebugInfoFromContext(c)
+	istack := v.Istack()
+	for i := 0; i < istack.Len(); i++ {
+		ctx := istack.Peek(i).Value().(*vm.Context)
+		ip := ctx.IP()
+		if i == 0 {
+			ip = ctx.NextIP()
+		}
+		loc := debugger.FormatLocation(di, ip)
+		if loc == "" {
+			loc = ctx.ScriptHash().StringLE()
+		}
+		c.Printf("#%d\t%d\t%s\n", i, ip, loc)
+	}
+}
+
+func handleWatch(c *ishell.Context) {
+	watches := getWatchesFromContext(c)
+	if len(c.Args) != 0 {
+		expr := strings.Join(c.Args, "")
+		if !debugger.IsValidExpression(expr) {
+			c.Err(fmt.Errorf("%w: invalid expression %s", ErrInvalidParameter, expr))
+			return
+		}
+		*watches = append(*watches, expr)
+	}
+	printWatches(c, getVMFromContext(c))
+}
+
+func handleUnwatch(c *ishell.Context) {
+	watches := getWatchesFromContext(c)
+	if len(c.Args) != 1 {
+		c.Err(fmt.Errorf("%w: <n>", ErrMissingParameter))
+		return
+	}
+	n, err := strconv.Atoi(c.Args[0])
+	if err != nil || n < 0 || n >= len(*watches) {
+		c.Err(fmt.Errorf("%w: no watch %s", ErrInvalidParameter, c.Args[0]))
+		return
+	}
+	*watches = append((*watches)[:n], (*watches)[n+1:]...)
+}
+
+// printWatches prints values of all watch expressions.
+func printWatches(c *ishell.Context, v *vm.VM) {
+	di := getDebugInfoFromContext(c)
+	for i, expr := range *getWatchesFromContext(c) {
+		var (
+			res  string
+			item stackitem.Item
+			err  = errors.New("no program running")
+		)
+		if ctx := v.Context(); ctx != nil {
+			item, err = debugger.Eval(ctx, ctx.NextIP(), di, expr)
+		}
+		if err == nil {
+			var data []byte
+			data, err = stackitem.ToJSONWithTypes(item)
+			res = string(data)
+		}
+		if err != nil {
+			res = "<" + err.Error() + ">"
+		}
+		c.Printf("#%d %s = %s\n", i, expr, res)
+	}
+}
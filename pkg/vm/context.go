@@ -201,6 +201,22 @@ func (c *Context) NextInstr() (int, opcode.Opcode) {
 	return c.nextip, op
 }
 
+// StaticSlot returns static slot of the context, nil if it's not initialized.
+func (c *Context) StaticSlot() *Slot {
+	return c.static
+}
+
+// LocalSlot returns local slot of the context, nil if it's not initialized.
+func (c *Context) LocalSlot() *Slot {
+	return c.local
+}
+
+// ArgumentSlot returns argument slot of the context, nil if it's not
+// initialized.
+func (c *Context) ArgumentSlot() *Slot {
+	return c.arguments
+}
+
 // Copy returns an new exact copy of c.
 func (c *Context) Copy() *Context {
 	ctx := new(Context)
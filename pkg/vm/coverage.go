@@ -0,0 +1,32 @@
+package vm
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// Coverage contains the number of executions of every instruction (by offset)
+// of every script (by hash) collected by the VM with coverage enabled.
+type Coverage map[util.Uint160]map[int]int
+
+// EnableCoverage makes VM count executions of every instruction, any
+// previously collected data is dropped. Unlike profiling data coverage is
+// accumulated across program loads, so that it's possible to collect it for
+// the whole test suite.
+func (v *VM) EnableCoverage() {
+	v.coverage = make(Coverage)
+}
+
+// Coverage returns coverage data collected since coverage was enabled, nil if
+// it's disabled.
+func (v *VM) Coverage() Coverage {
+	return v.coverage
+}
+
+func (c Coverage) add(h util.Uint160, ip int) {
+	m := c[h]
+	if m == nil {
+		m = make(map[int]int)
+		c[h] = m
+	}
+	m[ip]++
+}
@@ -0,0 +1,32 @@
+package vm
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVM_Coverage(t *testing.T) {
+	prog := makeProgram(opcode.PUSH1, opcode.JMPIF, 3, opcode.PUSH2, opcode.PUSH3)
+	h := hash.Hash160(prog)
+
+	v := newTestVM()
+	t.Run("disabled", func(t *testing.T) {
+		v.Load(prog)
+		runVM(t, v)
+		require.Nil(t, v.Coverage())
+	})
+
+	v.EnableCoverage()
+	v.Load(prog)
+	runVM(t, v)
+	require.Equal(t, Coverage{h: {0: 1, 1: 1, 4: 1, 5: 1}}, v.Coverage())
+
+	t.Run("accumulated across loads", func(t *testing.T) {
+		v.Load(prog)
+		runVM(t, v)
+		require.Equal(t, Coverage{h: {0: 2, 1: 2, 4: 2, 5: 2}}, v.Coverage())
+	})
+}
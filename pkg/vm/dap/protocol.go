@@ -0,0 +1,219 @@
+package dap
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// contentLengthHeader is the only header used by the base protocol.
+const contentLengthHeader = "Content-Length"
+
+// maxMessageSize is the maximum accepted message content length, header lines
+// are limited by bufio.Reader buffer size.
+const maxMessageSize = 1024 * 1024
+
+// Message types.
+const (
+	typeRequest  = "request"
+	typeResponse = "response"
+	typeEvent    = "event"
+)
+
+// request is a client-initiated protocol message.
+type request struct {
+	Seq       int             `json:"seq"`
+	Type      string          `json:"type"`
+	Command   string          `json:"command"`
+	Arguments json.RawMessage `json:"arguments,omitempty"`
+}
+
+// response is a reply to the request.
+type response struct {
+	Seq        int         `json:"seq"`
+	Type       string      `json:"type"`
+	RequestSeq int         `json:"request_seq"`
+	Success    bool        `json:"success"`
+	Command    string      `json:"command"`
+	Message    string      `json:"message,omitempty"`
+	Body       interface{} `json:"body,omitempty"`
+}
+
+// event is a server-initiated protocol message.
+type event struct {
+	Seq   int         `json:"seq"`
+	Type  string      `json:"type"`
+	Event string      `json:"event"`
+	Body  interface{} `json:"body,omitempty"`
+}
+
+// readMessage reads a single message with its header from r.
+func readMessage(r *bufio.Reader) ([]byte, error) {
+	length := -1
+	for {
+		raw, err := r.ReadSlice('\n')
+		if err != nil {
+			if errors.Is(err, bufio.ErrBufferFull) {
+				return nil, errors.New("header line is too long")
+			}
+			return nil, err
+		}
+		line := strings.TrimRight(string(raw), "\r\n")
+		if line == "" {
+			break
+		}
+		i := strings.IndexByte(line, ':')
+		if i < 0 {
+			return nil, fmt.Errorf("invalid header line %q", line)
+		}
+		if strings.TrimSpace(line[:i]) != contentLengthHeader {
+			continue
+		}
+		length, err = strconv.Atoi(strings.TrimSpace(line[i+1:]))
+		if err != nil || length < 0 || length > maxMessageSize {
+			return nil, fmt.Errorf("invalid %s: %q", contentLengthHeader, line[i+1:])
+		}
+	}
+	if length < 0 {
+		return nil, errors.New("no " + contentLengthHeader + " header")
+	}
+	data := make([]byte, length)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// writeMessage writes msg serialized to JSON along with its header to w.
+func writeMessage(w io.Writer, msg interface{}) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "%s: %d\r\n\r\n", contentLengthHeader, len(data)); err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// Capabilities is a body of initialize response.
+type Capabilities struct {
+	SupportsConfigurationDoneRequest bool `json:"supportsConfigurationDoneRequest"`
+	SupportsEvaluateForHovers        bool `json:"supportsEvaluateForHovers"`
+	SupportsTerminateRequest         bool `json:"supportsTerminateRequest"`
+}
+
+// LaunchArguments are arguments of launch request.
+type LaunchArguments struct {
+	// Program is a path to either Go source file or NEF file of the contract.
+	Program string `json:"program"`
+	// Manifest is a path to the contract manifest, it's required for NEF
+	// programs.
+	Manifest string `json:"manifest,omitempty"`
+	// DebugInfo is a path to the contract debug info, it's optional for NEF
+	// programs (source-level debugging is not available without it).
+	DebugInfo string `json:"debugInfo,omitempty"`
+	// Method is the contract method to run, the whole script is executed
+	// from the beginning if it's empty.
+	Method string `json:"method,omitempty"`
+	// Args are method arguments, integers, booleans and strings are
+	// supported.
+	Args []interface{} `json:"args,omitempty"`
+	// StopOnEntry makes the debugger stop before the first instruction.
+	StopOnEntry bool `json:"stopOnEntry,omitempty"`
+}
+
+// Source is a source file descriptor.
+type Source struct {
+	Name string `json:"name,omitempty"`
+	Path string `json:"path,omitempty"`
+}
+
+// SourceBreakpoint is a breakpoint requested by the client.
+type SourceBreakpoint struct {
+	Line int `json:"line"`
+}
+
+// SetBreakpointsArguments are arguments of setBreakpoints request.
+type SetBreakpointsArguments struct {
+	Source      Source             `json:"source"`
+	Breakpoints []SourceBreakpoint `json:"breakpoints"`
+}
+
+// Breakpoint is a breakpoint state reported to the client.
+type Breakpoint struct {
+	Verified bool   `json:"verified"`
+	Line     int    `json:"line,omitempty"`
+	Message  string `json:"message,omitempty"`
+}
+
+// Thread is an execution thread, there is only one in NeoVM.
+type Thread struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// StackFrameArguments are arguments of stackTrace request.
+type StackFrameArguments struct {
+	ThreadID int `json:"threadId"`
+}
+
+// StackFrame is a single frame of the invocation stack.
+type StackFrame struct {
+	ID                          int     `json:"id"`
+	Name                        string  `json:"name"`
+	Source                      *Source `json:"source,omitempty"`
+	Line                        int     `json:"line"`
+	Column                      int     `json:"column"`
+	InstructionPointerReference string  `json:"instructionPointerReference,omitempty"`
+}
+
+// ScopesArguments are arguments of scopes request.
+type ScopesArguments struct {
+	FrameID int `json:"frameId"`
+}
+
+// Scope is a named group of variables of the frame.
+type Scope struct {
+	Name               string `json:"name"`
+	VariablesReference int    `json:"variablesReference"`
+	Expensive          bool   `json:"expensive"`
+}
+
+// VariablesArguments are arguments of variables request.
+type VariablesArguments struct {
+	VariablesReference int `json:"variablesReference"`
+}
+
+// Variable is a single named value.
+type Variable struct {
+	Name               string `json:"name"`
+	Value              string `json:"value"`
+	Type               string `json:"type,omitempty"`
+	VariablesReference int    `json:"variablesReference"`
+}
+
+// EvaluateArguments are arguments of evaluate request.
+type EvaluateArguments struct {
+	Expression string `json:"expression"`
+	FrameID    int    `json:"frameId,omitempty"`
+}
+
+// StoppedEvent is a body of stopped event.
+type StoppedEvent struct {
+	Reason            string `json:"reason"`
+	Description       string `json:"description,omitempty"`
+	ThreadID          int    `json:"threadId"`
+	AllThreadsStopped bool   `json:"allThreadsStopped"`
+}
+
+// OutputEvent is a body of output event.
+type OutputEvent struct {
+	Category string `json:"category"`
+	Output   string `json:"output"`
+}
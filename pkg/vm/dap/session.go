@@ -0,0 +1,685 @@
+/*
+Package dap implements Debug Adapter Protocol server for NeoVM. It allows
+to debug contracts from any editor supporting the protocol (like VS Code)
+with source breakpoints, stepping and stack/slot inspection.
+*/
+package dap
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"path/filepath"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/core/fee"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/debugger"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+// threadID is the identifier of the only thread reported to the client.
+const threadID = 1
+
+// gasLimit is the amount of GAS (100 GAS) programs can spend.
+const gasLimit = 100_00000000
+
+// Variable scopes of the frame.
+const (
+	scopeArguments = iota
+	scopeLocals
+	scopeStatics
+	scopeEstack
+	scopeCount
+)
+
+var scopeNames = [scopeCount]string{"Arguments", "Locals", "Statics", "Evaluation stack"}
+
+// ErrNoProgram is returned for requests that need a program to be loaded
+// (and not yet finished).
+var ErrNoProgram = errors.New("no program running")
+
+// Session is a debugging session with a single client.
+type Session struct {
+	r   *bufio.Reader
+	w   io.Writer
+	seq int
+
+	vm *vm.VM
+	di *compiler.DebugInfo
+	// breakpoints contains instructions with breakpoints for every source
+	// file.
+	breakpoints map[string][]int
+	stopOnEntry bool
+	terminated  bool
+	// after is executed after the response to the current request is sent.
+	after func() error
+	done  bool
+}
+
+// handler processes request arguments and returns response body.
+type handler func(s *Session, args json.RawMessage) (interface{}, error)
+
+var handlers map[string]handler
+
+func init() {
+	handlers = map[string]handler{
+		"initialize":        (*Session).handleInitialize,
+		"launch":            (*Session).handleLaunch,
+		"setBreakpoints":    (*Session).handleSetBreakpoints,
+		"configurationDone": (*Session).handleConfigurationDone,
+		"threads":           (*Session).handleThreads,
+		"stackTrace":        (*Session).handleStackTrace,
+		"scopes":            (*Session).handleScopes,
+		"variables":         (*Session).handleVariables,
+		"evaluate":          (*Session).handleEvaluate,
+		"continue":          (*Session).handleContinue,
+		"next":              (*Session).handleNext,
+		"stepIn":            (*Session).handleStepIn,
+		"stepOut":           (*Session).handleStepOut,
+		"disconnect":        (*Session).handleDisconnect,
+		"terminate":         (*Session).handleDisconnect,
+	}
+}
+
+// NewSession returns a new session reading requests from r and writing
+// responses and events to w.
+func NewSession(r io.Reader, w io.Writer) *Session {
+	v := vm.New()
+	v.GasLimit = gasLimit
+	v.SetPriceGetter(func(op opcode.Opcode, _ []byte) int64 {
+		return fee.Opcode(interop.DefaultBaseExecFee, op)
+	})
+	return &Session{
+		r:           bufio.NewReader(r),
+		w:           w,
+		vm:          v,
+		breakpoints: make(map[string][]int),
+	}
+}
+
+// Serve accepts connections on the listener and runs a session for each of
+// them until the listener is closed. Session panics only terminate the
+// session.
+func Serve(l net.Listener) error {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+		go func() {
+			defer conn.Close()
+			defer func() { _ = recover() }()
+			_ = NewSession(conn, conn).Run()
+		}()
+	}
+}
+
+// ListenAndServe listens on the given TCP address and serves debugging
+// sessions. Clients can read and run any file available to the process, so
+// only loopback addresses are allowed (the host can be omitted, 127.0.0.1 is
+// used then).
+func ListenAndServe(addr string) error {
+	addr, err := loopbackAddress(addr)
+	if err != nil {
+		return err
+	}
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	defer l.Close()
+	return Serve(l)
+}
+
+// loopbackAddress returns addr with host set to 127.0.0.1 if it's omitted or
+// error if it's not a loopback one.
+func loopbackAddress(addr string) (string, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", err
+	}
+	if host == "" {
+		return net.JoinHostPort("127.0.0.1", port), nil
+	}
+	if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+		return "", fmt.Errorf("%s is not a loopback address", host)
+	}
+	return addr, nil
+}
+
+// Run serves requests until the client disconnects or the input is closed.
+func (s *Session) Run() error {
+	for !s.done {
+		data, err := readMessage(s.r)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		var req request
+		if err := json.Unmarshal(data, &req); err != nil {
+			return fmt.Errorf("invalid message: %w", err)
+		}
+		if req.Type != typeRequest {
+			continue
+		}
+		if err := s.handle(&req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Session) handle(req *request) error {
+	resp := &response{
+		Type:       typeResponse,
+		RequestSeq: req.Seq,
+		Command:    req.Command,
+	}
+	s.after = nil
+	h, ok := handlers[req.Command]
+	if !ok {
+		resp.Message = fmt.Sprintf("unsupported command %s", req.Command)
+	} else if body, err := h(s, req.Arguments); err != nil {
+		resp.Message = err.Error()
+		s.after = nil
+	} else {
+		resp.Success = true
+		resp.Body = body
+	}
+	if err := s.send(resp); err != nil {
+		return err
+	}
+	if s.after != nil {
+		return s.after()
+	}
+	return nil
+}
+
+func (s *Session) send(msg interface{}) error {
+	s.seq++
+	switch m := msg.(type) {
+	case *response:
+		m.Seq = s.seq
+	case *event:
+		m.Seq = s.seq
+	}
+	return writeMessage(s.w, msg)
+}
+
+func (s *Session) sendEvent(name string, body interface{}) error {
+	return s.send(&event{Type: typeEvent, Event: name, Body: body})
+}
+
+func (s *Session) output(category, text string) error {
+	return s.sendEvent("output", OutputEvent{Category: category, Output: text})
+}
+
+func unmarshalArgs(args json.RawMessage, v interface{}) error {
+	if len(args) == 0 {
+		return errors.New("missing arguments")
+	}
+	if err := json.Unmarshal(args, v); err != nil {
+		return fmt.Errorf("invalid arguments: %w", err)
+	}
+	return nil
+}
+
+func (s *Session) checkRunning() error {
+	if !s.vm.Ready() || s.vm.HasStopped() {
+		return ErrNoProgram
+	}
+	return nil
+}
+
+func (s *Session) handleInitialize(_ json.RawMessage) (interface{}, error) {
+	return Capabilities{
+		SupportsConfigurationDoneRequest: true,
+		SupportsEvaluateForHovers:        true,
+		SupportsTerminateRequest:         true,
+	}, nil
+}
+
+func (s *Session) handleLaunch(args json.RawMessage) (interface{}, error) {
+	var la LaunchArguments
+	if err := unmarshalArgs(args, &la); err != nil {
+		return nil, err
+	}
+	if err := s.load(&la); err != nil {
+		return nil, err
+	}
+	s.stopOnEntry = la.StopOnEntry
+	s.after = func() error {
+		return s.sendEvent("initialized", nil)
+	}
+	return nil, nil
+}
+
+// load loads the program and prepares VM to run the method requested.
+func (s *Session) load(la *LaunchArguments) error {
+	var (
+		m   *manifest.Manifest
+		err error
+	)
+	s.di = nil
+	if strings.HasSuffix(la.Program, ".go") {
+		var b []byte
+		b, s.di, err = compiler.CompileWithDebugInfo(la.Program, nil)
+		if err != nil {
+			return err
+		}
+		// Don't perform checks, just load.
+		m, err = s.di.ConvertToManifest(&compiler.Options{})
+		if err != nil {
+			return fmt.Errorf("can't create manifest: %w", err)
+		}
+		s.vm.Load(b)
+	} else {
+		if err := s.vm.LoadFile(la.Program); err != nil {
+			return err
+		}
+		if la.Manifest != "" {
+			m = new(manifest.Manifest)
+			if err := readJSONFile(la.Manifest, m); err != nil {
+				return fmt.Errorf("can't read manifest: %w", err)
+			}
+		}
+		if la.DebugInfo != "" {
+			s.di = new(compiler.DebugInfo)
+			if err := readJSONFile(la.DebugInfo, s.di); err != nil {
+				return fmt.Errorf("can't read debug info: %w", err)
+			}
+		}
+	}
+	s.breakpoints = make(map[string][]int)
+	s.terminated = false
+
+	params, err := convertArgs(la.Args)
+	if err != nil {
+		return err
+	}
+	var offset int
+	if la.Method != "" {
+		if m == nil {
+			return errors.New("manifest is required to run the method")
+		}
+		md := m.ABI.GetMethod(la.Method, len(params))
+		if md == nil {
+			return fmt.Errorf("method %s not found", la.Method)
+		}
+		offset = md.Offset
+	}
+	for i := len(params) - 1; i >= 0; i-- {
+		s.vm.Estack().PushVal(params[i])
+	}
+	if la.Method != "" {
+		s.vm.Jump(s.vm.Context(), offset)
+		if initMD := m.ABI.GetMethod(manifest.MethodInit, 0); initMD != nil {
+			s.vm.Call(s.vm.Context(), initMD.Offset)
+		}
+	}
+	return nil
+}
+
+func readJSONFile(name string, v interface{}) error {
+	data, err := ioutil.ReadFile(name)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// convertArgs converts JSON values to stack items.
+func convertArgs(args []interface{}) ([]stackitem.Item, error) {
+	items := make([]stackitem.Item, len(args))
+	for i, arg := range args {
+		switch a := arg.(type) {
+		case bool:
+			items[i] = stackitem.NewBool(a)
+		case float64:
+			n, acc := big.NewFloat(a).Int(nil)
+			if acc != big.Exact {
+				return nil, fmt.Errorf("argument %d: %v is not an integer", i, a)
+			}
+			items[i] = stackitem.NewBigInteger(n)
+		case string:
+			items[i] = stackitem.NewByteArray([]byte(a))
+		default:
+			return nil, fmt.Errorf("argument %d: unsupported type %T", i, arg)
+		}
+	}
+	return items, nil
+}
+
+func (s *Session) handleSetBreakpoints(args json.RawMessage) (interface{}, error) {
+	var sa SetBreakpointsArguments
+	if err := unmarshalArgs(args, &sa); err != nil {
+		return nil, err
+	}
+	path := sa.Source.Path
+	if path == "" {
+		path = sa.Source.Name
+	}
+	running := s.checkRunning() == nil
+	if running {
+		for _, ip := range s.breakpoints[path] {
+			s.vm.RemoveBreakPointAll(ip)
+		}
+	}
+	delete(s.breakpoints, path)
+
+	res := make([]Breakpoint, len(sa.Breakpoints))
+	for i, bp := range sa.Breakpoints {
+		res[i].Line = bp.Line
+		if !running {
+			res[i].Message = ErrNoProgram.Error()
+			continue
+		}
+		ip, err := debugger.LineToIP(s.di, path, bp.Line)
+		if err != nil {
+			res[i].Message = err.Error()
+			continue
+		}
+		s.vm.AddBreakPointAll(ip)
+		s.breakpoints[path] = append(s.breakpoints[path], ip)
+		res[i].Verified = true
+	}
+	return map[string]interface{}{"breakpoints": res}, nil
+}
+
+func (s *Session) handleConfigurationDone(_ json.RawMessage) (interface{}, error) {
+	if err := s.checkRunning(); err != nil {
+		return nil, err
+	}
+	s.after = func() error {
+		if s.stopOnEntry {
+			return s.stopped("entry")
+		}
+		return s.report(s.vm.Run(), "breakpoint")
+	}
+	return nil, nil
+}
+
+func (s *Session) handleThreads(_ json.RawMessage) (interface{}, error) {
+	return map[string]interface{}{
+		"threads": []Thread{{ID: threadID, Name: "NeoVM"}},
+	}, nil
+}
+
+// frame returns the context of the frame with the given ID (frame IDs start
+// from 1 for the current context) along with its instruction pointer.
+func (s *Session) frame(id int) (*vm.Context, int, error) {
+	if err := s.checkRunning(); err != nil {
+		return nil, 0, err
+	}
+	istack := s.vm.Istack()
+	if id < 1 || id > istack.Len() {
+		return nil, 0, fmt.Errorf("no frame %d", id)
+	}
+	ctx := istack.Peek(id - 1).Value().(*vm.Context)
+	if id == 1 {
+		return ctx, ctx.NextIP(), nil
+	}
+	return ctx, ctx.IP(), nil
+}
+
+func (s *Session) handleStackTrace(_ json.RawMessage) (interface{}, error) {
+	if err := s.checkRunning(); err != nil {
+		return nil, err
+	}
+	n := s.vm.Istack().Len()
+	frames := make([]StackFrame, n)
+	for i := range frames {
+		ctx, ip, _ := s.frame(i + 1)
+		f := &frames[i]
+		f.ID = i + 1
+		f.Name = ctx.ScriptHash().StringLE()
+		f.InstructionPointerReference = fmt.Sprint(ip)
+		m, sp, _ := debugger.FindSeqPoint(s.di, ip)
+		if m == nil {
+			continue
+		}
+		f.Name = debugger.MethodName(m)
+		if doc := debugger.Document(s.di, sp); doc != "" {
+			if abs, err := filepath.Abs(doc); err == nil {
+				doc = abs
+			}
+			f.Source = &Source{Name: filepath.Base(doc), Path: doc}
+			f.Line = sp.StartLine
+			f.Column = sp.StartCol
+		}
+	}
+	return map[string]interface{}{
+		"stackFrames": frames,
+		"totalFrames": n,
+	}, nil
+}
+
+func (s *Session) handleScopes(args json.RawMessage) (interface{}, error) {
+	var sa ScopesArguments
+	if err := unmarshalArgs(args, &sa); err != nil {
+		return nil, err
+	}
+	if _, _, err := s.frame(sa.FrameID); err != nil {
+		return nil, err
+	}
+	scopes := make([]Scope, scopeCount)
+	for i := range scopes {
+		scopes[i] = Scope{
+			Name:               scopeNames[i],
+			VariablesReference: (sa.FrameID-1)*scopeCount + i + 1,
+		}
+	}
+	return map[string]interface{}{"scopes": scopes}, nil
+}
+
+func (s *Session) handleVariables(args json.RawMessage) (interface{}, error) {
+	var va VariablesArguments
+	if err := unmarshalArgs(args, &va); err != nil {
+		return nil, err
+	}
+	if va.VariablesReference < 1 {
+		return nil, fmt.Errorf("invalid reference %d", va.VariablesReference)
+	}
+	ref := va.VariablesReference - 1
+	ctx, ip, err := s.frame(ref/scopeCount + 1)
+	if err != nil {
+		return nil, err
+	}
+	m, _, _ := debugger.FindSeqPoint(s.di, ip)
+	vars := []Variable{}
+	switch ref % scopeCount {
+	case scopeArguments:
+		var names []string
+		if m != nil {
+			for _, p := range m.Parameters {
+				names = append(names, p.Name)
+			}
+		}
+		vars = slotVariables(ctx.ArgumentSlot(), names)
+	case scopeLocals:
+		var names []string
+		if m != nil {
+			for _, v := range m.Variables {
+				names = append(names, strings.SplitN(v, ",", 2)[0])
+			}
+		}
+		vars = slotVariables(ctx.LocalSlot(), names)
+	case scopeStatics:
+		vars = slotVariables(ctx.StaticSlot(), nil)
+	case scopeEstack:
+		ctx.Estack().Iter(func(e *vm.Element) {
+			vars = append(vars, newVariable(fmt.Sprint(len(vars)), e.Item()))
+		})
+	}
+	return map[string]interface{}{"variables": vars}, nil
+}
+
+// slotVariables returns slot items named after names if they're available
+// and after their indexes otherwise.
+func slotVariables(slot *vm.Slot, names []string) []Variable {
+	vars := []Variable{}
+	if slot == nil {
+		return vars
+	}
+	for i := 0; i < slot.Size(); i++ {
+		name := fmt.Sprint(i)
+		if i < len(names) && names[i] != "" {
+			name = names[i]
+		}
+		vars = append(vars, newVariable(name, slot.Get(i)))
+	}
+	return vars
+}
+
+func newVariable(name string, item stackitem.Item) Variable {
+	v := Variable{Name: name, Value: itemString(item)}
+	if item != nil {
+		v.Type = item.Type().String()
+	}
+	return v
+}
+
+// itemString returns human-readable representation of the item.
+func itemString(item stackitem.Item) string {
+	switch it := item.(type) {
+	case nil, stackitem.Null:
+		return "null"
+	case *stackitem.BigInteger:
+		return it.Value().(*big.Int).String()
+	case *stackitem.Bool:
+		return fmt.Sprint(it.Value())
+	case *stackitem.ByteArray, *stackitem.Buffer:
+		b, _ := it.TryBytes()
+		return "0x" + hex.EncodeToString(b)
+	}
+	data, err := stackitem.ToJSONWithTypes(item)
+	if err != nil {
+		return item.Type().String()
+	}
+	return string(data)
+}
+
+func (s *Session) handleEvaluate(args json.RawMessage) (interface{}, error) {
+	var ea EvaluateArguments
+	if err := unmarshalArgs(args, &ea); err != nil {
+		return nil, err
+	}
+	if ea.FrameID == 0 {
+		ea.FrameID = 1
+	}
+	ctx, ip, err := s.frame(ea.FrameID)
+	if err != nil {
+		return nil, err
+	}
+	item, err := debugger.Eval(ctx, ip, s.di, strings.TrimSpace(ea.Expression))
+	if err != nil {
+		return nil, err
+	}
+	v := newVariable("", item)
+	return map[string]interface{}{
+		"result":             v.Value,
+		"type":               v.Type,
+		"variablesReference": 0,
+	}, nil
+}
+
+func (s *Session) handleContinue(_ json.RawMessage) (interface{}, error) {
+	if err := s.checkRunning(); err != nil {
+		return nil, err
+	}
+	s.after = func() error {
+		return s.report(s.vm.Run(), "breakpoint")
+	}
+	return map[string]interface{}{"allThreadsContinued": true}, nil
+}
+
+func (s *Session) handleNext(_ json.RawMessage) (interface{}, error) {
+	return nil, s.step(func() error {
+		if s.di != nil {
+			return debugger.StepSource(s.vm, s.di, false)
+		}
+		return s.vm.StepOver()
+	})
+}
+
+func (s *Session) handleStepIn(_ json.RawMessage) (interface{}, error) {
+	return nil, s.step(func() error {
+		if s.di != nil {
+			return debugger.StepSource(s.vm, s.di, true)
+		}
+		return s.vm.StepInto()
+	})
+}
+
+func (s *Session) handleStepOut(_ json.RawMessage) (interface{}, error) {
+	return nil, s.step(s.vm.StepOut)
+}
+
+// step schedules f execution after the response is sent.
+func (s *Session) step(f func() error) error {
+	if err := s.checkRunning(); err != nil {
+		return err
+	}
+	s.after = func() error {
+		return s.report(f(), "step")
+	}
+	return nil
+}
+
+func (s *Session) handleDisconnect(_ json.RawMessage) (interface{}, error) {
+	s.done = true
+	return nil, nil
+}
+
+func (s *Session) stopped(reason string) error {
+	return s.sendEvent("stopped", StoppedEvent{
+		Reason:            reason,
+		ThreadID:          threadID,
+		AllThreadsStopped: true,
+	})
+}
+
+// report notifies the client about VM state after execution with the given
+// result, reason is used if VM has stopped without finishing the program.
+func (s *Session) report(err error, reason string) error {
+	if err == nil && s.vm.HasFailed() {
+		err = errors.New("VM has failed")
+	}
+	if err != nil {
+		if e := s.output("stderr", fmt.Sprintf("Error: %s\n", err)); e != nil {
+			return e
+		}
+		return s.terminate(1)
+	}
+	if s.vm.HasHalted() {
+		if e := s.output("stdout", s.vm.Stack("estack")+"\n"); e != nil {
+			return e
+		}
+		return s.terminate(0)
+	}
+	return s.stopped(reason)
+}
+
+func (s *Session) terminate(code int) error {
+	if s.terminated {
+		return nil
+	}
+	s.terminated = true
+	if err := s.sendEvent("exited", map[string]int{"exitCode": code}); err != nil {
+		return err
+	}
+	return s.sendEvent("terminated", nil)
+}
@@ -0,0 +1,277 @@
+package dap
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testClient struct {
+	t   *testing.T
+	w   io.Writer
+	r   *bufio.Reader
+	seq int
+}
+
+type testMessage struct {
+	Type       string          `json:"type"`
+	Command    string          `json:"command"`
+	Event      string          `json:"event"`
+	RequestSeq int             `json:"request_seq"`
+	Success    bool            `json:"success"`
+	Message    string          `json:"message"`
+	Body       json.RawMessage `json:"body"`
+}
+
+func (c *testClient) request(command string, args interface{}) {
+	c.seq++
+	req := map[string]interface{}{
+		"seq":     c.seq,
+		"type":    typeRequest,
+		"command": command,
+	}
+	if args != nil {
+		req["arguments"] = args
+	}
+	require.NoError(c.t, writeMessage(c.w, req))
+}
+
+func (c *testClient) read() *testMessage {
+	data, err := readMessage(c.r)
+	require.NoError(c.t, err)
+	m := new(testMessage)
+	require.NoError(c.t, json.Unmarshal(data, m))
+	return m
+}
+
+// checkResponse reads successful response to the last request and
+// unmarshals its body into v (if it's not nil).
+func (c *testClient) checkResponse(command string, v interface{}) {
+	m := c.read()
+	require.Equal(c.t, typeResponse, m.Type)
+	require.Equal(c.t, command, m.Command)
+	require.Equal(c.t, c.seq, m.RequestSeq)
+	require.True(c.t, m.Success, m.Message)
+	if v != nil {
+		require.NoError(c.t, json.Unmarshal(m.Body, v))
+	}
+}
+
+func (c *testClient) checkEvent(name string, v interface{}) {
+	m := c.read()
+	require.Equal(c.t, typeEvent, m.Type)
+	require.Equal(c.t, name, m.Event)
+	if v != nil {
+		require.NoError(c.t, json.Unmarshal(m.Body, v))
+	}
+}
+
+func (c *testClient) checkStopped(reason string) {
+	var ev StoppedEvent
+	c.checkEvent("stopped", &ev)
+	require.Equal(c.t, reason, ev.Reason)
+	require.Equal(c.t, threadID, ev.ThreadID)
+}
+
+func (c *testClient) stackTrace() []StackFrame {
+	var res struct {
+		StackFrames []StackFrame `json:"stackFrames"`
+	}
+	c.request("stackTrace", StackFrameArguments{ThreadID: threadID})
+	c.checkResponse("stackTrace", &res)
+	return res.StackFrames
+}
+
+func newTestSession(t *testing.T) *testClient {
+	inR, inW := io.Pipe()
+	outR, outW := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		done <- NewSession(inR, outW).Run()
+		outW.Close()
+	}()
+	t.Cleanup(func() {
+		inW.Close()
+		require.NoError(t, <-done)
+	})
+	return &testClient{t: t, w: inW, r: bufio.NewReader(outR)}
+}
+
+func TestReadWriteMessage(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	require.NoError(t, writeMessage(buf, map[string]int{"seq": 1}))
+	require.Equal(t, "Content-Length: 9\r\n\r\n{\"seq\":1}", buf.String())
+
+	data, err := readMessage(bufio.NewReader(buf))
+	require.NoError(t, err)
+	require.Equal(t, `{"seq":1}`, string(data))
+
+	t.Run("no length", func(t *testing.T) {
+		_, err := readMessage(bufio.NewReader(bytes.NewBufferString("Content-Type: x\r\n\r\n{}")))
+		require.Error(t, err)
+	})
+	t.Run("invalid length", func(t *testing.T) {
+		_, err := readMessage(bufio.NewReader(bytes.NewBufferString("Content-Length: x\r\n\r\n{}")))
+		require.Error(t, err)
+	})
+	t.Run("short body", func(t *testing.T) {
+		_, err := readMessage(bufio.NewReader(bytes.NewBufferString("Content-Length: 10\r\n\r\n{}")))
+		require.Error(t, err)
+	})
+	t.Run("too big", func(t *testing.T) {
+		_, err := readMessage(bufio.NewReader(bytes.NewBufferString("Content-Length: 1048577\r\n\r\n{}")))
+		require.Error(t, err)
+	})
+	t.Run("long header", func(t *testing.T) {
+		_, err := readMessage(bufio.NewReader(bytes.NewBufferString("X-Header: " + strings.Repeat("x", 5000) + "\r\n\r\n{}")))
+		require.Error(t, err)
+	})
+}
+
+func TestLoopbackAddress(t *testing.T) {
+	addr, err := loopbackAddress(":4711")
+	require.NoError(t, err)
+	require.Equal(t, "127.0.0.1:4711", addr)
+	for _, a := range []string{"localhost:4711", "127.0.0.1:4711", "[::1]:4711"} {
+		addr, err = loopbackAddress(a)
+		require.NoError(t, err)
+		require.Equal(t, a, addr)
+	}
+	for _, a := range []string{"0.0.0.0:4711", "192.168.1.1:4711", "example.com:4711", "4711"} {
+		_, err = loopbackAddress(a)
+		require.Error(t, err, a)
+	}
+}
+
+func TestSession(t *testing.T) {
+	src := "package kek\n" +
+		"func Main(a, b int) int {\n" +
+		"	c := a + b\n" +
+		"	return sum(c, 1)\n" +
+		"}\n" +
+		"func sum(x, y int) int {\n" +
+		"	z := x + y\n" +
+		"	return z\n" +
+		"}\n"
+	tmpDir := path.Join(os.TempDir(), "vmdaptest")
+	require.NoError(t, os.Mkdir(tmpDir, os.ModePerm))
+	t.Cleanup(func() {
+		os.RemoveAll(tmpDir)
+	})
+	filename := path.Join(tmpDir, "vmdaptest.go")
+	require.NoError(t, ioutil.WriteFile(filename, []byte(src), os.ModePerm))
+
+	c := newTestSession(t)
+
+	var caps Capabilities
+	c.request("initialize", map[string]string{"adapterID": "neo-go"})
+	c.checkResponse("initialize", &caps)
+	require.True(t, caps.SupportsConfigurationDoneRequest)
+
+	c.request("unknown", nil)
+	m := c.read()
+	require.Equal(t, typeResponse, m.Type)
+	require.False(t, m.Success)
+
+	c.request("launch", LaunchArguments{
+		Program: filename,
+		Method:  "main",
+		Args:    []interface{}{2, 3},
+	})
+	c.checkResponse("launch", nil)
+	c.checkEvent("initialized", nil)
+
+	var bps struct {
+		Breakpoints []Breakpoint `json:"breakpoints"`
+	}
+	c.request("setBreakpoints", SetBreakpointsArguments{
+		Source:      Source{Path: filename},
+		Breakpoints: []SourceBreakpoint{{Line: 7}, {Line: 100}},
+	})
+	c.checkResponse("setBreakpoints", &bps)
+	require.Equal(t, 2, len(bps.Breakpoints))
+	require.True(t, bps.Breakpoints[0].Verified)
+	require.Equal(t, 7, bps.Breakpoints[0].Line)
+	require.False(t, bps.Breakpoints[1].Verified)
+
+	c.request("configurationDone", nil)
+	c.checkResponse("configurationDone", nil)
+	c.checkStopped("breakpoint")
+
+	var threads struct {
+		Threads []Thread `json:"threads"`
+	}
+	c.request("threads", nil)
+	c.checkResponse("threads", &threads)
+	require.Equal(t, []Thread{{ID: threadID, Name: "NeoVM"}}, threads.Threads)
+
+	frames := c.stackTrace()
+	require.Equal(t, 2, len(frames))
+	require.Equal(t, "kek.sum", frames[0].Name)
+	require.Equal(t, 7, frames[0].Line)
+	require.Equal(t, filename, frames[0].Source.Path)
+	require.Equal(t, "kek.Main", frames[1].Name)
+	require.Equal(t, 4, frames[1].Line)
+
+	var scopes struct {
+		Scopes []Scope `json:"scopes"`
+	}
+	c.request("scopes", ScopesArguments{FrameID: frames[0].ID})
+	c.checkResponse("scopes", &scopes)
+	require.Equal(t, scopeCount, len(scopes.Scopes))
+	require.Equal(t, "Arguments", scopes.Scopes[scopeArguments].Name)
+
+	var vars struct {
+		Variables []Variable `json:"variables"`
+	}
+	c.request("variables", VariablesArguments{VariablesReference: scopes.Scopes[scopeArguments].VariablesReference})
+	c.checkResponse("variables", &vars)
+	require.Equal(t, []Variable{
+		{Name: "x", Value: "5", Type: "Integer"},
+		{Name: "y", Value: "1", Type: "Integer"},
+	}, vars.Variables)
+
+	var eval struct {
+		Result string `json:"result"`
+	}
+	c.request("evaluate", EvaluateArguments{Expression: "y", FrameID: frames[0].ID})
+	c.checkResponse("evaluate", &eval)
+	require.Equal(t, "1", eval.Result)
+
+	c.request("next", nil)
+	c.checkResponse("next", nil)
+	c.checkStopped("step")
+	frames = c.stackTrace()
+	require.Equal(t, 8, frames[0].Line)
+
+	c.request("stepOut", nil)
+	c.checkResponse("stepOut", nil)
+	c.checkStopped("step")
+	frames = c.stackTrace()
+	require.Equal(t, 1, len(frames))
+	require.Equal(t, "kek.Main", frames[0].Name)
+
+	var out OutputEvent
+	c.request("continue", nil)
+	c.checkResponse("continue", nil)
+	c.checkEvent("output", &out)
+	require.Equal(t, "stdout", out.Category)
+	require.Contains(t, out.Output, `"value": "6"`)
+	c.checkEvent("exited", nil)
+	c.checkEvent("terminated", nil)
+
+	c.request("stackTrace", StackFrameArguments{ThreadID: threadID})
+	m = c.read()
+	require.False(t, m.Success)
+
+	c.request("disconnect", nil)
+	c.checkResponse("disconnect", nil)
+}
@@ -0,0 +1,82 @@
+package debugger
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+)
+
+// CoverMode is the mode of cover profiles written by WriteCoverProfile.
+const CoverMode = "count"
+
+// coverBlock is a single source code block of the cover profile.
+type coverBlock struct {
+	doc       string
+	startLine int
+	startCol  int
+	endLine   int
+	endCol    int
+}
+
+// WriteCoverProfile writes cover profile of the program with the given debug
+// info in the format used by `go test -coverprofile` (so it can be processed
+// by `go tool cover`), counts contains the number of executions of every
+// instruction (as collected by the VM with coverage enabled). Every sequence
+// point is reported as a single statement block executed as many times as
+// its first instruction.
+func WriteCoverProfile(w io.Writer, di *compiler.DebugInfo, counts map[int]int) error {
+	if di == nil {
+		return ErrNoDebugInfo
+	}
+	blocks := make(map[coverBlock]int)
+	for _, m := range di.Methods {
+		for i := range m.SeqPoints {
+			sp := &m.SeqPoints[i]
+			b := coverBlock{
+				doc:       Document(di, sp),
+				startLine: sp.StartLine,
+				startCol:  sp.StartCol,
+				endLine:   sp.EndLine,
+				endCol:    sp.EndCol,
+			}
+			if b.doc == "" {
+				continue
+			}
+			if n, ok := blocks[b]; !ok || counts[sp.Opcode] > n {
+				blocks[b] = counts[sp.Opcode]
+			}
+		}
+	}
+	keys := make([]coverBlock, 0, len(blocks))
+	for b := range blocks {
+		keys = append(keys, b)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.doc != b.doc {
+			return a.doc < b.doc
+		}
+		if a.startLine != b.startLine {
+			return a.startLine < b.startLine
+		}
+		if a.startCol != b.startCol {
+			return a.startCol < b.startCol
+		}
+		if a.endLine != b.endLine {
+			return a.endLine < b.endLine
+		}
+		return a.endCol < b.endCol
+	})
+	if _, err := fmt.Fprintf(w, "mode: %s\n", CoverMode); err != nil {
+		return err
+	}
+	for _, b := range keys {
+		if _, err := fmt.Fprintf(w, "%s:%d.%d,%d.%d 1 %d\n", b.doc,
+			b.startLine, b.startCol, b.endLine, b.endCol, blocks[b]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package debugger
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteCoverProfile(t *testing.T) {
+	di := &compiler.DebugInfo{
+		Documents: []string{"b.go", "a.go"},
+		Methods: []compiler.MethodDebugInfo{{
+			SeqPoints: []compiler.DebugSeqPoint{
+				{Opcode: 0, Document: 0, StartLine: 3, StartCol: 2, EndLine: 3, EndCol: 10},
+				{Opcode: 5, Document: 0, StartLine: 2, StartCol: 2, EndLine: 2, EndCol: 12},
+				{Opcode: 9, Document: 1, StartLine: 7, StartCol: 1, EndLine: 8, EndCol: 3},
+				// The same block as the previous one.
+				{Opcode: 12, Document: 1, StartLine: 7, StartCol: 1, EndLine: 8, EndCol: 3},
+				{Opcode: 15, Document: 2, StartLine: 1, StartCol: 1, EndLine: 1, EndCol: 3},
+			},
+		}},
+	}
+
+	t.Run("no debug info", func(t *testing.T) {
+		require.Error(t, WriteCoverProfile(bytes.NewBuffer(nil), nil, nil))
+	})
+
+	buf := bytes.NewBuffer(nil)
+	require.NoError(t, WriteCoverProfile(buf, di, map[int]int{0: 2, 1: 2, 12: 1}))
+	require.Equal(t, "mode: count\n"+
+		"a.go:7.1,8.3 1 1\n"+
+		"b.go:2.2,2.12 1 0\n"+
+		"b.go:3.2,3.10 1 2\n", buf.String())
+}
@@ -0,0 +1,233 @@
+/*
+Package debugger contains source-level debugging helpers for NeoVM programs
+compiled with debug info: mapping instructions to source locations and back,
+stepping by source lines and evaluating watch expressions.
+*/
+package debugger
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+// Various errors.
+var (
+	ErrNoDebugInfo       = errors.New("no debug info loaded")
+	ErrAmbiguousFile     = errors.New("file must be specified")
+	ErrNoCode            = errors.New("no code at the given line")
+	ErrInvalidExpression = errors.New("invalid expression")
+)
+
+// slotExpr is an expression referring to the stack or slot item.
+var slotExpr = regexp.MustCompile(`^(estack|arg|local|static)\[(\d+)\]$`)
+
+// FindSeqPoint returns the method containing the given instruction and the
+// sequence point it belongs to (the last one starting at or before it), exact
+// is true if the sequence point starts exactly at ip.
+func FindSeqPoint(di *compiler.DebugInfo, ip int) (m *compiler.MethodDebugInfo, sp *compiler.DebugSeqPoint, exact bool) {
+	if di == nil {
+		return nil, nil, false
+	}
+	for i := range di.Methods {
+		if ip < int(di.Methods[i].Range.Start) || ip > int(di.Methods[i].Range.End) {
+			continue
+		}
+		m = &di.Methods[i]
+		for j := range m.SeqPoints {
+			p := &m.SeqPoints[j]
+			if p.Opcode <= ip && (sp == nil || p.Opcode > sp.Opcode) {
+				sp = p
+			}
+		}
+		return m, sp, sp != nil && sp.Opcode == ip
+	}
+	return nil, nil, false
+}
+
+// Document returns the file name of the sequence point, empty string if it's
+// unknown.
+func Document(di *compiler.DebugInfo, sp *compiler.DebugSeqPoint) string {
+	if di == nil || sp == nil || sp.Document < 0 || sp.Document >= len(di.Documents) {
+		return ""
+	}
+	return di.Documents[sp.Document]
+}
+
+// MethodName returns the full name of the method (including package).
+func MethodName(m *compiler.MethodDebugInfo) string {
+	return m.Name.Namespace + "." + m.ID
+}
+
+// FormatLocation returns human-readable source location of the instruction,
+// empty string if it's unknown.
+func FormatLocation(di *compiler.DebugInfo, ip int) string {
+	m, sp, _ := FindSeqPoint(di, ip)
+	if m == nil {
+		return ""
+	}
+	res := MethodName(m)
+	if doc := Document(di, sp); doc != "" {
+		res += fmt.Sprintf(" at %s:%d", doc, sp.StartLine)
+	}
+	return res
+}
+
+// SourceLine returns the given line of the file (with leading and trailing
+// spaces trimmed), empty string if it can't be read.
+func SourceLine(file string, line int) string {
+	f, err := os.Open(file)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	s := bufio.NewScanner(f)
+	for i := 1; s.Scan(); i++ {
+		if i == line {
+			return strings.TrimSpace(s.Text())
+		}
+	}
+	return ""
+}
+
+// MatchDocument checks whether the document from debug info is the given
+// file. Either of them can be a path suffix of another (like "contract.go"
+// for "/path/to/contract.go"), because the document path depends on the way
+// the program was compiled.
+func MatchDocument(doc, file string) bool {
+	return doc == file || strings.HasSuffix(doc, "/"+file) || strings.HasSuffix(file, "/"+doc)
+}
+
+// LineToIP returns the first instruction of the given source line. file can
+// be omitted (left empty) if the program consists of a single file.
+func LineToIP(di *compiler.DebugInfo, file string, line int) (int, error) {
+	if di == nil {
+		return 0, ErrNoDebugInfo
+	}
+	docs := make(map[int]bool)
+	for i, d := range di.Documents {
+		if file == "" || MatchDocument(d, file) {
+			docs[i] = true
+		}
+	}
+	if file == "" && len(docs) > 1 {
+		return 0, ErrAmbiguousFile
+	}
+	ip := -1
+	for _, m := range di.Methods {
+		for _, sp := range m.SeqPoints {
+			if docs[sp.Document] && sp.StartLine == line && (ip < 0 || sp.Opcode < ip) {
+				ip = sp.Opcode
+			}
+		}
+	}
+	if ip < 0 {
+		return 0, ErrNoCode
+	}
+	return ip, nil
+}
+
+// StepSource executes instructions until the next source line is reached,
+// stepping into calls or over them depending on into flag.
+func StepSource(v *vm.VM, di *compiler.DebugInfo, into bool) error {
+	if di == nil {
+		return ErrNoDebugInfo
+	}
+	depth := v.Istack().Len()
+	_, start, _ := FindSeqPoint(di, v.Context().NextIP())
+	for {
+		if err := v.StepInto(); err != nil {
+			return err
+		}
+		if v.HasStopped() || v.Context() == nil {
+			return nil
+		}
+		d := v.Istack().Len()
+		if !into && d > depth {
+			continue
+		}
+		_, sp, exact := FindSeqPoint(di, v.Context().NextIP())
+		if exact && (sp != start || d != depth) {
+			return nil
+		}
+	}
+}
+
+// IsValidExpression checks whether the given string can be evaluated by Eval.
+func IsValidExpression(expr string) bool {
+	return slotExpr.MatchString(expr) || isIdentifier(expr)
+}
+
+func isIdentifier(s string) bool {
+	for i, r := range s {
+		if !(r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || i > 0 && r >= '0' && r <= '9') {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// Eval returns the item referred to by the expression in the given context,
+// it's either an estack, arg, local or static slot element (like "local[1]")
+// or a context method parameter name.
+func Eval(ctx *vm.Context, ip int, di *compiler.DebugInfo, expr string) (stackitem.Item, error) {
+	if ctx == nil {
+		return nil, errors.New("no program running")
+	}
+	var (
+		kind string
+		idx  int
+	)
+	if match := slotExpr.FindStringSubmatch(expr); match != nil {
+		kind = match[1]
+		idx, _ = strconv.Atoi(match[2])
+	} else if isIdentifier(expr) {
+		m, _, _ := FindSeqPoint(di, ip)
+		if m == nil {
+			return nil, ErrNoDebugInfo
+		}
+		idx = -1
+		for i := range m.Parameters {
+			if m.Parameters[i].Name == expr {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			return nil, errors.New("unknown parameter")
+		}
+		kind = "arg"
+	} else {
+		return nil, ErrInvalidExpression
+	}
+	var slot *vm.Slot
+	switch kind {
+	case "estack":
+		e := ctx.Estack().Peek(idx)
+		if e == nil {
+			return nil, errors.New("no such item")
+		}
+		return e.Item(), nil
+	case "arg":
+		slot = ctx.ArgumentSlot()
+	case "local":
+		slot = ctx.LocalSlot()
+	case "static":
+		slot = ctx.StaticSlot()
+	}
+	if slot == nil {
+		return nil, errors.New("slot is not initialized")
+	}
+	if idx >= slot.Size() {
+		return nil, errors.New("no such item")
+	}
+	return slot.Get(idx), nil
+}
@@ -0,0 +1,120 @@
+package vm
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+)
+
+// ProfileEntry contains execution statistics of an opcode or a syscall.
+type ProfileEntry struct {
+	Count       int
+	GasConsumed int64
+	Time        time.Duration
+}
+
+// Profile contains per-opcode and per-syscall (by ID) execution statistics
+// collected by the VM with profiling enabled. SYSCALL opcode statistics
+// include all syscalls. GAS consumed by an instruction includes both its price
+// and any additional GAS it has burnt during execution (like the one spent by
+// syscalls).
+type Profile struct {
+	Opcodes  map[opcode.Opcode]*ProfileEntry
+	Syscalls map[uint32]*ProfileEntry
+}
+
+func newProfile() *Profile {
+	return &Profile{
+		Opcodes:  make(map[opcode.Opcode]*ProfileEntry),
+		Syscalls: make(map[uint32]*ProfileEntry),
+	}
+}
+
+// EnableProfiling makes VM collect execution statistics for every instruction
+// executed, any previously collected statistics are dropped. It has some
+// performance impact, so it's intended to be used for test invocations only.
+func (v *VM) EnableProfiling() {
+	v.profile = newProfile()
+}
+
+// Profile returns execution statistics collected since profiling was enabled
+// (or since the last Load), nil if profiling is disabled.
+func (v *VM) Profile() *Profile {
+	return v.profile
+}
+
+func (p *Profile) add(op opcode.Opcode, parameter []byte, gas int64, t time.Duration) {
+	e := p.Opcodes[op]
+	if e == nil {
+		e = new(ProfileEntry)
+		p.Opcodes[op] = e
+	}
+	e.add(gas, t)
+	if op == opcode.SYSCALL && len(parameter) == 4 {
+		id := GetInteropID(parameter)
+		e = p.Syscalls[id]
+		if e == nil {
+			e = new(ProfileEntry)
+			p.Syscalls[id] = e
+		}
+		e.add(gas, t)
+	}
+}
+
+func (e *ProfileEntry) add(gas int64, t time.Duration) {
+	e.Count++
+	e.GasConsumed += gas
+	e.Time += t
+}
+
+// PrintProfile prints collected execution statistics (opcodes and syscalls
+// sorted by GAS consumed) to the given writer (defaults to os.Stdout if nil).
+func (v *VM) PrintProfile(out io.Writer) {
+	if out == nil {
+		out = os.Stdout
+	}
+	if v.profile == nil {
+		fmt.Fprintln(out, "profiling is disabled")
+		return
+	}
+	type namedEntry struct {
+		name string
+		*ProfileEntry
+	}
+	printEntries := func(header string, es []namedEntry) {
+		sort.Slice(es, func(i, j int) bool {
+			if es[i].GasConsumed != es[j].GasConsumed {
+				return es[i].GasConsumed > es[j].GasConsumed
+			}
+			return es[i].name < es[j].name
+		})
+		w := tabwriter.NewWriter(out, 0, 0, 4, ' ', 0)
+		fmt.Fprintf(w, "%s\tCOUNT\tGAS\tTIME\t\n", header)
+		for _, e := range es {
+			fmt.Fprintf(w, "%s\t%d\t%d\t%s\t\n", e.name, e.Count, e.GasConsumed, e.Time)
+		}
+		w.Flush()
+	}
+	ops := make([]namedEntry, 0, len(v.profile.Opcodes))
+	for op, e := range v.profile.Opcodes {
+		ops = append(ops, namedEntry{op.String(), e})
+	}
+	printEntries("OPCODE", ops)
+	if len(v.profile.Syscalls) != 0 {
+		scs := make([]namedEntry, 0, len(v.profile.Syscalls))
+		for id, e := range v.profile.Syscalls {
+			name, err := interopnames.FromID(id)
+			if err != nil {
+				name = fmt.Sprintf("0x%08x", id)
+			}
+			scs = append(scs, namedEntry{name, e})
+		}
+		printEntries("SYSCALL", scs)
+	}
+}
@@ -0,0 +1,66 @@
+package vm
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVM_Profile(t *testing.T) {
+	v := newTestVM()
+	v.SyscallHandler = func(v *VM, id uint32) error {
+		v.AddGas(10)
+		return fooInteropHandler(v, id)
+	}
+	v.SetPriceGetter(func(op opcode.Opcode, p []byte) int64 {
+		switch op {
+		case opcode.PUSH1:
+			return 1
+		case opcode.SYSCALL:
+			return 5
+		}
+		return 0
+	})
+
+	buf := io.NewBufBinWriter()
+	emit.Opcodes(buf.BinWriter, opcode.PUSH1, opcode.PUSH1, opcode.DROP)
+	emit.Syscall(buf.BinWriter, "foo")
+	emit.Opcodes(buf.BinWriter, opcode.RET)
+	prog := buf.Bytes()
+
+	t.Run("disabled", func(t *testing.T) {
+		v.Load(prog)
+		runVM(t, v)
+		require.Nil(t, v.Profile())
+	})
+
+	v.EnableProfiling()
+	t.Run("enabled", func(t *testing.T) {
+		v.Load(prog)
+		runVM(t, v)
+		p := v.Profile()
+		require.NotNil(t, p)
+		require.Equal(t, 4, len(p.Opcodes))
+		require.Equal(t, 2, p.Opcodes[opcode.PUSH1].Count)
+		require.EqualValues(t, 2, p.Opcodes[opcode.PUSH1].GasConsumed)
+		require.Equal(t, 1, p.Opcodes[opcode.DROP].Count)
+		require.EqualValues(t, 0, p.Opcodes[opcode.DROP].GasConsumed)
+		require.Equal(t, 1, p.Opcodes[opcode.SYSCALL].Count)
+		require.EqualValues(t, 16, p.Opcodes[opcode.SYSCALL].GasConsumed)
+
+		require.Equal(t, 1, len(p.Syscalls))
+		sc := p.Syscalls[interopnames.ToID([]byte("foo"))]
+		require.NotNil(t, sc)
+		require.Equal(t, 1, sc.Count)
+		require.EqualValues(t, 16, sc.GasConsumed)
+		require.Equal(t, v.GasConsumed(), p.Opcodes[opcode.PUSH1].GasConsumed+p.Opcodes[opcode.SYSCALL].GasConsumed)
+	})
+	t.Run("reset on load", func(t *testing.T) {
+		v.Load(prog)
+		require.Equal(t, 0, len(v.Profile().Opcodes))
+	})
+}
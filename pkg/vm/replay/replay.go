@@ -0,0 +1,337 @@
+/*
+Package replay implements recording of invocation interop results and their
+deterministic replay in a standalone VM. Recorded invocation doesn't need
+any chain state to be replayed, every syscall (including storage reads and
+witness checks) and every contract call just reproduces its recorded effect
+on the VM, so that it's possible to debug invocations performed by a node
+offline. Records are only made for test invocations (like invokescript RPC
+calls), transactions included in blocks are not recorded. Records are limited
+to MaxEffects effects and MaxRecordSize bytes of scripts and items.
+*/
+package replay
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+// Effect kinds.
+const (
+	KindSyscall = "syscall"
+	KindToken   = "token"
+)
+
+// Record limits, every effect stores items pushed and the script of the
+// contract called, so records can be much bigger than the invocation itself.
+const (
+	// MaxEffects is the maximum number of effects in a record.
+	MaxEffects = 65536
+	// MaxRecordSize is the maximum total size of scripts and encoded items
+	// stored in a record.
+	MaxRecordSize = 16 * 1024 * 1024
+)
+
+// ErrRecordTooBig is returned by Recorder when record limits are exceeded.
+var ErrRecordTooBig = errors.New("record is too big")
+
+// ErrNestedExecution is returned by Recorder when interop has executed
+// contract code synchronously (like native contracts calling back to
+// deployed ones), which can't be replayed.
+var ErrNestedExecution = errors.New("nested execution can't be recorded")
+
+// ErrDiverged is returned when the replayed program invokes interop different
+// from the recorded one.
+var ErrDiverged = errors.New("execution diverged from the record")
+
+// Record is a recorded invocation.
+type Record struct {
+	// Script is an invocation script.
+	Script []byte `json:"script"`
+	// Effects are recorded interop results in execution order.
+	Effects []Effect `json:"effects"`
+}
+
+// Effect is an effect of a single syscall or method token call on the VM.
+type Effect struct {
+	// Kind is either KindSyscall or KindToken.
+	Kind string `json:"kind"`
+	// ID is a syscall ID or method token index.
+	ID uint32 `json:"id"`
+	// Name is a syscall name (for convenience, it's not used for replay).
+	Name string `json:"name,omitempty"`
+	// Popped is the number of items popped from the evaluation stack.
+	Popped int `json:"popped"`
+	// Pushed are items pushed to the evaluation stack (bottom to top).
+	Pushed []json.RawMessage `json:"pushed,omitempty"`
+	// GasConsumed is GAS burnt by interop (not including opcode price).
+	GasConsumed int64 `json:"gasconsumed,string"`
+	// Contexts are contexts loaded (bottom to top).
+	Contexts []LoadedContext `json:"contexts,omitempty"`
+	// Error is the error returned by interop.
+	Error string `json:"error,omitempty"`
+}
+
+// LoadedContext is a context loaded by contract call. The first context of
+// the effect loads the contract script, all subsequent ones are calls made
+// in it (like `_initialize` method call), they're only described by IP.
+type LoadedContext struct {
+	Script      []byte            `json:"script,omitempty"`
+	Hash        util.Uint160      `json:"hash"`
+	CallingHash util.Uint160      `json:"callinghash"`
+	CallFlags   callflag.CallFlag `json:"callflags"`
+	RetCount    int               `json:"retcount"`
+	ParamCount  int               `json:"paramcount"`
+	IP          int               `json:"ip"`
+	// Estack are items of the new evaluation stack (bottom to top).
+	Estack []json.RawMessage `json:"estack,omitempty"`
+}
+
+// Recorder records interop results of the VM.
+type Recorder struct {
+	rec     Record
+	size    int
+	syscall func(*vm.VM, uint32) error
+	token   func(int32) error
+	depth   int
+	err     error
+}
+
+// snapshot is VM state before interop invocation.
+type snapshot struct {
+	estack *vm.Stack
+	items  []stackitem.Item
+	istack int
+	gas    int64
+}
+
+// NewRecorder starts recording interop results of the VM, the program must
+// already be loaded and VM interop handlers set.
+func NewRecorder(v *vm.VM) *Recorder {
+	r := &Recorder{
+		rec:     Record{Script: v.Context().Program()},
+		size:    len(v.Context().Program()),
+		syscall: v.SyscallHandler,
+		token:   v.LoadToken,
+	}
+	v.SyscallHandler = r.handleSyscall
+	if v.LoadToken != nil {
+		v.LoadToken = func(id int32) error {
+			return r.handleToken(v, id)
+		}
+	}
+	return r
+}
+
+// Record returns recorded invocation.
+func (r *Recorder) Record() (*Record, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	return &r.rec, nil
+}
+
+func (r *Recorder) handleSyscall(v *vm.VM, id uint32) error {
+	return r.record(v, Effect{Kind: KindSyscall, ID: id}, func() error {
+		return r.syscall(v, id)
+	})
+}
+
+func (r *Recorder) handleToken(v *vm.VM, id int32) error {
+	return r.record(v, Effect{Kind: KindToken, ID: uint32(id)}, func() error {
+		return r.token(id)
+	})
+}
+
+func (r *Recorder) record(v *vm.VM, e Effect, f func() error) error {
+	if r.depth > 0 {
+		if r.err == nil {
+			r.err = ErrNestedExecution
+		}
+		return f()
+	}
+	if r.err != nil {
+		// Recording has failed already, there is no need to track
+		// effects anymore.
+		return f()
+	}
+	r.depth++
+	defer func() { r.depth-- }()
+
+	s := snapshot{
+		estack: v.Estack(),
+		items:  v.Estack().ToArray(),
+		istack: v.Istack().Len(),
+		gas:    v.GasConsumed(),
+	}
+	err := f()
+	if e.Kind == KindSyscall {
+		e.Name, _ = interopnames.FromID(e.ID)
+	}
+	e.GasConsumed = v.GasConsumed() - s.gas
+	if err != nil {
+		e.Error = err.Error()
+		r.add(e)
+		return err
+	}
+
+	after := s.estack.ToArray()
+	var common int
+	for common < len(s.items) && common < len(after) && s.items[common] == after[common] {
+		common++
+	}
+	e.Popped = len(s.items) - common
+	if e.Pushed, err = encodeItems(after[common:]); err != nil {
+		r.err = err
+	}
+	istack := v.Istack()
+	for i := istack.Len() - s.istack - 1; i >= 0; i-- {
+		ctx := istack.Peek(i).Value().(*vm.Context)
+		lc := LoadedContext{IP: ctx.NextIP()}
+		if len(e.Contexts) == 0 {
+			lc.Script = ctx.Program()
+			lc.Hash = ctx.ScriptHash()
+			lc.CallingHash = v.GetCallingScriptHash()
+			lc.CallFlags = ctx.GetCallFlags()
+			lc.RetCount = ctx.RetCount
+			lc.ParamCount = ctx.ParamCount
+			if lc.Estack, err = encodeItems(ctx.Estack().ToArray()); err != nil {
+				r.err = err
+			}
+		}
+		e.Contexts = append(e.Contexts, lc)
+	}
+	r.add(e)
+	return nil
+}
+
+// add appends the effect to the record checking record limits.
+func (r *Recorder) add(e Effect) {
+	r.size += len(e.Error)
+	for i := range e.Pushed {
+		r.size += len(e.Pushed[i])
+	}
+	for _, lc := range e.Contexts {
+		r.size += len(lc.Script)
+		for i := range lc.Estack {
+			r.size += len(lc.Estack[i])
+		}
+	}
+	if len(r.rec.Effects) >= MaxEffects || r.size > MaxRecordSize {
+		r.err = ErrRecordTooBig
+		r.rec.Effects = nil
+		return
+	}
+	r.rec.Effects = append(r.rec.Effects, e)
+}
+
+func encodeItems(items []stackitem.Item) ([]json.RawMessage, error) {
+	res := make([]json.RawMessage, len(items))
+	for i := range items {
+		data, err := stackitem.ToJSONWithTypes(items[i])
+		if err != nil {
+			return nil, err
+		}
+		res[i] = data
+	}
+	return res, nil
+}
+
+func decodeItems(data []json.RawMessage) ([]stackitem.Item, error) {
+	res := make([]stackitem.Item, len(data))
+	for i := range data {
+		var typ struct {
+			Type string `json:"type"`
+		}
+		if err := json.Unmarshal(data[i], &typ); err != nil {
+			return nil, err
+		}
+		// Interop items are opaque, they're only passed back to interops
+		// which are replayed anyway.
+		if typ.Type == stackitem.InteropT.String() {
+			res[i] = stackitem.NewInterop(nil)
+			continue
+		}
+		item, err := stackitem.FromJSONWithTypes(data[i])
+		if err != nil {
+			return nil, err
+		}
+		res[i] = item
+	}
+	return res, nil
+}
+
+// player replays recorded effects.
+type player struct {
+	v       *vm.VM
+	effects []Effect
+}
+
+// Load loads recorded invocation into the VM replacing its interop handlers
+// with the ones reproducing recorded effects.
+func Load(v *vm.VM, r *Record) {
+	p := &player{v: v, effects: r.Effects}
+	v.Load(r.Script)
+	v.SyscallHandler = func(_ *vm.VM, id uint32) error {
+		return p.replay(KindSyscall, id)
+	}
+	v.LoadToken = func(id int32) error {
+		return p.replay(KindToken, uint32(id))
+	}
+}
+
+func (p *player) replay(kind string, id uint32) error {
+	if len(p.effects) == 0 {
+		return fmt.Errorf("%w: unexpected %s %d", ErrDiverged, kind, id)
+	}
+	e := &p.effects[0]
+	if e.Kind != kind || e.ID != id {
+		return fmt.Errorf("%w: expected %s %d, got %s %d", ErrDiverged, e.Kind, e.ID, kind, id)
+	}
+	p.effects = p.effects[1:]
+
+	v := p.v
+	if !v.AddGas(e.GasConsumed) {
+		return errors.New("gas limit exceeded")
+	}
+	if e.Error != "" {
+		return errors.New(e.Error)
+	}
+	pushed, err := decodeItems(e.Pushed)
+	if err != nil {
+		return fmt.Errorf("invalid record: %w", err)
+	}
+	if v.Estack().Len() < e.Popped {
+		return fmt.Errorf("%w: stack is too small", ErrDiverged)
+	}
+	for i := 0; i < e.Popped; i++ {
+		v.Estack().Pop()
+	}
+	for i := range pushed {
+		v.Estack().PushVal(pushed[i])
+	}
+	for i, lc := range e.Contexts {
+		if i != 0 {
+			v.Call(v.Context(), lc.IP)
+			continue
+		}
+		items, err := decodeItems(lc.Estack)
+		if err != nil {
+			return fmt.Errorf("invalid record: %w", err)
+		}
+		v.LoadScriptWithCallingHash(lc.CallingHash, lc.Script, lc.Hash, lc.CallFlags,
+			lc.RetCount > 0, uint16(lc.ParamCount))
+		v.Context().RetCount = lc.RetCount
+		for j := range items {
+			v.Estack().PushVal(items[j])
+		}
+		v.Jump(v.Context(), lc.IP)
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package replay
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/stretchr/testify/require"
+)
+
+// Test syscalls.
+const (
+	syscallSum uint32 = iota + 1
+	syscallIter
+	syscallNext
+	syscallCall
+	syscallNested
+	syscallBig
+)
+
+var calleeScript = []byte{byte(opcode.PUSH5), byte(opcode.ADD), byte(opcode.RET)}
+
+func testSyscallHandler(v *vm.VM, id uint32) error {
+	switch id {
+	case syscallSum:
+		a := v.Estack().Pop().BigInt().Int64()
+		b := v.Estack().Pop().BigInt().Int64()
+		v.Estack().PushVal(a + b)
+		v.AddGas(7)
+	case syscallIter:
+		v.Estack().PushVal(stackitem.NewInterop(nil))
+	case syscallNext:
+		v.Estack().Pop()
+		v.Estack().PushVal(true)
+		v.AddGas(3)
+	case syscallCall:
+		v.LoadScriptWithCallingHash(v.GetCurrentScriptHash(), calleeScript, util.Uint160{1, 2, 3},
+			callflag.All, true, 0)
+		v.Estack().PushVal(10)
+	case syscallNested:
+		return v.SyscallHandler(v, syscallIter)
+	case syscallBig:
+		v.Estack().PushVal(make([]byte, 512*1024))
+	default:
+		return errors.New("unknown syscall")
+	}
+	return nil
+}
+
+func emitSyscall(w *io.BinWriter, id uint32) {
+	b := make([]byte, 4)
+	binary.LittleEndian.PutUint32(b, id)
+	emit.Instruction(w, opcode.SYSCALL, b)
+}
+
+func newTestVM(script []byte) *vm.VM {
+	v := vm.New()
+	v.GasLimit = -1
+	v.SyscallHandler = testSyscallHandler
+	v.LoadToken = func(id int32) error {
+		v.Estack().PushVal(42 + id)
+		return nil
+	}
+	v.Load(script)
+	return v
+}
+
+func TestRecordReplay(t *testing.T) {
+	w := io.NewBufBinWriter()
+	emit.Opcodes(w.BinWriter, opcode.PUSH2, opcode.PUSH3)
+	emitSyscall(w.BinWriter, syscallSum)
+	emitSyscall(w.BinWriter, syscallIter)
+	emitSyscall(w.BinWriter, syscallNext)
+	emit.Opcodes(w.BinWriter, opcode.DROP)
+	emitSyscall(w.BinWriter, syscallCall)
+	emit.Instruction(w.BinWriter, opcode.CALLT, []byte{1, 0})
+	emit.Opcodes(w.BinWriter, opcode.RET)
+	script := w.Bytes()
+
+	v := newTestVM(script)
+	rec := NewRecorder(v)
+	require.NoError(t, v.Run())
+	r, err := rec.Record()
+	require.NoError(t, err)
+	require.Equal(t, script, r.Script)
+	require.Equal(t, 5, len(r.Effects))
+	require.Equal(t, 2, r.Effects[0].Popped)
+	require.EqualValues(t, 7, r.Effects[0].GasConsumed)
+	require.Equal(t, 1, len(r.Effects[3].Contexts))
+	require.Equal(t, calleeScript, r.Effects[3].Contexts[0].Script)
+	require.Equal(t, KindToken, r.Effects[4].Kind)
+
+	data, err := json.Marshal(r)
+	require.NoError(t, err)
+	actual := new(Record)
+	require.NoError(t, json.Unmarshal(data, actual))
+
+	t.Run("good", func(t *testing.T) {
+		p := vm.New()
+		p.GasLimit = -1
+		Load(p, actual)
+		require.NoError(t, p.Run())
+		require.Equal(t, v.Estack().ToArray(), p.Estack().ToArray())
+		require.Equal(t, v.GasConsumed(), p.GasConsumed())
+	})
+	t.Run("diverged", func(t *testing.T) {
+		bad := *actual
+		bad.Effects = append([]Effect{}, actual.Effects...)
+		bad.Effects[1].ID = syscallNext
+		p := vm.New()
+		p.GasLimit = -1
+		Load(p, &bad)
+		err := p.Run()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), ErrDiverged.Error())
+	})
+	t.Run("error", func(t *testing.T) {
+		bad := *actual
+		bad.Effects = append([]Effect{}, actual.Effects...)
+		bad.Effects[0].Error = "some error"
+		p := vm.New()
+		p.GasLimit = -1
+		Load(p, &bad)
+		err := p.Run()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "some error")
+	})
+}
+
+func TestRecordNested(t *testing.T) {
+	w := io.NewBufBinWriter()
+	emitSyscall(w.BinWriter, syscallNested)
+	v := newTestVM(w.Bytes())
+	rec := NewRecorder(v)
+	require.NoError(t, v.Run())
+	_, err := rec.Record()
+	require.True(t, errors.Is(err, ErrNestedExecution))
+}
+
+func TestRecordTooBig(t *testing.T) {
+	w := io.NewBufBinWriter()
+	for i := 0; i < 2*MaxRecordSize/(512*1024); i++ {
+		emitSyscall(w.BinWriter, syscallBig)
+		emit.Opcodes(w.BinWriter, opcode.DROP)
+	}
+	require.NoError(t, w.Err)
+
+	v := newTestVM(w.Bytes())
+	r := NewRecorder(v)
+	require.NoError(t, v.Run())
+	_, err := r.Record()
+	require.True(t, errors.Is(err, ErrRecordTooBig))
+}
@@ -0,0 +1,417 @@
+package stackitem
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// Convertible is implemented by types providing their own conversion from
+// stack items, ToGo uses it instead of reflection.
+type Convertible interface {
+	FromStackItem(Item) error
+}
+
+// itemer and itemerWithError are implemented by types providing their own
+// conversion to stack items, FromGo uses them instead of reflection.
+type (
+	itemer interface {
+		ToStackItem() Item
+	}
+	itemerWithError interface {
+		ToStackItem() (Item, error)
+	}
+)
+
+// tagName is the name of struct field tag used by ToGo and FromGo.
+const tagName = "stackitem"
+
+var (
+	bigIntType      = reflect.TypeOf((*big.Int)(nil))
+	itemType        = reflect.TypeOf((*Item)(nil)).Elem()
+	convertibleType = reflect.TypeOf((*Convertible)(nil)).Elem()
+)
+
+// ToGo converts stack item to the value pointed to by v. Integers, booleans,
+// strings, byte slices, util.Uint160, util.Uint256 and *big.Int are converted
+// from the corresponding primitive items, slices and arrays from Array and
+// Struct items, maps from Map items. Structs are filled either from Array or
+// Struct items (exported fields in the order of declaration) or from Map items
+// (by key equal to field name or name specified in `stackitem` tag, case
+// insensitive). Fields tagged with `stackitem:"-"` are skipped. Null item
+// makes pointers, slices and maps nil. Types implementing Convertible
+// interface convert themselves. Recursive items can't be converted.
+func ToGo(item Item, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return errors.New("non-nil pointer expected")
+	}
+	return toGo(item, rv.Elem(), make(map[Item]bool))
+}
+
+// toGo converts item to v, seen contains compound items with elements being
+// converted (the path from the root one to the current one).
+func toGo(item Item, v reflect.Value, seen map[Item]bool) error {
+	if item == nil {
+		return errors.New("nil item")
+	}
+	if v.CanAddr() && v.Addr().Type().Implements(convertibleType) {
+		return v.Addr().Interface().(Convertible).FromStackItem(item)
+	}
+	t := v.Type()
+	if t == itemType {
+		v.Set(reflect.ValueOf(item))
+		return nil
+	}
+	_, isNull := item.(Null)
+	switch t.Kind() {
+	case reflect.Ptr:
+		if isNull {
+			v.Set(reflect.Zero(t))
+			return nil
+		}
+		if t == bigIntType {
+			bi, err := item.TryInteger()
+			if err != nil {
+				return err
+			}
+			v.Set(reflect.ValueOf(bi))
+			return nil
+		}
+		p := reflect.New(t.Elem())
+		if err := toGo(item, p.Elem(), seen); err != nil {
+			return err
+		}
+		v.Set(p)
+		return nil
+	case reflect.Bool:
+		b, err := item.TryBool()
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		bi, err := item.TryInteger()
+		if err != nil {
+			return err
+		}
+		if !bi.IsInt64() || v.OverflowInt(bi.Int64()) {
+			return fmt.Errorf("integer %s overflows %s", bi, t)
+		}
+		v.SetInt(bi.Int64())
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		bi, err := item.TryInteger()
+		if err != nil {
+			return err
+		}
+		if !bi.IsUint64() || v.OverflowUint(bi.Uint64()) {
+			return fmt.Errorf("integer %s overflows %s", bi, t)
+		}
+		v.SetUint(bi.Uint64())
+		return nil
+	case reflect.String:
+		b, err := item.TryBytes()
+		if err != nil {
+			return err
+		}
+		v.SetString(string(b))
+		return nil
+	case reflect.Array:
+		// Hashes are byte arrays too, stack items contain them in BE which
+		// is their native order.
+		if t.Elem().Kind() == reflect.Uint8 {
+			b, err := item.TryBytes()
+			if err != nil {
+				return err
+			}
+			if len(b) != t.Len() {
+				return fmt.Errorf("expected %d bytes for %s, got %d", t.Len(), t, len(b))
+			}
+			reflect.Copy(v, reflect.ValueOf(b))
+			return nil
+		}
+		arr, err := arrayValue(item)
+		if err != nil {
+			return err
+		}
+		if err := enter(item, seen); err != nil {
+			return err
+		}
+		defer delete(seen, item)
+		if len(arr) != t.Len() {
+			return fmt.Errorf("expected %d elements for %s, got %d", t.Len(), t, len(arr))
+		}
+		for i := range arr {
+			if err := toGo(arr[i], v.Index(i), seen); err != nil {
+				return fmt.Errorf("element %d: %w", i, err)
+			}
+		}
+		return nil
+	case reflect.Slice:
+		if isNull {
+			v.Set(reflect.Zero(t))
+			return nil
+		}
+		if t.Elem().Kind() == reflect.Uint8 {
+			b, err := item.TryBytes()
+			if err != nil {
+				return err
+			}
+			v.SetBytes(append([]byte{}, b...))
+			return nil
+		}
+		arr, err := arrayValue(item)
+		if err != nil {
+			return err
+		}
+		if err := enter(item, seen); err != nil {
+			return err
+		}
+		defer delete(seen, item)
+		s := reflect.MakeSlice(t, len(arr), len(arr))
+		for i := range arr {
+			if err := toGo(arr[i], s.Index(i), seen); err != nil {
+				return fmt.Errorf("element %d: %w", i, err)
+			}
+		}
+		v.Set(s)
+		return nil
+	case reflect.Map:
+		if isNull {
+			v.Set(reflect.Zero(t))
+			return nil
+		}
+		m, ok := item.(*Map)
+		if !ok {
+			return fmt.Errorf("expected map, got %s", item.Type())
+		}
+		if err := enter(item, seen); err != nil {
+			return err
+		}
+		defer delete(seen, item)
+		res := reflect.MakeMapWithSize(t, m.Len())
+		for _, e := range m.Value().([]MapElement) {
+			key := reflect.New(t.Key()).Elem()
+			if err := toGo(e.Key, key, seen); err != nil {
+				return fmt.Errorf("map key: %w", err)
+			}
+			val := reflect.New(t.Elem()).Elem()
+			if err := toGo(e.Value, val, seen); err != nil {
+				return fmt.Errorf("map value: %w", err)
+			}
+			res.SetMapIndex(key, val)
+		}
+		v.Set(res)
+		return nil
+	case reflect.Struct:
+		fields := structFields(t)
+		switch item.(type) {
+		case *Array, *Struct, *Map:
+			if err := enter(item, seen); err != nil {
+				return err
+			}
+			defer delete(seen, item)
+		}
+		if m, ok := item.(*Map); ok {
+			for _, e := range m.Value().([]MapElement) {
+				key, err := e.Key.TryBytes()
+				if err != nil {
+					return fmt.Errorf("map key: %w", err)
+				}
+				for _, f := range fields {
+					if strings.EqualFold(f.name, string(key)) {
+						if err := toGo(e.Value, v.Field(f.index), seen); err != nil {
+							return fmt.Errorf("field %s: %w", t.Field(f.index).Name, err)
+						}
+						break
+					}
+				}
+			}
+			return nil
+		}
+		arr, err := arrayValue(item)
+		if err != nil {
+			return err
+		}
+		if len(arr) != len(fields) {
+			return fmt.Errorf("expected %d elements for %s, got %d", len(fields), t, len(arr))
+		}
+		for i, f := range fields {
+			if err := toGo(arr[i], v.Field(f.index), seen); err != nil {
+				return fmt.Errorf("field %s: %w", t.Field(f.index).Name, err)
+			}
+		}
+		return nil
+	case reflect.Interface:
+		if isNull {
+			v.Set(reflect.Zero(t))
+			return nil
+		}
+		if t.NumMethod() == 0 {
+			val := item.Value()
+			if val == nil {
+				// Interop items can contain nil.
+				v.Set(reflect.Zero(t))
+				return nil
+			}
+			v.Set(reflect.ValueOf(val))
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported type %s", t)
+}
+
+// FromGo converts Go value to stack item, it's the reverse of ToGo. Structs are
+// converted to Struct items, slices and arrays (except byte ones) to Array
+// items, nil pointers, slices, maps and interfaces to Null. Types providing
+// ToStackItem method convert themselves.
+func FromGo(v interface{}) (Item, error) {
+	if v == nil {
+		return Null{}, nil
+	}
+	return fromGo(reflect.ValueOf(v))
+}
+
+func fromGo(v reflect.Value) (Item, error) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+		if v.IsNil() {
+			return Null{}, nil
+		}
+	}
+	if v.CanAddr() {
+		switch val := v.Addr().Interface().(type) {
+		case itemer:
+			return val.ToStackItem(), nil
+		case itemerWithError:
+			return val.ToStackItem()
+		}
+	}
+	if v.CanInterface() {
+		switch val := v.Interface().(type) {
+		case Item:
+			return val, nil
+		case itemer:
+			return val.ToStackItem(), nil
+		case itemerWithError:
+			return val.ToStackItem()
+		case *big.Int:
+			return NewBigInteger(val), nil
+		case util.Uint160:
+			return NewByteArray(val.BytesBE()), nil
+		case util.Uint256:
+			return NewByteArray(val.BytesBE()), nil
+		}
+	}
+	t := v.Type()
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return fromGo(v.Elem())
+	case reflect.Bool:
+		return NewBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return NewBigInteger(big.NewInt(v.Int())), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return NewBigInteger(new(big.Int).SetUint64(v.Uint())), nil
+	case reflect.String:
+		return NewByteArray([]byte(v.String())), nil
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, v.Len())
+			reflect.Copy(reflect.ValueOf(b), v)
+			return NewByteArray(b), nil
+		}
+		items := make([]Item, v.Len())
+		for i := range items {
+			item, err := fromGo(v.Index(i))
+			if err != nil {
+				return nil, fmt.Errorf("element %d: %w", i, err)
+			}
+			items[i] = item
+		}
+		return NewArray(items), nil
+	case reflect.Map:
+		m := NewMap()
+		iter := v.MapRange()
+		for iter.Next() {
+			key, err := fromGo(iter.Key())
+			if err != nil {
+				return nil, fmt.Errorf("map key: %w", err)
+			}
+			if err := IsValidMapKey(key); err != nil {
+				return nil, err
+			}
+			val, err := fromGo(iter.Value())
+			if err != nil {
+				return nil, fmt.Errorf("map value: %w", err)
+			}
+			m.Add(key, val)
+		}
+		return m, nil
+	case reflect.Struct:
+		fields := structFields(t)
+		items := make([]Item, len(fields))
+		for i, f := range fields {
+			item, err := fromGo(v.Field(f.index))
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", t.Field(f.index).Name, err)
+			}
+			items[i] = item
+		}
+		return NewStruct(items), nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", t)
+}
+
+// structField is an exported struct field converted by ToGo and FromGo.
+type structField struct {
+	index int
+	name  string
+}
+
+func structFields(t reflect.Type) []structField {
+	var fields []structField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" { // unexported
+			continue
+		}
+		name := f.Name
+		if tag, ok := f.Tag.Lookup(tagName); ok {
+			if tag == "-" {
+				continue
+			}
+			if tag != "" {
+				name = tag
+			}
+		}
+		fields = append(fields, structField{index: i, name: name})
+	}
+	return fields
+}
+
+// enter marks compound item as being converted, it returns an error if it's
+// already being converted (so it's recursive).
+func enter(item Item, seen map[Item]bool) error {
+	if seen[item] {
+		return errors.New("recursive structures can't be converted")
+	}
+	seen[item] = true
+	return nil
+}
+
+// arrayValue returns elements of Array or Struct item.
+func arrayValue(item Item) ([]Item, error) {
+	switch it := item.(type) {
+	case *Array, *Struct:
+		return it.Value().([]Item), nil
+	default:
+		return nil, fmt.Errorf("expected array or struct, got %s", item.Type())
+	}
+}
@@ -0,0 +1,156 @@
+package stackitem
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+type testInner struct {
+	Name  string
+	Value *big.Int
+}
+
+type testStruct struct {
+	Hash    util.Uint160
+	Amount  int64
+	Flag    bool
+	Data    []byte
+	Inner   testInner
+	List    []uint8 `stackitem:"-"`
+	Ints    []int32
+	Map     map[string]uint32
+	Ptr     *testInner
+	Skipped int `stackitem:"-"`
+	private int
+}
+
+type testConvertible struct {
+	value int64
+}
+
+func (c *testConvertible) FromStackItem(item Item) error {
+	bi, err := item.TryInteger()
+	if err != nil {
+		return err
+	}
+	c.value = bi.Int64() * 2
+	return nil
+}
+
+func (c testConvertible) ToStackItem() Item {
+	return NewBigInteger(big.NewInt(c.value / 2))
+}
+
+func TestToGoFromGo(t *testing.T) {
+	orig := testStruct{
+		Hash:   util.Uint160{1, 2, 3},
+		Amount: -42,
+		Flag:   true,
+		Data:   []byte{4, 5, 6},
+		Inner:  testInner{Name: "inner", Value: big.NewInt(100500)},
+		Ints:   []int32{1, -2, 3},
+		Map:    map[string]uint32{"key": 7},
+		Ptr:    nil,
+	}
+	item, err := FromGo(orig)
+	require.NoError(t, err)
+	expected := NewStruct([]Item{
+		NewByteArray(orig.Hash.BytesBE()),
+		Make(-42),
+		NewBool(true),
+		NewByteArray([]byte{4, 5, 6}),
+		NewStruct([]Item{NewByteArray([]byte("inner")), Make(100500)}),
+		NewArray([]Item{Make(1), Make(-2), Make(3)}),
+		NewMapWithValue([]MapElement{{Key: NewByteArray([]byte("key")), Value: Make(7)}}),
+		Null{},
+	})
+	require.Equal(t, expected, item)
+
+	var actual testStruct
+	require.NoError(t, ToGo(item, &actual))
+	require.Equal(t, orig, actual)
+
+	t.Run("from array", func(t *testing.T) {
+		arr := NewArray(expected.Value().([]Item))
+		var actual testStruct
+		require.NoError(t, ToGo(arr, &actual))
+		require.Equal(t, orig, actual)
+	})
+	t.Run("from map", func(t *testing.T) {
+		m := NewMapWithValue([]MapElement{
+			{Key: NewByteArray([]byte("name")), Value: NewByteArray([]byte("from map"))},
+			{Key: NewByteArray([]byte("unknown")), Value: Make(1)},
+		})
+		var actual testInner
+		require.NoError(t, ToGo(m, &actual))
+		require.Equal(t, testInner{Name: "from map"}, actual)
+	})
+	t.Run("pointer", func(t *testing.T) {
+		var actual *testInner
+		require.NoError(t, ToGo(NewStruct([]Item{NewByteArray([]byte("ptr")), Make(1)}), &actual))
+		require.Equal(t, &testInner{Name: "ptr", Value: big.NewInt(1)}, actual)
+
+		require.NoError(t, ToGo(Null{}, &actual))
+		require.Nil(t, actual)
+	})
+	t.Run("convertible", func(t *testing.T) {
+		var c testConvertible
+		require.NoError(t, ToGo(Make(21), &c))
+		require.Equal(t, int64(42), c.value)
+
+		item, err := FromGo(c)
+		require.NoError(t, err)
+		require.Equal(t, Make(21), item)
+	})
+	t.Run("item", func(t *testing.T) {
+		var item Item
+		require.NoError(t, ToGo(Make(1), &item))
+		require.Equal(t, Make(1), item)
+	})
+}
+
+func TestToGoErrors(t *testing.T) {
+	var u8 uint8
+	require.Error(t, ToGo(Make(256), &u8))
+	require.Error(t, ToGo(Make(-1), &u8))
+	require.Error(t, ToGo(Make(1), u8))
+	require.Error(t, ToGo(Make(1), nil))
+
+	var h util.Uint256
+	require.Error(t, ToGo(NewByteArray([]byte{1, 2, 3}), &h))
+
+	var s testInner
+	require.Error(t, ToGo(NewArray([]Item{Make(1)}), &s))
+	require.Error(t, ToGo(Make(1), &s))
+
+	var m map[string]int
+	require.Error(t, ToGo(NewArray(nil), &m))
+
+	var ch chan int
+	require.Error(t, ToGo(Make(1), &ch))
+
+	var iface interface{}
+	require.NoError(t, ToGo(NewInterop(nil), &iface))
+	require.Nil(t, iface)
+	var arr []interface{}
+	require.Error(t, ToGo(NewArray([]Item{nil}), &arr))
+
+	type recursive []recursive
+	rec := NewArray(nil)
+	rec.Append(rec)
+	var r recursive
+	require.Error(t, ToGo(rec, &r))
+	// Shared (not recursive) items are fine.
+	shared := NewArray([]Item{Make(1)})
+	var ints [][]int
+	require.NoError(t, ToGo(NewArray([]Item{shared, shared}), &ints))
+	require.Equal(t, [][]int{{1}, {1}}, ints)
+
+	_, err := FromGo(make(chan int))
+	require.Error(t, err)
+	_, err = FromGo(map[string]interface{}{"a": make(chan int)})
+	require.Error(t, err)
+}
@@ -12,6 +12,7 @@ import (
 	"math/big"
 	"os"
 	"text/tabwriter"
+	"time"
 	"unicode/utf8"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
@@ -83,10 +84,19 @@ type VM struct {
 	// LoadToken handles CALLT opcode.
 	LoadToken func(id int32) error
 
+	// OnUnload is called for every context being unloaded (either by RET or
+	// exception handling), it's optional.
+	OnUnload func(ctx *Context)
+
 	trigger trigger.Type
 
 	// Invocations is a script invocation counter.
 	Invocations map[util.Uint160]int
+
+	// Execution statistics, nil if profiling is disabled.
+	profile *Profile
+	// Instruction execution counters, nil if coverage is disabled.
+	coverage Coverage
 }
 
 // New returns a new VM object ready to load AVM bytecode scripts.
@@ -240,6 +250,41 @@ func (v *VM) AddBreakPoint(n int) {
 	ctx.breakPoints = append(ctx.breakPoints, n)
 }
 
+// AddBreakPointAll adds a breakpoint to all contexts of the invocation stack
+// running the same script as the current one, so that it's triggered after
+// returning from the current call too (contexts of new calls inherit
+// breakpoints of their callers).
+func (v *VM) AddBreakPointAll(n int) {
+	h := v.Context().ScriptHash()
+	for i := 0; i < v.istack.Len(); i++ {
+		ctx := v.istack.Peek(i).Value().(*Context)
+		if ctx.ScriptHash().Equals(h) {
+			// Callee contexts share breakpoints array with their callers.
+			l := len(ctx.breakPoints)
+			ctx.breakPoints = append(ctx.breakPoints[:l:l], n)
+		}
+	}
+}
+
+// RemoveBreakPointAll removes the breakpoint from all contexts of the
+// invocation stack running the same script as the current one.
+func (v *VM) RemoveBreakPointAll(n int) {
+	h := v.Context().ScriptHash()
+	for i := 0; i < v.istack.Len(); i++ {
+		ctx := v.istack.Peek(i).Value().(*Context)
+		if !ctx.ScriptHash().Equals(h) {
+			continue
+		}
+		bps := make([]int, 0, len(ctx.breakPoints))
+		for _, bp := range ctx.breakPoints {
+			if bp != n {
+				bps = append(bps, bp)
+			}
+		}
+		ctx.breakPoints = bps
+	}
+}
+
 // AddBreakPointRel adds a breakpoint relative to the current
 // instruction pointer.
 func (v *VM) AddBreakPointRel(n int) {
@@ -268,6 +313,9 @@ func (v *VM) Load(prog []byte) {
 	v.estack.Clear()
 	v.state = NoneState
 	v.gasConsumed = 0
+	if v.profile != nil {
+		v.profile = newProfile()
+	}
 	v.LoadScript(prog)
 }
 
@@ -527,6 +575,17 @@ func (v *VM) execute(ctx *Context, op opcode.Opcode, parameter []byte) (err erro
 		}
 	}()
 
+	if v.coverage != nil {
+		v.coverage.add(ctx.ScriptHash(), ctx.ip)
+	}
+
+	if v.profile != nil {
+		start, gas := time.Now(), v.gasConsumed
+		defer func() {
+			v.profile.add(op, parameter, v.gasConsumed-gas, time.Since(start))
+		}()
+	}
+
 	if v.getPrice != nil && ctx.ip < len(ctx.prog) {
 		v.gasConsumed += v.getPrice(op, parameter)
 		if v.GasLimit >= 0 && v.gasConsumed > v.GasLimit {
@@ -1464,6 +1523,9 @@ func (v *VM) execute(ctx *Context, op opcode.Opcode, parameter []byte) (err erro
 }
 
 func (v *VM) unloadContext(ctx *Context) {
+	if v.OnUnload != nil {
+		v.OnUnload(ctx)
+	}
 	if ctx.local != nil {
 		ctx.local.Clear()
 	}
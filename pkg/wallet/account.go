@@ -96,10 +96,16 @@ func NewAccount() (*Account, error) {
 
 // SignTx signs transaction t and updates it's Witnesses.
 func (a *Account) SignTx(net netmode.Magic, t *transaction.Transaction) error {
+	if a.Contract == nil {
+		return errors.New("account has no verification script")
+	}
 	if len(a.Contract.Parameters) == 0 {
 		t.Scripts = append(t.Scripts, transaction.Witness{})
 		return nil
 	}
+	if a.IsWatchOnly() {
+		return errors.New("account is watch-only")
+	}
 	if a.privateKey == nil {
 		return errors.New("account is not unlocked")
 	}
@@ -126,7 +132,10 @@ func (a *Account) GetVerificationScript() []byte {
 	if a.Contract != nil {
 		return a.Contract.Script
 	}
-	return a.PrivateKey().PublicKey().GetVerificationScript()
+	if a.privateKey == nil {
+		return nil
+	}
+	return a.privateKey.PublicKey().GetVerificationScript()
 }
 
 // Decrypt decrypts the EncryptedWIF with the given passphrase returning error
@@ -159,6 +168,12 @@ func (a *Account) Encrypt(passphrase string) error {
 	return nil
 }
 
+// IsWatchOnly returns true if the account has no private key (neither
+// encrypted nor decrypted) and thus can't sign anything.
+func (a *Account) IsWatchOnly() bool {
+	return a.EncryptedWIF == "" && a.privateKey == nil
+}
+
 // PrivateKey returns private key corresponding to the account.
 func (a *Account) PrivateKey() *keys.PrivateKey {
 	return a.privateKey
@@ -234,6 +249,47 @@ func NewAccountFromPrivateKey(p *keys.PrivateKey) *Account {
 	return a
 }
 
+// NewWatchOnlyAccount creates an account for the given script hash without
+// private key and verification script. It can only be used to track the
+// address state.
+func NewWatchOnlyAccount(h util.Uint160) *Account {
+	return &Account{
+		Address: address.Uint160ToString(h),
+	}
+}
+
+// NewWatchOnlyAccountFromPublicKey creates a standard signature account for
+// the given public key without private key. Transactions for this account can
+// be created (including network fee calculation), but they have to be signed
+// elsewhere.
+func NewWatchOnlyAccountFromPublicKey(pub *keys.PublicKey) *Account {
+	return &Account{
+		publicKey: pub.Bytes(),
+		Address:   pub.Address(),
+		Contract: &Contract{
+			Script:     pub.GetVerificationScript(),
+			Parameters: getContractParams(1),
+		},
+	}
+}
+
+// NewWatchOnlyMultisigAccount creates m out of len(pubs) multisignature
+// account without private key. Transactions for this account can be created,
+// but they have to be signed with the keys stored elsewhere.
+func NewWatchOnlyMultisigAccount(m int, pubs []*keys.PublicKey) (*Account, error) {
+	script, err := smartcontract.CreateMultiSigRedeemScript(m, pubs)
+	if err != nil {
+		return nil, err
+	}
+	return &Account{
+		Address: address.Uint160ToString(hash.Hash160(script)),
+		Contract: &Contract{
+			Script:     script,
+			Parameters: getContractParams(m),
+		},
+	}, nil
+}
+
 func getContractParams(n int) []ContractParam {
 	params := make([]ContractParam, n)
 	for i := range params {
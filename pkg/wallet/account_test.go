@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/internal/keytestcases"
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/stretchr/testify/assert"
@@ -123,6 +125,26 @@ func TestAccount_ConvertMultisig(t *testing.T) {
 	})
 }
 
+func TestNewWatchOnlyMultisigAccount(t *testing.T) {
+	pubs := convertPubs(t, []string{
+		"02b3622bf4017bdfe317c58aed5f4c753f206b7db896046fa7d774bbc4bf7f8dc2",
+		"02103a7f7dd016558597f7960d27c516a4394fd968b9e65155eb4b013e4040406e",
+		"02a7bc55fe8684e0119768d104ba30795bdcc86619e864add26156723ed185cd62",
+		"03d90c07df63e690ce77912e10ab51acc944b66860237b608c4f8f8309e71ee699",
+	})
+
+	t.Run("invalid number of signatures", func(t *testing.T) {
+		_, err := NewWatchOnlyMultisigAccount(5, pubs)
+		require.Error(t, err)
+	})
+
+	acc, err := NewWatchOnlyMultisigAccount(3, pubs)
+	require.NoError(t, err)
+	require.Equal(t, "NgEisvCqr2h8wpRxQb7bVPWUZdbVCY8Uo6", acc.Address)
+	require.Equal(t, 3, len(acc.Contract.Parameters))
+	require.True(t, acc.IsWatchOnly())
+}
+
 func convertPubs(t *testing.T, hexKeys []string) []*keys.PublicKey {
 	pubs := make([]*keys.PublicKey, len(hexKeys))
 	for i := range pubs {
@@ -143,3 +165,30 @@ func compareFields(t *testing.T, tk keytestcases.Ktype, acc *Account) {
 	want, have = tk.PrivateKey, acc.privateKey.String()
 	require.Equalf(t, want, have, "expected priv key %s got %s", want, have)
 }
+
+func TestNewWatchOnlyAccount(t *testing.T) {
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	tx := transaction.New([]byte{1, 2, 3}, 0)
+
+	t.Run("address", func(t *testing.T) {
+		acc := NewWatchOnlyAccount(priv.GetScriptHash())
+		require.True(t, acc.IsWatchOnly())
+		require.Equal(t, priv.Address(), acc.Address)
+		require.Nil(t, acc.Contract)
+		require.Nil(t, acc.GetVerificationScript())
+		require.Error(t, acc.SignTx(netmode.UnitTestNet, tx))
+	})
+	t.Run("public key", func(t *testing.T) {
+		acc := NewWatchOnlyAccountFromPublicKey(priv.PublicKey())
+		require.True(t, acc.IsWatchOnly())
+		require.Equal(t, priv.Address(), acc.Address)
+		require.Equal(t, priv.PublicKey().GetVerificationScript(), acc.Contract.Script)
+		require.Error(t, acc.SignTx(netmode.UnitTestNet, tx))
+	})
+	t.Run("regular", func(t *testing.T) {
+		acc := NewAccountFromPrivateKey(priv)
+		require.False(t, acc.IsWatchOnly())
+		require.NoError(t, acc.SignTx(netmode.UnitTestNet, tx))
+	})
+}
@@ -2,16 +2,28 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
 	"encoding/binary"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"html/template"
 	"io/ioutil"
+	"math"
 	"os"
 	"path/filepath"
+	"regexp"
 	"sort"
+	"strconv"
+	"strings"
+	"sync"
 
+	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/client"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
+	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/urfave/cli"
 )
 
@@ -69,49 +81,524 @@ func (d dump) normalize() {
 	// assume that d is already sorted by Block
 }
 
-func compare(a, b string) error {
+// mismatch describes a single difference found between two dumps.
+type mismatch struct {
+	File       string `json:"file,omitempty"`
+	Block      uint32 `json:"block"`
+	Kind       string `json:"kind"`
+	Key        string `json:"key,omitempty"`
+	ContractID *int32 `json:"contract,omitempty"`
+	ItemKey    string `json:"itemkey,omitempty"`
+	A          string `json:"a"`
+	B          string `json:"b"`
+}
+
+// Mismatch kinds.
+const (
+	kindDumpSize    = "dump size"
+	kindBlockNumber = "block number"
+	kindChanges     = "changes length"
+	kindKey         = "key"
+	kindState       = "state"
+	kindValue       = "value"
+)
+
+// newKeyMismatch creates mismatch for the given base64-encoded storage key
+// decoding it into contract ID and storage item key.
+func newKeyMismatch(block uint32, kind string, key string, a, b string) mismatch {
+	m := mismatch{Block: block, Kind: kind, Key: key, A: a, B: b}
+	keyBytes, err := base64.StdEncoding.DecodeString(key)
+	if err == nil && len(keyBytes) >= 4 {
+		id := int32(binary.LittleEndian.Uint32(keyBytes))
+		m.ContractID = &id
+		m.ItemKey = hex.EncodeToString(keyBytes[4:])
+	}
+	return m
+}
+
+func (m mismatch) String() string {
+	var s string
+	switch m.Kind {
+	case kindDumpSize:
+		return fmt.Sprintf("dump files differ in size: %s vs %s", m.A, m.B)
+	case kindBlockNumber:
+		return fmt.Sprintf("block number mismatch: %s vs %s", m.A, m.B)
+	case kindChanges:
+		s = fmt.Sprintf("block %d, changes length mismatch: %s vs %s", m.Block, m.A, m.B)
+	case kindKey:
+		s = fmt.Sprintf("block %d: key mismatch: %s vs %s", m.Block, m.A, m.B)
+	default:
+		s = fmt.Sprintf("block %d: %s mismatch for key %s: %s vs %s", m.Block, m.Kind, m.Key, m.A, m.B)
+	}
+	if m.File != "" {
+		s = fmt.Sprintf("file %s: %s", m.File, s)
+	}
+	return s
+}
+
+// filter returns blocks from d within [start, stop] range.
+func (d dump) filter(start, stop uint32) dump {
+	res := d[:0]
+	for i := range d {
+		if start <= d[i].Block && d[i].Block <= stop {
+			res = append(res, d[i])
+		}
+	}
+	return res
+}
+
+// compare compares blocks from [start, stop] range of dump files a and b
+// returning found mismatches. It stops
+// at the first structural mismatch (when dumps can't be aligned anymore) or
+// at the end of the first block having value mismatches.
+func compare(a, b string, start, stop uint32) ([]mismatch, error) {
 	dumpA, err := readFile(a)
 	if err != nil {
-		return fmt.Errorf("reading file %s: %w", a, err)
+		return nil, fmt.Errorf("reading file %s: %w", a, err)
 	}
 	dumpB, err := readFile(b)
 	if err != nil {
-		return fmt.Errorf("reading file %s: %w", b, err)
+		return nil, fmt.Errorf("reading file %s: %w", b, err)
 	}
+	dumpA = dumpA.filter(start, stop)
+	dumpB = dumpB.filter(start, stop)
 	dumpA.normalize()
 	dumpB.normalize()
 	if len(dumpA) != len(dumpB) {
-		return fmt.Errorf("dump files differ in size: %d vs %d", len(dumpA), len(dumpB))
+		return []mismatch{{Kind: kindDumpSize, A: strconv.Itoa(len(dumpA)), B: strconv.Itoa(len(dumpB))}}, nil
 	}
 	for i := range dumpA {
 		blockA := &dumpA[i]
 		blockB := &dumpB[i]
 		if blockA.Block != blockB.Block {
-			return fmt.Errorf("block number mismatch: %d vs %d", blockA.Block, blockB.Block)
+			return []mismatch{{Block: blockA.Block, Kind: kindBlockNumber,
+				A: strconv.FormatUint(uint64(blockA.Block), 10), B: strconv.FormatUint(uint64(blockB.Block), 10)}}, nil
 		}
 		if len(blockA.Storage) != len(blockB.Storage) {
-			return fmt.Errorf("block %d, changes length mismatch: %d vs %d", blockA.Block, len(blockA.Storage), len(blockB.Storage))
+			return []mismatch{{Block: blockA.Block, Kind: kindChanges,
+				A: strconv.Itoa(len(blockA.Storage)), B: strconv.Itoa(len(blockB.Storage))}}, nil
 		}
-		fail := false
+		var res []mismatch
 		for j := range blockA.Storage {
-			if blockA.Storage[j].Key != blockB.Storage[j].Key {
-				return fmt.Errorf("block %d: key mismatch: %s vs %s", blockA.Block, blockA.Storage[j].Key, blockB.Storage[j].Key)
+			opA, opB := &blockA.Storage[j], &blockB.Storage[j]
+			if opA.Key != opB.Key {
+				return append(res, newKeyMismatch(blockA.Block, kindKey, opA.Key, opA.Key, opB.Key)), nil
 			}
-			if blockA.Storage[j].State != blockB.Storage[j].State {
-				return fmt.Errorf("block %d: state mismatch for key %s: %s vs %s", blockA.Block, blockA.Storage[j].Key, blockA.Storage[j].State, blockB.Storage[j].State)
+			if opA.State != opB.State {
+				return append(res, newKeyMismatch(blockA.Block, kindState, opA.Key, opA.State, opB.State)), nil
 			}
-			if blockA.Storage[j].Value != blockB.Storage[j].Value {
-				fail = true
-				fmt.Printf("block %d: value mismatch for key %s: %s vs %s\n", blockA.Block, blockA.Storage[j].Key, blockA.Storage[j].Value, blockB.Storage[j].Value)
+			if opA.Value != opB.Value {
+				res = append(res, newKeyMismatch(blockA.Block, kindValue, opA.Key, opA.Value, opB.Value))
 			}
 		}
-		if fail {
-			return errors.New("fail")
+		if len(res) != 0 {
+			return res, nil
 		}
 	}
-	return nil
+	return nil, nil
+}
+
+// options contains directory comparison parameters.
+type options struct {
+	start         uint32
+	stop          uint32
+	dirChunk      uint32
+	fileChunk     uint32
+	workers       int
+	maxMismatches int
+}
+
+// Default dump layout used by both C# and NeoGo nodes, see
+// https://github.com/NeoResearch/neo-storage-audit#folder-organization-where-to-find-the-desired-block
+const (
+	defaultDirChunk  = 100000
+	defaultFileChunk = 1000
+
+	// dumpLayout describes the dump layout detectLayout looks for.
+	dumpLayout = "BlockStorage_<N>/dump-block-<N>.json"
+)
+
+var (
+	dirPattern  = regexp.MustCompile(`^BlockStorage_(\d+)$`)
+	filePattern = regexp.MustCompile(`^dump-block-(\d+)\.json$`)
+)
+
+// upTo rounds n up to the nearest multiple of chunk.
+func upTo(n, chunk uint32) uint32 {
+	return (n + chunk - 1) / chunk * chunk
 }
 
+func gcd(a, b uint32) uint32 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// listNumbers returns numbers extracted from the names of dir entries
+// matching the given pattern.
+func listNumbers(dir string, pattern *regexp.Regexp, wantDirs bool) ([]uint32, error) {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var res []uint32
+	for _, info := range infos {
+		if info.IsDir() != wantDirs {
+			continue
+		}
+		m := pattern.FindStringSubmatch(info.Name())
+		if m == nil {
+			continue
+		}
+		n, err := strconv.ParseUint(m[1], 10, 32)
+		if err != nil {
+			continue
+		}
+		res = append(res, uint32(n))
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i] < res[j] })
+	return res, nil
+}
+
+// detectLayout detects dump directory chunk size, dump file chunk size and
+// the last dumped block chunk boundary from the contents of the directory
+// given.
+func detectLayout(dir string) (dirChunk uint32, fileChunk uint32, last uint32, err error) {
+	dirs, err := listNumbers(dir, dirPattern, true)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	for _, n := range dirs {
+		dirChunk = gcd(dirChunk, n)
+	}
+	if dirChunk == 0 {
+		return 0, 0, 0, fmt.Errorf("no BlockStorage_<N> directories found in %s (expected %s layout)", dir, dumpLayout)
+	}
+	for _, n := range dirs {
+		files, err := listNumbers(filepath.Join(dir, fmt.Sprintf("BlockStorage_%d", n)), filePattern, false)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		for _, f := range files {
+			fileChunk = gcd(fileChunk, f)
+			if f > last {
+				last = f
+			}
+		}
+	}
+	if fileChunk == 0 {
+		return 0, 0, 0, fmt.Errorf("no dump-block-<N>.json files found in BlockStorage_<N> directories of %s (expected %s layout)", dir, dumpLayout)
+	}
+	if fileChunk > dirChunk || dirChunk%fileChunk != 0 {
+		return 0, 0, 0, fmt.Errorf("inconsistent %s layout in %s: %d blocks per directory, %d blocks per file",
+			dumpLayout, dir, dirChunk, fileChunk)
+	}
+	return dirChunk, fileChunk, last, nil
+}
+
+// dumpFiles returns the list of dump file names (relative to the dump
+// directory) containing blocks from [start, stop] range in the order they
+// should be compared.
+func dumpFiles(start, stop, dirChunk, fileChunk uint32) []string {
+	var files []string
+	for f := upTo(start, fileChunk); f <= upTo(stop, fileChunk); f += fileChunk {
+		dir := fmt.Sprintf("BlockStorage_%d", upTo(f, dirChunk))
+		files = append(files, fmt.Sprintf("%s/dump-block-%d.json", dir, f))
+	}
+	return files
+}
+
+// compareDirs compares dump files from directories a and b containing blocks
+// from the specified range.
+func compareDirs(a, b string, opts options) ([]mismatch, error) {
+	files, err := dirFiles(a, &opts)
+	if err != nil {
+		return nil, err
+	}
+	return compareFiles(files, opts, func(file string) ([]mismatch, error) {
+		return compare(filepath.Join(a, file), filepath.Join(b, file), opts.start, opts.stop)
+	})
+}
+
+// dirFiles returns the list of dump files from the directory given
+// containing blocks from the specified range. Unspecified layout options
+// are detected from the directory contents.
+func dirFiles(dir string, opts *options) ([]string, error) {
+	if opts.dirChunk == 0 || opts.fileChunk == 0 || opts.stop == 0 {
+		dirChunk, fileChunk, last, err := detectLayout(dir)
+		if err != nil {
+			return nil, fmt.Errorf("can't detect dump layout: %w", err)
+		}
+		if opts.dirChunk == 0 {
+			opts.dirChunk = dirChunk
+		}
+		if opts.fileChunk == 0 {
+			opts.fileChunk = fileChunk
+		}
+		if opts.stop == 0 {
+			opts.stop = last
+		}
+	}
+	if opts.start > opts.stop {
+		return nil, fmt.Errorf("invalid block range: %d-%d", opts.start, opts.stop)
+	}
+	return dumpFiles(opts.start, opts.stop, opts.dirChunk, opts.fileChunk), nil
+}
+
+// compareFiles runs cmp for every file using the given number of workers. It
+// stops after maxMismatches files with mismatches are found and returns all
+// mismatches found in these files in the block order.
+func compareFiles(files []string, opts options, cmp func(file string) ([]mismatch, error)) ([]mismatch, error) {
+	var (
+		workers       = opts.workers
+		maxMismatches = opts.maxMismatches
+	)
+	if workers <= 0 {
+		workers = 1
+	}
+	if maxMismatches <= 0 {
+		maxMismatches = 1
+	}
+	var (
+		results = make([][]mismatch, len(files))
+		errs    = make([]error, len(files))
+		jobs    = make(chan int)
+		stop    = make(chan struct{})
+		wg      sync.WaitGroup
+		lock    sync.Mutex
+		fails   int
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				ms, err := cmp(files[i])
+				if err == nil && len(ms) == 0 {
+					continue
+				}
+				for j := range ms {
+					ms[j].File = files[i]
+				}
+				lock.Lock()
+				results[i], errs[i] = ms, err
+				fails++
+				if fails == maxMismatches {
+					close(stop)
+				}
+				lock.Unlock()
+			}
+		}()
+	}
+	var lastDir string
+loop:
+	for i := range files {
+		if dir := filepath.Dir(files[i]); dir != lastDir {
+			fmt.Println("Processing directory", dir)
+			lastDir = dir
+		}
+		select {
+		case jobs <- i:
+		case <-stop:
+			break loop
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	var res []mismatch
+	for i, n := 0, 0; i < len(files) && n < maxMismatches; i++ {
+		if errs[i] != nil {
+			return res, fmt.Errorf("file %s: %w", files[i], errs[i])
+		}
+		if len(results[i]) != 0 {
+			res = append(res, results[i]...)
+			n++
+		}
+	}
+	return res, nil
+}
+
+// node is a remote RPC node dumps can be compared against.
+type node struct {
+	c *client.Client
+
+	lock   sync.RWMutex
+	hashes map[int32]util.Uint160
+}
+
+// isEndpoint checks whether the argument given is an RPC node address.
+func isEndpoint(s string) bool {
+	return strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://")
+}
+
+func newNode(endpoint string) (*node, error) {
+	c, err := client.New(context.Background(), endpoint, client.Options{})
+	if err != nil {
+		return nil, err
+	}
+	if err := c.Init(); err != nil {
+		return nil, err
+	}
+	return &node{
+		c:      c,
+		hashes: make(map[int32]util.Uint160),
+	}, nil
+}
+
+// contractHash returns hash of the contract with the specified ID.
+func (n *node) contractHash(id int32) (util.Uint160, error) {
+	n.lock.RLock()
+	h, ok := n.hashes[id]
+	n.lock.RUnlock()
+	if ok {
+		return h, nil
+	}
+	cs, err := n.c.GetContractStateByID(id)
+	if err != nil {
+		return util.Uint160{}, fmt.Errorf("can't get contract %d state: %w", id, err)
+	}
+	n.lock.Lock()
+	n.hashes[id] = cs.Hash
+	n.lock.Unlock()
+	return cs.Hash, nil
+}
+
+// getValue returns value of the storage item with the given key (contract ID
+// followed by the item key) as of the specified state root. Proofs are
+// verified locally, nil is returned for missing items.
+func (n *node) getValue(root util.Uint256, key []byte) ([]byte, error) {
+	if len(key) < 4 {
+		return nil, fmt.Errorf("invalid storage key: %x", key)
+	}
+	h, err := n.contractHash(int32(binary.LittleEndian.Uint32(key)))
+	if err != nil {
+		return nil, err
+	}
+	proof, err := n.c.GetProof(root, h, key[4:])
+	if err != nil {
+		if isUnknownItem(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("can't get proof for key %x: %w", key, err)
+	}
+	val, ok := mpt.VerifyProof(root, proof.Key, proof.Proof)
+	if !ok {
+		return nil, fmt.Errorf("invalid proof for key %x", key)
+	}
+	return val, nil
+}
+
+// isUnknownItem checks whether getproof error means that the item is missing
+// in the state requested.
+func isUnknownItem(err error) bool {
+	var rpcErr *response.Error
+	return errors.As(err, &rpcErr) && strings.EqualFold(rpcErr.Message, "Unknown storage item")
+}
+
+// compareWithNode compares blocks from [start, stop] range of the dump file
+// given with the state of the node. Values of all storage items changed in
+// every block are checked against the node's state root for this block.
+func compareWithNode(n *node, path string, start, stop uint32) ([]mismatch, error) {
+	d, err := readFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading file %s: %w", path, err)
+	}
+	d = d.filter(start, stop)
+	d.normalize()
+	for i := range d {
+		b := &d[i]
+		if len(b.Storage) == 0 {
+			continue
+		}
+		root, err := n.c.GetStateRootByHeight(b.Block)
+		if err != nil {
+			return nil, fmt.Errorf("can't get state root for block %d: %w", b.Block, err)
+		}
+		var res []mismatch
+		for j := range b.Storage {
+			op := &b.Storage[j]
+			key, err := base64.StdEncoding.DecodeString(op.Key)
+			if err != nil {
+				return nil, fmt.Errorf("invalid key encoding: %w", err)
+			}
+			val, err := n.getValue(root.Root, key)
+			if err != nil {
+				return nil, fmt.Errorf("block %d: %w", b.Block, err)
+			}
+			switch {
+			case op.State == "Deleted" && val != nil:
+				res = append(res, newKeyMismatch(b.Block, kindState, op.Key, op.State, "Added"))
+			case op.State != "Deleted" && val == nil:
+				res = append(res, newKeyMismatch(b.Block, kindState, op.Key, op.State, "Deleted"))
+			case op.State != "Deleted":
+				if remote := base64.StdEncoding.EncodeToString(val); remote != op.Value {
+					res = append(res, newKeyMismatch(b.Block, kindValue, op.Key, op.Value, remote))
+				}
+			}
+		}
+		if len(res) != 0 {
+			return res, nil
+		}
+	}
+	return nil, nil
+}
+
+// compareNode compares dump file or directory a with the state of the node.
+func compareNode(a string, endpoint string, isDir bool, opts options) ([]mismatch, error) {
+	n, err := newNode(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to %s: %w", endpoint, err)
+	}
+	if !isDir {
+		return compareWithNode(n, a, opts.start, opts.stop)
+	}
+	files, err := dirFiles(a, &opts)
+	if err != nil {
+		return nil, err
+	}
+	return compareFiles(files, opts, func(file string) ([]mismatch, error) {
+		return compareWithNode(n, filepath.Join(a, file), opts.start, opts.stop)
+	})
+}
+
+// writeReport writes mismatches into the file specified in JSON or HTML
+// format depending on the file extension.
+func writeReport(path string, ms []mismatch) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if ms == nil {
+		ms = []mismatch{}
+	}
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".html", ".htm":
+		return reportTemplate.Execute(f, ms)
+	case ".json":
+		enc := json.NewEncoder(f)
+		enc.SetIndent("", " ")
+		return enc.Encode(ms)
+	default:
+		return fmt.Errorf("unsupported report format: %s", ext)
+	}
+}
+
+var reportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>compare-dumps report</title></head>
+<body>
+<p>{{len .}} mismatch(es) found.</p>
+<table border="1">
+<tr><th>File</th><th>Block</th><th>Kind</th><th>Contract</th><th>Item key (hex)</th><th>Key</th><th>A</th><th>B</th></tr>
+{{range .}}<tr><td>{{.File}}</td><td>{{.Block}}</td><td>{{.Kind}}</td><td>{{with .ContractID}}{{.}}{{end}}</td><td>{{.ItemKey}}</td><td>{{.Key}}</td><td>{{.A}}</td><td>{{.B}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
 func cliMain(c *cli.Context) error {
 	a := c.Args().Get(0)
 	b := c.Args().Get(1)
@@ -121,56 +608,100 @@ func cliMain(c *cli.Context) error {
 	if b == "" {
 		return errors.New("missing second argument")
 	}
-	fa, err := os.Open(a)
-	if err != nil {
-		return err
+	var (
+		ms   []mismatch
+		stop = uint32(c.Uint("stop"))
+		opts = options{
+			start:         uint32(c.Uint("start")),
+			stop:          stop,
+			dirChunk:      uint32(c.Uint("chunk-size")),
+			workers:       c.Int("workers"),
+			maxMismatches: c.Int("max-mismatches"),
+		}
+	)
+	if stop == 0 {
+		stop = math.MaxUint32
 	}
-	defer fa.Close()
-	fb, err := os.Open(b)
-	if err != nil {
-		return err
+	if isEndpoint(a) {
+		a, b = b, a
 	}
-	defer fb.Close()
-
-	astat, err := fa.Stat()
+	astat, err := os.Stat(a)
 	if err != nil {
 		return err
 	}
-	bstat, err := fb.Stat()
+	if isEndpoint(b) {
+		if !astat.Mode().IsRegular() && !astat.Mode().IsDir() {
+			return errors.New("dump file or directory is expected")
+		}
+		if !astat.Mode().IsDir() {
+			opts.stop = stop
+		}
+		ms, err = compareNode(a, b, astat.Mode().IsDir(), opts)
+	} else {
+		var bstat os.FileInfo
+		bstat, err = os.Stat(b)
+		if err != nil {
+			return err
+		}
+		switch {
+		case astat.Mode().IsRegular() && bstat.Mode().IsRegular():
+			ms, err = compare(a, b, opts.start, stop)
+		case astat.Mode().IsDir() && bstat.Mode().IsDir():
+			ms, err = compareDirs(a, b, opts)
+		default:
+			return errors.New("both parameters must be either dump files or directories")
+		}
+	}
 	if err != nil {
 		return err
 	}
-	if astat.Mode().IsRegular() && bstat.Mode().IsRegular() {
-		return compare(a, b)
+	for i := range ms {
+		fmt.Println(ms[i].String())
 	}
-	if astat.Mode().IsDir() && bstat.Mode().IsDir() {
-		for i := 0; i <= 6000000; i += 100000 {
-			dir := fmt.Sprintf("BlockStorage_%d", i)
-			fmt.Println("Processing directory", dir)
-			for j := i - 99000; j <= i; j += 1000 {
-				if j < 0 {
-					continue
-				}
-				fname := fmt.Sprintf("%s/dump-block-%d.json", dir, j)
-
-				aname := filepath.Join(a, fname)
-				bname := filepath.Join(b, fname)
-				err := compare(aname, bname)
-				if err != nil {
-					return fmt.Errorf("file %s: %w", fname, err)
-				}
-			}
+	if out := c.String("output"); out != "" {
+		if err := writeReport(out, ms); err != nil {
+			return fmt.Errorf("can't write report: %w", err)
 		}
-		return nil
 	}
-	return errors.New("both parameters must be either dump files or directories")
+	if len(ms) != 0 {
+		return errors.New("fail")
+	}
+	return nil
 }
 
 func main() {
 	ctl := cli.NewApp()
 	ctl.Name = "compare-dumps"
 	ctl.Version = "1.0"
-	ctl.Usage = "compare-dumps dumpDirA dumpDirB"
+	ctl.Usage = "compare-dumps dumpDirA dumpDirB|http://rpc.node:port"
+	ctl.Flags = []cli.Flag{
+		cli.IntFlag{
+			Name:  "workers, w",
+			Value: 1,
+			Usage: "number of dump files compared concurrently (directories only)",
+		},
+		cli.IntFlag{
+			Name:  "max-mismatches, m",
+			Value: 1,
+			Usage: "number of mismatching dump files to report before stopping (directories only)",
+		},
+		cli.UintFlag{
+			Name:  "start, s",
+			Usage: "first block to compare",
+		},
+		cli.UintFlag{
+			Name:  "stop",
+			Usage: "last block to compare (default or 0: the last dumped block)",
+		},
+		cli.UintFlag{
+			Name:  "chunk-size",
+			Usage: fmt.Sprintf("number of blocks per BlockStorage_N directory (default or 0: detected from the directory contents, usually %d)", defaultDirChunk),
+		},
+		cli.StringFlag{
+			Name:  "output, o",
+			Usage: "write mismatches report to the file given (JSON or HTML depending on the extension)",
+		},
+	}
 	ctl.Action = cliMain
 
 	if err := ctl.Run(os.Args); err != nil {
@@ -1,9 +1,17 @@
 package main
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/native"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
 	"github.com/stretchr/testify/require"
 )
 
@@ -11,3 +19,115 @@ func TestCompatibility(t *testing.T) {
 	cs := native.NewContracts(false, map[string][]uint32{})
 	require.Equal(t, cs.Ledger.ID, int32(ledgerContractID))
 }
+
+func writeDump(t *testing.T, path string, d dump) {
+	data, err := json.Marshal(d)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(path, data, os.ModePerm))
+}
+
+func TestCompare(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "compare-dumps")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(tmp) })
+
+	// Contract ID 1, key 0x01.
+	const key = "AQAAAAE="
+	a := dump{{Block: 1, Size: 1, Storage: []storageOp{{State: "Added", Key: key, Value: "AQ=="}}}}
+	b := dump{{Block: 1, Size: 1, Storage: []storageOp{{State: "Changed", Key: key, Value: "AQ=="}}}}
+	pathA, pathB := filepath.Join(tmp, "a.json"), filepath.Join(tmp, "b.json")
+	writeDump(t, pathA, a)
+	writeDump(t, pathB, b)
+
+	t.Run("equal", func(t *testing.T) {
+		ms, err := compare(pathA, pathB, 0, math.MaxUint32)
+		require.NoError(t, err)
+		require.Equal(t, 0, len(ms))
+	})
+	t.Run("value mismatch", func(t *testing.T) {
+		b[0].Storage[0].Value = "Ag=="
+		writeDump(t, pathB, b)
+		ms, err := compare(pathA, pathB, 0, math.MaxUint32)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(ms))
+		require.Equal(t, kindValue, ms[0].Kind)
+		require.Equal(t, uint32(1), ms[0].Block)
+		require.Equal(t, int32(1), *ms[0].ContractID)
+		require.Equal(t, "01", ms[0].ItemKey)
+		require.Equal(t, "AQ==", ms[0].A)
+		require.Equal(t, "Ag==", ms[0].B)
+
+		out := filepath.Join(tmp, "report.json")
+		require.NoError(t, writeReport(out, ms))
+		data, err := ioutil.ReadFile(out)
+		require.NoError(t, err)
+		var actual []mismatch
+		require.NoError(t, json.Unmarshal(data, &actual))
+		require.Equal(t, ms, actual)
+
+		require.NoError(t, writeReport(filepath.Join(tmp, "report.html"), ms))
+		require.Error(t, writeReport(filepath.Join(tmp, "report.txt"), ms))
+	})
+	t.Run("block mismatch", func(t *testing.T) {
+		b[0].Block = 2
+		writeDump(t, pathB, b)
+		ms, err := compare(pathA, pathB, 0, math.MaxUint32)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(ms))
+		require.Equal(t, kindBlockNumber, ms[0].Kind)
+	})
+	t.Run("missing file", func(t *testing.T) {
+		_, err := compare(pathA, filepath.Join(tmp, "c.json"), 0, math.MaxUint32)
+		require.Error(t, err)
+	})
+}
+
+func TestDumpFiles(t *testing.T) {
+	require.Equal(t, []string{"BlockStorage_0/dump-block-0.json"}, dumpFiles(0, 0, 100000, 1000))
+	require.Equal(t, []string{
+		"BlockStorage_0/dump-block-0.json",
+		"BlockStorage_100000/dump-block-1000.json",
+		"BlockStorage_100000/dump-block-2000.json",
+	}, dumpFiles(0, 1500, 100000, 1000))
+	require.Equal(t, []string{
+		"BlockStorage_20/dump-block-20.json",
+		"BlockStorage_40/dump-block-30.json",
+		"BlockStorage_40/dump-block-40.json",
+	}, dumpFiles(11, 31, 20, 10))
+}
+
+func TestDetectLayout(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "compare-dumps")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(tmp) })
+
+	_, _, _, err = detectLayout(tmp)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), tmp)
+	require.Contains(t, err.Error(), dumpLayout)
+
+	require.NoError(t, os.Mkdir(filepath.Join(tmp, "BlockStorage_20"), os.ModePerm))
+	_, _, _, err = detectLayout(tmp)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), tmp)
+	require.Contains(t, err.Error(), dumpLayout)
+
+	for _, f := range dumpFiles(0, 25, 20, 5) {
+		path := filepath.Join(tmp, f)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+		writeDump(t, path, dump{})
+	}
+	dirChunk, fileChunk, last, err := detectLayout(tmp)
+	require.NoError(t, err)
+	require.Equal(t, uint32(20), dirChunk)
+	require.Equal(t, uint32(5), fileChunk)
+	require.Equal(t, uint32(25), last)
+}
+
+func TestIsUnknownItem(t *testing.T) {
+	notFound := response.NewRPCError("Unknown storage item", "item not found", nil)
+	require.True(t, isUnknownItem(notFound))
+	require.True(t, isUnknownItem(fmt.Errorf("wrapped: %w", notFound)))
+	require.False(t, isUnknownItem(response.NewInternalServerError("failed to get proof", nil)))
+	require.False(t, isUnknownItem(errors.New("connection refused")))
+}